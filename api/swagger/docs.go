@@ -24,29 +24,86 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/buildings": {
+        "/.well-known/jwks.json": {
             "get": {
-                "description": "Retrieve a list of all buildings in the system",
-                "consumes": [
+                "description": "The JSON Web Key Set of the local token issuer. Empty for HS256, whose secret is never published.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get the token signing keys",
+                "responses": {
+                    "200": {
+                        "description": "Public keys",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_domain_auth.JWKS"
+                        }
+                    }
+                }
+            }
+        },
+        "/.well-known/openid-configuration": {
+            "get": {
+                "description": "Lets other services trust the tokens of the local issuer as an OpenID Connect provider, when jwt.issuer is the public URL of this server.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "auth"
+                ],
+                "summary": "Get the OpenID Connect discovery document",
+                "responses": {
+                    "200": {
+                        "description": "Discovery document",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_auth.DiscoveryDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
-                "summary": "Get all buildings",
+                "description": "Retrieve every API key with its permissions, buildings, expiry and last use. Keys themselves are not included. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
                 "responses": {
                     "200": {
-                        "description": "List of buildings",
+                        "description": "List of API keys",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/internal_transport_rest_building.BuildingDTO"
+                                "$ref": "#/definitions/internal_transport_rest_apikey.APIKeyDTO"
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -56,7 +113,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new building with the provided name and code",
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a key for a service or device that cannot sign in. It is sent in the X-API-Key header instead of a token and only has the listed permissions, optionally only in the listed buildings. The key is in the response and is not shown again; only its hash is stored. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -64,29 +129,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "api-keys"
                 ],
-                "summary": "Create a new building",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "description": "Building creation data",
-                        "name": "building",
+                        "description": "API key data",
+                        "name": "apiKey",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_building.CreateBuildingDTO"
+                            "$ref": "#/definitions/internal_transport_rest_apikey.CreateAPIKeyDTO"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created building",
+                        "description": "Created API key with the key",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_building.BuildingDTO"
+                            "$ref": "#/definitions/internal_transport_rest_apikey.APIKeySecretDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid name, permission, building or expiry",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -100,24 +177,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/buildings/{id}": {
+        "/api-keys/{id}": {
             "get": {
-                "description": "Retrieve a specific building by its unique identifier",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieve an API key by its unique identifier. The key itself is not included. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "api-keys"
                 ],
-                "summary": "Get building by ID",
+                "summary": "Get API key by ID",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Building ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -125,19 +207,31 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Building details",
+                        "description": "API key details",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_building.BuildingDTO"
+                            "$ref": "#/definitions/internal_transport_rest_apikey.APIKeyDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid building ID",
+                        "description": "Invalid API key ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Building not found",
+                        "description": "API key not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -151,7 +245,15 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing building's name and code by ID",
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the name, permissions, buildings and expiry of an API key by ID. The key itself is kept. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -159,43 +261,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "api-keys"
                 ],
-                "summary": "Update an existing building",
+                "summary": "Update an API key",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Building ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Building update data",
-                        "name": "building",
+                        "description": "API key data",
+                        "name": "apiKey",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_building.UpdateBuildingDTO"
+                            "$ref": "#/definitions/internal_transport_rest_apikey.UpdateAPIKeyDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated building",
+                        "description": "Updated API key",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_building.BuildingDTO"
+                            "$ref": "#/definitions/internal_transport_rest_apikey.APIKeyDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid name, permission or building",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Building not found",
+                        "description": "API key not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -209,22 +323,27 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a building by its ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Revoke an API key by ID; requests with it are refused from now on. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "buildings"
+                    "api-keys"
                 ],
-                "summary": "Delete a building",
+                "summary": "Delete an API key",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Building ID",
+                        "description": "API key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -232,53 +351,33 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Building deleted successfully",
+                        "description": "API key deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid building ID",
+                        "description": "Invalid API key ID",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Building not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/classes": {
-            "get": {
-                "description": "Retrieve a list of all classes in the system",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "classes"
-                ],
-                "summary": "Get all classes",
-                "responses": {
-                    "200": {
-                        "description": "List of classes",
+                    },
+                    "404": {
+                        "description": "API key not found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
-                            }
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "500": {
@@ -288,39 +387,145 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new class with the provided name and capacity",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieve a page of the changes made to buildings, classes, lessons, resources and reservations, with who made them and how each field changed. Sortable fields: id, action, entityType, entityId, actorId, requestId, createdAt. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "classes"
+                    "audit"
                 ],
-                "summary": "Create a new class",
+                "summary": "List audit entries",
                 "parameters": [
                     {
-                        "description": "Class creation data",
-                        "name": "class",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_class.CreateClassDTO"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "building",
+                            "class",
+                            "lesson",
+                            "resource",
+                            "reservation"
+                        ],
+                        "type": "string",
+                        "description": "Type of the changed entity",
+                        "name": "entityType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the changed entity",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "transition"
+                        ],
+                        "type": "string",
+                        "description": "Kind of change",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cognito sub of the user who made the change",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the request that made the change",
+                        "name": "requestId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes made at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes made at or before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created class",
+                    "200": {
+                        "description": "Page of audit entries",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_audit_AuditEntryDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid filter, sort field or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -334,44 +539,120 @@ const docTemplate = `{
                 }
             }
         },
-        "/classes/{id}": {
+        "/audit/export": {
             "get": {
-                "description": "Retrieve a specific class by its unique identifier",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Download every audit entry matching the filters as CSV, one row per changed field, streamed as the entries are read. Cells that would start a spreadsheet formula are prefixed with a quote. Takes the filters and sorting of the list; paging is ignored. Admins only.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "classes"
+                    "audit"
                 ],
-                "summary": "Get class by ID",
+                "summary": "Export audit entries as CSV",
                 "parameters": [
                     {
-                        "minimum": 1,
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "building",
+                            "class",
+                            "lesson",
+                            "resource",
+                            "reservation"
+                        ],
+                        "type": "string",
+                        "description": "Type of the changed entity",
+                        "name": "entityType",
+                        "in": "query"
+                    },
+                    {
                         "type": "integer",
-                        "description": "Class ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ID of the changed entity",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "transition"
+                        ],
+                        "type": "string",
+                        "description": "Kind of change",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cognito sub of the user who made the change",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the request that made the change",
+                        "name": "requestId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes made at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changes made at or before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Class details",
+                        "description": "CSV of the audit entries",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid class ID",
+                        "description": "Invalid filter or sort field",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Class not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -383,53 +664,63 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing class's name and capacity by ID",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/audit/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieve an audit entry by its unique identifier. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "classes"
+                    "audit"
                 ],
-                "summary": "Update an existing class",
+                "summary": "Get audit entry by ID",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Class ID",
+                        "description": "Audit entry ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Class update data",
-                        "name": "class",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_class.UpdateClassDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated class",
+                        "description": "Audit entry details",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
+                            "$ref": "#/definitions/internal_transport_rest_audit.AuditEntryDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid audit entry ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Class not found",
+                        "description": "Audit entry not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -441,9 +732,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a class by its ID",
+            }
+        },
+        "/auth/token": {
+            "post": {
+                "description": "Sign an access token for any subject, username, email and groups, so the protected API can be used without an identity provider. Only available with auth.provider local and jwt.dev_tokens enabled; never enable it in production.",
                 "consumes": [
                     "application/json"
                 ],
@@ -451,34 +744,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "classes"
+                    "auth"
                 ],
-                "summary": "Delete a class",
+                "summary": "Issue a development token",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "Class ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Who the token is for",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_auth.DevTokenRequestDTO"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Class deleted successfully",
+                    "201": {
+                        "description": "Issued token",
                         "schema": {
-                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                            "$ref": "#/definitions/internal_transport_rest_auth.TokenDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid class ID",
-                        "schema": {
-                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Class not found",
+                        "description": "Invalid input data",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -492,9 +780,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/lessons": {
+        "/booking-policies": {
             "get": {
-                "description": "Retrieve a list of all lessons in the system",
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the booking policy of every resource type that has one",
                 "consumes": [
                     "application/json"
                 ],
@@ -502,19 +798,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lessons"
+                    "booking-policies"
                 ],
-                "summary": "Get all lessons",
+                "summary": "List booking policies",
                 "responses": {
                     "200": {
-                        "description": "List of lessons",
+                        "description": "List of booking policies",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/internal_transport_rest_lesson.LessonDTO"
+                                "$ref": "#/definitions/internal_transport_rest_policy.PolicyDTO"
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -524,7 +826,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new lesson with the provided title, duration, and start time",
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create the booking policy of a resource type. Durations are in minutes and zero leaves a rule unset. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -532,25 +842,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lessons"
+                    "booking-policies"
                 ],
-                "summary": "Create a new lesson",
+                "summary": "Create a booking policy",
                 "parameters": [
                     {
-                        "description": "Lesson creation data",
-                        "name": "lesson",
+                        "description": "Booking policy data",
+                        "name": "policy",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_lesson.CreateLessonDTO"
+                            "$ref": "#/definitions/internal_transport_rest_policy.CreatePolicyDTO"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created lesson",
+                        "description": "Created booking policy",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_lesson.LessonDTO"
+                            "$ref": "#/definitions/internal_transport_rest_policy.PolicyDTO"
                         }
                     },
                     "400": {
@@ -559,6 +869,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Resource type already has a policy",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -568,9 +896,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/lessons/{id}": {
+        "/booking-policies/{id}": {
             "get": {
-                "description": "Retrieve a specific lesson by its unique identifier",
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific booking policy by its unique identifier",
                 "consumes": [
                     "application/json"
                 ],
@@ -578,14 +914,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lessons"
+                    "booking-policies"
                 ],
-                "summary": "Get lesson by ID",
+                "summary": "Get booking policy by ID",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Lesson ID",
+                        "description": "Policy ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -593,19 +929,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lesson details",
+                        "description": "Booking policy details",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_lesson.LessonDTO"
+                            "$ref": "#/definitions/internal_transport_rest_policy.PolicyDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid lesson ID",
+                        "description": "Invalid policy ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Lesson not found",
+                        "description": "Policy not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -619,7 +961,15 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing lesson's title, duration, and start time by ID",
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the rules of a booking policy by ID. Durations are in minutes and zero leaves a rule unset. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -627,33 +977,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lessons"
+                    "booking-policies"
                 ],
-                "summary": "Update an existing lesson",
+                "summary": "Update a booking policy",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Lesson ID",
+                        "description": "Policy ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Lesson update data",
-                        "name": "lesson",
+                        "description": "Booking policy data",
+                        "name": "policy",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_lesson.UpdateLessonDTO"
+                            "$ref": "#/definitions/internal_transport_rest_policy.UpdatePolicyDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated lesson",
+                        "description": "Updated booking policy",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_lesson.LessonDTO"
+                            "$ref": "#/definitions/internal_transport_rest_policy.PolicyDTO"
                         }
                     },
                     "400": {
@@ -662,8 +1012,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Lesson not found",
+                        "description": "Policy not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Resource type already has a policy",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -677,7 +1045,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a lesson by its ID",
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a booking policy by ID, lifting the rules of its resource type. Admins only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -685,14 +1061,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lessons"
+                    "booking-policies"
                 ],
-                "summary": "Delete a lesson",
+                "summary": "Delete a booking policy",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Lesson ID",
+                        "description": "Policy ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -700,19 +1076,31 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lesson deleted successfully",
+                        "description": "Policy deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid lesson ID",
+                        "description": "Invalid policy ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Lesson not found",
+                        "description": "Policy not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -726,17 +1114,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations": {
+        "/buildings": {
             "get": {
-                "security": [
-                    {
-                        "CognitoOAuth": []
-                    },
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a list of all reservations in the system",
+                "description": "Retrieve a page of buildings, optionally filtered. Sortable fields: id, name, code, createdAt, updatedAt.",
                 "consumes": [
                     "application/json"
                 ],
@@ -744,22 +1124,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "buildings"
                 ],
-                "summary": "Get all reservations",
-                "responses": {
-                    "200": {
-                        "description": "List of reservations",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
-                            }
-                        }
+                "summary": "List buildings",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Building code",
+                        "name": "code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of buildings",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_building_BuildingDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort field or cursor",
+                        "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
@@ -780,7 +1206,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new reservation with resource, user, and time information",
+                "description": "Create a new building with the provided name and code",
                 "consumes": [
                     "application/json"
                 ],
@@ -788,25 +1214,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "buildings"
                 ],
-                "summary": "Create a new reservation",
+                "summary": "Create a new building",
                 "parameters": [
                     {
-                        "description": "Reservation creation data",
-                        "name": "reservation",
+                        "description": "Building creation data",
+                        "name": "building",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_reservation.CreateReservationDTO"
+                            "$ref": "#/definitions/internal_transport_rest_building.CreateBuildingDTO"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created reservation",
+                        "description": "Created building",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                            "$ref": "#/definitions/internal_transport_rest_building.BuildingDTO"
                         }
                     },
                     "400": {
@@ -821,6 +1247,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -830,17 +1262,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/reservations/{id}": {
+        "/buildings/{id}": {
             "get": {
-                "security": [
-                    {
-                        "CognitoOAuth": []
-                    },
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a specific reservation by its unique identifier",
+                "description": "Retrieve a specific building by its unique identifier",
                 "consumes": [
                     "application/json"
                 ],
@@ -848,14 +1272,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "buildings"
                 ],
-                "summary": "Get reservation by ID",
+                "summary": "Get building by ID",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Reservation ID",
+                        "description": "Building ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -863,25 +1287,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reservation details",
+                        "description": "Building details",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                            "$ref": "#/definitions/internal_transport_rest_building.BuildingDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid reservation ID",
-                        "schema": {
-                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid building ID",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Reservation not found",
+                        "description": "Building not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -903,7 +1321,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing reservation's resource, user, time, and status information by ID",
+                "description": "Update an existing building's name and code by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -911,33 +1329,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "buildings"
                 ],
-                "summary": "Update an existing reservation",
+                "summary": "Update an existing building",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Reservation ID",
+                        "description": "Building ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Reservation update data",
-                        "name": "reservation",
+                        "description": "Building update data",
+                        "name": "building",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_reservation.UpdateReservationDTO"
+                            "$ref": "#/definitions/internal_transport_rest_building.UpdateBuildingDTO"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated reservation",
+                        "description": "Updated building",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                            "$ref": "#/definitions/internal_transport_rest_building.BuildingDTO"
                         }
                     },
                     "400": {
@@ -952,8 +1370,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Reservation not found",
+                        "description": "Building not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -975,7 +1399,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a reservation by its ID",
+                "description": "Delete a building by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -983,14 +1407,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reservations"
+                    "buildings"
                 ],
-                "summary": "Delete a reservation",
+                "summary": "Delete a building",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Reservation ID",
+                        "description": "Building ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -998,13 +1422,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reservation deleted successfully",
+                        "description": "Building deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid reservation ID",
+                        "description": "Invalid building ID",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -1015,42 +1439,22 @@ const docTemplate = `{
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Reservation not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Building not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/resources": {
-            "get": {
-                "description": "Retrieve a list of all resources in the system",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "resources"
-                ],
-                "summary": "Get all resources",
-                "responses": {
-                    "200": {
-                        "description": "List of resources",
+                    },
+                    "409": {
+                        "description": "Building still has classes or resources",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
-                            }
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "500": {
@@ -1060,39 +1464,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new resource with name, type, and availability information",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/buildings/{id}/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "iCalendar feed of the reservations of every resource in a building. Reservations are shown as anonymous busy blocks unless a manager signs in.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "resources"
+                    "calendar"
                 ],
-                "summary": "Create a new resource",
+                "summary": "Building calendar feed",
                 "parameters": [
                     {
-                        "description": "Resource creation data",
-                        "name": "resource",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_resource.CreateResourceDTO"
-                        }
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Building ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created resource",
+                    "200": {
+                        "description": "iCalendar feed",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid building ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Building not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -1106,9 +1519,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/resources/{id}": {
+        "/buildings/{id}/classes": {
             "get": {
-                "description": "Retrieve a specific resource by its unique identifier",
+                "description": "Retrieve the classes located in a specific building",
                 "consumes": [
                     "application/json"
                 ],
@@ -1116,14 +1529,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "resources"
+                    "classes"
                 ],
-                "summary": "Get resource by ID",
+                "summary": "Get classes of a building",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Resource ID",
+                        "description": "Building ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1131,19 +1544,22 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Resource details",
+                        "description": "List of classes",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid resource ID",
+                        "description": "Invalid building ID",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Resource not found",
+                        "description": "Building not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -1155,9 +1571,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing resource's name, type, and availability by ID",
+            }
+        },
+        "/buildings/{id}/resources": {
+            "get": {
+                "description": "Retrieve the resources kept in a specific building, including those in its classes",
                 "consumes": [
                     "application/json"
                 ],
@@ -1167,41 +1585,35 @@ const docTemplate = `{
                 "tags": [
                     "resources"
                 ],
-                "summary": "Update an existing resource",
+                "summary": "Get resources of a building",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Resource ID",
+                        "description": "Building ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Resource update data",
-                        "name": "resource",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_resource.UpdateResourceDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated resource",
+                        "description": "List of resources",
                         "schema": {
-                            "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid input data",
+                        "description": "Invalid building ID",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Resource not found",
+                        "description": "Building not found",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -1213,9 +1625,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a resource by its ID",
+            }
+        },
+        "/classes": {
+            "get": {
+                "description": "Retrieve a page of classes, optionally filtered. Sortable fields: id, name, capacity, buildingId, createdAt, updatedAt.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1223,34 +1637,335 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "resources"
+                    "classes"
                 ],
-                "summary": "Delete a resource",
+                "summary": "List classes",
                 "parameters": [
                     {
                         "minimum": 1,
                         "type": "integer",
-                        "description": "Resource ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Building ID",
+                        "name": "buildingId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Resource deleted successfully",
+                        "description": "Page of classes",
                         "schema": {
-                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_class_ClassDTO"
                         }
                     },
                     "400": {
-                        "description": "Invalid resource ID",
+                        "description": "Invalid filter, sort field or cursor",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Resource not found",
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new class with the provided name and capacity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "classes"
+                ],
+                "summary": "Create a new class",
+                "parameters": [
+                    {
+                        "description": "Class creation data",
+                        "name": "class",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_class.CreateClassDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created class",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/classes/{id}": {
+            "get": {
+                "description": "Retrieve a specific class by its unique identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "classes"
+                ],
+                "summary": "Get class by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Class details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid class ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing class's name and capacity by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "classes"
+                ],
+                "summary": "Update an existing class",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Class update data",
+                        "name": "class",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_class.UpdateClassDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated class",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a class by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "classes"
+                ],
+                "summary": "Delete a class",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Class deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid class ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Class still has resources or lessons",
                         "schema": {
                             "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
                         }
@@ -1263,221 +1978,6221 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_transport_rest_building.BuildingDTO": {
+        },
+        "/classes/{id}/resources": {
+            "get": {
+                "description": "Retrieve the resources kept in a specific class",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Get resources of a class",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of resources",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid class ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of the domain events recorded in the outbox with their delivery state. Sortable fields: id, type, aggregateType, aggregateId, status, attempts, nextAttemptAt, occurredAt, deliveredAt. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "List domain events",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "reservation.created",
+                        "description": "Event type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "building",
+                            "resource",
+                            "lesson",
+                            "reservation"
+                        ],
+                        "type": "string",
+                        "description": "Kind of record that changed",
+                        "name": "aggregateType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the record that changed",
+                        "name": "aggregateId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events that occurred at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events that occurred at or before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of events",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_event_EventDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort field or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a domain event recorded in the outbox with its delivery state. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Get a domain event",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_event.EventDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Make an event that ran out of delivery attempts pending again with a fresh round of attempts. Sinks that already accepted it are skipped. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Retry a dead event",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event pending again",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_event.EventDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid event ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is not dead",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the registered background jobs with their schedule, next scheduled run and last run. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "List background jobs",
+                "responses": {
+                    "200": {
+                        "description": "List of jobs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_job.JobDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/runs": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of the run history of background jobs. Sortable fields: id, job, trigger, status, startedAt. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "List job runs",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "schedule",
+                            "manual",
+                            "event"
+                        ],
+                        "type": "string",
+                        "description": "What started the run",
+                        "name": "trigger",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "running",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of job runs",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_job_JobRunDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort field or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{name}/run": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run a background job immediately and wait for it to finish. A job that fails is reported in the status of the run. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Run a job now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Finished run",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_job.JobRunDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Job is already running",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lessons": {
+            "get": {
+                "description": "Retrieve a page of lessons, optionally filtered. Sortable fields: id, title, duration, startTime, endTime, classId, createdAt, updatedAt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lessons"
+                ],
+                "summary": "List lessons",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "classId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only lessons ending at or after this RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only lessons starting at or before this RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of lessons",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_lesson_LessonDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort field or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new lesson with the provided title, duration, and start time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lessons"
+                ],
+                "summary": "Create a new lesson",
+                "parameters": [
+                    {
+                        "description": "Lesson creation data",
+                        "name": "lesson",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_lesson.CreateLessonDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created lesson",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_lesson.LessonDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lessons/calendar.ics": {
+            "get": {
+                "description": "iCalendar feed of recent and upcoming lessons",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Lesson calendar feed",
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lessons/import": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update lessons from the VEVENTs of an .ics file. Recurring events are expanded into one lesson per occurrence; re-importing the same file updates lessons instead of duplicating them. Per-event problems are reported in the items.",
+                "consumes": [
+                    "text/calendar"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lessons"
+                ],
+                "summary": "Import lessons from iCalendar",
+                "parameters": [
+                    {
+                        "description": "iCalendar data",
+                        "name": "calendar",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report what would change without saving",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "America/Sao_Paulo",
+                        "description": "IANA time zone for floating times",
+                        "name": "timeZone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import report",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_lesson.ImportReportDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid iCalendar data or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lessons/{id}": {
+            "get": {
+                "description": "Retrieve a specific lesson by its unique identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lessons"
+                ],
+                "summary": "Get lesson by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Lesson ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lesson details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_lesson.LessonDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid lesson ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Lesson not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing lesson's title, duration, and start time by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lessons"
+                ],
+                "summary": "Update an existing lesson",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Lesson ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lesson update data",
+                        "name": "lesson",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_lesson.UpdateLessonDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated lesson",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_lesson.LessonDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Lesson not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a lesson by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lessons"
+                ],
+                "summary": "Delete a lesson",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Lesson ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lesson deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid lesson ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Lesson not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/calendar-tokens": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the caller's feed tokens, including revoked ones. Secrets are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "List calendar feed tokens",
+                "responses": {
+                    "200": {
+                        "description": "List of feed tokens",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_calendar.FeedTokenDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a revocable token for the caller's personal calendar feed. The token is only returned once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Create a calendar feed token",
+                "parameters": [
+                    {
+                        "description": "Token data",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_calendar.CreateFeedTokenDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created token with its feed path",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_calendar.CreatedFeedTokenDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/calendar-tokens/{tokenId}": {
+            "delete": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a feed token; calendar clients using it stop receiving updates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Revoke a calendar feed token",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Feed token ID",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed token revoked",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed token not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Feed token already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/no-shows": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the reservations the caller did not check in to, most recent first. Booking policies may suspend booking after too many.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "check-in"
+                ],
+                "summary": "List my no-shows",
+                "responses": {
+                    "200": {
+                        "description": "List of no-shows",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_checkin.NoShowDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the language and opt-outs of the emails the authenticated user gets when their reservations are approved, rejected, cancelled or about to start. Emails go to the address in the access token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get my notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "Notification preferences",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_notification.PreferencesDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the language of the emails the authenticated user gets and opt out of all of them or of single kinds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update my notification preferences",
+                "parameters": [
+                    {
+                        "description": "Notification preferences",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_notification.UpdatePreferencesDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated notification preferences",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_notification.PreferencesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown notification or unsupported locale",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/quota": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report how many more active reservations and how much more booked time this week the authenticated user is allowed, per quota that applies to them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "Get my quota",
+                "responses": {
+                    "200": {
+                        "description": "Remaining allowance per quota",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_quota.AllowanceDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quota-overrides": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the quota overrides of a user, or of every user when no user is given. Managers only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "List quota overrides",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of quota overrides",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_quota.QuotaOverrideDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Raise, lower or waive the quota of a user for a resource type, or for every type when none is given. Zero limits mean unlimited. Managers only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "Create a quota override",
+                "parameters": [
+                    {
+                        "description": "Quota override data",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_quota.CreateQuotaOverrideDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created quota override",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_quota.QuotaOverrideDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already has an override for this quota",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/quota-overrides/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific quota override by its unique identifier. Managers only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "Get quota override by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Quota override ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quota override details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_quota.QuotaOverrideDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quota override ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quota override not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the limits of a quota override by ID. Zero limits mean unlimited. Managers only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "Update a quota override",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Quota override ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quota override data",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_quota.UpdateQuotaOverrideDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated quota override",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_quota.QuotaOverrideDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quota override not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User already has an override for this quota",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a quota override by ID, holding the user to the configured quota again. Managers only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotas"
+                ],
+                "summary": "Delete a quota override",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Quota override ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quota override deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid quota override ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Quota override not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of the caller's reservations. Managers get every reservation in the system unless mine is set. Sortable fields: id, resourceId, userId, status, startTime, endTime, seriesId, createdAt, updatedAt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "List reservations",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the caller's reservations (managers only, others always get their own)",
+                        "name": "mine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "resourceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner subject",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "seriesId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected",
+                            "cancelled",
+                            "completed",
+                            "no_show",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations ending at or after this RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations starting at or before this RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of reservations",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_reservation_ReservationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort field or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new reservation with resource and time information. The authenticated user becomes its owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Create a new reservation",
+                "parameters": [
+                    {
+                        "description": "Reservation creation data",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.CreateReservationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created reservation",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data or booking policy violated",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Booking policy restricts the resource type to other groups",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Resource not available for the requested time; join the waitlist through POST /waitlist",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/series": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a series from an RFC 5545 recurrence rule. Every occurrence is booked as a pending reservation, or none is if any of them conflicts. The authenticated user becomes its owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Create a recurring reservation series",
+                "parameters": [
+                    {
+                        "description": "Series creation data",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.CreateSeriesDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created series with its occurrences",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.SeriesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data, recurrence rule or booking policy violated",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Booking policy restricts the resource type to other groups",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Some occurrences conflict with existing reservations",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/series/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a recurring reservation series with all of its occurrences. Only the owner, a manager or a reviewer of reservations may see it; anyone else is told it does not exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get reservation series by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.SeriesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid series ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/series/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel every upcoming pending or approved occurrence of a series. Past occurrences are kept. Only the owner or a manager may cancel it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Cancel a reservation series",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series after cancellation",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.SeriesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid series ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the series",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Series not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "No upcoming occurrences to cancel",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific reservation by its unique identifier. Only the owner, a manager or a reviewer of reservations may see it; anyone else is told it does not exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Get reservation by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing reservation's resource, time, and status information by ID. Only the owner or a manager may update it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Update an existing reservation",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reservation update data",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.UpdateReservationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated reservation",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data or booking policy violated",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the reservation",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Resource not available for the requested time",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a reservation by its ID. Only the owner or a manager may delete it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Delete a reservation",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the reservation",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a pending reservation. Requires the manager or admin group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Approve a reservation",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Approved reservation",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a pending or approved reservation. Only the owner or a manager may cancel it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Cancel a reservation",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cancelled reservation",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the reservation",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/check-in": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check in to an approved reservation, from shortly before it starts until the grace period after. The owner or a manager can check in while signed in; anyone can with the check-in token from the QR code of the reservation's resource. Reservations nobody checks in to are released as no-shows.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Check in to a reservation",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Check-in token of the resource",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Checked in reservation",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reservation ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Not signed in or invalid check-in token",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the reservation",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation not approved, already checked in or outside the check-in window",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/occurrence": {
+            "put": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edit one occurrence of a recurring series. The scope selects whether the change applies to this occurrence only, to this and the following ones (splitting the series), or to all upcoming occurrences. Only the owner or a manager may edit it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Edit a series occurrence",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Occurrence update data",
+                        "name": "occurrence",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.UpdateOccurrenceDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series containing the edited occurrence",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.SeriesDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the reservation",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Occurrence cannot be edited or conflicts with existing reservations",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending reservation with a reason. Requires the manager or admin group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Reject a reservation",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "rejection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.RejectReservationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rejected reservation",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources": {
+            "get": {
+                "description": "Retrieve a page of resources, optionally filtered. Sortable fields: id, name, type, isAvailable, buildingId, classId, createdAt, updatedAt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "List resources",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Availability",
+                        "name": "isAvailable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Building ID",
+                        "name": "buildingId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "classId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of resources",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_resource_ResourceDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort field or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new resource with name, type, and availability information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Create a new resource",
+                "parameters": [
+                    {
+                        "description": "Resource creation data",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_resource.CreateResourceDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created resource",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/free-slots": {
+            "get": {
+                "description": "List the open windows of at least the given duration across every available resource matching the filters, e.g. any projector for 2h tomorrow. Slots are ranked by earliest start.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Find free slots across resources",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the search window (RFC 3339)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the search window (RFC 3339), at most 31 days after from",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum slot length, e.g. 90m or 2h",
+                        "name": "duration",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of slots",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Building ID",
+                        "name": "buildingId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "classId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Free slots, earliest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_availability.SlotDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search parameters",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}": {
+            "get": {
+                "description": "Retrieve a specific resource by its unique identifier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Get resource by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resource details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid resource ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing resource's name, type, and availability by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Update an existing resource",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resource update data",
+                        "name": "resource",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_resource.UpdateResourceDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated resource",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a resource by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Delete a resource",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Resource deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid resource ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "iCalendar feed of the reservations of a resource, for subscribing from calendar clients. Reservations are shown as anonymous busy blocks unless a manager signs in.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Resource calendar feed",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid resource ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}/check-in-token": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue the token encoded in the QR code posted at a resource, which lets anyone check in to its reservations. Earlier tokens of the resource stop working. The token is only returned once. Requires the manager or admin group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "check-in"
+                ],
+                "summary": "Issue a check-in token",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Issued token",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_checkin.CheckInTokenDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid resource ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}/free-slots": {
+            "get": {
+                "description": "List the open windows of at least the given duration in which the resource has no active reservation, no lesson in its class and is within opening hours. Slots are ranked by earliest start.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Find free slots of a resource",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the search window (RFC 3339)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the search window (RFC 3339), at most 31 days after from",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimum slot length, e.g. 90m or 2h",
+                        "name": "duration",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Maximum number of slots",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Free slots, earliest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_availability.SlotDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search parameters",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Push reservation and resource changes as Server-Sent Events until the client disconnects. Every event is named after its type (e.g. reservation.created), carries the ID of the domain event and an UpdateDTO as data; comments are sent as heartbeats. Filters are combined, and repeated or comma separated values match any of them. Updates are not replayed: clients refetch what they show after reconnecting. Only managers receive the reservations of other users. EventSource cannot send headers, so the access token may be given in the access_token parameter.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream live updates",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only changes in these buildings",
+                        "name": "buildingId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only changes to these resources",
+                        "name": "resourceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations of this user; 'me' for the authenticated user. Other users need a manager.",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only these event types",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token, for clients that cannot send the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of updates",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_stream.UpdateDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Following another user's reservations without being a manager",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/ws": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrade to a WebSocket connection and push reservation and resource changes as JSON text messages until either side closes it. Messages are UpdateDTOs, or {\"type\": \"heartbeat\"} after a while without changes; messages from the client are ignored. Takes the same filters as /stream, and the access token in the access_token parameter for browsers.",
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream live updates over WebSocket",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only changes in these buildings",
+                        "name": "buildingId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only changes to these resources",
+                        "name": "resourceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reservations of this user; 'me' for the authenticated user. Other users need a manager.",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only these event types",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token, for clients that cannot send the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_stream.UpdateDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or not a WebSocket request",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Following another user's reservations without being a manager",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/calendar.ics": {
+            "get": {
+                "description": "iCalendar feed of a user's reservations. Calendar clients cannot send bearer tokens, so access is granted by a revocable feed token in the query string.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Personal calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User subject",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or revoked feed token",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/waitlist": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of the caller's waitlist entries. Managers get every entry unless mine is set. Sortable fields: id, resourceId, userId, status, startTime, endTime, expiresAt, createdAt, updatedAt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "waitlist"
+                ],
+                "summary": "List waitlist entries",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return the caller's entries (managers only, others always get their own)",
+                        "name": "mine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resource ID",
+                        "name": "resourceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner subject",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "waiting",
+                            "promoted",
+                            "expired",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries ending at or after this RFC 3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries starting at or before this RFC 3339 time",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of waitlist entries",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_waitlist_WaitlistEntryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort field or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Wait for a time window of a resource that is taken. When a conflicting reservation is cancelled or rejected, the oldest waiting entry that fits becomes a pending reservation and its owner is notified. Entries expire at their start time unless an earlier expiresAt is given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "waitlist"
+                ],
+                "summary": "Join the waitlist",
+                "parameters": [
+                    {
+                        "description": "Waitlist entry data",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_waitlist.CreateWaitlistEntryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created waitlist entry",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_waitlist.WaitlistEntryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input data or booking policy violated",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Booking policy restricts the resource type to other groups",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Resource not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Resource is free for the requested time or the caller is already waiting for it",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/waitlist/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific waitlist entry by its unique identifier. Only the owner or a manager may see it; anyone else is told it does not exist.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "waitlist"
+                ],
+                "summary": "Get waitlist entry by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Waitlist entry details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_waitlist.WaitlistEntryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid waitlist entry ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Waitlist entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a waiting entry. Only its owner or a manager can.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "waitlist"
+                ],
+                "summary": "Leave the waitlist",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Waitlist entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Waitlist entry cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid waitlist entry ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner or a manager",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Waitlist entry not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Entry is no longer waiting",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve every webhook subscription. Secrets are not included. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "List of webhooks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_transport_rest_webhook.WebhookDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to domain events, optionally filtered by event type. Events are posted as JSON and signed with HMAC-SHA256 using the secret in the response, which is not shown again: the X-Sarc-Signature header holds \"sha256=\" and the hex HMAC of the X-Sarc-Timestamp header, a dot and the body. Failed posts are retried with exponential backoff. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_webhook.CreateWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created webhook with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_webhook.WebhookSecretDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL or unknown event type",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a webhook subscription by its unique identifier. The secret is not included. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook by ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook details",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_webhook.WebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the URL, description, event types and state of a webhook by ID. The secret is kept. Admins only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_webhook.UpdateWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated webhook",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_webhook.WebhookDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL or unknown event type",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a webhook by ID together with its delivery log. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of the attempts to post events to a webhook, one entry per attempt. Sortable fields: id, eventId, eventType, attempt, status, statusCode, createdAt. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "id",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset cursor from the previous page (sorting by id only)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the event",
+                        "name": "eventId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "reservation.created",
+                        "description": "Event type",
+                        "name": "eventType",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Outcome of the attempt",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attempts made at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attempts made at or before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_webhook_DeliveryDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID, filter, sort field or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/rotate-secret": {
+            "post": {
+                "security": [
+                    {
+                        "CognitoOAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new secret for a webhook. Requests are signed with it from now on; the old secret stops working immediately. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Rotate a webhook secret",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook with its new secret",
+                        "schema": {
+                            "$ref": "#/definitions/internal_transport_rest_webhook.WebhookSecretDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/sarc-ng_internal_transport_common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "internal_transport_rest_apikey.APIKeyDTO": {
+            "type": "object",
+            "properties": {
+                "buildingIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "sarc_3f1c9a0"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_apikey.APIKeySecretDTO": {
+            "type": "object",
+            "properties": {
+                "buildingIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "sarc_3f1c9a0..."
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "sarc_3f1c9a0"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_apikey.CreateAPIKeyDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "buildingIds": {
+                    "description": "empty for every building",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "expiresAt": {
+                    "description": "never expires when absent",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Lobby kiosk"
+                },
+                "permissions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "checkin:issue"
+                    ]
+                }
+            }
+        },
+        "internal_transport_rest_apikey.UpdateAPIKeyDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "buildingIds": {
+                    "description": "empty for every building",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "expiresAt": {
+                    "description": "never expires when absent",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Lobby kiosk"
+                },
+                "permissions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "checkin:issue"
+                    ]
+                }
+            }
+        },
+        "internal_transport_rest_audit.AuditEntryDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actorGroups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "actorId": {
+                    "description": "absent for changes made by background jobs and unauthenticated requests",
+                    "type": "string"
+                },
+                "actorUsername": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_audit.ChangeDTO"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "entityId": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "entityType": {
+                    "type": "string",
+                    "example": "reservation"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "requestId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_audit.ChangeDTO": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string",
+                    "example": "approved"
+                },
+                "before": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "status"
+                }
+            }
+        },
+        "internal_transport_rest_auth.DevTokenRequestDTO": {
+            "type": "object",
+            "required": [
+                "groups"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "alice@example.com"
+                },
+                "expiresIn": {
+                    "description": "Go duration; the configured expiry when empty",
+                    "type": "string",
+                    "example": "8h"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "manager"
+                    ]
+                },
+                "subject": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "dev-alice"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "alice"
+                }
+            }
+        },
+        "internal_transport_rest_auth.DiscoveryDTO": {
+            "type": "object",
+            "properties": {
+                "id_token_signing_alg_values_supported": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "jwks_uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_auth.TokenDTO": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "expiresIn": {
+                    "description": "seconds",
+                    "type": "integer",
+                    "example": 86400
+                },
+                "tokenType": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "internal_transport_rest_availability.SlotDTO": {
+            "type": "object",
+            "properties": {
+                "durationMinutes": {
+                    "type": "integer"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "integer"
+                },
+                "resourceName": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_building.BuildingDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_building.CreateBuildingDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_building.UpdateBuildingDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_calendar.CreateFeedTokenDTO": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Work laptop"
+                }
+            }
+        },
+        "internal_transport_rest_calendar.CreatedFeedTokenDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "feedPath": {
+                    "type": "string",
+                    "example": "/api/v1/users/0f2c.../calendar.ics?token=..."
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_calendar.FeedTokenDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_checkin.CheckInTokenDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issuedBy": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_checkin.NoShowDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reservationId": {
+                    "type": "integer"
+                },
+                "resourceId": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_class.ClassDTO": {
+            "type": "object",
+            "properties": {
+                "buildingId": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_class.CreateClassDTO": {
+            "type": "object",
+            "required": [
+                "buildingId",
+                "name"
+            ],
+            "properties": {
+                "buildingId": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_class.UpdateClassDTO": {
+            "type": "object",
+            "required": [
+                "buildingId",
+                "name"
+            ],
+            "properties": {
+                "buildingId": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_event.EventDTO": {
+            "type": "object",
+            "properties": {
+                "aggregateId": {
+                    "type": "integer"
+                },
+                "aggregateType": {
+                    "type": "string",
+                    "example": "reservation"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "deliveredTo": {
+                    "description": "sinks that accepted the event",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "description": "set while the event is pending",
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "reservation.created"
+                }
+            }
+        },
+        "internal_transport_rest_job.JobDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "lastRun": {
+                    "$ref": "#/definitions/internal_transport_rest_job.JobRunDTO"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "expire-pending-reservations"
+                },
+                "nextRun": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "empty for jobs run on demand only",
+                    "type": "string",
+                    "example": "*/5 * * * *"
+                },
+                "timeoutSeconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_transport_rest_job.JobRunDTO": {
+            "type": "object",
+            "properties": {
+                "durationSeconds": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "holder": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "succeeded"
+                },
+                "summary": {
+                    "type": "string",
+                    "example": "3 pending reservations expired"
+                },
+                "trigger": {
+                    "type": "string",
+                    "example": "schedule"
+                }
+            }
+        },
+        "internal_transport_rest_lesson.CreateLessonDTO": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "classId": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_lesson.ImportItemDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "skip",
+                        "error"
+                    ],
+                    "example": "create"
+                },
+                "externalId": {
+                    "type": "string"
+                },
+                "lessonId": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_lesson.ImportReportDTO": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_lesson.ImportItemDTO"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_transport_rest_lesson.LessonDTO": {
+            "type": "object",
+            "properties": {
+                "classId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "externalId": {
+                    "description": "ExternalID is the calendar event an imported lesson came from",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_lesson.UpdateLessonDTO": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "classId": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_notification.PreferencesDTO": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "notifications that can be opted out of",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "reservation.approved",
+                        "reservation.rejected",
+                        "reservation.cancelled",
+                        "reservation.starting",
+                        "waitlist.promoted"
+                    ]
+                },
+                "disabled": {
+                    "description": "opts out of every notification",
+                    "type": "boolean",
+                    "example": false
+                },
+                "email": {
+                    "description": "taken from the access token; empty when the token has none",
+                    "type": "string",
+                    "example": "ana@example.com"
+                },
+                "locale": {
+                    "description": "empty for the default locale",
+                    "type": "string",
+                    "example": "pt-BR"
+                },
+                "optOut": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "reservation.starting"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_notification.UpdatePreferencesDTO": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "pt-BR"
+                },
+                "optOut": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "reservation.starting"
+                    ]
+                }
+            }
+        },
+        "internal_transport_rest_policy.CreatePolicyDTO": {
+            "type": "object",
+            "required": [
+                "resourceType"
+            ],
+            "properties": {
+                "allowedGroups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "teacher",
+                        "manager"
+                    ]
+                },
+                "allowedHours": {
+                    "$ref": "#/definitions/internal_transport_rest_policy.HoursDTO"
+                },
+                "bufferMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 15
+                },
+                "maxAdvanceMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 43200
+                },
+                "maxDurationMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 240
+                },
+                "minLeadTimeMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 60
+                },
+                "noShowLimit": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "noShowPeriodMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 43200
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "projector"
+                }
+            }
+        },
+        "internal_transport_rest_policy.HoursDTO": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri"
+                    ]
+                },
+                "from": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "timeZone": {
+                    "type": "string",
+                    "example": "America/Sao_Paulo"
+                },
+                "until": {
+                    "type": "string",
+                    "example": "22:00"
+                }
+            }
+        },
+        "internal_transport_rest_policy.PolicyDTO": {
+            "type": "object",
+            "properties": {
+                "allowedGroups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowedHours": {
+                    "$ref": "#/definitions/internal_transport_rest_policy.HoursDTO"
+                },
+                "bufferMinutes": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "maxAdvanceMinutes": {
+                    "type": "integer"
+                },
+                "maxDurationMinutes": {
+                    "type": "integer"
+                },
+                "minLeadTimeMinutes": {
+                    "type": "integer"
+                },
+                "noShowLimit": {
+                    "type": "integer"
+                },
+                "noShowPeriodMinutes": {
+                    "type": "integer"
+                },
+                "resourceType": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_policy.UpdatePolicyDTO": {
+            "type": "object",
+            "required": [
+                "resourceType"
+            ],
+            "properties": {
+                "allowedGroups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "teacher",
+                        "manager"
+                    ]
+                },
+                "allowedHours": {
+                    "$ref": "#/definitions/internal_transport_rest_policy.HoursDTO"
+                },
+                "bufferMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 15
+                },
+                "maxAdvanceMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 43200
+                },
+                "maxDurationMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 240
+                },
+                "minLeadTimeMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 60
+                },
+                "noShowLimit": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "noShowPeriodMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 43200
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "projector"
+                }
+            }
+        },
+        "internal_transport_rest_quota.AllowanceDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "maxActive": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "maxWeeklyMinutes": {
+                    "type": "integer",
+                    "example": 600
+                },
+                "remainingActive": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "remainingWeeklyMinutes": {
+                    "type": "integer",
+                    "example": 480
+                },
+                "resourceType": {
+                    "description": "empty for the quota over every resource type",
+                    "type": "string",
+                    "example": "laboratory"
+                },
+                "waived": {
+                    "type": "boolean"
+                },
+                "weekEnd": {
+                    "type": "string"
+                },
+                "weekStart": {
+                    "type": "string"
+                },
+                "weeklyMinutes": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "internal_transport_rest_quota.CreateQuotaOverrideDTO": {
+            "type": "object",
+            "required": [
+                "userId"
+            ],
+            "properties": {
+                "maxActive": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "maxWeeklyMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1200
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Thesis experiments"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "laboratory"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "7d3f6a2e-1b2c-4d5e-8f90-a1b2c3d4e5f6"
+                },
+                "waived": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "internal_transport_rest_quota.QuotaOverrideDTO": {
             "type": "object",
             "properties": {
-                "code": {
+                "createdAt": {
                     "type": "string"
                 },
-                "createdAt": {
+                "grantedBy": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "maxActive": {
+                    "type": "integer"
+                },
+                "maxWeeklyMinutes": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "resourceType": {
                     "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "waived": {
+                    "type": "boolean"
                 }
             }
         },
-        "internal_transport_rest_building.CreateBuildingDTO": {
+        "internal_transport_rest_quota.UpdateQuotaOverrideDTO": {
             "type": "object",
             "required": [
-                "code",
-                "name"
+                "userId"
             ],
             "properties": {
-                "code": {
+                "maxActive": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "maxWeeklyMinutes": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1200
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Thesis experiments"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "laboratory"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "7d3f6a2e-1b2c-4d5e-8f90-a1b2c3d4e5f6"
+                },
+                "waived": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "internal_transport_rest_reservation.CreateReservationDTO": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "purpose",
+                "resourceId",
+                "startTime"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "name": {
+                "endTime": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "internal_transport_rest_building.UpdateBuildingDTO": {
+        "internal_transport_rest_reservation.CreateSeriesDTO": {
             "type": "object",
             "required": [
-                "code",
-                "id",
-                "name"
+                "endTime",
+                "purpose",
+                "recurrenceRule",
+                "resourceId",
+                "startTime"
             ],
             "properties": {
-                "code": {
+                "description": {
                     "type": "string"
                 },
-                "id": {
+                "endTime": {
+                    "type": "string"
+                },
+                "exceptionDates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "recurrenceRule": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20261220T235959Z"
+                },
+                "resourceId": {
                     "type": "integer"
                 },
-                "name": {
+                "startTime": {
                     "type": "string"
+                },
+                "timeZone": {
+                    "type": "string",
+                    "example": "America/Sao_Paulo"
                 }
             }
         },
-        "internal_transport_rest_class.ClassDTO": {
+        "internal_transport_rest_reservation.RejectReservationDTO": {
             "type": "object",
+            "required": [
+                "reason"
+            ],
             "properties": {
-                "capacity": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_reservation.ReservationDTO": {
+            "type": "object",
+            "properties": {
+                "checkedInAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "integer"
+                },
+                "seriesId": {
                     "type": "integer"
                 },
+                "startTime": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "statusReason": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_reservation.SeriesDTO": {
+            "type": "object",
+            "properties": {
                 "createdAt": {
                     "type": "string"
                 },
+                "description": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "exceptionDates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                    }
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "recurrenceRule": {
+                    "type": "string"
+                },
+                "resourceId": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "timeZone": {
                     "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "userId": {
+                    "type": "string"
                 }
             }
         },
-        "internal_transport_rest_class.CreateClassDTO": {
+        "internal_transport_rest_reservation.UpdateOccurrenceDTO": {
             "type": "object",
             "required": [
-                "name"
+                "endTime",
+                "purpose",
+                "scope",
+                "startTime"
             ],
             "properties": {
-                "capacity": {
-                    "type": "integer",
-                    "minimum": 1
+                "description": {
+                    "type": "string"
                 },
-                "name": {
+                "endTime": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "recurrenceRule": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "this",
+                        "following",
+                        "all"
+                    ]
+                },
+                "startTime": {
                     "type": "string"
                 }
             }
         },
-        "internal_transport_rest_class.UpdateClassDTO": {
+        "internal_transport_rest_reservation.UpdateReservationDTO": {
             "type": "object",
             "required": [
-                "id",
-                "name"
+                "endTime",
+                "purpose",
+                "resourceId",
+                "startTime"
             ],
             "properties": {
-                "capacity": {
-                    "type": "integer",
-                    "minimum": 1
+                "description": {
+                    "type": "string"
                 },
-                "id": {
+                "endTime": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "type": "string"
+                },
+                "resourceId": {
                     "type": "integer"
                 },
-                "name": {
+                "startTime": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "internal_transport_rest_lesson.CreateLessonDTO": {
+        "internal_transport_rest_resource.CreateResourceDTO": {
             "type": "object",
             "required": [
-                "title"
+                "name",
+                "type"
             ],
             "properties": {
-                "duration": {
-                    "type": "integer",
-                    "minimum": 1
+                "buildingId": {
+                    "description": "BuildingID may be left out when ClassID is set; it is taken from the class",
+                    "type": "integer"
                 },
-                "startTime": {
+                "classId": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
-                "title": {
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "internal_transport_rest_lesson.LessonDTO": {
+        "internal_transport_rest_resource.ResourceDTO": {
             "type": "object",
             "properties": {
+                "buildingId": {
+                    "type": "integer"
+                },
+                "classId": {
+                    "type": "integer"
+                },
                 "createdAt": {
                     "type": "string"
                 },
-                "duration": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "endTime": {
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "location": {
                     "type": "string"
                 },
-                "id": {
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_resource.UpdateResourceDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "buildingId": {
+                    "description": "BuildingID may be left out when ClassID is set; it is taken from the class",
+                    "type": "integer"
+                },
+                "classId": {
                     "type": "integer"
                 },
-                "startTime": {
+                "description": {
+                    "type": "string"
+                },
+                "isAvailable": {
+                    "type": "boolean"
+                },
+                "location": {
                     "type": "string"
                 },
-                "title": {
+                "name": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "internal_transport_rest_lesson.UpdateLessonDTO": {
+        "internal_transport_rest_stream.UpdateDTO": {
             "type": "object",
-            "required": [
-                "id",
-                "title"
-            ],
             "properties": {
-                "duration": {
+                "aggregateId": {
                     "type": "integer",
-                    "minimum": 1
+                    "example": 7
+                },
+                "aggregateType": {
+                    "type": "string",
+                    "example": "reservation"
+                },
+                "buildingId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "data": {
+                    "description": "the domain event, as posted to webhooks",
+                    "type": "object"
                 },
                 "id": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 42
                 },
-                "startTime": {
-                    "type": "string"
+                "occurredAt": {
+                    "type": "string",
+                    "example": "2025-10-04T14:00:00Z"
                 },
-                "title": {
-                    "type": "string"
+                "resourceId": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "type": {
+                    "type": "string",
+                    "example": "reservation.status_changed"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "user-123"
                 }
             }
         },
-        "internal_transport_rest_reservation.CreateReservationDTO": {
+        "internal_transport_rest_waitlist.CreateWaitlistEntryDTO": {
             "type": "object",
             "required": [
                 "endTime",
+                "purpose",
                 "resourceId",
-                "startTime",
-                "userId"
+                "startTime"
             ],
             "properties": {
-                "endTime": {
+                "description": {
                     "type": "string"
                 },
-                "resourceId": {
-                    "type": "integer"
+                "endTime": {
+                    "type": "string"
                 },
-                "startTime": {
+                "expiresAt": {
+                    "description": "defaults to the start time",
                     "type": "string"
                 },
-                "status": {
+                "purpose": {
                     "type": "string"
                 },
-                "userId": {
+                "resourceId": {
                     "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
                 }
             }
         },
-        "internal_transport_rest_reservation.ReservationDTO": {
+        "internal_transport_rest_waitlist.WaitlistEntryDTO": {
             "type": "object",
             "properties": {
                 "createdAt": {
                     "type": "string"
                 },
+                "description": {
+                    "type": "string"
+                },
                 "endTime": {
                     "type": "string"
                 },
+                "expiresAt": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "purpose": {
+                    "type": "string"
+                },
+                "reservationId": {
+                    "type": "integer"
+                },
                 "resourceId": {
                     "type": "integer"
                 },
@@ -1491,103 +8206,228 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "userId": {
-                    "type": "integer"
+                    "type": "string"
                 }
             }
         },
-        "internal_transport_rest_reservation.UpdateReservationDTO": {
+        "internal_transport_rest_webhook.CreateWebhookDTO": {
             "type": "object",
             "required": [
-                "endTime",
-                "id",
-                "resourceId",
-                "startTime",
-                "userId"
+                "url"
             ],
             "properties": {
-                "endTime": {
+                "active": {
+                    "description": "defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Room display sync"
+                },
+                "eventTypes": {
+                    "description": "empty for every event",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "reservation.created",
+                        "reservation.cancelled"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/sarc"
+                }
+            }
+        },
+        "internal_transport_rest_webhook.DeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "createdAt": {
                     "type": "string"
                 },
-                "id": {
+                "durationMs": {
+                    "type": "integer",
+                    "example": 87
+                },
+                "error": {
+                    "type": "string"
+                },
+                "eventId": {
                     "type": "integer"
                 },
-                "resourceId": {
+                "eventType": {
+                    "type": "string",
+                    "example": "reservation.created"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "startTime": {
+                "responseBody": {
                     "type": "string"
                 },
                 "status": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "succeeded"
                 },
-                "userId": {
+                "statusCode": {
+                    "description": "absent when no response was received",
+                    "type": "integer",
+                    "example": 204
+                },
+                "subscriptionId": {
                     "type": "integer"
                 }
             }
         },
-        "internal_transport_rest_resource.CreateResourceDTO": {
+        "internal_transport_rest_webhook.UpdateWebhookDTO": {
             "type": "object",
             "required": [
-                "name",
-                "type"
+                "url"
             ],
             "properties": {
-                "isAvailable": {
-                    "type": "boolean"
+                "active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "name": {
-                    "type": "string"
+                "description": {
+                    "type": "string",
+                    "example": "Room display sync"
                 },
-                "type": {
-                    "type": "string"
+                "eventTypes": {
+                    "description": "empty for every event",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "reservation.created",
+                        "reservation.cancelled"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/sarc"
                 }
             }
         },
-        "internal_transport_rest_resource.ResourceDTO": {
+        "internal_transport_rest_webhook.WebhookDTO": {
             "type": "object",
             "properties": {
+                "active": {
+                    "type": "boolean"
+                },
                 "createdAt": {
                     "type": "string"
                 },
+                "createdBy": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
-                "isAvailable": {
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_transport_rest_webhook.WebhookSecretDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
                     "type": "boolean"
                 },
-                "name": {
+                "createdAt": {
                     "type": "string"
                 },
-                "type": {
+                "createdBy": {
+                    "type": "string"
+                },
+                "description": {
                     "type": "string"
                 },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_3f1c..."
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "internal_transport_rest_resource.UpdateResourceDTO": {
+        "sarc-ng_internal_domain_auth.JWK": {
             "type": "object",
-            "required": [
-                "id",
-                "name",
-                "type"
-            ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "alg": {
+                    "type": "string"
                 },
-                "isAvailable": {
-                    "type": "boolean"
+                "crv": {
+                    "type": "string"
                 },
-                "name": {
+                "e": {
                     "type": "string"
                 },
-                "type": {
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
                     "type": "string"
                 }
             }
         },
+        "sarc-ng_internal_domain_auth.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sarc-ng_internal_domain_auth.JWK"
+                    }
+                }
+            }
+        },
         "sarc-ng_internal_transport_common.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -1602,6 +8442,10 @@ const docTemplate = `{
                 "message": {
                     "type": "string",
                     "example": "The provided data is invalid"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "maxDuration"
                 }
             }
         },
@@ -1614,9 +8458,176 @@ const docTemplate = `{
                     "example": "Operation completed successfully"
                 }
             }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_audit_AuditEntryDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_audit.AuditEntryDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_building_BuildingDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_building.BuildingDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_class_ClassDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_class.ClassDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_event_EventDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_event.EventDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_job_JobRunDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_job.JobRunDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_lesson_LessonDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_lesson.LessonDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_reservation_ReservationDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_reservation.ReservationDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_resource_ResourceDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_resource.ResourceDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_waitlist_WaitlistEntryDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_waitlist.WaitlistEntryDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginatedResponse-internal_transport_rest_webhook_DeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_transport_rest_webhook.DeliveryDTO"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/sarc-ng_pkg_rest_types.PaginationMeta"
+                }
+            }
+        },
+        "sarc-ng_pkg_rest_types.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "nextCursor": {
+                    "description": "NextCursor continues the listing with keyset pagination, when available",
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "totalItems": {
+                    "type": "integer"
+                },
+                "totalPages": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key for services and devices, accepted wherever a token is",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "JWT token from Cognito (use the access_token from OAuth2 login)",
             "type": "apiKey",
@@ -0,0 +1,278 @@
+package apikeys
+
+import (
+	"encoding/json"
+	"fmt"
+	"sarc-ng/pkg/rest/client"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the API keys command group
+func NewCommand(clientFactory func() *client.Client) *cobra.Command {
+	apiKeysCmd := &cobra.Command{
+		Use:   "apikeys",
+		Short: "Manage API keys",
+		Long:  "Create and revoke API keys for jobs and devices that cannot sign in. Keys are sent in the X-API-Key header and may only take the actions of their permissions, optionally only in some buildings. Admins only.",
+	}
+
+	// Add subcommands
+	apiKeysCmd.AddCommand(newListCommand(clientFactory))
+	apiKeysCmd.AddCommand(newGetCommand(clientFactory))
+	apiKeysCmd.AddCommand(newCreateCommand(clientFactory))
+	apiKeysCmd.AddCommand(newUpdateCommand(clientFactory))
+	apiKeysCmd.AddCommand(newDeleteCommand(clientFactory))
+
+	return apiKeysCmd
+}
+
+// List API keys
+func newListCommand(clientFactory func() *client.Client) *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List API keys",
+		Long:  "Retrieve and display every API key, without the keys themselves.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := clientFactory()
+			data, err := client.APIKeys().List()
+			if err != nil {
+				return fmt.Errorf("failed to list API keys: %w", err)
+			}
+
+			var keys []APIKey
+			if err := json.Unmarshal(data, &keys); err != nil {
+				return fmt.Errorf("failed to parse API keys: %w", err)
+			}
+
+			if len(keys) == 0 {
+				fmt.Println("No API keys found.")
+				return nil
+			}
+
+			return OutputWithFormat(keys, OutputFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+// Get a specific API key
+func newGetCommand(clientFactory func() *client.Client) *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "get <id>",
+		Short: "Get an API key by ID",
+		Long:  "Retrieve and display details for a specific API key.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client := clientFactory()
+			key, err := getAPIKey(client, id)
+			if err != nil {
+				return err
+			}
+
+			return OutputWithFormat([]APIKey{*key}, OutputFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+// Create a new API key
+func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
+	var name, expiresAt string
+	var permissions []string
+	var buildingIDs []uint
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an API key",
+		Long:  "Generate an API key granted the given permissions, optionally only in the given buildings. The key is shown once.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expiry, err := parseExpiry(expiresAt)
+			if err != nil {
+				return err
+			}
+
+			req := APIKeyRequest{
+				Name:        name,
+				Permissions: permissions,
+				BuildingIDs: buildingIDs,
+				ExpiresAt:   expiry,
+			}
+
+			client := clientFactory()
+			data, err := client.APIKeys().Create(req)
+			if err != nil {
+				return fmt.Errorf("failed to create API key: %w", err)
+			}
+
+			var key APIKey
+			if err := json.Unmarshal(data, &key); err != nil {
+				return fmt.Errorf("failed to parse API key: %w", err)
+			}
+
+			fmt.Printf("✅ API key created successfully:\n")
+			if err := OutputTable([]APIKey{key}); err != nil {
+				return err
+			}
+			fmt.Printf("\nAPI key (shown only once, store it now):\n  %s\n", key.Key)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Name of the API key (required)")
+	cmd.Flags().StringSliceVarP(&permissions, "permission", "p", nil, "Permission granted to the key, such as checkin:issue, repeatable (required)")
+	cmd.Flags().UintSliceVarP(&buildingIDs, "building", "b", nil, "Building the key may make changes in, repeatable (defaults to every building)")
+	cmd.Flags().StringVar(&expiresAt, "expires-at", "", "Expiry time (ISO format, defaults to never)")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("permission")
+
+	return cmd
+}
+
+// Update an existing API key
+func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
+	var name, expiresAt string
+	var permissions []string
+	var buildingIDs []uint
+	var allBuildings bool
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update an API key",
+		Long:  "Change the name, permissions, buildings or expiry of an API key. The key itself stays the same. Flags that are not given keep their current value.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client := clientFactory()
+			current, err := getAPIKey(client, id)
+			if err != nil {
+				return err
+			}
+
+			req := APIKeyRequest{
+				Name:        current.Name,
+				Permissions: current.Permissions,
+				BuildingIDs: current.BuildingIDs,
+				ExpiresAt:   current.ExpiresAt,
+			}
+			if cmd.Flags().Changed("name") {
+				req.Name = name
+			}
+			if cmd.Flags().Changed("permission") {
+				req.Permissions = permissions
+			}
+			if cmd.Flags().Changed("building") {
+				req.BuildingIDs = buildingIDs
+			}
+			if allBuildings {
+				req.BuildingIDs = nil
+			}
+			if cmd.Flags().Changed("expires-at") {
+				if req.ExpiresAt, err = parseExpiry(expiresAt); err != nil {
+					return err
+				}
+			}
+
+			data, err := client.APIKeys().Update(id, req)
+			if err != nil {
+				return fmt.Errorf("failed to update API key: %w", err)
+			}
+
+			var key APIKey
+			if err := json.Unmarshal(data, &key); err != nil {
+				return fmt.Errorf("failed to parse API key: %w", err)
+			}
+
+			fmt.Printf("✅ API key updated successfully:\n")
+			return OutputTable([]APIKey{key})
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Name of the API key")
+	cmd.Flags().StringSliceVarP(&permissions, "permission", "p", nil, "Permission granted to the key, repeatable")
+	cmd.Flags().UintSliceVarP(&buildingIDs, "building", "b", nil, "Building the key may make changes in, repeatable")
+	cmd.Flags().BoolVar(&allBuildings, "all-buildings", false, "Let the key make changes in every building")
+	cmd.Flags().StringVar(&expiresAt, "expires-at", "", "Expiry time (ISO format); pass --expires-at= to never expire")
+	cmd.MarkFlagsMutuallyExclusive("building", "all-buildings")
+
+	return cmd
+}
+
+// Delete an API key
+func newDeleteCommand(clientFactory func() *client.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete an API key",
+		Long:  "Revoke an API key by ID. Requests with the key are refused immediately.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client := clientFactory()
+			if err := client.APIKeys().Delete(id); err != nil {
+				return fmt.Errorf("failed to delete API key: %w", err)
+			}
+
+			fmt.Printf("✅ API key %d deleted successfully.\n", id)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// getAPIKey retrieves and parses an API key
+func getAPIKey(client *client.Client, id uint) (*APIKey, error) {
+	data, err := client.APIKeys().Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	var key APIKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse API key: %w", err)
+	}
+	return &key, nil
+}
+
+// parseID parses an API key ID argument
+func parseID(arg string) (uint, error) {
+	id, err := strconv.ParseUint(arg, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid API key ID: %s", arg)
+	}
+	return uint(id), nil
+}
+
+// parseExpiry parses an expiry time, where empty means never
+func parseExpiry(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry time format: %w", err)
+	}
+	return &expiry, nil
+}
@@ -0,0 +1,86 @@
+package apikeys
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// OutputFormat represents the output format for displaying data
+type OutputFormat string
+
+const (
+	// TableFormat displays data in a table
+	TableFormat OutputFormat = "table"
+	// JSONFormat displays data as JSON
+	JSONFormat OutputFormat = "json"
+)
+
+// OutputWithFormat displays API keys in the specified format
+func OutputWithFormat(keys []APIKey, format OutputFormat) error {
+	switch format {
+	case JSONFormat:
+		return OutputJSON(keys)
+	default:
+		return OutputTable(keys)
+	}
+}
+
+// OutputJSON outputs data as JSON
+func OutputJSON(data interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// OutputTable outputs API keys in a formatted table
+func OutputTable(keys []APIKey) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Name", "Prefix", "Permissions", "Buildings", "Expires", "Last Used", "Created By"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+
+	for _, key := range keys {
+		buildings := "all"
+		if len(key.BuildingIDs) > 0 {
+			ids := make([]string, len(key.BuildingIDs))
+			for i, id := range key.BuildingIDs {
+				ids[i] = fmt.Sprintf("%d", id)
+			}
+			buildings = strings.Join(ids, ", ")
+		}
+		expires := "never"
+		if key.ExpiresAt != nil {
+			expires = formatTime(*key.ExpiresAt)
+		}
+		lastUsed := "-"
+		if key.LastUsedAt != nil {
+			lastUsed = formatTime(*key.LastUsedAt)
+		}
+		table.Append([]string{
+			fmt.Sprintf("%d", key.ID),
+			key.Name,
+			key.Prefix,
+			strings.Join(key.Permissions, ", "),
+			buildings,
+			expires,
+			lastUsed,
+			key.CreatedBy,
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+// formatTime formats a time.Time for display
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
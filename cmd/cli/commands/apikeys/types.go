@@ -0,0 +1,27 @@
+package apikeys
+
+import "time"
+
+// APIKeyRequest represents a request to create or update an API key
+type APIKeyRequest struct {
+	Name        string     `json:"name"`
+	Permissions []string   `json:"permissions"`
+	BuildingIDs []uint     `json:"buildingIds,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// APIKey represents an API key response. The key itself is only returned
+// when it is created.
+type APIKey struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	Permissions []string   `json:"permissions"`
+	BuildingIDs []uint     `json:"buildingIds"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	Key         string     `json:"key,omitempty"`
+	CreatedBy   string     `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
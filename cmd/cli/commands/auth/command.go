@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sarc-ng/pkg/rest/client"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the auth command group
+func NewCommand(clientFactory func() *client.Client) *cobra.Command {
+	authCmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage access tokens",
+		Long:  "Obtain tokens to call protected endpoints. Pass them with --token or the SARC_TOKEN environment variable, or use sarc login to have them sent and renewed for you.",
+	}
+
+	// Add subcommands
+	authCmd.AddCommand(newDevTokenCommand(clientFactory))
+
+	return authCmd
+}
+
+// Get a development token
+func newDevTokenCommand(clientFactory func() *client.Client) *cobra.Command {
+	var subject, username, email string
+	var groups []string
+	var ttl time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "dev-token",
+		Short: "Get a development token",
+		Long: `Ask a server signing its own tokens for a token of any user. Only servers
+with jwt.dev_tokens enabled, such as the development configuration, hand them out.
+
+The token alone is printed, so it can be captured:
+
+  export SARC_TOKEN=$(sarc auth dev-token --groups manager)`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := DevTokenRequest{
+				Subject:  subject,
+				Username: username,
+				Email:    email,
+				Groups:   groups,
+			}
+			if ttl > 0 {
+				req.ExpiresIn = ttl.String()
+			}
+
+			client := clientFactory()
+			data, err := client.Auth().DevToken(req)
+			if err != nil {
+				return fmt.Errorf("failed to get development token: %w", err)
+			}
+
+			var token Token
+			if err := json.Unmarshal(data, &token); err != nil {
+				return fmt.Errorf("failed to parse token: %w", err)
+			}
+
+			fmt.Println(token.AccessToken)
+			fmt.Fprintf(os.Stderr, "Token expires at %s\n", token.ExpiresAt.Local().Format(time.RFC1123))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&groups, "groups", "g", nil, "Groups of the user, such as admin, manager or teacher")
+	cmd.Flags().StringVarP(&username, "username", "u", "", "Username (defaults to developer)")
+	cmd.Flags().StringVar(&email, "email", "", "Email address")
+	cmd.Flags().StringVar(&subject, "subject", "", "Subject, the user ID (defaults to dev-<username>)")
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "Lifetime of the token, such as 8h (defaults to the server's expiry)")
+
+	return cmd
+}
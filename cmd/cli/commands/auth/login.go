@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"sarc-ng/pkg/oauth"
+	"sarc-ng/pkg/rest/client"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cobra"
+)
+
+// NewLoginCommand creates the login command
+func NewLoginCommand(clientFactory func() *client.Client) *cobra.Command {
+	var issuer, clientID string
+	var scopes []string
+	var device, noBrowser bool
+	var port int
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Sign in to the API",
+		Long: `Sign in with the OpenID Connect provider of the API, in a browser or, with
+--device, by entering a code on another device. The tokens are kept in
+~/.sarc/credentials and sent with every request, and are renewed when they
+expire until the provider ends the session.
+
+The issuer and the public client to sign in with are remembered, so they only
+need to be given the first time:
+
+  sarc login --issuer https://id.example.com/realms/sarc --client-id sarc-cli`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := oauth.DefaultStore()
+			if err != nil {
+				return err
+			}
+			apiURL := clientFactory().BaseURL()
+
+			provider := &oauth.Provider{Issuer: issuer, ClientID: clientID, Scopes: scopes, CallbackPort: port}
+			if previous, err := store.Load(apiURL); err == nil {
+				if provider.Issuer == "" {
+					provider.Issuer = previous.Issuer
+				}
+				if provider.ClientID == "" {
+					provider.ClientID = previous.ClientID
+				}
+				if !cmd.Flags().Changed("scope") {
+					provider.Scopes = previous.Scopes
+				}
+			}
+			if provider.Issuer == "" || provider.ClientID == "" {
+				return fmt.Errorf("the OIDC issuer and client ID are required. Set them with --issuer and --client-id or the SARC_OIDC_ISSUER and SARC_OIDC_CLIENT_ID environment variables")
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			var token *oauth.Token
+			if device {
+				token, err = provider.LoginWithDevice(ctx, printDeviceCode)
+			} else {
+				token, err = provider.LoginWithBrowser(ctx, func(authURL string) error {
+					fmt.Fprintf(os.Stderr, "Sign in at:\n  %s\n", authURL)
+					if !noBrowser {
+						if err := openBrowser(authURL); err != nil {
+							fmt.Fprintf(os.Stderr, "Could not open a browser, open the link above instead.\n")
+						}
+					}
+					return nil
+				})
+			}
+			if err != nil {
+				return fmt.Errorf("failed to sign in: %w", err)
+			}
+
+			creds := &oauth.Credentials{
+				Issuer:   provider.Issuer,
+				ClientID: provider.ClientID,
+				Scopes:   provider.Scopes,
+				Token:    *token,
+			}
+			if err := store.Save(apiURL, creds); err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Signed in to %s as %s.\n", apiURL, describeUser(token))
+			if token.RefreshToken == "" {
+				fmt.Fprintf(os.Stderr, "The provider issued no refresh token, so you will have to sign in again when the token expires.\n")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&issuer, "issuer", os.Getenv("SARC_OIDC_ISSUER"), "OpenID Connect issuer URL (or set SARC_OIDC_ISSUER)")
+	cmd.Flags().StringVar(&clientID, "client-id", os.Getenv("SARC_OIDC_CLIENT_ID"), "Public client to sign in with (or set SARC_OIDC_CLIENT_ID)")
+	cmd.Flags().StringSliceVar(&scopes, "scope", nil, "Scope to request, repeatable (defaults to openid, profile, email and offline_access)")
+	cmd.Flags().BoolVar(&device, "device", false, "Sign in by entering a code on another device")
+	cmd.Flags().BoolVar(&noBrowser, "no-browser", false, "Print the sign-in link without opening a browser")
+	cmd.Flags().IntVar(&port, "port", 0, "Loopback port the browser is redirected to (defaults to a free one)")
+
+	return cmd
+}
+
+// NewLogoutCommand creates the logout command
+func NewLogoutCommand(clientFactory func() *client.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Sign out of the API",
+		Long:  "Revoke the session with the OpenID Connect provider, where it allows that, and forget the tokens kept for the API.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := oauth.DefaultStore()
+			if err != nil {
+				return err
+			}
+			apiURL := clientFactory().BaseURL()
+
+			creds, err := store.Load(apiURL)
+			if errors.Is(err, oauth.ErrLoginRequired) {
+				fmt.Printf("Not signed in to %s.\n", apiURL)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+			if err := creds.Provider().Revoke(ctx, creds.RefreshToken); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to end the session with the provider: %v\n", err)
+			}
+
+			if err := store.Delete(apiURL); err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Signed out of %s.\n", apiURL)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// printDeviceCode tells the user where to approve a device sign-in
+func printDeviceCode(code oauth.DeviceCode) {
+	fmt.Fprintf(os.Stderr, "To sign in, visit %s and enter the code %s\n", code.VerificationURI, code.UserCode)
+	if code.VerificationURIComplete != "" {
+		fmt.Fprintf(os.Stderr, "or visit %s\n", code.VerificationURIComplete)
+	}
+	fmt.Fprintf(os.Stderr, "The code expires at %s. Waiting for approval...\n", code.ExpiresAt.Local().Format(time.Kitchen))
+}
+
+// openBrowser opens the URL in the default browser
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// describeUser names the user the tokens were issued to. The tokens are not
+// verified, as they are only shown.
+func describeUser(token *oauth.Token) string {
+	raw := token.IDToken
+	if raw == "" {
+		raw = token.AccessToken
+	}
+
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(raw, claims); err != nil {
+		return "an unknown user"
+	}
+	for _, name := range []string{"preferred_username", "email", "sub"} {
+		if value, ok := claims[name].(string); ok && value != "" {
+			return value
+		}
+	}
+	return "an unknown user"
+}
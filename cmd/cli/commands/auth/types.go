@@ -0,0 +1,20 @@
+package auth
+
+import "time"
+
+// DevTokenRequest represents a request for a development token
+type DevTokenRequest struct {
+	Subject   string   `json:"subject,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	Email     string   `json:"email,omitempty"`
+	Groups    []string `json:"groups"`
+	ExpiresIn string   `json:"expiresIn,omitempty"`
+}
+
+// Token represents an issued access token
+type Token struct {
+	AccessToken string    `json:"accessToken"`
+	TokenType   string    `json:"tokenType"`
+	ExpiresIn   int64     `json:"expiresIn"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
@@ -30,14 +30,21 @@ func NewCommand(clientFactory func() *client.Client) *cobra.Command {
 // List all classes
 func newListCommand(clientFactory func() *client.Client) *cobra.Command {
 	var outputFormat string
+	var buildingID uint
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all classes",
-		Long:  "Retrieve and display all classes in the system.",
+		Long:  "Retrieve and display all classes in the system, or only those of a building.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := clientFactory()
-			rawResp, err := client.Classes().List(1, 100) // Get first 100 classes
+			var rawResp []byte
+			var err error
+			if buildingID != 0 {
+				rawResp, err = client.Buildings().Classes(buildingID)
+			} else {
+				rawResp, err = client.Classes().List(1, 100) // Get first 100 classes
+			}
 			if err != nil {
 				return fmt.Errorf("failed to list classes: %w", err)
 			}
@@ -65,6 +72,7 @@ func newListCommand(clientFactory func() *client.Client) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	cmd.Flags().UintVarP(&buildingID, "building-id", "b", 0, "Only list the classes of this building")
 	return cmd
 }
 
@@ -107,11 +115,12 @@ func newGetCommand(clientFactory func() *client.Client) *cobra.Command {
 func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 	var name string
 	var capacity int
+	var buildingID uint
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new class",
-		Long:  "Create a new class with the specified name and capacity.",
+		Long:  "Create a new class with the specified name and capacity in a building.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if name == "" {
 				return fmt.Errorf("class name is required")
@@ -122,8 +131,9 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 
 			client := clientFactory()
 			req := ClassRequest{
-				Name:     name,
-				Capacity: capacity,
+				Name:       name,
+				Capacity:   capacity,
+				BuildingID: buildingID,
 			}
 
 			rawResp, err := client.Classes().Create(req)
@@ -144,8 +154,10 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Class name (required)")
 	cmd.Flags().IntVarP(&capacity, "capacity", "c", 0, "Class capacity (required)")
+	cmd.Flags().UintVarP(&buildingID, "building-id", "b", 0, "Building the class is in (required)")
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("capacity")
+	_ = cmd.MarkFlagRequired("building-id")
 
 	return cmd
 }
@@ -154,11 +166,12 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 	var name string
 	var capacity int
+	var buildingID uint
 
 	cmd := &cobra.Command{
 		Use:   "update <id>",
 		Short: "Update a class",
-		Long:  "Update an existing class's name and/or capacity.",
+		Long:  "Update an existing class's name, capacity and/or building.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.ParseUint(args[0], 10, 32)
@@ -186,10 +199,14 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 			if capacity == 0 {
 				capacity = current.Capacity
 			}
+			if buildingID == 0 {
+				buildingID = current.BuildingID
+			}
 
 			req := ClassRequest{
-				Name:     name,
-				Capacity: capacity,
+				Name:       name,
+				Capacity:   capacity,
+				BuildingID: buildingID,
 			}
 
 			rawResp, err := client.Classes().Update(uint(id), req)
@@ -210,6 +227,7 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Class name")
 	cmd.Flags().IntVarP(&capacity, "capacity", "c", 0, "Class capacity")
+	cmd.Flags().UintVarP(&buildingID, "building-id", "b", 0, "Move the class to this building")
 
 	return cmd
 }
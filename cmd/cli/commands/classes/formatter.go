@@ -39,7 +39,7 @@ func OutputJSON(classes []Class) error {
 // OutputTable outputs classes in a formatted table
 func OutputTable(classes []Class) error {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Name", "Capacity", "Created", "Updated"})
+	table.SetHeader([]string{"ID", "Name", "Capacity", "Building ID", "Created", "Updated"})
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetCenterSeparator("|")
 
@@ -48,6 +48,7 @@ func OutputTable(classes []Class) error {
 			fmt.Sprintf("%d", class.ID),
 			class.Name,
 			fmt.Sprintf("%d", class.Capacity),
+			formatID(class.BuildingID),
 			formatTime(class.CreatedAt),
 			formatTime(class.UpdatedAt),
 		})
@@ -57,6 +58,14 @@ func OutputTable(classes []Class) error {
 	return nil
 }
 
+// formatID formats an optional reference for display
+func formatID(id uint) string {
+	if id == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", id)
+}
+
 // formatTime formats a time.Time for display
 func formatTime(t time.Time) string {
 	if t.IsZero() {
@@ -4,15 +4,17 @@ import "time"
 
 // ClassRequest represents a class creation/update request
 type ClassRequest struct {
-	Name     string `json:"name"`
-	Capacity int    `json:"capacity"`
+	Name       string `json:"name"`
+	Capacity   int    `json:"capacity"`
+	BuildingID uint   `json:"buildingId"`
 }
 
 // Class represents a class response
 type Class struct {
-	ID        uint      `json:"id"`
-	Name      string    `json:"name"`
-	Capacity  int       `json:"capacity"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID         uint      `json:"id"`
+	Name       string    `json:"name"`
+	Capacity   int       `json:"capacity"`
+	BuildingID uint      `json:"buildingId"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
 }
@@ -3,6 +3,7 @@ package lessons
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"sarc-ng/pkg/rest/client"
 	"strconv"
 	"time"
@@ -15,7 +16,7 @@ func NewCommand(clientFactory func() *client.Client) *cobra.Command {
 	lessonsCmd := &cobra.Command{
 		Use:   "lessons",
 		Short: "Manage lessons",
-		Long:  "Create, read, update, and delete lessons in the SARC system.",
+		Long:  "Create, read, update, delete, and import lessons in the SARC system.",
 	}
 
 	// Add subcommands
@@ -24,6 +25,7 @@ func NewCommand(clientFactory func() *client.Client) *cobra.Command {
 	lessonsCmd.AddCommand(newCreateCommand(clientFactory))
 	lessonsCmd.AddCommand(newUpdateCommand(clientFactory))
 	lessonsCmd.AddCommand(newDeleteCommand(clientFactory))
+	lessonsCmd.AddCommand(newImportCommand(clientFactory))
 
 	return lessonsCmd
 }
@@ -109,6 +111,7 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 	var title string
 	var duration int
 	var startTime string
+	var classID uint
 
 	cmd := &cobra.Command{
 		Use:   "create",
@@ -137,6 +140,9 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 				Duration:  duration,
 				StartTime: parsedTime,
 			}
+			if classID != 0 {
+				req.ClassID = &classID
+			}
 
 			rawResp, err := client.Lessons().Create(req)
 			if err != nil {
@@ -157,6 +163,7 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 	cmd.Flags().StringVarP(&title, "title", "t", "", "Lesson title (required)")
 	cmd.Flags().IntVarP(&duration, "duration", "d", 0, "Lesson duration in minutes (required)")
 	cmd.Flags().StringVarP(&startTime, "start-time", "s", "", "Start time (YYYY-MM-DD HH:MM:SS)")
+	cmd.Flags().UintVarP(&classID, "class-id", "c", 0, "Class the lesson is held in")
 	_ = cmd.MarkFlagRequired("title")
 	_ = cmd.MarkFlagRequired("duration")
 
@@ -168,6 +175,7 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 	var title string
 	var duration int
 	var startTime string
+	var classID uint
 
 	cmd := &cobra.Command{
 		Use:   "update <id>",
@@ -213,6 +221,10 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 				Title:     title,
 				Duration:  duration,
 				StartTime: parsedTime,
+				ClassID:   current.ClassID,
+			}
+			if classID != 0 {
+				req.ClassID = &classID
 			}
 
 			rawResp, err := client.Lessons().Update(uint(id), req)
@@ -234,6 +246,7 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 	cmd.Flags().StringVarP(&title, "title", "t", "", "Lesson title")
 	cmd.Flags().IntVarP(&duration, "duration", "d", 0, "Lesson duration in minutes")
 	cmd.Flags().StringVarP(&startTime, "start-time", "s", "", "Start time (YYYY-MM-DD HH:MM:SS)")
+	cmd.Flags().UintVarP(&classID, "class-id", "c", 0, "Move the lesson to this class")
 
 	return cmd
 }
@@ -289,3 +302,55 @@ func newDeleteCommand(clientFactory func() *client.Client) *cobra.Command {
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force deletion without confirmation")
 	return cmd
 }
+
+// Import lessons from an iCalendar file
+func newImportCommand(clientFactory func() *client.Client) *cobra.Command {
+	var file string
+	var dryRun bool
+	var timeZone string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import lessons from an iCalendar file",
+		Long: `Create or update lessons from the events of an .ics file, such as a term timetable.
+Recurring events become one lesson per occurrence. Importing the same file again
+updates the lessons instead of duplicating them. Use --dry-run to see what would
+be created, updated or skipped without changing anything.`,
+		Example: "  sarc lessons import --file term.ics --dry-run",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(file)
+			if err != nil {
+				return fmt.Errorf("failed to open calendar file: %w", err)
+			}
+			defer f.Close()
+
+			client := clientFactory()
+			rawResp, err := client.Lessons().Import(f, dryRun, timeZone)
+			if err != nil {
+				return fmt.Errorf("failed to import lessons: %w", err)
+			}
+
+			var report ImportReport
+			if err := json.Unmarshal(rawResp, &report); err != nil {
+				return fmt.Errorf("failed to parse response: %w", err)
+			}
+
+			if err := OutputImportWithFormat(report, OutputFormat(outputFormat)); err != nil {
+				return err
+			}
+			if report.Failed > 0 {
+				return fmt.Errorf("%d event(s) could not be imported", report.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "iCalendar file to import (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without saving")
+	cmd.Flags().StringVar(&timeZone, "time-zone", "", "IANA time zone for times without one (default UTC)")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
@@ -59,6 +59,50 @@ func OutputTable(lessons []Lesson) error {
 	return nil
 }
 
+// OutputImportWithFormat displays an import report in the specified format
+func OutputImportWithFormat(report ImportReport, format OutputFormat) error {
+	if format == JSONFormat {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return OutputImportTable(report)
+}
+
+// OutputImportTable outputs an import report as a table followed by a summary
+func OutputImportTable(report ImportReport) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Action", "External ID", "Title", "Start Time", "Lesson ID", "Reason"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+
+	for _, item := range report.Items {
+		lessonID := "-"
+		if item.LessonID != 0 {
+			lessonID = fmt.Sprintf("%d", item.LessonID)
+		}
+		table.Append([]string{
+			item.Action,
+			item.ExternalID,
+			item.Title,
+			formatTime(item.StartTime),
+			lessonID,
+			item.Reason,
+		})
+	}
+
+	table.Render()
+
+	if report.DryRun {
+		fmt.Printf("Dry run, nothing saved: %d would be created, %d updated, %d skipped, %d failed\n",
+			report.Created, report.Updated, report.Skipped, report.Failed)
+	} else {
+		fmt.Printf("%d created, %d updated, %d skipped, %d failed\n",
+			report.Created, report.Updated, report.Skipped, report.Failed)
+	}
+	return nil
+}
+
 // formatTime formats a time.Time for display
 func formatTime(t time.Time) string {
 	if t.IsZero() {
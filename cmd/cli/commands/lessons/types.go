@@ -7,6 +7,7 @@ type LessonRequest struct {
 	Title     string    `json:"title"`
 	Duration  int       `json:"duration"`
 	StartTime time.Time `json:"startTime,omitempty"`
+	ClassID   *uint     `json:"classId,omitempty"`
 }
 
 // Lesson represents a lesson response
@@ -16,6 +17,27 @@ type Lesson struct {
 	Duration  int       `json:"duration"`
 	StartTime time.Time `json:"startTime"`
 	EndTime   time.Time `json:"endTime"`
+	ClassID   *uint     `json:"classId,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+// ImportItem reports what happened to one imported event occurrence
+type ImportItem struct {
+	ExternalID string    `json:"externalId"`
+	Title      string    `json:"title"`
+	StartTime  time.Time `json:"startTime"`
+	Action     string    `json:"action"`
+	LessonID   uint      `json:"lessonId,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+}
+
+// ImportReport represents a lesson import response
+type ImportReport struct {
+	DryRun  bool         `json:"dryRun"`
+	Created int          `json:"created"`
+	Updated int          `json:"updated"`
+	Skipped int          `json:"skipped"`
+	Failed  int          `json:"failed"`
+	Items   []ImportItem `json:"items"`
+}
@@ -3,6 +3,7 @@ package reservations
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"sarc-ng/pkg/rest/client"
 	"strconv"
 	"time"
@@ -30,34 +31,66 @@ func NewCommand(clientFactory func() *client.Client) *cobra.Command {
 
 // List all reservations
 func newListCommand(clientFactory func() *client.Client) *cobra.Command {
-	var outputFormat string
+	var outputFormat, status, from, to string
+	var resourceID uint
+	var mine bool
+	var page, pageSize int
 
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "List all reservations",
-		Long:  "Retrieve and display all reservations in the system.",
+		Short: "List reservations",
+		Long:  "Retrieve and display your reservations, or every reservation in the system for managers, optionally filtered by resource, status and time window.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			filters := url.Values{}
+			if resourceID != 0 {
+				filters.Set("resourceId", strconv.FormatUint(uint64(resourceID), 10))
+			}
+			if status != "" {
+				filters.Set("status", status)
+			}
+			for name, value := range map[string]string{"from": from, "to": to} {
+				if value == "" {
+					continue
+				}
+				if _, err := time.Parse(time.RFC3339, value); err != nil {
+					return fmt.Errorf("invalid %s time format: %w", name, err)
+				}
+				filters.Set(name, value)
+			}
+			if mine {
+				filters.Set("mine", "true")
+			}
+
 			client := clientFactory()
-			data, err := client.Reservations().List(1, 100) // Default pagination
+			data, err := client.Reservations().ListFiltered(page, pageSize, filters)
 			if err != nil {
 				return fmt.Errorf("failed to list reservations: %w", err)
 			}
 
-			var reservations []Reservation
-			if err := json.Unmarshal(data, &reservations); err != nil {
+			var response struct {
+				Data []Reservation `json:"data"`
+			}
+			if err := json.Unmarshal(data, &response); err != nil {
 				return fmt.Errorf("failed to parse reservations: %w", err)
 			}
 
-			if len(reservations) == 0 {
+			if len(response.Data) == 0 {
 				fmt.Println("No reservations found.")
 				return nil
 			}
 
-			return OutputWithFormat(reservations, OutputFormat(outputFormat))
+			return OutputWithFormat(response.Data, OutputFormat(outputFormat))
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	cmd.Flags().UintVarP(&resourceID, "resource-id", "r", 0, "Only list reservations of this resource")
+	cmd.Flags().StringVar(&status, "status", "", "Only list reservations with this status (pending, approved, rejected, cancelled)")
+	cmd.Flags().StringVar(&from, "from", "", "Only list reservations ending at or after this time (ISO format)")
+	cmd.Flags().StringVar(&to, "to", "", "Only list reservations starting at or before this time (ISO format)")
+	cmd.Flags().BoolVar(&mine, "mine", false, "Only list your own reservations (managers see everyone's by default)")
+	cmd.Flags().IntVar(&page, "page", 1, "Page number")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "Reservations per page")
 	return cmd
 }
 
@@ -97,20 +130,17 @@ func newGetCommand(clientFactory func() *client.Client) *cobra.Command {
 
 // Create a new reservation
 func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
-	var resourceID, userID uint
+	var resourceID uint
 	var startTime, endTime string
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new reservation",
-		Long:  "Create a new reservation for a resource.",
+		Long:  "Create a new reservation for a resource. The reservation is booked for the logged-in user.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if resourceID == 0 {
 				return fmt.Errorf("resource ID is required")
 			}
-			if userID == 0 {
-				return fmt.Errorf("user ID is required")
-			}
 			if startTime == "" {
 				return fmt.Errorf("start time is required")
 			}
@@ -131,7 +161,6 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 			client := clientFactory()
 			req := ReservationRequest{
 				ResourceID: resourceID,
-				UserID:     userID,
 				StartTime:  start,
 				EndTime:    end,
 			}
@@ -152,11 +181,9 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 	}
 
 	cmd.Flags().UintVarP(&resourceID, "resource-id", "r", 0, "Resource ID (required)")
-	cmd.Flags().UintVarP(&userID, "user-id", "u", 0, "User ID (required)")
 	cmd.Flags().StringVarP(&startTime, "start-time", "s", "", "Start time (ISO format, required)")
 	cmd.Flags().StringVarP(&endTime, "end-time", "e", "", "End time (ISO format, required)")
 	_ = cmd.MarkFlagRequired("resource-id")
-	_ = cmd.MarkFlagRequired("user-id")
 	_ = cmd.MarkFlagRequired("start-time")
 	_ = cmd.MarkFlagRequired("end-time")
 
@@ -165,7 +192,7 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 
 // Update an existing reservation
 func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
-	var resourceID, userID uint
+	var resourceID uint
 	var startTime, endTime string
 
 	cmd := &cobra.Command{
@@ -196,9 +223,6 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 			if resourceID == 0 {
 				resourceID = current.ResourceID
 			}
-			if userID == 0 {
-				userID = current.UserID
-			}
 
 			var start, end time.Time
 			if startTime == "" {
@@ -220,7 +244,6 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 
 			req := ReservationRequest{
 				ResourceID: resourceID,
-				UserID:     userID,
 				StartTime:  start,
 				EndTime:    end,
 			}
@@ -241,7 +264,6 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 	}
 
 	cmd.Flags().UintVarP(&resourceID, "resource-id", "r", 0, "Resource ID")
-	cmd.Flags().UintVarP(&userID, "user-id", "u", 0, "User ID")
 	cmd.Flags().StringVarP(&startTime, "start-time", "s", "", "Start time (ISO format)")
 	cmd.Flags().StringVarP(&endTime, "end-time", "e", "", "End time (ISO format)")
 
@@ -47,7 +47,7 @@ func OutputTable(reservations []Reservation) error {
 		table.Append([]string{
 			fmt.Sprintf("%d", reservation.ID),
 			fmt.Sprintf("%d", reservation.ResourceID),
-			fmt.Sprintf("%d", reservation.UserID),
+			reservation.UserID,
 			formatTime(reservation.StartTime),
 			formatTime(reservation.EndTime),
 			reservation.Status,
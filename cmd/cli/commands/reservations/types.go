@@ -5,7 +5,6 @@ import "time"
 // ReservationRequest represents a reservation creation/update request
 type ReservationRequest struct {
 	ResourceID uint      `json:"resourceId"`
-	UserID     uint      `json:"userId"`
 	StartTime  time.Time `json:"startTime"`
 	EndTime    time.Time `json:"endTime"`
 	Status     string    `json:"status,omitempty"`
@@ -15,7 +14,7 @@ type ReservationRequest struct {
 type Reservation struct {
 	ID         uint      `json:"id"`
 	ResourceID uint      `json:"resourceId"`
-	UserID     uint      `json:"userId"`
+	UserID     string    `json:"userId"`
 	StartTime  time.Time `json:"startTime"`
 	EndTime    time.Time `json:"endTime"`
 	Status     string    `json:"status"`
@@ -3,8 +3,10 @@ package resources
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"sarc-ng/pkg/rest/client"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -23,40 +25,65 @@ func NewCommand(clientFactory func() *client.Client) *cobra.Command {
 	resourcesCmd.AddCommand(newCreateCommand(clientFactory))
 	resourcesCmd.AddCommand(newUpdateCommand(clientFactory))
 	resourcesCmd.AddCommand(newDeleteCommand(clientFactory))
+	resourcesCmd.AddCommand(newFreeSlotsCommand(clientFactory))
 
 	return resourcesCmd
 }
 
 // List all resources
 func newListCommand(clientFactory func() *client.Client) *cobra.Command {
-	var outputFormat string
+	var outputFormat, resourceType, available string
+	var buildingID, classID uint
+	var page, pageSize int
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all resources",
-		Long:  "Retrieve and display all resources in the system.",
+		Long:  "Retrieve and display resources, optionally filtered by type, availability, building or class.",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			filters := url.Values{}
+			if resourceType != "" {
+				filters.Set("type", resourceType)
+			}
+			if available != "" {
+				filters.Set("isAvailable", available)
+			}
+			if buildingID != 0 {
+				filters.Set("buildingId", strconv.FormatUint(uint64(buildingID), 10))
+			}
+			if classID != 0 {
+				filters.Set("classId", strconv.FormatUint(uint64(classID), 10))
+			}
+
 			client := clientFactory()
-			data, err := client.Resources().List(1, 100)
+			data, err := client.Resources().ListFiltered(page, pageSize, filters)
 			if err != nil {
 				return fmt.Errorf("failed to list resources: %w", err)
 			}
 
-			var resources []Resource
-			if err := json.Unmarshal(data, &resources); err != nil {
+			var response struct {
+				Data []Resource `json:"data"`
+			}
+			if err := json.Unmarshal(data, &response); err != nil {
 				return fmt.Errorf("failed to parse resources: %w", err)
 			}
 
-			if len(resources) == 0 {
+			if len(response.Data) == 0 {
 				fmt.Println("No resources found.")
 				return nil
 			}
 
-			return OutputWithFormat(resources, OutputFormat(outputFormat))
+			return OutputWithFormat(response.Data, OutputFormat(outputFormat))
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	cmd.Flags().StringVarP(&resourceType, "type", "t", "", "Only list resources of this type")
+	cmd.Flags().StringVar(&available, "available", "", "Only list available (true) or unavailable (false) resources")
+	cmd.Flags().UintVarP(&buildingID, "building-id", "b", 0, "Only list the resources kept in this building")
+	cmd.Flags().UintVarP(&classID, "class-id", "c", 0, "Only list the resources kept in this class")
+	cmd.Flags().IntVar(&page, "page", 1, "Page number")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "Resources per page")
 	return cmd
 }
 
@@ -97,6 +124,7 @@ func newGetCommand(clientFactory func() *client.Client) *cobra.Command {
 // Create a new resource
 func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 	var name, resourceType string
+	var buildingID, classID uint
 
 	cmd := &cobra.Command{
 		Use:   "create",
@@ -112,8 +140,10 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 
 			client := clientFactory()
 			req := ResourceRequest{
-				Name: name,
-				Type: resourceType,
+				Name:       name,
+				Type:       resourceType,
+				BuildingID: optionalID(buildingID),
+				ClassID:    optionalID(classID),
 			}
 
 			data, err := client.Resources().Create(req)
@@ -133,6 +163,8 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Resource name (required)")
 	cmd.Flags().StringVarP(&resourceType, "type", "t", "", "Resource type (required)")
+	cmd.Flags().UintVarP(&buildingID, "building-id", "b", 0, "Building the resource is kept in")
+	cmd.Flags().UintVarP(&classID, "class-id", "c", 0, "Class the resource is kept in")
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("type")
 
@@ -142,6 +174,7 @@ func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
 // Update an existing resource
 func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 	var name, resourceType string
+	var buildingID, classID uint
 
 	cmd := &cobra.Command{
 		Use:   "update <id>",
@@ -176,8 +209,21 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 			}
 
 			req := ResourceRequest{
-				Name: name,
-				Type: resourceType,
+				Name:       name,
+				Type:       resourceType,
+				BuildingID: current.BuildingID,
+				ClassID:    current.ClassID,
+			}
+			if classID != 0 {
+				// The building follows from the new class
+				req.ClassID = optionalID(classID)
+				req.BuildingID = nil
+			}
+			if buildingID != 0 {
+				req.BuildingID = optionalID(buildingID)
+				if classID == 0 {
+					req.ClassID = nil
+				}
 			}
 
 			updateData, err := client.Resources().Update(uint(id), req)
@@ -197,6 +243,8 @@ func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
 
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Resource name")
 	cmd.Flags().StringVarP(&resourceType, "type", "t", "", "Resource type")
+	cmd.Flags().UintVarP(&buildingID, "building-id", "b", 0, "Move the resource to this building")
+	cmd.Flags().UintVarP(&classID, "class-id", "c", 0, "Move the resource to this class")
 
 	return cmd
 }
@@ -252,3 +300,103 @@ func newDeleteCommand(clientFactory func() *client.Client) *cobra.Command {
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
 	return cmd
 }
+
+// Find free slots of one resource or across resources
+func newFreeSlotsCommand(clientFactory func() *client.Client) *cobra.Command {
+	var outputFormat, from, to, resourceType string
+	var duration time.Duration
+	var buildingID, classID uint
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "free-slots [id]",
+		Short: "Find free time slots",
+		Long: `Find open windows of at least the given duration in which a resource has no
+active reservation, no lesson in its class and is within opening hours. Without
+an ID every available resource matching the filters is searched, e.g.
+"sarc resources free-slots --type projector --duration 2h".`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start := time.Now()
+			if from != "" {
+				var err error
+				if start, err = time.Parse(time.RFC3339, from); err != nil {
+					return fmt.Errorf("invalid from time format, use ISO format: %w", err)
+				}
+			}
+			end := start.Add(24 * time.Hour)
+			if to != "" {
+				var err error
+				if end, err = time.Parse(time.RFC3339, to); err != nil {
+					return fmt.Errorf("invalid to time format, use ISO format: %w", err)
+				}
+			}
+
+			params := url.Values{}
+			params.Set("from", start.Format(time.RFC3339))
+			params.Set("to", end.Format(time.RFC3339))
+			params.Set("duration", duration.String())
+			if limit != 0 {
+				params.Set("limit", strconv.Itoa(limit))
+			}
+
+			client := clientFactory()
+			var data []byte
+			if len(args) == 1 {
+				id, err := strconv.ParseUint(args[0], 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid resource ID: %s", args[0])
+				}
+				data, err = client.Resources().FreeSlots(uint(id), params)
+				if err != nil {
+					return fmt.Errorf("failed to find free slots: %w", err)
+				}
+			} else {
+				if resourceType != "" {
+					params.Set("type", resourceType)
+				}
+				if buildingID != 0 {
+					params.Set("buildingId", strconv.FormatUint(uint64(buildingID), 10))
+				}
+				if classID != 0 {
+					params.Set("classId", strconv.FormatUint(uint64(classID), 10))
+				}
+				var err error
+				data, err = client.Resources().FindFreeSlots(params)
+				if err != nil {
+					return fmt.Errorf("failed to find free slots: %w", err)
+				}
+			}
+
+			var slots []Slot
+			if err := json.Unmarshal(data, &slots); err != nil {
+				return fmt.Errorf("failed to parse free slots: %w", err)
+			}
+
+			if len(slots) == 0 {
+				fmt.Println("No free slots found.")
+				return nil
+			}
+
+			return OutputSlotsWithFormat(slots, OutputFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	cmd.Flags().StringVar(&from, "from", "", "Start of the search (ISO format, default now)")
+	cmd.Flags().StringVar(&to, "to", "", "End of the search (ISO format, default 24h after --from)")
+	cmd.Flags().DurationVarP(&duration, "duration", "d", time.Hour, "Minimum length of a slot, e.g. 90m or 2h")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of slots (server default 20)")
+	cmd.Flags().StringVarP(&resourceType, "type", "t", "", "Only search resources of this type")
+	cmd.Flags().UintVarP(&buildingID, "building-id", "b", 0, "Only search the resources kept in this building")
+	cmd.Flags().UintVarP(&classID, "class-id", "c", 0, "Only search the resources kept in this class")
+	return cmd
+}
+
+// optionalID converts an unset (zero) ID flag to nil
+func optionalID(id uint) *uint {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
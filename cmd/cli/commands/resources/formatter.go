@@ -39,7 +39,7 @@ func OutputJSON(resources []Resource) error {
 // OutputTable outputs resources in a formatted table
 func OutputTable(resources []Resource) error {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"ID", "Name", "Type", "Available", "Created", "Updated"})
+	table.SetHeader([]string{"ID", "Name", "Type", "Available", "Building ID", "Class ID", "Created", "Updated"})
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetCenterSeparator("|")
 
@@ -54,6 +54,8 @@ func OutputTable(resources []Resource) error {
 			resource.Name,
 			resource.Type,
 			available,
+			formatID(resource.BuildingID),
+			formatID(resource.ClassID),
 			formatTime(resource.CreatedAt),
 			formatTime(resource.UpdatedAt),
 		})
@@ -63,6 +65,47 @@ func OutputTable(resources []Resource) error {
 	return nil
 }
 
+// OutputSlotsWithFormat displays free slots in the specified format
+func OutputSlotsWithFormat(slots []Slot, format OutputFormat) error {
+	switch format {
+	case JSONFormat:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(slots)
+	default:
+		return OutputSlotsTable(slots)
+	}
+}
+
+// OutputSlotsTable outputs free slots in a formatted table
+func OutputSlotsTable(slots []Slot) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Resource ID", "Resource", "Start", "End", "Minutes"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+
+	for _, slot := range slots {
+		table.Append([]string{
+			fmt.Sprintf("%d", slot.ResourceID),
+			slot.ResourceName,
+			formatTime(slot.StartTime),
+			formatTime(slot.EndTime),
+			fmt.Sprintf("%d", slot.DurationMinutes),
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+// formatID formats an optional reference for display
+func formatID(id *uint) string {
+	if id == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *id)
+}
+
 // formatTime formats a time.Time for display
 func formatTime(t time.Time) string {
 	if t.IsZero() {
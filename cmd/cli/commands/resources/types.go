@@ -7,6 +7,8 @@ type ResourceRequest struct {
 	Name        string `json:"name"`
 	Type        string `json:"type"`
 	IsAvailable bool   `json:"isAvailable"`
+	BuildingID  *uint  `json:"buildingId,omitempty"`
+	ClassID     *uint  `json:"classId,omitempty"`
 }
 
 // Resource represents a resource response
@@ -15,6 +17,17 @@ type Resource struct {
 	Name        string    `json:"name"`
 	Type        string    `json:"type"`
 	IsAvailable bool      `json:"isAvailable"`
+	BuildingID  *uint     `json:"buildingId,omitempty"`
+	ClassID     *uint     `json:"classId,omitempty"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
+
+// Slot represents a free slot response
+type Slot struct {
+	ResourceID      uint      `json:"resourceId"`
+	ResourceName    string    `json:"resourceName"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	DurationMinutes int       `json:"durationMinutes"`
+}
@@ -0,0 +1,207 @@
+package waitlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sarc-ng/pkg/rest/client"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the waitlist command group
+func NewCommand(clientFactory func() *client.Client) *cobra.Command {
+	waitlistCmd := &cobra.Command{
+		Use:   "waitlist",
+		Short: "Manage waitlist entries",
+		Long:  "Wait for fully booked resources. When a conflicting reservation is cancelled or rejected, the oldest waiting entry that fits becomes a pending reservation.",
+	}
+
+	// Add subcommands
+	waitlistCmd.AddCommand(newListCommand(clientFactory))
+	waitlistCmd.AddCommand(newGetCommand(clientFactory))
+	waitlistCmd.AddCommand(newJoinCommand(clientFactory))
+	waitlistCmd.AddCommand(newLeaveCommand(clientFactory))
+
+	return waitlistCmd
+}
+
+// List waitlist entries
+func newListCommand(clientFactory func() *client.Client) *cobra.Command {
+	var outputFormat, status string
+	var resourceID uint
+	var mine bool
+	var page, pageSize int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List waitlist entries",
+		Long:  "Retrieve and display your waitlist entries, or every entry for managers, optionally filtered by resource and status.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filters := url.Values{}
+			if resourceID != 0 {
+				filters.Set("resourceId", strconv.FormatUint(uint64(resourceID), 10))
+			}
+			if status != "" {
+				filters.Set("status", status)
+			}
+			if mine {
+				filters.Set("mine", "true")
+			}
+
+			client := clientFactory()
+			data, err := client.Waitlist().List(page, pageSize, filters)
+			if err != nil {
+				return fmt.Errorf("failed to list waitlist entries: %w", err)
+			}
+
+			var response struct {
+				Data []Entry `json:"data"`
+			}
+			if err := json.Unmarshal(data, &response); err != nil {
+				return fmt.Errorf("failed to parse waitlist entries: %w", err)
+			}
+
+			if len(response.Data) == 0 {
+				fmt.Println("No waitlist entries found.")
+				return nil
+			}
+
+			return OutputWithFormat(response.Data, OutputFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	cmd.Flags().UintVarP(&resourceID, "resource-id", "r", 0, "Only list entries for this resource")
+	cmd.Flags().StringVar(&status, "status", "", "Only list entries with this status (waiting, promoted, expired, cancelled)")
+	cmd.Flags().BoolVar(&mine, "mine", false, "Only list your own entries (managers see everyone's by default)")
+	cmd.Flags().IntVar(&page, "page", 1, "Page number")
+	cmd.Flags().IntVar(&pageSize, "page-size", 100, "Entries per page")
+	return cmd
+}
+
+// Get a specific waitlist entry
+func newGetCommand(clientFactory func() *client.Client) *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "get <id>",
+		Short: "Get a waitlist entry by ID",
+		Long:  "Retrieve and display details for a specific waitlist entry.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid waitlist entry ID: %s", args[0])
+			}
+
+			client := clientFactory()
+			data, err := client.Waitlist().Get(uint(id))
+			if err != nil {
+				return fmt.Errorf("failed to get waitlist entry: %w", err)
+			}
+
+			var entry Entry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return fmt.Errorf("failed to parse waitlist entry: %w", err)
+			}
+
+			return OutputWithFormat([]Entry{entry}, OutputFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+// Join the waitlist for a taken time window
+func newJoinCommand(clientFactory func() *client.Client) *cobra.Command {
+	var resourceID uint
+	var startTime, endTime, expiresAt, purpose, description string
+
+	cmd := &cobra.Command{
+		Use:   "join",
+		Short: "Join the waitlist for a resource",
+		Long:  "Wait for a time window of a resource that is already booked. The entry expires at its start time unless --expires-at is given.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, err := time.Parse(time.RFC3339, startTime)
+			if err != nil {
+				return fmt.Errorf("invalid start time format: %w", err)
+			}
+			end, err := time.Parse(time.RFC3339, endTime)
+			if err != nil {
+				return fmt.Errorf("invalid end time format: %w", err)
+			}
+
+			req := EntryRequest{
+				ResourceID:  resourceID,
+				StartTime:   start,
+				EndTime:     end,
+				Purpose:     purpose,
+				Description: description,
+			}
+			if expiresAt != "" {
+				expiry, err := time.Parse(time.RFC3339, expiresAt)
+				if err != nil {
+					return fmt.Errorf("invalid expiry time format: %w", err)
+				}
+				req.ExpiresAt = &expiry
+			}
+
+			client := clientFactory()
+			data, err := client.Waitlist().Join(req)
+			if err != nil {
+				return fmt.Errorf("failed to join waitlist: %w", err)
+			}
+
+			var entry Entry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return fmt.Errorf("failed to parse waitlist entry: %w", err)
+			}
+
+			fmt.Printf("✅ Joined the waitlist:\n")
+			return OutputTable([]Entry{entry})
+		},
+	}
+
+	cmd.Flags().UintVarP(&resourceID, "resource-id", "r", 0, "Resource ID (required)")
+	cmd.Flags().StringVarP(&startTime, "start-time", "s", "", "Start time (ISO format, required)")
+	cmd.Flags().StringVarP(&endTime, "end-time", "e", "", "End time (ISO format, required)")
+	cmd.Flags().StringVarP(&purpose, "purpose", "p", "", "Purpose of the booking (required)")
+	cmd.Flags().StringVar(&description, "description", "", "Description of the booking")
+	cmd.Flags().StringVar(&expiresAt, "expires-at", "", "Stop waiting after this time (ISO format, defaults to the start time)")
+	_ = cmd.MarkFlagRequired("resource-id")
+	_ = cmd.MarkFlagRequired("start-time")
+	_ = cmd.MarkFlagRequired("end-time")
+	_ = cmd.MarkFlagRequired("purpose")
+
+	return cmd
+}
+
+// Leave the waitlist
+func newLeaveCommand(clientFactory func() *client.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "leave <id>",
+		Short: "Leave the waitlist",
+		Long:  "Cancel a waiting entry by ID.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid waitlist entry ID: %s", args[0])
+			}
+
+			client := clientFactory()
+			if err := client.Waitlist().Leave(uint(id)); err != nil {
+				return fmt.Errorf("failed to leave waitlist: %w", err)
+			}
+
+			fmt.Printf("✅ Waitlist entry %d cancelled.\n", id)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+package waitlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// OutputFormat represents the output format for displaying data
+type OutputFormat string
+
+const (
+	// TableFormat displays data in a table
+	TableFormat OutputFormat = "table"
+	// JSONFormat displays data as JSON
+	JSONFormat OutputFormat = "json"
+)
+
+// OutputWithFormat displays waitlist entries in the specified format
+func OutputWithFormat(entries []Entry, format OutputFormat) error {
+	switch format {
+	case JSONFormat:
+		return OutputJSON(entries)
+	default:
+		return OutputTable(entries)
+	}
+}
+
+// OutputJSON outputs waitlist entries as JSON
+func OutputJSON(entries []Entry) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+// OutputTable outputs waitlist entries in a formatted table
+func OutputTable(entries []Entry) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Resource ID", "User ID", "Start Time", "End Time", "Status", "Expires", "Reservation ID"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+
+	for _, entry := range entries {
+		reservationID := "-"
+		if entry.ReservationID != nil {
+			reservationID = fmt.Sprintf("%d", *entry.ReservationID)
+		}
+		table.Append([]string{
+			fmt.Sprintf("%d", entry.ID),
+			fmt.Sprintf("%d", entry.ResourceID),
+			entry.UserID,
+			formatTime(entry.StartTime),
+			formatTime(entry.EndTime),
+			entry.Status,
+			formatTime(entry.ExpiresAt),
+			reservationID,
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+// formatTime formats a time.Time for display
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
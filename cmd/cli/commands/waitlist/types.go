@@ -0,0 +1,27 @@
+package waitlist
+
+import "time"
+
+// EntryRequest represents a request to join the waitlist
+type EntryRequest struct {
+	ResourceID  uint       `json:"resourceId"`
+	StartTime   time.Time  `json:"startTime"`
+	EndTime     time.Time  `json:"endTime"`
+	Purpose     string     `json:"purpose"`
+	Description string     `json:"description,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// Entry represents a waitlist entry response
+type Entry struct {
+	ID            uint      `json:"id"`
+	ResourceID    uint      `json:"resourceId"`
+	UserID        string    `json:"userId"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+	Purpose       string    `json:"purpose"`
+	Status        string    `json:"status"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	ReservationID *uint     `json:"reservationId,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
@@ -0,0 +1,354 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sarc-ng/pkg/rest/client"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the webhooks command group
+func NewCommand(clientFactory func() *client.Client) *cobra.Command {
+	webhooksCmd := &cobra.Command{
+		Use:   "webhooks",
+		Short: "Manage webhook subscriptions",
+		Long:  "Subscribe URLs to domain events and inspect the delivery log. Events are posted as signed JSON and failed posts are retried with exponential backoff. Admins only.",
+	}
+
+	// Add subcommands
+	webhooksCmd.AddCommand(newListCommand(clientFactory))
+	webhooksCmd.AddCommand(newGetCommand(clientFactory))
+	webhooksCmd.AddCommand(newCreateCommand(clientFactory))
+	webhooksCmd.AddCommand(newUpdateCommand(clientFactory))
+	webhooksCmd.AddCommand(newDeleteCommand(clientFactory))
+	webhooksCmd.AddCommand(newRotateSecretCommand(clientFactory))
+	webhooksCmd.AddCommand(newDeliveriesCommand(clientFactory))
+
+	return webhooksCmd
+}
+
+// List webhooks
+func newListCommand(clientFactory func() *client.Client) *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List webhooks",
+		Long:  "Retrieve and display every webhook subscription.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client := clientFactory()
+			data, err := client.Webhooks().List()
+			if err != nil {
+				return fmt.Errorf("failed to list webhooks: %w", err)
+			}
+
+			var webhooks []Webhook
+			if err := json.Unmarshal(data, &webhooks); err != nil {
+				return fmt.Errorf("failed to parse webhooks: %w", err)
+			}
+
+			if len(webhooks) == 0 {
+				fmt.Println("No webhooks found.")
+				return nil
+			}
+
+			return OutputWithFormat(webhooks, OutputFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+// Get a specific webhook
+func newGetCommand(clientFactory func() *client.Client) *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "get <id>",
+		Short: "Get a webhook by ID",
+		Long:  "Retrieve and display details for a specific webhook subscription.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client := clientFactory()
+			webhook, err := getWebhook(client, id)
+			if err != nil {
+				return err
+			}
+
+			return OutputWithFormat([]Webhook{*webhook}, OutputFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+// Create a new webhook
+func newCreateCommand(clientFactory func() *client.Client) *cobra.Command {
+	var webhookURL, description string
+	var eventTypes []string
+	var inactive bool
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a webhook",
+		Long:  "Subscribe a URL to domain events, optionally only to the given event types. The signing secret is shown once.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			active := !inactive
+			req := WebhookRequest{
+				URL:         webhookURL,
+				Description: description,
+				EventTypes:  eventTypes,
+				Active:      &active,
+			}
+
+			client := clientFactory()
+			data, err := client.Webhooks().Create(req)
+			if err != nil {
+				return fmt.Errorf("failed to create webhook: %w", err)
+			}
+
+			var webhook Webhook
+			if err := json.Unmarshal(data, &webhook); err != nil {
+				return fmt.Errorf("failed to parse webhook: %w", err)
+			}
+
+			fmt.Printf("✅ Webhook created successfully:\n")
+			if err := OutputTable([]Webhook{webhook}); err != nil {
+				return err
+			}
+			printSecret(webhook.Secret)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&webhookURL, "url", "u", "", "URL events are posted to (required)")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Description of the webhook")
+	cmd.Flags().StringSliceVarP(&eventTypes, "event", "e", nil, "Event type to post, repeatable (defaults to every event)")
+	cmd.Flags().BoolVar(&inactive, "inactive", false, "Create the webhook without posting events to it yet")
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+// Update an existing webhook
+func newUpdateCommand(clientFactory func() *client.Client) *cobra.Command {
+	var webhookURL, description string
+	var eventTypes []string
+	var active bool
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Update a webhook",
+		Long:  "Change the URL, description, event types or state of a webhook. Flags that are not given keep their current value.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client := clientFactory()
+			current, err := getWebhook(client, id)
+			if err != nil {
+				return err
+			}
+
+			req := WebhookRequest{
+				URL:         current.URL,
+				Description: current.Description,
+				EventTypes:  current.EventTypes,
+				Active:      &current.Active,
+			}
+			if cmd.Flags().Changed("url") {
+				req.URL = webhookURL
+			}
+			if cmd.Flags().Changed("description") {
+				req.Description = description
+			}
+			if cmd.Flags().Changed("event") {
+				req.EventTypes = eventTypes
+			}
+			if cmd.Flags().Changed("active") {
+				req.Active = &active
+			}
+
+			data, err := client.Webhooks().Update(id, req)
+			if err != nil {
+				return fmt.Errorf("failed to update webhook: %w", err)
+			}
+
+			var webhook Webhook
+			if err := json.Unmarshal(data, &webhook); err != nil {
+				return fmt.Errorf("failed to parse webhook: %w", err)
+			}
+
+			fmt.Printf("✅ Webhook updated successfully:\n")
+			return OutputTable([]Webhook{webhook})
+		},
+	}
+
+	cmd.Flags().StringVarP(&webhookURL, "url", "u", "", "URL events are posted to")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Description of the webhook")
+	cmd.Flags().StringSliceVarP(&eventTypes, "event", "e", nil, "Event type to post, repeatable; pass --event= to post every event")
+	cmd.Flags().BoolVar(&active, "active", true, "Whether events are posted to the webhook")
+
+	return cmd
+}
+
+// Delete a webhook
+func newDeleteCommand(clientFactory func() *client.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a webhook",
+		Long:  "Delete a webhook subscription by ID together with its delivery log.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client := clientFactory()
+			if err := client.Webhooks().Delete(id); err != nil {
+				return fmt.Errorf("failed to delete webhook: %w", err)
+			}
+
+			fmt.Printf("✅ Webhook %d deleted successfully.\n", id)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// Rotate the secret of a webhook
+func newRotateSecretCommand(clientFactory func() *client.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-secret <id>",
+		Short: "Rotate a webhook secret",
+		Long:  "Generate a new signing secret for a webhook. The old secret stops working immediately.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			client := clientFactory()
+			data, err := client.Webhooks().RotateSecret(id)
+			if err != nil {
+				return fmt.Errorf("failed to rotate webhook secret: %w", err)
+			}
+
+			var webhook Webhook
+			if err := json.Unmarshal(data, &webhook); err != nil {
+				return fmt.Errorf("failed to parse webhook: %w", err)
+			}
+
+			fmt.Printf("✅ Secret of webhook %d rotated.\n", id)
+			printSecret(webhook.Secret)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// List the delivery log of a webhook
+func newDeliveriesCommand(clientFactory func() *client.Client) *cobra.Command {
+	var outputFormat, status, eventType string
+	var eventID uint
+	var page, pageSize int
+
+	cmd := &cobra.Command{
+		Use:   "deliveries <id>",
+		Short: "List webhook deliveries",
+		Long:  "Retrieve and display the attempts to post events to a webhook, latest first, optionally filtered by event and outcome.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			filters := url.Values{}
+			filters.Set("sort", "id")
+			filters.Set("order", "desc")
+			if status != "" {
+				filters.Set("status", status)
+			}
+			if eventType != "" {
+				filters.Set("eventType", eventType)
+			}
+			if eventID != 0 {
+				filters.Set("eventId", strconv.FormatUint(uint64(eventID), 10))
+			}
+
+			client := clientFactory()
+			data, err := client.Webhooks().Deliveries(id, page, pageSize, filters)
+			if err != nil {
+				return fmt.Errorf("failed to list webhook deliveries: %w", err)
+			}
+
+			var response struct {
+				Data []Delivery `json:"data"`
+			}
+			if err := json.Unmarshal(data, &response); err != nil {
+				return fmt.Errorf("failed to parse webhook deliveries: %w", err)
+			}
+
+			if len(response.Data) == 0 {
+				fmt.Println("No deliveries found.")
+				return nil
+			}
+
+			return OutputDeliveriesWithFormat(response.Data, OutputFormat(outputFormat))
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	cmd.Flags().StringVar(&status, "status", "", "Only list attempts with this outcome (succeeded, failed)")
+	cmd.Flags().StringVarP(&eventType, "event", "e", "", "Only list attempts for this event type")
+	cmd.Flags().UintVar(&eventID, "event-id", 0, "Only list attempts for this event")
+	cmd.Flags().IntVar(&page, "page", 1, "Page number")
+	cmd.Flags().IntVar(&pageSize, "page-size", 20, "Deliveries per page")
+	return cmd
+}
+
+// getWebhook retrieves and parses a webhook
+func getWebhook(client *client.Client, id uint) (*Webhook, error) {
+	data, err := client.Webhooks().Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	var webhook Webhook
+	if err := json.Unmarshal(data, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to parse webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// parseID parses a webhook ID argument
+func parseID(arg string) (uint, error) {
+	id, err := strconv.ParseUint(arg, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook ID: %s", arg)
+	}
+	return uint(id), nil
+}
+
+// printSecret shows a newly generated signing secret
+func printSecret(secret string) {
+	fmt.Printf("\nSigning secret (shown only once, store it now):\n  %s\n", secret)
+}
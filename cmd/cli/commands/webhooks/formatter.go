@@ -0,0 +1,111 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// OutputFormat represents the output format for displaying data
+type OutputFormat string
+
+const (
+	// TableFormat displays data in a table
+	TableFormat OutputFormat = "table"
+	// JSONFormat displays data as JSON
+	JSONFormat OutputFormat = "json"
+)
+
+// OutputWithFormat displays webhooks in the specified format
+func OutputWithFormat(webhooks []Webhook, format OutputFormat) error {
+	switch format {
+	case JSONFormat:
+		return OutputJSON(webhooks)
+	default:
+		return OutputTable(webhooks)
+	}
+}
+
+// OutputDeliveriesWithFormat displays delivery attempts in the specified format
+func OutputDeliveriesWithFormat(deliveries []Delivery, format OutputFormat) error {
+	switch format {
+	case JSONFormat:
+		return OutputJSON(deliveries)
+	default:
+		return OutputDeliveriesTable(deliveries)
+	}
+}
+
+// OutputJSON outputs data as JSON
+func OutputJSON(data interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// OutputTable outputs webhooks in a formatted table
+func OutputTable(webhooks []Webhook) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "URL", "Event Types", "Active", "Description", "Created"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+
+	for _, webhook := range webhooks {
+		types := "all"
+		if len(webhook.EventTypes) > 0 {
+			types = strings.Join(webhook.EventTypes, ", ")
+		}
+		table.Append([]string{
+			fmt.Sprintf("%d", webhook.ID),
+			webhook.URL,
+			types,
+			fmt.Sprintf("%t", webhook.Active),
+			webhook.Description,
+			formatTime(webhook.CreatedAt),
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+// OutputDeliveriesTable outputs delivery attempts in a formatted table
+func OutputDeliveriesTable(deliveries []Delivery) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Event ID", "Event Type", "Attempt", "Status", "HTTP Status", "Duration", "Error", "Time"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+
+	for _, delivery := range deliveries {
+		statusCode := "-"
+		if delivery.StatusCode != 0 {
+			statusCode = fmt.Sprintf("%d", delivery.StatusCode)
+		}
+		table.Append([]string{
+			fmt.Sprintf("%d", delivery.ID),
+			fmt.Sprintf("%d", delivery.EventID),
+			delivery.EventType,
+			fmt.Sprintf("%d", delivery.Attempt),
+			delivery.Status,
+			statusCode,
+			(time.Duration(delivery.DurationMs) * time.Millisecond).String(),
+			delivery.Error,
+			formatTime(delivery.CreatedAt),
+		})
+	}
+
+	table.Render()
+	return nil
+}
+
+// formatTime formats a time.Time for display
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,39 @@
+package webhooks
+
+import "time"
+
+// WebhookRequest represents a request to create or update a webhook
+type WebhookRequest struct {
+	URL         string   `json:"url"`
+	Description string   `json:"description,omitempty"`
+	EventTypes  []string `json:"eventTypes,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// Webhook represents a webhook subscription response. The secret is only
+// returned when it is generated.
+type Webhook struct {
+	ID          uint      `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description,omitempty"`
+	EventTypes  []string  `json:"eventTypes"`
+	Active      bool      `json:"active"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Delivery represents one attempt to post an event to a webhook
+type Delivery struct {
+	ID           uint      `json:"id"`
+	EventID      uint      `json:"eventId"`
+	EventType    string    `json:"eventType"`
+	Attempt      int       `json:"attempt"`
+	Status       string    `json:"status"`
+	StatusCode   int       `json:"statusCode,omitempty"`
+	ResponseBody string    `json:"responseBody,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	DurationMs   int64     `json:"durationMs"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
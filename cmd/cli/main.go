@@ -1,7 +1,10 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"sarc-ng/pkg/oauth"
 )
 
 // main is the entry point for the SARC CLI application
@@ -11,6 +14,9 @@ func main() {
 
 	// Execute the command and handle any errors
 	if err := rootCmd.Execute(); err != nil {
+		if errors.Is(err, oauth.ErrLoginRequired) {
+			fmt.Fprintln(os.Stderr, "Run 'sarc login' to sign in again.")
+		}
 		os.Exit(1)
 	}
 }
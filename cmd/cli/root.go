@@ -1,14 +1,20 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"sarc-ng/cmd/cli/commands/apikeys"
+	"sarc-ng/cmd/cli/commands/auth"
 	"sarc-ng/cmd/cli/commands/buildings"
 	"sarc-ng/cmd/cli/commands/classes"
 	"sarc-ng/cmd/cli/commands/health"
 	"sarc-ng/cmd/cli/commands/lessons"
 	"sarc-ng/cmd/cli/commands/reservations"
 	"sarc-ng/cmd/cli/commands/resources"
+	"sarc-ng/cmd/cli/commands/waitlist"
+	"sarc-ng/cmd/cli/commands/webhooks"
+	"sarc-ng/pkg/oauth"
 	"sarc-ng/pkg/rest/client"
 
 	"github.com/spf13/cobra"
@@ -17,6 +23,8 @@ import (
 // GlobalConfig holds configuration used across all commands
 type GlobalConfig struct {
 	APIBaseURL string
+	Token      string
+	APIKey     string
 	Timeout    int
 	Verbose    bool
 }
@@ -43,6 +51,12 @@ Use this CLI to manage buildings, resources, classes, lessons, and reservations.
 	rootCmd.PersistentFlags().StringVar(&config.APIBaseURL, "api-url",
 		getEnvWithDefault("SARC_API_URL", "http://localhost:8080"),
 		"SARC API base URL")
+	rootCmd.PersistentFlags().StringVar(&config.Token, "token",
+		os.Getenv("SARC_TOKEN"),
+		"Bearer token for protected endpoints (or set SARC_TOKEN)")
+	rootCmd.PersistentFlags().StringVar(&config.APIKey, "api-key",
+		os.Getenv("SARC_API_KEY"),
+		"API key for protected endpoints, instead of a token (or set SARC_API_KEY)")
 	rootCmd.PersistentFlags().IntVar(&config.Timeout, "timeout", 30,
 		"Request timeout in seconds")
 	rootCmd.PersistentFlags().BoolVarP(&config.Verbose, "verbose", "v", false,
@@ -50,9 +64,16 @@ Use this CLI to manage buildings, resources, classes, lessons, and reservations.
 
 	// Create API client factory
 	clientFactory := func() *client.Client {
-		return client.NewClient(client.Config{
+		clientConfig := client.Config{
 			BaseURL: config.APIBaseURL,
-		})
+			Token:   config.Token,
+			APIKey:  config.APIKey,
+		}
+		// Without a token or key, the tokens of sarc login are used
+		if config.Token == "" && config.APIKey == "" {
+			clientConfig.TokenSource = storedTokens(config.APIBaseURL)
+		}
+		return client.NewClient(clientConfig)
 	}
 
 	// Add subcommands
@@ -62,10 +83,31 @@ Use this CLI to manage buildings, resources, classes, lessons, and reservations.
 	rootCmd.AddCommand(reservations.NewCommand(clientFactory))
 	rootCmd.AddCommand(classes.NewCommand(clientFactory))
 	rootCmd.AddCommand(lessons.NewCommand(clientFactory))
+	rootCmd.AddCommand(waitlist.NewCommand(clientFactory))
+	rootCmd.AddCommand(webhooks.NewCommand(clientFactory))
+	rootCmd.AddCommand(apikeys.NewCommand(clientFactory))
+	rootCmd.AddCommand(auth.NewCommand(clientFactory))
+	rootCmd.AddCommand(auth.NewLoginCommand(clientFactory))
+	rootCmd.AddCommand(auth.NewLogoutCommand(clientFactory))
 
 	return rootCmd
 }
 
+// storedTokens returns the tokens sarc login keeps for the API, if any
+func storedTokens(apiURL string) client.TokenSource {
+	store, err := oauth.DefaultStore()
+	if err == nil {
+		var source *oauth.TokenSource
+		if source, err = oauth.NewTokenSource(store, apiURL); err == nil {
+			return source
+		}
+	}
+	if !errors.Is(err, oauth.ErrLoginRequired) {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring stored credentials: %v\n", err)
+	}
+	return nil
+}
+
 // getEnvWithDefault gets an environment variable or returns a default value
 func getEnvWithDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
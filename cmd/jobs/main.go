@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sarc-ng/internal/domain/job"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// Global variable to hold the application
+// This is initialized once and reused across Lambda invocations
+var app *Application
+
+// ScheduledEvent is the input of the function. EventBridge schedules pass a
+// constant input naming the job; events routed from elsewhere may name it in
+// their detail instead.
+type ScheduledEvent struct {
+	Job    string          `json:"job"`
+	Detail json.RawMessage `json:"detail,omitempty"`
+}
+
+// RunResult reports the run of a job back to the invoker
+type RunResult struct {
+	ID              uint    `json:"id"`
+	Job             string  `json:"job"`
+	Status          string  `json:"status"`
+	Summary         string  `json:"summary,omitempty"`
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// init function runs once when the Lambda container starts. The API function
+// runs the database migrations, so this one only connects.
+func init() {
+	log.Println("Initializing jobs Lambda function...")
+
+	var err error
+	app, err = InitializeApplication()
+	if err != nil {
+		log.Fatalf("Failed to initialize application: %v", err)
+	}
+
+	log.Println("Jobs Lambda function initialized successfully")
+}
+
+// Handler runs the job named by the event and fails the invocation when the
+// job fails, so the run shows up in the function's error metrics
+func Handler(ctx context.Context, event ScheduledEvent) (RunResult, error) {
+	name, err := jobName(event)
+	if err != nil {
+		return RunResult{}, err
+	}
+
+	run, err := app.JobService.RunJob(ctx, name, job.TriggerEvent)
+	if err != nil {
+		return RunResult{}, fmt.Errorf("failed to run job %s: %w", name, err)
+	}
+
+	result := RunResult{
+		ID:              run.ID,
+		Job:             run.Job,
+		Status:          string(run.Status),
+		Summary:         run.Summary,
+		DurationSeconds: run.Duration().Seconds(),
+	}
+	if run.Status == job.RunFailed {
+		return result, fmt.Errorf("job %s failed: %s", name, run.Error)
+	}
+	log.Printf("Job %s finished: %s", name, run.Summary)
+	return result, nil
+}
+
+// jobName reads the job to run from the event or from its detail
+func jobName(event ScheduledEvent) (string, error) {
+	name := strings.TrimSpace(event.Job)
+	if name == "" && len(event.Detail) > 0 {
+		var detail struct {
+			Job string `json:"job"`
+		}
+		if err := json.Unmarshal(event.Detail, &detail); err != nil {
+			return "", fmt.Errorf("invalid event detail: %w", err)
+		}
+		name = strings.TrimSpace(detail.Job)
+	}
+	if name == "" {
+		return "", fmt.Errorf("event does not name a job")
+	}
+	return name, nil
+}
+
+// main function starts the Lambda runtime
+func main() {
+	// Check if we're running in Lambda environment
+	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+		log.Println("Starting Lambda runtime...")
+		lambda.Start(Handler)
+	} else {
+		log.Println("Not running in Lambda environment. The server runs jobs on its own scheduler locally.")
+	}
+}
@@ -4,11 +4,23 @@ import (
 	"context"
 	"log"
 	"os"
-	"sarc-ng/internal/domain/building"
-	"sarc-ng/internal/domain/class"
-	"sarc-ng/internal/domain/lesson"
-	"sarc-ng/internal/domain/reservation"
-	"sarc-ng/internal/domain/resource"
+	apikeyAdapter "sarc-ng/internal/adapter/gorm/apikey"
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	calendarAdapter "sarc-ng/internal/adapter/gorm/calendar"
+	checkinAdapter "sarc-ng/internal/adapter/gorm/checkin"
+	classAdapter "sarc-ng/internal/adapter/gorm/class"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	jobAdapter "sarc-ng/internal/adapter/gorm/job"
+	lessonAdapter "sarc-ng/internal/adapter/gorm/lesson"
+	notificationAdapter "sarc-ng/internal/adapter/gorm/notification"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	streamAdapter "sarc-ng/internal/adapter/gorm/stream"
+	waitlistAdapter "sarc-ng/internal/adapter/gorm/waitlist"
+	webhookAdapter "sarc-ng/internal/adapter/gorm/webhook"
 
 	docs "sarc-ng/api/swagger"
 
@@ -43,18 +55,47 @@ func init() {
 	// Auto-migrate database tables
 	// In production, consider running migrations separately to avoid cold start delays
 	log.Println("Running database migrations...")
+	if err := classAdapter.PrepareMigration(app.DB); err != nil {
+		log.Fatalf("Failed to prepare database migrations: %v", err)
+	}
 	err = app.DB.AutoMigrate(
-		&building.Building{},
-		&class.Class{},
-		&lesson.Lesson{},
-		&reservation.Reservation{},
-		&resource.Resource{},
+		&apikeyAdapter.GormModel{},
+		&auditAdapter.GormModel{},
+		&buildingAdapter.GormModel{},
+		&calendarAdapter.FeedTokenGormModel{},
+		&checkinAdapter.NoShowGormModel{},
+		&checkinAdapter.TokenGormModel{},
+		&classAdapter.GormModel{},
+		&eventAdapter.OutboxGormModel{},
+		&jobAdapter.LockGormModel{},
+		&jobAdapter.RunGormModel{},
+		&lessonAdapter.GormModel{},
+		&notificationAdapter.GormModel{},
+		&notificationAdapter.PreferencesGormModel{},
+		&policyAdapter.GormModel{},
+		&quotaAdapter.GormModel{},
+		&reservationAdapter.GormModel{},
+		&reservationAdapter.SeriesGormModel{},
+		&resourceAdapter.GormModel{},
+		&streamAdapter.GormModel{},
+		&waitlistAdapter.GormModel{},
+		&webhookAdapter.DeliveryGormModel{},
+		&webhookAdapter.SubscriptionGormModel{},
 	)
 	if err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 	log.Println("Database migrations completed successfully")
 
+	// Store the configured booking policies of resource types that have none yet
+	seeded, err := app.PolicyService.SeedPolicies()
+	if err != nil {
+		log.Fatalf("Failed to seed booking policies: %v", err)
+	}
+	if seeded > 0 {
+		log.Printf("Seeded %d booking policies", seeded)
+	}
+
 	// Get mode from environment or use release mode for Lambda
 	mode := os.Getenv("GIN_MODE")
 	if mode == "" {
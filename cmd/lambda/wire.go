@@ -3,32 +3,79 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"slices"
+	"time"
 
+	"sarc-ng/internal/adapter/broker"
 	"sarc-ng/internal/adapter/db"
+	"sarc-ng/internal/adapter/eventsink"
+	apikeyAdapter "sarc-ng/internal/adapter/gorm/apikey"
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
 	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	calendarAdapter "sarc-ng/internal/adapter/gorm/calendar"
+	checkinAdapter "sarc-ng/internal/adapter/gorm/checkin"
 	classAdapter "sarc-ng/internal/adapter/gorm/class"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	jobAdapter "sarc-ng/internal/adapter/gorm/job"
 	lessonAdapter "sarc-ng/internal/adapter/gorm/lesson"
+	notificationAdapter "sarc-ng/internal/adapter/gorm/notification"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
 	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
 	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	streamAdapter "sarc-ng/internal/adapter/gorm/stream"
+	waitlistAdapter "sarc-ng/internal/adapter/gorm/waitlist"
+	webhookAdapter "sarc-ng/internal/adapter/gorm/webhook"
+	"sarc-ng/internal/adapter/notify"
 	"sarc-ng/internal/adapter/secrets"
+	webhookSender "sarc-ng/internal/adapter/webhook"
 	"sarc-ng/internal/config"
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/domain/audit"
 	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/availability"
 	"sarc-ng/internal/domain/building"
+	"sarc-ng/internal/domain/calendar"
+	"sarc-ng/internal/domain/checkin"
 	"sarc-ng/internal/domain/class"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/job"
 	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/internal/domain/notification"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/quota"
 	"sarc-ng/internal/domain/reservation"
 	"sarc-ng/internal/domain/resource"
+	"sarc-ng/internal/domain/stream"
+	"sarc-ng/internal/domain/waitlist"
+	"sarc-ng/internal/domain/webhook"
+	apikeyService "sarc-ng/internal/service/apikey"
+	auditService "sarc-ng/internal/service/audit"
 	authService "sarc-ng/internal/service/auth"
+	availabilityService "sarc-ng/internal/service/availability"
 	buildingService "sarc-ng/internal/service/building"
+	calendarService "sarc-ng/internal/service/calendar"
+	checkinService "sarc-ng/internal/service/checkin"
 	classService "sarc-ng/internal/service/class"
+	eventService "sarc-ng/internal/service/event"
+	jobService "sarc-ng/internal/service/job"
 	lessonService "sarc-ng/internal/service/lesson"
+	notificationService "sarc-ng/internal/service/notification"
+	policyService "sarc-ng/internal/service/policy"
+	quotaService "sarc-ng/internal/service/quota"
 	reservationService "sarc-ng/internal/service/reservation"
 	resourceService "sarc-ng/internal/service/resource"
+	streamService "sarc-ng/internal/service/stream"
+	waitlistService "sarc-ng/internal/service/waitlist"
+	webhookService "sarc-ng/internal/service/webhook"
 	"sarc-ng/internal/transport/rest"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/wire"
 	"gorm.io/gorm"
 )
@@ -43,6 +90,12 @@ type Application struct {
 	LessonService      lesson.Usecase
 	ResourceService    resource.Usecase
 	ReservationService reservation.Usecase
+	PolicyService      policy.Usecase
+	JobService         job.Usecase
+	Scheduler          *jobService.Scheduler
+	EventService       event.Usecase
+	Dispatcher         *eventService.Dispatcher
+	NotificationWorker *notificationService.Worker
 }
 
 // ProviderSet for the application
@@ -54,22 +107,92 @@ var ProviderSet = wire.NewSet(
 	provideDatabaseConnection,
 
 	// Authentication
+	provideTokenIssuer,
 	provideTokenValidator,
-	wire.Bind(new(auth.TokenValidator), new(*authService.JWTValidator)),
+	providePermissions,
+
+	// Scheduling
+	provideOpeningHours,
+	provideBookingPolicies,
+	provideQuotaSettings,
+	provideCheckInSettings,
+
+	// Background jobs
+	provideJobSettings,
+
+	// Domain events
+	provideEventSettings,
+	provideEventSinks,
+	provideEventDispatcher,
+	eventsink.NewInProcess,
 
 	// GORM Adapters - these provide the repository implementations
 	buildingAdapter.NewGormAdapter,
+	buildingAdapter.NewUnitOfWork,
 	classAdapter.NewGormAdapter,
+	classAdapter.NewUnitOfWork,
 	lessonAdapter.NewGormAdapter,
+	lessonAdapter.NewUnitOfWork,
 	resourceAdapter.NewGormAdapter,
+	resourceAdapter.NewUnitOfWork,
 	reservationAdapter.NewGormAdapter,
+	reservationAdapter.NewUnitOfWork,
+	calendarAdapter.NewGormAdapter,
+	policyAdapter.NewGormAdapter,
+	quotaAdapter.NewGormAdapter,
+	waitlistAdapter.NewGormAdapter,
+	waitlistAdapter.NewUnitOfWork,
+	checkinAdapter.NewGormAdapter,
+	checkinAdapter.NewUnitOfWork,
+	jobAdapter.NewGormAdapter,
+	jobAdapter.NewPurger,
+	eventAdapter.NewGormAdapter,
+	webhookAdapter.NewGormAdapter,
+	notificationAdapter.NewGormAdapter,
+	auditAdapter.NewGormAdapter,
+	apikeyAdapter.NewGormAdapter,
 
 	// Repository interface bindings
 	wire.Bind(new(building.Repository), new(*buildingAdapter.GormAdapter)),
+	wire.Bind(new(building.UnitOfWork), new(*buildingAdapter.UnitOfWork)),
 	wire.Bind(new(class.Repository), new(*classAdapter.GormAdapter)),
+	wire.Bind(new(class.UnitOfWork), new(*classAdapter.UnitOfWork)),
 	wire.Bind(new(lesson.Repository), new(*lessonAdapter.GormAdapter)),
+	wire.Bind(new(lesson.UnitOfWork), new(*lessonAdapter.UnitOfWork)),
 	wire.Bind(new(resource.Repository), new(*resourceAdapter.GormAdapter)),
+	wire.Bind(new(resource.UnitOfWork), new(*resourceAdapter.UnitOfWork)),
 	wire.Bind(new(reservation.Repository), new(*reservationAdapter.GormAdapter)),
+	wire.Bind(new(reservation.UnitOfWork), new(*reservationAdapter.UnitOfWork)),
+	wire.Bind(new(calendar.Repository), new(*calendarAdapter.GormAdapter)),
+	wire.Bind(new(policy.Repository), new(*policyAdapter.GormAdapter)),
+	wire.Bind(new(quota.Repository), new(*quotaAdapter.GormAdapter)),
+	wire.Bind(new(waitlist.Repository), new(*waitlistAdapter.GormAdapter)),
+	wire.Bind(new(waitlist.UnitOfWork), new(*waitlistAdapter.UnitOfWork)),
+	wire.Bind(new(checkin.Repository), new(*checkinAdapter.GormAdapter)),
+	wire.Bind(new(checkin.UnitOfWork), new(*checkinAdapter.UnitOfWork)),
+	wire.Bind(new(job.Repository), new(*jobAdapter.GormAdapter)),
+	wire.Bind(new(job.Purger), new(*jobAdapter.Purger)),
+	wire.Bind(new(event.Repository), new(*eventAdapter.GormAdapter)),
+	wire.Bind(new(webhook.Repository), new(*webhookAdapter.GormAdapter)),
+	wire.Bind(new(notification.Repository), new(*notificationAdapter.GormAdapter)),
+	wire.Bind(new(audit.Repository), new(*auditAdapter.GormAdapter)),
+	wire.Bind(new(apikey.Repository), new(*apikeyAdapter.GormAdapter)),
+
+	// Webhooks
+	webhookSender.NewHTTPSender,
+	wire.Bind(new(webhook.Sender), new(*webhookSender.HTTPSender)),
+
+	// Notifications
+	notify.NewLogNotifier,
+	wire.Bind(new(waitlist.Notifier), new(*notificationService.Service)),
+	wire.Bind(new(reservation.Notifier), new(*notificationService.Service)),
+	provideNotificationSettings,
+	provideNotificationTemplates,
+	provideNotifier,
+	provideNotificationWorker,
+
+	// Live updates
+	provideStreamBroker,
 
 	// Services
 	buildingService.NewService,
@@ -77,6 +200,21 @@ var ProviderSet = wire.NewSet(
 	lessonService.NewService,
 	resourceService.NewService,
 	reservationService.NewService,
+	calendarService.NewService,
+	availabilityService.NewService,
+	policyService.NewService,
+	quotaService.NewService,
+	waitlistService.NewService,
+	checkinService.NewService,
+	jobService.NewRegistry,
+	jobService.NewService,
+	jobService.NewScheduler,
+	eventService.NewService,
+	webhookService.NewService,
+	notificationService.NewService,
+	streamService.NewService,
+	auditService.NewService,
+	apikeyService.NewService,
 
 	// Service interface bindings
 	wire.Bind(new(building.Usecase), new(*buildingService.Service)),
@@ -84,6 +222,19 @@ var ProviderSet = wire.NewSet(
 	wire.Bind(new(lesson.Usecase), new(*lessonService.Service)),
 	wire.Bind(new(resource.Usecase), new(*resourceService.Service)),
 	wire.Bind(new(reservation.Usecase), new(*reservationService.Service)),
+	wire.Bind(new(calendar.Usecase), new(*calendarService.Service)),
+	wire.Bind(new(availability.Usecase), new(*availabilityService.Service)),
+	wire.Bind(new(policy.Usecase), new(*policyService.Service)),
+	wire.Bind(new(quota.Usecase), new(*quotaService.Service)),
+	wire.Bind(new(waitlist.Usecase), new(*waitlistService.Service)),
+	wire.Bind(new(checkin.Usecase), new(*checkinService.Service)),
+	wire.Bind(new(job.Usecase), new(*jobService.Service)),
+	wire.Bind(new(event.Usecase), new(*eventService.Service)),
+	wire.Bind(new(webhook.Usecase), new(*webhookService.Service)),
+	wire.Bind(new(notification.Usecase), new(*notificationService.Service)),
+	wire.Bind(new(stream.Usecase), new(*streamService.Service)),
+	wire.Bind(new(audit.Usecase), new(*auditService.Service)),
+	wire.Bind(new(apikey.Usecase), new(*apikeyService.Service)),
 
 	// REST Router
 	rest.NewRouter,
@@ -147,14 +298,298 @@ func parsePort(portStr string) int {
 	return port
 }
 
-// provideTokenValidator creates a new JWT token validator
-func provideTokenValidator(cfg *config.Config) *authService.JWTValidator {
-	return authService.NewJWTValidator(
-		cfg.Cognito.Region,
-		cfg.Cognito.UserPoolID,
-		cfg.Cognito.ClientID,
-		cfg.Cognito.JWKSCacheExp,
-	)
+// provideTokenIssuer creates the local token issuer, or none unless it is the
+// configured provider
+func provideTokenIssuer(cfg *config.Config) (auth.TokenIssuer, error) {
+	if cfg.Auth.Provider != "local" {
+		return nil, nil
+	}
+
+	settings := authService.LocalSettings{
+		Issuer:    cfg.JWT.Issuer,
+		Algorithm: cfg.JWT.Algorithm,
+		Secret:    cfg.JWT.Secret,
+		Expiry:    cfg.JWT.Expiry,
+		DevTokens: cfg.JWT.DevTokens,
+	}
+	if cfg.JWT.KeyFile != "" {
+		pem, err := os.ReadFile(cfg.JWT.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt.key_file: %w", err)
+		}
+		settings.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwt.key_file: %w", err)
+		}
+	}
+	for _, file := range cfg.JWT.PublicKeyFiles {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt.public_key_files: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %w", file, err)
+		}
+		settings.PublicKeys = append(settings.PublicKeys, key)
+	}
+
+	issuer, err := authService.NewLocalIssuer(settings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt: %w", err)
+	}
+	return issuer, nil
+}
+
+// provideTokenValidator creates the token validator of the configured provider
+func provideTokenValidator(cfg *config.Config, issuer auth.TokenIssuer) (auth.TokenValidator, error) {
+	switch cfg.Auth.Provider {
+	case "cognito":
+		return authService.NewJWTValidator(
+			cfg.Cognito.Region,
+			cfg.Cognito.UserPoolID,
+			cfg.Cognito.ClientID,
+			cfg.Cognito.JWKSCacheExp,
+		), nil
+	case "oidc":
+		oidc := cfg.Auth.OIDC
+		issuers := make([]authService.OIDCIssuer, len(oidc.Issuers))
+		for i, issuer := range oidc.Issuers {
+			issuers[i] = authService.OIDCIssuer{
+				URL:           issuer.URL,
+				Audiences:     issuer.Audiences,
+				UsernameClaim: cmp.Or(issuer.UsernameClaim, oidc.UsernameClaim),
+				GroupsClaim:   cmp.Or(issuer.GroupsClaim, oidc.GroupsClaim),
+				Primary:       issuer.Primary,
+			}
+		}
+		validator, err := authService.NewOIDCValidator(issuers, oidc.JWKSCacheExp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth.oidc: %w", err)
+		}
+		return validator, nil
+	case "local":
+		return issuer, nil
+	default:
+		return nil, fmt.Errorf("unknown auth provider %q, expected cognito, oidc or local", cfg.Auth.Provider)
+	}
+}
+
+// providePermissions applies the configured roles to the default permissions
+func providePermissions(cfg *config.Config) (auth.Permissions, error) {
+	permissions, err := auth.ParsePermissions(cfg.Auth.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth.permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+// provideOpeningHours parses the configured opening hours
+func provideOpeningHours(cfg *config.Config) (availability.OpeningHours, error) {
+	hours := cfg.Scheduling.OpeningHours
+	openingHours, err := availability.ParseOpeningHours(hours.TimeZone, hours.Open, hours.Close, hours.Days)
+	if err != nil {
+		return availability.OpeningHours{}, fmt.Errorf("invalid opening hours: %w", err)
+	}
+	return openingHours, nil
+}
+
+// provideBookingPolicies converts the configured booking policies, which are
+// stored on startup for resource types that have none yet
+func provideBookingPolicies(cfg *config.Config) []policy.Policy {
+	policies := make([]policy.Policy, len(cfg.Scheduling.Policies))
+	for i, p := range cfg.Scheduling.Policies {
+		policies[i] = policy.Policy{
+			ResourceType: p.ResourceType,
+			MaxDuration:  p.MaxDuration,
+			MinLeadTime:  p.MinLeadTime,
+			MaxAdvance:   p.MaxAdvance,
+			Buffer:       p.Buffer,
+			Hours: policy.Hours{
+				TimeZone: p.AllowedHours.TimeZone,
+				From:     p.AllowedHours.Open,
+				Until:    p.AllowedHours.Close,
+				Days:     p.AllowedHours.Days,
+			},
+			AllowedGroups: p.AllowedGroups,
+			NoShowLimit:   p.NoShowLimit,
+			NoShowPeriod:  p.NoShowPeriod,
+		}
+	}
+	return policies
+}
+
+// provideQuotaSettings converts the configured quota rules
+func provideQuotaSettings(cfg *config.Config) (quota.Settings, error) {
+	quotas := cfg.Scheduling.Quotas
+	location, err := time.LoadLocation(quotas.TimeZone)
+	if err != nil {
+		return quota.Settings{}, fmt.Errorf("invalid quota time zone %q: %w", quotas.TimeZone, err)
+	}
+
+	rules := make([]quota.Rule, len(quotas.Rules))
+	for i, r := range quotas.Rules {
+		if r.MaxActive < 0 || r.MaxWeekly < 0 {
+			return quota.Settings{}, fmt.Errorf("invalid quota rule for group '%s': limits cannot be negative", r.Group)
+		}
+		rules[i] = quota.Rule{
+			Group:        r.Group,
+			ResourceType: r.ResourceType,
+			MaxActive:    r.MaxActive,
+			MaxWeekly:    r.MaxWeekly,
+		}
+	}
+	return quota.Settings{Location: location, Rules: rules}, nil
+}
+
+// provideCheckInSettings converts the configured check-in window
+func provideCheckInSettings(cfg *config.Config) (checkin.Settings, error) {
+	checkIn := cfg.Scheduling.CheckIn
+	if checkIn.GracePeriod <= 0 || checkIn.EarlyWindow < 0 {
+		return checkin.Settings{}, fmt.Errorf("invalid check-in settings: grace period must be positive and early window not negative")
+	}
+	return checkin.Settings{GracePeriod: checkIn.GracePeriod, EarlyWindow: checkIn.EarlyWindow}, nil
+}
+
+// provideJobSettings converts the configured job settings
+func provideJobSettings(cfg *config.Config) (job.Settings, error) {
+	jobs := cfg.Jobs
+	location, err := time.LoadLocation(jobs.TimeZone)
+	if err != nil {
+		return job.Settings{}, fmt.Errorf("invalid job time zone %q: %w", jobs.TimeZone, err)
+	}
+	if jobs.Timeout <= 0 || jobs.ReminderLead <= 0 || jobs.PurgeAfter < 0 {
+		return job.Settings{}, fmt.Errorf("invalid job settings: timeout and reminder lead must be positive and purge after not negative")
+	}
+	return job.Settings{
+		Location:     location,
+		Timeout:      jobs.Timeout,
+		Schedules:    jobs.Schedules,
+		ReminderLead: jobs.ReminderLead,
+		PurgeAfter:   jobs.PurgeAfter,
+	}, nil
+}
+
+// provideEventSettings converts the configured event dispatch settings
+func provideEventSettings(cfg *config.Config) (event.Settings, error) {
+	events := cfg.Events
+	if events.BatchSize <= 0 || events.MaxAttempts <= 0 || events.RetryBackoff <= 0 || events.DispatchInterval < 0 {
+		return event.Settings{}, fmt.Errorf("invalid event settings: batch size, max attempts and retry backoff must be positive and dispatch interval not negative")
+	}
+	return event.Settings{
+		BatchSize:    events.BatchSize,
+		MaxAttempts:  events.MaxAttempts,
+		RetryBackoff: events.RetryBackoff,
+	}, nil
+}
+
+// provideEventSinks lists the sinks events are delivered to: subscribers
+// within the application, the webhook subscriptions managed through the API,
+// the log if enabled and the configured webhooks. Email notifications are
+// queued and live updates published by in-process subscribers.
+func provideEventSinks(
+	cfg *config.Config,
+	inProcess *eventsink.InProcess,
+	webhooks *webhookService.Service,
+	notifications *notificationService.Service,
+	streams *streamService.Service,
+) ([]event.Sink, error) {
+	inProcess.Subscribe(notifications.HandleEvent, notificationService.EventTypes...)
+	inProcess.Subscribe(streams.HandleEvent, streamService.EventTypes...)
+
+	sinks := []event.Sink{inProcess, webhooks}
+	if cfg.Events.Log {
+		sinks = append(sinks, eventsink.NewLogSink())
+	}
+
+	for _, w := range cfg.Events.Webhooks {
+		if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid event webhook URL %q", w.URL)
+		}
+		types := make([]event.Type, len(w.Types))
+		for i, t := range w.Types {
+			types[i] = event.Type(t)
+			if !slices.Contains(event.Types, types[i]) {
+				return nil, fmt.Errorf("unknown event type %q for webhook %s", t, w.URL)
+			}
+		}
+		sinks = append(sinks, eventsink.NewWebhook(w.URL, types...))
+	}
+	return sinks, nil
+}
+
+// provideEventDispatcher creates the dispatcher polling the outbox at the configured interval
+func provideEventDispatcher(cfg *config.Config, service *eventService.Service) *eventService.Dispatcher {
+	return eventService.NewDispatcher(service, cfg.Events.DispatchInterval)
+}
+
+// provideNotificationSettings converts the configured notification settings
+func provideNotificationSettings(cfg *config.Config) (notification.Settings, error) {
+	notifications := cfg.Notifications
+	location, err := time.LoadLocation(notifications.TimeZone)
+	if err != nil {
+		return notification.Settings{}, fmt.Errorf("invalid notification time zone %q: %w", notifications.TimeZone, err)
+	}
+	if notifications.BatchSize <= 0 || notifications.MaxAttempts <= 0 || notifications.RetryBackoff <= 0 || notifications.SendInterval < 0 {
+		return notification.Settings{}, fmt.Errorf("invalid notification settings: batch size, max attempts and retry backoff must be positive and send interval not negative")
+	}
+	return notification.Settings{
+		DefaultLocale: notifications.DefaultLocale,
+		Location:      location,
+		BatchSize:     notifications.BatchSize,
+		MaxAttempts:   notifications.MaxAttempts,
+		RetryBackoff:  notifications.RetryBackoff,
+	}, nil
+}
+
+// provideNotificationTemplates loads the built-in email templates and those
+// in the configured directory
+func provideNotificationTemplates(cfg *config.Config) (*notificationService.Templates, error) {
+	templates, err := notificationService.LoadTemplates(cfg.Notifications.TemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+	if !templates.Supports(cfg.Notifications.DefaultLocale) {
+		return nil, fmt.Errorf("no notification templates for default locale %q", cfg.Notifications.DefaultLocale)
+	}
+	return templates, nil
+}
+
+// provideNotifier sends emails through the configured SMTP server, or writes
+// them to the log when there is none
+func provideNotifier(cfg *config.Config, logNotifier *notify.LogNotifier) (notification.Notifier, error) {
+	smtpConfig := cfg.Notifications.SMTP
+	if smtpConfig.Host == "" {
+		return logNotifier, nil
+	}
+	return notify.NewSMTPNotifier(notify.SMTPSettings{
+		Host:     smtpConfig.Host,
+		Port:     smtpConfig.Port,
+		Username: smtpConfig.Username,
+		Password: smtpConfig.Password,
+		From:     smtpConfig.From,
+		TLS:      smtpConfig.TLS,
+		Timeout:  smtpConfig.Timeout,
+	})
+}
+
+// provideNotificationWorker creates the worker sending queued emails at the configured interval
+func provideNotificationWorker(cfg *config.Config, service *notificationService.Service) *notificationService.Worker {
+	return notificationService.NewWorker(service, cfg.Notifications.SendInterval)
+}
+
+// provideStreamBroker creates the configured broker for live updates. The
+// memory broker only reaches the clients of its own instance, so deployments
+// running several use the database broker.
+func provideStreamBroker(cfg *config.Config, db *gorm.DB) (stream.Broker, error) {
+	switch cfg.Stream.Broker {
+	case "memory":
+		return broker.NewMemory(cfg.Stream.BufferSize), nil
+	case "database":
+		return streamAdapter.NewBroker(db, cfg.Stream.PollInterval, cfg.Stream.BufferSize), nil
+	default:
+		return nil, fmt.Errorf("unknown stream broker %q, expected memory or database", cfg.Stream.Broker)
+	}
 }
 
 // InitializeApplication initializes the application with all dependencies
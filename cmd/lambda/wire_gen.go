@@ -7,32 +7,79 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/wire"
 	"gorm.io/gorm"
+	"net/url"
 	"os"
+	"sarc-ng/internal/adapter/broker"
 	"sarc-ng/internal/adapter/db"
+	"sarc-ng/internal/adapter/eventsink"
+	"sarc-ng/internal/adapter/gorm/apikey"
+	"sarc-ng/internal/adapter/gorm/audit"
 	"sarc-ng/internal/adapter/gorm/building"
+	"sarc-ng/internal/adapter/gorm/calendar"
+	"sarc-ng/internal/adapter/gorm/checkin"
 	"sarc-ng/internal/adapter/gorm/class"
+	"sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/job"
 	"sarc-ng/internal/adapter/gorm/lesson"
+	"sarc-ng/internal/adapter/gorm/notification"
+	"sarc-ng/internal/adapter/gorm/policy"
+	"sarc-ng/internal/adapter/gorm/quota"
 	"sarc-ng/internal/adapter/gorm/reservation"
 	"sarc-ng/internal/adapter/gorm/resource"
+	stream3 "sarc-ng/internal/adapter/gorm/stream"
+	"sarc-ng/internal/adapter/gorm/waitlist"
+	"sarc-ng/internal/adapter/gorm/webhook"
+	"sarc-ng/internal/adapter/notify"
 	"sarc-ng/internal/adapter/secrets"
+	webhook2 "sarc-ng/internal/adapter/webhook"
 	"sarc-ng/internal/config"
+	apikey3 "sarc-ng/internal/domain/apikey"
+	audit3 "sarc-ng/internal/domain/audit"
 	"sarc-ng/internal/domain/auth"
+	availability2 "sarc-ng/internal/domain/availability"
 	building3 "sarc-ng/internal/domain/building"
+	calendar3 "sarc-ng/internal/domain/calendar"
+	checkin3 "sarc-ng/internal/domain/checkin"
 	class3 "sarc-ng/internal/domain/class"
+	event3 "sarc-ng/internal/domain/event"
+	job3 "sarc-ng/internal/domain/job"
 	lesson3 "sarc-ng/internal/domain/lesson"
+	notification3 "sarc-ng/internal/domain/notification"
+	policy3 "sarc-ng/internal/domain/policy"
+	quota3 "sarc-ng/internal/domain/quota"
 	reservation3 "sarc-ng/internal/domain/reservation"
 	resource3 "sarc-ng/internal/domain/resource"
+	stream2 "sarc-ng/internal/domain/stream"
+	waitlist3 "sarc-ng/internal/domain/waitlist"
+	webhook4 "sarc-ng/internal/domain/webhook"
+	apikey2 "sarc-ng/internal/service/apikey"
+	audit2 "sarc-ng/internal/service/audit"
 	auth2 "sarc-ng/internal/service/auth"
+	"sarc-ng/internal/service/availability"
 	building2 "sarc-ng/internal/service/building"
+	calendar2 "sarc-ng/internal/service/calendar"
+	checkin2 "sarc-ng/internal/service/checkin"
 	class2 "sarc-ng/internal/service/class"
+	event2 "sarc-ng/internal/service/event"
+	job2 "sarc-ng/internal/service/job"
 	lesson2 "sarc-ng/internal/service/lesson"
+	notification2 "sarc-ng/internal/service/notification"
+	policy2 "sarc-ng/internal/service/policy"
+	quota2 "sarc-ng/internal/service/quota"
 	reservation2 "sarc-ng/internal/service/reservation"
 	resource2 "sarc-ng/internal/service/resource"
+	"sarc-ng/internal/service/stream"
+	waitlist2 "sarc-ng/internal/service/waitlist"
+	webhook3 "sarc-ng/internal/service/webhook"
 	"sarc-ng/internal/transport/rest"
+	"slices"
+	"time"
 )
 
 // Injectors from wire.go:
@@ -48,17 +95,111 @@ func InitializeApplication() (*Application, error) {
 		return nil, err
 	}
 	gormAdapter := building.NewGormAdapter(db)
-	service := building2.NewService(gormAdapter)
+	unitOfWork := building.NewUnitOfWork(db)
 	classGormAdapter := class.NewGormAdapter(db)
-	classService := class2.NewService(classGormAdapter)
+	resourceGormAdapter := resource.NewGormAdapter(db)
+	service := building2.NewService(gormAdapter, unitOfWork, classGormAdapter, resourceGormAdapter)
+	classUnitOfWork := class.NewUnitOfWork(db)
 	lessonGormAdapter := lesson.NewGormAdapter(db)
-	lessonService := lesson2.NewService(lessonGormAdapter)
+	classService := class2.NewService(classGormAdapter, classUnitOfWork, gormAdapter, resourceGormAdapter, lessonGormAdapter)
+	lessonUnitOfWork := lesson.NewUnitOfWork(db)
+	lessonService := lesson2.NewService(lessonGormAdapter, lessonUnitOfWork, classGormAdapter)
 	reservationGormAdapter := reservation.NewGormAdapter(db)
-	reservationService := reservation2.NewService(reservationGormAdapter)
-	resourceGormAdapter := resource.NewGormAdapter(db)
-	resourceService := resource2.NewService(resourceGormAdapter)
-	jwtValidator := provideTokenValidator(configConfig)
-	router := rest.NewRouter(service, classService, lessonService, reservationService, resourceService, jwtValidator)
+	reservationUnitOfWork := reservation.NewUnitOfWork(db)
+	policyGormAdapter := policy.NewGormAdapter(db)
+	quotaGormAdapter := quota.NewGormAdapter(db)
+	settings, err := provideQuotaSettings(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	waitlistUnitOfWork := waitlist.NewUnitOfWork(db)
+	logNotifier := notify.NewLogNotifier()
+	checkinGormAdapter := checkin.NewGormAdapter(db)
+	checkinUnitOfWork := checkin.NewUnitOfWork(db)
+	checkinSettings, err := provideCheckInSettings(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	notificationGormAdapter := notification.NewGormAdapter(db)
+	notifier, err := provideNotifier(configConfig, logNotifier)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := provideNotificationTemplates(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	notificationSettings, err := provideNotificationSettings(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	notificationService := notification2.NewService(notificationGormAdapter, resourceGormAdapter, notifier, templates, notificationSettings)
+	reservationService := reservation2.NewService(reservationGormAdapter, reservationUnitOfWork, resourceGormAdapter, policyGormAdapter, quotaGormAdapter, settings, waitlistUnitOfWork, notificationService, checkinGormAdapter, checkinUnitOfWork, checkinSettings, notificationService)
+	resourceUnitOfWork := resource.NewUnitOfWork(db)
+	resourceService := resource2.NewService(resourceGormAdapter, resourceUnitOfWork, gormAdapter, classGormAdapter)
+	calendarGormAdapter := calendar.NewGormAdapter(db)
+	calendarService := calendar2.NewService(calendarGormAdapter, reservationGormAdapter, resourceGormAdapter, gormAdapter, classGormAdapter, lessonGormAdapter)
+	openingHours, err := provideOpeningHours(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	availabilityService := availability.NewService(resourceGormAdapter, reservationGormAdapter, lessonGormAdapter, policyGormAdapter, openingHours)
+	v := provideBookingPolicies(configConfig)
+	policyService := policy2.NewService(policyGormAdapter, v)
+	quotaService := quota2.NewService(quotaGormAdapter, reservationGormAdapter, resourceGormAdapter, settings)
+	waitlistGormAdapter := waitlist.NewGormAdapter(db)
+	waitlistService := waitlist2.NewService(waitlistGormAdapter, reservationGormAdapter, resourceGormAdapter, policyGormAdapter, checkinGormAdapter, quotaGormAdapter, settings)
+	checkinService := checkin2.NewService(checkinGormAdapter, resourceGormAdapter)
+	jobGormAdapter := job.NewGormAdapter(db)
+	jobSettings, err := provideJobSettings(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	purger := job.NewPurger(db)
+	eventGormAdapter := event.NewGormAdapter(db)
+	inProcess := eventsink.NewInProcess()
+	webhookGormAdapter := webhook.NewGormAdapter(db)
+	httpSender := webhook2.NewHTTPSender()
+	webhookService := webhook3.NewService(webhookGormAdapter, httpSender)
+	broker, err := provideStreamBroker(configConfig, db)
+	if err != nil {
+		return nil, err
+	}
+	streamService := stream.NewService(broker, resourceGormAdapter)
+	v2, err := provideEventSinks(configConfig, inProcess, webhookService, notificationService, streamService)
+	if err != nil {
+		return nil, err
+	}
+	eventSettings, err := provideEventSettings(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	eventService := event2.NewService(eventGormAdapter, v2, eventSettings)
+	registry, err := job2.NewRegistry(jobSettings, reservationService, waitlistService, purger, eventService, notificationService)
+	if err != nil {
+		return nil, err
+	}
+	jobService := job2.NewService(jobGormAdapter, registry, jobSettings)
+	auditGormAdapter := audit.NewGormAdapter(db)
+	auditService := audit2.NewService(auditGormAdapter)
+	apikeyGormAdapter := apikey.NewGormAdapter(db)
+	apikeyService := apikey2.NewService(apikeyGormAdapter)
+	tokenIssuer, err := provideTokenIssuer(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	tokenValidator, err := provideTokenValidator(configConfig, tokenIssuer)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := providePermissions(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	router := rest.NewRouter(service, classService, lessonService, reservationService, resourceService, calendarService, availabilityService, policyService, quotaService, waitlistService, checkinService, jobService, eventService, webhookService, notificationService, streamService, auditService, apikeyService, tokenValidator, tokenIssuer, permissions)
+	scheduler := job2.NewScheduler(jobService, registry)
+	dispatcher := provideEventDispatcher(configConfig, eventService)
+	worker := provideNotificationWorker(configConfig, notificationService)
 	application := &Application{
 		DB:                 db,
 		Config:             configConfig,
@@ -68,6 +209,12 @@ func InitializeApplication() (*Application, error) {
 		LessonService:      lessonService,
 		ResourceService:    resourceService,
 		ReservationService: reservationService,
+		PolicyService:      policyService,
+		JobService:         jobService,
+		Scheduler:          scheduler,
+		EventService:       eventService,
+		Dispatcher:         dispatcher,
+		NotificationWorker: worker,
 	}
 	return application, nil
 }
@@ -84,12 +231,36 @@ type Application struct {
 	LessonService      lesson3.Usecase
 	ResourceService    resource3.Usecase
 	ReservationService reservation3.Usecase
+	PolicyService      policy3.Usecase
+	JobService         job3.Usecase
+	Scheduler          *job2.Scheduler
+	EventService       event3.Usecase
+	Dispatcher         *event2.Dispatcher
+	NotificationWorker *notification2.Worker
 }
 
 // ProviderSet for the application
 var ProviderSet = wire.NewSet(config.LoadConfig, provideDatabaseConnection,
 
-	provideTokenValidator, wire.Bind(new(auth.TokenValidator), new(*auth2.JWTValidator)), building.NewGormAdapter, class.NewGormAdapter, lesson.NewGormAdapter, resource.NewGormAdapter, reservation.NewGormAdapter, wire.Bind(new(building3.Repository), new(*building.GormAdapter)), wire.Bind(new(class3.Repository), new(*class.GormAdapter)), wire.Bind(new(lesson3.Repository), new(*lesson.GormAdapter)), wire.Bind(new(resource3.Repository), new(*resource.GormAdapter)), wire.Bind(new(reservation3.Repository), new(*reservation.GormAdapter)), building2.NewService, class2.NewService, lesson2.NewService, resource2.NewService, reservation2.NewService, wire.Bind(new(building3.Usecase), new(*building2.Service)), wire.Bind(new(class3.Usecase), new(*class2.Service)), wire.Bind(new(lesson3.Usecase), new(*lesson2.Service)), wire.Bind(new(resource3.Usecase), new(*resource2.Service)), wire.Bind(new(reservation3.Usecase), new(*reservation2.Service)), rest.NewRouter, wire.Struct(new(Application), "*"),
+	provideTokenIssuer,
+	provideTokenValidator,
+	providePermissions,
+
+	provideOpeningHours,
+	provideBookingPolicies,
+	provideQuotaSettings,
+	provideCheckInSettings,
+
+	provideJobSettings,
+
+	provideEventSettings,
+	provideEventSinks,
+	provideEventDispatcher, eventsink.NewInProcess, building.NewGormAdapter, building.NewUnitOfWork, class.NewGormAdapter, class.NewUnitOfWork, lesson.NewGormAdapter, lesson.NewUnitOfWork, resource.NewGormAdapter, resource.NewUnitOfWork, reservation.NewGormAdapter, reservation.NewUnitOfWork, calendar.NewGormAdapter, policy.NewGormAdapter, quota.NewGormAdapter, waitlist.NewGormAdapter, waitlist.NewUnitOfWork, checkin.NewGormAdapter, checkin.NewUnitOfWork, job.NewGormAdapter, job.NewPurger, event.NewGormAdapter, webhook.NewGormAdapter, notification.NewGormAdapter, audit.NewGormAdapter, apikey.NewGormAdapter, wire.Bind(new(building3.Repository), new(*building.GormAdapter)), wire.Bind(new(building3.UnitOfWork), new(*building.UnitOfWork)), wire.Bind(new(class3.Repository), new(*class.GormAdapter)), wire.Bind(new(class3.UnitOfWork), new(*class.UnitOfWork)), wire.Bind(new(lesson3.Repository), new(*lesson.GormAdapter)), wire.Bind(new(lesson3.UnitOfWork), new(*lesson.UnitOfWork)), wire.Bind(new(resource3.Repository), new(*resource.GormAdapter)), wire.Bind(new(resource3.UnitOfWork), new(*resource.UnitOfWork)), wire.Bind(new(reservation3.Repository), new(*reservation.GormAdapter)), wire.Bind(new(reservation3.UnitOfWork), new(*reservation.UnitOfWork)), wire.Bind(new(calendar3.Repository), new(*calendar.GormAdapter)), wire.Bind(new(policy3.Repository), new(*policy.GormAdapter)), wire.Bind(new(quota3.Repository), new(*quota.GormAdapter)), wire.Bind(new(waitlist3.Repository), new(*waitlist.GormAdapter)), wire.Bind(new(waitlist3.UnitOfWork), new(*waitlist.UnitOfWork)), wire.Bind(new(checkin3.Repository), new(*checkin.GormAdapter)), wire.Bind(new(checkin3.UnitOfWork), new(*checkin.UnitOfWork)), wire.Bind(new(job3.Repository), new(*job.GormAdapter)), wire.Bind(new(job3.Purger), new(*job.Purger)), wire.Bind(new(event3.Repository), new(*event.GormAdapter)), wire.Bind(new(webhook4.Repository), new(*webhook.GormAdapter)), wire.Bind(new(notification3.Repository), new(*notification.GormAdapter)), wire.Bind(new(audit3.Repository), new(*audit.GormAdapter)), wire.Bind(new(apikey3.Repository), new(*apikey.GormAdapter)), webhook2.NewHTTPSender, wire.Bind(new(webhook4.Sender), new(*webhook2.HTTPSender)), notify.NewLogNotifier, wire.Bind(new(waitlist3.Notifier), new(*notification2.Service)), wire.Bind(new(reservation3.Notifier), new(*notification2.Service)), provideNotificationSettings,
+	provideNotificationTemplates,
+	provideNotifier,
+	provideNotificationWorker,
+
+	provideStreamBroker, building2.NewService, class2.NewService, lesson2.NewService, resource2.NewService, reservation2.NewService, calendar2.NewService, availability.NewService, policy2.NewService, quota2.NewService, waitlist2.NewService, checkin2.NewService, job2.NewRegistry, job2.NewService, job2.NewScheduler, event2.NewService, webhook3.NewService, notification2.NewService, stream.NewService, audit2.NewService, apikey2.NewService, wire.Bind(new(building3.Usecase), new(*building2.Service)), wire.Bind(new(class3.Usecase), new(*class2.Service)), wire.Bind(new(lesson3.Usecase), new(*lesson2.Service)), wire.Bind(new(resource3.Usecase), new(*resource2.Service)), wire.Bind(new(reservation3.Usecase), new(*reservation2.Service)), wire.Bind(new(calendar3.Usecase), new(*calendar2.Service)), wire.Bind(new(availability2.Usecase), new(*availability.Service)), wire.Bind(new(policy3.Usecase), new(*policy2.Service)), wire.Bind(new(quota3.Usecase), new(*quota2.Service)), wire.Bind(new(waitlist3.Usecase), new(*waitlist2.Service)), wire.Bind(new(checkin3.Usecase), new(*checkin2.Service)), wire.Bind(new(job3.Usecase), new(*job2.Service)), wire.Bind(new(event3.Usecase), new(*event2.Service)), wire.Bind(new(webhook4.Usecase), new(*webhook3.Service)), wire.Bind(new(notification3.Usecase), new(*notification2.Service)), wire.Bind(new(stream2.Usecase), new(*stream.Service)), wire.Bind(new(audit3.Usecase), new(*audit2.Service)), wire.Bind(new(apikey3.Usecase), new(*apikey2.Service)), rest.NewRouter, wire.Struct(new(Application), "*"),
 )
 
 // provideDatabaseConnection provides a database connection using Secrets Manager or config
@@ -145,12 +316,296 @@ func parsePort(portStr string) int {
 	return port
 }
 
-// provideTokenValidator creates a new JWT token validator
-func provideTokenValidator(cfg *config.Config) *auth2.JWTValidator {
-	return auth2.NewJWTValidator(
-		cfg.Cognito.Region,
-		cfg.Cognito.UserPoolID,
-		cfg.Cognito.ClientID,
-		cfg.Cognito.JWKSCacheExp,
-	)
+// provideTokenIssuer creates the local token issuer, or none unless it is the
+// configured provider
+func provideTokenIssuer(cfg *config.Config) (auth.TokenIssuer, error) {
+	if cfg.Auth.Provider != "local" {
+		return nil, nil
+	}
+
+	settings := auth2.LocalSettings{
+		Issuer:    cfg.JWT.Issuer,
+		Algorithm: cfg.JWT.Algorithm,
+		Secret:    cfg.JWT.Secret,
+		Expiry:    cfg.JWT.Expiry,
+		DevTokens: cfg.JWT.DevTokens,
+	}
+	if cfg.JWT.KeyFile != "" {
+		pem, err := os.ReadFile(cfg.JWT.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt.key_file: %w", err)
+		}
+		settings.PrivateKey, err = jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwt.key_file: %w", err)
+		}
+	}
+	for _, file := range cfg.JWT.PublicKeyFiles {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt.public_key_files: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %w", file, err)
+		}
+		settings.PublicKeys = append(settings.PublicKeys, key)
+	}
+
+	issuer, err := auth2.NewLocalIssuer(settings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt: %w", err)
+	}
+	return issuer, nil
+}
+
+// provideTokenValidator creates the token validator of the configured provider
+func provideTokenValidator(cfg *config.Config, issuer auth.TokenIssuer) (auth.TokenValidator, error) {
+	switch cfg.Auth.Provider {
+	case "cognito":
+		return auth2.NewJWTValidator(
+			cfg.Cognito.Region,
+			cfg.Cognito.UserPoolID,
+			cfg.Cognito.ClientID,
+			cfg.Cognito.JWKSCacheExp,
+		), nil
+	case "oidc":
+		oidc := cfg.Auth.OIDC
+		issuers := make([]auth2.OIDCIssuer, len(oidc.Issuers))
+		for i, issuer := range oidc.Issuers {
+			issuers[i] = auth2.OIDCIssuer{
+				URL:           issuer.URL,
+				Audiences:     issuer.Audiences,
+				UsernameClaim: cmp.Or(issuer.UsernameClaim, oidc.UsernameClaim),
+				GroupsClaim:   cmp.Or(issuer.GroupsClaim, oidc.GroupsClaim),
+				Primary:       issuer.Primary,
+			}
+		}
+		validator, err := auth2.NewOIDCValidator(issuers, oidc.JWKSCacheExp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth.oidc: %w", err)
+		}
+		return validator, nil
+	case "local":
+		return issuer, nil
+	default:
+		return nil, fmt.Errorf("unknown auth provider %q, expected cognito, oidc or local", cfg.Auth.Provider)
+	}
+}
+
+// providePermissions applies the configured roles to the default permissions
+func providePermissions(cfg *config.Config) (auth.Permissions, error) {
+	permissions, err := auth.ParsePermissions(cfg.Auth.Permissions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth.permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+// provideOpeningHours parses the configured opening hours
+func provideOpeningHours(cfg *config.Config) (availability2.OpeningHours, error) {
+	hours := cfg.Scheduling.OpeningHours
+	openingHours, err := availability2.ParseOpeningHours(hours.TimeZone, hours.Open, hours.Close, hours.Days)
+	if err != nil {
+		return availability2.OpeningHours{}, fmt.Errorf("invalid opening hours: %w", err)
+	}
+	return openingHours, nil
+}
+
+// provideBookingPolicies converts the configured booking policies, which are
+// stored on startup for resource types that have none yet
+func provideBookingPolicies(cfg *config.Config) []policy3.Policy {
+	policies := make([]policy3.Policy, len(cfg.Scheduling.Policies))
+	for i, p := range cfg.Scheduling.Policies {
+		policies[i] = policy3.Policy{
+			ResourceType: p.ResourceType,
+			MaxDuration:  p.MaxDuration,
+			MinLeadTime:  p.MinLeadTime,
+			MaxAdvance:   p.MaxAdvance,
+			Buffer:       p.Buffer,
+			Hours: policy3.Hours{
+				TimeZone: p.AllowedHours.TimeZone,
+				From:     p.AllowedHours.Open,
+				Until:    p.AllowedHours.Close,
+				Days:     p.AllowedHours.Days,
+			},
+			AllowedGroups: p.AllowedGroups,
+			NoShowLimit:   p.NoShowLimit,
+			NoShowPeriod:  p.NoShowPeriod,
+		}
+	}
+	return policies
+}
+
+// provideQuotaSettings converts the configured quota rules
+func provideQuotaSettings(cfg *config.Config) (quota3.Settings, error) {
+	quotas := cfg.Scheduling.Quotas
+	location, err := time.LoadLocation(quotas.TimeZone)
+	if err != nil {
+		return quota3.Settings{}, fmt.Errorf("invalid quota time zone %q: %w", quotas.TimeZone, err)
+	}
+
+	rules := make([]quota3.Rule, len(quotas.Rules))
+	for i, r := range quotas.Rules {
+		if r.MaxActive < 0 || r.MaxWeekly < 0 {
+			return quota3.Settings{}, fmt.Errorf("invalid quota rule for group '%s': limits cannot be negative", r.Group)
+		}
+		rules[i] = quota3.Rule{
+			Group:        r.Group,
+			ResourceType: r.ResourceType,
+			MaxActive:    r.MaxActive,
+			MaxWeekly:    r.MaxWeekly,
+		}
+	}
+	return quota3.Settings{Location: location, Rules: rules}, nil
+}
+
+// provideCheckInSettings converts the configured check-in window
+func provideCheckInSettings(cfg *config.Config) (checkin3.Settings, error) {
+	checkIn := cfg.Scheduling.CheckIn
+	if checkIn.GracePeriod <= 0 || checkIn.EarlyWindow < 0 {
+		return checkin3.Settings{}, fmt.Errorf("invalid check-in settings: grace period must be positive and early window not negative")
+	}
+	return checkin3.Settings{GracePeriod: checkIn.GracePeriod, EarlyWindow: checkIn.EarlyWindow}, nil
+}
+
+// provideJobSettings converts the configured job settings
+func provideJobSettings(cfg *config.Config) (job3.Settings, error) {
+	jobs := cfg.Jobs
+	location, err := time.LoadLocation(jobs.TimeZone)
+	if err != nil {
+		return job3.Settings{}, fmt.Errorf("invalid job time zone %q: %w", jobs.TimeZone, err)
+	}
+	if jobs.Timeout <= 0 || jobs.ReminderLead <= 0 || jobs.PurgeAfter < 0 {
+		return job3.Settings{}, fmt.Errorf("invalid job settings: timeout and reminder lead must be positive and purge after not negative")
+	}
+	return job3.Settings{
+		Location:     location,
+		Timeout:      jobs.Timeout,
+		Schedules:    jobs.Schedules,
+		ReminderLead: jobs.ReminderLead,
+		PurgeAfter:   jobs.PurgeAfter,
+	}, nil
+}
+
+// provideEventSettings converts the configured event dispatch settings
+func provideEventSettings(cfg *config.Config) (event3.Settings, error) {
+	events := cfg.Events
+	if events.BatchSize <= 0 || events.MaxAttempts <= 0 || events.RetryBackoff <= 0 || events.DispatchInterval < 0 {
+		return event3.Settings{}, fmt.Errorf("invalid event settings: batch size, max attempts and retry backoff must be positive and dispatch interval not negative")
+	}
+	return event3.Settings{
+		BatchSize:    events.BatchSize,
+		MaxAttempts:  events.MaxAttempts,
+		RetryBackoff: events.RetryBackoff,
+	}, nil
+}
+
+// provideEventSinks lists the sinks events are delivered to: subscribers
+// within the application, the webhook subscriptions managed through the API,
+// the log if enabled and the configured webhooks. Email notifications are
+// queued and live updates published by in-process subscribers.
+func provideEventSinks(
+	cfg *config.Config,
+	inProcess *eventsink.InProcess,
+	webhooks *webhook3.Service,
+	notifications *notification2.Service,
+	streams *stream.Service,
+) ([]event3.Sink, error) {
+	inProcess.Subscribe(notifications.HandleEvent, notification2.EventTypes...)
+	inProcess.Subscribe(streams.HandleEvent, stream.EventTypes...)
+
+	sinks := []event3.Sink{inProcess, webhooks}
+	if cfg.Events.Log {
+		sinks = append(sinks, eventsink.NewLogSink())
+	}
+
+	for _, w := range cfg.Events.Webhooks {
+		if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid event webhook URL %q", w.URL)
+		}
+		types := make([]event3.Type, len(w.Types))
+		for i, t := range w.Types {
+			types[i] = event3.Type(t)
+			if !slices.Contains(event3.Types, types[i]) {
+				return nil, fmt.Errorf("unknown event type %q for webhook %s", t, w.URL)
+			}
+		}
+		sinks = append(sinks, eventsink.NewWebhook(w.URL, types...))
+	}
+	return sinks, nil
+}
+
+// provideEventDispatcher creates the dispatcher polling the outbox at the configured interval
+func provideEventDispatcher(cfg *config.Config, service *event2.Service) *event2.Dispatcher {
+	return event2.NewDispatcher(service, cfg.Events.DispatchInterval)
+}
+
+// provideNotificationSettings converts the configured notification settings
+func provideNotificationSettings(cfg *config.Config) (notification3.Settings, error) {
+	notifications := cfg.Notifications
+	location, err := time.LoadLocation(notifications.TimeZone)
+	if err != nil {
+		return notification3.Settings{}, fmt.Errorf("invalid notification time zone %q: %w", notifications.TimeZone, err)
+	}
+	if notifications.BatchSize <= 0 || notifications.MaxAttempts <= 0 || notifications.RetryBackoff <= 0 || notifications.SendInterval < 0 {
+		return notification3.Settings{}, fmt.Errorf("invalid notification settings: batch size, max attempts and retry backoff must be positive and send interval not negative")
+	}
+	return notification3.Settings{
+		DefaultLocale: notifications.DefaultLocale,
+		Location:      location,
+		BatchSize:     notifications.BatchSize,
+		MaxAttempts:   notifications.MaxAttempts,
+		RetryBackoff:  notifications.RetryBackoff,
+	}, nil
+}
+
+// provideNotificationTemplates loads the built-in email templates and those
+// in the configured directory
+func provideNotificationTemplates(cfg *config.Config) (*notification2.Templates, error) {
+	templates, err := notification2.LoadTemplates(cfg.Notifications.TemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+	if !templates.Supports(cfg.Notifications.DefaultLocale) {
+		return nil, fmt.Errorf("no notification templates for default locale %q", cfg.Notifications.DefaultLocale)
+	}
+	return templates, nil
+}
+
+// provideNotifier sends emails through the configured SMTP server, or writes
+// them to the log when there is none
+func provideNotifier(cfg *config.Config, logNotifier *notify.LogNotifier) (notification3.Notifier, error) {
+	smtpConfig := cfg.Notifications.SMTP
+	if smtpConfig.Host == "" {
+		return logNotifier, nil
+	}
+	return notify.NewSMTPNotifier(notify.SMTPSettings{
+		Host:     smtpConfig.Host,
+		Port:     smtpConfig.Port,
+		Username: smtpConfig.Username,
+		Password: smtpConfig.Password,
+		From:     smtpConfig.From,
+		TLS:      smtpConfig.TLS,
+		Timeout:  smtpConfig.Timeout,
+	})
+}
+
+// provideNotificationWorker creates the worker sending queued emails at the configured interval
+func provideNotificationWorker(cfg *config.Config, service *notification2.Service) *notification2.Worker {
+	return notification2.NewWorker(service, cfg.Notifications.SendInterval)
+}
+
+// provideStreamBroker creates the configured broker for live updates. The
+// memory broker only reaches the clients of its own instance, so deployments
+// running several use the database broker.
+func provideStreamBroker(cfg *config.Config, db *gorm.DB) (stream2.Broker, error) {
+	switch cfg.Stream.Broker {
+	case "memory":
+		return broker.NewMemory(cfg.Stream.BufferSize), nil
+	case "database":
+		return stream3.NewBroker(db, cfg.Stream.PollInterval, cfg.Stream.BufferSize), nil
+	default:
+		return nil, fmt.Errorf("unknown stream broker %q, expected memory or database", cfg.Stream.Broker)
+	}
 }
@@ -0,0 +1,66 @@
+package broker
+
+import (
+	"context"
+	"log"
+	"sarc-ng/internal/domain/stream"
+	"sync"
+)
+
+// defaultBufferSize is how many updates a subscriber may fall behind by
+const defaultBufferSize = 64
+
+// Memory fans updates out to the subscribers of this instance only. It suits
+// single node deployments; with several instances, a client only receives the
+// updates dispatched by the instance it is connected to.
+type Memory struct {
+	bufferSize int
+
+	mu          sync.RWMutex
+	subscribers map[chan stream.Update]struct{}
+}
+
+// Compile-time verification that Memory implements stream.Broker
+var _ stream.Broker = (*Memory)(nil)
+
+// NewMemory creates an in-memory broker keeping up to bufferSize updates for
+// every subscriber
+func NewMemory(bufferSize int) *Memory {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Memory{bufferSize: bufferSize, subscribers: make(map[chan stream.Update]struct{})}
+}
+
+// Publish hands the update to every subscriber with room for it
+func (b *Memory) Publish(_ context.Context, update stream.Update) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- update:
+		default:
+			log.Printf("Dropping %s update %d for a subscriber that fell behind", update.Type, update.ID)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber until ctx is done
+func (b *Memory) Subscribe(ctx context.Context) (<-chan stream.Update, error) {
+	ch := make(chan stream.Update, b.bufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+		close(ch)
+	}()
+	return ch, nil
+}
@@ -0,0 +1,63 @@
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"sarc-ng/internal/domain/event"
+	"slices"
+	"sync"
+)
+
+// Handler handles an event delivered in process. An error has the message
+// retried, which hands it to every subscriber of the sink again.
+type Handler func(ctx context.Context, message event.Message) error
+
+// InProcess delivers events to handlers subscribed within the application
+type InProcess struct {
+	mu          sync.RWMutex
+	subscribers []subscriber
+}
+
+// subscriber is a handler together with the event types it wants
+type subscriber struct {
+	handler Handler
+	types   []event.Type
+}
+
+// Compile-time verification that InProcess implements event.Sink
+var _ event.Sink = (*InProcess)(nil)
+
+// NewInProcess creates an in-process sink without subscribers
+func NewInProcess() *InProcess {
+	return &InProcess{}
+}
+
+// Subscribe registers a handler for the given event types, or for every
+// event when no type is given
+func (s *InProcess) Subscribe(handler Handler, types ...event.Type) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, subscriber{handler: handler, types: types})
+}
+
+// Name returns "in-process"
+func (s *InProcess) Name() string {
+	return "in-process"
+}
+
+// Deliver calls the handlers subscribed to the type of the message
+func (s *InProcess) Deliver(ctx context.Context, m event.Message) error {
+	s.mu.RLock()
+	subscribers := slices.Clone(s.subscribers)
+	s.mu.RUnlock()
+
+	for _, sub := range subscribers {
+		if len(sub.types) > 0 && !slices.Contains(sub.types, m.Type) {
+			continue
+		}
+		if err := sub.handler(ctx, m); err != nil {
+			return fmt.Errorf("subscriber failed: %w", err)
+		}
+	}
+	return nil
+}
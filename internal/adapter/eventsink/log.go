@@ -0,0 +1,30 @@
+// Package eventsink provides the sinks outbox messages are delivered to
+package eventsink
+
+import (
+	"context"
+	"log"
+	"sarc-ng/internal/domain/event"
+)
+
+// LogSink writes every event to the application log
+type LogSink struct{}
+
+// Compile-time verification that LogSink implements event.Sink
+var _ event.Sink = (*LogSink)(nil)
+
+// NewLogSink creates a new log sink
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+// Name returns "log"
+func (s *LogSink) Name() string {
+	return "log"
+}
+
+// Deliver logs the event
+func (s *LogSink) Deliver(_ context.Context, m event.Message) error {
+	log.Printf("Event %d %s of %s %d: %s", m.ID, m.Type, m.AggregateType, m.AggregateID, m.Payload)
+	return nil
+}
@@ -0,0 +1,73 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sarc-ng/internal/domain/event"
+	"slices"
+	"time"
+)
+
+// webhookTimeout bounds a single webhook request
+const webhookTimeout = 10 * time.Second
+
+// Webhook posts events to a URL configured in the events section. Any
+// response outside the 2xx range counts as a failed delivery.
+type Webhook struct {
+	url    string
+	types  []event.Type
+	client *http.Client
+}
+
+// Compile-time verification that Webhook implements event.Sink
+var _ event.Sink = (*Webhook)(nil)
+
+// NewWebhook creates a sink posting the given event types, or every event
+// when no type is given, to the URL
+func NewWebhook(url string, types ...event.Type) *Webhook {
+	return &Webhook{
+		url:    url,
+		types:  types,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Name identifies the webhook by its URL
+func (w *Webhook) Name() string {
+	return "webhook:" + w.url
+}
+
+// Deliver posts the message unless the webhook does not want its type
+func (w *Webhook) Deliver(ctx context.Context, m event.Message) error {
+	if len(w.types) > 0 && !slices.Contains(w.types, m.Type) {
+		return nil
+	}
+
+	body, err := json.Marshal(event.NewEnvelope(m))
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sarc-Event", string(m.Type))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package apikey
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/domain/auth"
+	domainCommon "sarc-ng/internal/domain/common"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements apikey.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements apikey.Repository
+var _ apikey.Repository = (*GormAdapter)(nil)
+
+// NewGormAdapter creates a new API key GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadAPIKeyList retrieves every key ordered by ID
+func (a *GormAdapter) ReadAPIKeyList() ([]apikey.APIKey, error) {
+	var models []GormModel
+	if err := a.db.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]apikey.APIKey, len(models))
+	for i, model := range models {
+		entities[i] = toDomain(model)
+	}
+	return entities, nil
+}
+
+// ReadAPIKey retrieves a key by ID
+func (a *GormAdapter) ReadAPIKey(id uint) (*apikey.APIKey, error) {
+	var model GormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("API key not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := toDomain(model)
+	return &entity, nil
+}
+
+// FindAPIKeyByHash retrieves the key with the hash, or nil if there is none
+func (a *GormAdapter) FindAPIKeyByHash(hash string) (*apikey.APIKey, error) {
+	var model GormModel
+	if err := a.db.Where("hash = ?", hash).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entity := toDomain(model)
+	return &entity, nil
+}
+
+// CreateAPIKey adds a new key
+func (a *GormAdapter) CreateAPIKey(k *apikey.APIKey) error {
+	model := toModel(*k)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields, keeping the key itself
+	key := k.Key
+	*k = toDomain(model)
+	k.Key = key
+	return nil
+}
+
+// UpdateAPIKey modifies an existing key
+func (a *GormAdapter) UpdateAPIKey(k *apikey.APIKey) error {
+	model := toModel(*k)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*k = toDomain(model)
+	return nil
+}
+
+// DeleteAPIKey removes a key by ID
+func (a *GormAdapter) DeleteAPIKey(id uint) error {
+	return a.db.Delete(&GormModel{}, id).Error
+}
+
+// TouchAPIKey records when a key was last used, without changing UpdatedAt
+func (a *GormAdapter) TouchAPIKey(id uint, usedAt time.Time) error {
+	return a.db.Model(&GormModel{}).Where("id = ?", id).UpdateColumn("last_used_at", usedAt).Error
+}
+
+// toModel converts domain entity to GORM model
+func toModel(entity apikey.APIKey) GormModel {
+	permissions := make([]string, len(entity.Permissions))
+	for i, p := range entity.Permissions {
+		permissions[i] = string(p)
+	}
+	buildings := make([]string, len(entity.BuildingIDs))
+	for i, id := range entity.BuildingIDs {
+		buildings[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return GormModel{
+		ID:          entity.ID,
+		Name:        entity.Name,
+		Prefix:      entity.Prefix,
+		Hash:        entity.Hash,
+		Permissions: strings.Join(permissions, ","),
+		BuildingIDs: strings.Join(buildings, ","),
+		ExpiresAt:   entity.ExpiresAt,
+		LastUsedAt:  entity.LastUsedAt,
+		CreatedBy:   entity.CreatedBy,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+// toDomain converts GORM model to domain entity
+func toDomain(model GormModel) apikey.APIKey {
+	var permissions []auth.Permission
+	if model.Permissions != "" {
+		for _, p := range strings.Split(model.Permissions, ",") {
+			permissions = append(permissions, auth.Permission(p))
+		}
+	}
+	var buildings []uint
+	if model.BuildingIDs != "" {
+		for _, s := range strings.Split(model.BuildingIDs, ",") {
+			if id, err := strconv.ParseUint(s, 10, 64); err == nil {
+				buildings = append(buildings, uint(id))
+			}
+		}
+	}
+	return apikey.APIKey{
+		ID:          model.ID,
+		Name:        model.Name,
+		Prefix:      model.Prefix,
+		Hash:        model.Hash,
+		Permissions: permissions,
+		BuildingIDs: buildings,
+		ExpiresAt:   model.ExpiresAt,
+		LastUsedAt:  model.LastUsedAt,
+		CreatedBy:   model.CreatedBy,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package apikey
+
+import (
+	"time"
+)
+
+// GormModel represents the GORM database model for API keys. Permissions and
+// building IDs are stored comma separated; the key itself is never stored.
+type GormModel struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string     `gorm:"type:varchar(255);not null" json:"name"`
+	Prefix      string     `gorm:"type:varchar(20);not null" json:"prefix"`
+	Hash        string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	Permissions string     `gorm:"type:varchar(1000);not null" json:"permissions"`
+	BuildingIDs string     `gorm:"type:varchar(1000)" json:"buildingIds"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+	CreatedBy   string     `gorm:"type:varchar(255)" json:"createdBy"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for the APIKey model
+func (GormModel) TableName() string {
+	return "api_keys"
+}
@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sarc-ng/internal/adapter/gorm/common"
+	"sarc-ng/internal/domain/audit"
+	domainCommon "sarc-ng/internal/domain/common"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements audit.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements audit.Repository
+var _ audit.Repository = (*GormAdapter)(nil)
+
+// columns lists the fields audit entries can be filtered and sorted by
+var columns = common.Columns{
+	"action":     "action",
+	"entityType": "entity_type",
+	"entityId":   "entity_id",
+	"actorId":    "actor_id",
+	"requestId":  "request_id",
+	"createdAt":  "created_at",
+}
+
+// NewGormAdapter creates a new audit GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadAuditEntryList retrieves the page of audit entries selected by the query
+func (a *GormAdapter) ReadAuditEntryList(query domainCommon.Query) (*domainCommon.Page[audit.Entry], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
+}
+
+// ReadAuditEntry retrieves an audit entry by ID
+func (a *GormAdapter) ReadAuditEntry(id uint) (*audit.Entry, error) {
+	var model GormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("audit entry not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// Trail implements audit.Trail by inserting entries with the GORM handle it
+// is given, which is a transaction in every unit of work
+type Trail struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that Trail implements audit.Trail
+var _ audit.Trail = (*Trail)(nil)
+
+// NewTrail creates a trail writing with db
+func NewTrail(db *gorm.DB) *Trail {
+	return &Trail{
+		db: db,
+	}
+}
+
+// RecordAuditEntries stores the entries
+func (t *Trail) RecordAuditEntries(entries ...audit.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	models := make([]GormModel, len(entries))
+	for i, e := range entries {
+		model, err := domainToModel(e)
+		if err != nil {
+			return err
+		}
+		models[i] = model
+	}
+	return t.db.Create(&models).Error
+}
+
+// change is the stored form of an audit.Change
+type change struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// domainToModel converts domain entity to GORM model
+func domainToModel(entity audit.Entry) (GormModel, error) {
+	changes := make([]change, len(entity.Changes))
+	for i, c := range entity.Changes {
+		changes[i] = change(c)
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return GormModel{}, fmt.Errorf("failed to encode changes of %s %d: %w", entity.EntityType, entity.EntityID, err)
+	}
+
+	return GormModel{
+		ID:            entity.ID,
+		Action:        string(entity.Action),
+		EntityType:    entity.EntityType,
+		EntityID:      entity.EntityID,
+		ActorID:       entity.ActorID,
+		ActorUsername: entity.ActorUsername,
+		ActorGroups:   strings.Join(entity.ActorGroups, ","),
+		RequestID:     entity.RequestID,
+		Changes:       string(data),
+		CreatedAt:     entity.CreatedAt,
+	}, nil
+}
+
+// modelToDomain converts GORM model to domain entity. Changed values come back
+// as their JSON types.
+func modelToDomain(model GormModel) audit.Entry {
+	var changes []change
+	if model.Changes != "" {
+		// Entries are only written by domainToModel, so the JSON is well formed
+		_ = json.Unmarshal([]byte(model.Changes), &changes)
+	}
+	entityChanges := make([]audit.Change, len(changes))
+	for i, c := range changes {
+		entityChanges[i] = audit.Change(c)
+	}
+
+	var groups []string
+	if model.ActorGroups != "" {
+		groups = strings.Split(model.ActorGroups, ",")
+	}
+	return audit.Entry{
+		ID:            model.ID,
+		Action:        audit.Action(model.Action),
+		EntityType:    model.EntityType,
+		EntityID:      model.EntityID,
+		ActorID:       model.ActorID,
+		ActorUsername: model.ActorUsername,
+		ActorGroups:   groups,
+		RequestID:     model.RequestID,
+		Changes:       entityChanges,
+		CreatedAt:     model.CreatedAt,
+	}
+}
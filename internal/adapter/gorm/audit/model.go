@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"time"
+)
+
+// GormModel represents the GORM database model for audit entries. Groups are
+// stored comma separated and changes as JSON.
+// idx_audit_entries_entity backs listing the history of an entity
+type GormModel struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Action        string    `gorm:"type:varchar(20);not null;index" json:"action"`
+	EntityType    string    `gorm:"type:varchar(50);not null;index:idx_audit_entries_entity,priority:1" json:"entityType"`
+	EntityID      uint      `gorm:"not null;index:idx_audit_entries_entity,priority:2" json:"entityId"`
+	ActorID       string    `gorm:"type:varchar(255);index" json:"actorId"`
+	ActorUsername string    `gorm:"type:varchar(255)" json:"actorUsername"`
+	ActorGroups   string    `gorm:"type:varchar(500)" json:"actorGroups"`
+	RequestID     string    `gorm:"type:varchar(128);index" json:"requestId"`
+	Changes       string    `gorm:"type:text" json:"changes"`
+	CreatedAt     time.Time `gorm:"autoCreateTime;index" json:"createdAt"`
+}
+
+// TableName returns the table name for the audit entry model
+func (GormModel) TableName() string {
+	return "audit_entries"
+}
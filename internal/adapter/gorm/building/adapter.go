@@ -17,6 +17,14 @@ type GormAdapter struct {
 // Compile-time verification that GormAdapter implements building.Repository
 var _ building.Repository = (*GormAdapter)(nil)
 
+// columns lists the fields buildings can be filtered and sorted by
+var columns = common.Columns{
+	"name":      "name",
+	"code":      "code",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
 // NewGormAdapter creates a new building GORM adapter
 func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	return &GormAdapter{
@@ -24,18 +32,9 @@ func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	}
 }
 
-// ReadBuildingList retrieves all buildings
-func (a *GormAdapter) ReadBuildingList() ([]building.Building, error) {
-	var models []GormModel
-	if err := a.db.Find(&models).Error; err != nil {
-		return nil, err
-	}
-
-	entities := make([]building.Building, len(models))
-	for i, model := range models {
-		entities[i] = modelToDomain(model)
-	}
-	return entities, nil
+// ReadBuildingList retrieves the page of buildings selected by the query
+func (a *GormAdapter) ReadBuildingList(query domainCommon.Query) (*domainCommon.Page[building.Building], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
 }
 
 // ReadBuilding retrieves a building by ID
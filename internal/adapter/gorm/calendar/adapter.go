@@ -0,0 +1,114 @@
+package calendar
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/calendar"
+	domainCommon "sarc-ng/internal/domain/common"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements calendar.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements calendar.Repository
+var _ calendar.Repository = (*GormAdapter)(nil)
+
+// NewGormAdapter creates a new calendar GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadFeedTokenList retrieves the feed tokens of a user
+func (a *GormAdapter) ReadFeedTokenList(userID string) ([]calendar.FeedToken, error) {
+	var models []FeedTokenGormModel
+	if err := a.db.Where("user_id = ?", userID).Order("created_at").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]calendar.FeedToken, len(models))
+	for i, model := range models {
+		entities[i] = modelToDomain(model)
+	}
+	return entities, nil
+}
+
+// ReadFeedToken retrieves a feed token by ID
+func (a *GormAdapter) ReadFeedToken(id uint) (*calendar.FeedToken, error) {
+	return a.readFeedToken(a.db.Where("id = ?", id))
+}
+
+// ReadFeedTokenByHash retrieves a feed token by the hash of its secret
+func (a *GormAdapter) ReadFeedTokenByHash(hash string) (*calendar.FeedToken, error) {
+	return a.readFeedToken(a.db.Where("token_hash = ?", hash))
+}
+
+// CreateFeedToken adds a new feed token
+func (a *GormAdapter) CreateFeedToken(t *calendar.FeedToken) error {
+	model := domainToModel(*t)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*t = modelToDomain(model)
+	return nil
+}
+
+// UpdateFeedToken modifies an existing feed token
+func (a *GormAdapter) UpdateFeedToken(t *calendar.FeedToken) error {
+	model := domainToModel(*t)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*t = modelToDomain(model)
+	return nil
+}
+
+// readFeedToken retrieves the first feed token matching query
+func (a *GormAdapter) readFeedToken(query *gorm.DB) (*calendar.FeedToken, error) {
+	var model FeedTokenGormModel
+	if err := query.First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("feed token not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// domainToModel converts domain entity to GORM model
+func domainToModel(entity calendar.FeedToken) FeedTokenGormModel {
+	return FeedTokenGormModel{
+		ID:         entity.ID,
+		UserID:     entity.UserID,
+		Label:      entity.Label,
+		TokenHash:  entity.TokenHash,
+		LastUsedAt: entity.LastUsedAt,
+		RevokedAt:  entity.RevokedAt,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+	}
+}
+
+// modelToDomain converts GORM model to domain entity
+func modelToDomain(model FeedTokenGormModel) calendar.FeedToken {
+	return calendar.FeedToken{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		Label:      model.Label,
+		TokenHash:  model.TokenHash,
+		LastUsedAt: model.LastUsedAt,
+		RevokedAt:  model.RevokedAt,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
@@ -0,0 +1,22 @@
+package calendar
+
+import (
+	"time"
+)
+
+// FeedTokenGormModel represents the GORM database model for calendar feed tokens
+type FeedTokenGormModel struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID     string     `gorm:"type:varchar(255);not null;index" json:"userId"`
+	Label      string     `gorm:"type:varchar(100)" json:"label"`
+	TokenHash  string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for the FeedToken model
+func (FeedTokenGormModel) TableName() string {
+	return "calendar_feed_tokens"
+}
@@ -0,0 +1,143 @@
+package checkin
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/checkin"
+	domainCommon "sarc-ng/internal/domain/common"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements checkin.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements checkin.Repository
+var _ checkin.Repository = (*GormAdapter)(nil)
+
+// NewGormAdapter creates a new check-in GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadCheckInTokenByHash retrieves a check-in token by the hash of its secret
+func (a *GormAdapter) ReadCheckInTokenByHash(hash string) (*checkin.Token, error) {
+	var model TokenGormModel
+	if err := a.db.Where("token_hash = ?", hash).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("check-in token not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := tokenToDomain(model)
+	return &entity, nil
+}
+
+// CreateCheckInToken adds a new check-in token
+func (a *GormAdapter) CreateCheckInToken(t *checkin.Token) error {
+	model := tokenToModel(*t)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*t = tokenToDomain(model)
+	return nil
+}
+
+// RevokeCheckInTokens revokes the unrevoked check-in tokens of a resource
+func (a *GormAdapter) RevokeCheckInTokens(resourceID uint, at time.Time) error {
+	return a.db.Model(&TokenGormModel{}).
+		Where("resource_id = ? AND revoked_at IS NULL", resourceID).
+		Update("revoked_at", at).Error
+}
+
+// ReadNoShowList retrieves the no-shows of a user, most recent first
+func (a *GormAdapter) ReadNoShowList(userID string) ([]checkin.NoShow, error) {
+	var models []NoShowGormModel
+	if err := a.db.Where("user_id = ?", userID).Order("start_time DESC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]checkin.NoShow, len(models))
+	for i, model := range models {
+		entities[i] = noShowToDomain(model)
+	}
+	return entities, nil
+}
+
+// CreateNoShow records a new no-show
+func (a *GormAdapter) CreateNoShow(n *checkin.NoShow) error {
+	model := noShowToModel(*n)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*n = noShowToDomain(model)
+	return nil
+}
+
+// CountNoShows counts the no-shows of a user at reservations starting after since
+func (a *GormAdapter) CountNoShows(userID string, since time.Time) (int, error) {
+	var count int64
+	err := a.db.Model(&NoShowGormModel{}).
+		Where("user_id = ? AND start_time > ?", userID, since).
+		Count(&count).Error
+	return int(count), err
+}
+
+// tokenToModel converts a check-in token to its GORM model
+func tokenToModel(entity checkin.Token) TokenGormModel {
+	return TokenGormModel{
+		ID:         entity.ID,
+		ResourceID: entity.ResourceID,
+		TokenHash:  entity.TokenHash,
+		IssuedBy:   entity.IssuedBy,
+		RevokedAt:  entity.RevokedAt,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+	}
+}
+
+// tokenToDomain converts a GORM model to a check-in token
+func tokenToDomain(model TokenGormModel) checkin.Token {
+	return checkin.Token{
+		ID:         model.ID,
+		ResourceID: model.ResourceID,
+		TokenHash:  model.TokenHash,
+		IssuedBy:   model.IssuedBy,
+		RevokedAt:  model.RevokedAt,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
+
+// noShowToModel converts a no-show to its GORM model
+func noShowToModel(entity checkin.NoShow) NoShowGormModel {
+	return NoShowGormModel{
+		ID:            entity.ID,
+		UserID:        entity.UserID,
+		ReservationID: entity.ReservationID,
+		ResourceID:    entity.ResourceID,
+		StartTime:     entity.StartTime,
+		CreatedAt:     entity.CreatedAt,
+	}
+}
+
+// noShowToDomain converts a GORM model to a no-show
+func noShowToDomain(model NoShowGormModel) checkin.NoShow {
+	return checkin.NoShow{
+		ID:            model.ID,
+		UserID:        model.UserID,
+		ReservationID: model.ReservationID,
+		ResourceID:    model.ResourceID,
+		StartTime:     model.StartTime,
+		CreatedAt:     model.CreatedAt,
+	}
+}
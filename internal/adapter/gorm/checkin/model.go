@@ -0,0 +1,37 @@
+package checkin
+
+import (
+	"time"
+)
+
+// TokenGormModel represents the GORM database model for check-in tokens
+type TokenGormModel struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	ResourceID uint       `gorm:"not null;index" json:"resourceId"`
+	TokenHash  string     `gorm:"type:char(64);not null;uniqueIndex" json:"-"`
+	IssuedBy   string     `gorm:"type:varchar(255)" json:"issuedBy"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for the Token model
+func (TokenGormModel) TableName() string {
+	return "check_in_tokens"
+}
+
+// NoShowGormModel represents the GORM database model for no-shows.
+// idx_no_shows_user backs CountNoShows.
+type NoShowGormModel struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        string    `gorm:"type:varchar(255);not null;index:idx_no_shows_user,priority:1" json:"userId"`
+	ReservationID uint      `gorm:"not null;uniqueIndex" json:"reservationId"`
+	ResourceID    uint      `gorm:"not null" json:"resourceId"`
+	StartTime     time.Time `gorm:"not null;index:idx_no_shows_user,priority:2" json:"startTime"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"createdAt"`
+}
+
+// TableName returns the table name for the NoShow model
+func (NoShowGormModel) TableName() string {
+	return "no_shows"
+}
@@ -0,0 +1,35 @@
+package checkin
+
+import (
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/reservation"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork implements checkin.UnitOfWork using GORM transactions
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that UnitOfWork implements checkin.UnitOfWork
+var _ checkin.UnitOfWork = (*UnitOfWork)(nil)
+
+// NewUnitOfWork creates a new check-in unit of work
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn with check-in and reservation adapters, an outbox and an audit trail bound to one transaction
+func (u *UnitOfWork) Do(fn func(checkins checkin.Repository, reservations reservation.Repository, events event.Outbox, trail audit.Trail) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGormAdapter(tx), reservationAdapter.NewGormAdapter(tx), eventAdapter.NewOutbox(tx), auditAdapter.NewTrail(tx))
+	})
+}
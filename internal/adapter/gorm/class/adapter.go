@@ -17,6 +17,15 @@ type GormAdapter struct {
 // Compile-time verification that GormAdapter implements class.Repository
 var _ class.Repository = (*GormAdapter)(nil)
 
+// columns lists the fields classes can be filtered and sorted by
+var columns = common.Columns{
+	"name":       "name",
+	"capacity":   "capacity",
+	"buildingId": "building_id",
+	"createdAt":  "created_at",
+	"updatedAt":  "updated_at",
+}
+
 // NewGormAdapter creates a new class GORM adapter
 func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	return &GormAdapter{
@@ -24,18 +33,9 @@ func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	}
 }
 
-// ReadClassList retrieves all classes
-func (a *GormAdapter) ReadClassList() ([]class.Class, error) {
-	var models []GormModel
-	if err := a.db.Find(&models).Error; err != nil {
-		return nil, err
-	}
-
-	entities := make([]class.Class, len(models))
-	for i, model := range models {
-		entities[i] = modelToDomain(model)
-	}
-	return entities, nil
+// ReadClassList retrieves the page of classes selected by the query
+func (a *GormAdapter) ReadClassList(query domainCommon.Query) (*domainCommon.Page[class.Class], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
 }
 
 // ReadClass retrieves a class by ID
@@ -52,6 +52,20 @@ func (a *GormAdapter) ReadClass(id uint) (*class.Class, error) {
 	return &entity, nil
 }
 
+// ReadClassListByBuilding retrieves the classes of a building
+func (a *GormAdapter) ReadClassListByBuilding(buildingID uint) ([]class.Class, error) {
+	var models []GormModel
+	if err := a.db.Where("building_id = ?", buildingID).Order("name").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]class.Class, len(models))
+	for i, model := range models {
+		entities[i] = modelToDomain(model)
+	}
+	return entities, nil
+}
+
 // CreateClass adds a new class
 func (a *GormAdapter) CreateClass(c *class.Class) error {
 	model := domainToModel(*c)
@@ -83,7 +97,7 @@ func (a *GormAdapter) DeleteClass(id uint) error {
 
 // domainToModel converts domain entity to GORM model
 func domainToModel(entity class.Class) GormModel {
-	return GormModel{
+	model := GormModel{
 		ID:        entity.ID,
 		Name:      entity.Name,
 		Capacity:  entity.Capacity,
@@ -91,11 +105,15 @@ func domainToModel(entity class.Class) GormModel {
 		UpdatedAt: entity.UpdatedAt,
 		DeletedAt: common.ConvertTimeToGormDeletedAt(entity.DeletedAt),
 	}
+	if entity.BuildingID != 0 {
+		model.BuildingID = &entity.BuildingID
+	}
+	return model
 }
 
 // modelToDomain converts GORM model to domain entity
 func modelToDomain(model GormModel) class.Class {
-	return class.Class{
+	entity := class.Class{
 		ID:        model.ID,
 		Name:      model.Name,
 		Capacity:  model.Capacity,
@@ -103,4 +121,8 @@ func modelToDomain(model GormModel) class.Class {
 		UpdatedAt: model.UpdatedAt,
 		DeletedAt: common.ConvertGormDeletedAtToTime(model.DeletedAt),
 	}
+	if model.BuildingID != nil {
+		entity.BuildingID = *model.BuildingID
+	}
+	return entity
 }
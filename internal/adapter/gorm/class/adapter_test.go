@@ -0,0 +1,33 @@
+package class
+
+import (
+	"testing"
+
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	"sarc-ng/internal/domain/class"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildingForeignKey(t *testing.T) {
+	db := gormtest.Open(t, &GormModel{})
+	adapter := NewGormAdapter(db)
+
+	main := &buildingAdapter.GormModel{Name: "Main", Code: "MAIN"}
+	require.NoError(t, db.Create(main).Error)
+
+	room := &class.Class{Name: "Room 101", Capacity: 30, BuildingID: main.ID}
+	require.NoError(t, adapter.CreateClass(room))
+
+	assert.Error(t, adapter.CreateClass(&class.Class{Name: "Nowhere", BuildingID: main.ID + 1}), "unknown building")
+	assert.Error(t, db.Unscoped().Delete(main).Error, "building that still has a class")
+
+	// Classes without a building are stored with no key rather than a zero one
+	legacy := &class.Class{Name: "Legacy room"}
+	require.NoError(t, adapter.CreateClass(legacy))
+	stored, err := adapter.ReadClass(legacy.ID)
+	require.NoError(t, err)
+	assert.Zero(t, stored.BuildingID)
+}
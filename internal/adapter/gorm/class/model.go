@@ -1,6 +1,8 @@
 package class
 
 import (
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,15 +10,35 @@ import (
 
 // GormModel represents the GORM database model for classes
 type GormModel struct {
-	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name      string         `gorm:"type:varchar(255);not null" json:"name"`
-	Capacity  int            `gorm:"not null;default:0" json:"capacity"`
-	CreatedAt time.Time      `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID       uint   `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name     string `gorm:"type:varchar(255);not null" json:"name"`
+	Capacity int    `gorm:"not null;default:0" json:"capacity"`
+	// BuildingID is NULL for classes created before classes belonged to buildings
+	BuildingID *uint                      `gorm:"index" json:"buildingId"`
+	Building   *buildingAdapter.GormModel `gorm:"constraint:OnDelete:RESTRICT" json:"-"`
+	// Resources declares the key of resources to their class on this side, as
+	// the class unit of work depends on the resource adapter
+	Resources []resourceAdapter.GormModel `gorm:"foreignKey:ClassID;constraint:OnDelete:RESTRICT" json:"-"`
+	CreatedAt time.Time                   `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt time.Time                   `gorm:"autoUpdateTime" json:"updatedAt"`
+	DeletedAt gorm.DeletedAt              `gorm:"index" json:"-"`
 }
 
 // TableName returns the table name for the Class model
 func (GormModel) TableName() string {
 	return "classes"
 }
+
+// PrepareMigration clears the zero building ID that classes created before
+// classes belonged to buildings were stored with, so that the foreign key to
+// buildings can be added. It does nothing once the key exists.
+func PrepareMigration(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&GormModel{}) || migrator.HasConstraint(&GormModel{}, "Building") {
+		return nil
+	}
+	if err := migrator.AlterColumn(&GormModel{}, "BuildingID"); err != nil {
+		return err
+	}
+	return db.Unscoped().Model(&GormModel{}).Where("building_id = ?", 0).UpdateColumn("building_id", nil).Error
+}
@@ -0,0 +1,35 @@
+package class
+
+import (
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/class"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/resource"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork implements class.UnitOfWork using GORM transactions
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that UnitOfWork implements class.UnitOfWork
+var _ class.UnitOfWork = (*UnitOfWork)(nil)
+
+// NewUnitOfWork creates a new class unit of work
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn with class and resource adapters, an outbox and an audit trail bound to one transaction
+func (u *UnitOfWork) Do(fn func(repo class.Repository, resources resource.Repository, events event.Outbox, trail audit.Trail) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGormAdapter(tx), resourceAdapter.NewGormAdapter(tx), eventAdapter.NewOutbox(tx), auditAdapter.NewTrail(tx))
+	})
+}
@@ -0,0 +1,144 @@
+package common
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	domainCommon "sarc-ng/internal/domain/common"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// Columns maps the API field names a list may be filtered and sorted by to
+// their SQL columns. Fields missing from the map are rejected.
+type Columns map[string]string
+
+// operators translates filter operators to SQL
+var operators = map[domainCommon.Operator]string{
+	domainCommon.OpEqual:          "=",
+	domainCommon.OpGreaterOrEqual: ">=",
+	domainCommon.OpLessOrEqual:    "<=",
+}
+
+// FindPage reads the page of models selected by the query and converts it to
+// domain entities. The total counts every match regardless of paging.
+func FindPage[M any, T any](db *gorm.DB, query domainCommon.Query, columns Columns, toDomain func(M) T) (*domainCommon.Page[T], error) {
+	db, err := applyFilters(db.Model(new(M)), query.Filters, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	sortColumn, err := sortColumn(query.Sort, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	direction := "ASC"
+	if query.Desc {
+		direction = "DESC"
+	}
+	db = db.Order(sortColumn + " " + direction)
+	if sortColumn != "id" {
+		// Keep pages stable when several rows share the sort value
+		db = db.Order("id " + direction)
+	}
+
+	switch {
+	case query.Cursor != "":
+		if sortColumn != "id" {
+			return nil, fmt.Errorf("%w: cursor pagination requires sorting by %s", domainCommon.ErrInvalidInput, domainCommon.SortID)
+		}
+		after, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if query.Desc {
+			db = db.Where("id < ?", after)
+		} else {
+			db = db.Where("id > ?", after)
+		}
+	case query.Offset > 0:
+		db = db.Offset(query.Offset)
+	}
+
+	if query.Limit > 0 {
+		// Read one extra row to learn whether another page follows
+		db = db.Limit(query.Limit + 1)
+	}
+
+	var models []M
+	if err := db.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	page := &domainCommon.Page[T]{Total: total}
+	if query.Limit > 0 && len(models) > query.Limit {
+		models = models[:query.Limit]
+		if sortColumn == "id" {
+			page.NextCursor = encodeCursor(primaryKey(models[len(models)-1]))
+		}
+	}
+
+	page.Items = make([]T, len(models))
+	for i, model := range models {
+		page.Items[i] = toDomain(model)
+	}
+	return page, nil
+}
+
+// applyFilters adds a WHERE condition for each filter
+func applyFilters(db *gorm.DB, filters []domainCommon.Filter, columns Columns) (*gorm.DB, error) {
+	for _, filter := range filters {
+		column, ok := columns[filter.Field]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot filter by %q", domainCommon.ErrInvalidInput, filter.Field)
+		}
+		operator, ok := operators[filter.Op]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown filter operator %q", domainCommon.ErrInvalidInput, filter.Op)
+		}
+		db = db.Where(column+" "+operator+" ?", filter.Value)
+	}
+	return db, nil
+}
+
+// sortColumn resolves the sort field, defaulting to the ID
+func sortColumn(field string, columns Columns) (string, error) {
+	if field == "" || field == domainCommon.SortID {
+		return "id", nil
+	}
+	column, ok := columns[field]
+	if !ok {
+		return "", fmt.Errorf("%w: cannot sort by %q", domainCommon.ErrInvalidInput, field)
+	}
+	return column, nil
+}
+
+// primaryKey reads the ID field every GORM model in this repository declares
+func primaryKey(model any) uint64 {
+	return reflect.Indirect(reflect.ValueOf(model)).FieldByName("ID").Uint()
+}
+
+// encodeCursor hides the last ID of a page behind an opaque token
+func encodeCursor(id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(id, 10)))
+}
+
+// decodeCursor recovers the last ID of the previous page
+func decodeCursor(cursor string) (uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", domainCommon.ErrInvalidInput)
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: malformed cursor", domainCommon.ErrInvalidInput)
+	}
+	return id, nil
+}
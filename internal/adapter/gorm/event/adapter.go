@@ -0,0 +1,147 @@
+package event
+
+import (
+	"fmt"
+	"sarc-ng/internal/adapter/gorm/common"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements event.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements event.Repository
+var _ event.Repository = (*GormAdapter)(nil)
+
+// columns lists the fields outbox messages can be filtered and sorted by
+var columns = common.Columns{
+	"type":          "type",
+	"aggregateType": "aggregate_type",
+	"aggregateId":   "aggregate_id",
+	"status":        "status",
+	"attempts":      "attempts",
+	"nextAttemptAt": "next_attempt_at",
+	"occurredAt":    "occurred_at",
+	"deliveredAt":   "delivered_at",
+}
+
+// NewGormAdapter creates a new event GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadOutboxMessageList retrieves the page of outbox messages selected by the query
+func (a *GormAdapter) ReadOutboxMessageList(query domainCommon.Query) (*domainCommon.Page[event.Message], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
+}
+
+// ReadOutboxMessage retrieves an outbox message by ID
+func (a *GormAdapter) ReadOutboxMessage(id uint) (*event.Message, error) {
+	var model OutboxGormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("outbox message not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// UpdateOutboxMessage modifies an existing outbox message
+func (a *GormAdapter) UpdateOutboxMessage(m *event.Message) error {
+	model := domainToModel(*m)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*m = modelToDomain(model)
+	return nil
+}
+
+// FindDueOutboxMessages returns up to limit pending messages due at now that
+// no dispatcher holds, oldest first
+func (a *GormAdapter) FindDueOutboxMessages(now time.Time, limit int) ([]event.Message, error) {
+	var models []OutboxGormModel
+	err := a.db.
+		Where("status = ? AND next_attempt_at <= ?", string(event.StatusPending), now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]event.Message, len(models))
+	for i, model := range models {
+		messages[i] = modelToDomain(model)
+	}
+	return messages, nil
+}
+
+// ClaimOutboxMessage holds a pending message until the given time. The check
+// and the update are a single statement, so concurrent dispatchers cannot
+// both claim the same message.
+func (a *GormAdapter) ClaimOutboxMessage(id uint, now, until time.Time) (bool, error) {
+	result := a.db.Model(&OutboxGormModel{}).
+		Where("id = ? AND status = ?", id, string(event.StatusPending)).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Update("locked_until", until)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim outbox message: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// domainToModel converts domain entity to GORM model
+func domainToModel(entity event.Message) OutboxGormModel {
+	return OutboxGormModel{
+		ID:            entity.ID,
+		Type:          string(entity.Type),
+		AggregateType: entity.AggregateType,
+		AggregateID:   entity.AggregateID,
+		Payload:       string(entity.Payload),
+		Status:        string(entity.Status),
+		Attempts:      entity.Attempts,
+		NextAttemptAt: entity.NextAttemptAt,
+		LockedUntil:   entity.LockedUntil,
+		DeliveredTo:   strings.Join(entity.DeliveredTo, ","),
+		LastError:     entity.LastError,
+		OccurredAt:    entity.OccurredAt,
+		DeliveredAt:   entity.DeliveredAt,
+	}
+}
+
+// modelToDomain converts GORM model to domain entity
+func modelToDomain(model OutboxGormModel) event.Message {
+	var deliveredTo []string
+	if model.DeliveredTo != "" {
+		deliveredTo = strings.Split(model.DeliveredTo, ",")
+	}
+	return event.Message{
+		ID:            model.ID,
+		Type:          event.Type(model.Type),
+		AggregateType: model.AggregateType,
+		AggregateID:   model.AggregateID,
+		Payload:       []byte(model.Payload),
+		Status:        event.Status(model.Status),
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		LockedUntil:   model.LockedUntil,
+		DeliveredTo:   deliveredTo,
+		LastError:     model.LastError,
+		OccurredAt:    model.OccurredAt,
+		DeliveredAt:   model.DeliveredAt,
+	}
+}
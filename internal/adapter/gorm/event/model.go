@@ -0,0 +1,28 @@
+package event
+
+import (
+	"time"
+)
+
+// OutboxGormModel represents the GORM database model for outbox messages
+// idx_outbox_messages_due backs the dispatch query in FindDueOutboxMessages
+type OutboxGormModel struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Type          string     `gorm:"type:varchar(100);not null;index" json:"type"`
+	AggregateType string     `gorm:"type:varchar(50);not null;index:idx_outbox_messages_aggregate,priority:1" json:"aggregateType"`
+	AggregateID   uint       `gorm:"not null;index:idx_outbox_messages_aggregate,priority:2" json:"aggregateId"`
+	Payload       string     `gorm:"type:text;not null" json:"payload"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_messages_due,priority:1" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_outbox_messages_due,priority:2" json:"nextAttemptAt"`
+	LockedUntil   *time.Time `json:"lockedUntil"`
+	DeliveredTo   string     `gorm:"type:varchar(1000)" json:"deliveredTo"`
+	LastError     string     `gorm:"type:text" json:"lastError"`
+	OccurredAt    time.Time  `gorm:"not null;index" json:"occurredAt"`
+	DeliveredAt   *time.Time `json:"deliveredAt"`
+}
+
+// TableName returns the table name for the Message model
+func (OutboxGormModel) TableName() string {
+	return "outbox_messages"
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sarc-ng/internal/domain/event"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Outbox implements event.Outbox by inserting messages with the GORM handle it
+// is given, which is a transaction in every unit of work
+type Outbox struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that Outbox implements event.Outbox
+var _ event.Outbox = (*Outbox)(nil)
+
+// NewOutbox creates an outbox writing with db
+func NewOutbox(db *gorm.DB) *Outbox {
+	return &Outbox{
+		db: db,
+	}
+}
+
+// RecordEvents stores the events as pending outbox messages
+func (o *Outbox) RecordEvents(events ...event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]OutboxGormModel, len(events))
+	for i, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s event: %w", e.EventType(), err)
+		}
+		models[i] = OutboxGormModel{
+			Type:          string(e.EventType()),
+			AggregateType: e.AggregateType(),
+			AggregateID:   e.AggregateID(),
+			Payload:       string(payload),
+			Status:        string(event.StatusPending),
+			NextAttemptAt: now,
+			OccurredAt:    now,
+		}
+	}
+	return o.db.Create(&models).Error
+}
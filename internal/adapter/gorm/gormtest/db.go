@@ -0,0 +1,40 @@
+// Package gormtest provides a throwaway SQLite database for GORM adapter tests
+package gormtest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open creates a file-backed SQLite database in the test's temp directory and
+// migrates the given models into it. Transactions begin IMMEDIATE so that, as
+// with row locks on MySQL, concurrent writers queue instead of failing, and
+// foreign keys are enforced as they are on MySQL.
+// The connection is closed on cleanup.
+func Open(tb testing.TB, models ...any) *gorm.DB {
+	tb.Helper()
+
+	dsn := filepath.Join(tb.TempDir(), "test.db") + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		tb.Fatalf("failed to open test database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		tb.Fatalf("failed to get test database instance: %v", err)
+	}
+	tb.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(models...); err != nil {
+		tb.Fatalf("failed to migrate test database: %v", err)
+	}
+
+	return db
+}
@@ -0,0 +1,135 @@
+package job
+
+import (
+	"fmt"
+	"sarc-ng/internal/adapter/gorm/common"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/job"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormAdapter implements job.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements job.Repository
+var _ job.Repository = (*GormAdapter)(nil)
+
+// columns lists the fields job runs can be filtered and sorted by
+var columns = common.Columns{
+	"job":       "job",
+	"trigger":   "triggered_by",
+	"status":    "status",
+	"startedAt": "started_at",
+}
+
+// NewGormAdapter creates a new job GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadJobRunList retrieves the page of job runs selected by the query
+func (a *GormAdapter) ReadJobRunList(query domainCommon.Query) (*domainCommon.Page[job.Run], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
+}
+
+// ReadLastJobRun retrieves the most recent run of a job
+func (a *GormAdapter) ReadLastJobRun(name string) (*job.Run, error) {
+	var model RunGormModel
+	if err := a.db.Where("job = ?", name).Order("started_at DESC").Order("id DESC").First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// CreateJobRun records a new job run
+func (a *GormAdapter) CreateJobRun(r *job.Run) error {
+	model := domainToModel(*r)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*r = modelToDomain(model)
+	return nil
+}
+
+// UpdateJobRun modifies an existing job run
+func (a *GormAdapter) UpdateJobRun(r *job.Run) error {
+	model := domainToModel(*r)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*r = modelToDomain(model)
+	return nil
+}
+
+// AcquireJobLock takes the lock if nobody has it, renews it if the holder has
+// it and takes it over if it has expired. Each step is a single statement, so
+// concurrent callers cannot both succeed.
+func (a *GormAdapter) AcquireJobLock(name, holder string, now time.Time, ttl time.Duration) (bool, error) {
+	lock := LockGormModel{Name: name, Holder: holder, ExpiresAt: now.Add(ttl)}
+	result := a.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&lock)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create job lock: %w", result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return true, nil
+	}
+
+	result = a.db.Model(&LockGormModel{}).
+		Where("name = ? AND (holder = ? OR expires_at <= ?)", name, holder, now).
+		Updates(map[string]any{"holder": holder, "expires_at": now.Add(ttl)})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to take job lock: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseJobLock deletes the lock if the holder has it
+func (a *GormAdapter) ReleaseJobLock(name, holder string) error {
+	return a.db.Where("name = ? AND holder = ?", name, holder).Delete(&LockGormModel{}).Error
+}
+
+// domainToModel converts domain entity to GORM model
+func domainToModel(entity job.Run) RunGormModel {
+	return RunGormModel{
+		ID:          entity.ID,
+		Job:         entity.Job,
+		TriggeredBy: string(entity.Trigger),
+		Holder:      entity.Holder,
+		Status:      string(entity.Status),
+		Summary:     entity.Summary,
+		Error:       entity.Error,
+		StartedAt:   entity.StartedAt,
+		FinishedAt:  entity.FinishedAt,
+	}
+}
+
+// modelToDomain converts GORM model to domain entity
+func modelToDomain(model RunGormModel) job.Run {
+	return job.Run{
+		ID:         model.ID,
+		Job:        model.Job,
+		Trigger:    job.Trigger(model.TriggeredBy),
+		Holder:     model.Holder,
+		Status:     job.RunStatus(model.Status),
+		Summary:    model.Summary,
+		Error:      model.Error,
+		StartedAt:  model.StartedAt,
+		FinishedAt: model.FinishedAt,
+	}
+}
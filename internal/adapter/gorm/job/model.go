@@ -0,0 +1,35 @@
+package job
+
+import (
+	"time"
+)
+
+// RunGormModel represents the GORM database model for job runs
+type RunGormModel struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Job         string     `gorm:"type:varchar(100);not null;index:idx_job_runs_job,priority:1" json:"job"`
+	TriggeredBy string     `gorm:"type:varchar(20);not null" json:"triggeredBy"`
+	Holder      string     `gorm:"type:varchar(255)" json:"holder"`
+	Status      string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Summary     string     `gorm:"type:varchar(500)" json:"summary"`
+	Error       string     `gorm:"type:text" json:"error"`
+	StartedAt   time.Time  `gorm:"not null;index:idx_job_runs_job,priority:2" json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt"`
+}
+
+// TableName returns the table name for the Run model
+func (RunGormModel) TableName() string {
+	return "job_runs"
+}
+
+// LockGormModel represents the GORM database model for job locks
+type LockGormModel struct {
+	Name      string    `gorm:"type:varchar(100);primaryKey" json:"name"`
+	Holder    string    `gorm:"type:varchar(255);not null" json:"holder"`
+	ExpiresAt time.Time `gorm:"not null" json:"expiresAt"`
+}
+
+// TableName returns the table name for the Lock model
+func (LockGormModel) TableName() string {
+	return "job_locks"
+}
@@ -0,0 +1,50 @@
+package job
+
+import (
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	classAdapter "sarc-ng/internal/adapter/gorm/class"
+	lessonAdapter "sarc-ng/internal/adapter/gorm/lesson"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/job"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Purger implements job.Purger for every soft-deleted GORM model
+type Purger struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that Purger implements job.Purger
+var _ job.Purger = (*Purger)(nil)
+
+// NewPurger creates a new purger of soft-deleted rows
+func NewPurger(db *gorm.DB) *Purger {
+	return &Purger{
+		db: db,
+	}
+}
+
+// PurgeDeleted permanently removes the rows soft-deleted before the given time.
+// Dependent rows are purged before the rows they refer to.
+func (p *Purger) PurgeDeleted(before time.Time) (int, error) {
+	models := []any{
+		&reservationAdapter.GormModel{},
+		&lessonAdapter.GormModel{},
+		&resourceAdapter.GormModel{},
+		&classAdapter.GormModel{},
+		&buildingAdapter.GormModel{},
+	}
+
+	purged := 0
+	for _, model := range models {
+		result := p.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(model)
+		if result.Error != nil {
+			return purged, result.Error
+		}
+		purged += int(result.RowsAffected)
+	}
+	return purged, nil
+}
@@ -17,6 +17,17 @@ type GormAdapter struct {
 // Compile-time verification that GormAdapter implements lesson.Repository
 var _ lesson.Repository = (*GormAdapter)(nil)
 
+// columns lists the fields lessons can be filtered and sorted by
+var columns = common.Columns{
+	"title":     "title",
+	"duration":  "duration",
+	"startTime": "start_time",
+	"endTime":   "end_time",
+	"classId":   "class_id",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
 // NewGormAdapter creates a new lesson GORM adapter
 func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	return &GormAdapter{
@@ -24,10 +35,29 @@ func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	}
 }
 
-// ReadLessonList retrieves all lessons
-func (a *GormAdapter) ReadLessonList() ([]lesson.Lesson, error) {
+// ReadLessonList retrieves the page of lessons selected by the query
+func (a *GormAdapter) ReadLessonList(query domainCommon.Query) (*domainCommon.Page[lesson.Lesson], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
+}
+
+// ReadLesson retrieves a lesson by ID
+func (a *GormAdapter) ReadLesson(id uint) (*lesson.Lesson, error) {
+	var model GormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("lesson not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// ReadLessonListByClass retrieves the lessons held in a class
+func (a *GormAdapter) ReadLessonListByClass(classID uint) ([]lesson.Lesson, error) {
 	var models []GormModel
-	if err := a.db.Find(&models).Error; err != nil {
+	if err := a.db.Where("class_id = ?", classID).Order("start_time").Find(&models).Error; err != nil {
 		return nil, err
 	}
 
@@ -38,12 +68,12 @@ func (a *GormAdapter) ReadLessonList() ([]lesson.Lesson, error) {
 	return entities, nil
 }
 
-// ReadLesson retrieves a lesson by ID
-func (a *GormAdapter) ReadLesson(id uint) (*lesson.Lesson, error) {
+// FindLessonByExternalID retrieves a lesson by the identifier of the calendar event it was imported from
+func (a *GormAdapter) FindLessonByExternalID(externalID string) (*lesson.Lesson, error) {
 	var model GormModel
-	if err := a.db.First(&model, id).Error; err != nil {
+	if err := a.db.Where("external_id = ?", externalID).First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("lesson not found: %w", domainCommon.ErrNotFound)
+			return nil, nil
 		}
 		return nil, err
 	}
@@ -90,6 +120,8 @@ func domainToModel(entity lesson.Lesson) GormModel {
 		Description: entity.Description,
 		StartTime:   entity.StartTime,
 		EndTime:     entity.EndTime,
+		ClassID:     entity.ClassID,
+		ExternalID:  entity.ExternalID,
 		CreatedAt:   entity.CreatedAt,
 		UpdatedAt:   entity.UpdatedAt,
 		DeletedAt:   common.ConvertTimeToGormDeletedAt(entity.DeletedAt),
@@ -105,6 +137,8 @@ func modelToDomain(model GormModel) lesson.Lesson {
 		Description: model.Description,
 		StartTime:   model.StartTime,
 		EndTime:     model.EndTime,
+		ClassID:     model.ClassID,
+		ExternalID:  model.ExternalID,
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
 		DeletedAt:   common.ConvertGormDeletedAtToTime(model.DeletedAt),
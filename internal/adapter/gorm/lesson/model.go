@@ -1,6 +1,7 @@
 package lesson
 
 import (
+	classAdapter "sarc-ng/internal/adapter/gorm/class"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,15 +9,18 @@ import (
 
 // GormModel represents the GORM database model for lessons
 type GormModel struct {
-	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	Title       string         `gorm:"type:varchar(255);not null" json:"title"`
-	Duration    int            `gorm:"not null;default:60" json:"duration"` // Duration in minutes
-	Description string         `gorm:"type:text" json:"description"`
-	StartTime   time.Time      `gorm:"type:datetime" json:"startTime"`
-	EndTime     time.Time      `gorm:"type:datetime" json:"endTime"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID          uint                    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title       string                  `gorm:"type:varchar(255);not null" json:"title"`
+	Duration    int                     `gorm:"not null;default:60" json:"duration"` // Duration in minutes
+	Description string                  `gorm:"type:text" json:"description"`
+	StartTime   time.Time               `gorm:"type:datetime" json:"startTime"`
+	EndTime     time.Time               `gorm:"type:datetime" json:"endTime"`
+	ClassID     *uint                   `gorm:"index" json:"classId"`
+	Class       *classAdapter.GormModel `gorm:"constraint:OnDelete:RESTRICT" json:"-"`
+	ExternalID  string                  `gorm:"type:varchar(255);index" json:"externalId"`
+	CreatedAt   time.Time               `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time               `gorm:"autoUpdateTime" json:"updatedAt"`
+	DeletedAt   gorm.DeletedAt          `gorm:"index" json:"-"`
 }
 
 // TableName returns the table name for the Lesson model
@@ -0,0 +1,33 @@
+package lesson
+
+import (
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/lesson"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork implements lesson.UnitOfWork using GORM transactions
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that UnitOfWork implements lesson.UnitOfWork
+var _ lesson.UnitOfWork = (*UnitOfWork)(nil)
+
+// NewUnitOfWork creates a new lesson unit of work
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn with a GormAdapter, an outbox and an audit trail bound to one transaction
+func (u *UnitOfWork) Do(fn func(repo lesson.Repository, events event.Outbox, trail audit.Trail) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGormAdapter(tx), eventAdapter.NewOutbox(tx), auditAdapter.NewTrail(tx))
+	})
+}
@@ -0,0 +1,193 @@
+package notification
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/notification"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements notification.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements notification.Repository
+var _ notification.Repository = (*GormAdapter)(nil)
+
+// NewGormAdapter creates a new notification GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// CreateNotification queues a new notification
+func (a *GormAdapter) CreateNotification(n *notification.Notification) error {
+	model := domainToModel(*n)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*n = modelToDomain(model)
+	return nil
+}
+
+// UpdateNotification modifies a queued notification
+func (a *GormAdapter) UpdateNotification(n *notification.Notification) error {
+	model := domainToModel(*n)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*n = modelToDomain(model)
+	return nil
+}
+
+// ExistsNotification reports whether a notification with the key was queued
+func (a *GormAdapter) ExistsNotification(key string) (bool, error) {
+	var count int64
+	if err := a.db.Model(&GormModel{}).Where("notification_key = ?", key).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindDueNotifications returns up to limit pending notifications due at now
+// that no sender holds, oldest first
+func (a *GormAdapter) FindDueNotifications(now time.Time, limit int) ([]notification.Notification, error) {
+	var models []GormModel
+	err := a.db.
+		Where("status = ? AND next_attempt_at <= ?", string(notification.StatusPending), now).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Order("id").
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make([]notification.Notification, len(models))
+	for i, model := range models {
+		notifications[i] = modelToDomain(model)
+	}
+	return notifications, nil
+}
+
+// ClaimNotification holds a pending notification until the given time. The
+// check and the update are a single statement, so concurrent senders cannot
+// both claim the same notification.
+func (a *GormAdapter) ClaimNotification(id uint, now, until time.Time) (bool, error) {
+	result := a.db.Model(&GormModel{}).
+		Where("id = ? AND status = ?", id, string(notification.StatusPending)).
+		Where("locked_until IS NULL OR locked_until <= ?", now).
+		Update("locked_until", until)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim notification: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReadNotificationPreferences retrieves the preferences of a user, or nil if there are none
+func (a *GormAdapter) ReadNotificationPreferences(userID string) (*notification.Preferences, error) {
+	var model PreferencesGormModel
+	if err := a.db.Where("user_id = ?", userID).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entity := preferencesToDomain(model)
+	return &entity, nil
+}
+
+// SaveNotificationPreferences creates or replaces the preferences of a user
+func (a *GormAdapter) SaveNotificationPreferences(p *notification.Preferences) error {
+	model := preferencesToModel(*p)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*p = preferencesToDomain(model)
+	return nil
+}
+
+// domainToModel converts domain entity to GORM model
+func domainToModel(entity notification.Notification) GormModel {
+	return GormModel{
+		ID:            entity.ID,
+		Key:           entity.Key,
+		Kind:          string(entity.Kind),
+		UserID:        entity.UserID,
+		Recipient:     entity.Recipient,
+		Subject:       entity.Subject,
+		Body:          entity.Body,
+		Status:        string(entity.Status),
+		Attempts:      entity.Attempts,
+		NextAttemptAt: entity.NextAttemptAt,
+		LockedUntil:   entity.LockedUntil,
+		LastError:     entity.LastError,
+		CreatedAt:     entity.CreatedAt,
+		SentAt:        entity.SentAt,
+	}
+}
+
+// modelToDomain converts GORM model to domain entity
+func modelToDomain(model GormModel) notification.Notification {
+	return notification.Notification{
+		ID:            model.ID,
+		Key:           model.Key,
+		Kind:          notification.Kind(model.Kind),
+		UserID:        model.UserID,
+		Recipient:     model.Recipient,
+		Subject:       model.Subject,
+		Body:          model.Body,
+		Status:        notification.Status(model.Status),
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		LockedUntil:   model.LockedUntil,
+		LastError:     model.LastError,
+		CreatedAt:     model.CreatedAt,
+		SentAt:        model.SentAt,
+	}
+}
+
+// preferencesToModel converts domain entity to GORM model
+func preferencesToModel(entity notification.Preferences) PreferencesGormModel {
+	optOut := make([]string, len(entity.OptOut))
+	for i, kind := range entity.OptOut {
+		optOut[i] = string(kind)
+	}
+	return PreferencesGormModel{
+		UserID:    entity.UserID,
+		Email:     entity.Email,
+		Locale:    entity.Locale,
+		Disabled:  entity.Disabled,
+		OptOut:    strings.Join(optOut, ","),
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+// preferencesToDomain converts GORM model to domain entity
+func preferencesToDomain(model PreferencesGormModel) notification.Preferences {
+	var optOut []notification.Kind
+	if model.OptOut != "" {
+		for _, kind := range strings.Split(model.OptOut, ",") {
+			optOut = append(optOut, notification.Kind(kind))
+		}
+	}
+	return notification.Preferences{
+		UserID:    model.UserID,
+		Email:     model.Email,
+		Locale:    model.Locale,
+		Disabled:  model.Disabled,
+		OptOut:    optOut,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"time"
+)
+
+// GormModel represents the GORM database model for queued notifications
+// idx_notifications_due backs the send query in FindDueNotifications
+type GormModel struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Key           string     `gorm:"column:notification_key;type:varchar(255);not null;uniqueIndex" json:"key"`
+	Kind          string     `gorm:"type:varchar(50);not null" json:"kind"`
+	UserID        string     `gorm:"type:varchar(255);not null;index" json:"userId"`
+	Recipient     string     `gorm:"type:varchar(320);not null" json:"recipient"`
+	Subject       string     `gorm:"type:varchar(500);not null" json:"subject"`
+	Body          string     `gorm:"type:text;not null" json:"body"`
+	Status        string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_notifications_due,priority:1" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_notifications_due,priority:2" json:"nextAttemptAt"`
+	LockedUntil   *time.Time `json:"lockedUntil"`
+	LastError     string     `gorm:"type:text" json:"lastError"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"createdAt"`
+	SentAt        *time.Time `json:"sentAt"`
+}
+
+// TableName returns the table name for the Notification model
+func (GormModel) TableName() string {
+	return "notifications"
+}
+
+// PreferencesGormModel represents the GORM database model for notification
+// preferences. Opted out kinds are stored comma separated.
+type PreferencesGormModel struct {
+	UserID    string    `gorm:"primaryKey;type:varchar(255)" json:"userId"`
+	Email     string    `gorm:"type:varchar(320)" json:"email"`
+	Locale    string    `gorm:"type:varchar(20)" json:"locale"`
+	Disabled  bool      `gorm:"not null;default:false" json:"disabled"`
+	OptOut    string    `gorm:"type:varchar(500)" json:"optOut"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for the Preferences model
+func (PreferencesGormModel) TableName() string {
+	return "notification_preferences"
+}
@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"fmt"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/policy"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements policy.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements policy.Repository
+var _ policy.Repository = (*GormAdapter)(nil)
+
+// NewGormAdapter creates a new booking policy GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadPolicyList retrieves every booking policy ordered by resource type
+func (a *GormAdapter) ReadPolicyList() ([]policy.Policy, error) {
+	var models []GormModel
+	if err := a.db.Order("resource_type").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]policy.Policy, len(models))
+	for i, model := range models {
+		entities[i] = modelToDomain(model)
+	}
+	return entities, nil
+}
+
+// ReadPolicy retrieves a booking policy by ID
+func (a *GormAdapter) ReadPolicy(id uint) (*policy.Policy, error) {
+	var model GormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("policy not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// FindPolicyByResourceType retrieves the policy of a resource type, or nil if it has none
+func (a *GormAdapter) FindPolicyByResourceType(resourceType string) (*policy.Policy, error) {
+	var model GormModel
+	if err := a.db.Where("resource_type = ?", resourceType).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// CreatePolicy adds a new booking policy
+func (a *GormAdapter) CreatePolicy(p *policy.Policy) error {
+	model := domainToModel(*p)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*p = modelToDomain(model)
+	return nil
+}
+
+// UpdatePolicy modifies an existing booking policy
+func (a *GormAdapter) UpdatePolicy(p *policy.Policy) error {
+	model := domainToModel(*p)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*p = modelToDomain(model)
+	return nil
+}
+
+// DeletePolicy removes a booking policy
+func (a *GormAdapter) DeletePolicy(id uint) error {
+	return a.db.Delete(&GormModel{}, id).Error
+}
+
+// domainToModel converts domain entity to GORM model
+func domainToModel(entity policy.Policy) GormModel {
+	return GormModel{
+		ID:                  entity.ID,
+		ResourceType:        entity.ResourceType,
+		MaxDurationMinutes:  int(entity.MaxDuration / time.Minute),
+		MinLeadTimeMinutes:  int(entity.MinLeadTime / time.Minute),
+		MaxAdvanceMinutes:   int(entity.MaxAdvance / time.Minute),
+		BufferMinutes:       int(entity.Buffer / time.Minute),
+		TimeZone:            entity.Hours.TimeZone,
+		AllowedFrom:         entity.Hours.From,
+		AllowedUntil:        entity.Hours.Until,
+		AllowedDays:         entity.Hours.Days,
+		AllowedGroups:       entity.AllowedGroups,
+		NoShowLimit:         entity.NoShowLimit,
+		NoShowPeriodMinutes: int(entity.NoShowPeriod / time.Minute),
+		CreatedAt:           entity.CreatedAt,
+		UpdatedAt:           entity.UpdatedAt,
+	}
+}
+
+// modelToDomain converts GORM model to domain entity
+func modelToDomain(model GormModel) policy.Policy {
+	return policy.Policy{
+		ID:           model.ID,
+		ResourceType: model.ResourceType,
+		MaxDuration:  time.Duration(model.MaxDurationMinutes) * time.Minute,
+		MinLeadTime:  time.Duration(model.MinLeadTimeMinutes) * time.Minute,
+		MaxAdvance:   time.Duration(model.MaxAdvanceMinutes) * time.Minute,
+		Buffer:       time.Duration(model.BufferMinutes) * time.Minute,
+		Hours: policy.Hours{
+			TimeZone: model.TimeZone,
+			From:     model.AllowedFrom,
+			Until:    model.AllowedUntil,
+			Days:     model.AllowedDays,
+		},
+		AllowedGroups: model.AllowedGroups,
+		NoShowLimit:   model.NoShowLimit,
+		NoShowPeriod:  time.Duration(model.NoShowPeriodMinutes) * time.Minute,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}
+}
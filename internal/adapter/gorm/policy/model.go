@@ -0,0 +1,30 @@
+package policy
+
+import (
+	"time"
+)
+
+// GormModel represents the GORM database model for booking policies.
+// Durations are stored in minutes.
+type GormModel struct {
+	ID                  uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ResourceType        string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"resourceType"`
+	MaxDurationMinutes  int       `gorm:"not null;default:0" json:"maxDurationMinutes"`
+	MinLeadTimeMinutes  int       `gorm:"not null;default:0" json:"minLeadTimeMinutes"`
+	MaxAdvanceMinutes   int       `gorm:"not null;default:0" json:"maxAdvanceMinutes"`
+	BufferMinutes       int       `gorm:"not null;default:0" json:"bufferMinutes"`
+	TimeZone            string    `gorm:"type:varchar(64)" json:"timeZone"`
+	AllowedFrom         string    `gorm:"type:varchar(5)" json:"allowedFrom"`
+	AllowedUntil        string    `gorm:"type:varchar(5)" json:"allowedUntil"`
+	AllowedDays         []string  `gorm:"type:text;serializer:json" json:"allowedDays"`
+	AllowedGroups       []string  `gorm:"type:text;serializer:json" json:"allowedGroups"`
+	NoShowLimit         int       `gorm:"not null;default:0" json:"noShowLimit"`
+	NoShowPeriodMinutes int       `gorm:"not null;default:0" json:"noShowPeriodMinutes"`
+	CreatedAt           time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt           time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for the Policy model
+func (GormModel) TableName() string {
+	return "booking_policies"
+}
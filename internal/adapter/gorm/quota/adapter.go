@@ -0,0 +1,134 @@
+package quota
+
+import (
+	"fmt"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/quota"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements quota.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements quota.Repository
+var _ quota.Repository = (*GormAdapter)(nil)
+
+// NewGormAdapter creates a new quota override GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadQuotaOverrideList retrieves the overrides of a user, or of every user
+// when userID is empty, ordered by user and resource type
+func (a *GormAdapter) ReadQuotaOverrideList(userID string) ([]quota.Override, error) {
+	query := a.db.Order("user_id").Order("resource_type")
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var models []GormModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]quota.Override, len(models))
+	for i, model := range models {
+		entities[i] = modelToDomain(model)
+	}
+	return entities, nil
+}
+
+// ReadQuotaOverride retrieves a quota override by ID
+func (a *GormAdapter) ReadQuotaOverride(id uint) (*quota.Override, error) {
+	var model GormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("quota override not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// FindQuotaOverride retrieves the override of a user for a resource type, or nil if there is none
+func (a *GormAdapter) FindQuotaOverride(userID, resourceType string) (*quota.Override, error) {
+	var model GormModel
+	if err := a.db.Where("user_id = ? AND resource_type = ?", userID, resourceType).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// CreateQuotaOverride adds a new quota override
+func (a *GormAdapter) CreateQuotaOverride(o *quota.Override) error {
+	model := domainToModel(*o)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*o = modelToDomain(model)
+	return nil
+}
+
+// UpdateQuotaOverride modifies an existing quota override
+func (a *GormAdapter) UpdateQuotaOverride(o *quota.Override) error {
+	model := domainToModel(*o)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*o = modelToDomain(model)
+	return nil
+}
+
+// DeleteQuotaOverride removes a quota override
+func (a *GormAdapter) DeleteQuotaOverride(id uint) error {
+	return a.db.Delete(&GormModel{}, id).Error
+}
+
+// domainToModel converts domain entity to GORM model
+func domainToModel(entity quota.Override) GormModel {
+	return GormModel{
+		ID:               entity.ID,
+		UserID:           entity.UserID,
+		ResourceType:     entity.ResourceType,
+		MaxActive:        entity.MaxActive,
+		MaxWeeklyMinutes: int(entity.MaxWeekly / time.Minute),
+		Waived:           entity.Waived,
+		Reason:           entity.Reason,
+		GrantedBy:        entity.GrantedBy,
+		CreatedAt:        entity.CreatedAt,
+		UpdatedAt:        entity.UpdatedAt,
+	}
+}
+
+// modelToDomain converts GORM model to domain entity
+func modelToDomain(model GormModel) quota.Override {
+	return quota.Override{
+		ID:           model.ID,
+		UserID:       model.UserID,
+		ResourceType: model.ResourceType,
+		MaxActive:    model.MaxActive,
+		MaxWeekly:    time.Duration(model.MaxWeeklyMinutes) * time.Minute,
+		Waived:       model.Waived,
+		Reason:       model.Reason,
+		GrantedBy:    model.GrantedBy,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}
+}
@@ -0,0 +1,25 @@
+package quota
+
+import (
+	"time"
+)
+
+// GormModel represents the GORM database model for quota overrides.
+// The weekly limit is stored in minutes.
+type GormModel struct {
+	ID               uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID           string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_quota_override_user_type" json:"userId"`
+	ResourceType     string    `gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_quota_override_user_type" json:"resourceType"`
+	MaxActive        int       `gorm:"not null;default:0" json:"maxActive"`
+	MaxWeeklyMinutes int       `gorm:"not null;default:0" json:"maxWeeklyMinutes"`
+	Waived           bool      `gorm:"not null;default:false" json:"waived"`
+	Reason           string    `gorm:"type:text" json:"reason"`
+	GrantedBy        string    `gorm:"type:varchar(255)" json:"grantedBy"`
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for the Override model
+func (GormModel) TableName() string {
+	return "quota_overrides"
+}
@@ -3,10 +3,13 @@ package reservation
 import (
 	"fmt"
 	"sarc-ng/internal/adapter/gorm/common"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
 	domainCommon "sarc-ng/internal/domain/common"
 	"sarc-ng/internal/domain/reservation"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GormAdapter implements reservation.Repository using GORM
@@ -17,6 +20,18 @@ type GormAdapter struct {
 // Compile-time verification that GormAdapter implements reservation.Repository
 var _ reservation.Repository = (*GormAdapter)(nil)
 
+// columns lists the fields reservations can be filtered and sorted by
+var columns = common.Columns{
+	"resourceId": "resource_id",
+	"userId":     "user_id",
+	"status":     "status",
+	"startTime":  "start_time",
+	"endTime":    "end_time",
+	"seriesId":   "series_id",
+	"createdAt":  "created_at",
+	"updatedAt":  "updated_at",
+}
+
 // NewGormAdapter creates a new reservation GORM adapter
 func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	return &GormAdapter{
@@ -24,18 +39,9 @@ func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	}
 }
 
-// ReadReservationList retrieves all reservations
-func (a *GormAdapter) ReadReservationList() ([]reservation.Reservation, error) {
-	var models []GormModel
-	if err := a.db.Find(&models).Error; err != nil {
-		return nil, err
-	}
-
-	entities := make([]reservation.Reservation, len(models))
-	for i, model := range models {
-		entities[i] = modelToDomain(model)
-	}
-	return entities, nil
+// ReadReservationList retrieves the page of reservations selected by the query
+func (a *GormAdapter) ReadReservationList(query domainCommon.Query) (*domainCommon.Page[reservation.Reservation], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
 }
 
 // ReadReservation retrieves a reservation by ID
@@ -81,36 +87,117 @@ func (a *GormAdapter) DeleteReservation(id uint) error {
 	return a.db.Delete(&GormModel{}, id).Error
 }
 
+// FindOverlappingReservations retrieves active reservations of a resource overlapping [start, end)
+func (a *GormAdapter) FindOverlappingReservations(resourceID uint, start, end time.Time, excludeID uint) ([]reservation.Reservation, error) {
+	query := a.db.
+		Where("resource_id = ?", resourceID).
+		Where("start_time < ? AND end_time > ?", end, start).
+		Where("status NOT IN ?", reservation.InactiveStatuses)
+	if excludeID != 0 {
+		query = query.Where("id <> ?", excludeID)
+	}
+	return a.findReservations(query)
+}
+
+// ReadReservationListByResource retrieves the reservations of a resource ending after from
+// and, unless to is zero, starting before to
+func (a *GormAdapter) ReadReservationListByResource(resourceID uint, from, to time.Time) ([]reservation.Reservation, error) {
+	query := a.db.Where("resource_id = ? AND end_time > ?", resourceID, from)
+	if !to.IsZero() {
+		query = query.Where("start_time < ?", to)
+	}
+	return a.findReservations(query)
+}
+
+// ReadReservationListByUser retrieves the reservations of a user ending after from
+func (a *GormAdapter) ReadReservationListByUser(userID string, from time.Time) ([]reservation.Reservation, error) {
+	return a.findReservations(a.db.Where("user_id = ? AND end_time > ?", userID, from))
+}
+
+// FindReservationsMissingCheckIn retrieves the approved reservations nobody checked in to
+// that started at or before cutoff and end after it
+func (a *GormAdapter) FindReservationsMissingCheckIn(cutoff time.Time) ([]reservation.Reservation, error) {
+	return a.findReservations(a.db.
+		Where("status = ? AND checked_in_at IS NULL", reservation.StatusApproved).
+		Where("start_time <= ? AND end_time > ?", cutoff, cutoff))
+}
+
+// FindReservationsToRemind retrieves the approved reservations starting in
+// (from, until] whose owners have not been reminded
+func (a *GormAdapter) FindReservationsToRemind(from, until time.Time) ([]reservation.Reservation, error) {
+	return a.findReservations(a.db.
+		Where("status = ? AND reminded_at IS NULL", reservation.StatusApproved).
+		Where("start_time > ? AND start_time <= ?", from, until))
+}
+
+// findReservations runs a reservation query ordered by start time
+func (a *GormAdapter) findReservations(query *gorm.DB) ([]reservation.Reservation, error) {
+	var models []GormModel
+	if err := query.Order("start_time").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]reservation.Reservation, len(models))
+	for i, model := range models {
+		entities[i] = modelToDomain(model)
+	}
+	return entities, nil
+}
+
+// LockReservationResource takes a row lock on the resource being booked
+func (a *GormAdapter) LockReservationResource(resourceID uint) error {
+	var ids []uint
+	err := a.db.Model(&resourceAdapter.GormModel{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", resourceID).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("resource not found: %w", domainCommon.ErrNotFound)
+	}
+	return nil
+}
+
 // domainToModel converts domain entity to GORM model
 func domainToModel(entity reservation.Reservation) GormModel {
 	return GormModel{
-		ID:          entity.ID,
-		ResourceID:  entity.ResourceID,
-		UserID:      entity.UserID,
-		StartTime:   entity.StartTime,
-		EndTime:     entity.EndTime,
-		Purpose:     entity.Purpose,
-		Status:      entity.Status,
-		Description: entity.Description,
-		CreatedAt:   entity.CreatedAt,
-		UpdatedAt:   entity.UpdatedAt,
-		DeletedAt:   common.ConvertTimeToGormDeletedAt(entity.DeletedAt),
+		ID:           entity.ID,
+		ResourceID:   entity.ResourceID,
+		UserID:       entity.UserID,
+		StartTime:    entity.StartTime,
+		EndTime:      entity.EndTime,
+		Purpose:      entity.Purpose,
+		Status:       string(entity.Status),
+		StatusReason: entity.StatusReason,
+		Description:  entity.Description,
+		SeriesID:     entity.SeriesID,
+		CheckedInAt:  entity.CheckedInAt,
+		RemindedAt:   entity.RemindedAt,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+		DeletedAt:    common.ConvertTimeToGormDeletedAt(entity.DeletedAt),
 	}
 }
 
 // modelToDomain converts GORM model to domain entity
 func modelToDomain(model GormModel) reservation.Reservation {
 	return reservation.Reservation{
-		ID:          model.ID,
-		ResourceID:  model.ResourceID,
-		UserID:      model.UserID,
-		StartTime:   model.StartTime,
-		EndTime:     model.EndTime,
-		Purpose:     model.Purpose,
-		Status:      model.Status,
-		Description: model.Description,
-		CreatedAt:   model.CreatedAt,
-		UpdatedAt:   model.UpdatedAt,
-		DeletedAt:   common.ConvertGormDeletedAtToTime(model.DeletedAt),
+		ID:           model.ID,
+		ResourceID:   model.ResourceID,
+		UserID:       model.UserID,
+		StartTime:    model.StartTime,
+		EndTime:      model.EndTime,
+		Purpose:      model.Purpose,
+		Status:       reservation.Status(model.Status),
+		StatusReason: model.StatusReason,
+		Description:  model.Description,
+		SeriesID:     model.SeriesID,
+		CheckedInAt:  model.CheckedInAt,
+		RemindedAt:   model.RemindedAt,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+		DeletedAt:    common.ConvertGormDeletedAtToTime(model.DeletedAt),
 	}
 }
@@ -0,0 +1,230 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/reservation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOverlappingReservations(t *testing.T) {
+	db := gormtest.Open(t, &GormModel{})
+	adapter := NewGormAdapter(db)
+
+	base := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return base.Add(time.Duration(hour) * time.Hour) }
+
+	seed := []reservation.Reservation{
+		{ResourceID: 1, UserID: "user-1", StartTime: at(9), EndTime: at(10), Purpose: "active", Status: "approved"},
+		{ResourceID: 1, UserID: "user-1", StartTime: at(11), EndTime: at(12), Purpose: "cancelled", Status: "cancelled"},
+		{ResourceID: 1, UserID: "user-1", StartTime: at(13), EndTime: at(14), Purpose: "rejected", Status: "rejected"},
+		{ResourceID: 2, UserID: "user-1", StartTime: at(9), EndTime: at(10), Purpose: "other resource", Status: "pending"},
+		{ResourceID: 1, UserID: "user-1", StartTime: at(15), EndTime: at(16), Purpose: "deleted", Status: "pending"},
+	}
+	for i := range seed {
+		require.NoError(t, adapter.CreateReservation(&seed[i]))
+	}
+	require.NoError(t, adapter.DeleteReservation(seed[4].ID))
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		end       time.Time
+		excludeID uint
+		wantIDs   []uint
+	}{
+		{"overlaps active reservation", at(9).Add(30 * time.Minute), at(10).Add(30 * time.Minute), 0, []uint{seed[0].ID}},
+		{"contains active reservation", at(8), at(11), 0, []uint{seed[0].ID}},
+		{"touching boundaries do not overlap", at(10), at(11), 0, nil},
+		{"cancelled reservation is ignored", at(11), at(12), 0, nil},
+		{"rejected reservation is ignored", at(13), at(14), 0, nil},
+		{"soft-deleted reservation is ignored", at(15), at(16), 0, nil},
+		{"excluded reservation is ignored", at(9), at(10), seed[0].ID, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts, err := adapter.FindOverlappingReservations(1, tt.start, tt.end, tt.excludeID)
+			require.NoError(t, err)
+
+			var ids []uint
+			for _, c := range conflicts {
+				ids = append(ids, c.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestReadReservationListByResource(t *testing.T) {
+	db := gormtest.Open(t, &GormModel{})
+	adapter := NewGormAdapter(db)
+
+	base := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return base.Add(time.Duration(hour) * time.Hour) }
+
+	for _, hour := range []int{8, 10, 12, 14} {
+		require.NoError(t, adapter.CreateReservation(&reservation.Reservation{
+			ResourceID: 1, UserID: "user-1", StartTime: at(hour), EndTime: at(hour + 1), Purpose: "lecture", Status: reservation.StatusApproved,
+		}))
+	}
+
+	window, err := adapter.ReadReservationListByResource(1, at(9), at(12))
+	require.NoError(t, err)
+	require.Len(t, window, 1, "reservations ending at from or starting at to are outside")
+	assert.Equal(t, at(10), window[0].StartTime.UTC())
+
+	open, err := adapter.ReadReservationListByResource(1, at(9), time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, open, 3, "a zero to leaves the window open")
+}
+
+func TestReadReservationList(t *testing.T) {
+	db := gormtest.Open(t, &GormModel{})
+	adapter := NewGormAdapter(db)
+
+	base := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return base.Add(time.Duration(hour) * time.Hour) }
+
+	for hour := 0; hour < 5; hour++ {
+		status := reservation.StatusPending
+		if hour%2 == 0 {
+			status = reservation.StatusApproved
+		}
+		require.NoError(t, adapter.CreateReservation(&reservation.Reservation{
+			ResourceID: 1, UserID: "user-1", StartTime: at(4 - hour), EndTime: at(5 - hour), Purpose: "lecture", Status: status,
+		}))
+	}
+	require.NoError(t, adapter.CreateReservation(&reservation.Reservation{
+		ResourceID: 2, UserID: "user-2", StartTime: at(0), EndTime: at(1), Purpose: "other", Status: reservation.StatusApproved,
+	}))
+
+	t.Run("Filters", func(t *testing.T) {
+		query := domainCommon.Query{}.
+			Where("resourceId", domainCommon.OpEqual, 1).
+			Where("status", domainCommon.OpEqual, reservation.StatusApproved).
+			Where("endTime", domainCommon.OpGreaterOrEqual, at(2))
+		page, err := adapter.ReadReservationList(query)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, page.Total)
+		assert.Len(t, page.Items, 2)
+	})
+
+	t.Run("Sort and offset", func(t *testing.T) {
+		page, err := adapter.ReadReservationList(domainCommon.Query{Sort: "startTime", Limit: 2, Offset: 1})
+		require.NoError(t, err)
+		assert.EqualValues(t, 6, page.Total)
+		require.Len(t, page.Items, 2)
+		assert.Equal(t, at(0), page.Items[0].StartTime.UTC(), "ties are broken by ID")
+		assert.Equal(t, at(1), page.Items[1].StartTime.UTC())
+		assert.Empty(t, page.NextCursor, "cursors only follow the ID order")
+	})
+
+	t.Run("Keyset cursor", func(t *testing.T) {
+		var ids []uint
+		query := domainCommon.Query{Limit: 4}
+		for {
+			page, err := adapter.ReadReservationList(query)
+			require.NoError(t, err)
+			for _, r := range page.Items {
+				ids = append(ids, r.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+		assert.Equal(t, []uint{1, 2, 3, 4, 5, 6}, ids)
+	})
+
+	t.Run("Fields outside the whitelist are rejected", func(t *testing.T) {
+		_, err := adapter.ReadReservationList(domainCommon.Query{Sort: "purpose"})
+		assert.ErrorIs(t, err, domainCommon.ErrInvalidInput)
+
+		_, err = adapter.ReadReservationList(domainCommon.Query{}.Where("1=1 OR purpose", domainCommon.OpEqual, "x"))
+		assert.ErrorIs(t, err, domainCommon.ErrInvalidInput)
+
+		_, err = adapter.ReadReservationList(domainCommon.Query{Sort: "startTime", Cursor: "Mg"})
+		assert.ErrorIs(t, err, domainCommon.ErrInvalidInput)
+	})
+}
+
+// BenchmarkAvailabilityCheck compares the previous full-table scan with the
+// indexed overlap query on a table of 100k reservations.
+func BenchmarkAvailabilityCheck(b *testing.B) {
+	const (
+		resources       = 100
+		perResource     = 1000
+		targetResource  = 42
+		batchSize       = 1000
+		slotsPerDay     = 10
+		reservationSpan = time.Hour
+	)
+
+	db := gormtest.Open(b, &GormModel{})
+	adapter := NewGormAdapter(db)
+
+	base := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	slot := func(n int) time.Time {
+		return base.AddDate(0, 0, n/slotsPerDay).Add(time.Duration(n%slotsPerDay) * reservationSpan)
+	}
+
+	models := make([]GormModel, 0, resources*perResource)
+	for r := 1; r <= resources; r++ {
+		for n := 0; n < perResource; n++ {
+			models = append(models, GormModel{
+				ResourceID: uint(r),
+				UserID:     "user-1",
+				StartTime:  slot(n),
+				EndTime:    slot(n).Add(reservationSpan),
+				Purpose:    "benchmark",
+				Status:     "approved",
+			})
+		}
+	}
+	if err := db.CreateInBatches(models, batchSize).Error; err != nil {
+		b.Fatalf("failed to seed reservations: %v", err)
+	}
+
+	start := slot(perResource / 2).Add(30 * time.Minute)
+	end := start.Add(reservationSpan)
+
+	b.Run("ScanAll", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			all, err := adapter.ReadReservationList(domainCommon.Query{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			available := true
+			for _, existing := range all.Items {
+				if existing.ResourceID == targetResource &&
+					existing.Status != "cancelled" &&
+					existing.Status != "rejected" &&
+					start.Before(existing.EndTime) && existing.StartTime.Before(end) {
+					available = false
+					break
+				}
+			}
+			if available {
+				b.Fatal("expected a conflict")
+			}
+		}
+	})
+
+	b.Run("OverlapQuery", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			conflicts, err := adapter.FindOverlappingReservations(targetResource, start, end, 0)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(conflicts) == 0 {
+				b.Fatal("expected a conflict")
+			}
+		}
+	})
+}
@@ -7,18 +7,23 @@ import (
 )
 
 // GormModel represents the GORM database model for reservations
+// idx_reservations_overlap backs the conflict query in FindOverlappingReservations
 type GormModel struct {
-	ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
-	ResourceID  uint           `gorm:"not null;index" json:"resourceId"`
-	UserID      uint           `gorm:"not null;index" json:"userId"`
-	StartTime   time.Time      `gorm:"not null" json:"startTime"`
-	EndTime     time.Time      `gorm:"not null" json:"endTime"`
-	Purpose     string         `gorm:"type:varchar(255)" json:"purpose"`
-	Status      string         `gorm:"type:varchar(50);default:'active'" json:"status"`
-	Description string         `gorm:"type:text" json:"description"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"createdAt"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ResourceID   uint           `gorm:"not null;index;index:idx_reservations_overlap,priority:1" json:"resourceId"`
+	UserID       string         `gorm:"type:varchar(255);not null;index" json:"userId"`
+	StartTime    time.Time      `gorm:"not null;index:idx_reservations_overlap,priority:2" json:"startTime"`
+	EndTime      time.Time      `gorm:"not null;index:idx_reservations_overlap,priority:3" json:"endTime"`
+	Purpose      string         `gorm:"type:varchar(255)" json:"purpose"`
+	Status       string         `gorm:"type:varchar(50);default:'pending';index:idx_reservations_overlap,priority:4" json:"status"`
+	StatusReason string         `gorm:"type:varchar(500)" json:"statusReason"`
+	Description  string         `gorm:"type:text" json:"description"`
+	SeriesID     *uint          `gorm:"index" json:"seriesId"`
+	CheckedInAt  *time.Time     `json:"checkedInAt"`
+	RemindedAt   *time.Time     `json:"remindedAt"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // TableName returns the table name for the Reservation model
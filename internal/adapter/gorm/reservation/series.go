@@ -0,0 +1,117 @@
+package reservation
+
+import (
+	"fmt"
+	"sarc-ng/internal/adapter/gorm/common"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/reservation"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SeriesGormModel represents the GORM database model for recurring reservation series
+type SeriesGormModel struct {
+	ID             uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ResourceID     uint           `gorm:"not null;index" json:"resourceId"`
+	UserID         string         `gorm:"type:varchar(255);not null;index" json:"userId"`
+	StartTime      time.Time      `gorm:"not null" json:"startTime"`
+	EndTime        time.Time      `gorm:"not null" json:"endTime"`
+	TimeZone       string         `gorm:"type:varchar(64);not null;default:'UTC'" json:"timeZone"`
+	RecurrenceRule string         `gorm:"type:varchar(255);not null" json:"recurrenceRule"`
+	ExceptionDates []time.Time    `gorm:"type:text;serializer:json" json:"exceptionDates"`
+	Purpose        string         `gorm:"type:varchar(255)" json:"purpose"`
+	Description    string         `gorm:"type:text" json:"description"`
+	CreatedAt      time.Time      `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt      time.Time      `gorm:"autoUpdateTime" json:"updatedAt"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName returns the table name for the Series model
+func (SeriesGormModel) TableName() string {
+	return "reservation_series"
+}
+
+// ReadReservationListBySeries retrieves the occurrences of a series in chronological order
+func (a *GormAdapter) ReadReservationListBySeries(seriesID uint) ([]reservation.Reservation, error) {
+	return a.findReservations(a.db.Where("series_id = ?", seriesID))
+}
+
+// ReadReservationSeries retrieves a series by ID
+func (a *GormAdapter) ReadReservationSeries(id uint) (*reservation.Series, error) {
+	var model SeriesGormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("reservation series not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := seriesModelToDomain(model)
+	return &entity, nil
+}
+
+// CreateReservationSeries adds a new series
+func (a *GormAdapter) CreateReservationSeries(s *reservation.Series) error {
+	model := seriesDomainToModel(*s)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields, keeping occurrences set by the caller
+	occurrences := s.Occurrences
+	*s = seriesModelToDomain(model)
+	s.Occurrences = occurrences
+	return nil
+}
+
+// UpdateReservationSeries modifies an existing series
+func (a *GormAdapter) UpdateReservationSeries(s *reservation.Series) error {
+	model := seriesDomainToModel(*s)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	occurrences := s.Occurrences
+	*s = seriesModelToDomain(model)
+	s.Occurrences = occurrences
+	return nil
+}
+
+// seriesDomainToModel converts domain series to GORM model
+func seriesDomainToModel(entity reservation.Series) SeriesGormModel {
+	return SeriesGormModel{
+		ID:             entity.ID,
+		ResourceID:     entity.ResourceID,
+		UserID:         entity.UserID,
+		StartTime:      entity.StartTime,
+		EndTime:        entity.EndTime,
+		TimeZone:       entity.TimeZone,
+		RecurrenceRule: entity.RecurrenceRule,
+		ExceptionDates: entity.ExceptionDates,
+		Purpose:        entity.Purpose,
+		Description:    entity.Description,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+		DeletedAt:      common.ConvertTimeToGormDeletedAt(entity.DeletedAt),
+	}
+}
+
+// seriesModelToDomain converts GORM model to domain series
+func seriesModelToDomain(model SeriesGormModel) reservation.Series {
+	return reservation.Series{
+		ID:             model.ID,
+		ResourceID:     model.ResourceID,
+		UserID:         model.UserID,
+		StartTime:      model.StartTime,
+		EndTime:        model.EndTime,
+		TimeZone:       model.TimeZone,
+		RecurrenceRule: model.RecurrenceRule,
+		ExceptionDates: model.ExceptionDates,
+		Purpose:        model.Purpose,
+		Description:    model.Description,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+		DeletedAt:      common.ConvertGormDeletedAtToTime(model.DeletedAt),
+	}
+}
@@ -17,6 +17,17 @@ type GormAdapter struct {
 // Compile-time verification that GormAdapter implements resource.Repository
 var _ resource.Repository = (*GormAdapter)(nil)
 
+// columns lists the fields resources can be filtered and sorted by
+var columns = common.Columns{
+	"name":        "name",
+	"type":        "type",
+	"isAvailable": "is_available",
+	"buildingId":  "building_id",
+	"classId":     "class_id",
+	"createdAt":   "created_at",
+	"updatedAt":   "updated_at",
+}
+
 // NewGormAdapter creates a new resource GORM adapter
 func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	return &GormAdapter{
@@ -24,18 +35,9 @@ func NewGormAdapter(db *gorm.DB) *GormAdapter {
 	}
 }
 
-// ReadResourceList retrieves all resources
-func (a *GormAdapter) ReadResourceList() ([]resource.Resource, error) {
-	var models []GormModel
-	if err := a.db.Find(&models).Error; err != nil {
-		return nil, err
-	}
-
-	entities := make([]resource.Resource, len(models))
-	for i, model := range models {
-		entities[i] = modelToDomain(model)
-	}
-	return entities, nil
+// ReadResourceList retrieves the page of resources selected by the query
+func (a *GormAdapter) ReadResourceList(query domainCommon.Query) (*domainCommon.Page[resource.Resource], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
 }
 
 // ReadResource retrieves a resource by ID
@@ -52,6 +54,37 @@ func (a *GormAdapter) ReadResource(id uint) (*resource.Resource, error) {
 	return &entity, nil
 }
 
+// ReadResourceListByBuilding retrieves the resources kept in a building, including its classes
+func (a *GormAdapter) ReadResourceListByBuilding(buildingID uint) ([]resource.Resource, error) {
+	return a.findResources(a.db.Where("building_id = ?", buildingID))
+}
+
+// ReadResourceListByClass retrieves the resources kept in a class
+func (a *GormAdapter) ReadResourceListByClass(classID uint) ([]resource.Resource, error) {
+	return a.findResources(a.db.Where("class_id = ?", classID))
+}
+
+// UpdateResourceBuildingByClass moves the resources of a class to the class's new building
+func (a *GormAdapter) UpdateResourceBuildingByClass(classID uint, buildingID uint) error {
+	return a.db.Model(&GormModel{}).
+		Where("class_id = ?", classID).
+		Update("building_id", buildingID).Error
+}
+
+// findResources runs a resource query ordered by name
+func (a *GormAdapter) findResources(query *gorm.DB) ([]resource.Resource, error) {
+	var models []GormModel
+	if err := query.Order("name").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]resource.Resource, len(models))
+	for i, model := range models {
+		entities[i] = modelToDomain(model)
+	}
+	return entities, nil
+}
+
 // CreateResource adds a new resource
 func (a *GormAdapter) CreateResource(r *resource.Resource) error {
 	model := domainToModel(*r)
@@ -90,6 +123,8 @@ func domainToModel(entity resource.Resource) GormModel {
 		Description: entity.Description,
 		IsAvailable: entity.IsAvailable,
 		Location:    entity.Location,
+		BuildingID:  entity.BuildingID,
+		ClassID:     entity.ClassID,
 		CreatedAt:   entity.CreatedAt,
 		UpdatedAt:   entity.UpdatedAt,
 		DeletedAt:   common.ConvertTimeToGormDeletedAt(entity.DeletedAt),
@@ -105,6 +140,8 @@ func modelToDomain(model GormModel) resource.Resource {
 		Description: model.Description,
 		IsAvailable: model.IsAvailable,
 		Location:    model.Location,
+		BuildingID:  model.BuildingID,
+		ClassID:     model.ClassID,
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
 		DeletedAt:   common.ConvertGormDeletedAtToTime(model.DeletedAt),
@@ -0,0 +1,167 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sarc-ng/internal/adapter/broker"
+	"sarc-ng/internal/domain/stream"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultPollInterval is how often instances look for new updates
+	defaultPollInterval = time.Second
+	// retention is how long published updates are kept for instances to poll
+	retention = time.Minute
+	// lateRows is how far below the newest update seen an instance looks for
+	// updates whose insert committed after a later one
+	lateRows = 100
+)
+
+// Broker shares updates between instances through the database. Published
+// updates are written to a table that every instance with subscribers polls,
+// fanning the new rows out to its own subscribers in memory.
+type Broker struct {
+	db       *gorm.DB
+	local    *broker.Memory
+	interval time.Duration
+
+	mu          sync.Mutex
+	subscribers int
+	stop        context.CancelFunc
+	cleanedAt   time.Time
+}
+
+// Compile-time verification that Broker implements stream.Broker
+var _ stream.Broker = (*Broker)(nil)
+
+// NewBroker creates a database broker polling every interval and keeping up
+// to bufferSize updates for every subscriber
+func NewBroker(db *gorm.DB, interval time.Duration, bufferSize int) *Broker {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &Broker{
+		db:       db,
+		local:    broker.NewMemory(bufferSize),
+		interval: interval,
+	}
+}
+
+// Publish stores the update for every instance to pick up, removing the
+// updates kept past their retention
+func (b *Broker) Publish(ctx context.Context, update stream.Update) error {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to encode update: %w", err)
+	}
+	if err := b.db.WithContext(ctx).Create(&GormModel{Payload: string(payload)}).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	b.mu.Lock()
+	due := now.Sub(b.cleanedAt) >= retention
+	if due {
+		b.cleanedAt = now
+	}
+	b.mu.Unlock()
+	if due {
+		if err := b.db.WithContext(ctx).Where("created_at < ?", now.Add(-retention)).Delete(&GormModel{}).Error; err != nil {
+			log.Printf("Failed to remove old stream updates: %v", err)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber until ctx is done. The instance polls for
+// updates while it has subscribers.
+func (b *Broker) Subscribe(ctx context.Context) (<-chan stream.Update, error) {
+	b.mu.Lock()
+	if b.subscribers == 0 {
+		from, err := b.newestID()
+		if err != nil {
+			b.mu.Unlock()
+			return nil, err
+		}
+		pollCtx, stop := context.WithCancel(context.Background())
+		b.stop = stop
+		go b.poll(pollCtx, from)
+	}
+	b.subscribers++
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		b.subscribers--
+		if b.subscribers == 0 {
+			b.stop()
+		}
+		b.mu.Unlock()
+	}()
+	return b.local.Subscribe(ctx)
+}
+
+// poll fans the updates stored after the one with ID from out to the
+// subscribers of this instance until ctx is done
+func (b *Broker) poll(ctx context.Context, from uint) {
+	newest := from
+	seen := make(map[uint]bool)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var after uint
+		if newest > lateRows {
+			after = newest - lateRows
+		}
+		var models []GormModel
+		if err := b.db.WithContext(ctx).Where("id > ?", max(after, from)).Order("id").Find(&models).Error; err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Failed to poll stream updates: %v", err)
+			}
+			continue
+		}
+
+		for _, model := range models {
+			if seen[model.ID] {
+				continue
+			}
+			seen[model.ID] = true
+			newest = max(newest, model.ID)
+
+			var update stream.Update
+			if err := json.Unmarshal([]byte(model.Payload), &update); err != nil {
+				log.Printf("Skipping stream update %d that cannot be decoded: %v", model.ID, err)
+				continue
+			}
+			_ = b.local.Publish(ctx, update)
+		}
+
+		// IDs below the late window are no longer looked at
+		for id := range seen {
+			if id+lateRows <= newest {
+				delete(seen, id)
+			}
+		}
+	}
+}
+
+// newestID returns the ID of the newest stored update, or 0 if there is none
+func (b *Broker) newestID() (uint, error) {
+	var newest uint
+	err := b.db.Model(&GormModel{}).Select("COALESCE(MAX(id), 0)").Scan(&newest).Error
+	return newest, err
+}
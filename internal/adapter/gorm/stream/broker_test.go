@@ -0,0 +1,52 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/stream"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerSharesUpdatesBetweenInstances(t *testing.T) {
+	db := gormtest.Open(t, &GormModel{})
+	// Two instances sharing the database
+	first := NewBroker(db, 10*time.Millisecond, 8)
+	second := NewBroker(db, 10*time.Millisecond, 8)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, first.Publish(ctx, stream.Update{ID: 1, Type: event.TypeReservationCreated}))
+	updates, err := second.Subscribe(ctx)
+	require.NoError(t, err)
+
+	buildingID := uint(3)
+	published := stream.Update{ID: 2, Type: event.TypeReservationCancelled, AggregateID: 7, ResourceID: 4, BuildingID: &buildingID, UserID: "user-1"}
+	require.NoError(t, first.Publish(ctx, published))
+
+	select {
+	case update := <-updates:
+		assert.Equal(t, published.ID, update.ID, "updates published before subscribing are not replayed")
+		assert.Equal(t, published.Type, update.Type)
+		assert.Equal(t, published.UserID, update.UserID)
+		require.NotNil(t, update.BuildingID)
+		assert.Equal(t, buildingID, *update.BuildingID)
+	case <-time.After(time.Second):
+		t.Fatal("the update published by the other instance was not received")
+	}
+
+	select {
+	case update := <-updates:
+		t.Fatalf("update %d was received twice", update.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	_, open := <-updates
+	assert.False(t, open, "the channel is closed once the subscriber is done")
+}
@@ -0,0 +1,19 @@
+package stream
+
+import (
+	"time"
+)
+
+// GormModel represents the GORM database model for live updates shared
+// between instances. Rows are only kept until every instance has had time to
+// poll them.
+type GormModel struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Payload   string    `gorm:"type:text;not null" json:"payload"` // the update as JSON
+	CreatedAt time.Time `gorm:"autoCreateTime;index" json:"createdAt"`
+}
+
+// TableName returns the table name for the Update model
+func (GormModel) TableName() string {
+	return "stream_updates"
+}
@@ -0,0 +1,164 @@
+package waitlist
+
+import (
+	"fmt"
+	"sarc-ng/internal/adapter/gorm/common"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/waitlist"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements waitlist.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements waitlist.Repository
+var _ waitlist.Repository = (*GormAdapter)(nil)
+
+// columns lists the fields waitlist entries can be filtered and sorted by
+var columns = common.Columns{
+	"resourceId": "resource_id",
+	"userId":     "user_id",
+	"status":     "status",
+	"startTime":  "start_time",
+	"endTime":    "end_time",
+	"expiresAt":  "expires_at",
+	"createdAt":  "created_at",
+	"updatedAt":  "updated_at",
+}
+
+// NewGormAdapter creates a new waitlist GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadWaitlistEntryList retrieves the page of waitlist entries selected by the query
+func (a *GormAdapter) ReadWaitlistEntryList(query domainCommon.Query) (*domainCommon.Page[waitlist.Entry], error) {
+	return common.FindPage(a.db, query, columns, modelToDomain)
+}
+
+// ReadWaitlistEntry retrieves a waitlist entry by ID
+func (a *GormAdapter) ReadWaitlistEntry(id uint) (*waitlist.Entry, error) {
+	var model GormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("waitlist entry not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// CreateWaitlistEntry adds a new waitlist entry
+func (a *GormAdapter) CreateWaitlistEntry(e *waitlist.Entry) error {
+	model := domainToModel(*e)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*e = modelToDomain(model)
+	return nil
+}
+
+// UpdateWaitlistEntry modifies an existing waitlist entry
+func (a *GormAdapter) UpdateWaitlistEntry(e *waitlist.Entry) error {
+	model := domainToModel(*e)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*e = modelToDomain(model)
+	return nil
+}
+
+// FindWaitingWaitlistEntries retrieves the waiting entries of a resource overlapping [start, end), oldest first
+func (a *GormAdapter) FindWaitingWaitlistEntries(resourceID uint, start, end time.Time) ([]waitlist.Entry, error) {
+	var models []GormModel
+	err := a.db.
+		Where("resource_id = ? AND status = ?", resourceID, waitlist.StatusWaiting).
+		Where("start_time < ? AND end_time > ?", end, start).
+		Order("created_at").Order("id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]waitlist.Entry, len(models))
+	for i, model := range models {
+		entities[i] = modelToDomain(model)
+	}
+	return entities, nil
+}
+
+// FindWaitingWaitlistEntry retrieves a waiting entry of the user for the resource overlapping [start, end)
+func (a *GormAdapter) FindWaitingWaitlistEntry(userID string, resourceID uint, start, end time.Time) (*waitlist.Entry, error) {
+	var model GormModel
+	err := a.db.
+		Where("user_id = ? AND resource_id = ? AND status = ?", userID, resourceID, waitlist.StatusWaiting).
+		Where("start_time < ? AND end_time > ?", end, start).
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entity := modelToDomain(model)
+	return &entity, nil
+}
+
+// ExpireWaitlistEntries marks the waiting entries expired at now
+func (a *GormAdapter) ExpireWaitlistEntries(now time.Time) (int, error) {
+	result := a.db.Model(&GormModel{}).
+		Where("status = ? AND expires_at <= ?", waitlist.StatusWaiting, now).
+		Update("status", waitlist.StatusExpired)
+	return int(result.RowsAffected), result.Error
+}
+
+// domainToModel converts domain entity to GORM model
+func domainToModel(entity waitlist.Entry) GormModel {
+	return GormModel{
+		ID:            entity.ID,
+		ResourceID:    entity.ResourceID,
+		UserID:        entity.UserID,
+		UserGroups:    entity.UserGroups,
+		StartTime:     entity.StartTime,
+		EndTime:       entity.EndTime,
+		Purpose:       entity.Purpose,
+		Description:   entity.Description,
+		Status:        string(entity.Status),
+		ExpiresAt:     entity.ExpiresAt,
+		ReservationID: entity.ReservationID,
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
+	}
+}
+
+// modelToDomain converts GORM model to domain entity
+func modelToDomain(model GormModel) waitlist.Entry {
+	return waitlist.Entry{
+		ID:            model.ID,
+		ResourceID:    model.ResourceID,
+		UserID:        model.UserID,
+		UserGroups:    model.UserGroups,
+		StartTime:     model.StartTime,
+		EndTime:       model.EndTime,
+		Purpose:       model.Purpose,
+		Description:   model.Description,
+		Status:        waitlist.Status(model.Status),
+		ExpiresAt:     model.ExpiresAt,
+		ReservationID: model.ReservationID,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}
+}
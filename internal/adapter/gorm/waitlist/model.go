@@ -0,0 +1,28 @@
+package waitlist
+
+import (
+	"time"
+)
+
+// GormModel represents the GORM database model for waitlist entries
+// idx_waitlist_entries_waiting backs the promotion query in FindWaitingWaitlistEntries
+type GormModel struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ResourceID    uint      `gorm:"not null;index:idx_waitlist_entries_waiting,priority:1" json:"resourceId"`
+	UserID        string    `gorm:"type:varchar(255);not null;index" json:"userId"`
+	UserGroups    []string  `gorm:"type:text;serializer:json" json:"userGroups"`
+	StartTime     time.Time `gorm:"not null;index:idx_waitlist_entries_waiting,priority:3" json:"startTime"`
+	EndTime       time.Time `gorm:"not null" json:"endTime"`
+	Purpose       string    `gorm:"type:varchar(255)" json:"purpose"`
+	Description   string    `gorm:"type:text" json:"description"`
+	Status        string    `gorm:"type:varchar(50);default:'waiting';index:idx_waitlist_entries_waiting,priority:2" json:"status"`
+	ExpiresAt     time.Time `gorm:"not null;index" json:"expiresAt"`
+	ReservationID *uint     `json:"reservationId"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for the Entry model
+func (GormModel) TableName() string {
+	return "waitlist_entries"
+}
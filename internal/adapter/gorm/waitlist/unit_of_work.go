@@ -0,0 +1,35 @@
+package waitlist
+
+import (
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/waitlist"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork implements waitlist.UnitOfWork using GORM transactions
+type UnitOfWork struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that UnitOfWork implements waitlist.UnitOfWork
+var _ waitlist.UnitOfWork = (*UnitOfWork)(nil)
+
+// NewUnitOfWork creates a new waitlist unit of work
+func NewUnitOfWork(db *gorm.DB) *UnitOfWork {
+	return &UnitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn with waitlist and reservation adapters, an outbox and an audit trail bound to one transaction
+func (u *UnitOfWork) Do(fn func(entries waitlist.Repository, reservations reservation.Repository, events event.Outbox, trail audit.Trail) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGormAdapter(tx), reservationAdapter.NewGormAdapter(tx), eventAdapter.NewOutbox(tx), auditAdapter.NewTrail(tx))
+	})
+}
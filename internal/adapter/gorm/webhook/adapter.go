@@ -0,0 +1,221 @@
+package webhook
+
+import (
+	"fmt"
+	"sarc-ng/internal/adapter/gorm/common"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/webhook"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GormAdapter implements webhook.Repository using GORM
+type GormAdapter struct {
+	db *gorm.DB
+}
+
+// Compile-time verification that GormAdapter implements webhook.Repository
+var _ webhook.Repository = (*GormAdapter)(nil)
+
+// deliveryColumns lists the fields deliveries can be filtered and sorted by
+var deliveryColumns = common.Columns{
+	"subscriptionId": "subscription_id",
+	"eventId":        "event_id",
+	"eventType":      "event_type",
+	"attempt":        "attempt",
+	"status":         "status",
+	"statusCode":     "status_code",
+	"createdAt":      "created_at",
+}
+
+// NewGormAdapter creates a new webhook GORM adapter
+func NewGormAdapter(db *gorm.DB) *GormAdapter {
+	return &GormAdapter{
+		db: db,
+	}
+}
+
+// ReadWebhookSubscriptionList retrieves every subscription ordered by ID
+func (a *GormAdapter) ReadWebhookSubscriptionList() ([]webhook.Subscription, error) {
+	return a.findSubscriptions(a.db)
+}
+
+// ReadActiveWebhookSubscriptionList retrieves the active subscriptions ordered by ID
+func (a *GormAdapter) ReadActiveWebhookSubscriptionList() ([]webhook.Subscription, error) {
+	return a.findSubscriptions(a.db.Where("active = ?", true))
+}
+
+// ReadWebhookSubscription retrieves a subscription by ID
+func (a *GormAdapter) ReadWebhookSubscription(id uint) (*webhook.Subscription, error) {
+	var model SubscriptionGormModel
+	if err := a.db.First(&model, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("webhook subscription not found: %w", domainCommon.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	entity := subscriptionToDomain(model)
+	return &entity, nil
+}
+
+// CreateWebhookSubscription adds a new subscription
+func (a *GormAdapter) CreateWebhookSubscription(s *webhook.Subscription) error {
+	model := subscriptionToModel(*s)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*s = subscriptionToDomain(model)
+	return nil
+}
+
+// UpdateWebhookSubscription modifies an existing subscription
+func (a *GormAdapter) UpdateWebhookSubscription(s *webhook.Subscription) error {
+	model := subscriptionToModel(*s)
+	if err := a.db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with modified fields
+	*s = subscriptionToDomain(model)
+	return nil
+}
+
+// DeleteWebhookSubscription removes a subscription and its delivery log
+func (a *GormAdapter) DeleteWebhookSubscription(id uint) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&DeliveryGormModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&SubscriptionGormModel{}, id).Error
+	})
+}
+
+// ReadWebhookDeliveryList retrieves the page of deliveries selected by the query
+func (a *GormAdapter) ReadWebhookDeliveryList(query domainCommon.Query) (*domainCommon.Page[webhook.Delivery], error) {
+	return common.FindPage(a.db, query, deliveryColumns, deliveryToDomain)
+}
+
+// FindLastWebhookDelivery retrieves the latest attempt to post an event to a
+// subscription, or nil if there is none
+func (a *GormAdapter) FindLastWebhookDelivery(subscriptionID, eventID uint) (*webhook.Delivery, error) {
+	var model DeliveryGormModel
+	err := a.db.
+		Where("subscription_id = ? AND event_id = ?", subscriptionID, eventID).
+		Order("id DESC").
+		First(&model).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entity := deliveryToDomain(model)
+	return &entity, nil
+}
+
+// CreateWebhookDelivery records a delivery attempt
+func (a *GormAdapter) CreateWebhookDelivery(d *webhook.Delivery) error {
+	model := deliveryToModel(*d)
+	if err := a.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// Update the entity with generated fields
+	*d = deliveryToDomain(model)
+	return nil
+}
+
+// findSubscriptions retrieves the subscriptions selected by db ordered by ID
+func (a *GormAdapter) findSubscriptions(db *gorm.DB) ([]webhook.Subscription, error) {
+	var models []SubscriptionGormModel
+	if err := db.Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]webhook.Subscription, len(models))
+	for i, model := range models {
+		entities[i] = subscriptionToDomain(model)
+	}
+	return entities, nil
+}
+
+// subscriptionToModel converts domain entity to GORM model
+func subscriptionToModel(entity webhook.Subscription) SubscriptionGormModel {
+	types := make([]string, len(entity.EventTypes))
+	for i, t := range entity.EventTypes {
+		types[i] = string(t)
+	}
+	return SubscriptionGormModel{
+		ID:          entity.ID,
+		URL:         entity.URL,
+		Description: entity.Description,
+		EventTypes:  strings.Join(types, ","),
+		Secret:      entity.Secret,
+		Active:      entity.Active,
+		CreatedBy:   entity.CreatedBy,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+// subscriptionToDomain converts GORM model to domain entity
+func subscriptionToDomain(model SubscriptionGormModel) webhook.Subscription {
+	var types []event.Type
+	if model.EventTypes != "" {
+		for _, t := range strings.Split(model.EventTypes, ",") {
+			types = append(types, event.Type(t))
+		}
+	}
+	return webhook.Subscription{
+		ID:          model.ID,
+		URL:         model.URL,
+		Description: model.Description,
+		EventTypes:  types,
+		Secret:      model.Secret,
+		Active:      model.Active,
+		CreatedBy:   model.CreatedBy,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+// deliveryToModel converts domain entity to GORM model
+func deliveryToModel(entity webhook.Delivery) DeliveryGormModel {
+	return DeliveryGormModel{
+		ID:             entity.ID,
+		SubscriptionID: entity.SubscriptionID,
+		EventID:        entity.EventID,
+		EventType:      string(entity.EventType),
+		Attempt:        entity.Attempt,
+		Status:         string(entity.Status),
+		StatusCode:     entity.StatusCode,
+		ResponseBody:   entity.ResponseBody,
+		Error:          entity.Error,
+		DurationMs:     entity.Duration.Milliseconds(),
+		CreatedAt:      entity.CreatedAt,
+	}
+}
+
+// deliveryToDomain converts GORM model to domain entity
+func deliveryToDomain(model DeliveryGormModel) webhook.Delivery {
+	return webhook.Delivery{
+		ID:             model.ID,
+		SubscriptionID: model.SubscriptionID,
+		EventID:        model.EventID,
+		EventType:      event.Type(model.EventType),
+		Attempt:        model.Attempt,
+		Status:         webhook.DeliveryStatus(model.Status),
+		StatusCode:     model.StatusCode,
+		ResponseBody:   model.ResponseBody,
+		Error:          model.Error,
+		Duration:       time.Duration(model.DurationMs) * time.Millisecond,
+		CreatedAt:      model.CreatedAt,
+	}
+}
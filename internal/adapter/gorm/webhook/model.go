@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"time"
+)
+
+// SubscriptionGormModel represents the GORM database model for webhook subscriptions.
+// Event types are stored comma separated.
+type SubscriptionGormModel struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	URL         string    `gorm:"type:varchar(2048);not null" json:"url"`
+	Description string    `gorm:"type:text" json:"description"`
+	EventTypes  string    `gorm:"type:varchar(2000)" json:"eventTypes"`
+	Secret      string    `gorm:"type:varchar(255);not null" json:"-"`
+	Active      bool      `gorm:"not null;index" json:"active"`
+	CreatedBy   string    `gorm:"type:varchar(255)" json:"createdBy"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updatedAt"`
+}
+
+// TableName returns the table name for the Subscription model
+func (SubscriptionGormModel) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// DeliveryGormModel represents the GORM database model for webhook delivery attempts.
+// The duration is stored in milliseconds.
+// idx_webhook_deliveries_event backs FindLastWebhookDelivery
+type DeliveryGormModel struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	SubscriptionID uint      `gorm:"not null;index:idx_webhook_deliveries_event,priority:1" json:"subscriptionId"`
+	EventID        uint      `gorm:"not null;index:idx_webhook_deliveries_event,priority:2" json:"eventId"`
+	EventType      string    `gorm:"type:varchar(100);not null" json:"eventType"`
+	Attempt        int       `gorm:"not null" json:"attempt"`
+	Status         string    `gorm:"type:varchar(20);not null;index" json:"status"`
+	StatusCode     int       `gorm:"not null;default:0" json:"statusCode"`
+	ResponseBody   string    `gorm:"type:text" json:"responseBody"`
+	Error          string    `gorm:"type:text" json:"error"`
+	DurationMs     int64     `gorm:"not null;default:0" json:"durationMs"`
+	CreatedAt      time.Time `gorm:"autoCreateTime;index" json:"createdAt"`
+}
+
+// TableName returns the table name for the Delivery model
+func (DeliveryGormModel) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"sarc-ng/internal/domain/notification"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/waitlist"
+	"time"
+)
+
+// LogNotifier writes notifications to the application log, for deployments
+// that have no other way to reach users
+type LogNotifier struct{}
+
+// Compile-time verification that LogNotifier implements the notifier ports
+var (
+	_ waitlist.Notifier     = (*LogNotifier)(nil)
+	_ reservation.Notifier  = (*LogNotifier)(nil)
+	_ notification.Notifier = (*LogNotifier)(nil)
+)
+
+// NewLogNotifier creates a new log notifier
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// NotifyWaitlistPromoted logs that a waitlist entry became a reservation
+func (n *LogNotifier) NotifyWaitlistPromoted(entry waitlist.Entry, r reservation.Reservation) error {
+	log.Printf("Waitlist entry %d of user %s promoted to pending reservation %d of resource %d from %s to %s",
+		entry.ID, entry.UserID, r.ID, r.ResourceID, r.StartTime.Format(time.RFC3339), r.EndTime.Format(time.RFC3339))
+	return nil
+}
+
+// NotifyReservationReminder logs a reminder of an upcoming reservation
+func (n *LogNotifier) NotifyReservationReminder(r reservation.Reservation) error {
+	log.Printf("Reminder for user %s: reservation %d of resource %d from %s to %s",
+		r.UserID, r.ID, r.ResourceID, r.StartTime.Format(time.RFC3339), r.EndTime.Format(time.RFC3339))
+	return nil
+}
+
+// Send logs an email instead of sending it, for deployments without a mail server
+func (n *LogNotifier) Send(_ context.Context, m notification.Message) error {
+	log.Printf("Email to %s: %s", m.To, m.Subject)
+	return nil
+}
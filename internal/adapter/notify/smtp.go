@@ -0,0 +1,183 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"sarc-ng/internal/domain/notification"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TLS modes of an SMTP connection
+const (
+	// TLSStartTLS upgrades a plain connection with STARTTLS, failing if the server cannot
+	TLSStartTLS = "starttls"
+	// TLSImplicit connects over TLS from the start, usually on port 465
+	TLSImplicit = "tls"
+	// TLSNone sends in the clear, for local relays and tests
+	TLSNone = "none"
+)
+
+// defaultSMTPTimeout bounds a whole SMTP conversation when no timeout is set
+const defaultSMTPTimeout = 30 * time.Second
+
+// SMTPSettings configure the mail server notifications are sent through
+type SMTPSettings struct {
+	Host     string
+	Port     int
+	Username string // no authentication when empty
+	Password string
+	From     string // sender address, e.g. "SARC <no-reply@example.com>"
+	TLS      string
+	Timeout  time.Duration
+}
+
+// SMTPNotifier sends notifications as plain text emails over SMTP
+type SMTPNotifier struct {
+	settings SMTPSettings
+	from     *mail.Address
+}
+
+// Compile-time verification that SMTPNotifier implements notification.Notifier
+var _ notification.Notifier = (*SMTPNotifier)(nil)
+
+// NewSMTPNotifier creates a notifier sending through the configured mail server
+func NewSMTPNotifier(settings SMTPSettings) (*SMTPNotifier, error) {
+	if settings.Host == "" || settings.Port <= 0 {
+		return nil, fmt.Errorf("SMTP host and port are required")
+	}
+	switch settings.TLS {
+	case TLSStartTLS, TLSImplicit, TLSNone:
+	default:
+		return nil, fmt.Errorf("unknown SMTP TLS mode %q, expected %s, %s or %s", settings.TLS, TLSStartTLS, TLSImplicit, TLSNone)
+	}
+	from, err := mail.ParseAddress(settings.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", settings.From, err)
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = defaultSMTPTimeout
+	}
+	return &SMTPNotifier{settings: settings, from: from}, nil
+}
+
+// Send delivers the message to the mail server
+func (n *SMTPNotifier) Send(ctx context.Context, m notification.Message) error {
+	to, err := mail.ParseAddress(m.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", m.To, err)
+	}
+	body, err := n.compose(to, m)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.settings.Timeout)
+	defer cancel()
+
+	conn, err := n.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to mail server: %w", err)
+	}
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, n.settings.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to greet mail server: %w", err)
+	}
+	defer client.Close()
+
+	if n.settings.TLS == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("mail server does not support STARTTLS")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: n.settings.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if n.settings.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.settings.Username, n.settings.Password, n.settings.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.from.Address); err != nil {
+		return fmt.Errorf("mail server refused sender: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("mail server refused recipient: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("mail server refused message: %w", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("mail server refused message: %w", err)
+	}
+	return client.Quit()
+}
+
+// dial opens the connection to the mail server
+func (n *SMTPNotifier) dial(ctx context.Context) (net.Conn, error) {
+	address := net.JoinHostPort(n.settings.Host, strconv.Itoa(n.settings.Port))
+	if n.settings.TLS == TLSImplicit {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: n.settings.Host}}
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", address)
+}
+
+// compose formats the message as a MIME email with a quoted-printable body
+func (n *SMTPNotifier) compose(to *mail.Address, m notification.Message) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	domain := n.from.Address[strings.LastIndex(n.from.Address, "@")+1:]
+
+	var buf bytes.Buffer
+	headers := []struct{ name, value string }{
+		{"From", n.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", singleLine(m.Subject))},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", "<" + hex.EncodeToString(id) + "@" + domain + ">"},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h.name, h.value)
+	}
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// singleLine keeps a header value on one line
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
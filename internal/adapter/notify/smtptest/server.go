@@ -0,0 +1,151 @@
+// Package smtptest provides an in-process SMTP server for notifier tests
+package smtptest
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Message is an email the server accepted
+type Message struct {
+	From string
+	To   []string
+	Data string // headers and body as received, with CRLF line endings
+}
+
+// Server speaks just enough SMTP to accept plain text mail without TLS or
+// authentication. It keeps every accepted message.
+type Server struct {
+	Host string
+	Port int
+
+	listener net.Listener
+	mu       sync.Mutex
+	messages []Message
+	refuse   int
+}
+
+// NewServer starts a server on a random local port. It is closed on cleanup.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("failed to start SMTP server: %v", err)
+	}
+	addr := listener.Addr().(*net.TCPAddr)
+	s := &Server{Host: addr.IP.String(), Port: addr.Port, listener: listener}
+	tb.Cleanup(func() { _ = listener.Close() })
+
+	go s.serve()
+	return s
+}
+
+// Messages returns the messages accepted so far
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// RefuseNext has the server reject the next n messages with a temporary failure
+func (s *Server) RefuseNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refuse = n
+}
+
+// serve accepts connections until the listener is closed
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle runs one SMTP session
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(code int, text string) {
+		_, _ = conn.Write([]byte(strconv.Itoa(code) + " " + text + "\r\n"))
+	}
+
+	reply(220, "smtptest ready")
+	var current Message
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply(250, "smtptest")
+		case "MAIL":
+			if s.refused() {
+				reply(451, "try again later")
+				continue
+			}
+			current = Message{From: address(arg)}
+			reply(250, "OK")
+		case "RCPT":
+			current.To = append(current.To, address(arg))
+			reply(250, "OK")
+		case "DATA":
+			reply(354, "end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			current.Data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			reply(250, "OK")
+		case "RSET", "NOOP":
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, "command not implemented")
+		}
+	}
+}
+
+// refused reports whether the next message should be rejected
+func (s *Server) refused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refuse > 0 {
+		s.refuse--
+		return true
+	}
+	return false
+}
+
+// address extracts the address from a "FROM:<a@b>" or "TO:<a@b>" argument
+func address(arg string) string {
+	start, end := strings.Index(arg, "<"), strings.Index(arg, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return arg[start+1 : end]
+}
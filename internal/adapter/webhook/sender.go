@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sarc-ng/internal/domain/webhook"
+	"time"
+)
+
+// requestTimeout bounds a single webhook request
+const requestTimeout = 10 * time.Second
+
+// HTTPSender posts webhook requests over HTTP
+type HTTPSender struct {
+	client *http.Client
+}
+
+// Compile-time verification that HTTPSender implements webhook.Sender
+var _ webhook.Sender = (*HTTPSender)(nil)
+
+// NewHTTPSender creates a sender with a bounded request timeout
+func NewHTTPSender() *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Send posts the request and returns the status and the start of the body of the response
+func (s *HTTPSender) Send(ctx context.Context, r webhook.Request) (*webhook.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range r.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, webhook.MaxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	// Drain the rest so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return &webhook.Response{StatusCode: resp.StatusCode, Body: string(body)}, nil
+}
@@ -6,13 +6,20 @@ import (
 
 // Config holds the complete application configuration
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Redis    RedisConfig    `mapstructure:"redis"`
-	Cognito  CognitoConfig  `mapstructure:"cognito"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
-	API      APIConfig      `mapstructure:"api"`
+	Server     ServerConfig     `mapstructure:"server"`
+	Database   DatabaseConfig   `mapstructure:"database"`
+	Redis      RedisConfig      `mapstructure:"redis"`
+	Cognito    CognitoConfig    `mapstructure:"cognito"`
+	Auth       AuthConfig       `mapstructure:"auth"`
+	JWT        JWTConfig        `mapstructure:"jwt"`
+	Logging    LoggingConfig    `mapstructure:"logging"`
+	API        APIConfig        `mapstructure:"api"`
+	Scheduling SchedulingConfig `mapstructure:"scheduling"`
+	Jobs       JobsConfig       `mapstructure:"jobs"`
+	Events     EventsConfig     `mapstructure:"events"`
+
+	Notifications NotificationsConfig `mapstructure:"notifications"`
+	Stream        StreamConfig        `mapstructure:"stream"`
 }
 
 // ServerConfig holds server-related configuration
@@ -53,10 +60,50 @@ type CognitoConfig struct {
 	JWKSCacheExp time.Duration `mapstructure:"jwks_cache_expiry"`
 }
 
-// JWTConfig holds JWT-related configuration
+// AuthConfig holds who signs users in and what they may do. Provider is
+// cognito, oidc or local. Permissions maps permission names such as building:write to
+// the least privileged role granted them (admin, manager or teacher);
+// permissions left out keep their default role.
+type AuthConfig struct {
+	Provider    string            `mapstructure:"provider"`
+	OIDC        OIDCConfig        `mapstructure:"oidc"`
+	Permissions map[string]string `mapstructure:"permissions"`
+}
+
+// OIDCConfig holds the OpenID Connect providers whose tokens are trusted. The
+// claims name the username and groups of every issuer that does not name its
+// own; groups may be a dotted path such as realm_access.roles.
+type OIDCConfig struct {
+	Issuers       []OIDCIssuerConfig `mapstructure:"issuers"`
+	UsernameClaim string             `mapstructure:"username_claim"`
+	GroupsClaim   string             `mapstructure:"groups_claim"`
+	JWKSCacheExp  time.Duration      `mapstructure:"jwks_cache_expiry"`
+}
+
+// OIDCIssuerConfig is a trusted issuer. Audiences lists the accepted aud or
+// azp values; empty accepts any. Users of the primary issuer are identified by
+// their sub, those of the others by "<url>|<sub>"; a single issuer is primary.
+type OIDCIssuerConfig struct {
+	URL           string   `mapstructure:"url"`
+	Audiences     []string `mapstructure:"audiences"`
+	UsernameClaim string   `mapstructure:"username_claim"`
+	GroupsClaim   string   `mapstructure:"groups_claim"`
+	Primary       bool     `mapstructure:"primary"`
+}
+
+// JWTConfig holds the local token issuer, used with auth.provider local.
+// HS256 signs with Secret. RS256 signs with the PEM private key in KeyFile, or
+// in development a key generated on startup, and also accepts the PEM public
+// keys in PublicKeyFiles, such as those of replaced keys. DevTokens lets
+// anyone ask for a token at POST /api/v1/auth/token.
 type JWTConfig struct {
-	Secret string        `mapstructure:"secret"`
-	Expiry time.Duration `mapstructure:"expiry"`
+	Secret         string        `mapstructure:"secret"`
+	Expiry         time.Duration `mapstructure:"expiry"`
+	Issuer         string        `mapstructure:"issuer"`
+	Algorithm      string        `mapstructure:"algorithm"`
+	KeyFile        string        `mapstructure:"key_file"`
+	PublicKeyFiles []string      `mapstructure:"public_key_files"`
+	DevTokens      bool          `mapstructure:"dev_tokens"`
 }
 
 // LoggingConfig holds logging-related configuration
@@ -71,3 +118,123 @@ type APIConfig struct {
 	BaseURL string        `mapstructure:"base_url"`
 	Timeout time.Duration `mapstructure:"timeout"`
 }
+
+// SchedulingConfig holds booking-related configuration
+type SchedulingConfig struct {
+	OpeningHours OpeningHoursConfig    `mapstructure:"opening_hours"`
+	Policies     []BookingPolicyConfig `mapstructure:"policies"`
+	Quotas       QuotaConfig           `mapstructure:"quotas"`
+	CheckIn      CheckInConfig         `mapstructure:"check_in"`
+}
+
+// OpeningHoursConfig holds the daily hours in which resources can be booked
+type OpeningHoursConfig struct {
+	TimeZone string   `mapstructure:"time_zone"`
+	Open     string   `mapstructure:"open"`  // HH:MM
+	Close    string   `mapstructure:"close"` // HH:MM, 24:00 for midnight
+	Days     []string `mapstructure:"days"`
+}
+
+// BookingPolicyConfig holds the booking rules of a resource type. Policies are
+// stored on startup for resource types that have none yet and are then managed
+// through the API. Zero values leave a rule unset.
+type BookingPolicyConfig struct {
+	ResourceType  string             `mapstructure:"resource_type"`
+	MaxDuration   time.Duration      `mapstructure:"max_duration"`
+	MinLeadTime   time.Duration      `mapstructure:"min_lead_time"`
+	MaxAdvance    time.Duration      `mapstructure:"max_advance"`
+	Buffer        time.Duration      `mapstructure:"buffer"`
+	AllowedHours  OpeningHoursConfig `mapstructure:"allowed_hours"`
+	AllowedGroups []string           `mapstructure:"allowed_groups"`
+	NoShowLimit   int                `mapstructure:"no_show_limit"`
+	NoShowPeriod  time.Duration      `mapstructure:"no_show_period"`
+}
+
+// QuotaConfig holds the limits on how much each user may book. Weeks start on
+// Monday in the time zone.
+type QuotaConfig struct {
+	TimeZone string            `mapstructure:"time_zone"`
+	Rules    []QuotaRuleConfig `mapstructure:"rules"`
+}
+
+// CheckInConfig holds when reservations can be checked in to. Approved
+// reservations nobody checked in to within the grace period after their start
+// are released as no-shows by the release-no-shows job.
+type CheckInConfig struct {
+	GracePeriod time.Duration `mapstructure:"grace_period"`
+	EarlyWindow time.Duration `mapstructure:"early_window"`
+}
+
+// QuotaRuleConfig limits the bookings of a group's members, or of every user
+// without a rule of their own when the group is empty. An empty resource type
+// counts bookings of every type. Zero values mean unlimited.
+type QuotaRuleConfig struct {
+	Group        string        `mapstructure:"group"`
+	ResourceType string        `mapstructure:"resource_type"`
+	MaxActive    int           `mapstructure:"max_active"`
+	MaxWeekly    time.Duration `mapstructure:"max_weekly"`
+}
+
+// JobsConfig holds the background jobs. Schedules are cron expressions in the
+// time zone that replace the default schedule of a job by name, or "off" to
+// run it on demand only.
+type JobsConfig struct {
+	Enabled      bool              `mapstructure:"enabled"` // run the scheduler in the server
+	TimeZone     string            `mapstructure:"time_zone"`
+	Timeout      time.Duration     `mapstructure:"timeout"`
+	Schedules    map[string]string `mapstructure:"schedules"`
+	ReminderLead time.Duration     `mapstructure:"reminder_lead"`
+	PurgeAfter   time.Duration     `mapstructure:"purge_after"` // 0 keeps deleted records
+}
+
+// EventsConfig holds how the domain events recorded in the outbox are
+// dispatched. Messages failing MaxAttempts times are marked dead; retries wait
+// RetryBackoff, doubled after every attempt.
+type EventsConfig struct {
+	DispatchInterval time.Duration   `mapstructure:"dispatch_interval"` // poll the outbox in the server; 0 leaves it to the dispatch job
+	BatchSize        int             `mapstructure:"batch_size"`
+	MaxAttempts      int             `mapstructure:"max_attempts"`
+	RetryBackoff     time.Duration   `mapstructure:"retry_backoff"`
+	Log              bool            `mapstructure:"log"` // write every event to the application log
+	Webhooks         []WebhookConfig `mapstructure:"webhooks"`
+}
+
+// WebhookConfig is a URL events are posted to. Types limits the events sent;
+// empty sends every event.
+type WebhookConfig struct {
+	URL   string   `mapstructure:"url"`
+	Types []string `mapstructure:"types"`
+}
+
+// NotificationsConfig holds the emails sent to users about their reservations.
+// Notifications are queued and sent in the background; failures are retried
+// after RetryBackoff, doubled after every attempt, up to MaxAttempts times.
+type NotificationsConfig struct {
+	SendInterval  time.Duration `mapstructure:"send_interval"` // poll the queue in the server; 0 leaves it to the send job
+	BatchSize     int           `mapstructure:"batch_size"`
+	MaxAttempts   int           `mapstructure:"max_attempts"`
+	RetryBackoff  time.Duration `mapstructure:"retry_backoff"`
+	DefaultLocale string        `mapstructure:"default_locale"`
+	TimeZone      string        `mapstructure:"time_zone"`     // times in emails are shown in this zone
+	TemplatesDir  string        `mapstructure:"templates_dir"` // adds or replaces templates, as <locale>/<kind>.tmpl
+	SMTP          SMTPConfig    `mapstructure:"smtp"`
+}
+
+// SMTPConfig holds the mail server notifications are sent through. Without a
+// host, emails are written to the application log instead.
+type SMTPConfig struct {
+	Host     string        `mapstructure:"host"`
+	Port     int           `mapstructure:"port"`
+	Username string        `mapstructure:"username"`
+	Password string        `mapstructure:"password"`
+	From     string        `mapstructure:"from"`
+	TLS      string        `mapstructure:"tls"` // starttls, tls or none
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// StreamConfig holds the live updates pushed over SSE and WebSocket
+type StreamConfig struct {
+	Broker       string        `mapstructure:"broker"`        // fans updates out to the clients: "memory" within one instance, "database" across instances
+	BufferSize   int           `mapstructure:"buffer_size"`   // updates a slow client may fall behind by before missing some
+	PollInterval time.Duration `mapstructure:"poll_interval"` // how often the database broker looks for new updates
+}
@@ -108,9 +108,19 @@ func setDefaults() {
 	viper.SetDefault("cognito.client_id", "")
 	viper.SetDefault("cognito.jwks_cache_expiry", "1h")
 
-	// JWT defaults
+	// Auth defaults: Cognito signs users in unless an OIDC provider is set up
+	viper.SetDefault("auth.provider", "cognito")
+	viper.SetDefault("auth.oidc.username_claim", "preferred_username")
+	viper.SetDefault("auth.oidc.groups_claim", "groups")
+	viper.SetDefault("auth.oidc.jwks_cache_expiry", "1h")
+
+	// JWT defaults: the local issuer signs with a generated RS256 key
 	viper.SetDefault("jwt.secret", "your-secret-key")
 	viper.SetDefault("jwt.expiry", "24h")
+	viper.SetDefault("jwt.issuer", "http://localhost:8080")
+	viper.SetDefault("jwt.algorithm", "RS256")
+	viper.SetDefault("jwt.key_file", "")
+	viper.SetDefault("jwt.dev_tokens", false)
 
 	// Logging defaults
 	viper.SetDefault("logging.level", "info")
@@ -120,6 +130,52 @@ func setDefaults() {
 	// API defaults
 	viper.SetDefault("api.base_url", "http://localhost:8080")
 	viper.SetDefault("api.timeout", "30s")
+
+	// Scheduling defaults: resources are always open, quotas unlimited and
+	// check-in closes 15 minutes after a reservation starts
+	viper.SetDefault("scheduling.opening_hours.time_zone", "UTC")
+	viper.SetDefault("scheduling.opening_hours.open", "00:00")
+	viper.SetDefault("scheduling.opening_hours.close", "24:00")
+	viper.SetDefault("scheduling.quotas.time_zone", "UTC")
+	viper.SetDefault("scheduling.check_in.grace_period", "15m")
+	viper.SetDefault("scheduling.check_in.early_window", "15m")
+
+	// Job defaults: the server runs the scheduler, reminds owners an hour
+	// ahead and keeps deleted records for 30 days
+	viper.SetDefault("jobs.enabled", true)
+	viper.SetDefault("jobs.time_zone", "UTC")
+	viper.SetDefault("jobs.timeout", "5m")
+	viper.SetDefault("jobs.reminder_lead", "1h")
+	viper.SetDefault("jobs.purge_after", "720h")
+
+	// Event defaults: the server delivers events every few seconds and gives
+	// up on one after ten failed attempts
+	viper.SetDefault("events.dispatch_interval", "5s")
+	viper.SetDefault("events.batch_size", 100)
+	viper.SetDefault("events.max_attempts", 10)
+	viper.SetDefault("events.retry_backoff", "30s")
+	viper.SetDefault("events.log", false)
+
+	// Notification defaults: without an SMTP host emails are only logged
+	viper.SetDefault("notifications.send_interval", "10s")
+	viper.SetDefault("notifications.batch_size", 50)
+	viper.SetDefault("notifications.max_attempts", 5)
+	viper.SetDefault("notifications.retry_backoff", "1m")
+	viper.SetDefault("notifications.default_locale", "en")
+	viper.SetDefault("notifications.time_zone", "UTC")
+	viper.SetDefault("notifications.templates_dir", "")
+	viper.SetDefault("notifications.smtp.host", "")
+	viper.SetDefault("notifications.smtp.port", 587)
+	viper.SetDefault("notifications.smtp.username", "")
+	viper.SetDefault("notifications.smtp.password", "")
+	viper.SetDefault("notifications.smtp.from", "sarc@localhost")
+	viper.SetDefault("notifications.smtp.tls", "starttls")
+	viper.SetDefault("notifications.smtp.timeout", "30s")
+
+	// Stream defaults: a single node fans live updates out in memory
+	viper.SetDefault("stream.broker", "memory")
+	viper.SetDefault("stream.buffer_size", 64)
+	viper.SetDefault("stream.poll_interval", "1s")
 }
 
 // mapEnvironmentVars maps standard environment variables to viper keys
@@ -141,6 +197,15 @@ func mapEnvironmentVars() {
 		"COGNITO_REGION":       "cognito.region",
 	}
 
+	// Map SMTP environment variables, so that credentials stay out of files
+	smtpEnvMap := map[string]string{
+		"SMTP_HOST":     "notifications.smtp.host",
+		"SMTP_PORT":     "notifications.smtp.port",
+		"SMTP_USERNAME": "notifications.smtp.username",
+		"SMTP_PASSWORD": "notifications.smtp.password",
+		"SMTP_FROM":     "notifications.smtp.from",
+	}
+
 	// Apply database environment variable mappings
 	for envVar, configKey := range dbEnvMap {
 		if value := os.Getenv(envVar); value != "" {
@@ -155,6 +220,13 @@ func mapEnvironmentVars() {
 		}
 	}
 
+	// Apply SMTP environment variable mappings
+	for envVar, configKey := range smtpEnvMap {
+		if value := os.Getenv(envVar); value != "" {
+			viper.Set(configKey, value)
+		}
+	}
+
 	// Also handle PORT for server (common in Docker/Heroku)
 	if port := os.Getenv("PORT"); port != "" {
 		viper.Set("server.port", port)
@@ -210,10 +282,26 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("database name is required")
 	}
 
-	if config.JWT.Secret == "" || config.JWT.Secret == "your-secret-key" {
+	if config.Auth.Provider == "local" && config.JWT.Algorithm == "HS256" &&
+		(config.JWT.Secret == "" || config.JWT.Secret == "your-secret-key") {
 		log.Printf("Warning: Using default JWT secret, please set a secure secret in production")
 	}
 
+	if env := getEnvironment(); config.JWT.DevTokens && (env == "prod" || env == "production") {
+		return fmt.Errorf("jwt.dev_tokens cannot be enabled in %s", env)
+	}
+
+	// A generated key is lost on restart and differs between instances, so
+	// tokens signed by one are rejected by the others
+	if env := getEnvironment(); config.Auth.Provider == "local" && config.JWT.Algorithm == "RS256" &&
+		config.JWT.KeyFile == "" && env != "dev" && env != "development" {
+		return fmt.Errorf("jwt.key_file is required in %s, every instance would sign tokens with its own generated key", env)
+	}
+
+	if config.JWT.DevTokens {
+		log.Printf("Warning: Development tokens are enabled, anyone can sign in as anyone")
+	}
+
 	return nil
 }
 
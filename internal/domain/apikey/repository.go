@@ -0,0 +1,17 @@
+package apikey
+
+import "time"
+
+// Repository defines the data access operations for API keys
+// All methods are explicitly named with the APIKey entity
+type Repository interface {
+	ReadAPIKeyList() ([]APIKey, error)
+	ReadAPIKey(id uint) (*APIKey, error)
+	// FindAPIKeyByHash retrieves the key with the hash, or nil if there is none
+	FindAPIKeyByHash(hash string) (*APIKey, error)
+	CreateAPIKey(key *APIKey) error
+	UpdateAPIKey(key *APIKey) error
+	DeleteAPIKey(id uint) error
+	// TouchAPIKey records when a key was last used
+	TouchAPIKey(id uint, usedAt time.Time) error
+}
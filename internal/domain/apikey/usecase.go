@@ -0,0 +1,19 @@
+package apikey
+
+import "sarc-ng/internal/domain/auth"
+
+// Usecase defines the business logic operations for API keys. Keys are
+// accepted in the X-API-Key header wherever tokens are.
+type Usecase interface {
+	auth.KeyAuthenticator
+
+	GetAllAPIKeys() ([]APIKey, error)
+	GetAPIKey(id uint) (*APIKey, error)
+	// CreateAPIKey generates and stores a new key, recording the admin who
+	// created it. The key is only returned by this call.
+	CreateAPIKey(admin *auth.User, key *APIKey) error
+	// UpdateAPIKey changes the name, permissions, buildings or expiry of a key
+	UpdateAPIKey(key *APIKey) error
+	// DeleteAPIKey revokes a key
+	DeleteAPIKey(id uint) error
+}
@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"reflect"
+	"sarc-ng/internal/domain/auth"
+	"strings"
+	"time"
+)
+
+// Action is the kind of change an audit entry records
+type Action string
+
+const (
+	// ActionCreate records a new entity
+	ActionCreate Action = "create"
+	// ActionUpdate records an edit of an entity
+	ActionUpdate Action = "update"
+	// ActionDelete records a removed entity
+	ActionDelete Action = "delete"
+	// ActionTransition records a change of state, such as a reservation being
+	// approved, cancelled or checked in to
+	ActionTransition Action = "transition"
+)
+
+// Actions lists every action
+var Actions = []Action{ActionCreate, ActionUpdate, ActionDelete, ActionTransition}
+
+// Types of the audited entities
+const (
+	EntityBuilding    = "building"
+	EntityClass       = "class"
+	EntityLesson      = "lesson"
+	EntityResource    = "resource"
+	EntityReservation = "reservation"
+)
+
+// Entry records who changed an entity, when and how
+type Entry struct {
+	ID         uint
+	Action     Action
+	EntityType string
+	EntityID   uint
+	// ActorID is the Cognito sub of the user who made the change. It is empty
+	// for changes made by background jobs and for requests without a token,
+	// which still have a RequestID.
+	ActorID       string
+	ActorUsername string
+	ActorGroups   []string
+	RequestID     string
+	Changes       []Change
+	CreatedAt     time.Time
+}
+
+// Change is the value of a field before and after a change. Values are nil
+// for fields of entities that did not exist before or do not exist after it.
+type Change struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// NewEntry describes a change to an entity made by the actor, or by the
+// system when actor is nil. before is nil for created entities and after is
+// nil for deleted ones.
+func NewEntry(actor *auth.User, action Action, entityType string, entityID uint, before, after any) Entry {
+	entry := Entry{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    Diff(before, after),
+	}
+	if actor != nil {
+		entry.ActorID = actor.ID
+		entry.ActorUsername = actor.Username
+		entry.ActorGroups = actor.Groups
+		entry.RequestID = actor.RequestID
+	}
+	return entry
+}
+
+// untracked are the fields left out of diffs because the database maintains them
+var untracked = map[string]bool{"CreatedAt": true, "UpdatedAt": true, "DeletedAt": true}
+
+// Diff compares two states of an entity field by field, in the order the
+// fields are declared. Either state may be nil, in which case only the fields
+// set in the other are listed. Times are compared as instants and given in
+// RFC 3339; pointers are compared by the values they point to.
+func Diff(before, after any) []Change {
+	names, beforeValues := fields(before)
+	afterNames, afterValues := fields(after)
+	if names == nil {
+		names = afterNames
+	}
+
+	var changes []Change
+	for _, name := range names {
+		b, hasBefore := beforeValues[name]
+		a, hasAfter := afterValues[name]
+		switch {
+		case !hasBefore && isZero(a), !hasAfter && isZero(b):
+			continue
+		case reflect.DeepEqual(b, a):
+			continue
+		}
+		changes = append(changes, Change{Field: fieldName(name), Before: b, After: a})
+	}
+	return changes
+}
+
+// fields returns the tracked fields of a struct or pointer to a struct in
+// declaration order together with their comparable values
+func fields(v any) ([]string, map[string]any) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	rt := rv.Type()
+	names := make([]string, 0, rt.NumField())
+	values := make(map[string]any, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if !f.IsExported() || untracked[f.Name] {
+			continue
+		}
+		names = append(names, f.Name)
+		values[f.Name] = value(rv.Field(i))
+	}
+	return names, values
+}
+
+// value converts a field to the form it is compared and stored in
+func value(rv reflect.Value) any {
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if t, ok := rv.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return nil
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0 {
+		return nil
+	}
+	return rv.Interface()
+}
+
+// isZero reports whether a compared value is unset
+func isZero(v any) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
+
+// fieldName converts a Go field name to its API name, e.g. ResourceID to resourceId
+func fieldName(name string) string {
+	if strings.HasSuffix(name, "ID") {
+		name = strings.TrimSuffix(name, "ID") + "Id"
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"sarc-ng/internal/domain/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// booking has the kinds of fields the audited entities have
+type booking struct {
+	ID          uint
+	ResourceID  uint
+	Purpose     string
+	StartTime   time.Time
+	SeriesID    *uint
+	CheckedInAt *time.Time
+	Tags        []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func TestDiff(t *testing.T) {
+	start := time.Date(2030, 3, 4, 14, 0, 0, 0, time.UTC)
+	series := uint(3)
+	current := booking{ID: 7, ResourceID: 2, Purpose: "Lab", StartTime: start, SeriesID: &series, CreatedAt: start}
+
+	t.Run("Created entities list their set fields", func(t *testing.T) {
+		assert.Equal(t, []Change{
+			{Field: "id", After: uint(7)},
+			{Field: "resourceId", After: uint(2)},
+			{Field: "purpose", After: "Lab"},
+			{Field: "startTime", After: "2030-03-04T14:00:00Z"},
+			{Field: "seriesId", After: uint(3)},
+		}, Diff(nil, &current))
+	})
+
+	t.Run("Deleted entities list their set fields", func(t *testing.T) {
+		changes := Diff(current, nil)
+		assert.Len(t, changes, 5)
+		assert.Equal(t, Change{Field: "purpose", Before: "Lab"}, changes[2])
+	})
+
+	t.Run("Updates list the changed fields only", func(t *testing.T) {
+		checkedIn := start.Add(5 * time.Minute)
+		sameSeries := uint(3)
+		updated := current
+		updated.Purpose = "Exam"
+		updated.StartTime = start.In(time.FixedZone("BRT", -3*60*60))
+		updated.SeriesID = &sameSeries
+		updated.CheckedInAt = &checkedIn
+		updated.Tags = []string{}
+		updated.UpdatedAt = checkedIn
+
+		assert.Equal(t, []Change{
+			{Field: "purpose", Before: "Lab", After: "Exam"},
+			{Field: "checkedInAt", Before: nil, After: "2030-03-04T14:05:00Z"},
+		}, Diff(&current, &updated), "equal instants, pointed to values and empty slices are unchanged")
+	})
+
+	t.Run("Equal states have no changes", func(t *testing.T) {
+		assert.Empty(t, Diff(&current, current))
+	})
+}
+
+func TestNewEntry(t *testing.T) {
+	actor := &auth.User{ID: "user-1", Username: "alice", Groups: []string{"manager"}, RequestID: "req-1"}
+
+	entry := NewEntry(actor, ActionTransition, EntityReservation, 7, &booking{Purpose: "Lab"}, &booking{Purpose: "Exam"})
+	assert.Equal(t, Entry{
+		Action:        ActionTransition,
+		EntityType:    EntityReservation,
+		EntityID:      7,
+		ActorID:       "user-1",
+		ActorUsername: "alice",
+		ActorGroups:   []string{"manager"},
+		RequestID:     "req-1",
+		Changes:       []Change{{Field: "purpose", Before: "Lab", After: "Exam"}},
+	}, entry)
+
+	system := NewEntry(nil, ActionTransition, EntityReservation, 7, nil, nil)
+	assert.Empty(t, system.ActorID, "changes without an actor are made by the system")
+	assert.Empty(t, system.Changes)
+}
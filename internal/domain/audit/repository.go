@@ -0,0 +1,16 @@
+package audit
+
+import "sarc-ng/internal/domain/common"
+
+// Repository defines the data access operations for the audit log
+// All methods are explicitly named with the AuditEntry entity
+type Repository interface {
+	ReadAuditEntryList(query common.Query) (*common.Page[Entry], error)
+	ReadAuditEntry(id uint) (*Entry, error)
+}
+
+// Trail records audit entries. Units of work hand out a trail bound to their
+// transaction, so entries are only kept when the change they describe is.
+type Trail interface {
+	RecordAuditEntries(entries ...Entry) error
+}
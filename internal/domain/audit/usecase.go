@@ -0,0 +1,16 @@
+package audit
+
+import (
+	"io"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for the audit log. Entries
+// are recorded by the services making the changes.
+type Usecase interface {
+	GetAuditEntries(query common.Query) (*common.Page[Entry], error)
+	GetAuditEntry(id uint) (*Entry, error)
+	// ExportAuditEntries writes every entry selected by the query as CSV,
+	// one row per changed field
+	ExportAuditEntries(w io.Writer, query common.Query) error
+}
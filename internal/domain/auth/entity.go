@@ -10,6 +10,14 @@ type User struct {
 	Groups     []string
 	Attributes map[string]string
 	AuthTime   time.Time
+	// RequestID identifies the API request the user is making, for the audit log
+	RequestID string
+	// Scopes are the permissions of an API key, which has them instead of
+	// those of groups; nil for users signed in with a token
+	Scopes []Permission
+	// Buildings are the buildings an API key may make changes in; empty for
+	// every building
+	Buildings []uint
 }
 
 // Claims represents JWT token claims from Cognito
@@ -75,3 +83,27 @@ func (u *User) IsManager() bool {
 func (u *User) IsTeacher() bool {
 	return u.HasGroup("teacher") || u.IsManager()
 }
+
+// Token is an access token issued by the application itself
+type Token struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// JWKS represents the JSON Web Key Set structure
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK represents a JSON Web Key
+type JWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
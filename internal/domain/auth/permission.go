@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/common"
+	"slices"
+	"sort"
+)
+
+// Permission names an action that only some users may take
+type Permission string
+
+// Permissions checked by the API
+const (
+	PermissionBuildingWrite      Permission = "building:write"
+	PermissionClassWrite         Permission = "class:write"
+	PermissionLessonWrite        Permission = "lesson:write"
+	PermissionResourceWrite      Permission = "resource:write"
+	PermissionReservationWrite   Permission = "reservation:write"
+	PermissionReservationApprove Permission = "reservation:approve"
+	PermissionPolicyWrite        Permission = "policy:write"
+	PermissionQuotaManage        Permission = "quota:manage"
+	PermissionCheckInIssue       Permission = "checkin:issue"
+	PermissionJobManage          Permission = "job:manage"
+	PermissionEventManage        Permission = "event:manage"
+	PermissionWebhookManage      Permission = "webhook:manage"
+	PermissionAuditRead          Permission = "audit:read"
+	PermissionAPIKeyManage       Permission = "apikey:manage"
+)
+
+// Role is a Cognito group of the role hierarchy: admins are also managers,
+// and managers are also teachers. Every signed-in user has the user role.
+type Role string
+
+// Roles, from the most to the least privileged
+const (
+	RoleAdmin   Role = "admin"
+	RoleManager Role = "manager"
+	RoleTeacher Role = "teacher"
+	RoleUser    Role = "user"
+)
+
+// IsValid checks if the permission is one the API checks
+func (p Permission) IsValid() bool {
+	_, ok := DefaultPermissions()[p]
+	return ok
+}
+
+// HasRole checks if the user has the role or one above it
+func (u *User) HasRole(role Role) bool {
+	switch role {
+	case RoleAdmin:
+		return u.IsAdmin()
+	case RoleManager:
+		return u.IsManager()
+	case RoleTeacher:
+		return u.IsTeacher()
+	case RoleUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// Permissions maps each permission to the least privileged role granted it.
+// Permissions that are not mapped are reserved to admins.
+type Permissions map[Permission]Role
+
+// DefaultPermissions returns the permissions of a fresh installation: every
+// user books, managers maintain the catalogue and approve reservations,
+// teachers also maintain lessons, and admins operate the system
+func DefaultPermissions() Permissions {
+	return Permissions{
+		PermissionBuildingWrite:      RoleManager,
+		PermissionClassWrite:         RoleManager,
+		PermissionLessonWrite:        RoleTeacher,
+		PermissionResourceWrite:      RoleManager,
+		PermissionReservationWrite:   RoleUser,
+		PermissionReservationApprove: RoleManager,
+		PermissionPolicyWrite:        RoleAdmin,
+		PermissionQuotaManage:        RoleManager,
+		PermissionCheckInIssue:       RoleManager,
+		PermissionJobManage:          RoleAdmin,
+		PermissionEventManage:        RoleAdmin,
+		PermissionWebhookManage:      RoleAdmin,
+		PermissionAuditRead:          RoleAdmin,
+		PermissionAPIKeyManage:       RoleAdmin,
+	}
+}
+
+// ParsePermissions returns the default permissions with the roles of the
+// overrides, given as permission name to role name
+func ParsePermissions(overrides map[string]string) (Permissions, error) {
+	p := DefaultPermissions()
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		permission, role := Permission(name), Role(overrides[name])
+		if _, ok := p[permission]; !ok {
+			return nil, fmt.Errorf("%w: unknown permission '%s'", common.ErrInvalidInput, name)
+		}
+		if role != RoleAdmin && role != RoleManager && role != RoleTeacher && role != RoleUser {
+			return nil, fmt.Errorf("%w: unknown role '%s' for permission '%s', expected admin, manager, teacher or user",
+				common.ErrInvalidInput, role, name)
+		}
+		p[permission] = role
+	}
+	return p, nil
+}
+
+// Role returns the least privileged role granted the permission
+func (p Permissions) Role(permission Permission) Role {
+	if role, ok := p[permission]; ok {
+		return role
+	}
+	return RoleAdmin
+}
+
+// Allows checks if the user has been granted the permission, through the
+// scopes of an API key or else through the user's groups
+func (p Permissions) Allows(user *User, permission Permission) bool {
+	if user == nil {
+		return false
+	}
+	if user.Scopes != nil {
+		return slices.Contains(user.Scopes, permission)
+	}
+	return user.HasRole(p.Role(permission))
+}
+
+// CheckBuilding fails unless the user may make changes in the building, which
+// is 0 for changes outside any building. Only API keys limited to some
+// buildings are refused.
+func (u *User) CheckBuilding(buildingID uint) error {
+	if u == nil || len(u.Buildings) == 0 || slices.Contains(u.Buildings, buildingID) {
+		return nil
+	}
+	if buildingID == 0 {
+		return fmt.Errorf("%w: only changes in buildings %v are allowed", common.ErrForbidden, u.Buildings)
+	}
+	return fmt.Errorf("%w: changes in building %d are not allowed, only in buildings %v",
+		common.ErrForbidden, buildingID, u.Buildings)
+}
+
+// Groups returns the Cognito groups granted the permission, which is none
+// when every signed-in user is
+func (p Permissions) Groups(permission Permission) []string {
+	switch p.Role(permission) {
+	case RoleUser:
+		return []string{}
+	case RoleTeacher:
+		return []string{string(RoleAdmin), string(RoleManager), string(RoleTeacher)}
+	case RoleManager:
+		return []string{string(RoleAdmin), string(RoleManager)}
+	default:
+		return []string{string(RoleAdmin)}
+	}
+}
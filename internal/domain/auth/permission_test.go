@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"testing"
+
+	"sarc-ng/internal/domain/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissions(t *testing.T) {
+	permissions, err := ParsePermissions(map[string]string{"lesson:write": "manager"})
+	require.NoError(t, err)
+
+	teacher := &User{ID: "t", Groups: []string{"teacher"}}
+	manager := &User{ID: "m", Groups: []string{"manager"}}
+	admin := &User{ID: "a", Groups: []string{"admin"}}
+
+	assert.False(t, permissions.Allows(teacher, PermissionLessonWrite), "overrides replace the default role")
+	assert.True(t, permissions.Allows(manager, PermissionLessonWrite))
+	assert.True(t, permissions.Allows(admin, PermissionLessonWrite), "admins are also managers")
+	assert.True(t, permissions.Allows(manager, PermissionBuildingWrite), "other permissions keep their default")
+	assert.False(t, permissions.Allows(manager, PermissionAuditRead))
+	assert.False(t, permissions.Allows(nil, PermissionBuildingWrite))
+	assert.False(t, Permissions{}.Allows(manager, PermissionBuildingWrite), "unmapped permissions are reserved to admins")
+	assert.Equal(t, []string{"admin", "manager"}, permissions.Groups(PermissionLessonWrite))
+	assert.True(t, permissions.Allows(&User{ID: "s"}, PermissionReservationWrite), "every user books")
+	assert.False(t, permissions.Allows(&User{ID: "k", Scopes: []Permission{}}, PermissionReservationWrite), "keys only have their scopes")
+	assert.Empty(t, permissions.Groups(PermissionReservationWrite))
+
+	_, err = ParsePermissions(map[string]string{"building:delete": "manager"})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	_, err = ParsePermissions(map[string]string{"building:write": "student"})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	permissions := DefaultPermissions()
+	key := &User{ID: "apikey:1", Scopes: []Permission{PermissionCheckInIssue}, Buildings: []uint{3}}
+
+	assert.True(t, permissions.Allows(key, PermissionCheckInIssue))
+	assert.False(t, permissions.Allows(key, PermissionBuildingWrite), "keys only have their scopes")
+	assert.False(t, permissions.Allows(&User{Scopes: []Permission{}, Groups: []string{"admin"}}, PermissionAuditRead),
+		"keys do not have the permissions of groups")
+
+	assert.NoError(t, key.CheckBuilding(3))
+	assert.ErrorIs(t, key.CheckBuilding(4), common.ErrForbidden)
+	assert.ErrorIs(t, key.CheckBuilding(0), common.ErrForbidden, "changes outside buildings are refused")
+	assert.NoError(t, (&User{ID: "m", Groups: []string{"manager"}}).CheckBuilding(4))
+	assert.NoError(t, (*User)(nil).CheckBuilding(4))
+}
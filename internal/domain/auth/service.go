@@ -1,6 +1,9 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // TokenValidator defines the interface for JWT token validation
 type TokenValidator interface {
@@ -10,3 +13,30 @@ type TokenValidator interface {
 	// RefreshJWKS forces a refresh of the JWKS cache
 	RefreshJWKS(ctx context.Context) error
 }
+
+// TokenIssuer signs tokens itself instead of relying on an identity provider,
+// for development and offline deployments. It validates the tokens it issued.
+type TokenIssuer interface {
+	TokenValidator
+
+	// IssueToken signs an access token for the user, valid for ttl or the
+	// default expiry when ttl is zero
+	IssueToken(user *User, ttl time.Duration) (*Token, error)
+
+	// Issuer returns the iss claim of the tokens
+	Issuer() string
+
+	// KeySet returns the public keys tokens are verified with
+	KeySet() JWKS
+
+	// DevTokens reports whether anyone may ask for a token of their choice
+	DevTokens() bool
+}
+
+// KeyAuthenticator authenticates API keys, for services and devices that
+// cannot sign in interactively
+type KeyAuthenticator interface {
+	// AuthenticateKey returns the user the key acts as, with the key's scopes
+	// and buildings, or an error if it is unknown or expired
+	AuthenticateKey(ctx context.Context, key string) (*User, error)
+}
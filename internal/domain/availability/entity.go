@@ -0,0 +1,38 @@
+package availability
+
+import "time"
+
+const (
+	// DefaultLimit is the number of slots returned when a search sets no limit
+	DefaultLimit = 20
+	// MaxLimit is the largest number of slots a search may return
+	MaxLimit = 100
+	// MaxSearchWindow bounds how far apart the start and end of a search may be
+	MaxSearchWindow = 31 * 24 * time.Hour
+)
+
+// Window is a span of time
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Length returns how long the window lasts
+func (w Window) Length() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Slot is an open window in which a resource has nothing booked or scheduled
+type Slot struct {
+	ResourceID   uint
+	ResourceName string
+	Window
+}
+
+// Search describes the open windows to look for
+type Search struct {
+	From     time.Time
+	To       time.Time
+	Duration time.Duration // minimum length of a slot
+	Limit    int
+}
@@ -0,0 +1,119 @@
+package availability
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// minutesPerDay is the closing time of a resource open until midnight
+const minutesPerDay = 24 * 60
+
+// OpeningHours are the daily hours in which resources can be booked
+type OpeningHours struct {
+	Location *time.Location
+	Open     int // minutes after midnight
+	Close    int // minutes after midnight, at most a full day
+	Days     map[time.Weekday]bool
+}
+
+// AlwaysOpen returns opening hours without any closed time
+func AlwaysOpen() OpeningHours {
+	hours := OpeningHours{Location: time.UTC, Close: minutesPerDay, Days: make(map[time.Weekday]bool)}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		hours.Days[day] = true
+	}
+	return hours
+}
+
+// ParseOpeningHours builds opening hours from their configuration. Times use
+// the HH:MM format, with 24:00 for midnight at the end of the day, and days are
+// English weekday names. No days means every day.
+func ParseOpeningHours(timeZone, open, close string, days []string) (OpeningHours, error) {
+	hours := AlwaysOpen()
+
+	if timeZone != "" {
+		location, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return OpeningHours{}, fmt.Errorf("unknown time zone %q: %w", timeZone, err)
+		}
+		hours.Location = location
+	}
+
+	var err error
+	if open != "" {
+		if hours.Open, err = parseClock(open); err != nil {
+			return OpeningHours{}, err
+		}
+	}
+	if close != "" {
+		if hours.Close, err = parseClock(close); err != nil {
+			return OpeningHours{}, err
+		}
+	}
+	if hours.Open >= hours.Close {
+		return OpeningHours{}, fmt.Errorf("opening time %s must be before closing time %s", open, close)
+	}
+
+	if len(days) > 0 {
+		hours.Days = make(map[time.Weekday]bool, len(days))
+		for _, name := range days {
+			day, err := parseWeekday(name)
+			if err != nil {
+				return OpeningHours{}, err
+			}
+			hours.Days[day] = true
+		}
+	}
+	return hours, nil
+}
+
+// Windows returns the open windows between from and to, merging days that run
+// into each other
+func (h OpeningHours) Windows(from, to time.Time) []Window {
+	location := h.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	var windows []Window
+	start := from.In(location)
+	// Start a day early so a window that spans midnight is not missed
+	day := time.Date(start.Year(), start.Month(), start.Day()-1, 0, 0, 0, 0, location)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !h.Days[day.Weekday()] {
+			continue
+		}
+		open := time.Date(day.Year(), day.Month(), day.Day(), 0, h.Open, 0, 0, location)
+		close := time.Date(day.Year(), day.Month(), day.Day(), 0, h.Close, 0, 0, location)
+		if window, ok := clip(Window{Start: open, End: close}, from, to); ok {
+			windows = append(windows, window)
+		}
+	}
+	return merge(windows)
+}
+
+// parseClock parses an HH:MM time of day into minutes after midnight
+func parseClock(value string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	minutes := hour*60 + minute
+	if hour < 0 || minute < 0 || minute > 59 || minutes > minutesPerDay {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return minutes, nil
+}
+
+// parseWeekday parses a full or three-letter English weekday name
+func parseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", name)
+}
@@ -0,0 +1,62 @@
+package availability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOpeningHours(t *testing.T) {
+	_, err := ParseOpeningHours("UTC", "18:00", "08:00", nil)
+	assert.Error(t, err)
+	_, err = ParseOpeningHours("UTC", "8h", "18:00", nil)
+	assert.Error(t, err)
+	_, err = ParseOpeningHours("UTC", "08:00", "18:00", []string{"someday"})
+	assert.Error(t, err)
+	_, err = ParseOpeningHours("Mars/Olympus", "08:00", "18:00", nil)
+	assert.Error(t, err)
+
+	hours, err := ParseOpeningHours("America/Sao_Paulo", "07:00", "24:00", []string{"Monday", "sat"})
+	require.NoError(t, err)
+	assert.Equal(t, 7*60, hours.Open)
+	assert.Equal(t, minutesPerDay, hours.Close)
+	assert.Equal(t, map[time.Weekday]bool{time.Monday: true, time.Saturday: true}, hours.Days)
+}
+
+func TestOpeningHoursWindows(t *testing.T) {
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	hours, err := ParseOpeningHours("America/Sao_Paulo", "07:00", "23:00", []string{"mon", "tue"})
+	require.NoError(t, err)
+
+	// Sunday noon UTC to Wednesday noon UTC
+	from := time.Date(2030, time.January, 6, 12, 0, 0, 0, time.UTC)
+	windows := hours.Windows(from, from.AddDate(0, 0, 3))
+	require.Len(t, windows, 2)
+	assert.True(t, time.Date(2030, time.January, 7, 7, 0, 0, 0, location).Equal(windows[0].Start))
+	assert.True(t, time.Date(2030, time.January, 7, 23, 0, 0, 0, location).Equal(windows[0].End))
+	assert.True(t, time.Date(2030, time.January, 8, 7, 0, 0, 0, location).Equal(windows[1].Start))
+
+	// Days open until midnight run into each other
+	always := AlwaysOpen()
+	windows = always.Windows(from, from.AddDate(0, 0, 3))
+	require.Len(t, windows, 1)
+	assert.Equal(t, 72*time.Hour, windows[0].Length())
+}
+
+func TestFreeWindows(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2030, time.January, 7, hour, 0, 0, 0, time.UTC) }
+	open := []Window{{Start: at(8), End: at(18)}}
+	busy := []Window{
+		{Start: at(7), End: at(9)},
+		{Start: at(11), End: at(12)},
+		{Start: at(11), End: at(13)},
+		{Start: at(17), End: at(20)},
+	}
+
+	free := FreeWindows(open, busy, time.Hour)
+	assert.Equal(t, []Window{{Start: at(9), End: at(11)}, {Start: at(13), End: at(17)}}, free)
+	assert.Equal(t, []Window{{Start: at(13), End: at(17)}}, FreeWindows(open, busy, 3*time.Hour))
+}
@@ -0,0 +1,86 @@
+package availability
+
+import (
+	"sort"
+	"time"
+)
+
+// FreeWindows subtracts the busy windows from the open ones and keeps the
+// remaining windows that last at least minLength, earliest first
+func FreeWindows(open, busy []Window, minLength time.Duration) []Window {
+	busy = merge(busy)
+
+	var free []Window
+	for _, window := range merge(open) {
+		cursor := window.Start
+		for _, b := range busy {
+			if !b.End.After(cursor) {
+				continue
+			}
+			if !b.Start.Before(window.End) {
+				break
+			}
+			if b.Start.After(cursor) {
+				free = appendIfLongEnough(free, Window{Start: cursor, End: b.Start}, minLength)
+			}
+			cursor = b.End
+		}
+		if cursor.Before(window.End) {
+			free = appendIfLongEnough(free, Window{Start: cursor, End: window.End}, minLength)
+		}
+	}
+	return free
+}
+
+// SortSlots ranks slots by earliest start, then by resource
+func SortSlots(slots []Slot) {
+	sort.SliceStable(slots, func(i, j int) bool {
+		if !slots[i].Start.Equal(slots[j].Start) {
+			return slots[i].Start.Before(slots[j].Start)
+		}
+		return slots[i].ResourceID < slots[j].ResourceID
+	})
+}
+
+// appendIfLongEnough appends the window when it lasts at least minLength
+func appendIfLongEnough(windows []Window, window Window, minLength time.Duration) []Window {
+	if window.Length() >= minLength {
+		return append(windows, window)
+	}
+	return windows
+}
+
+// merge sorts windows and joins the ones that overlap or touch
+func merge(windows []Window) []Window {
+	if len(windows) == 0 {
+		return nil
+	}
+
+	sorted := make([]Window, len(windows))
+	copy(sorted, windows)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	merged := []Window{sorted[0]}
+	for _, window := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if window.Start.After(last.End) {
+			merged = append(merged, window)
+			continue
+		}
+		if window.End.After(last.End) {
+			last.End = window.End
+		}
+	}
+	return merged
+}
+
+// clip restricts a window to [from, to) and reports whether anything is left
+func clip(window Window, from, to time.Time) (Window, bool) {
+	if window.Start.Before(from) {
+		window.Start = from
+	}
+	if window.End.After(to) {
+		window.End = to
+	}
+	return window, window.Start.Before(window.End)
+}
@@ -0,0 +1,12 @@
+package availability
+
+import "sarc-ng/internal/domain/common"
+
+// Usecase defines the business logic operations for finding free time
+type Usecase interface {
+	// FindFreeSlots lists the open windows of a resource, earliest first
+	FindFreeSlots(resourceID uint, search Search) ([]Slot, error)
+	// FindFreeSlotsAcross lists the open windows of every available resource
+	// selected by the query, earliest first
+	FindFreeSlotsAcross(resources common.Query, search Search) ([]Slot, error)
+}
@@ -1,12 +1,25 @@
 package building
 
+import (
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+)
+
 // Repository defines the data access operations for buildings
 // All methods are explicitly named with the Building entity
 type Repository interface {
-	ReadBuildingList() ([]Building, error)
+	ReadBuildingList(query common.Query) (*common.Page[Building], error)
 	ReadBuilding(id uint) (*Building, error)
 	FindBuildingByCode(code string) (*Building, error)
 	CreateBuilding(building *Building) error
 	UpdateBuilding(building *Building) error
 	DeleteBuilding(id uint) error
 }
+
+// UnitOfWork runs building changes atomically together with the events and audit entries they record
+type UnitOfWork interface {
+	// Do executes fn with a Repository, an event.Outbox and an audit.Trail bound to a single transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(repo Repository, events event.Outbox, trail audit.Trail) error) error
+}
@@ -1,10 +1,16 @@
 package building
 
-// Usecase defines the business logic operations for building management
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for building management.
+// Changes are made on behalf of an actor, who is recorded in the audit log.
 type Usecase interface {
-	GetAllBuildings() ([]Building, error)
+	GetAllBuildings(query common.Query) (*common.Page[Building], error)
 	GetBuilding(id uint) (*Building, error)
-	CreateBuilding(building *Building) error
-	UpdateBuilding(building *Building) error
-	DeleteBuilding(id uint) error
+	CreateBuilding(actor *auth.User, building *Building) error
+	UpdateBuilding(actor *auth.User, building *Building) error
+	DeleteBuilding(actor *auth.User, id uint) error
 }
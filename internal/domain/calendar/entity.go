@@ -0,0 +1,52 @@
+package calendar
+
+import (
+	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/resource"
+	"time"
+)
+
+// FeedToken grants read access to a user's personal calendar feed.
+// Calendar clients cannot send bearer tokens, so the token travels in the feed URL;
+// only its SHA-256 hash is stored.
+type FeedToken struct {
+	ID         uint
+	UserID     string // subject of the authenticated user
+	Label      string
+	TokenHash  string
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IsRevoked checks if the token can no longer be used
+func (t *FeedToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// Feed holds the events published in a calendar feed
+type Feed struct {
+	Name         string
+	Reservations []reservation.Reservation
+	Lessons      []lesson.Lesson
+	// Resources holds the resources referenced by Reservations, keyed by ID
+	Resources map[uint]resource.Resource
+	// Anonymous feeds show reservations as busy blocks: their owner, purpose,
+	// description and status reason are cleared
+	Anonymous bool
+}
+
+// Anonymize clears the details of the reservations that only their owner and
+// managers may see
+func (f *Feed) Anonymize() {
+	f.Anonymous = true
+	for i := range f.Reservations {
+		r := &f.Reservations[i]
+		r.UserID = ""
+		r.Purpose = ""
+		r.Description = ""
+		r.StatusReason = ""
+	}
+}
@@ -0,0 +1,12 @@
+package calendar
+
+// Repository defines the data access operations for calendar feed tokens
+// All methods are explicitly named with the FeedToken entity
+type Repository interface {
+	ReadFeedTokenList(userID string) ([]FeedToken, error)
+	ReadFeedToken(id uint) (*FeedToken, error)
+	// ReadFeedTokenByHash retrieves a token by the hash of its secret, revoked or not
+	ReadFeedTokenByHash(hash string) (*FeedToken, error)
+	CreateFeedToken(token *FeedToken) error
+	UpdateFeedToken(token *FeedToken) error
+}
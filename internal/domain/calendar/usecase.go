@@ -0,0 +1,19 @@
+package calendar
+
+import "sarc-ng/internal/domain/auth"
+
+// Usecase defines the business logic operations for calendar feeds. Resource
+// and building feeds are public, so they are anonymous unless the actor is a
+// manager.
+type Usecase interface {
+	GetResourceFeed(actor *auth.User, resourceID uint) (*Feed, error)
+	GetBuildingFeed(actor *auth.User, buildingID uint) (*Feed, error)
+	GetLessonFeed() (*Feed, error)
+	// GetUserFeed returns a user's personal feed if token is a valid feed token of that user
+	GetUserFeed(userID string, token string) (*Feed, error)
+
+	// CreateFeedToken issues a feed token and returns it with its secret, which is not stored
+	CreateFeedToken(userID string, label string) (*FeedToken, string, error)
+	GetFeedTokens(userID string) ([]FeedToken, error)
+	RevokeFeedToken(userID string, tokenID uint) error
+}
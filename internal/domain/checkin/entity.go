@@ -0,0 +1,59 @@
+package checkin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Settings control when reservations can be checked in to. Check-in opens
+// EarlyWindow before a reservation starts and closes GracePeriod after it, or
+// when the reservation ends if that is sooner. Approved reservations nobody
+// checked in to by then are released as no-shows.
+type Settings struct {
+	GracePeriod time.Duration
+	EarlyWindow time.Duration
+}
+
+// Window returns when check-in to a reservation of [start, end) opens and closes
+func (s Settings) Window(start, end time.Time) (time.Time, time.Time) {
+	closes := start.Add(s.GracePeriod)
+	if end.Before(closes) {
+		closes = end
+	}
+	return start.Add(-s.EarlyWindow), closes
+}
+
+// Token lets whoever scans the QR code posted at a resource check in to its
+// reservations without signing in. The token travels in the QR code URL; only
+// its SHA-256 hash is stored.
+type Token struct {
+	ID         uint
+	ResourceID uint
+	TokenHash  string
+	IssuedBy   string // subject of the manager who issued it
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IsRevoked checks if the token can no longer be used
+func (t *Token) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// HashToken returns the hex SHA-256 digest stored for a token secret
+func HashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// NoShow records an approved reservation its owner did not check in to
+type NoShow struct {
+	ID            uint
+	UserID        string
+	ReservationID uint
+	ResourceID    uint
+	StartTime     time.Time // start of the missed reservation
+	CreatedAt     time.Time
+}
@@ -0,0 +1,31 @@
+package checkin
+
+import (
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/reservation"
+	"time"
+)
+
+// Repository defines the data access operations for check-in tokens and no-shows
+// All methods are explicitly named with the CheckInToken or NoShow entity
+type Repository interface {
+	// ReadCheckInTokenByHash retrieves a token by the hash of its secret, revoked or not
+	ReadCheckInTokenByHash(hash string) (*Token, error)
+	CreateCheckInToken(token *Token) error
+	// RevokeCheckInTokens revokes the tokens of a resource that are still in use
+	RevokeCheckInTokens(resourceID uint, at time.Time) error
+
+	ReadNoShowList(userID string) ([]NoShow, error)
+	CreateNoShow(noShow *NoShow) error
+	// CountNoShows counts the no-shows of a user at reservations starting after since
+	CountNoShows(userID string, since time.Time) (int, error)
+}
+
+// UnitOfWork records no-shows atomically together with the reservations they
+// release and the events and audit entries they record
+type UnitOfWork interface {
+	// Do executes fn with repositories, an event.Outbox and an audit.Trail bound to a single transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(checkins Repository, reservations reservation.Repository, events event.Outbox, trail audit.Trail) error) error
+}
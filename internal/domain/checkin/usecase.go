@@ -0,0 +1,13 @@
+package checkin
+
+import "sarc-ng/internal/domain/auth"
+
+// Usecase defines the business logic operations for check-in tokens and no-shows.
+// Checking in to a reservation is part of reservation.Usecase.
+type Usecase interface {
+	// IssueCheckInToken issues a token for the QR code of a resource and returns
+	// it with its secret, which is not stored. Earlier tokens of the resource
+	// are revoked.
+	IssueCheckInToken(issuer *auth.User, resourceID uint) (*Token, string, error)
+	GetNoShows(userID string) ([]NoShow, error)
+}
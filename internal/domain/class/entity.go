@@ -6,10 +6,12 @@ import (
 
 // Class represents a classroom or space in the system
 type Class struct {
-	ID        uint
-	Name      string
-	Capacity  int
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time
+	ID       uint
+	Name     string
+	Capacity int
+	// BuildingID is the building the class is in
+	BuildingID uint
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  *time.Time
 }
@@ -1,11 +1,27 @@
 package class
 
+import (
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/resource"
+)
+
 // Repository defines the data access operations for classes
 // All methods are explicitly named with the Class entity
 type Repository interface {
-	ReadClassList() ([]Class, error)
+	ReadClassList(query common.Query) (*common.Page[Class], error)
 	ReadClass(id uint) (*Class, error)
+	ReadClassListByBuilding(buildingID uint) ([]Class, error)
 	CreateClass(class *Class) error
 	UpdateClass(class *Class) error
 	DeleteClass(id uint) error
 }
+
+// UnitOfWork runs class changes atomically together with the resources they
+// move and the events and audit entries they record
+type UnitOfWork interface {
+	// Do executes fn with repositories, an event.Outbox and an audit.Trail bound to a single transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(repo Repository, resources resource.Repository, events event.Outbox, trail audit.Trail) error) error
+}
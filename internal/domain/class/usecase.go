@@ -1,10 +1,17 @@
 package class
 
-// Usecase defines the business logic operations for class management
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for class management.
+// Changes are made on behalf of an actor, who is recorded in the audit log.
 type Usecase interface {
-	GetAllClasses() ([]Class, error)
+	GetAllClasses(query common.Query) (*common.Page[Class], error)
 	GetClass(id uint) (*Class, error)
-	CreateClass(class *Class) error
-	UpdateClass(class *Class) error
-	DeleteClass(id uint) error
+	GetClassesByBuilding(buildingID uint) ([]Class, error)
+	CreateClass(actor *auth.User, class *Class) error
+	UpdateClass(actor *auth.User, class *Class) error
+	DeleteClass(actor *auth.User, id uint) error
 }
@@ -1,6 +1,9 @@
 package common
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Domain errors that can be checked by type across layers
 var (
@@ -44,3 +47,33 @@ func IsUnauthorizedError(err error) bool {
 func IsForbiddenError(err error) bool {
 	return errors.Is(err, ErrForbidden)
 }
+
+// RuleError reports the policy rule a request broke. It wraps one of the
+// domain errors above, so callers that only check the error kind keep working.
+type RuleError struct {
+	Err     error
+	Rule    string
+	Message string
+}
+
+// NewRuleError creates a RuleError of the given kind for the named rule
+func NewRuleError(err error, rule string, format string, args ...any) *RuleError {
+	return &RuleError{Err: err, Rule: rule, Message: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the error kind followed by the message
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.Message)
+}
+
+// Unwrap returns the domain error the rule violation is reported as
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// AsRuleError returns the RuleError in err's chain, if any
+func AsRuleError(err error) (*RuleError, bool) {
+	var ruleErr *RuleError
+	ok := errors.As(err, &ruleErr)
+	return ruleErr, ok
+}
@@ -0,0 +1,55 @@
+package common
+
+// Operator is the comparison a filter applies to a field
+type Operator string
+
+const (
+	// OpEqual matches items whose field equals the value
+	OpEqual Operator = "eq"
+	// OpGreaterOrEqual matches items whose field is at least the value
+	OpGreaterOrEqual Operator = "gte"
+	// OpLessOrEqual matches items whose field is at most the value
+	OpLessOrEqual Operator = "lte"
+)
+
+// SortID is the default sort field and the only one keyset cursors support
+const SortID = "id"
+
+// Filter restricts a list to the items whose field compares to a value
+type Filter struct {
+	Field string
+	Op    Operator
+	Value any
+}
+
+// Query describes how to filter, sort and page a list. Fields use their API
+// names (e.g. "resourceId"); every repository accepts its own whitelist of
+// fields and rejects any other with ErrInvalidInput. A zero Limit reads all
+// matching items.
+type Query struct {
+	Filters []Filter
+	Sort    string
+	Desc    bool
+	Limit   int
+	Offset  int
+	// Cursor continues a listing sorted by ID after the last item of the
+	// previous page. Offset is ignored when it is set.
+	Cursor string
+}
+
+// Where returns a copy of the query with an additional filter
+func (q Query) Where(field string, op Operator, value any) Query {
+	filters := make([]Filter, len(q.Filters), len(q.Filters)+1)
+	copy(filters, q.Filters)
+	q.Filters = append(filters, Filter{Field: field, Op: op, Value: value})
+	return q
+}
+
+// Page is one page of a list with the total number of matching items
+type Page[T any] struct {
+	Items []T
+	Total int64
+	// NextCursor continues the listing after this page; it is empty on the
+	// last page and when the list is not sorted by ID.
+	NextCursor string
+}
@@ -0,0 +1,109 @@
+package event
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Type names a kind of domain event, e.g. "reservation.created"
+type Type string
+
+// Event is a change to a building, resource, lesson or reservation that
+// integrations are told about. Events are serialized to JSON as they are.
+type Event interface {
+	EventType() Type
+	// AggregateType names the kind of record that changed, e.g. "reservation"
+	AggregateType() string
+	// AggregateID is the ID of the record that changed
+	AggregateID() uint
+}
+
+// Status represents the delivery state of an outbox message
+type Status string
+
+const (
+	// StatusPending marks a message waiting to be delivered or retried
+	StatusPending Status = "pending"
+	// StatusDelivered marks a message every sink accepted
+	StatusDelivered Status = "delivered"
+	// StatusDead marks a message given up on after too many failed attempts
+	StatusDead Status = "dead"
+)
+
+// IsValid reports whether the status is known
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusDelivered, StatusDead:
+		return true
+	}
+	return false
+}
+
+// Message is an event stored in the outbox together with the change it
+// describes, waiting to be delivered to the sinks
+type Message struct {
+	ID            uint
+	Type          Type
+	AggregateType string
+	AggregateID   uint
+	Payload       []byte // the event as JSON
+	Status        Status
+	Attempts      int
+	NextAttemptAt time.Time
+	LockedUntil   *time.Time // set while a dispatcher is delivering the message
+	DeliveredTo   []string   // names of the sinks that accepted the message
+	LastError     string
+	OccurredAt    time.Time
+	DeliveredAt   *time.Time
+}
+
+// IsDeliveredTo reports whether the named sink already accepted the message
+func (m *Message) IsDeliveredTo(sink string) bool {
+	return slices.Contains(m.DeliveredTo, sink)
+}
+
+// Envelope is the JSON body posted to webhooks
+type Envelope struct {
+	ID            uint            `json:"id"`
+	Type          Type            `json:"type"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   uint            `json:"aggregateId"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// NewEnvelope wraps a message for delivery over HTTP
+func NewEnvelope(m Message) Envelope {
+	return Envelope{
+		ID:            m.ID,
+		Type:          m.Type,
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		OccurredAt:    m.OccurredAt,
+		Data:          json.RawMessage(m.Payload),
+	}
+}
+
+// Settings configure how outbox messages are dispatched
+type Settings struct {
+	// BatchSize is how many messages a dispatch run delivers at most
+	BatchSize int
+	// MaxAttempts is how many failed attempts turn a message dead
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry, doubled on each later one
+	RetryBackoff time.Duration
+}
+
+// maxBackoff caps the delay between retries
+const maxBackoff = time.Hour
+
+// Backoff returns how long to wait before retrying a message that failed the
+// given number of attempts
+func (s Settings) Backoff(attempts int) time.Duration {
+	delay := s.RetryBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,217 @@
+package event
+
+import (
+	"time"
+)
+
+// Types of the events recorded for every change
+const (
+	TypeBuildingCreated Type = "building.created"
+	TypeBuildingUpdated Type = "building.updated"
+	TypeBuildingDeleted Type = "building.deleted"
+
+	TypeResourceCreated             Type = "resource.created"
+	TypeResourceUpdated             Type = "resource.updated"
+	TypeResourceDeleted             Type = "resource.deleted"
+	TypeResourceAvailabilityChanged Type = "resource.availability_changed"
+
+	TypeLessonCreated     Type = "lesson.created"
+	TypeLessonUpdated     Type = "lesson.updated"
+	TypeLessonDeleted     Type = "lesson.deleted"
+	TypeLessonRescheduled Type = "lesson.rescheduled"
+
+	TypeReservationCreated       Type = "reservation.created"
+	TypeReservationUpdated       Type = "reservation.updated"
+	TypeReservationDeleted       Type = "reservation.deleted"
+	TypeReservationCancelled     Type = "reservation.cancelled"
+	TypeReservationStatusChanged Type = "reservation.status_changed"
+	TypeReservationCheckedIn     Type = "reservation.checked_in"
+)
+
+// Types lists every event type
+var Types = []Type{
+	TypeBuildingCreated, TypeBuildingUpdated, TypeBuildingDeleted,
+	TypeResourceCreated, TypeResourceUpdated, TypeResourceDeleted, TypeResourceAvailabilityChanged,
+	TypeLessonCreated, TypeLessonUpdated, TypeLessonDeleted, TypeLessonRescheduled,
+	TypeReservationCreated, TypeReservationUpdated, TypeReservationDeleted,
+	TypeReservationCancelled, TypeReservationStatusChanged, TypeReservationCheckedIn,
+}
+
+// Building is the state of a building carried by its events
+type Building struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+// AggregateType returns "building"
+func (Building) AggregateType() string { return "building" }
+
+// AggregateID returns the building ID
+func (b Building) AggregateID() uint { return b.ID }
+
+// Resource is the state of a resource carried by its events
+type Resource struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	IsAvailable bool   `json:"isAvailable"`
+	BuildingID  *uint  `json:"buildingId,omitempty"`
+	ClassID     *uint  `json:"classId,omitempty"`
+}
+
+// AggregateType returns "resource"
+func (Resource) AggregateType() string { return "resource" }
+
+// AggregateID returns the resource ID
+func (r Resource) AggregateID() uint { return r.ID }
+
+// Lesson is the state of a lesson carried by its events
+type Lesson struct {
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	ClassID   *uint     `json:"classId,omitempty"`
+}
+
+// AggregateType returns "lesson"
+func (Lesson) AggregateType() string { return "lesson" }
+
+// AggregateID returns the lesson ID
+func (l Lesson) AggregateID() uint { return l.ID }
+
+// Reservation is the state of a reservation carried by its events
+type Reservation struct {
+	ID           uint      `json:"id"`
+	ResourceID   uint      `json:"resourceId"`
+	UserID       string    `json:"userId"`
+	StartTime    time.Time `json:"startTime"`
+	EndTime      time.Time `json:"endTime"`
+	Status       string    `json:"status"`
+	StatusReason string    `json:"statusReason,omitempty"`
+	SeriesID     *uint     `json:"seriesId,omitempty"`
+}
+
+// AggregateType returns "reservation"
+func (Reservation) AggregateType() string { return "reservation" }
+
+// AggregateID returns the reservation ID
+func (r Reservation) AggregateID() uint { return r.ID }
+
+// BuildingCreated is recorded when a building is created
+type BuildingCreated struct{ Building }
+
+// EventType returns TypeBuildingCreated
+func (BuildingCreated) EventType() Type { return TypeBuildingCreated }
+
+// BuildingUpdated is recorded when a building is edited
+type BuildingUpdated struct{ Building }
+
+// EventType returns TypeBuildingUpdated
+func (BuildingUpdated) EventType() Type { return TypeBuildingUpdated }
+
+// BuildingDeleted is recorded when a building is deleted
+type BuildingDeleted struct{ Building }
+
+// EventType returns TypeBuildingDeleted
+func (BuildingDeleted) EventType() Type { return TypeBuildingDeleted }
+
+// ResourceCreated is recorded when a resource is created
+type ResourceCreated struct{ Resource }
+
+// EventType returns TypeResourceCreated
+func (ResourceCreated) EventType() Type { return TypeResourceCreated }
+
+// ResourceUpdated is recorded when a resource is edited
+type ResourceUpdated struct{ Resource }
+
+// EventType returns TypeResourceUpdated
+func (ResourceUpdated) EventType() Type { return TypeResourceUpdated }
+
+// ResourceDeleted is recorded when a resource is deleted
+type ResourceDeleted struct{ Resource }
+
+// EventType returns TypeResourceDeleted
+func (ResourceDeleted) EventType() Type { return TypeResourceDeleted }
+
+// ResourceAvailabilityChanged is recorded when a resource becomes available
+// for booking or stops being so
+type ResourceAvailabilityChanged struct{ Resource }
+
+// EventType returns TypeResourceAvailabilityChanged
+func (ResourceAvailabilityChanged) EventType() Type { return TypeResourceAvailabilityChanged }
+
+// LessonCreated is recorded when a lesson is created or imported
+type LessonCreated struct{ Lesson }
+
+// EventType returns TypeLessonCreated
+func (LessonCreated) EventType() Type { return TypeLessonCreated }
+
+// LessonUpdated is recorded when a lesson is edited or re-imported with changes
+type LessonUpdated struct{ Lesson }
+
+// EventType returns TypeLessonUpdated
+func (LessonUpdated) EventType() Type { return TypeLessonUpdated }
+
+// LessonDeleted is recorded when a lesson is deleted
+type LessonDeleted struct{ Lesson }
+
+// EventType returns TypeLessonDeleted
+func (LessonDeleted) EventType() Type { return TypeLessonDeleted }
+
+// LessonRescheduled is recorded, after LessonUpdated, when a lesson moves to
+// another time or class
+type LessonRescheduled struct {
+	Lesson
+	PreviousStartTime time.Time `json:"previousStartTime"`
+	PreviousEndTime   time.Time `json:"previousEndTime"`
+	PreviousClassID   *uint     `json:"previousClassId,omitempty"`
+}
+
+// EventType returns TypeLessonRescheduled
+func (LessonRescheduled) EventType() Type { return TypeLessonRescheduled }
+
+// ReservationCreated is recorded when a reservation is booked, including the
+// occurrences of a series and reservations promoted from the waitlist
+type ReservationCreated struct{ Reservation }
+
+// EventType returns TypeReservationCreated
+func (ReservationCreated) EventType() Type { return TypeReservationCreated }
+
+// ReservationUpdated is recorded when the details or time of a reservation change
+type ReservationUpdated struct{ Reservation }
+
+// EventType returns TypeReservationUpdated
+func (ReservationUpdated) EventType() Type { return TypeReservationUpdated }
+
+// ReservationDeleted is recorded when a reservation is deleted
+type ReservationDeleted struct{ Reservation }
+
+// EventType returns TypeReservationDeleted
+func (ReservationDeleted) EventType() Type { return TypeReservationDeleted }
+
+// ReservationCancelled is recorded when a reservation is cancelled
+type ReservationCancelled struct{ Reservation }
+
+// EventType returns TypeReservationCancelled
+func (ReservationCancelled) EventType() Type { return TypeReservationCancelled }
+
+// ReservationStatusChanged is recorded when a reservation is approved,
+// rejected, expired, completed or released as a no-show
+type ReservationStatusChanged struct {
+	Reservation
+	PreviousStatus string `json:"previousStatus"`
+}
+
+// EventType returns TypeReservationStatusChanged
+func (ReservationStatusChanged) EventType() Type { return TypeReservationStatusChanged }
+
+// ReservationCheckedIn is recorded when someone checks in to a reservation
+type ReservationCheckedIn struct {
+	Reservation
+	CheckedInAt time.Time `json:"checkedInAt"`
+}
+
+// EventType returns TypeReservationCheckedIn
+func (ReservationCheckedIn) EventType() Type { return TypeReservationCheckedIn }
@@ -0,0 +1,38 @@
+package event
+
+import (
+	"context"
+	"sarc-ng/internal/domain/common"
+	"time"
+)
+
+// Outbox records events in the transaction of the change they describe, so
+// an event is stored exactly when its change is
+type Outbox interface {
+	// RecordEvents stores the events to be dispatched after the transaction commits
+	RecordEvents(events ...Event) error
+}
+
+// Repository defines the data access operations for outbox messages
+// All methods are explicitly named with the OutboxMessage entity
+type Repository interface {
+	ReadOutboxMessageList(query common.Query) (*common.Page[Message], error)
+	ReadOutboxMessage(id uint) (*Message, error)
+	UpdateOutboxMessage(message *Message) error
+
+	// FindDueOutboxMessages returns up to limit pending messages due at now
+	// that no dispatcher holds, oldest first
+	FindDueOutboxMessages(now time.Time, limit int) ([]Message, error)
+	// ClaimOutboxMessage holds a message for the caller until the given time.
+	// It reports false when another dispatcher holds it.
+	ClaimOutboxMessage(id uint, now, until time.Time) (bool, error)
+}
+
+// Sink receives the dispatched events. Deliveries are at least once, so
+// sinks may see a message again after a failure or a crash.
+type Sink interface {
+	// Name identifies the sink in the messages it accepted
+	Name() string
+	// Deliver hands over a message; an error has it retried later
+	Deliver(ctx context.Context, message Message) error
+}
@@ -0,0 +1,18 @@
+package event
+
+import (
+	"context"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for the event outbox
+type Usecase interface {
+	GetOutboxMessages(query common.Query) (*common.Page[Message], error)
+	GetOutboxMessage(id uint) (*Message, error)
+	// RetryOutboxMessage gives a dead message another round of attempts
+	RetryOutboxMessage(id uint) (*Message, error)
+	// DispatchEvents delivers the due messages to every sink and returns how
+	// many were delivered. Failed messages are retried with a growing delay
+	// until they run out of attempts and are marked dead.
+	DispatchEvents(ctx context.Context) (int, error)
+}
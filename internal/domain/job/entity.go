@@ -0,0 +1,86 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a named piece of periodic work
+type Job struct {
+	Name        string
+	Description string
+	// Schedule is a cron expression evaluated in the scheduler's time zone;
+	// jobs without one only run on demand
+	Schedule string
+	// Timeout bounds a single run; zero uses the scheduler's default
+	Timeout time.Duration
+	// Run does the work and returns a short summary of what it did
+	Run func(ctx context.Context) (string, error)
+}
+
+// Trigger tells what started a run
+type Trigger string
+
+const (
+	// TriggerSchedule marks runs started by the in-process scheduler
+	TriggerSchedule Trigger = "schedule"
+	// TriggerManual marks runs started through the API
+	TriggerManual Trigger = "manual"
+	// TriggerEvent marks runs started by a scheduled Lambda event
+	TriggerEvent Trigger = "event"
+)
+
+// RunStatus represents the outcome of a run
+type RunStatus string
+
+const (
+	// RunRunning marks a run that has not finished
+	RunRunning RunStatus = "running"
+	// RunSucceeded marks a run that finished without error
+	RunSucceeded RunStatus = "succeeded"
+	// RunFailed marks a run that returned an error or timed out
+	RunFailed RunStatus = "failed"
+)
+
+// Run records one execution of a job
+type Run struct {
+	ID         uint
+	Job        string
+	Trigger    Trigger
+	Holder     string // instance that ran the job
+	Status     RunStatus
+	Summary    string
+	Error      string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// Duration returns how long the run took, or zero while it is running
+func (r *Run) Duration() time.Duration {
+	if r.FinishedAt == nil {
+		return 0
+	}
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Info describes a registered job with its next scheduled and last run
+type Info struct {
+	Job
+	NextRun *time.Time
+	LastRun *Run
+}
+
+// Settings configure how jobs are scheduled and run
+type Settings struct {
+	// Location is the time zone schedules are evaluated in
+	Location *time.Location
+	// Timeout bounds runs of jobs that set none
+	Timeout time.Duration
+	// Schedules replace the default schedule of jobs by name; "off" leaves a
+	// job to run on demand only
+	Schedules map[string]string
+	// ReminderLead is how long before a reservation starts its owner is reminded
+	ReminderLead time.Duration
+	// PurgeAfter is how long soft-deleted records are kept
+	PurgeAfter time.Duration
+}
@@ -0,0 +1,63 @@
+package job
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/pkg/cron"
+	"strings"
+)
+
+// Registry holds the jobs that can be run, in the order they were registered
+type Registry struct {
+	jobs      []Job
+	schedules map[string]*cron.Schedule
+}
+
+// NewRegistry creates an empty job registry
+func NewRegistry() *Registry {
+	return &Registry{
+		schedules: make(map[string]*cron.Schedule),
+	}
+}
+
+// Register adds a job. Names must be unique and schedules valid cron expressions.
+func (r *Registry) Register(j Job) error {
+	if strings.TrimSpace(j.Name) == "" {
+		return fmt.Errorf("%w: job name cannot be empty", common.ErrInvalidInput)
+	}
+	if j.Run == nil {
+		return fmt.Errorf("%w: job %s has nothing to run", common.ErrInvalidInput, j.Name)
+	}
+	if _, exists := r.Lookup(j.Name); exists {
+		return fmt.Errorf("%w: job %s is already registered", common.ErrConflict, j.Name)
+	}
+	if j.Schedule != "" {
+		schedule, err := cron.Parse(j.Schedule)
+		if err != nil {
+			return fmt.Errorf("%w: job %s: %w", common.ErrInvalidInput, j.Name, err)
+		}
+		r.schedules[j.Name] = schedule
+	}
+	r.jobs = append(r.jobs, j)
+	return nil
+}
+
+// Jobs returns the registered jobs
+func (r *Registry) Jobs() []Job {
+	return r.jobs
+}
+
+// Lookup returns the job with the given name
+func (r *Registry) Lookup(name string) (Job, bool) {
+	for _, j := range r.jobs {
+		if j.Name == name {
+			return j, true
+		}
+	}
+	return Job{}, false
+}
+
+// Schedule returns the parsed schedule of a job, or nil if it only runs on demand
+func (r *Registry) Schedule(name string) *cron.Schedule {
+	return r.schedules[name]
+}
@@ -0,0 +1,28 @@
+package job
+
+import (
+	"sarc-ng/internal/domain/common"
+	"time"
+)
+
+// Repository defines the data access operations for job runs and locks
+// All methods are explicitly named with the JobRun or JobLock entity
+type Repository interface {
+	ReadJobRunList(query common.Query) (*common.Page[Run], error)
+	// ReadLastJobRun retrieves the most recent run of a job, or nil if it never ran
+	ReadLastJobRun(job string) (*Run, error)
+	CreateJobRun(run *Run) error
+	UpdateJobRun(run *Run) error
+
+	// AcquireJobLock takes or renews the named lock for the holder until
+	// now+ttl. It reports false when another holder has it and it has not expired.
+	AcquireJobLock(name, holder string, now time.Time, ttl time.Duration) (bool, error)
+	// ReleaseJobLock gives up the named lock if the holder has it
+	ReleaseJobLock(name, holder string) error
+}
+
+// Purger permanently removes soft-deleted records
+type Purger interface {
+	// PurgeDeleted removes the records deleted before the given time and returns how many there were
+	PurgeDeleted(before time.Time) (int, error)
+}
@@ -0,0 +1,16 @@
+package job
+
+import (
+	"context"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for background jobs. Only
+// one run of a job happens at a time across every instance.
+type Usecase interface {
+	GetJobs() ([]Info, error)
+	GetJobRuns(query common.Query) (*common.Page[Run], error)
+	// RunJob runs a registered job now and records the run. It fails with
+	// common.ErrConflict when the job is already running.
+	RunJob(ctx context.Context, name string, trigger Trigger) (*Run, error)
+}
@@ -12,6 +12,8 @@ type Lesson struct {
 	Description string
 	StartTime   time.Time
 	EndTime     time.Time
+	ClassID     *uint  // the class the lesson is held in, if assigned
+	ExternalID  string // identifies lessons imported from a calendar, so re-imports update them
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
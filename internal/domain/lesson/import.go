@@ -0,0 +1,60 @@
+package lesson
+
+import "time"
+
+// ImportAction is the outcome of importing one calendar event occurrence
+type ImportAction string
+
+const (
+	// ImportCreate means a new lesson is created
+	ImportCreate ImportAction = "create"
+	// ImportUpdate means an existing lesson is changed
+	ImportUpdate ImportAction = "update"
+	// ImportSkip means the event is left out, e.g. because it is unchanged or cancelled
+	ImportSkip ImportAction = "skip"
+	// ImportError means the event could not be imported
+	ImportError ImportAction = "error"
+)
+
+// ImportOptions controls a lesson import
+type ImportOptions struct {
+	// DryRun reports what would happen without changing any lesson
+	DryRun bool
+	// TimeZone is the IANA zone used for floating times; empty means UTC
+	TimeZone string
+}
+
+// ImportItem reports what happened to one occurrence of a calendar event
+type ImportItem struct {
+	ExternalID string
+	Title      string
+	StartTime  time.Time
+	Action     ImportAction
+	LessonID   uint
+	Reason     string
+}
+
+// ImportReport summarises a lesson import
+type ImportReport struct {
+	DryRun  bool
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+	Items   []ImportItem
+}
+
+// Add records an item and updates the counters
+func (r *ImportReport) Add(item ImportItem) {
+	switch item.Action {
+	case ImportCreate:
+		r.Created++
+	case ImportUpdate:
+		r.Updated++
+	case ImportSkip:
+		r.Skipped++
+	case ImportError:
+		r.Failed++
+	}
+	r.Items = append(r.Items, item)
+}
@@ -1,11 +1,26 @@
 package lesson
 
+import (
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+)
+
 // Repository defines the data access operations for lessons
 // All methods are explicitly named with the Lesson entity
 type Repository interface {
-	ReadLessonList() ([]Lesson, error)
+	ReadLessonList(query common.Query) (*common.Page[Lesson], error)
 	ReadLesson(id uint) (*Lesson, error)
+	ReadLessonListByClass(classID uint) ([]Lesson, error)
+	FindLessonByExternalID(externalID string) (*Lesson, error)
 	CreateLesson(lesson *Lesson) error
 	UpdateLesson(lesson *Lesson) error
 	DeleteLesson(id uint) error
 }
+
+// UnitOfWork runs lesson changes atomically together with the events and audit entries they record
+type UnitOfWork interface {
+	// Do executes fn with a Repository, an event.Outbox and an audit.Trail bound to a single transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(repo Repository, events event.Outbox, trail audit.Trail) error) error
+}
@@ -1,10 +1,20 @@
 package lesson
 
-// Usecase defines the business logic operations for lesson management
+import (
+	"io"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for lesson management.
+// Changes are made on behalf of an actor, who is recorded in the audit log.
 type Usecase interface {
-	GetAllLessons() ([]Lesson, error)
+	GetAllLessons(query common.Query) (*common.Page[Lesson], error)
 	GetLesson(id uint) (*Lesson, error)
-	CreateLesson(lesson *Lesson) error
-	UpdateLesson(lesson *Lesson) error
-	DeleteLesson(id uint) error
+	CreateLesson(actor *auth.User, lesson *Lesson) error
+	UpdateLesson(actor *auth.User, lesson *Lesson) error
+	DeleteLesson(actor *auth.User, id uint) error
+
+	// ImportLessons creates or updates lessons from the events of an iCalendar file
+	ImportLessons(actor *auth.User, calendar io.Reader, options ImportOptions) (*ImportReport, error)
 }
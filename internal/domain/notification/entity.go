@@ -0,0 +1,135 @@
+package notification
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/common"
+	"slices"
+	"time"
+)
+
+// Kind names a notification users can receive
+type Kind string
+
+const (
+	// KindReservationApproved tells the owner their reservation was approved
+	KindReservationApproved Kind = "reservation.approved"
+	// KindReservationRejected tells the owner their reservation was rejected
+	KindReservationRejected Kind = "reservation.rejected"
+	// KindReservationCancelled tells the owner their reservation was cancelled
+	KindReservationCancelled Kind = "reservation.cancelled"
+	// KindReservationStarting reminds the owner their reservation is about to start
+	KindReservationStarting Kind = "reservation.starting"
+	// KindWaitlistPromoted tells the owner their waitlist entry became a pending reservation
+	KindWaitlistPromoted Kind = "waitlist.promoted"
+)
+
+// Kinds lists every notification kind
+var Kinds = []Kind{
+	KindReservationApproved,
+	KindReservationRejected,
+	KindReservationCancelled,
+	KindReservationStarting,
+	KindWaitlistPromoted,
+}
+
+// Status represents the delivery state of a queued notification
+type Status string
+
+const (
+	// StatusPending marks a notification waiting to be sent or retried
+	StatusPending Status = "pending"
+	// StatusSent marks a notification the mail server accepted
+	StatusSent Status = "sent"
+	// StatusFailed marks a notification given up on after too many failed attempts
+	StatusFailed Status = "failed"
+)
+
+// Notification is a rendered email queued for sending
+type Notification struct {
+	ID            uint
+	Key           string // identifies what the notification is about, so it is queued once
+	Kind          Kind
+	UserID        string
+	Recipient     string
+	Subject       string
+	Body          string
+	Status        Status
+	Attempts      int
+	NextAttemptAt time.Time
+	LockedUntil   *time.Time // set while a sender is sending the notification
+	LastError     string
+	CreatedAt     time.Time
+	SentAt        *time.Time
+}
+
+// Message is an email handed to a notifier
+type Message struct {
+	To      string
+	Subject string
+	Body    string // plain text
+}
+
+// Reservation describes the reservation a notification is about to the templates
+type Reservation struct {
+	ID           uint
+	ResourceID   uint
+	ResourceName string
+	StartTime    time.Time // in the location of the notification settings
+	EndTime      time.Time
+	Reason       string // why it was rejected or cancelled, if given
+}
+
+// Preferences are the notification settings of a user
+type Preferences struct {
+	UserID    string
+	Email     string // address notifications are sent to, taken from the user's token
+	Locale    string // language of the templates; empty uses the default locale
+	Disabled  bool   // opts out of every notification
+	OptOut    []Kind // kinds the user does not want
+	UpdatedAt time.Time
+}
+
+// Wants reports whether the user receives notifications of the given kind
+func (p *Preferences) Wants(kind Kind) bool {
+	return !p.Disabled && p.Email != "" && !slices.Contains(p.OptOut, kind)
+}
+
+// Validate checks that the preferences are well formed
+func (p *Preferences) Validate() error {
+	for _, kind := range p.OptOut {
+		if !slices.Contains(Kinds, kind) {
+			return fmt.Errorf("%w: unknown notification '%s'", common.ErrInvalidInput, kind)
+		}
+	}
+	if len(p.Locale) > 20 {
+		return fmt.Errorf("%w: locale is too long", common.ErrInvalidInput)
+	}
+	return nil
+}
+
+// Settings configure how notifications are rendered and sent
+type Settings struct {
+	// DefaultLocale is used for users without a locale of their own
+	DefaultLocale string
+	// Location is the time zone times are shown in
+	Location *time.Location
+	// BatchSize is how many notifications a send run sends at most
+	BatchSize int
+	// MaxAttempts is how many failed attempts mark a notification failed
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry, doubled on each later one
+	RetryBackoff time.Duration
+}
+
+// maxBackoff caps the delay between retries
+const maxBackoff = time.Hour
+
+// Backoff returns how long to wait before retrying a notification that failed
+// the given number of attempts
+func (s Settings) Backoff(attempts int) time.Duration {
+	delay := s.RetryBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,33 @@
+package notification
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the data access operations for the notification queue
+// and user preferences
+// All methods are explicitly named with the Notification or NotificationPreferences entity
+type Repository interface {
+	CreateNotification(notification *Notification) error
+	UpdateNotification(notification *Notification) error
+	// ExistsNotification reports whether a notification with the key was queued
+	ExistsNotification(key string) (bool, error)
+	// FindDueNotifications returns up to limit pending notifications due at
+	// now that no sender holds, oldest first
+	FindDueNotifications(now time.Time, limit int) ([]Notification, error)
+	// ClaimNotification holds a notification for the caller until the given
+	// time. It reports false when another sender holds it.
+	ClaimNotification(id uint, now, until time.Time) (bool, error)
+
+	// ReadNotificationPreferences retrieves the preferences of a user, or nil
+	// if there are none
+	ReadNotificationPreferences(userID string) (*Preferences, error)
+	SaveNotificationPreferences(preferences *Preferences) error
+}
+
+// Notifier sends notifications to users
+type Notifier interface {
+	// Send delivers the message; an error has it retried later
+	Send(ctx context.Context, message Message) error
+}
@@ -0,0 +1,23 @@
+package notification
+
+import (
+	"context"
+	"sarc-ng/internal/domain/auth"
+)
+
+// Usecase defines the business logic operations for notifications. Changes
+// to reservations queue notifications, which are sent in the background.
+type Usecase interface {
+	// GetPreferences retrieves the notification preferences of the user
+	GetPreferences(user *auth.User) (*Preferences, error)
+	// UpdatePreferences replaces the locale and opt-outs of the user
+	UpdatePreferences(user *auth.User, preferences *Preferences) error
+	// RememberRecipient records the email address of the user, so they can be
+	// notified of changes made by others
+	RememberRecipient(user *auth.User) error
+
+	// SendNotifications sends the due notifications and returns how many were
+	// sent. Failed notifications are retried with a growing delay until they
+	// run out of attempts.
+	SendNotifications(ctx context.Context) (int, error)
+}
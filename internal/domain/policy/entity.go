@@ -0,0 +1,186 @@
+package policy
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/availability"
+	"sarc-ng/internal/domain/common"
+	"strings"
+	"time"
+)
+
+// Names of the rules reported when a booking breaks a policy
+const (
+	RuleMaxDuration   = "maxDuration"
+	RuleMinLeadTime   = "minLeadTime"
+	RuleMaxAdvance    = "maxAdvance"
+	RuleAllowedHours  = "allowedHours"
+	RuleBuffer        = "buffer"
+	RuleAllowedGroups = "allowedGroups"
+	RuleNoShows       = "noShows"
+)
+
+// Policy holds the booking rules of a resource type. A zero value leaves the
+// rule unset.
+type Policy struct {
+	ID            uint
+	ResourceType  string
+	MaxDuration   time.Duration // longest single booking
+	MinLeadTime   time.Duration // how long before its start a booking must be made
+	MaxAdvance    time.Duration // how far ahead a booking may start
+	Buffer        time.Duration // turnaround time kept free around each booking
+	Hours         Hours         // when bookings may take place
+	AllowedGroups []string      // groups whose members may book; empty means everyone
+	NoShowLimit   int           // no-shows within NoShowPeriod that suspend booking
+	NoShowPeriod  time.Duration // how long a no-show counts against its owner
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Hours are the times of day and days of week in which bookings may take place
+type Hours struct {
+	TimeZone string
+	From     string   // HH:MM
+	Until    string   // HH:MM, 24:00 for midnight
+	Days     []string // English weekday names; empty means every day
+}
+
+// IsSet reports whether any of the hours are configured
+func (h Hours) IsSet() bool {
+	return h.TimeZone != "" || h.From != "" || h.Until != "" || len(h.Days) > 0
+}
+
+// String describes the hours, e.g. "08:00-18:00 on mon, tue (UTC)"
+func (h Hours) String() string {
+	from, until := h.From, h.Until
+	if from == "" {
+		from = "00:00"
+	}
+	if until == "" {
+		until = "24:00"
+	}
+	description := from + "-" + until
+	if len(h.Days) > 0 {
+		description += " on " + strings.Join(h.Days, ", ")
+	}
+	if h.TimeZone != "" {
+		description += " (" + h.TimeZone + ")"
+	}
+	return description
+}
+
+// Booking is a time range someone wants to book
+type Booking struct {
+	Start  time.Time
+	End    time.Time
+	Booker *auth.User // checked against the allowed groups when set
+}
+
+// Validate checks that the policy is well formed
+func (p *Policy) Validate() error {
+	if strings.TrimSpace(p.ResourceType) == "" {
+		return fmt.Errorf("%w: resource type cannot be empty", common.ErrInvalidInput)
+	}
+	durations := []struct {
+		rule  string
+		value time.Duration
+	}{
+		{RuleMaxDuration, p.MaxDuration},
+		{RuleMinLeadTime, p.MinLeadTime},
+		{RuleMaxAdvance, p.MaxAdvance},
+		{RuleBuffer, p.Buffer},
+		{RuleNoShows, p.NoShowPeriod},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			return fmt.Errorf("%w: %s cannot be negative", common.ErrInvalidInput, d.rule)
+		}
+		if d.value%time.Minute != 0 {
+			return fmt.Errorf("%w: %s must be a whole number of minutes", common.ErrInvalidInput, d.rule)
+		}
+	}
+	if p.MaxAdvance > 0 && p.MinLeadTime > p.MaxAdvance {
+		return fmt.Errorf("%w: %s cannot exceed %s", common.ErrInvalidInput, RuleMinLeadTime, RuleMaxAdvance)
+	}
+	if p.NoShowLimit < 0 {
+		return fmt.Errorf("%w: %s limit cannot be negative", common.ErrInvalidInput, RuleNoShows)
+	}
+	if p.NoShowLimit > 0 && p.NoShowPeriod == 0 {
+		return fmt.Errorf("%w: %s limit needs a period", common.ErrInvalidInput, RuleNoShows)
+	}
+	if p.Hours.IsSet() {
+		if _, err := p.openingHours(); err != nil {
+			return fmt.Errorf("%w: %s: %w", common.ErrInvalidInput, RuleAllowedHours, err)
+		}
+	}
+	return nil
+}
+
+// Check verifies a booking made at now against the policy. It returns a
+// *common.RuleError naming the first rule broken, or nil.
+func (p *Policy) Check(b Booking, now time.Time) error {
+	if len(p.AllowedGroups) > 0 && b.Booker != nil && !b.Booker.HasAnyGroup(p.AllowedGroups) {
+		return common.NewRuleError(common.ErrForbidden, RuleAllowedGroups,
+			"%s resources can only be booked by members of %s", p.ResourceType, strings.Join(p.AllowedGroups, ", "))
+	}
+
+	if p.MaxDuration > 0 && b.End.Sub(b.Start) > p.MaxDuration {
+		return common.NewRuleError(common.ErrInvalidInput, RuleMaxDuration,
+			"%s bookings cannot last longer than %s", p.ResourceType, p.MaxDuration)
+	}
+
+	if p.MinLeadTime > 0 && b.Start.Sub(now) < p.MinLeadTime {
+		return common.NewRuleError(common.ErrInvalidInput, RuleMinLeadTime,
+			"%s bookings must be made at least %s before they start", p.ResourceType, p.MinLeadTime)
+	}
+
+	if p.MaxAdvance > 0 && b.Start.Sub(now) > p.MaxAdvance {
+		return common.NewRuleError(common.ErrInvalidInput, RuleMaxAdvance,
+			"%s bookings cannot start more than %s ahead", p.ResourceType, p.MaxAdvance)
+	}
+
+	if p.Hours.IsSet() {
+		hours, err := p.openingHours()
+		if err != nil {
+			return err
+		}
+		// The booking must fit in a single allowed window
+		windows := hours.Windows(b.Start, b.End)
+		if len(windows) != 1 || !windows[0].Start.Equal(b.Start) || !windows[0].End.Equal(b.End) {
+			return common.NewRuleError(common.ErrInvalidInput, RuleAllowedHours,
+				"%s bookings must fall within %s", p.ResourceType, p.Hours)
+		}
+	}
+
+	return nil
+}
+
+// CheckNoShows verifies that a booker with the given number of no-shows within
+// the no-show period may still book
+func (p *Policy) CheckNoShows(noShows int) error {
+	if p.NoShowLimit > 0 && noShows >= p.NoShowLimit {
+		return common.NewRuleError(common.ErrForbidden, RuleNoShows,
+			"booking %s resources is suspended after %d no-shows within %s", p.ResourceType, noShows, p.NoShowPeriod)
+	}
+	return nil
+}
+
+// CheckBuffer verifies that a booking keeps the turnaround time free from the
+// given neighbouring bookings, which must not overlap the booking itself
+func (p *Policy) CheckBuffer(b Booking, neighbours []availability.Window) error {
+	if p.Buffer <= 0 {
+		return nil
+	}
+	for _, n := range neighbours {
+		if n.Start.Before(b.End.Add(p.Buffer)) && n.End.After(b.Start.Add(-p.Buffer)) {
+			return common.NewRuleError(common.ErrInvalidInput, RuleBuffer,
+				"%s bookings need %s free before and after them", p.ResourceType, p.Buffer)
+		}
+	}
+	return nil
+}
+
+// openingHours parses the allowed hours
+func (p *Policy) openingHours() (availability.OpeningHours, error) {
+	return availability.ParseOpeningHours(p.Hours.TimeZone, p.Hours.From, p.Hours.Until, p.Hours.Days)
+}
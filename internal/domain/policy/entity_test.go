@@ -0,0 +1,81 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"sarc-ng/internal/domain/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyAllowedHours(t *testing.T) {
+	p := &Policy{
+		ResourceType: "room",
+		Hours:        Hours{TimeZone: "America/Sao_Paulo", From: "07:00", Until: "22:00", Days: []string{"mon", "tue", "wed", "thu", "fri"}},
+	}
+	require.NoError(t, p.Validate())
+
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	// A Monday long before the bookings are made
+	at := func(day, hour int) time.Time { return time.Date(2030, time.January, 7+day, hour, 0, 0, 0, location) }
+	now := at(-30, 0)
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		allowed    bool
+	}{
+		{"Within the hours", at(0, 8), at(0, 10), true},
+		{"Whole day", at(0, 7), at(0, 22), true},
+		{"Starts too early", at(0, 6), at(0, 8), false},
+		{"Ends too late", at(0, 21), at(0, 23), false},
+		{"Spans the night", at(0, 20), at(1, 8), false},
+		{"On a Saturday", at(5, 8), at(5, 10), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Check(Booking{Start: tt.start, End: tt.end}, now)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			ruleErr, ok := common.AsRuleError(err)
+			require.True(t, ok, "expected a rule error, got %v", err)
+			assert.Equal(t, RuleAllowedHours, ruleErr.Rule)
+			assert.ErrorIs(t, err, common.ErrInvalidInput)
+		})
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	invalid := map[string]Policy{
+		"no resource type":  {MaxDuration: time.Hour},
+		"negative buffer":   {ResourceType: "room", Buffer: -time.Minute},
+		"partial minutes":   {ResourceType: "room", MaxDuration: 90 * time.Second},
+		"lead after limit":  {ResourceType: "room", MinLeadTime: 48 * time.Hour, MaxAdvance: 24 * time.Hour},
+		"bad opening hours": {ResourceType: "room", Hours: Hours{From: "22:00", Until: "07:00"}},
+		"unknown weekday":   {ResourceType: "room", Hours: Hours{Days: []string{"funday"}}},
+		"no-shows forever":  {ResourceType: "room", NoShowLimit: 3},
+	}
+	for name, p := range invalid {
+		assert.ErrorIs(t, p.Validate(), common.ErrInvalidInput, name)
+	}
+}
+
+func TestPolicyCheckNoShows(t *testing.T) {
+	p := Policy{ResourceType: "room", NoShowLimit: 3, NoShowPeriod: 30 * 24 * time.Hour}
+
+	assert.NoError(t, p.CheckNoShows(2))
+
+	ruleErr, ok := common.AsRuleError(p.CheckNoShows(3))
+	if assert.True(t, ok) {
+		assert.Equal(t, RuleNoShows, ruleErr.Rule)
+		assert.ErrorIs(t, ruleErr, common.ErrForbidden)
+	}
+
+	unlimited := Policy{ResourceType: "room"}
+	assert.NoError(t, unlimited.CheckNoShows(10))
+}
@@ -0,0 +1,12 @@
+package policy
+
+// Repository defines the data access operations for booking policies
+// All methods are explicitly named with the Policy entity
+type Repository interface {
+	ReadPolicyList() ([]Policy, error)
+	ReadPolicy(id uint) (*Policy, error)
+	FindPolicyByResourceType(resourceType string) (*Policy, error)
+	CreatePolicy(policy *Policy) error
+	UpdatePolicy(policy *Policy) error
+	DeletePolicy(id uint) error
+}
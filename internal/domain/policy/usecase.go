@@ -0,0 +1,13 @@
+package policy
+
+// Usecase defines the business logic operations for booking policy management
+type Usecase interface {
+	GetAllPolicies() ([]Policy, error)
+	GetPolicy(id uint) (*Policy, error)
+	CreatePolicy(policy *Policy) error
+	UpdatePolicy(policy *Policy) error
+	DeletePolicy(id uint) error
+	// SeedPolicies stores the configured policies of the resource types that
+	// have none yet and returns how many were added
+	SeedPolicies() (int, error)
+}
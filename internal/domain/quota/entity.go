@@ -0,0 +1,192 @@
+package quota
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"strings"
+	"time"
+)
+
+// Names of the quota rules reported when a booking exceeds a quota
+const (
+	RuleMaxActive = "maxActive"
+	RuleMaxWeekly = "maxWeekly"
+)
+
+// Rule limits the bookings of the members of a group. Rules without a group
+// apply to users none of whose groups has a rule for the same resource type.
+type Rule struct {
+	Group        string        // "" for everyone else
+	ResourceType string        // "" counts bookings of every resource type
+	MaxActive    int           // pending or approved reservations not yet over; 0 means unlimited
+	MaxWeekly    time.Duration // booked time per week; 0 means unlimited
+}
+
+// Settings are the configured quota rules. Weeks start on Monday in Location.
+type Settings struct {
+	Location *time.Location
+	Rules    []Rule
+}
+
+// Override raises, lowers or waives a quota of a single user
+type Override struct {
+	ID           uint
+	UserID       string
+	ResourceType string // "" for the quota over every resource type
+	MaxActive    int    // 0 means unlimited
+	MaxWeekly    time.Duration
+	Waived       bool
+	Reason       string
+	GrantedBy    string // subject of the manager who granted it
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Limit is the quota a user is held to for bookings of a resource type, or of
+// every type when ResourceType is empty
+type Limit struct {
+	ResourceType string
+	MaxActive    int
+	MaxWeekly    time.Duration
+	Waived       bool
+}
+
+// Booking is an active reservation counted against a quota
+type Booking struct {
+	ID           uint
+	ResourceType string
+	Start        time.Time
+	End          time.Time
+}
+
+// Allowance reports how much of a limit a user has used
+type Allowance struct {
+	Limit
+	Active    int
+	Weekly    time.Duration // booked time in the current week
+	WeekStart time.Time
+	WeekEnd   time.Time
+}
+
+// Covers reports whether bookings of the resource type count against the limit
+func (l Limit) Covers(resourceType string) bool {
+	return l.ResourceType == "" || l.ResourceType == resourceType
+}
+
+// IsUnlimited reports whether the limit restricts nothing
+func (l Limit) IsUnlimited() bool {
+	return l.Waived || (l.MaxActive == 0 && l.MaxWeekly == 0)
+}
+
+// RemainingActive returns how many more active reservations are allowed, or -1 when unlimited
+func (a Allowance) RemainingActive() int {
+	if a.Waived || a.MaxActive == 0 {
+		return -1
+	}
+	return max(a.MaxActive-a.Active, 0)
+}
+
+// RemainingWeekly returns how much more time may be booked this week, or -1 when unlimited
+func (a Allowance) RemainingWeekly() time.Duration {
+	if a.Waived || a.MaxWeekly == 0 {
+		return -1
+	}
+	return max(a.MaxWeekly-a.Weekly, 0)
+}
+
+// LimitsFor returns the limits a user is held to. For every resource type the
+// most generous rule among the user's groups applies, or the rule without a
+// group when none of them has one. Overrides of the user replace the rule of
+// their resource type.
+func (s Settings) LimitsFor(user *auth.User, overrides []Override) []Limit {
+	// Resource types in the order they are first configured
+	var types []string
+	seen := make(map[string]bool)
+	grouped := make(map[string][]Rule)
+	fallback := make(map[string]Rule)
+	for _, rule := range s.Rules {
+		if !seen[rule.ResourceType] {
+			seen[rule.ResourceType] = true
+			types = append(types, rule.ResourceType)
+		}
+		if rule.Group == "" {
+			fallback[rule.ResourceType] = rule
+		} else if user.HasGroup(rule.Group) {
+			grouped[rule.ResourceType] = append(grouped[rule.ResourceType], rule)
+		}
+	}
+
+	limits := make(map[string]Limit)
+	for _, resourceType := range types {
+		rules := grouped[resourceType]
+		if len(rules) == 0 {
+			rule, ok := fallback[resourceType]
+			if !ok {
+				continue
+			}
+			rules = []Rule{rule}
+		}
+		limits[resourceType] = mostGenerous(resourceType, rules)
+	}
+
+	for _, o := range overrides {
+		if !seen[o.ResourceType] {
+			seen[o.ResourceType] = true
+			types = append(types, o.ResourceType)
+		}
+		limits[o.ResourceType] = Limit{ResourceType: o.ResourceType, MaxActive: o.MaxActive, MaxWeekly: o.MaxWeekly, Waived: o.Waived}
+	}
+
+	var result []Limit
+	for _, resourceType := range types {
+		if limit, ok := limits[resourceType]; ok {
+			result = append(result, limit)
+		}
+	}
+	return result
+}
+
+// WeekOf returns the start and end of the week containing t
+func (s Settings) WeekOf(t time.Time) (time.Time, time.Time) {
+	location := s.Location
+	if location == nil {
+		location = time.UTC
+	}
+	local := t.In(location)
+	daysSinceMonday := (int(local.Weekday()) + 6) % 7
+	start := time.Date(local.Year(), local.Month(), local.Day()-daysSinceMonday, 0, 0, 0, 0, location)
+	return start, start.AddDate(0, 0, 7)
+}
+
+// mostGenerous combines the rules of a resource type, keeping the highest of
+// each limit, where 0 counts as unlimited
+func mostGenerous(resourceType string, rules []Rule) Limit {
+	limit := Limit{ResourceType: resourceType, MaxActive: rules[0].MaxActive, MaxWeekly: rules[0].MaxWeekly}
+	for _, rule := range rules[1:] {
+		if limit.MaxActive != 0 && (rule.MaxActive == 0 || rule.MaxActive > limit.MaxActive) {
+			limit.MaxActive = rule.MaxActive
+		}
+		if limit.MaxWeekly != 0 && (rule.MaxWeekly == 0 || rule.MaxWeekly > limit.MaxWeekly) {
+			limit.MaxWeekly = rule.MaxWeekly
+		}
+	}
+	return limit
+}
+
+// Validate checks that the override is well formed
+func (o *Override) Validate() error {
+	if strings.TrimSpace(o.UserID) == "" {
+		return fmt.Errorf("%w: user ID cannot be empty", common.ErrInvalidInput)
+	}
+	if o.MaxActive < 0 {
+		return fmt.Errorf("%w: %s cannot be negative", common.ErrInvalidInput, RuleMaxActive)
+	}
+	if o.MaxWeekly < 0 {
+		return fmt.Errorf("%w: %s cannot be negative", common.ErrInvalidInput, RuleMaxWeekly)
+	}
+	if o.MaxWeekly%time.Minute != 0 {
+		return fmt.Errorf("%w: %s must be a whole number of minutes", common.ErrInvalidInput, RuleMaxWeekly)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitsFor(t *testing.T) {
+	settings := Settings{Rules: []Rule{
+		{MaxActive: 3, MaxWeekly: 10 * time.Hour},
+		{ResourceType: "laboratory", MaxActive: 1},
+		{Group: "teacher", MaxActive: 20, MaxWeekly: 40 * time.Hour},
+		{Group: "tutor", MaxActive: 5, MaxWeekly: 5 * time.Hour},
+		{Group: "manager"},
+	}}
+
+	t.Run("Users without a group rule get the fallback", func(t *testing.T) {
+		limits := settings.LimitsFor(&auth.User{ID: "student"}, nil)
+		assert.Equal(t, []Limit{
+			{MaxActive: 3, MaxWeekly: 10 * time.Hour},
+			{ResourceType: "laboratory", MaxActive: 1},
+		}, limits)
+	})
+
+	t.Run("The most generous group rule applies", func(t *testing.T) {
+		limits := settings.LimitsFor(&auth.User{ID: "t", Groups: []string{"tutor", "teacher"}}, nil)
+		assert.Equal(t, Limit{MaxActive: 20, MaxWeekly: 40 * time.Hour}, limits[0])
+	})
+
+	t.Run("A rule without limits is unlimited", func(t *testing.T) {
+		limits := settings.LimitsFor(&auth.User{ID: "m", Groups: []string{"manager", "teacher"}}, nil)
+		assert.True(t, limits[0].IsUnlimited())
+	})
+
+	t.Run("Overrides replace the rule of their type", func(t *testing.T) {
+		overrides := []Override{
+			{UserID: "student", ResourceType: "laboratory", Waived: true},
+			{UserID: "student", ResourceType: "projector", MaxActive: 2},
+		}
+		limits := settings.LimitsFor(&auth.User{ID: "student"}, overrides)
+		require.Len(t, limits, 3)
+		assert.Equal(t, 3, limits[0].MaxActive)
+		assert.True(t, limits[1].IsUnlimited())
+		assert.Equal(t, Limit{ResourceType: "projector", MaxActive: 2}, limits[2])
+	})
+}
+
+func TestCheck(t *testing.T) {
+	settings := Settings{Location: time.UTC}
+	limits := []Limit{
+		{MaxActive: 2, MaxWeekly: 4 * time.Hour},
+		{ResourceType: "laboratory", MaxActive: 1},
+	}
+	// Monday 2030-01-07
+	now := time.Date(2030, time.January, 7, 8, 0, 0, 0, time.UTC)
+	at := func(day, hour int, length time.Duration, resourceType string) Booking {
+		start := now.Add(time.Duration(day*24+hour-8) * time.Hour)
+		return Booking{ResourceType: resourceType, Start: start, End: start.Add(length)}
+	}
+
+	tests := []struct {
+		name     string
+		existing []Booking
+		added    []Booking
+		rule     string
+	}{
+		{"Within quota", []Booking{at(0, 10, time.Hour, "room")}, []Booking{at(1, 10, time.Hour, "room")}, ""},
+		{"Too many active", []Booking{at(0, 10, time.Hour, "room"), at(1, 10, time.Hour, "room")}, []Booking{at(2, 10, time.Hour, "room")}, RuleMaxActive},
+		{"Past bookings are not active", []Booking{at(0, 5, time.Hour, "room"), at(1, 10, time.Hour, "room")}, []Booking{at(2, 10, time.Hour, "room")}, ""},
+		{"Too many of a type", []Booking{at(0, 10, time.Hour, "laboratory")}, []Booking{at(1, 10, time.Hour, "laboratory")}, RuleMaxActive},
+		{"Too much in a week", []Booking{at(0, 10, 3*time.Hour, "room")}, []Booking{at(1, 10, 2*time.Hour, "room")}, RuleMaxWeekly},
+		{"Next week starts afresh", []Booking{at(0, 10, 3*time.Hour, "room")}, []Booking{at(7, 10, 2*time.Hour, "room")}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := settings.Check(limits, tt.existing, tt.added, now)
+			if tt.rule == "" {
+				assert.NoError(t, err)
+				return
+			}
+			ruleErr, ok := common.AsRuleError(err)
+			require.True(t, ok, "expected a rule error, got %v", err)
+			assert.Equal(t, tt.rule, ruleErr.Rule)
+			assert.ErrorIs(t, err, common.ErrForbidden)
+		})
+	}
+
+	t.Run("Waived limits are not enforced", func(t *testing.T) {
+		waived := []Limit{{MaxActive: 1, Waived: true}}
+		err := settings.Check(waived, []Booking{at(0, 10, time.Hour, "room")}, []Booking{at(1, 10, time.Hour, "room")}, now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Allowances report what is left", func(t *testing.T) {
+		bookings := []Booking{at(0, 5, time.Hour, "room"), at(0, 10, time.Hour, "laboratory"), at(8, 10, time.Hour, "room")}
+		allowances := settings.Allowances(limits, bookings, now)
+		require.Len(t, allowances, 2)
+		assert.Equal(t, 2, allowances[0].Active)
+		assert.Equal(t, 0, allowances[0].RemainingActive())
+		assert.Equal(t, 2*time.Hour, allowances[0].Weekly)
+		assert.Equal(t, 2*time.Hour, allowances[0].RemainingWeekly())
+		assert.Equal(t, 0, allowances[1].RemainingActive())
+		assert.Equal(t, time.Duration(-1), allowances[1].RemainingWeekly())
+	})
+}
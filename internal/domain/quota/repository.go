@@ -0,0 +1,16 @@
+package quota
+
+// Repository defines the data access operations for quota overrides
+// All methods are explicitly named with the QuotaOverride entity
+type Repository interface {
+	// ReadQuotaOverrideList retrieves the overrides of a user, or of every
+	// user when userID is empty
+	ReadQuotaOverrideList(userID string) ([]Override, error)
+	ReadQuotaOverride(id uint) (*Override, error)
+	// FindQuotaOverride retrieves the override of a user for a resource type,
+	// or nil if there is none
+	FindQuotaOverride(userID, resourceType string) (*Override, error)
+	CreateQuotaOverride(override *Override) error
+	UpdateQuotaOverride(override *Override) error
+	DeleteQuotaOverride(id uint) error
+}
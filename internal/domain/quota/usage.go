@@ -0,0 +1,96 @@
+package quota
+
+import (
+	"sarc-ng/internal/domain/common"
+	"time"
+)
+
+// Allowances reports how much of each limit the bookings use at now. The
+// bookings must be active and include every one ending after the week started.
+func (s Settings) Allowances(limits []Limit, bookings []Booking, now time.Time) []Allowance {
+	weekStart, weekEnd := s.WeekOf(now)
+
+	allowances := make([]Allowance, len(limits))
+	for i, limit := range limits {
+		allowance := Allowance{Limit: limit, WeekStart: weekStart, WeekEnd: weekEnd}
+		for _, b := range bookings {
+			if !limit.Covers(b.ResourceType) {
+				continue
+			}
+			if b.End.After(now) {
+				allowance.Active++
+			}
+			if !b.Start.Before(weekStart) && b.Start.Before(weekEnd) {
+				allowance.Weekly += b.End.Sub(b.Start)
+			}
+		}
+		allowances[i] = allowance
+	}
+	return allowances
+}
+
+// Check verifies that the added bookings keep a user within the limits next to
+// the existing ones. The existing bookings must be active and include every
+// one ending after the week of the earliest added booking started. It returns
+// a *common.RuleError naming the quota exceeded, or nil.
+func (s Settings) Check(limits []Limit, existing, added []Booking, now time.Time) error {
+	for _, limit := range limits {
+		if limit.IsUnlimited() {
+			continue
+		}
+
+		var counted []Booking
+		for _, b := range added {
+			if limit.Covers(b.ResourceType) {
+				counted = append(counted, b)
+			}
+		}
+		if len(counted) == 0 {
+			continue
+		}
+		for _, b := range existing {
+			if limit.Covers(b.ResourceType) {
+				counted = append(counted, b)
+			}
+		}
+
+		if limit.MaxActive > 0 {
+			active := 0
+			for _, b := range counted {
+				if b.End.After(now) {
+					active++
+				}
+			}
+			if active > limit.MaxActive {
+				return common.NewRuleError(common.ErrForbidden, RuleMaxActive,
+					"quota allows at most %d active %sreservations", limit.MaxActive, scope(limit))
+			}
+		}
+
+		if limit.MaxWeekly > 0 {
+			weekly := make(map[time.Time]time.Duration)
+			for _, b := range counted {
+				weekStart, _ := s.WeekOf(b.Start)
+				weekly[weekStart] += b.End.Sub(b.Start)
+			}
+			// Only the weeks of the added bookings can have gone over
+			for _, b := range added {
+				weekStart, _ := s.WeekOf(b.Start)
+				if limit.Covers(b.ResourceType) && weekly[weekStart] > limit.MaxWeekly {
+					return common.NewRuleError(common.ErrForbidden, RuleMaxWeekly,
+						"quota allows at most %s of %sreservations per week, the week of %s would have %s",
+						limit.MaxWeekly, scope(limit), weekStart.Format(time.DateOnly), weekly[weekStart])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// scope names the resource type a limit counts, followed by a space
+func scope(limit Limit) string {
+	if limit.ResourceType == "" {
+		return ""
+	}
+	return limit.ResourceType + " "
+}
@@ -0,0 +1,17 @@
+package quota
+
+import "sarc-ng/internal/domain/auth"
+
+// Usecase defines the business logic operations for booking quotas. Quotas
+// are enforced when reservations are created or moved; overrides are granted
+// by managers.
+type Usecase interface {
+	// GetQuota reports the remaining allowance of the user
+	GetQuota(user *auth.User) ([]Allowance, error)
+
+	GetAllQuotaOverrides(userID string) ([]Override, error)
+	GetQuotaOverride(id uint) (*Override, error)
+	CreateQuotaOverride(manager *auth.User, override *Override) error
+	UpdateQuotaOverride(manager *auth.User, override *Override) error
+	DeleteQuotaOverride(id uint) error
+}
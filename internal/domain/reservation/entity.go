@@ -6,15 +6,19 @@ import (
 
 // Reservation represents a booking in the system
 type Reservation struct {
-	ID          uint
-	ResourceID  uint
-	UserID      uint
-	StartTime   time.Time
-	EndTime     time.Time
-	Purpose     string
-	Status      string
-	Description string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   *time.Time
+	ID           uint
+	ResourceID   uint
+	UserID       string // subject of the user who made the booking
+	StartTime    time.Time
+	EndTime      time.Time
+	Purpose      string
+	Status       Status
+	StatusReason string
+	Description  string
+	SeriesID     *uint      // set when the reservation is an occurrence of a Series
+	CheckedInAt  *time.Time // when someone checked in at the resource
+	RemindedAt   *time.Time // when the owner was reminded of the reservation
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time
 }
@@ -0,0 +1,7 @@
+package reservation
+
+// Notifier tells users about their reservations
+type Notifier interface {
+	// NotifyReservationReminder reminds the owner of an upcoming reservation
+	NotifyReservationReminder(r Reservation) error
+}
@@ -1,11 +1,45 @@
 package reservation
 
+import (
+	"sarc-ng/internal/domain/common"
+	"time"
+)
+
 // Repository defines the data access operations for reservations
 // All methods are explicitly named with the Reservation entity
 type Repository interface {
-	ReadReservationList() ([]Reservation, error)
+	ReadReservationList(query common.Query) (*common.Page[Reservation], error)
 	ReadReservation(id uint) (*Reservation, error)
 	CreateReservation(reservation *Reservation) error
 	UpdateReservation(reservation *Reservation) error
 	DeleteReservation(id uint) error
+
+	// FindOverlappingReservations returns the active reservations of a resource
+	// whose time range overlaps [start, end). A non-zero excludeID is left out
+	// of the result so a reservation does not conflict with itself on update.
+	FindOverlappingReservations(resourceID uint, start, end time.Time, excludeID uint) ([]Reservation, error)
+
+	// LockReservationResource locks the resource being booked until the
+	// enclosing transaction ends, so concurrent bookings of the same resource
+	// are checked and written one at a time. It returns common.ErrNotFound
+	// when the resource does not exist.
+	LockReservationResource(resourceID uint) error
+
+	ReadReservationListBySeries(seriesID uint) ([]Reservation, error)
+
+	// ReadReservationListByResource retrieves the reservations of a resource ending after from
+	// and, unless to is zero, starting before to, including cancelled and rejected ones
+	ReadReservationListByResource(resourceID uint, from, to time.Time) ([]Reservation, error)
+	// ReadReservationListByUser retrieves the reservations of a user ending after from,
+	// including cancelled and rejected ones
+	ReadReservationListByUser(userID string, from time.Time) ([]Reservation, error)
+	// FindReservationsMissingCheckIn returns the approved reservations nobody
+	// checked in to that started at or before cutoff and end after it
+	FindReservationsMissingCheckIn(cutoff time.Time) ([]Reservation, error)
+	// FindReservationsToRemind returns the approved reservations starting in
+	// (from, until] whose owners have not been reminded
+	FindReservationsToRemind(from, until time.Time) ([]Reservation, error)
+	ReadReservationSeries(id uint) (*Series, error)
+	CreateReservationSeries(series *Series) error
+	UpdateReservationSeries(series *Series) error
 }
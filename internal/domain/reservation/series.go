@@ -0,0 +1,54 @@
+package reservation
+
+import "time"
+
+// Series represents a recurring reservation described by an RFC 5545 rule.
+// Each occurrence is stored as a Reservation linked through SeriesID.
+type Series struct {
+	ID             uint
+	ResourceID     uint
+	UserID         string
+	Purpose        string
+	Description    string
+	StartTime      time.Time // start of the first occurrence (DTSTART)
+	EndTime        time.Time // end of the first occurrence
+	TimeZone       string    // IANA zone that keeps occurrences on the same wall-clock time
+	RecurrenceRule string    // RRULE value, e.g. FREQ=WEEKLY;BYDAY=TU;UNTIL=20270101T000000Z
+	ExceptionDates []time.Time
+	Occurrences    []Reservation // populated by the service, not persisted with the series
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+// Duration returns the length of each occurrence
+func (s *Series) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// EditScope selects which occurrences of a series an edit applies to
+type EditScope string
+
+const (
+	// EditThis changes only the selected occurrence
+	EditThis EditScope = "this"
+	// EditFollowing splits the series and changes the selected and later occurrences
+	EditFollowing EditScope = "following"
+	// EditAll changes every upcoming occurrence of the series
+	EditAll EditScope = "all"
+)
+
+// IsValid checks if the scope is one of the known edit scopes
+func (s EditScope) IsValid() bool {
+	return s == EditThis || s == EditFollowing || s == EditAll
+}
+
+// OccurrenceUpdate describes an edit to a series occurrence
+type OccurrenceUpdate struct {
+	StartTime   time.Time
+	EndTime     time.Time
+	Purpose     string
+	Description string
+	// RecurrenceRule optionally replaces the rule for the following or all scopes
+	RecurrenceRule string
+}
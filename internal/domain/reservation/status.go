@@ -0,0 +1,64 @@
+package reservation
+
+// Status represents the lifecycle state of a reservation
+type Status string
+
+const (
+	// StatusPending is the initial state of a new reservation awaiting review
+	StatusPending Status = "pending"
+	// StatusApproved marks a reservation confirmed by a manager
+	StatusApproved Status = "approved"
+	// StatusRejected marks a reservation declined by a manager
+	StatusRejected Status = "rejected"
+	// StatusCancelled marks a reservation withdrawn before it took place
+	StatusCancelled Status = "cancelled"
+	// StatusCompleted marks an approved reservation whose time has passed
+	StatusCompleted Status = "completed"
+	// StatusExpired marks a pending reservation nobody reviewed before it started
+	StatusExpired Status = "expired"
+	// StatusNoShow marks an approved reservation released because nobody checked in
+	StatusNoShow Status = "no_show"
+)
+
+// InactiveStatuses lists the statuses whose reservations no longer hold their time slot
+var InactiveStatuses = []Status{StatusRejected, StatusCancelled, StatusExpired, StatusNoShow}
+
+// transitions defines the legal status changes; statuses without an entry are final
+var transitions = map[Status][]Status{
+	StatusPending:  {StatusApproved, StatusRejected, StatusCancelled, StatusExpired},
+	StatusApproved: {StatusCancelled, StatusCompleted, StatusNoShow},
+}
+
+// IsValid checks if the status is one of the known reservation statuses
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusApproved, StatusRejected, StatusCancelled, StatusCompleted, StatusExpired, StatusNoShow:
+		return true
+	}
+	return false
+}
+
+// IsActive checks if a reservation in this status still holds its time slot
+func (s Status) IsActive() bool {
+	for _, inactive := range InactiveStatuses {
+		if s == inactive {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFinal checks if no further transitions are allowed from this status
+func (s Status) IsFinal() bool {
+	return len(transitions[s]) == 0
+}
+
+// CanTransitionTo checks if moving from this status to next is allowed
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
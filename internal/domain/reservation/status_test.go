@@ -0,0 +1,49 @@
+package reservation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from Status
+		to   Status
+		want bool
+	}{
+		{StatusPending, StatusApproved, true},
+		{StatusPending, StatusRejected, true},
+		{StatusPending, StatusCancelled, true},
+		{StatusPending, StatusCompleted, false},
+		{StatusApproved, StatusCancelled, true},
+		{StatusApproved, StatusCompleted, true},
+		{StatusApproved, StatusNoShow, true},
+		{StatusApproved, StatusRejected, false},
+		{StatusPending, StatusNoShow, false},
+		{StatusPending, StatusExpired, true},
+		{StatusApproved, StatusExpired, false},
+		{StatusNoShow, StatusApproved, false},
+		{StatusApproved, StatusPending, false},
+		{StatusRejected, StatusApproved, false},
+		{StatusCancelled, StatusPending, false},
+		{StatusCompleted, StatusCancelled, false},
+		{Status("active"), StatusApproved, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestStatusIsActive(t *testing.T) {
+	assert.True(t, StatusPending.IsActive())
+	assert.True(t, StatusApproved.IsActive())
+	assert.True(t, StatusCompleted.IsActive())
+	assert.False(t, StatusRejected.IsActive())
+	assert.False(t, StatusCancelled.IsActive())
+	assert.False(t, StatusExpired.IsActive())
+	assert.False(t, StatusNoShow.IsActive())
+}
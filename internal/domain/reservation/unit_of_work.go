@@ -0,0 +1,14 @@
+package reservation
+
+import (
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/event"
+)
+
+// UnitOfWork runs reservation operations atomically together with the events
+// and audit entries they record
+type UnitOfWork interface {
+	// Do executes fn with a Repository, an event.Outbox and an audit.Trail bound to a single transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(repo Repository, events event.Outbox, trail audit.Trail) error) error
+}
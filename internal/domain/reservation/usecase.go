@@ -1,14 +1,52 @@
 package reservation
 
-import "time"
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"time"
+)
 
-// Usecase defines the business logic operations for reservation management
+// Usecase defines the business logic operations for reservation management.
+// New bookings are owned by the actor making them. Changes to an existing
+// booking are made on behalf of an actor, who must own the booking or be a
+// manager, and only they and reviewers of reservations may see it. Bookings must follow the policy of their resource's type and keep
+// their owner within quota, unless a manager makes them for someone else.
+// Approved bookings must be checked in to, by their owner or with the QR code
+// token of their resource, or they are released as no-shows. Time freed by
+// cancelling, rejecting or releasing a booking is offered to the waitlist.
+// Every change is recorded in the audit log with its actor; changes made by
+// background jobs are recorded without one.
 type Usecase interface {
-	GetAllReservations() ([]Reservation, error)
-	GetReservation(id uint) (*Reservation, error)
-	CreateReservation(reservation *Reservation) error
-	UpdateReservation(reservation *Reservation) error
-	DeleteReservation(id uint) error
-	CancelReservation(id uint) error
+	GetAllReservations(query common.Query) (*common.Page[Reservation], error)
+	GetReservation(actor *auth.User, id uint) (*Reservation, error)
+	CreateReservation(actor *auth.User, reservation *Reservation) error
+	UpdateReservation(actor *auth.User, reservation *Reservation) error
+	DeleteReservation(actor *auth.User, id uint) error
+	CancelReservation(actor *auth.User, id uint) error
+	ApproveReservation(actor *auth.User, id uint) error
+	RejectReservation(actor *auth.User, id uint, reason string) error
 	CheckReservationAvailability(resourceID uint, start, end time.Time) (bool, error)
+
+	// CheckInReservation records that someone showed up for an approved
+	// reservation. Without a check-in token of the reservation's resource the
+	// actor must own the reservation or be a manager.
+	CheckInReservation(actor *auth.User, id uint, token string) (*Reservation, error)
+	// ReleaseNoShows marks the approved reservations nobody checked in to within
+	// the grace period as no-shows and returns how many there were
+	ReleaseNoShows() (int, error)
+
+	// ExpirePendingReservations marks the pending reservations that started
+	// without being reviewed as expired and returns how many there were
+	ExpirePendingReservations() (int, error)
+	// CompleteReservations marks the approved reservations that are over as
+	// completed and returns how many there were
+	CompleteReservations() (int, error)
+	// SendReservationReminders reminds the owners of approved reservations
+	// starting within the lead time, once each, and returns how many were sent
+	SendReservationReminders(lead time.Duration) (int, error)
+
+	CreateReservationSeries(actor *auth.User, series *Series) error
+	GetReservationSeries(actor *auth.User, id uint) (*Series, error)
+	UpdateReservationOccurrence(actor *auth.User, id uint, scope EditScope, update OccurrenceUpdate) (*Series, error)
+	CancelReservationSeries(actor *auth.User, id uint) error
 }
@@ -11,8 +11,21 @@ type Resource struct {
 	Type        string
 	Description string
 	IsAvailable bool
-	Location    string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   *time.Time
+	Location    string // free-form detail such as a floor or cabinet
+	// BuildingID is the building the resource is kept in; set from the class when ClassID is
+	BuildingID *uint
+	// ClassID is the class the resource is kept in, if any
+	ClassID   *uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// Building returns the ID of the building the resource is kept in, or 0 if it
+// is not kept in one
+func (r *Resource) Building() uint {
+	if r.BuildingID == nil {
+		return 0
+	}
+	return *r.BuildingID
 }
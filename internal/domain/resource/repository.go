@@ -1,11 +1,27 @@
 package resource
 
+import (
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+)
+
 // Repository defines the data access operations for resources
 // All methods are explicitly named with the Resource entity
 type Repository interface {
-	ReadResourceList() ([]Resource, error)
+	ReadResourceList(query common.Query) (*common.Page[Resource], error)
 	ReadResource(id uint) (*Resource, error)
+	ReadResourceListByBuilding(buildingID uint) ([]Resource, error)
+	ReadResourceListByClass(classID uint) ([]Resource, error)
+	UpdateResourceBuildingByClass(classID uint, buildingID uint) error
 	CreateResource(resource *Resource) error
 	UpdateResource(resource *Resource) error
 	DeleteResource(id uint) error
 }
+
+// UnitOfWork runs resource changes atomically together with the events and audit entries they record
+type UnitOfWork interface {
+	// Do executes fn with a Repository, an event.Outbox and an audit.Trail bound to a single transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(repo Repository, events event.Outbox, trail audit.Trail) error) error
+}
@@ -1,11 +1,19 @@
 package resource
 
-// Usecase defines the business logic operations for resource management
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for resource management.
+// Changes are made on behalf of an actor, who is recorded in the audit log.
 type Usecase interface {
-	GetAllResources() ([]Resource, error)
+	GetAllResources(query common.Query) (*common.Page[Resource], error)
 	GetResource(id uint) (*Resource, error)
-	CreateResource(resource *Resource) error
-	UpdateResource(resource *Resource) error
-	DeleteResource(id uint) error
-	SetResourceAvailability(id uint, available bool) error
+	GetResourcesByBuilding(buildingID uint) ([]Resource, error)
+	GetResourcesByClass(classID uint) ([]Resource, error)
+	CreateResource(actor *auth.User, resource *Resource) error
+	UpdateResource(actor *auth.User, resource *Resource) error
+	DeleteResource(actor *auth.User, id uint) error
+	SetResourceAvailability(actor *auth.User, id uint, available bool) error
 }
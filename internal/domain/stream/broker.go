@@ -0,0 +1,14 @@
+package stream
+
+import "context"
+
+// Broker fans updates out to the subscribers of every application instance.
+// Updates are published by the instance that dispatches the domain event.
+type Broker interface {
+	// Publish hands the update to every subscriber
+	Publish(ctx context.Context, update Update) error
+	// Subscribe returns a channel receiving the updates published from now on.
+	// The channel is closed once ctx is done. Subscribers that fall behind
+	// miss updates rather than holding up the others.
+	Subscribe(ctx context.Context) (<-chan Update, error)
+}
@@ -0,0 +1,49 @@
+package stream
+
+import (
+	"encoding/json"
+	"sarc-ng/internal/domain/event"
+	"slices"
+	"time"
+)
+
+// Update is a change to a reservation or resource pushed to live clients
+type Update struct {
+	// ID is the ID of the domain event the update comes from
+	ID            uint
+	Type          event.Type
+	AggregateType string // "reservation" or "resource"
+	AggregateID   uint
+	ResourceID    uint
+	BuildingID    *uint  // building the resource is kept in, if any
+	UserID        string // owner of the reservation; empty for resources
+	Data          json.RawMessage
+	OccurredAt    time.Time
+}
+
+// Filter narrows the updates a client receives. Updates match when they
+// match every field that is set; an empty filter matches every update.
+type Filter struct {
+	BuildingIDs []uint
+	ResourceIDs []uint
+	UserID      string
+	Types       []event.Type
+}
+
+// Matches reports whether the update passes the filter. Updates of resources
+// never match a user filter.
+func (f Filter) Matches(u Update) bool {
+	if len(f.BuildingIDs) > 0 && (u.BuildingID == nil || !slices.Contains(f.BuildingIDs, *u.BuildingID)) {
+		return false
+	}
+	if len(f.ResourceIDs) > 0 && !slices.Contains(f.ResourceIDs, u.ResourceID) {
+		return false
+	}
+	if f.UserID != "" && f.UserID != u.UserID {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, u.Type) {
+		return false
+	}
+	return true
+}
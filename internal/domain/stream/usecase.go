@@ -0,0 +1,14 @@
+package stream
+
+import (
+	"context"
+	"sarc-ng/internal/domain/auth"
+)
+
+// Usecase defines the business logic operations for live updates
+type Usecase interface {
+	// Subscribe returns a channel receiving the updates matching the filter
+	// until ctx is done, when the channel is closed. Users who are not managers
+	// only receive updates of their own reservations.
+	Subscribe(ctx context.Context, user *auth.User, filter Filter) (<-chan Update, error)
+}
@@ -0,0 +1,67 @@
+package waitlist
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"time"
+)
+
+// Status represents the lifecycle state of a waitlist entry
+type Status string
+
+const (
+	// StatusWaiting marks an entry waiting for its time window to free up
+	StatusWaiting Status = "waiting"
+	// StatusPromoted marks an entry turned into a pending reservation
+	StatusPromoted Status = "promoted"
+	// StatusExpired marks an entry that was not promoted before it expired
+	StatusExpired Status = "expired"
+	// StatusCancelled marks an entry withdrawn by its owner or a manager
+	StatusCancelled Status = "cancelled"
+)
+
+// Entry is a request to book a resource for a time window that is taken. When
+// a conflicting reservation is cancelled or rejected, the oldest waiting entry
+// that fits is promoted to a pending reservation, if its owner may still book it.
+type Entry struct {
+	ID            uint
+	ResourceID    uint
+	UserID        string   // subject of the user waiting
+	UserGroups    []string // groups of the user when they joined, held to the policy and quota on promotion
+	StartTime     time.Time
+	EndTime       time.Time
+	Purpose       string
+	Description   string
+	Status        Status
+	ExpiresAt     time.Time // the entry is no longer promoted after this time
+	ReservationID *uint     // set once the entry is promoted
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// IsValid checks if the status is one of the known waitlist statuses
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusWaiting, StatusPromoted, StatusExpired, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsExpired reports whether a waiting entry can no longer be promoted at now
+func (e *Entry) IsExpired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// Owner returns the user waiting, with the groups they had when they joined
+func (e *Entry) Owner() *auth.User {
+	return &auth.User{ID: e.UserID, Groups: e.UserGroups}
+}
+
+// StatusAt returns the status of the entry at now, reporting waiting entries
+// past their expiry as expired before they are marked so
+func (e *Entry) StatusAt(now time.Time) Status {
+	if e.Status == StatusWaiting && e.IsExpired(now) {
+		return StatusExpired
+	}
+	return e.Status
+}
@@ -0,0 +1,41 @@
+package waitlist
+
+import (
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/reservation"
+	"time"
+)
+
+// Repository defines the data access operations for waitlist entries
+// All methods are explicitly named with the WaitlistEntry entity
+type Repository interface {
+	ReadWaitlistEntryList(query common.Query) (*common.Page[Entry], error)
+	ReadWaitlistEntry(id uint) (*Entry, error)
+	CreateWaitlistEntry(entry *Entry) error
+	UpdateWaitlistEntry(entry *Entry) error
+
+	// FindWaitingWaitlistEntries returns the waiting entries of a resource whose
+	// time window overlaps [start, end), oldest first
+	FindWaitingWaitlistEntries(resourceID uint, start, end time.Time) ([]Entry, error)
+	// FindWaitingWaitlistEntry returns a waiting entry of the user for the
+	// resource overlapping [start, end), or nil if there is none
+	FindWaitingWaitlistEntry(userID string, resourceID uint, start, end time.Time) (*Entry, error)
+	// ExpireWaitlistEntries marks the waiting entries expired at now and returns how many there were
+	ExpireWaitlistEntries(now time.Time) (int, error)
+}
+
+// UnitOfWork runs waitlist operations atomically together with the
+// reservations they create and the events and audit entries they record
+type UnitOfWork interface {
+	// Do executes fn with repositories, an event.Outbox and an audit.Trail bound to a single transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(entries Repository, reservations reservation.Repository, events event.Outbox, trail audit.Trail) error) error
+}
+
+// Notifier tells users about changes to their waitlist entries
+type Notifier interface {
+	// NotifyWaitlistPromoted tells the owner of the entry that it became the reservation
+	NotifyWaitlistPromoted(entry Entry, r reservation.Reservation) error
+}
@@ -0,0 +1,20 @@
+package waitlist
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for the waitlist. Entries are
+// promoted by the reservation use cases that free a time window.
+type Usecase interface {
+	GetAllWaitlistEntries(query common.Query) (*common.Page[Entry], error)
+	// GetWaitlistEntry retrieves an entry of the actor, or of anyone for managers
+	GetWaitlistEntry(actor *auth.User, id uint) (*Entry, error)
+	// JoinWaitlist queues the actor for a time window of a resource that is taken
+	JoinWaitlist(actor *auth.User, entry *Entry) error
+	// LeaveWaitlist cancels a waiting entry of the actor, or of anyone for managers
+	LeaveWaitlist(actor *auth.User, id uint) error
+	// ExpireWaitlistEntries marks the entries past their expiry and returns how many there were
+	ExpireWaitlistEntries() (int, error)
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxResponseBody bounds how much of a receiver's response is kept in the delivery log
+const MaxResponseBody = 1024
+
+// Subscription asks for domain events to be posted to a URL. Requests are
+// signed with the subscription's secret.
+type Subscription struct {
+	ID          uint
+	URL         string
+	Description string
+	EventTypes  []event.Type // empty means every event
+	Secret      string
+	Active      bool
+	CreatedBy   string // subject of the admin who created it
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Wants reports whether the subscription receives events of the given type
+func (s *Subscription) Wants(t event.Type) bool {
+	return s.Active && (len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, t))
+}
+
+// Validate checks that the subscription is well formed
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(strings.TrimSpace(s.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: URL must be an absolute http or https URL", common.ErrInvalidInput)
+	}
+	for _, t := range s.EventTypes {
+		if !slices.Contains(event.Types, t) {
+			return fmt.Errorf("%w: unknown event type '%s'", common.ErrInvalidInput, t)
+		}
+	}
+	return nil
+}
+
+// DeliveryStatus represents the outcome of a delivery attempt
+type DeliveryStatus string
+
+const (
+	// DeliverySucceeded marks an attempt the receiver answered with a 2xx status
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed marks an attempt that could not be sent or was refused
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// IsValid reports whether the status is known
+func (s DeliveryStatus) IsValid() bool {
+	return s == DeliverySucceeded || s == DeliveryFailed
+}
+
+// Delivery records one attempt to post an event to a subscription
+type Delivery struct {
+	ID             uint
+	SubscriptionID uint
+	EventID        uint // ID of the outbox message
+	EventType      event.Type
+	Attempt        int // 1 for the first attempt of the event at this subscription
+	Status         DeliveryStatus
+	StatusCode     int    // 0 when no response was received
+	ResponseBody   string // truncated to MaxResponseBody bytes
+	Error          string
+	Duration       time.Duration
+	CreatedAt      time.Time
+}
+
+// Request is a signed event ready to be posted
+type Request struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// Response is what a receiver answered
+type Response struct {
+	StatusCode int
+	Body       string // truncated to MaxResponseBody bytes
+}
@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"context"
+	"sarc-ng/internal/domain/common"
+)
+
+// Repository defines the data access operations for webhook subscriptions and
+// their delivery log
+// All methods are explicitly named with the WebhookSubscription or WebhookDelivery entity
+type Repository interface {
+	ReadWebhookSubscriptionList() ([]Subscription, error)
+	// ReadActiveWebhookSubscriptionList retrieves the subscriptions events are posted to
+	ReadActiveWebhookSubscriptionList() ([]Subscription, error)
+	ReadWebhookSubscription(id uint) (*Subscription, error)
+	CreateWebhookSubscription(subscription *Subscription) error
+	UpdateWebhookSubscription(subscription *Subscription) error
+	// DeleteWebhookSubscription removes a subscription together with its delivery log
+	DeleteWebhookSubscription(id uint) error
+
+	ReadWebhookDeliveryList(query common.Query) (*common.Page[Delivery], error)
+	// FindLastWebhookDelivery retrieves the latest attempt to post an event to
+	// a subscription, or nil if there is none
+	FindLastWebhookDelivery(subscriptionID, eventID uint) (*Delivery, error)
+	CreateWebhookDelivery(delivery *Delivery) error
+}
+
+// Sender posts webhook requests
+type Sender interface {
+	// Send posts the request. An error means no response was received.
+	Send(ctx context.Context, request Request) (*Response, error)
+}
@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+)
+
+// Usecase defines the business logic operations for webhook subscriptions.
+// Events are posted to the subscriptions as the outbox dispatches them.
+type Usecase interface {
+	GetAllWebhookSubscriptions() ([]Subscription, error)
+	GetWebhookSubscription(id uint) (*Subscription, error)
+	// CreateWebhookSubscription stores a subscription with a new secret,
+	// recording the admin who created it
+	CreateWebhookSubscription(admin *auth.User, subscription *Subscription) error
+	// UpdateWebhookSubscription changes the URL, filters or state of a
+	// subscription, keeping its secret
+	UpdateWebhookSubscription(subscription *Subscription) error
+	DeleteWebhookSubscription(id uint) error
+	// RotateWebhookSecret replaces the secret of a subscription
+	RotateWebhookSecret(id uint) (*Subscription, error)
+
+	// GetWebhookDeliveries retrieves a page of the delivery log of a subscription
+	GetWebhookDeliveries(subscriptionID uint, query common.Query) (*common.Page[Delivery], error)
+}
@@ -0,0 +1,160 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"slices"
+	"strings"
+	"time"
+)
+
+// touchInterval is how stale the last use of a key may get before it is
+// recorded again, so busy keys do not write on every request
+const touchInterval = time.Minute
+
+// Service implements apikey.Usecase interface
+type Service struct {
+	repo apikey.Repository
+}
+
+// Compile-time verification that Service implements apikey.Usecase
+var _ apikey.Usecase = (*Service)(nil)
+
+// NewService creates a new API key service
+func NewService(repo apikey.Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// GetAllAPIKeys retrieves every key, without the keys themselves
+func (s *Service) GetAllAPIKeys() ([]apikey.APIKey, error) {
+	return s.repo.ReadAPIKeyList()
+}
+
+// GetAPIKey retrieves a key by ID with validation
+func (s *Service) GetAPIKey(id uint) (*apikey.APIKey, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: API key ID cannot be zero", common.ErrInvalidInput)
+	}
+	return s.repo.ReadAPIKey(id)
+}
+
+// CreateAPIKey generates a key and stores its hash, recording the admin who
+// created it
+func (s *Service) CreateAPIKey(admin *auth.User, k *apikey.APIKey) error {
+	if admin == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+
+	normalize(k)
+	if err := k.Validate(); err != nil {
+		return err
+	}
+	if k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("%w: expiry must be in the future", common.ErrInvalidInput)
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return fmt.Errorf("failed to generate API key: %w", err)
+	}
+	k.Key = key
+	k.Prefix = key[:apikey.PrefixLength]
+	k.Hash = hash(key)
+	k.LastUsedAt = nil
+	k.CreatedBy = admin.ID
+	return s.repo.CreateAPIKey(k)
+}
+
+// UpdateAPIKey replaces the name, permissions, buildings and expiry of a key,
+// keeping the key itself
+func (s *Service) UpdateAPIKey(k *apikey.APIKey) error {
+	if k.ID == 0 {
+		return fmt.Errorf("%w: API key ID cannot be zero for update", common.ErrInvalidInput)
+	}
+
+	normalize(k)
+	if err := k.Validate(); err != nil {
+		return err
+	}
+
+	current, err := s.repo.ReadAPIKey(k.ID)
+	if err != nil {
+		return err
+	}
+
+	k.Prefix = current.Prefix
+	k.Hash = current.Hash
+	k.LastUsedAt = current.LastUsedAt
+	k.CreatedBy = current.CreatedBy
+	k.CreatedAt = current.CreatedAt
+	return s.repo.UpdateAPIKey(k)
+}
+
+// DeleteAPIKey revokes a key; requests with it are refused from then on
+func (s *Service) DeleteAPIKey(id uint) error {
+	if _, err := s.GetAPIKey(id); err != nil {
+		return err
+	}
+	return s.repo.DeleteAPIKey(id)
+}
+
+// AuthenticateKey returns the user a key acts as, recording its use
+func (s *Service) AuthenticateKey(ctx context.Context, key string) (*auth.User, error) {
+	if !strings.HasPrefix(key, apikey.KeyPrefix) {
+		return nil, fmt.Errorf("%w: malformed API key", common.ErrUnauthorized)
+	}
+
+	k, err := s.repo.FindAPIKeyByHash(hash(key))
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return nil, fmt.Errorf("%w: unknown API key", common.ErrUnauthorized)
+	}
+	now := time.Now()
+	if k.Expired(now) {
+		return nil, fmt.Errorf("%w: API key %s expired", common.ErrUnauthorized, k.Prefix)
+	}
+
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= touchInterval {
+		// A failure to record the use must not refuse the request
+		if err := s.repo.TouchAPIKey(k.ID, now); err != nil {
+			log.Printf("Failed to record use of API key %d: %v", k.ID, err)
+		}
+	}
+	return k.User(), nil
+}
+
+// normalize trims the name and drops duplicate permissions and buildings
+func normalize(k *apikey.APIKey) {
+	k.Name = strings.TrimSpace(k.Name)
+	slices.Sort(k.Permissions)
+	k.Permissions = slices.Compact(k.Permissions)
+	slices.Sort(k.BuildingIDs)
+	k.BuildingIDs = slices.Compact(k.BuildingIDs)
+}
+
+// newKey generates a random key
+func newKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apikey.KeyPrefix + hex.EncodeToString(secret), nil
+}
+
+// hash returns the digest a key is stored and looked up by. Keys are random,
+// so a fast hash suffices.
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,129 @@
+package apikey
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apikeyAdapter "sarc-ng/internal/adapter/gorm/apikey"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var admin = &auth.User{ID: "admin-1", Groups: []string{"admin"}}
+
+func newService(t *testing.T) (*Service, *apikeyAdapter.GormAdapter) {
+	repo := apikeyAdapter.NewGormAdapter(gormtest.Open(t, &apikeyAdapter.GormModel{}))
+	return NewService(repo), repo
+}
+
+func TestCreateAndAuthenticateAPIKey(t *testing.T) {
+	service, repo := newService(t)
+	ctx := context.Background()
+
+	kiosk := &apikey.APIKey{
+		Name:        " Lobby kiosk ",
+		Permissions: []auth.Permission{auth.PermissionCheckInIssue, auth.PermissionCheckInIssue},
+		BuildingIDs: []uint{3},
+	}
+	require.NoError(t, service.CreateAPIKey(admin, kiosk))
+	assert.True(t, strings.HasPrefix(kiosk.Key, apikey.KeyPrefix))
+	assert.Equal(t, kiosk.Key[:apikey.PrefixLength], kiosk.Prefix)
+	assert.Equal(t, "Lobby kiosk", kiosk.Name)
+	assert.Equal(t, []auth.Permission{auth.PermissionCheckInIssue}, kiosk.Permissions)
+	assert.Equal(t, "admin-1", kiosk.CreatedBy)
+
+	stored, err := service.GetAPIKey(kiosk.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.Key, "keys are not stored")
+	assert.NotContains(t, stored.Hash, kiosk.Key[len(apikey.KeyPrefix):])
+	assert.Nil(t, stored.LastUsedAt)
+
+	user, err := service.AuthenticateKey(ctx, kiosk.Key)
+	require.NoError(t, err)
+	assert.Equal(t, kiosk.Subject(), user.ID)
+	assert.Equal(t, "Lobby kiosk", user.Username)
+	assert.Equal(t, []auth.Permission{auth.PermissionCheckInIssue}, user.Scopes)
+	assert.Equal(t, []uint{3}, user.Buildings)
+	assert.True(t, auth.DefaultPermissions().Allows(user, auth.PermissionCheckInIssue))
+	assert.False(t, auth.DefaultPermissions().Allows(user, auth.PermissionResourceWrite))
+
+	stored, err = service.GetAPIKey(kiosk.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastUsedAt, "uses are recorded")
+	assert.WithinDuration(t, time.Now(), *stored.LastUsedAt, time.Minute)
+
+	_, err = service.AuthenticateKey(ctx, kiosk.Key+"0")
+	assert.ErrorIs(t, err, common.ErrUnauthorized)
+	_, err = service.AuthenticateKey(ctx, "not-a-key")
+	assert.ErrorIs(t, err, common.ErrUnauthorized)
+
+	require.NoError(t, service.DeleteAPIKey(kiosk.ID))
+	_, err = service.AuthenticateKey(ctx, kiosk.Key)
+	assert.ErrorIs(t, err, common.ErrUnauthorized, "deleted keys are revoked")
+
+	// Expired keys are refused
+	sync := &apikey.APIKey{Name: "Timetable sync", Permissions: []auth.Permission{auth.PermissionLessonWrite}}
+	require.NoError(t, service.CreateAPIKey(admin, sync))
+	key := sync.Key
+	_, err = service.AuthenticateKey(ctx, key)
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Hour)
+	sync.ExpiresAt = &past
+	require.NoError(t, repo.UpdateAPIKey(sync))
+	_, err = service.AuthenticateKey(ctx, key)
+	assert.ErrorIs(t, err, common.ErrUnauthorized)
+}
+
+func TestUpdateAPIKeyKeepsTheKey(t *testing.T) {
+	service, _ := newService(t)
+
+	k := &apikey.APIKey{Name: "Sync", Permissions: []auth.Permission{auth.PermissionLessonWrite}}
+	require.NoError(t, service.CreateAPIKey(admin, k))
+	key := k.Key
+
+	expiry := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	update := &apikey.APIKey{
+		ID:          k.ID,
+		Name:        "Timetable sync",
+		Permissions: []auth.Permission{auth.PermissionLessonWrite, auth.PermissionClassWrite},
+		ExpiresAt:   &expiry,
+	}
+	require.NoError(t, service.UpdateAPIKey(update))
+	assert.Equal(t, k.Hash, update.Hash)
+	assert.Equal(t, "admin-1", update.CreatedBy)
+
+	user, err := service.AuthenticateKey(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, "Timetable sync", user.Username)
+	assert.ElementsMatch(t, []auth.Permission{auth.PermissionLessonWrite, auth.PermissionClassWrite}, user.Scopes)
+}
+
+func TestAPIKeyValidation(t *testing.T) {
+	service, _ := newService(t)
+	past := time.Now().Add(-time.Minute)
+
+	for name, k := range map[string]*apikey.APIKey{
+		"no name":            {Permissions: []auth.Permission{auth.PermissionLessonWrite}},
+		"no permissions":     {Name: "k"},
+		"unknown permission": {Name: "k", Permissions: []auth.Permission{"lesson:delete"}},
+		"manage keys":        {Name: "k", Permissions: []auth.Permission{auth.PermissionAPIKeyManage}},
+		"global permission":  {Name: "k", Permissions: []auth.Permission{auth.PermissionAuditRead}, BuildingIDs: []uint{1}},
+		"zero building":      {Name: "k", Permissions: []auth.Permission{auth.PermissionClassWrite}, BuildingIDs: []uint{0}},
+		"expired":            {Name: "k", Permissions: []auth.Permission{auth.PermissionClassWrite}, ExpiresAt: &past},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, service.CreateAPIKey(admin, k), common.ErrInvalidInput)
+		})
+	}
+
+	assert.ErrorIs(t, service.CreateAPIKey(nil, &apikey.APIKey{Name: "k"}), common.ErrUnauthorized)
+	assert.ErrorIs(t, service.UpdateAPIKey(&apikey.APIKey{ID: 42, Name: "k", Permissions: []auth.Permission{auth.PermissionClassWrite}}), common.ErrNotFound)
+	assert.ErrorIs(t, service.DeleteAPIKey(42), common.ErrNotFound)
+}
@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/common"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportBatchSize is how many entries are read at a time while exporting
+const exportBatchSize = 500
+
+// csvHeader names the columns of exported entries
+var csvHeader = []string{
+	"id", "createdAt", "action", "entityType", "entityId",
+	"actorId", "actorUsername", "actorGroups", "requestId",
+	"field", "before", "after",
+}
+
+// Service implements audit.Usecase interface
+type Service struct {
+	repo audit.Repository
+}
+
+// Compile-time verification that Service implements audit.Usecase
+var _ audit.Usecase = (*Service)(nil)
+
+// NewService creates a new audit service
+func NewService(repo audit.Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// GetAuditEntries retrieves the page of audit entries selected by the query
+func (s *Service) GetAuditEntries(query common.Query) (*common.Page[audit.Entry], error) {
+	return s.repo.ReadAuditEntryList(query)
+}
+
+// GetAuditEntry retrieves an audit entry by ID with validation
+func (s *Service) GetAuditEntry(id uint) (*audit.Entry, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: audit entry ID cannot be zero", common.ErrInvalidInput)
+	}
+	return s.repo.ReadAuditEntry(id)
+}
+
+// ExportAuditEntries writes every entry selected by the query as CSV, one row
+// per changed field. The paging of the query is ignored; entries are read and
+// written in batches so large exports do not hold the whole log at once.
+// Nothing is written before the first batch is read, so invalid queries fail
+// before the output is touched.
+func (s *Service) ExportAuditEntries(w io.Writer, query common.Query) error {
+	query.Limit = exportBatchSize
+	query.Offset = 0
+	query.Cursor = ""
+
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for {
+		page, err := s.repo.ReadAuditEntryList(query)
+		if err != nil {
+			return err
+		}
+		for _, entry := range page.Items {
+			rows, err := csvRows(entry)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				if err := out.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+		out.Flush()
+		if err := out.Error(); err != nil {
+			return err
+		}
+
+		switch {
+		case len(page.Items) < exportBatchSize:
+			return nil
+		case page.NextCursor != "":
+			// Cursors keep the export consistent while entries are added
+			query.Cursor = page.NextCursor
+		default:
+			query.Offset += exportBatchSize
+		}
+	}
+}
+
+// csvRows returns the rows of an entry, one per changed field. Entries without
+// changes still get a row. Every cell is safe to open in a spreadsheet.
+func csvRows(e audit.Entry) ([][]string, error) {
+	entry := []string{
+		strconv.FormatUint(uint64(e.ID), 10),
+		e.CreatedAt.UTC().Format(time.RFC3339),
+		string(e.Action),
+		e.EntityType,
+		strconv.FormatUint(uint64(e.EntityID), 10),
+		e.ActorID,
+		e.ActorUsername,
+		strings.Join(e.ActorGroups, ";"),
+		e.RequestID,
+	}
+	for i, cell := range entry {
+		entry[i] = csvCell(cell)
+	}
+	if len(e.Changes) == 0 {
+		return [][]string{append(entry, "", "", "")}, nil
+	}
+
+	rows := make([][]string, len(e.Changes))
+	for i, c := range e.Changes {
+		before, err := csvValue(c.Before)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export audit entry %d: %w", e.ID, err)
+		}
+		after, err := csvValue(c.After)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export audit entry %d: %w", e.ID, err)
+		}
+		rows[i] = append(append([]string(nil), entry...), csvCell(c.Field), csvCell(before), csvCell(after))
+	}
+	return rows, nil
+}
+
+// csvCell keeps spreadsheets from evaluating a cell as a formula by prefixing
+// cells that start like one with a quote
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// csvValue formats a changed value: strings as they are, missing values as
+// empty cells and anything else as JSON
+func csvValue(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		data, err := json.Marshal(v)
+		return string(data), err
+	}
+}
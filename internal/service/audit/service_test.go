@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"testing"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	classAdapter "sarc-ng/internal/adapter/gorm/class"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/building"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/resource"
+	buildingService "sarc-ng/internal/service/building"
+	resourceService "sarc-ng/internal/service/resource"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var admin = &auth.User{ID: "user-1", Username: "alice", Groups: []string{"admin", "manager"}, RequestID: "req-1"}
+
+// record makes audited changes to a building and a resource through their services
+func record(t *testing.T) (*Service, *building.Building, *resource.Resource) {
+	db := gormtest.Open(t,
+		&auditAdapter.GormModel{},
+		&buildingAdapter.GormModel{},
+		&classAdapter.GormModel{},
+		&eventAdapter.OutboxGormModel{},
+		&resourceAdapter.GormModel{},
+	)
+	buildings := buildingAdapter.NewGormAdapter(db)
+	classes := classAdapter.NewGormAdapter(db)
+	resources := resourceAdapter.NewGormAdapter(db)
+	buildingUsecase := buildingService.NewService(buildings, buildingAdapter.NewUnitOfWork(db), classes, resources)
+	resourceUsecase := resourceService.NewService(resources, resourceAdapter.NewUnitOfWork(db), buildings, classes)
+
+	hall := &building.Building{Name: "Main Hall", Code: "MH"}
+	require.NoError(t, buildingUsecase.CreateBuilding(admin, hall))
+	room := &resource.Resource{Name: "Room 101", Type: "room", IsAvailable: true, BuildingID: &hall.ID}
+	require.NoError(t, resourceUsecase.CreateResource(admin, room))
+	require.NoError(t, resourceUsecase.SetResourceAvailability(&auth.User{RequestID: "req-2"}, room.ID, false))
+
+	duplicate := &building.Building{Name: "Annex", Code: "MH"}
+	require.Error(t, buildingUsecase.CreateBuilding(admin, duplicate), "rejected changes are not audited")
+
+	return NewService(auditAdapter.NewGormAdapter(db)), hall, room
+}
+
+func TestAuditEntriesAreRecorded(t *testing.T) {
+	service, hall, room := record(t)
+
+	page, err := service.GetAuditEntries(common.Query{})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 3)
+
+	created := page.Items[0]
+	assert.Equal(t, audit.ActionCreate, created.Action)
+	assert.Equal(t, audit.EntityBuilding, created.EntityType)
+	assert.Equal(t, hall.ID, created.EntityID)
+	assert.Equal(t, "user-1", created.ActorID)
+	assert.Equal(t, "alice", created.ActorUsername)
+	assert.Equal(t, []string{"admin", "manager"}, created.ActorGroups)
+	assert.Equal(t, "req-1", created.RequestID)
+	assert.Contains(t, created.Changes, audit.Change{Field: "code", After: "MH"})
+	assert.False(t, created.CreatedAt.IsZero())
+
+	transitions, err := service.GetAuditEntries(common.Query{}.
+		Where("entityType", common.OpEqual, audit.EntityResource).
+		Where("action", common.OpEqual, string(audit.ActionTransition)))
+	require.NoError(t, err)
+	require.Len(t, transitions.Items, 1)
+	assert.Equal(t, room.ID, transitions.Items[0].EntityID)
+	assert.Empty(t, transitions.Items[0].ActorID, "anonymous requests are recorded by request ID")
+	assert.Equal(t, "req-2", transitions.Items[0].RequestID)
+	assert.Equal(t, []audit.Change{{Field: "isAvailable", Before: true, After: false}}, transitions.Items[0].Changes)
+
+	entry, err := service.GetAuditEntry(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, entry.ID)
+	_, err = service.GetAuditEntry(999)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+	_, err = service.GetAuditEntries(common.Query{}.Where("changes", common.OpEqual, "x"))
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+}
+
+func TestExportAuditEntries(t *testing.T) {
+	service, _, room := record(t)
+
+	var out bytes.Buffer
+	require.NoError(t, service.ExportAuditEntries(&out, common.Query{Limit: 1}.Where("entityType", common.OpEqual, audit.EntityResource)))
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, csvHeader, rows[0])
+	rows = rows[1:]
+
+	fields := make([]string, len(rows))
+	for i, row := range rows {
+		assert.Equal(t, "resource", row[3])
+		fields[i] = row[9]
+	}
+	assert.Equal(t, []string{"id", "name", "type", "isAvailable", "buildingId", "isAvailable"}, fields,
+		"one row per changed field of every entry, regardless of the page size")
+
+	last := rows[len(rows)-1]
+	assert.Equal(t, "transition", last[2])
+	assert.Equal(t, []string{"true", "false"}, last[10:])
+	assert.Equal(t, "admin;manager", rows[0][7])
+	assert.Equal(t, []string{"", "Room 101"}, rows[1][10:])
+	assert.Equal(t, "", rows[0][10], "values of created entities are empty before")
+	assert.Equal(t, strconv.FormatUint(uint64(room.ID), 10), rows[0][4])
+}
+
+func TestExportedCellsAreNotFormulas(t *testing.T) {
+	rows, err := csvRows(audit.Entry{
+		ID:            1,
+		Action:        audit.ActionUpdate,
+		EntityType:    audit.EntityResource,
+		ActorUsername: "@admin",
+		Changes: []audit.Change{
+			{Field: "name", Before: "Room 101", After: `=HYPERLINK("http://example.com","Room")`},
+			{Field: "location", Before: "+1 floor", After: "-1 floor"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, "'@admin", rows[0][6])
+	assert.Equal(t, []string{"Room 101", `'=HYPERLINK("http://example.com","Room")`}, rows[0][10:])
+	assert.Equal(t, []string{"'+1 floor", "'-1 floor"}, rows[1][10:])
+}
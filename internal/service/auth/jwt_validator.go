@@ -28,21 +28,6 @@ var (
 	ErrInvalidTokenUse  = errors.New("invalid token use")
 )
 
-// JWKS represents the JSON Web Key Set structure
-type JWKS struct {
-	Keys []JWK `json:"keys"`
-}
-
-// JWK represents a JSON Web Key
-type JWK struct {
-	Kid string `json:"kid"`
-	Kty string `json:"kty"`
-	Alg string `json:"alg"`
-	Use string `json:"use"`
-	N   string `json:"n"`
-	E   string `json:"e"`
-}
-
 // JWTValidator validates JWT tokens from AWS Cognito
 type JWTValidator struct {
 	region          string
@@ -209,7 +194,7 @@ func (v *JWTValidator) fetchJWKS(ctx context.Context) error {
 		return fmt.Errorf("failed to fetch JWKS: status code %d", resp.StatusCode)
 	}
 
-	var jwks JWKS
+	var jwks auth.JWKS
 	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
 		return fmt.Errorf("failed to decode JWKS: %w", err)
 	}
@@ -224,7 +209,7 @@ func (v *JWTValidator) fetchJWKS(ctx context.Context) error {
 			continue
 		}
 
-		publicKey, err := v.parseRSAPublicKey(key)
+		publicKey, err := parseRSAPublicKey(key)
 		if err != nil {
 			continue
 		}
@@ -237,7 +222,7 @@ func (v *JWTValidator) fetchJWKS(ctx context.Context) error {
 }
 
 // parseRSAPublicKey converts a JWK to an RSA public key
-func (v *JWTValidator) parseRSAPublicKey(jwk JWK) (*rsa.PublicKey, error) {
+func parseRSAPublicKey(jwk auth.JWK) (*rsa.PublicKey, error) {
 	nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
 	if err != nil {
 		return nil, err
@@ -295,7 +280,7 @@ func (v *JWTValidator) mapClaimsToDomain(mapClaims jwt.MapClaims, authClaims *au
 
 // loadJWKSFromJSON loads JWKS from JSON data (for pre-fetched JWKS from env var)
 func (v *JWTValidator) loadJWKSFromJSON(data []byte) {
-	var jwks JWKS
+	var jwks auth.JWKS
 	if err := json.Unmarshal(data, &jwks); err != nil {
 		log.Printf("Failed to unmarshal JWKS from environment variable: %v", err)
 		return
@@ -305,7 +290,7 @@ func (v *JWTValidator) loadJWKSFromJSON(data []byte) {
 	defer v.cacheMutex.Unlock()
 
 	for _, key := range jwks.Keys {
-		publicKey, err := v.parseRSAPublicKey(key)
+		publicKey, err := parseRSAPublicKey(key)
 		if err != nil {
 			log.Printf("Failed to convert JWK to public key for kid %s: %v", key.Kid, err)
 			continue
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"sarc-ng/internal/domain/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minSecretLength is the shortest HS256 secret accepted, the size of the hash
+const minSecretLength = 32
+
+// LocalSettings holds how the local issuer signs its tokens. HS256 tokens are
+// signed with Secret. RS256 tokens are signed with PrivateKey, generated on
+// startup when nil, and also verified with PublicKeys so that tokens of a
+// replaced key stay valid until they expire.
+type LocalSettings struct {
+	Issuer     string
+	Algorithm  string // HS256 or RS256
+	Secret     string
+	PrivateKey *rsa.PrivateKey
+	PublicKeys []*rsa.PublicKey
+	Expiry     time.Duration
+	DevTokens  bool
+}
+
+// LocalIssuer issues and validates tokens signed with its own keys, for
+// development and deployments without an identity provider. Its claims are
+// those of OpenID Connect, so other services can trust it as an issuer.
+type LocalIssuer struct {
+	settings   LocalSettings
+	method     jwt.SigningMethod
+	signingKey interface{}
+	kid        string
+	keys       map[string]interface{}
+	jwks       auth.JWKS
+}
+
+// Compile-time verification that LocalIssuer implements auth.TokenIssuer
+var _ auth.TokenIssuer = (*LocalIssuer)(nil)
+
+// NewLocalIssuer creates an issuer signing with the configured keys
+func NewLocalIssuer(settings LocalSettings) (*LocalIssuer, error) {
+	if settings.Issuer == "" {
+		return nil, errors.New("token issuer is required")
+	}
+	if settings.Expiry <= 0 {
+		return nil, errors.New("token expiry must be positive")
+	}
+
+	issuer := &LocalIssuer{
+		settings: settings,
+		keys:     make(map[string]interface{}),
+		jwks:     auth.JWKS{Keys: []auth.JWK{}},
+	}
+
+	switch settings.Algorithm {
+	case jwt.SigningMethodHS256.Alg():
+		if len(settings.Secret) < minSecretLength {
+			return nil, fmt.Errorf("HS256 secret must be at least %d bytes", minSecretLength)
+		}
+		// Secrets are never published, so the key set stays empty
+		issuer.method = jwt.SigningMethodHS256
+		issuer.signingKey = []byte(settings.Secret)
+		issuer.kid = "hs256"
+		issuer.keys[issuer.kid] = issuer.signingKey
+	case jwt.SigningMethodRS256.Alg():
+		privateKey := settings.PrivateKey
+		if privateKey == nil {
+			var err error
+			privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate signing key: %w", err)
+			}
+			log.Printf("Warning: Signing tokens with a key generated on startup; they stop working on restart and other instances reject them. Set jwt.key_file to share one key.")
+		}
+		issuer.method = jwt.SigningMethodRS256
+		issuer.signingKey = privateKey
+		issuer.kid = issuer.addPublicKey(&privateKey.PublicKey)
+		for _, key := range settings.PublicKeys {
+			issuer.addPublicKey(key)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q, expected HS256 or RS256", settings.Algorithm)
+	}
+
+	return issuer, nil
+}
+
+// addPublicKey accepts and publishes an RSA key under its thumbprint
+func (i *LocalIssuer) addPublicKey(key *rsa.PublicKey) string {
+	jwk := auth.JWK{
+		Kty: "RSA",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+	// RFC 7638 thumbprint: the hash of the required members in lexical order
+	thumbprint, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{jwk.E, jwk.Kty, jwk.N})
+	sum := sha256.Sum256(thumbprint)
+	jwk.Kid = base64.RawURLEncoding.EncodeToString(sum[:])
+	jwk.Alg = jwt.SigningMethodRS256.Alg()
+	jwk.Use = "sig"
+
+	if _, exists := i.keys[jwk.Kid]; !exists {
+		i.keys[jwk.Kid] = key
+		i.jwks.Keys = append(i.jwks.Keys, jwk)
+	}
+	return jwk.Kid
+}
+
+// IssueToken signs an access token for the user
+func (i *LocalIssuer) IssueToken(user *auth.User, ttl time.Duration) (*auth.Token, error) {
+	if user == nil || user.ID == "" {
+		return nil, errors.New("tokens need a subject")
+	}
+	if ttl <= 0 {
+		ttl = i.settings.Expiry
+	}
+
+	groups := user.Groups
+	if groups == nil {
+		groups = []string{}
+	}
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims := jwt.MapClaims{
+		"iss":                i.settings.Issuer,
+		"sub":                user.ID,
+		"preferred_username": user.Username,
+		"groups":             groups,
+		"token_use":          "access",
+		"auth_time":          now.Unix(),
+		"iat":                now.Unix(),
+		"exp":                expiresAt.Unix(),
+	}
+	if user.Email != "" {
+		claims["email"] = user.Email
+	}
+
+	token := jwt.NewWithClaims(i.method, claims)
+	token.Header["kid"] = i.kid
+	signed, err := token.SignedString(i.signingKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return &auth.Token{AccessToken: signed, ExpiresAt: expiresAt}, nil
+}
+
+// ValidateToken validates a token this issuer signed and returns claims
+func (i *LocalIssuer) ValidateToken(ctx context.Context, tokenString string) (*auth.Claims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{i.method.Alg()}),
+		jwt.WithIssuer(i.settings.Issuer),
+		jwt.WithExpirationRequired(),
+	)
+	token, err := parser.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := i.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return mapTokenClaims(claims, DefaultUsernameClaim, DefaultGroupsClaim), nil
+}
+
+// RefreshJWKS does nothing, as the keys are local
+func (i *LocalIssuer) RefreshJWKS(ctx context.Context) error {
+	return nil
+}
+
+// Issuer returns the iss claim of the tokens
+func (i *LocalIssuer) Issuer() string {
+	return i.settings.Issuer
+}
+
+// KeySet returns the public keys tokens are verified with
+func (i *LocalIssuer) KeySet() auth.JWKS {
+	return i.jwks
+}
+
+// DevTokens reports whether anyone may ask for a token of their choice
+func (i *LocalIssuer) DevTokens() bool {
+	return i.settings.DevTokens
+}
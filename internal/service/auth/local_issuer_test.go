@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sarc-ng/internal/domain/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var manager = &auth.User{ID: "dev-alice", Username: "alice", Email: "alice@example.com", Groups: []string{"manager"}}
+
+func TestLocalIssuerHS256(t *testing.T) {
+	_, err := NewLocalIssuer(LocalSettings{Issuer: "sarc", Algorithm: "HS256", Secret: "too-short", Expiry: time.Hour})
+	assert.Error(t, err)
+
+	settings := LocalSettings{Issuer: "sarc", Algorithm: "HS256", Secret: strings.Repeat("s", 32), Expiry: time.Hour}
+	issuer, err := NewLocalIssuer(settings)
+	require.NoError(t, err)
+	assert.Empty(t, issuer.KeySet().Keys, "secrets are not published")
+
+	token, err := issuer.IssueToken(manager, 0)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+
+	claims, err := issuer.ValidateToken(context.Background(), token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "dev-alice", claims.Sub)
+	assert.Equal(t, "alice", claims.Username)
+	assert.Equal(t, "alice@example.com", claims.Email)
+	assert.Equal(t, []string{"manager"}, claims.Groups)
+	assert.Equal(t, "sarc", claims.Issuer)
+
+	settings.Secret = strings.Repeat("t", 32)
+	other, err := NewLocalIssuer(settings)
+	require.NoError(t, err)
+	_, err = other.ValidateToken(context.Background(), token.AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	expired, err := issuer.IssueToken(manager, time.Nanosecond)
+	require.NoError(t, err)
+	time.Sleep(time.Second)
+	_, err = issuer.ValidateToken(context.Background(), expired.AccessToken)
+	assert.ErrorIs(t, err, ErrExpiredToken)
+}
+
+func TestLocalIssuerRS256KeepsReplacedKeys(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	before, err := NewLocalIssuer(LocalSettings{Issuer: "sarc", Algorithm: "RS256", PrivateKey: oldKey, Expiry: time.Hour})
+	require.NoError(t, err)
+	token, err := before.IssueToken(manager, 0)
+	require.NoError(t, err)
+
+	after, err := NewLocalIssuer(LocalSettings{Issuer: "sarc", Algorithm: "RS256", PrivateKey: newKey, PublicKeys: []*rsa.PublicKey{&oldKey.PublicKey}, Expiry: time.Hour})
+	require.NoError(t, err)
+	require.Len(t, after.KeySet().Keys, 2)
+	assert.Equal(t, before.KeySet().Keys[0], after.KeySet().Keys[1], "keys are published under their thumbprint")
+
+	_, err = after.ValidateToken(context.Background(), token.AccessToken)
+	assert.NoError(t, err, "tokens of the replaced key stay valid")
+
+	generated, err := NewLocalIssuer(LocalSettings{Issuer: "sarc", Algorithm: "RS256", Expiry: time.Hour})
+	require.NoError(t, err)
+	_, err = generated.ValidateToken(context.Background(), token.AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = NewLocalIssuer(LocalSettings{Issuer: "sarc", Algorithm: "none", Expiry: time.Hour})
+	assert.Error(t, err)
+}
+
+func TestOIDCValidatorTrustsLocalIssuer(t *testing.T) {
+	var issuer *LocalIssuer
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": issuer.Issuer(), "jwks_uri": issuer.Issuer() + "/.well-known/jwks.json"})
+	})
+	mux.HandleFunc("/.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(issuer.KeySet())
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	issuer, err := NewLocalIssuer(LocalSettings{Issuer: server.URL, Algorithm: "RS256", Expiry: time.Hour})
+	require.NoError(t, err)
+	token, err := issuer.IssueToken(manager, 0)
+	require.NoError(t, err)
+
+	validator, err := NewOIDCValidator([]OIDCIssuer{{URL: server.URL}}, time.Hour)
+	require.NoError(t, err)
+	claims, err := validator.ValidateToken(context.Background(), token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", claims.Username)
+	assert.Equal(t, []string{"manager"}, claims.Groups)
+}
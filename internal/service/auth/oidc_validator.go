@@ -0,0 +1,403 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"sarc-ng/internal/domain/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims looked up when an issuer does not name its own
+const (
+	DefaultUsernameClaim = "preferred_username"
+	DefaultGroupsClaim   = "groups"
+)
+
+// minRefreshInterval keeps tokens with unknown key IDs from making the
+// validator fetch the keys of an issuer over and over
+const minRefreshInterval = 30 * time.Second
+
+// subjectSeparator joins an issuer and a subject into the user ID of a user
+// of an issuer other than the primary one
+const subjectSeparator = "|"
+
+// OIDCIssuer is an OpenID Connect provider whose tokens are trusted. A subject
+// is only unique within its issuer, so the user IDs of the users of every
+// issuer but the primary one are "<issuer URL>|<sub>". The users of the
+// primary issuer keep their sub as their ID.
+type OIDCIssuer struct {
+	URL           string   // issuer identifier, exactly as in the iss claim
+	Audiences     []string // accepted aud or azp values; empty accepts any
+	UsernameClaim string   // defaults to preferred_username
+	GroupsClaim   string   // dotted path such as realm_access.roles; defaults to groups
+	Primary       bool     // user IDs are the bare sub; implied when there is a single issuer
+}
+
+// oidcIssuer holds the discovered signing keys of a trusted issuer
+type oidcIssuer struct {
+	OIDCIssuer
+
+	mutex     sync.RWMutex
+	jwksURL   string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// OIDCValidator validates RS256 and ES256 tokens of any OpenID Connect
+// provider, such as Keycloak. The signing keys of each trusted issuer are
+// found through its discovery document and cached.
+type OIDCValidator struct {
+	issuers     map[string]*oidcIssuer
+	cacheExpiry time.Duration
+	minRefresh  time.Duration
+	httpClient  *http.Client
+}
+
+// Compile-time verification that OIDCValidator implements auth.TokenValidator
+var _ auth.TokenValidator = (*OIDCValidator)(nil)
+
+// NewOIDCValidator creates a validator trusting the tokens of the issuers, of
+// which at most one may be primary
+func NewOIDCValidator(issuers []OIDCIssuer, cacheExpiry time.Duration) (*OIDCValidator, error) {
+	if len(issuers) == 0 {
+		return nil, errors.New("at least one OIDC issuer is required")
+	}
+
+	validator := &OIDCValidator{
+		issuers:     make(map[string]*oidcIssuer, len(issuers)),
+		cacheExpiry: cacheExpiry,
+		minRefresh:  minRefreshInterval,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+	primary := ""
+	for _, issuer := range issuers {
+		if issuer.URL == "" {
+			return nil, errors.New("OIDC issuer URL is required")
+		}
+		if _, exists := validator.issuers[issuer.URL]; exists {
+			return nil, fmt.Errorf("OIDC issuer %s is configured twice", issuer.URL)
+		}
+		if len(issuers) == 1 {
+			issuer.Primary = true
+		}
+		if issuer.Primary {
+			if primary != "" {
+				return nil, fmt.Errorf("OIDC issuers %s and %s are both primary, at most one may be", primary, issuer.URL)
+			}
+			primary = issuer.URL
+		}
+		if issuer.UsernameClaim == "" {
+			issuer.UsernameClaim = DefaultUsernameClaim
+		}
+		if issuer.GroupsClaim == "" {
+			issuer.GroupsClaim = DefaultGroupsClaim
+		}
+		validator.issuers[issuer.URL] = &oidcIssuer{OIDCIssuer: issuer}
+	}
+
+	return validator, nil
+}
+
+// ValidateToken validates a JWT token of a trusted issuer and returns claims
+func (v *OIDCValidator) ValidateToken(ctx context.Context, tokenString string) (*auth.Claims, error) {
+	// The issuer tells which keys to verify the signature with
+	unverified, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	iss, _ := unverified.Claims.GetIssuer()
+	issuer, ok := v.issuers[iss]
+	if !ok {
+		return nil, ErrInvalidIssuer
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithIssuer(issuer.URL),
+		jwt.WithExpirationRequired(),
+	)
+	token, err := parser.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errors.New("missing kid in token header")
+		}
+		return v.getPublicKey(ctx, issuer, kid)
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	if !issuer.acceptsAudience(claims) {
+		return nil, ErrInvalidAudience
+	}
+
+	return issuer.mapClaimsToDomain(claims), nil
+}
+
+// RefreshJWKS forces a refresh of the signing keys of every issuer
+func (v *OIDCValidator) RefreshJWKS(ctx context.Context) error {
+	var errs []error
+	for _, issuer := range v.issuers {
+		if err := v.fetchJWKS(ctx, issuer); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getPublicKey retrieves the public key of the issuer for a given kid,
+// fetching the keys again when they expired or the kid is new
+func (v *OIDCValidator) getPublicKey(ctx context.Context, issuer *oidcIssuer, kid string) (crypto.PublicKey, error) {
+	issuer.mutex.RLock()
+	key, exists := issuer.keys[kid]
+	fetchedAt := issuer.fetchedAt
+	issuer.mutex.RUnlock()
+
+	age := time.Since(fetchedAt)
+	if exists && age <= v.cacheExpiry {
+		return key, nil
+	}
+	// Keys are rotated by publishing a new kid, but a kid that is still
+	// unknown after a recent fetch is not worth asking for again
+	if !exists && !fetchedAt.IsZero() && age < v.minRefresh {
+		return nil, fmt.Errorf("key with kid %s not found in JWKS of %s", kid, issuer.URL)
+	}
+
+	if err := v.fetchJWKS(ctx, issuer); err != nil {
+		if exists {
+			log.Printf("Failed to refresh JWKS of %s, using the cached keys: %v", issuer.URL, err)
+			return key, nil
+		}
+		return nil, err
+	}
+
+	issuer.mutex.RLock()
+	key, exists = issuer.keys[kid]
+	issuer.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("key with kid %s not found in JWKS of %s", kid, issuer.URL)
+	}
+	return key, nil
+}
+
+// fetchJWKS discovers the JWKS URL of the issuer unless known and caches its keys
+func (v *OIDCValidator) fetchJWKS(ctx context.Context, issuer *oidcIssuer) error {
+	issuer.mutex.RLock()
+	jwksURL := issuer.jwksURL
+	issuer.mutex.RUnlock()
+
+	if jwksURL == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		discoveryURL := strings.TrimSuffix(issuer.URL, "/") + "/.well-known/openid-configuration"
+		if err := v.getJSON(ctx, discoveryURL, &discovery); err != nil {
+			return fmt.Errorf("failed to discover OIDC configuration of %s: %w", issuer.URL, err)
+		}
+		if discovery.Issuer != issuer.URL {
+			return fmt.Errorf("OIDC configuration of %s is for issuer %s", issuer.URL, discovery.Issuer)
+		}
+		if discovery.JWKSURI == "" {
+			return fmt.Errorf("OIDC configuration of %s has no jwks_uri", issuer.URL)
+		}
+		jwksURL = discovery.JWKSURI
+	}
+
+	var jwks auth.JWKS
+	if err := v.getJSON(ctx, jwksURL, &jwks); err != nil {
+		return fmt.Errorf("failed to fetch JWKS of %s: %w", issuer.URL, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parsePublicKey(jwk)
+		if err != nil {
+			log.Printf("Skipping key %s of %s: %v", jwk.Kid, issuer.URL, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	issuer.mutex.Lock()
+	defer issuer.mutex.Unlock()
+	issuer.jwksURL = jwksURL
+	issuer.keys = keys
+	issuer.fetchedAt = time.Now()
+	return nil
+}
+
+// getJSON fetches and decodes a JSON document
+func (v *OIDCValidator) getJSON(ctx context.Context, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status code %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// parsePublicKey converts an RSA or P-256 JWK to a public key
+func parsePublicKey(jwk auth.JWK) (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		return parseRSAPublicKey(jwk)
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		xBytes, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		yBytes, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(xBytes),
+			Y:     new(big.Int).SetBytes(yBytes),
+		}
+		if _, err := key.ECDH(); err != nil {
+			return nil, fmt.Errorf("invalid P-256 key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+	}
+}
+
+// acceptsAudience checks the aud claim, or the azp claim Keycloak puts the
+// client in, against the audiences of the issuer
+func (i *oidcIssuer) acceptsAudience(claims jwt.MapClaims) bool {
+	if len(i.Audiences) == 0 {
+		return true
+	}
+
+	audiences, _ := claims.GetAudience()
+	if azp, ok := claims["azp"].(string); ok {
+		audiences = append(audiences, azp)
+	}
+	for _, audience := range audiences {
+		if slices.Contains(i.Audiences, audience) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapClaimsToDomain maps the claims to our domain Claims structure, taking the
+// username and groups from the claims configured for the issuer and
+// namespacing the subject unless the issuer is primary
+func (i *oidcIssuer) mapClaimsToDomain(mapClaims jwt.MapClaims) *auth.Claims {
+	claims := mapTokenClaims(mapClaims, i.UsernameClaim, i.GroupsClaim)
+	if !i.Primary && claims.Sub != "" {
+		claims.Sub = i.URL + subjectSeparator + claims.Sub
+	}
+	return claims
+}
+
+// mapTokenClaims maps standard OpenID Connect claims to our domain Claims
+// structure, taking the username and groups from the named claims
+func mapTokenClaims(mapClaims jwt.MapClaims, usernameClaim, groupsClaim string) *auth.Claims {
+	authClaims := &auth.Claims{}
+	authClaims.Sub, _ = mapClaims["sub"].(string)
+	authClaims.Email, _ = mapClaims["email"].(string)
+	authClaims.EmailVerified, _ = mapClaims["email_verified"].(bool)
+	authClaims.Username, _ = claimAt(mapClaims, usernameClaim).(string)
+	authClaims.TokenUse, _ = mapClaims["token_use"].(string)
+	authClaims.Scope, _ = mapClaims["scope"].(string)
+	authClaims.Issuer, _ = mapClaims["iss"].(string)
+	authClaims.ClientID, _ = mapClaims["azp"].(string)
+	if clientID, ok := mapClaims["client_id"].(string); ok {
+		authClaims.ClientID = clientID
+	}
+	if audiences, _ := mapClaims.GetAudience(); len(audiences) > 0 {
+		authClaims.Audience = audiences[0]
+	}
+
+	// Handle numeric claims
+	if authTime, ok := mapClaims["auth_time"].(float64); ok {
+		authClaims.AuthTime = int64(authTime)
+	}
+	if iat, ok := mapClaims["iat"].(float64); ok {
+		authClaims.IssuedAt = int64(iat)
+	}
+	if exp, ok := mapClaims["exp"].(float64); ok {
+		authClaims.ExpirationTime = int64(exp)
+	}
+
+	// Groups are a list of names, or a single one. Keycloak prefixes group
+	// paths with a slash.
+	switch groups := claimAt(mapClaims, groupsClaim).(type) {
+	case []interface{}:
+		authClaims.Groups = make([]string, 0, len(groups))
+		for _, group := range groups {
+			if groupStr, ok := group.(string); ok {
+				authClaims.Groups = append(authClaims.Groups, strings.TrimPrefix(groupStr, "/"))
+			}
+		}
+	case string:
+		authClaims.Groups = []string{strings.TrimPrefix(groups, "/")}
+	}
+
+	return authClaims
+}
+
+// claimAt looks a claim up by name, or by a dotted path into nested objects
+// when no claim has that name
+func claimAt(claims map[string]interface{}, path string) interface{} {
+	if value, ok := claims[path]; ok {
+		return value
+	}
+
+	name, rest, nested := strings.Cut(path, ".")
+	if !nested {
+		return nil
+	}
+	object, ok := claims[name].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return claimAt(object, rest)
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sarc-ng/internal/domain/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testIssuer is a stand-in OpenID Connect provider publishing one signing key
+type testIssuer struct {
+	URL    string
+	method jwt.SigningMethod
+
+	mutex sync.Mutex
+	kid   string
+	key   crypto.Signer
+
+	jwksFetches atomic.Int32
+}
+
+func newTestIssuer(t *testing.T, method jwt.SigningMethod) *testIssuer {
+	issuer := &testIssuer{method: method}
+	issuer.rotate(t, "key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.URL,
+			"jwks_uri": issuer.URL + "/protocol/openid-connect/certs",
+		})
+	})
+	mux.HandleFunc("/protocol/openid-connect/certs", func(w http.ResponseWriter, r *http.Request) {
+		issuer.jwksFetches.Add(1)
+		_ = json.NewEncoder(w).Encode(auth.JWKS{Keys: []auth.JWK{issuer.jwk()}})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	issuer.URL = server.URL
+	return issuer
+}
+
+// rotate replaces the signing key with a new one
+func (i *testIssuer) rotate(t *testing.T, kid string) {
+	var key crypto.Signer
+	var err error
+	if i.method == jwt.SigningMethodES256 {
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	} else {
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	}
+	require.NoError(t, err)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.kid, i.key = kid, key
+}
+
+func (i *testIssuer) jwk() auth.JWK {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	switch key := i.key.Public().(type) {
+	case *ecdsa.PublicKey:
+		return auth.JWK{Kid: i.kid, Kty: "EC", Use: "sig", Crv: "P-256", X: encode(key.X.Bytes()), Y: encode(key.Y.Bytes())}
+	case *rsa.PublicKey:
+		return auth.JWK{Kid: i.kid, Kty: "RSA", Use: "sig", N: encode(key.N.Bytes()), E: encode(big.NewInt(int64(key.E)).Bytes())}
+	default:
+		panic("unexpected key type")
+	}
+}
+
+// sign issues a token with the claims, which expires in an hour unless set
+func (i *testIssuer) sign(t *testing.T, claims jwt.MapClaims) string {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if _, ok := claims["iss"]; !ok {
+		claims["iss"] = i.URL
+	}
+	if _, ok := claims["exp"]; !ok {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+	}
+	token := jwt.NewWithClaims(i.method, claims)
+	token.Header["kid"] = i.kid
+	signed, err := token.SignedString(i.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestOIDCValidatorTrustsSeveralIssuers(t *testing.T) {
+	keycloak := newTestIssuer(t, jwt.SigningMethodRS256)
+	other := newTestIssuer(t, jwt.SigningMethodES256)
+	validator, err := NewOIDCValidator([]OIDCIssuer{
+		{URL: keycloak.URL, Audiences: []string{"sarc"}, GroupsClaim: "realm_access.roles", Primary: true},
+		{URL: other.URL, Audiences: []string{"sarc-api"}, UsernameClaim: "upn"},
+	}, time.Hour)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	claims, err := validator.ValidateToken(ctx, keycloak.sign(t, jwt.MapClaims{
+		"sub":                "f2c1",
+		"aud":                "account",
+		"azp":                "sarc",
+		"preferred_username": "alice",
+		"email":              "alice@example.com",
+		"realm_access":       map[string]any{"roles": []string{"manager", "offline_access"}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "f2c1", claims.Sub)
+	assert.Equal(t, "alice", claims.Username)
+	assert.Equal(t, "alice@example.com", claims.Email)
+	assert.Equal(t, []string{"manager", "offline_access"}, claims.Groups)
+	assert.Equal(t, "sarc", claims.ClientID)
+	assert.True(t, claims.ToUser().IsManager())
+
+	claims, err = validator.ValidateToken(ctx, other.sign(t, jwt.MapClaims{
+		"sub":    "b7",
+		"aud":    []string{"sarc-api", "other"},
+		"upn":    "bob@example.com",
+		"groups": []string{"/teacher"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, other.URL+"|b7", claims.Sub, "subjects of other issuers are namespaced")
+	assert.Equal(t, "bob@example.com", claims.Username)
+	assert.Equal(t, []string{"teacher"}, claims.Groups, "group paths lose their leading slash")
+	assert.Equal(t, other.URL, claims.Issuer)
+
+	_, err = validator.ValidateToken(ctx, keycloak.sign(t, jwt.MapClaims{"sub": "f2c1", "azp": "sarc"}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), keycloak.jwksFetches.Load(), "keys are cached")
+}
+
+func TestOIDCValidatorKeepsIssuersUsersApart(t *testing.T) {
+	primary := newTestIssuer(t, jwt.SigningMethodRS256)
+	other := newTestIssuer(t, jwt.SigningMethodRS256)
+	third := newTestIssuer(t, jwt.SigningMethodES256)
+	validator, err := NewOIDCValidator([]OIDCIssuer{
+		{URL: primary.URL, Primary: true},
+		{URL: other.URL},
+		{URL: third.URL},
+	}, time.Hour)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Every issuer has a user with the same subject
+	var ids []string
+	for _, issuer := range []*testIssuer{primary, other, third} {
+		claims, err := validator.ValidateToken(ctx, issuer.sign(t, jwt.MapClaims{"sub": "42"}))
+		require.NoError(t, err)
+		ids = append(ids, claims.ToUser().ID)
+	}
+	assert.Equal(t, []string{"42", other.URL + "|42", third.URL + "|42"}, ids)
+}
+
+func TestOIDCValidatorRejectsTokens(t *testing.T) {
+	trusted := newTestIssuer(t, jwt.SigningMethodES256)
+	untrusted := newTestIssuer(t, jwt.SigningMethodES256)
+	validator, err := NewOIDCValidator([]OIDCIssuer{{URL: trusted.URL, Audiences: []string{"sarc"}}}, time.Hour)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = validator.ValidateToken(ctx, untrusted.sign(t, jwt.MapClaims{"aud": "sarc"}))
+	assert.ErrorIs(t, err, ErrInvalidIssuer)
+
+	_, err = validator.ValidateToken(ctx, trusted.sign(t, jwt.MapClaims{"aud": "another-app"}))
+	assert.ErrorIs(t, err, ErrInvalidAudience)
+
+	_, err = validator.ValidateToken(ctx, trusted.sign(t, jwt.MapClaims{"aud": "sarc", "exp": time.Now().Add(-time.Minute).Unix()}))
+	assert.ErrorIs(t, err, ErrExpiredToken)
+
+	forged := untrusted.sign(t, jwt.MapClaims{"aud": "sarc", "iss": trusted.URL})
+	_, err = validator.ValidateToken(ctx, forged)
+	assert.ErrorIs(t, err, ErrInvalidToken, "signed with a key of another issuer under the same kid")
+
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"aud": "sarc", "iss": trusted.URL, "exp": time.Now().Add(time.Hour).Unix()})
+	hmac.Header["kid"] = "key-1"
+	signed, err := hmac.SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = validator.ValidateToken(ctx, signed)
+	assert.ErrorIs(t, err, ErrInvalidToken, "only RS256 and ES256 are accepted")
+
+	_, err = validator.ValidateToken(ctx, "not-a-token")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestOIDCValidatorPicksUpRotatedKeys(t *testing.T) {
+	issuer := newTestIssuer(t, jwt.SigningMethodRS256)
+	validator, err := NewOIDCValidator([]OIDCIssuer{{URL: issuer.URL}}, time.Hour)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = validator.ValidateToken(ctx, issuer.sign(t, jwt.MapClaims{"sub": "a"}))
+	require.NoError(t, err)
+
+	issuer.rotate(t, "key-2")
+	token := issuer.sign(t, jwt.MapClaims{"sub": "a"})
+	_, err = validator.ValidateToken(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidToken, "unknown kids do not refetch the keys right after a fetch")
+	assert.Equal(t, int32(1), issuer.jwksFetches.Load())
+
+	validator.minRefresh = 0
+	_, err = validator.ValidateToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), issuer.jwksFetches.Load())
+}
+
+func TestNewOIDCValidator(t *testing.T) {
+	_, err := NewOIDCValidator(nil, time.Hour)
+	assert.Error(t, err)
+	_, err = NewOIDCValidator([]OIDCIssuer{{URL: "https://id.example.com"}, {URL: "https://id.example.com"}}, time.Hour)
+	assert.Error(t, err)
+	_, err = NewOIDCValidator([]OIDCIssuer{{URL: "https://id.example.com", Primary: true}, {URL: "https://other.example.com", Primary: true}}, time.Hour)
+	assert.Error(t, err, "at most one issuer is primary")
+
+	validator, err := NewOIDCValidator([]OIDCIssuer{{URL: "http://127.0.0.1:1/realms/sarc"}}, time.Hour)
+	require.NoError(t, err)
+	assert.Error(t, validator.RefreshJWKS(context.Background()), "discovery fails for unreachable issuers")
+}
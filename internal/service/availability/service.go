@@ -0,0 +1,178 @@
+package availability
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/availability"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/resource"
+	"time"
+)
+
+// maxCandidates bounds how many resources a multi-resource search inspects
+const maxCandidates = 100
+
+// Service implements availability.Usecase interface
+type Service struct {
+	resources    resource.Repository
+	reservations reservation.Repository
+	lessons      lesson.Repository
+	policies     policy.Repository
+	hours        availability.OpeningHours
+}
+
+// Compile-time verification that Service implements availability.Usecase
+var _ availability.Usecase = (*Service)(nil)
+
+// NewService creates a new availability service
+func NewService(
+	resources resource.Repository,
+	reservations reservation.Repository,
+	lessons lesson.Repository,
+	policies policy.Repository,
+	hours availability.OpeningHours,
+) *Service {
+	return &Service{
+		resources:    resources,
+		reservations: reservations,
+		lessons:      lessons,
+		policies:     policies,
+		hours:        hours,
+	}
+}
+
+// FindFreeSlots lists the open windows of a resource, earliest first
+func (s *Service) FindFreeSlots(resourceID uint, search availability.Search) ([]availability.Slot, error) {
+	if resourceID == 0 {
+		return nil, fmt.Errorf("%w: resource ID cannot be zero", common.ErrInvalidInput)
+	}
+	search, err := validateSearch(search)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := s.resources.ReadResource(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsAvailable {
+		return []availability.Slot{}, nil
+	}
+
+	slots, err := s.freeSlots(r, search)
+	if err != nil {
+		return nil, err
+	}
+	return truncate(slots, search.Limit), nil
+}
+
+// FindFreeSlotsAcross lists the open windows of every available resource
+// selected by the query, earliest first
+func (s *Service) FindFreeSlotsAcross(resources common.Query, search availability.Search) ([]availability.Slot, error) {
+	search, err := validateSearch(search)
+	if err != nil {
+		return nil, err
+	}
+
+	resources = resources.Where("isAvailable", common.OpEqual, true)
+	resources.Limit, resources.Offset, resources.Cursor = maxCandidates, 0, ""
+	candidates, err := s.resources.ReadResourceList(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := []availability.Slot{}
+	for i := range candidates.Items {
+		found, err := s.freeSlots(&candidates.Items[i], search)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, found...)
+	}
+
+	availability.SortSlots(slots)
+	return truncate(slots, search.Limit), nil
+}
+
+// freeSlots computes the open windows of a resource from its opening hours,
+// active reservations and the lessons held in its class. Reservations also
+// keep the buffer of the resource type's booking policy busy.
+func (s *Service) freeSlots(r *resource.Resource, search availability.Search) ([]availability.Slot, error) {
+	var busy []availability.Window
+
+	var buffer time.Duration
+	p, err := s.policies.FindPolicyByResourceType(r.Type)
+	if err != nil {
+		return nil, err
+	}
+	if p != nil {
+		buffer = p.Buffer
+	}
+
+	reservations, err := s.reservations.ReadReservationListByResource(r.ID, search.From.Add(-buffer), search.To.Add(buffer))
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range reservations {
+		if existing.Status.IsActive() {
+			busy = append(busy, availability.Window{Start: existing.StartTime.Add(-buffer), End: existing.EndTime.Add(buffer)})
+		}
+	}
+
+	if r.ClassID != nil {
+		query := common.Query{}.
+			Where("classId", common.OpEqual, *r.ClassID).
+			Where("endTime", common.OpGreaterOrEqual, search.From).
+			Where("startTime", common.OpLessOrEqual, search.To)
+		lessons, err := s.lessons.ReadLessonList(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range lessons.Items {
+			busy = append(busy, availability.Window{Start: l.StartTime, End: l.EndTime})
+		}
+	}
+
+	windows := availability.FreeWindows(s.hours.Windows(search.From, search.To), busy, search.Duration)
+	slots := make([]availability.Slot, len(windows))
+	for i, window := range windows {
+		slots[i] = availability.Slot{ResourceID: r.ID, ResourceName: r.Name, Window: window}
+	}
+	return slots, nil
+}
+
+// validateSearch checks the search window and applies the default limit
+func validateSearch(search availability.Search) (availability.Search, error) {
+	if search.From.IsZero() || search.To.IsZero() {
+		return search, fmt.Errorf("%w: from and to are required", common.ErrInvalidInput)
+	}
+	if !search.To.After(search.From) {
+		return search, fmt.Errorf("%w: to must be after from", common.ErrInvalidInput)
+	}
+	if search.To.Sub(search.From) > availability.MaxSearchWindow {
+		return search, fmt.Errorf("%w: search window cannot exceed %s", common.ErrInvalidInput, availability.MaxSearchWindow)
+	}
+	if search.Duration <= 0 {
+		return search, fmt.Errorf("%w: duration must be positive", common.ErrInvalidInput)
+	}
+	if search.Duration > search.To.Sub(search.From) {
+		return search, fmt.Errorf("%w: duration is longer than the search window", common.ErrInvalidInput)
+	}
+	if search.Limit < 0 || search.Limit > availability.MaxLimit {
+		return search, fmt.Errorf("%w: limit must be between 1 and %d", common.ErrInvalidInput, availability.MaxLimit)
+	}
+	if search.Limit == 0 {
+		search.Limit = availability.DefaultLimit
+	}
+	return search, nil
+}
+
+// truncate keeps the first limit slots
+func truncate(slots []availability.Slot, limit int) []availability.Slot {
+	if len(slots) > limit {
+		return slots[:limit]
+	}
+	return slots
+}
@@ -0,0 +1,145 @@
+package availability
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	classAdapter "sarc-ng/internal/adapter/gorm/class"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	lessonAdapter "sarc-ng/internal/adapter/gorm/lesson"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/availability"
+	"sarc-ng/internal/domain/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestService(t *testing.T, hours availability.OpeningHours) (*Service, *gorm.DB) {
+	db := gormtest.Open(t,
+		&classAdapter.GormModel{},
+		&lessonAdapter.GormModel{},
+		&policyAdapter.GormModel{},
+		&reservationAdapter.GormModel{},
+		&resourceAdapter.GormModel{},
+	)
+	service := NewService(
+		resourceAdapter.NewGormAdapter(db),
+		reservationAdapter.NewGormAdapter(db),
+		lessonAdapter.NewGormAdapter(db),
+		policyAdapter.NewGormAdapter(db),
+		hours,
+	)
+	return service, db
+}
+
+// day returns the given hour of a fixed Monday in UTC
+func day(hour int) time.Time {
+	return time.Date(2030, time.January, 7, hour, 0, 0, 0, time.UTC)
+}
+
+func TestFindFreeSlots(t *testing.T) {
+	hours, err := availability.ParseOpeningHours("UTC", "08:00", "18:00", []string{"mon", "tue", "wed", "thu", "fri"})
+	require.NoError(t, err)
+	service, db := newTestService(t, hours)
+
+	main := &buildingAdapter.GormModel{Name: "Main", Code: "MAIN"}
+	require.NoError(t, db.Create(main).Error)
+	room := &classAdapter.GormModel{Name: "Room 101", Capacity: 30, BuildingID: &main.ID}
+	require.NoError(t, db.Create(room).Error)
+	lab := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true, ClassID: &room.ID}
+	require.NoError(t, db.Create(lab).Error)
+
+	require.NoError(t, db.Create(&[]reservationAdapter.GormModel{
+		{ResourceID: lab.ID, UserID: "user-1", StartTime: day(9), EndTime: day(10), Status: "approved"},
+		{ResourceID: lab.ID, UserID: "user-1", StartTime: day(13), EndTime: day(14), Status: "pending"},
+		// Cancelled reservations free their time again
+		{ResourceID: lab.ID, UserID: "user-1", StartTime: day(15), EndTime: day(17), Status: "cancelled"},
+	}).Error)
+	require.NoError(t, db.Create(&lessonAdapter.GormModel{
+		Title: "Algorithms", Duration: 90, StartTime: day(10).Add(30 * time.Minute), EndTime: day(12), ClassID: &room.ID,
+	}).Error)
+
+	slots, err := service.FindFreeSlots(lab.ID, availability.Search{From: day(0), To: day(24), Duration: time.Hour})
+	require.NoError(t, err)
+
+	want := []availability.Window{
+		{Start: day(8), End: day(9)},
+		{Start: day(12), End: day(13)},
+		{Start: day(14), End: day(18)},
+	}
+	require.Len(t, slots, len(want))
+	for i, slot := range slots {
+		assert.Equal(t, lab.ID, slot.ResourceID)
+		assert.Equal(t, "Lab 1", slot.ResourceName)
+		assert.True(t, want[i].Start.Equal(slot.Start), "slot %d starts at %s", i, slot.Start)
+		assert.True(t, want[i].End.Equal(slot.End), "slot %d ends at %s", i, slot.End)
+	}
+
+	t.Run("Gaps shorter than the duration are skipped", func(t *testing.T) {
+		slots, err := service.FindFreeSlots(lab.ID, availability.Search{From: day(0), To: day(24), Duration: 2 * time.Hour})
+		require.NoError(t, err)
+		require.Len(t, slots, 1)
+		assert.True(t, day(14).Equal(slots[0].Start))
+	})
+
+	t.Run("Closed days have no slots", func(t *testing.T) {
+		saturday := day(0).AddDate(0, 0, 5)
+		slots, err := service.FindFreeSlots(lab.ID, availability.Search{From: saturday, To: saturday.AddDate(0, 0, 2), Duration: time.Hour})
+		require.NoError(t, err)
+		assert.Empty(t, slots)
+	})
+
+	t.Run("Invalid searches", func(t *testing.T) {
+		searches := map[string]availability.Search{
+			"missing window":  {Duration: time.Hour},
+			"reversed window": {From: day(12), To: day(8), Duration: time.Hour},
+			"window too long": {From: day(0), To: day(0).AddDate(0, 2, 0), Duration: time.Hour},
+			"no duration":     {From: day(0), To: day(24)},
+			"long duration":   {From: day(8), To: day(9), Duration: 2 * time.Hour},
+			"limit too large": {From: day(0), To: day(24), Duration: time.Hour, Limit: availability.MaxLimit + 1},
+		}
+		for name, search := range searches {
+			_, err := service.FindFreeSlots(lab.ID, search)
+			assert.True(t, errors.Is(err, common.ErrInvalidInput), name)
+		}
+	})
+
+	t.Run("Unknown resource", func(t *testing.T) {
+		_, err := service.FindFreeSlots(999, availability.Search{From: day(0), To: day(24), Duration: time.Hour})
+		assert.True(t, errors.Is(err, common.ErrNotFound))
+	})
+}
+
+func TestFindFreeSlotsAcross(t *testing.T) {
+	service, db := newTestService(t, availability.AlwaysOpen())
+
+	first := &resourceAdapter.GormModel{Name: "Projector A", Type: "projector", IsAvailable: true}
+	second := &resourceAdapter.GormModel{Name: "Projector B", Type: "projector", IsAvailable: true}
+	broken := &resourceAdapter.GormModel{Name: "Projector C", Type: "projector", IsAvailable: false}
+	screen := &resourceAdapter.GormModel{Name: "Screen", Type: "screen", IsAvailable: true}
+	require.NoError(t, db.Create(&[]*resourceAdapter.GormModel{first, second, broken, screen}).Error)
+	// The column defaults to true, so the zero value is ignored on create
+	require.NoError(t, db.Model(broken).Update("is_available", false).Error)
+
+	require.NoError(t, db.Create(&[]reservationAdapter.GormModel{
+		{ResourceID: first.ID, UserID: "user-1", StartTime: day(8), EndTime: day(12), Status: "approved"},
+		{ResourceID: second.ID, UserID: "user-1", StartTime: day(9), EndTime: day(11), Status: "approved"},
+	}).Error)
+
+	query := common.Query{}.Where("type", common.OpEqual, "projector")
+	slots, err := service.FindFreeSlotsAcross(query, availability.Search{From: day(8), To: day(14), Duration: 2 * time.Hour, Limit: 2})
+	require.NoError(t, err)
+
+	// Projector B frees up first, then A; C is out of order and the screen is filtered out
+	require.Len(t, slots, 2)
+	assert.Equal(t, second.ID, slots[0].ResourceID)
+	assert.True(t, day(11).Equal(slots[0].Start))
+	assert.Equal(t, first.ID, slots[1].ResourceID)
+	assert.True(t, day(12).Equal(slots[1].Start))
+}
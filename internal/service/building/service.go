@@ -2,29 +2,41 @@ package building
 
 import (
 	"fmt"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
 	"sarc-ng/internal/domain/building"
+	"sarc-ng/internal/domain/class"
 	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/resource"
 	"strings"
 )
 
 // Service implements building.Usecase interface
 type Service struct {
-	repo building.Repository
+	repo      building.Repository
+	uow       building.UnitOfWork
+	classes   class.Repository
+	resources resource.Repository
 }
 
 // Compile-time verification that Service implements building.Usecase
 var _ building.Usecase = (*Service)(nil)
 
-// NewService creates a new building service
-func NewService(repo building.Repository) *Service {
+// NewService creates a new building service. Every change is written through
+// the unit of work together with its event and audit entry.
+func NewService(repo building.Repository, uow building.UnitOfWork, classes class.Repository, resources resource.Repository) *Service {
 	return &Service{
-		repo: repo,
+		repo:      repo,
+		uow:       uow,
+		classes:   classes,
+		resources: resources,
 	}
 }
 
-// GetAllBuildings retrieves all buildings
-func (s *Service) GetAllBuildings() ([]building.Building, error) {
-	return s.repo.ReadBuildingList()
+// GetAllBuildings retrieves the page of buildings selected by the query
+func (s *Service) GetAllBuildings(query common.Query) (*common.Page[building.Building], error) {
+	return s.repo.ReadBuildingList(query)
 }
 
 // GetBuilding retrieves a building by ID with validation
@@ -36,7 +48,11 @@ func (s *Service) GetBuilding(id uint) (*building.Building, error) {
 }
 
 // CreateBuilding creates a new building with validation
-func (s *Service) CreateBuilding(b *building.Building) error {
+func (s *Service) CreateBuilding(actor *auth.User, b *building.Building) error {
+	if err := actor.CheckBuilding(0); err != nil {
+		return err
+	}
+
 	if strings.TrimSpace(b.Name) == "" {
 		return fmt.Errorf("%w: building name cannot be empty", common.ErrInvalidInput)
 	}
@@ -53,14 +69,25 @@ func (s *Service) CreateBuilding(b *building.Building) error {
 		return fmt.Errorf("%w: building with code '%s' already exists", common.ErrConflict, b.Code)
 	}
 
-	return s.repo.CreateBuilding(b)
+	return s.uow.Do(func(repo building.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.CreateBuilding(b); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionCreate, audit.EntityBuilding, b.ID, nil, b)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.BuildingCreated{Building: snapshot(*b)})
+	})
 }
 
 // UpdateBuilding updates an existing building with validation
-func (s *Service) UpdateBuilding(b *building.Building) error {
+func (s *Service) UpdateBuilding(actor *auth.User, b *building.Building) error {
 	if b.ID == 0 {
 		return fmt.Errorf("%w: building ID cannot be zero for update", common.ErrInvalidInput)
 	}
+	if err := actor.CheckBuilding(b.ID); err != nil {
+		return err
+	}
 
 	if strings.TrimSpace(b.Name) == "" {
 		return fmt.Errorf("%w: building name cannot be empty", common.ErrInvalidInput)
@@ -70,27 +97,73 @@ func (s *Service) UpdateBuilding(b *building.Building) error {
 		return fmt.Errorf("%w: building code cannot be empty", common.ErrInvalidInput)
 	}
 
-	existing, err := s.repo.FindBuildingByCode(b.Code)
+	duplicate, err := s.repo.FindBuildingByCode(b.Code)
 	if err != nil {
 		return fmt.Errorf("failed to check for duplicate code: %w", err)
 	}
-	if existing != nil && existing.ID != b.ID {
+	if duplicate != nil && duplicate.ID != b.ID {
 		return fmt.Errorf("%w: building with code '%s' already exists", common.ErrConflict, b.Code)
 	}
 
-	return s.repo.UpdateBuilding(b)
+	existing, err := s.repo.ReadBuilding(b.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repo building.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.UpdateBuilding(b); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionUpdate, audit.EntityBuilding, b.ID, existing, b)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.BuildingUpdated{Building: snapshot(*b)})
+	})
 }
 
-// DeleteBuilding removes a building by ID
-func (s *Service) DeleteBuilding(id uint) error {
+// DeleteBuilding removes a building by ID.
+// A building that still has classes or resources cannot be deleted.
+func (s *Service) DeleteBuilding(actor *auth.User, id uint) error {
 	if id == 0 {
 		return fmt.Errorf("%w: building ID cannot be zero", common.ErrInvalidInput)
 	}
+	if err := actor.CheckBuilding(id); err != nil {
+		return err
+	}
 
-	_, err := s.repo.ReadBuilding(id)
+	existing, err := s.repo.ReadBuilding(id)
 	if err != nil {
 		return err
 	}
 
-	return s.repo.DeleteBuilding(id)
+	classes, err := s.classes.ReadClassListByBuilding(id)
+	if err != nil {
+		return fmt.Errorf("failed to check for classes: %w", err)
+	}
+	if len(classes) > 0 {
+		return fmt.Errorf("%w: building still has %d class(es)", common.ErrConflict, len(classes))
+	}
+
+	resources, err := s.resources.ReadResourceListByBuilding(id)
+	if err != nil {
+		return fmt.Errorf("failed to check for resources: %w", err)
+	}
+	if len(resources) > 0 {
+		return fmt.Errorf("%w: building still has %d resource(s)", common.ErrConflict, len(resources))
+	}
+
+	return s.uow.Do(func(repo building.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.DeleteBuilding(id); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionDelete, audit.EntityBuilding, id, existing, nil)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.BuildingDeleted{Building: snapshot(*existing)})
+	})
+}
+
+// snapshot returns the state of a building carried by its events
+func snapshot(b building.Building) event.Building {
+	return event.Building{ID: b.ID, Name: b.Name, Code: b.Code}
 }
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"testing"
 
+	"sarc-ng/internal/domain/audit"
 	"sarc-ng/internal/domain/building"
+	"sarc-ng/internal/domain/class"
 	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/resource"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -16,13 +20,13 @@ type MockRepository struct {
 	mock.Mock
 }
 
-// ReadBuildingList retrieves all buildings
-func (m *MockRepository) ReadBuildingList() ([]building.Building, error) {
-	args := m.Called()
+// ReadBuildingList retrieves a page of buildings
+func (m *MockRepository) ReadBuildingList(query common.Query) (*common.Page[building.Building], error) {
+	args := m.Called(query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]building.Building), args.Error(1)
+	return args.Get(0).(*common.Page[building.Building]), args.Error(1)
 }
 
 // ReadBuilding retrieves a building by ID
@@ -61,10 +65,67 @@ func (m *MockRepository) DeleteBuilding(id uint) error {
 	return args.Error(0)
 }
 
+// MockClassRepository mocks the class lookups made by the building service
+type MockClassRepository struct {
+	mock.Mock
+	class.Repository
+}
+
+// ReadClassListByBuilding retrieves the classes of a building
+func (m *MockClassRepository) ReadClassListByBuilding(buildingID uint) ([]class.Class, error) {
+	args := m.Called(buildingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]class.Class), args.Error(1)
+}
+
+// MockResourceRepository mocks the resource lookups made by the building service
+type MockResourceRepository struct {
+	mock.Mock
+	resource.Repository
+}
+
+// ReadResourceListByBuilding retrieves the resources of a building
+func (m *MockResourceRepository) ReadResourceListByBuilding(buildingID uint) ([]resource.Resource, error) {
+	args := m.Called(buildingID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]resource.Resource), args.Error(1)
+}
+
+// unitOfWork runs changes directly against the mock repository
+type unitOfWork struct {
+	repo building.Repository
+}
+
+// Do runs fn with the mock repository, an outbox that drops the events and a
+// trail that drops the audit entries
+func (u unitOfWork) Do(fn func(repo building.Repository, events event.Outbox, trail audit.Trail) error) error {
+	return fn(u.repo, discardOutbox{}, discardTrail{})
+}
+
+// discardOutbox drops the recorded events
+type discardOutbox struct{}
+
+// RecordEvents does nothing
+func (discardOutbox) RecordEvents(...event.Event) error {
+	return nil
+}
+
+// discardTrail drops the recorded audit entries
+type discardTrail struct{}
+
+// RecordAuditEntries does nothing
+func (discardTrail) RecordAuditEntries(...audit.Entry) error {
+	return nil
+}
+
 func TestGetBuilding(t *testing.T) {
 	t.Run("Valid ID returns building", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		expectedBuilding := &building.Building{
 			ID:   1,
@@ -83,7 +144,7 @@ func TestGetBuilding(t *testing.T) {
 
 	t.Run("Zero ID returns error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		result, err := service.GetBuilding(0)
 
@@ -94,7 +155,7 @@ func TestGetBuilding(t *testing.T) {
 
 	t.Run("Not found returns error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		mockRepo.On("ReadBuilding", uint(999)).Return(nil, fmt.Errorf("not found: %w", common.ErrNotFound))
 
@@ -109,7 +170,7 @@ func TestGetBuilding(t *testing.T) {
 func TestCreateBuilding(t *testing.T) {
 	t.Run("Valid building is created", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		newBuilding := &building.Building{
 			Name: "New Building",
@@ -119,7 +180,7 @@ func TestCreateBuilding(t *testing.T) {
 		mockRepo.On("FindBuildingByCode", "NB01").Return(nil, nil)
 		mockRepo.On("CreateBuilding", newBuilding).Return(nil)
 
-		err := service.CreateBuilding(newBuilding)
+		err := service.CreateBuilding(nil, newBuilding)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -127,14 +188,14 @@ func TestCreateBuilding(t *testing.T) {
 
 	t.Run("Empty name returns error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		invalidBuilding := &building.Building{
 			Name: "  ",
 			Code: "NB01",
 		}
 
-		err := service.CreateBuilding(invalidBuilding)
+		err := service.CreateBuilding(nil, invalidBuilding)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrInvalidInput)
@@ -143,14 +204,14 @@ func TestCreateBuilding(t *testing.T) {
 
 	t.Run("Empty code returns error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		invalidBuilding := &building.Building{
 			Name: "New Building",
 			Code: "  ",
 		}
 
-		err := service.CreateBuilding(invalidBuilding)
+		err := service.CreateBuilding(nil, invalidBuilding)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrInvalidInput)
@@ -159,7 +220,7 @@ func TestCreateBuilding(t *testing.T) {
 
 	t.Run("Duplicate code returns conflict error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		existingBuilding := &building.Building{
 			ID:   1,
@@ -174,7 +235,7 @@ func TestCreateBuilding(t *testing.T) {
 
 		mockRepo.On("FindBuildingByCode", "EB01").Return(existingBuilding, nil)
 
-		err := service.CreateBuilding(newBuilding)
+		err := service.CreateBuilding(nil, newBuilding)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrConflict)
@@ -186,7 +247,7 @@ func TestCreateBuilding(t *testing.T) {
 func TestUpdateBuilding(t *testing.T) {
 	t.Run("Valid update succeeds", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		updateBuilding := &building.Building{
 			ID:   1,
@@ -195,9 +256,10 @@ func TestUpdateBuilding(t *testing.T) {
 		}
 
 		mockRepo.On("FindBuildingByCode", "UB01").Return(nil, nil)
+		mockRepo.On("ReadBuilding", uint(1)).Return(&building.Building{ID: 1, Name: "Building", Code: "UB01"}, nil)
 		mockRepo.On("UpdateBuilding", updateBuilding).Return(nil)
 
-		err := service.UpdateBuilding(updateBuilding)
+		err := service.UpdateBuilding(nil, updateBuilding)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
@@ -205,7 +267,7 @@ func TestUpdateBuilding(t *testing.T) {
 
 	t.Run("Zero ID returns error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		invalidBuilding := &building.Building{
 			ID:   0,
@@ -213,7 +275,7 @@ func TestUpdateBuilding(t *testing.T) {
 			Code: "B01",
 		}
 
-		err := service.UpdateBuilding(invalidBuilding)
+		err := service.UpdateBuilding(nil, invalidBuilding)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrInvalidInput)
@@ -221,7 +283,7 @@ func TestUpdateBuilding(t *testing.T) {
 
 	t.Run("Duplicate code for different building returns error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		existingBuilding := &building.Building{
 			ID:   2,
@@ -237,7 +299,7 @@ func TestUpdateBuilding(t *testing.T) {
 
 		mockRepo.On("FindBuildingByCode", "OB01").Return(existingBuilding, nil)
 
-		err := service.UpdateBuilding(updateBuilding)
+		err := service.UpdateBuilding(nil, updateBuilding)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrConflict)
@@ -248,7 +310,9 @@ func TestUpdateBuilding(t *testing.T) {
 func TestDeleteBuilding(t *testing.T) {
 	t.Run("Valid delete succeeds", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		mockClassRepo := new(MockClassRepository)
+		mockResourceRepo := new(MockResourceRepository)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, mockClassRepo, mockResourceRepo)
 
 		existingBuilding := &building.Building{
 			ID:   1,
@@ -257,19 +321,54 @@ func TestDeleteBuilding(t *testing.T) {
 		}
 
 		mockRepo.On("ReadBuilding", uint(1)).Return(existingBuilding, nil)
+		mockClassRepo.On("ReadClassListByBuilding", uint(1)).Return([]class.Class{}, nil)
+		mockResourceRepo.On("ReadResourceListByBuilding", uint(1)).Return([]resource.Resource{}, nil)
 		mockRepo.On("DeleteBuilding", uint(1)).Return(nil)
 
-		err := service.DeleteBuilding(1)
+		err := service.DeleteBuilding(nil, 1)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
+		mockClassRepo.AssertExpectations(t)
+		mockResourceRepo.AssertExpectations(t)
+	})
+
+	t.Run("Building with classes returns conflict error", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockClassRepo := new(MockClassRepository)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, mockClassRepo, nil)
+
+		mockRepo.On("ReadBuilding", uint(1)).Return(&building.Building{ID: 1, Name: "Main", Code: "MB01"}, nil)
+		mockClassRepo.On("ReadClassListByBuilding", uint(1)).Return([]class.Class{{ID: 3, Name: "Room 101", BuildingID: 1}}, nil)
+
+		err := service.DeleteBuilding(nil, 1)
+
+		assert.ErrorIs(t, err, common.ErrConflict)
+		mockRepo.AssertNotCalled(t, "DeleteBuilding", uint(1))
+	})
+
+	t.Run("Building with resources returns conflict error", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockClassRepo := new(MockClassRepository)
+		mockResourceRepo := new(MockResourceRepository)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, mockClassRepo, mockResourceRepo)
+
+		buildingID := uint(1)
+		mockRepo.On("ReadBuilding", uint(1)).Return(&building.Building{ID: 1, Name: "Main", Code: "MB01"}, nil)
+		mockClassRepo.On("ReadClassListByBuilding", uint(1)).Return([]class.Class{}, nil)
+		mockResourceRepo.On("ReadResourceListByBuilding", uint(1)).Return([]resource.Resource{{ID: 5, Name: "Projector", BuildingID: &buildingID}}, nil)
+
+		err := service.DeleteBuilding(nil, 1)
+
+		assert.ErrorIs(t, err, common.ErrConflict)
+		mockRepo.AssertNotCalled(t, "DeleteBuilding", uint(1))
 	})
 
 	t.Run("Zero ID returns error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
-		err := service.DeleteBuilding(0)
+		err := service.DeleteBuilding(nil, 0)
 
 		assert.Error(t, err)
 		assert.ErrorIs(t, err, common.ErrInvalidInput)
@@ -277,11 +376,11 @@ func TestDeleteBuilding(t *testing.T) {
 
 	t.Run("Not found returns error", func(t *testing.T) {
 		mockRepo := new(MockRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, unitOfWork{mockRepo}, nil, nil)
 
 		mockRepo.On("ReadBuilding", uint(999)).Return(nil, fmt.Errorf("not found: %w", common.ErrNotFound))
 
-		err := service.DeleteBuilding(999)
+		err := service.DeleteBuilding(nil, 999)
 
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
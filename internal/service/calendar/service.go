@@ -0,0 +1,290 @@
+package calendar
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/building"
+	"sarc-ng/internal/domain/calendar"
+	"sarc-ng/internal/domain/class"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/resource"
+	"strings"
+	"time"
+)
+
+// feedHistory is how far back feeds include past events
+const feedHistory = 90 * 24 * time.Hour
+
+// tokenBytes is the amount of randomness in a feed token secret
+const tokenBytes = 32
+
+// Service implements calendar.Usecase interface
+type Service struct {
+	repo         calendar.Repository
+	reservations reservation.Repository
+	resources    resource.Repository
+	buildings    building.Repository
+	classes      class.Repository
+	lessons      lesson.Repository
+}
+
+// Compile-time verification that Service implements calendar.Usecase
+var _ calendar.Usecase = (*Service)(nil)
+
+// NewService creates a new calendar service
+func NewService(
+	repo calendar.Repository,
+	reservations reservation.Repository,
+	resources resource.Repository,
+	buildings building.Repository,
+	classes class.Repository,
+	lessons lesson.Repository,
+) *Service {
+	return &Service{
+		repo:         repo,
+		reservations: reservations,
+		resources:    resources,
+		buildings:    buildings,
+		classes:      classes,
+		lessons:      lessons,
+	}
+}
+
+// GetResourceFeed retrieves the reservations of a resource, anonymous unless
+// the actor is a manager
+func (s *Service) GetResourceFeed(actor *auth.User, resourceID uint) (*calendar.Feed, error) {
+	if resourceID == 0 {
+		return nil, fmt.Errorf("%w: resource ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	res, err := s.resources.ReadResource(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.reservations.ReadReservationListByResource(resourceID, feedStart(), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &calendar.Feed{
+		Name:         res.Name,
+		Reservations: reservations,
+		Resources:    map[uint]resource.Resource{res.ID: *res},
+	}
+	if !seesDetails(actor) {
+		feed.Anonymize()
+	}
+	return feed, nil
+}
+
+// GetBuildingFeed retrieves the reservations of every resource kept in a building
+// and the lessons held in its classes, anonymous unless the actor is a manager
+func (s *Service) GetBuildingFeed(actor *auth.User, buildingID uint) (*calendar.Feed, error) {
+	if buildingID == 0 {
+		return nil, fmt.Errorf("%w: building ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	b, err := s.buildings.ReadBuilding(buildingID)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := s.resources.ReadResourceListByBuilding(buildingID)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &calendar.Feed{
+		Name:      b.Name,
+		Resources: make(map[uint]resource.Resource),
+	}
+	from := feedStart()
+	for _, res := range resources {
+		reservations, err := s.reservations.ReadReservationListByResource(res.ID, from, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		feed.Reservations = append(feed.Reservations, reservations...)
+		feed.Resources[res.ID] = res
+	}
+
+	classes, err := s.classes.ReadClassListByBuilding(buildingID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range classes {
+		lessons, err := s.lessons.ReadLessonListByClass(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range lessons {
+			if l.EndTime.After(from) {
+				feed.Lessons = append(feed.Lessons, l)
+			}
+		}
+	}
+	if !seesDetails(actor) {
+		feed.Anonymize()
+	}
+	return feed, nil
+}
+
+// seesDetails reports whether the actor may see who booked what in public
+// feeds. Anonymous callers may not.
+func seesDetails(actor *auth.User) bool {
+	return actor != nil && actor.IsManager()
+}
+
+// GetLessonFeed retrieves all recent and upcoming lessons
+func (s *Service) GetLessonFeed() (*calendar.Feed, error) {
+	query := common.Query{Sort: "startTime"}.Where("endTime", common.OpGreaterOrEqual, feedStart())
+	lessons, err := s.lessons.ReadLessonList(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &calendar.Feed{Name: "Lessons", Lessons: lessons.Items}, nil
+}
+
+// GetUserFeed retrieves the reservations of a user after checking the feed token
+func (s *Service) GetUserFeed(userID string, token string) (*calendar.Feed, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user ID cannot be empty", common.ErrInvalidInput)
+	}
+
+	feedToken, err := s.authenticate(userID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, err := s.reservations.ReadReservationListByUser(userID, feedStart())
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &calendar.Feed{
+		Name:         "My reservations",
+		Reservations: reservations,
+		Resources:    make(map[uint]resource.Resource),
+	}
+	for _, r := range reservations {
+		if _, ok := feed.Resources[r.ResourceID]; ok {
+			continue
+		}
+		res, err := s.resources.ReadResource(r.ResourceID)
+		if errors.Is(err, common.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		feed.Resources[res.ID] = *res
+	}
+
+	now := time.Now()
+	feedToken.LastUsedAt = &now
+	if err := s.repo.UpdateFeedToken(feedToken); err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+// CreateFeedToken issues a new feed token for a user
+func (s *Service) CreateFeedToken(userID string, label string) (*calendar.FeedToken, string, error) {
+	if userID == "" {
+		return nil, "", fmt.Errorf("%w: user ID cannot be empty", common.ErrInvalidInput)
+	}
+
+	label = strings.TrimSpace(label)
+	if len(label) > 100 {
+		return nil, "", fmt.Errorf("%w: label cannot be longer than 100 characters", common.ErrInvalidInput)
+	}
+
+	secret := make([]byte, tokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate feed token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	feedToken := &calendar.FeedToken{
+		UserID:    userID,
+		Label:     label,
+		TokenHash: hashToken(token),
+	}
+	if err := s.repo.CreateFeedToken(feedToken); err != nil {
+		return nil, "", err
+	}
+
+	return feedToken, token, nil
+}
+
+// GetFeedTokens retrieves the feed tokens of a user
+func (s *Service) GetFeedTokens(userID string) ([]calendar.FeedToken, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user ID cannot be empty", common.ErrInvalidInput)
+	}
+	return s.repo.ReadFeedTokenList(userID)
+}
+
+// RevokeFeedToken revokes a feed token so feeds using it stop working
+func (s *Service) RevokeFeedToken(userID string, tokenID uint) error {
+	if userID == "" || tokenID == 0 {
+		return fmt.Errorf("%w: user and token ID are required", common.ErrInvalidInput)
+	}
+
+	feedToken, err := s.repo.ReadFeedToken(tokenID)
+	if err != nil {
+		return err
+	}
+	if feedToken.UserID != userID {
+		return fmt.Errorf("%w: feed token not found", common.ErrNotFound)
+	}
+	if feedToken.IsRevoked() {
+		return fmt.Errorf("%w: feed token is already revoked", common.ErrConflict)
+	}
+
+	now := time.Now()
+	feedToken.RevokedAt = &now
+	return s.repo.UpdateFeedToken(feedToken)
+}
+
+// authenticate returns the feed token matching the secret if it is active and belongs to the user
+func (s *Service) authenticate(userID string, token string) (*calendar.FeedToken, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: feed token is required", common.ErrUnauthorized)
+	}
+
+	feedToken, err := s.repo.ReadFeedTokenByHash(hashToken(token))
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, fmt.Errorf("%w: invalid feed token", common.ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A token of another user is reported like an unknown one
+	if feedToken.UserID != userID || feedToken.IsRevoked() {
+		return nil, fmt.Errorf("%w: invalid feed token", common.ErrUnauthorized)
+	}
+	return feedToken, nil
+}
+
+// hashToken returns the hex SHA-256 digest stored for a token secret
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// feedStart returns the earliest end time of events included in feeds
+func feedStart() time.Time {
+	return time.Now().Add(-feedHistory)
+}
@@ -0,0 +1,148 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	calendarAdapter "sarc-ng/internal/adapter/gorm/calendar"
+	classAdapter "sarc-ng/internal/adapter/gorm/class"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	lessonAdapter "sarc-ng/internal/adapter/gorm/lesson"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/calendar"
+	"sarc-ng/internal/domain/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var manager = &auth.User{ID: "user-3", Groups: []string{"manager"}}
+
+func newTestService(t *testing.T) (*Service, *gorm.DB) {
+	db := gormtest.Open(t,
+		&calendarAdapter.FeedTokenGormModel{},
+		&buildingAdapter.GormModel{},
+		&classAdapter.GormModel{},
+		&lessonAdapter.GormModel{},
+		&reservationAdapter.GormModel{},
+		&resourceAdapter.GormModel{},
+	)
+	service := NewService(
+		calendarAdapter.NewGormAdapter(db),
+		reservationAdapter.NewGormAdapter(db),
+		resourceAdapter.NewGormAdapter(db),
+		buildingAdapter.NewGormAdapter(db),
+		classAdapter.NewGormAdapter(db),
+		lessonAdapter.NewGormAdapter(db),
+	)
+	return service, db
+}
+
+func TestBuildingFeed(t *testing.T) {
+	service, db := newTestService(t)
+
+	main := &buildingAdapter.GormModel{Name: "Main", Code: "MAIN"}
+	annex := &buildingAdapter.GormModel{Name: "Annex", Code: "ANX"}
+	require.NoError(t, db.Create(main).Error)
+	require.NoError(t, db.Create(annex).Error)
+	room := &classAdapter.GormModel{Name: "Room 101", Capacity: 30, BuildingID: &main.ID}
+	require.NoError(t, db.Create(room).Error)
+
+	lab := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true, BuildingID: &main.ID, ClassID: &room.ID}
+	// The free-form location no longer decides the building
+	elsewhere := &resourceAdapter.GormModel{Name: "Van", Type: "vehicle", IsAvailable: true, BuildingID: &annex.ID, Location: "MAIN"}
+	require.NoError(t, db.Create(lab).Error)
+	require.NoError(t, db.Create(elsewhere).Error)
+
+	start := time.Now().Add(24 * time.Hour)
+	require.NoError(t, db.Create(&[]reservationAdapter.GormModel{
+		{ResourceID: lab.ID, UserID: "user-1", StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Lab session"},
+		{ResourceID: elsewhere.ID, UserID: "user-1", StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Field trip"},
+	}).Error)
+	require.NoError(t, db.Create(&lessonAdapter.GormModel{
+		Title: "Algorithms", Duration: 60, StartTime: start, EndTime: start.Add(time.Hour), ClassID: &room.ID,
+	}).Error)
+
+	feed, err := service.GetBuildingFeed(manager, main.ID)
+	require.NoError(t, err)
+	require.Len(t, feed.Reservations, 1)
+	assert.Equal(t, "Lab session", feed.Reservations[0].Purpose)
+	require.Len(t, feed.Lessons, 1)
+	assert.Equal(t, "Algorithms", feed.Lessons[0].Title)
+}
+
+func TestPublicFeedsAreAnonymous(t *testing.T) {
+	service, db := newTestService(t)
+
+	main := &buildingAdapter.GormModel{Name: "Main", Code: "MAIN"}
+	require.NoError(t, db.Create(main).Error)
+	lab := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true, BuildingID: &main.ID}
+	require.NoError(t, db.Create(lab).Error)
+	start := time.Now().Add(24 * time.Hour)
+	require.NoError(t, db.Create(&[]reservationAdapter.GormModel{
+		{ResourceID: lab.ID, UserID: "user-2", StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Job interview", Description: "Room 3, ask for Ana", Status: "approved"},
+		{ResourceID: lab.ID, UserID: "user-2", StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour), Purpose: "Therapy group", Status: "rejected", StatusReason: "Not a teaching use"},
+	}).Error)
+
+	for _, actor := range []*auth.User{nil, {ID: "user-1"}} {
+		resourceFeed, err := service.GetResourceFeed(actor, lab.ID)
+		require.NoError(t, err)
+		buildingFeed, err := service.GetBuildingFeed(actor, main.ID)
+		require.NoError(t, err)
+
+		for _, feed := range []*calendar.Feed{resourceFeed, buildingFeed} {
+			assert.True(t, feed.Anonymous)
+			require.Len(t, feed.Reservations, 2, "busy blocks are still shown")
+			for _, r := range feed.Reservations {
+				assert.Empty(t, r.Purpose)
+				assert.Empty(t, r.Description)
+				assert.Empty(t, r.StatusReason)
+				assert.Empty(t, r.UserID)
+			}
+		}
+	}
+
+	feed, err := service.GetResourceFeed(manager, lab.ID)
+	require.NoError(t, err)
+	assert.False(t, feed.Anonymous)
+	assert.Equal(t, "Job interview", feed.Reservations[0].Purpose)
+}
+
+func TestUserFeedToken(t *testing.T) {
+	service, db := newTestService(t)
+
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	start := time.Now().Add(24 * time.Hour)
+	require.NoError(t, db.Create(&[]reservationAdapter.GormModel{
+		{ResourceID: room.ID, UserID: "user-1", StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Mine"},
+		{ResourceID: room.ID, UserID: "user-2", StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Theirs"},
+	}).Error)
+
+	feedToken, token, err := service.CreateFeedToken("user-1", "Phone")
+	require.NoError(t, err)
+	assert.NotEqual(t, token, feedToken.TokenHash, "only the hash may be stored")
+
+	feed, err := service.GetUserFeed("user-1", token)
+	require.NoError(t, err)
+	require.Len(t, feed.Reservations, 1)
+	assert.Equal(t, "Mine", feed.Reservations[0].Purpose)
+	assert.Equal(t, "Lab 1", feed.Resources[room.ID].Name)
+
+	_, err = service.GetUserFeed("user-2", token)
+	assert.ErrorIs(t, err, common.ErrUnauthorized, "token of another user")
+
+	_, err = service.GetUserFeed("user-1", "guessed")
+	assert.ErrorIs(t, err, common.ErrUnauthorized)
+
+	assert.ErrorIs(t, service.RevokeFeedToken("user-2", feedToken.ID), common.ErrNotFound)
+	require.NoError(t, service.RevokeFeedToken("user-1", feedToken.ID))
+	assert.ErrorIs(t, service.RevokeFeedToken("user-1", feedToken.ID), common.ErrConflict)
+
+	_, err = service.GetUserFeed("user-1", token)
+	assert.ErrorIs(t, err, common.ErrUnauthorized, "revoked token")
+}
@@ -0,0 +1,77 @@
+package checkin
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/resource"
+	"time"
+)
+
+// tokenBytes is the amount of randomness in a check-in token secret
+const tokenBytes = 32
+
+// Service implements checkin.Usecase interface
+type Service struct {
+	repo      checkin.Repository
+	resources resource.Repository
+}
+
+// Compile-time verification that Service implements checkin.Usecase
+var _ checkin.Usecase = (*Service)(nil)
+
+// NewService creates a new check-in service
+func NewService(repo checkin.Repository, resources resource.Repository) *Service {
+	return &Service{
+		repo:      repo,
+		resources: resources,
+	}
+}
+
+// IssueCheckInToken issues a new QR code token for a resource, revoking its earlier ones
+func (s *Service) IssueCheckInToken(issuer *auth.User, resourceID uint) (*checkin.Token, string, error) {
+	if issuer == nil {
+		return nil, "", fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+	if resourceID == 0 {
+		return nil, "", fmt.Errorf("%w: resource ID cannot be zero", common.ErrInvalidInput)
+	}
+	r, err := s.resources.ReadResource(resourceID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := issuer.CheckBuilding(r.Building()); err != nil {
+		return nil, "", err
+	}
+
+	secret := make([]byte, tokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate check-in token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	if err := s.repo.RevokeCheckInTokens(resourceID, time.Now()); err != nil {
+		return nil, "", err
+	}
+	checkInToken := &checkin.Token{
+		ResourceID: resourceID,
+		TokenHash:  checkin.HashToken(token),
+		IssuedBy:   issuer.ID,
+	}
+	if err := s.repo.CreateCheckInToken(checkInToken); err != nil {
+		return nil, "", err
+	}
+
+	return checkInToken, token, nil
+}
+
+// GetNoShows retrieves the no-shows of a user, most recent first
+func (s *Service) GetNoShows(userID string) ([]checkin.NoShow, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user ID cannot be empty", common.ErrInvalidInput)
+	}
+	return s.repo.ReadNoShowList(userID)
+}
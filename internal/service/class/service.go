@@ -1,30 +1,52 @@
 package class
 
 import (
+	"errors"
 	"fmt"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/building"
 	"sarc-ng/internal/domain/class"
 	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/internal/domain/resource"
 	"strings"
 )
 
 // Service implements class.Usecase interface
 type Service struct {
-	repo class.Repository
+	repo      class.Repository
+	uow       class.UnitOfWork
+	buildings building.Repository
+	resources resource.Repository
+	lessons   lesson.Repository
 }
 
 // Compile-time verification that Service implements class.Usecase
 var _ class.Usecase = (*Service)(nil)
 
-// NewService creates a new class service
-func NewService(repo class.Repository) *Service {
+// NewService creates a new class service. Every change is written through the
+// unit of work together with its audit entry.
+func NewService(
+	repo class.Repository,
+	uow class.UnitOfWork,
+	buildings building.Repository,
+	resources resource.Repository,
+	lessons lesson.Repository,
+) *Service {
 	return &Service{
-		repo: repo,
+		repo:      repo,
+		uow:       uow,
+		buildings: buildings,
+		resources: resources,
+		lessons:   lessons,
 	}
 }
 
-// GetAllClasses retrieves all classes
-func (s *Service) GetAllClasses() ([]class.Class, error) {
-	return s.repo.ReadClassList()
+// GetAllClasses retrieves the page of classes selected by the query
+func (s *Service) GetAllClasses(query common.Query) (*common.Page[class.Class], error) {
+	return s.repo.ReadClassList(query)
 }
 
 // GetClass retrieves a class by ID with validation
@@ -35,8 +57,21 @@ func (s *Service) GetClass(id uint) (*class.Class, error) {
 	return s.repo.ReadClass(id)
 }
 
+// GetClassesByBuilding retrieves the classes of a building
+func (s *Service) GetClassesByBuilding(buildingID uint) ([]class.Class, error) {
+	if buildingID == 0 {
+		return nil, fmt.Errorf("%w: building ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	if _, err := s.buildings.ReadBuilding(buildingID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadClassListByBuilding(buildingID)
+}
+
 // CreateClass creates a new class with validation
-func (s *Service) CreateClass(c *class.Class) error {
+func (s *Service) CreateClass(actor *auth.User, c *class.Class) error {
 	// Validate name
 	if strings.TrimSpace(c.Name) == "" {
 		return fmt.Errorf("%w: class name cannot be empty", common.ErrInvalidInput)
@@ -47,11 +82,23 @@ func (s *Service) CreateClass(c *class.Class) error {
 		return fmt.Errorf("%w: class capacity must be greater than zero", common.ErrInvalidInput)
 	}
 
-	return s.repo.CreateClass(c)
+	if err := s.validateBuilding(c.BuildingID); err != nil {
+		return err
+	}
+	if err := actor.CheckBuilding(c.BuildingID); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repo class.Repository, _ resource.Repository, _ event.Outbox, trail audit.Trail) error {
+		if err := repo.CreateClass(c); err != nil {
+			return err
+		}
+		return trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionCreate, audit.EntityClass, c.ID, nil, c))
+	})
 }
 
 // UpdateClass updates an existing class with validation
-func (s *Service) UpdateClass(c *class.Class) error {
+func (s *Service) UpdateClass(actor *auth.User, c *class.Class) error {
 	if c.ID == 0 {
 		return fmt.Errorf("%w: class ID cannot be zero for update", common.ErrInvalidInput)
 	}
@@ -66,19 +113,122 @@ func (s *Service) UpdateClass(c *class.Class) error {
 		return fmt.Errorf("%w: class capacity must be greater than zero", common.ErrInvalidInput)
 	}
 
-	return s.repo.UpdateClass(c)
+	if err := s.validateBuilding(c.BuildingID); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.ReadClass(c.ID)
+	if err != nil {
+		return err
+	}
+	// Moving a class needs access to both buildings
+	if err := actor.CheckBuilding(existing.BuildingID); err != nil {
+		return err
+	}
+	if err := actor.CheckBuilding(c.BuildingID); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repo class.Repository, resources resource.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.UpdateClass(c); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionUpdate, audit.EntityClass, c.ID, existing, c)); err != nil {
+			return err
+		}
+
+		// Resources kept in the class move along with it
+		if existing.BuildingID == c.BuildingID {
+			return nil
+		}
+		return moveResources(resources, events, trail, actor, c)
+	})
 }
 
 // DeleteClass removes a class by ID
-func (s *Service) DeleteClass(id uint) error {
+func (s *Service) DeleteClass(actor *auth.User, id uint) error {
 	if id == 0 {
 		return fmt.Errorf("%w: class ID cannot be zero", common.ErrInvalidInput)
 	}
 
-	_, err := s.repo.ReadClass(id)
+	existing, err := s.repo.ReadClass(id)
+	if err != nil {
+		return err
+	}
+	if err := actor.CheckBuilding(existing.BuildingID); err != nil {
+		return err
+	}
+
+	resources, err := s.resources.ReadResourceListByClass(id)
+	if err != nil {
+		return fmt.Errorf("failed to check for resources: %w", err)
+	}
+	if len(resources) > 0 {
+		return fmt.Errorf("%w: class still has %d resource(s)", common.ErrConflict, len(resources))
+	}
+
+	lessons, err := s.lessons.ReadLessonListByClass(id)
+	if err != nil {
+		return fmt.Errorf("failed to check for lessons: %w", err)
+	}
+	if len(lessons) > 0 {
+		return fmt.Errorf("%w: class still has %d lesson(s)", common.ErrConflict, len(lessons))
+	}
+
+	return s.uow.Do(func(repo class.Repository, _ resource.Repository, _ event.Outbox, trail audit.Trail) error {
+		if err := repo.DeleteClass(id); err != nil {
+			return err
+		}
+		return trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionDelete, audit.EntityClass, id, existing, nil))
+	})
+}
+
+// moveResources moves the resources kept in a class to the class's building,
+// recording an audit entry and a ResourceUpdated event for each of them
+func moveResources(resources resource.Repository, events event.Outbox, trail audit.Trail, actor *auth.User, c *class.Class) error {
+	moved, err := resources.ReadResourceListByClass(c.ID)
 	if err != nil {
+		return fmt.Errorf("failed to read the class resources: %w", err)
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+	if err := resources.UpdateResourceBuildingByClass(c.ID, c.BuildingID); err != nil {
+		return fmt.Errorf("failed to move the class resources: %w", err)
+	}
+
+	entries := make([]audit.Entry, len(moved))
+	updates := make([]event.Event, len(moved))
+	for i, before := range moved {
+		after := before
+		after.BuildingID = &c.BuildingID
+		entries[i] = audit.NewEntry(actor, audit.ActionUpdate, audit.EntityResource, after.ID, &before, &after)
+		updates[i] = event.ResourceUpdated{Resource: event.Resource{
+			ID:          after.ID,
+			Name:        after.Name,
+			Type:        after.Type,
+			IsAvailable: after.IsAvailable,
+			BuildingID:  after.BuildingID,
+			ClassID:     after.ClassID,
+		}}
+	}
+	if err := trail.RecordAuditEntries(entries...); err != nil {
 		return err
 	}
+	return events.RecordEvents(updates...)
+}
+
+// validateBuilding checks that a class refers to an existing building
+func (s *Service) validateBuilding(buildingID uint) error {
+	if buildingID == 0 {
+		return fmt.Errorf("%w: class building is required", common.ErrInvalidInput)
+	}
 
-	return s.repo.DeleteClass(id)
+	if _, err := s.buildings.ReadBuilding(buildingID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return fmt.Errorf("%w: building %d does not exist", common.ErrInvalidInput, buildingID)
+		}
+		return err
+	}
+	return nil
 }
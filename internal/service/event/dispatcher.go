@@ -0,0 +1,42 @@
+package event
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Dispatcher delivers outbox messages shortly after they are recorded, so
+// integrations do not wait for the next scheduled dispatch job
+type Dispatcher struct {
+	service  *Service
+	interval time.Duration
+}
+
+// NewDispatcher creates a dispatcher polling the outbox at the given interval
+func NewDispatcher(service *Service, interval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Start dispatches events until the context is cancelled. It blocks, so
+// callers run it in its own goroutine.
+func (d *Dispatcher) Start(ctx context.Context) {
+	log.Printf("Event dispatcher started, polling every %s", d.interval)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Event dispatcher stopped")
+			return
+		case <-ticker.C:
+			if _, err := d.service.DispatchEvents(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Failed to dispatch events: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"strings"
+	"time"
+)
+
+// claimLease is how long a dispatcher holds a message while delivering it.
+// A dispatcher that crashes mid-delivery leaves the message to be picked up
+// again once the lease runs out.
+const claimLease = time.Minute
+
+// deliveryTimeout bounds the delivery of a message to every sink, so it ends
+// well before the claim lease and no other dispatcher picks the message up
+// while it is still being delivered. Sinks still cut off fail and are retried.
+const deliveryTimeout = claimLease / 2
+
+// Service implements event.Usecase interface
+type Service struct {
+	repo     event.Repository
+	sinks    []event.Sink
+	settings event.Settings
+}
+
+// Compile-time verification that Service implements event.Usecase
+var _ event.Usecase = (*Service)(nil)
+
+// NewService creates a new event service delivering outbox messages to the sinks
+func NewService(repo event.Repository, sinks []event.Sink, settings event.Settings) *Service {
+	return &Service{
+		repo:     repo,
+		sinks:    sinks,
+		settings: settings,
+	}
+}
+
+// GetOutboxMessages retrieves the page of outbox messages selected by the query
+func (s *Service) GetOutboxMessages(query common.Query) (*common.Page[event.Message], error) {
+	return s.repo.ReadOutboxMessageList(query)
+}
+
+// GetOutboxMessage retrieves an outbox message by ID
+func (s *Service) GetOutboxMessage(id uint) (*event.Message, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: message ID cannot be zero", common.ErrInvalidInput)
+	}
+	return s.repo.ReadOutboxMessage(id)
+}
+
+// RetryOutboxMessage makes a dead message pending again with a fresh round of attempts
+func (s *Service) RetryOutboxMessage(id uint) (*event.Message, error) {
+	m, err := s.GetOutboxMessage(id)
+	if err != nil {
+		return nil, err
+	}
+	if m.Status != event.StatusDead {
+		return nil, fmt.Errorf("%w: only %s messages can be retried, message is %s", common.ErrConflict, event.StatusDead, m.Status)
+	}
+
+	m.Status = event.StatusPending
+	m.Attempts = 0
+	m.NextAttemptAt = time.Now()
+	m.LockedUntil = nil
+	if err := s.repo.UpdateOutboxMessage(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DispatchEvents delivers the due messages, oldest first, and returns how
+// many were delivered to every sink
+func (s *Service) DispatchEvents(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := s.repo.FindDueOutboxMessages(now, s.settings.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i := range due {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+
+		m := &due[i]
+		claimed, err := s.repo.ClaimOutboxMessage(m.ID, now, time.Now().Add(claimLease))
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			// Another dispatcher is delivering it
+			continue
+		}
+
+		deliverCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+		ok := s.deliver(deliverCtx, m)
+		cancel()
+		if ok {
+			delivered++
+		}
+		m.LockedUntil = nil
+		if err := s.repo.UpdateOutboxMessage(m); err != nil {
+			return delivered, fmt.Errorf("failed to record delivery of message %d: %w", m.ID, err)
+		}
+	}
+	return delivered, nil
+}
+
+// deliver hands the message to every sink that has not accepted it yet and
+// updates its status. It reports whether the message is now delivered.
+func (s *Service) deliver(ctx context.Context, m *event.Message) bool {
+	var failures []error
+	for _, sink := range s.sinks {
+		if m.IsDeliveredTo(sink.Name()) {
+			continue
+		}
+		if err := sink.Deliver(ctx, *m); err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", sink.Name(), err))
+			continue
+		}
+		m.DeliveredTo = append(m.DeliveredTo, sink.Name())
+	}
+
+	m.Attempts++
+	if len(failures) == 0 {
+		now := time.Now()
+		m.Status = event.StatusDelivered
+		m.DeliveredAt = &now
+		m.LastError = ""
+		return true
+	}
+
+	m.LastError = errors.Join(failures...).Error()
+	if m.Attempts >= s.settings.MaxAttempts {
+		m.Status = event.StatusDead
+		log.Printf("Giving up on %s event %d after %d attempts: %s",
+			m.Type, m.ID, m.Attempts, strings.ReplaceAll(m.LastError, "\n", "; "))
+		return false
+	}
+	m.NextAttemptAt = time.Now().Add(s.settings.Backoff(m.Attempts))
+	return false
+}
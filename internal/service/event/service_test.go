@@ -0,0 +1,243 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"sarc-ng/internal/adapter/eventsink"
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/resource"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakySink fails until it is told to accept messages
+type flakySink struct {
+	accept bool
+	calls  int
+}
+
+func (s *flakySink) Name() string { return "flaky" }
+
+func (s *flakySink) Deliver(context.Context, event.Message) error {
+	s.calls++
+	if !s.accept {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+// deadlineSink records the deadline its deliveries are given
+type deadlineSink struct {
+	deadline time.Time
+	ok       bool
+}
+
+func (s *deadlineSink) Name() string { return "deadline" }
+
+func (s *deadlineSink) Deliver(ctx context.Context, _ event.Message) error {
+	s.deadline, s.ok = ctx.Deadline()
+	return nil
+}
+
+func TestDeliveryEndsWithinTheClaimLease(t *testing.T) {
+	db := gormtest.Open(t, &eventAdapter.OutboxGormModel{})
+	sink := &deadlineSink{}
+	service := NewService(eventAdapter.NewGormAdapter(db), []event.Sink{sink}, event.Settings{BatchSize: 10, MaxAttempts: 3})
+	require.NoError(t, eventAdapter.NewOutbox(db).RecordEvents(event.ReservationCreated{Reservation: event.Reservation{ID: 7}}))
+
+	claimed := time.Now()
+	delivered, err := service.DispatchEvents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	require.True(t, sink.ok, "sinks are given a deadline")
+	assert.True(t, sink.deadline.Before(claimed.Add(claimLease)), "the deadline falls before another dispatcher may claim the message")
+}
+
+func TestOutboxIsWrittenWithTheChange(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{})
+	uow := resourceAdapter.NewUnitOfWork(db)
+	repo := eventAdapter.NewGormAdapter(db)
+
+	projector := &resource.Resource{Name: "Projector", Type: "equipment", IsAvailable: true}
+	err := uow.Do(func(resources resource.Repository, events event.Outbox, _ audit.Trail) error {
+		if err := resources.CreateResource(projector); err != nil {
+			return err
+		}
+		if err := events.RecordEvents(event.ResourceCreated{Resource: event.Resource{ID: projector.ID, Name: projector.Name}}); err != nil {
+			return err
+		}
+		return errors.New("validation failed after the write")
+	})
+	require.Error(t, err)
+
+	messages, err := repo.ReadOutboxMessageList(common.Query{})
+	require.NoError(t, err)
+	assert.Empty(t, messages.Items, "a rolled back change records no event")
+
+	err = uow.Do(func(resources resource.Repository, events event.Outbox, _ audit.Trail) error {
+		if err := resources.CreateResource(projector); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.ResourceCreated{Resource: event.Resource{ID: projector.ID, Name: projector.Name}})
+	})
+	require.NoError(t, err)
+
+	messages, err = repo.ReadOutboxMessageList(common.Query{})
+	require.NoError(t, err)
+	require.Len(t, messages.Items, 1)
+	m := messages.Items[0]
+	assert.Equal(t, event.TypeResourceCreated, m.Type)
+	assert.Equal(t, "resource", m.AggregateType)
+	assert.Equal(t, projector.ID, m.AggregateID)
+	assert.Equal(t, event.StatusPending, m.Status)
+
+	var payload event.Resource
+	require.NoError(t, json.Unmarshal(m.Payload, &payload))
+	assert.Equal(t, "Projector", payload.Name)
+}
+
+func TestDispatchEvents(t *testing.T) {
+	db := gormtest.Open(t, &eventAdapter.OutboxGormModel{})
+	repo := eventAdapter.NewGormAdapter(db)
+	outbox := eventAdapter.NewOutbox(db)
+
+	var received []event.Type
+	inProcess := eventsink.NewInProcess()
+	inProcess.Subscribe(func(_ context.Context, m event.Message) error {
+		received = append(received, m.Type)
+		return nil
+	}, event.TypeReservationCreated)
+	flaky := &flakySink{}
+
+	settings := event.Settings{BatchSize: 10, MaxAttempts: 3, RetryBackoff: time.Minute}
+	service := NewService(repo, []event.Sink{inProcess, flaky}, settings)
+	ctx := context.Background()
+
+	// makeDue moves the retry of a message to the past
+	makeDue := func(id uint) {
+		require.NoError(t, db.Model(&eventAdapter.OutboxGormModel{}).Where("id = ?", id).
+			Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+	}
+
+	require.NoError(t, outbox.RecordEvents(event.ReservationCreated{Reservation: event.Reservation{ID: 7, Status: "pending"}}))
+
+	t.Run("Failed sinks are retried with backoff", func(t *testing.T) {
+		delivered, err := service.DispatchEvents(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, []event.Type{event.TypeReservationCreated}, received)
+
+		m, err := service.GetOutboxMessage(1)
+		require.NoError(t, err)
+		assert.Equal(t, event.StatusPending, m.Status)
+		assert.Equal(t, 1, m.Attempts)
+		assert.Equal(t, []string{"in-process"}, m.DeliveredTo)
+		assert.Contains(t, m.LastError, "connection refused")
+		assert.WithinDuration(t, time.Now().Add(time.Minute), m.NextAttemptAt, 5*time.Second)
+		assert.Nil(t, m.LockedUntil)
+
+		// Not due until the backoff has passed
+		delivered, err = service.DispatchEvents(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, 1, flaky.calls)
+	})
+
+	t.Run("Sinks that accepted a message do not get it again", func(t *testing.T) {
+		makeDue(1)
+		flaky.accept = true
+
+		delivered, err := service.DispatchEvents(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Len(t, received, 1)
+
+		m, err := service.GetOutboxMessage(1)
+		require.NoError(t, err)
+		assert.Equal(t, event.StatusDelivered, m.Status)
+		assert.Equal(t, 2, m.Attempts)
+		assert.ElementsMatch(t, []string{"in-process", "flaky"}, m.DeliveredTo)
+		assert.NotNil(t, m.DeliveredAt)
+		assert.Empty(t, m.LastError)
+	})
+
+	t.Run("Messages are marked dead after the last attempt", func(t *testing.T) {
+		flaky.accept = false
+		require.NoError(t, outbox.RecordEvents(event.BuildingDeleted{Building: event.Building{ID: 3, Code: "OLD"}}))
+
+		for attempt := 1; attempt <= settings.MaxAttempts; attempt++ {
+			makeDue(2)
+			_, err := service.DispatchEvents(ctx)
+			require.NoError(t, err)
+		}
+
+		m, err := service.GetOutboxMessage(2)
+		require.NoError(t, err)
+		assert.Equal(t, event.StatusDead, m.Status)
+		assert.Equal(t, settings.MaxAttempts, m.Attempts)
+
+		makeDue(2)
+		calls := flaky.calls
+		_, err = service.DispatchEvents(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, calls, flaky.calls, "dead messages are not delivered")
+	})
+
+	t.Run("Dead messages can be retried", func(t *testing.T) {
+		flaky.accept = true
+
+		m, err := service.RetryOutboxMessage(2)
+		require.NoError(t, err)
+		assert.Equal(t, event.StatusPending, m.Status)
+		assert.Equal(t, 0, m.Attempts)
+
+		delivered, err := service.DispatchEvents(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+
+		_, err = service.RetryOutboxMessage(2)
+		assert.ErrorIs(t, err, common.ErrConflict)
+		_, err = service.RetryOutboxMessage(99)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+
+	t.Run("A claimed message is left to its dispatcher", func(t *testing.T) {
+		require.NoError(t, outbox.RecordEvents(event.LessonDeleted{Lesson: event.Lesson{ID: 4}}))
+		now := time.Now()
+
+		claimed, err := repo.ClaimOutboxMessage(3, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		claimed, err = repo.ClaimOutboxMessage(3, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.False(t, claimed)
+
+		delivered, err := service.DispatchEvents(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+
+		// The lease runs out if the dispatcher holding it went away
+		claimed, err = repo.ClaimOutboxMessage(3, now.Add(2*time.Minute), now.Add(3*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, claimed)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	settings := event.Settings{RetryBackoff: 30 * time.Second}
+	assert.Equal(t, 30*time.Second, settings.Backoff(1))
+	assert.Equal(t, time.Minute, settings.Backoff(2))
+	assert.Equal(t, 4*time.Minute, settings.Backoff(4))
+	assert.Equal(t, time.Hour, settings.Backoff(20))
+}
@@ -0,0 +1,159 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/job"
+	"sarc-ng/internal/domain/notification"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/waitlist"
+	"strings"
+	"time"
+)
+
+// Names of the built-in jobs
+const (
+	JobReleaseNoShows    = "release-no-shows"
+	JobExpirePending     = "expire-pending-reservations"
+	JobCompletePast      = "complete-reservations"
+	JobSendReminders     = "send-reminders"
+	JobExpireWaitlist    = "expire-waitlist"
+	JobPurgeDeleted      = "purge-deleted"
+	JobDispatchEvents    = "dispatch-events"
+	JobSendNotifications = "send-notifications"
+)
+
+// scheduleOff in the settings leaves a job to run on demand only
+const scheduleOff = "off"
+
+const (
+	defaultReminderLead = time.Hour
+	purgeTimeout        = 10 * time.Minute
+)
+
+// NewRegistry registers the built-in jobs. Their default schedules can be
+// replaced or switched off by name in the settings.
+func NewRegistry(
+	settings job.Settings,
+	reservations reservation.Usecase,
+	waitlists waitlist.Usecase,
+	purger job.Purger,
+	events event.Usecase,
+	notifications notification.Usecase,
+) (*job.Registry, error) {
+	lead := settings.ReminderLead
+	if lead <= 0 {
+		lead = defaultReminderLead
+	}
+
+	jobs := []job.Job{
+		{
+			Name:        JobReleaseNoShows,
+			Description: "Release approved reservations nobody checked in to within the grace period",
+			Schedule:    "* * * * *",
+			Run: count("no-shows released", func() (int, error) {
+				return reservations.ReleaseNoShows()
+			}),
+		},
+		{
+			Name:        JobExpirePending,
+			Description: "Expire pending reservations that started without being reviewed",
+			Schedule:    "*/5 * * * *",
+			Run: count("pending reservations expired", func() (int, error) {
+				return reservations.ExpirePendingReservations()
+			}),
+		},
+		{
+			Name:        JobCompletePast,
+			Description: "Mark approved reservations that have ended as completed",
+			Schedule:    "*/15 * * * *",
+			Run: count("reservations completed", func() (int, error) {
+				return reservations.CompleteReservations()
+			}),
+		},
+		{
+			Name:        JobSendReminders,
+			Description: fmt.Sprintf("Remind owners of approved reservations starting within %s", lead),
+			Schedule:    "*/5 * * * *",
+			Run: count("reminders sent", func() (int, error) {
+				return reservations.SendReservationReminders(lead)
+			}),
+		},
+		{
+			Name:        JobExpireWaitlist,
+			Description: "Expire waitlist entries past their expiry",
+			Schedule:    "*/5 * * * *",
+			Run: count("waitlist entries expired", func() (int, error) {
+				return waitlists.ExpireWaitlistEntries()
+			}),
+		},
+		{
+			Name:        JobDispatchEvents,
+			Description: "Deliver recorded domain events that are due to every sink",
+			Schedule:    "* * * * *",
+			Run: func(ctx context.Context) (string, error) {
+				n, err := events.DispatchEvents(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d events delivered", n), nil
+			},
+		},
+		{
+			Name:        JobSendNotifications,
+			Description: "Send the queued notifications that are due",
+			Schedule:    "* * * * *",
+			Run: func(ctx context.Context) (string, error) {
+				n, err := notifications.SendNotifications(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d notifications sent", n), nil
+			},
+		},
+	}
+
+	if settings.PurgeAfter > 0 {
+		jobs = append(jobs, job.Job{
+			Name:        JobPurgeDeleted,
+			Description: fmt.Sprintf("Permanently remove records deleted more than %s ago", settings.PurgeAfter),
+			Schedule:    "0 3 * * *",
+			Timeout:     purgeTimeout,
+			Run: count("deleted records purged", func() (int, error) {
+				return purger.PurgeDeleted(time.Now().Add(-settings.PurgeAfter))
+			}),
+		})
+	}
+
+	registry := job.NewRegistry()
+	for _, j := range jobs {
+		if schedule, ok := settings.Schedules[j.Name]; ok {
+			j.Schedule = strings.TrimSpace(schedule)
+			if j.Schedule == scheduleOff {
+				j.Schedule = ""
+			}
+		}
+		if err := registry.Register(j); err != nil {
+			return nil, err
+		}
+	}
+
+	for name := range settings.Schedules {
+		if _, ok := registry.Lookup(name); !ok {
+			return nil, fmt.Errorf("schedule configured for unknown job %s", name)
+		}
+	}
+	return registry, nil
+}
+
+// count adapts a use case that reports how many records it handled to a job
+func count(what string, fn func() (int, error)) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		n, err := fn()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %s", n, what), nil
+	}
+}
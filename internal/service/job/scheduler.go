@@ -0,0 +1,89 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/job"
+	"time"
+)
+
+// leaderLock is the lock held by the replica that runs scheduled jobs
+const leaderLock = "scheduler"
+
+// leaderTTL is how long leadership lasts without being renewed. The leader
+// renews it every minute, so another replica takes over within two minutes
+// of the leader going away.
+const leaderTTL = 2 * time.Minute
+
+// Scheduler runs the jobs of a registry at the start of every minute their
+// schedule matches. Replicas sharing a database elect a leader through a lock
+// so each scheduled run happens once.
+type Scheduler struct {
+	service  *Service
+	registry *job.Registry
+}
+
+// NewScheduler creates a scheduler for the jobs of the registry
+func NewScheduler(service *Service, registry *job.Registry) *Scheduler {
+	return &Scheduler{
+		service:  service,
+		registry: registry,
+	}
+}
+
+// Start runs scheduled jobs until the context is cancelled, then gives up
+// leadership. It blocks, so callers run it in its own goroutine.
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Printf("Job scheduler started as %s", s.service.holder)
+	defer s.service.repo.ReleaseJobLock(leaderLock, s.service.holder)
+
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.Println("Job scheduler stopped")
+			return
+		case <-timer.C:
+			s.Tick(ctx, next)
+		}
+	}
+}
+
+// Tick starts the jobs due at the given minute if this replica is the leader
+func (s *Scheduler) Tick(ctx context.Context, minute time.Time) {
+	leader, err := s.service.repo.AcquireJobLock(leaderLock, s.service.holder, time.Now(), leaderTTL)
+	if err != nil {
+		log.Printf("Failed to acquire the job scheduler lock: %v", err)
+		return
+	}
+	if !leader {
+		return
+	}
+
+	local := minute.In(s.service.location())
+	for _, j := range s.registry.Jobs() {
+		schedule := s.registry.Schedule(j.Name)
+		if schedule == nil || !schedule.Matches(local) {
+			continue
+		}
+		go s.run(ctx, j.Name)
+	}
+}
+
+// run runs a scheduled job and logs the outcome
+func (s *Scheduler) run(ctx context.Context, name string) {
+	run, err := s.service.RunJob(ctx, name, job.TriggerSchedule)
+	switch {
+	case errors.Is(err, common.ErrConflict):
+		log.Printf("Skipped job %s: the previous run has not finished", name)
+	case err != nil:
+		log.Printf("Failed to run job %s: %v", name, err)
+	case run.Status == job.RunFailed:
+		log.Printf("Job %s failed after %s: %s", name, run.Duration(), run.Error)
+	}
+}
@@ -0,0 +1,177 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/job"
+	"sync"
+	"time"
+)
+
+// lockMargin keeps a job lock past the run timeout so a run that is cut off
+// still finishes recording before another instance can start the job
+const lockMargin = time.Minute
+
+// Service implements job.Usecase interface
+type Service struct {
+	repo     job.Repository
+	registry *job.Registry
+	settings job.Settings
+	holder   string
+
+	mu      sync.Mutex
+	running map[string]bool // jobs running in this process
+}
+
+// Compile-time verification that Service implements job.Usecase
+var _ job.Usecase = (*Service)(nil)
+
+// NewService creates a new job service. Runs are attributed to this instance
+// by host name and process ID.
+func NewService(repo job.Repository, registry *job.Registry, settings job.Settings) *Service {
+	return &Service{
+		repo:     repo,
+		registry: registry,
+		settings: settings,
+		holder:   instanceName(),
+		running:  make(map[string]bool),
+	}
+}
+
+// GetJobs lists the registered jobs with their next scheduled and last run
+func (s *Service) GetJobs() ([]job.Info, error) {
+	now := time.Now().In(s.location())
+	jobs := s.registry.Jobs()
+	infos := make([]job.Info, len(jobs))
+	for i, j := range jobs {
+		infos[i] = job.Info{Job: j}
+		if schedule := s.registry.Schedule(j.Name); schedule != nil {
+			if next := schedule.Next(now); !next.IsZero() {
+				infos[i].NextRun = &next
+			}
+		}
+
+		last, err := s.repo.ReadLastJobRun(j.Name)
+		if err != nil {
+			return nil, err
+		}
+		infos[i].LastRun = last
+	}
+	return infos, nil
+}
+
+// GetJobRuns retrieves the page of job runs selected by the query
+func (s *Service) GetJobRuns(query common.Query) (*common.Page[job.Run], error) {
+	return s.repo.ReadJobRunList(query)
+}
+
+// RunJob runs a job now under its lock and records the run. A job that fails
+// is recorded as failed; only failures to start or record the run are returned.
+func (s *Service) RunJob(ctx context.Context, name string, trigger job.Trigger) (*job.Run, error) {
+	j, ok := s.registry.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: job %s is not registered", common.ErrNotFound, name)
+	}
+
+	// The database lock keeps other instances out; this process renews its
+	// own locks, so it keeps track of its runs itself
+	if !s.claim(j.Name) {
+		return nil, fmt.Errorf("%w: job %s is already running", common.ErrConflict, j.Name)
+	}
+	defer s.unclaim(j.Name)
+
+	timeout := j.Timeout
+	if timeout <= 0 {
+		timeout = s.settings.Timeout
+	}
+
+	lock := "job:" + j.Name
+	acquired, err := s.repo.AcquireJobLock(lock, s.holder, time.Now(), timeout+lockMargin)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, fmt.Errorf("%w: job %s is already running", common.ErrConflict, j.Name)
+	}
+	defer s.repo.ReleaseJobLock(lock, s.holder)
+
+	run := &job.Run{
+		Job:       j.Name,
+		Trigger:   trigger,
+		Holder:    s.holder,
+		Status:    job.RunRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.repo.CreateJobRun(run); err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	summary, err := execute(runCtx, j)
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Summary = summary
+	run.Status = job.RunSucceeded
+	if err != nil {
+		run.Status = job.RunFailed
+		run.Error = err.Error()
+	}
+	if err := s.repo.UpdateJobRun(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// claim marks a job as running in this process unless it already is
+func (s *Service) claim(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[name] {
+		return false
+	}
+	s.running[name] = true
+	return true
+}
+
+// unclaim marks a job as no longer running in this process
+func (s *Service) unclaim(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, name)
+}
+
+// location returns the time zone schedules are evaluated in
+func (s *Service) location() *time.Location {
+	if s.settings.Location == nil {
+		return time.UTC
+	}
+	return s.settings.Location
+}
+
+// execute runs the job, turning a panic or an overrun of its timeout into an error
+func execute(ctx context.Context, j job.Job) (summary string, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+
+	summary, err = j.Run(ctx)
+	if err == nil && ctx.Err() != nil {
+		err = fmt.Errorf("job did not finish in time: %w", ctx.Err())
+	}
+	return summary, err
+}
+
+// instanceName identifies this process among the instances sharing the database
+func instanceName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
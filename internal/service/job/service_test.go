@@ -0,0 +1,172 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	jobAdapter "sarc-ng/internal/adapter/gorm/job"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/job"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunJob(t *testing.T) {
+	db := gormtest.Open(t, &jobAdapter.RunGormModel{}, &jobAdapter.LockGormModel{})
+	repo := jobAdapter.NewGormAdapter(db)
+
+	registry := job.NewRegistry()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	require.NoError(t, registry.Register(job.Job{
+		Name:     "tidy",
+		Schedule: "*/5 * * * *",
+		Run:      func(ctx context.Context) (string, error) { return "3 records tidied", nil },
+	}))
+	require.NoError(t, registry.Register(job.Job{
+		Name: "broken",
+		Run:  func(ctx context.Context) (string, error) { return "", errors.New("disk full") },
+	}))
+	require.NoError(t, registry.Register(job.Job{
+		Name: "slow",
+		Run: func(ctx context.Context) (string, error) {
+			close(started)
+			<-release
+			return "done", nil
+		},
+	}))
+	require.NoError(t, registry.Register(job.Job{
+		Name:    "stuck",
+		Timeout: 10 * time.Millisecond,
+		Run: func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", nil
+		},
+	}))
+	service := NewService(repo, registry, job.Settings{Location: time.UTC, Timeout: time.Minute})
+
+	t.Run("Records a successful run", func(t *testing.T) {
+		run, err := service.RunJob(context.Background(), "tidy", job.TriggerManual)
+		require.NoError(t, err)
+		assert.Equal(t, job.RunSucceeded, run.Status)
+		assert.Equal(t, "3 records tidied", run.Summary)
+		assert.NotNil(t, run.FinishedAt)
+
+		last, err := repo.ReadLastJobRun("tidy")
+		require.NoError(t, err)
+		require.NotNil(t, last)
+		assert.Equal(t, run.ID, last.ID)
+		assert.Equal(t, job.TriggerManual, last.Trigger)
+	})
+
+	t.Run("Records failures and timeouts", func(t *testing.T) {
+		run, err := service.RunJob(context.Background(), "broken", job.TriggerEvent)
+		require.NoError(t, err)
+		assert.Equal(t, job.RunFailed, run.Status)
+		assert.Equal(t, "disk full", run.Error)
+
+		run, err = service.RunJob(context.Background(), "stuck", job.TriggerSchedule)
+		require.NoError(t, err)
+		assert.Equal(t, job.RunFailed, run.Status)
+		assert.Contains(t, run.Error, "did not finish in time")
+	})
+
+	t.Run("Refuses to run a job twice at once", func(t *testing.T) {
+		done := make(chan error)
+		go func() {
+			_, err := service.RunJob(context.Background(), "slow", job.TriggerSchedule)
+			done <- err
+		}()
+		<-started
+
+		_, err := service.RunJob(context.Background(), "slow", job.TriggerManual)
+		assert.ErrorIs(t, err, common.ErrConflict)
+
+		// Another replica sees the lock too
+		other := NewService(repo, registry, job.Settings{Timeout: time.Minute})
+		other.holder = "other-replica"
+		_, err = other.RunJob(context.Background(), "slow", job.TriggerManual)
+		assert.ErrorIs(t, err, common.ErrConflict)
+
+		close(release)
+		require.NoError(t, <-done)
+	})
+
+	t.Run("Unknown job", func(t *testing.T) {
+		_, err := service.RunJob(context.Background(), "missing", job.TriggerManual)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+	})
+
+	t.Run("Lists jobs with their next and last run", func(t *testing.T) {
+		infos, err := service.GetJobs()
+		require.NoError(t, err)
+		require.Len(t, infos, 4)
+
+		assert.Equal(t, "tidy", infos[0].Name)
+		require.NotNil(t, infos[0].NextRun)
+		assert.Zero(t, infos[0].NextRun.Minute()%5)
+		require.NotNil(t, infos[0].LastRun)
+		assert.Equal(t, job.RunSucceeded, infos[0].LastRun.Status)
+		assert.Nil(t, infos[1].NextRun)
+
+		page, err := service.GetJobRuns(common.Query{}.Where("status", common.OpEqual, job.RunFailed))
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, page.Total)
+	})
+}
+
+func TestAcquireJobLock(t *testing.T) {
+	db := gormtest.Open(t, &jobAdapter.LockGormModel{})
+	repo := jobAdapter.NewGormAdapter(db)
+	now := time.Now()
+
+	acquired, err := repo.AcquireJobLock(leaderLock, "a", now, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// The holder renews its lock, others wait for it to expire
+	acquired, err = repo.AcquireJobLock(leaderLock, "a", now.Add(30*time.Second), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, err = repo.AcquireJobLock(leaderLock, "b", now.Add(time.Minute), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	acquired, err = repo.AcquireJobLock(leaderLock, "b", now.Add(2*time.Minute), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Only the holder can release it
+	require.NoError(t, repo.ReleaseJobLock(leaderLock, "a"))
+	acquired, err = repo.AcquireJobLock(leaderLock, "a", now.Add(2*time.Minute), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	require.NoError(t, repo.ReleaseJobLock(leaderLock, "b"))
+	acquired, err = repo.AcquireJobLock(leaderLock, "a", now.Add(2*time.Minute), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestNewRegistry(t *testing.T) {
+	settings := job.Settings{
+		PurgeAfter: 30 * 24 * time.Hour,
+		Schedules:  map[string]string{JobSendReminders: "off", JobPurgeDeleted: "0 4 * * 0"},
+	}
+	registry, err := NewRegistry(settings, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, registry.Jobs(), 8)
+	assert.NotNil(t, registry.Schedule(JobReleaseNoShows))
+	assert.Nil(t, registry.Schedule(JobSendReminders))
+	purge, ok := registry.Lookup(JobPurgeDeleted)
+	require.True(t, ok)
+	assert.Equal(t, "0 4 * * 0", purge.Schedule)
+
+	_, err = NewRegistry(job.Settings{Schedules: map[string]string{"reticulate": "* * * * *"}}, nil, nil, nil, nil, nil)
+	assert.Error(t, err)
+	_, err = NewRegistry(job.Settings{Schedules: map[string]string{JobExpireWaitlist: "every minute"}}, nil, nil, nil, nil, nil)
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+}
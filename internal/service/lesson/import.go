@@ -0,0 +1,230 @@
+package lesson
+
+import (
+	"fmt"
+	"io"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/pkg/ical"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxImportOccurrences caps the occurrences expanded from one recurring event
+const maxImportOccurrences = 1000
+
+// occurrenceIDLayout formats the original start of an occurrence in its external ID
+const occurrenceIDLayout = "20060102T150405Z"
+
+// ImportLessons creates or updates lessons from the events of an iCalendar file.
+// Every occurrence of a recurring event becomes its own lesson, identified by the
+// event UID and the original start so a re-import updates instead of duplicating.
+// Problems with single events are reported per item; only an unreadable file fails
+// the whole import.
+func (s *Service) ImportLessons(actor *auth.User, data io.Reader, options lesson.ImportOptions) (*lesson.ImportReport, error) {
+	loc := time.UTC
+	if options.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(options.TimeZone); err != nil {
+			return nil, fmt.Errorf("%w: unknown time zone %q", common.ErrInvalidInput, options.TimeZone)
+		}
+	}
+
+	cal, err := ical.Decode(data, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrInvalidInput, err)
+	}
+
+	imp := &importer{
+		repo:   s.repo,
+		uow:    s.uow,
+		actor:  actor,
+		check:  func(classID *uint) error { return s.checkBuilding(actor, classID) },
+		dryRun: options.DryRun,
+		report: &lesson.ImportReport{DryRun: options.DryRun},
+		seen:   make(map[string]bool),
+	}
+	for _, group := range groupEvents(cal.Events) {
+		imp.importGroup(group)
+	}
+	return imp.report, nil
+}
+
+// eventGroup is a calendar event together with the overrides of its occurrences
+type eventGroup struct {
+	base      *ical.Event
+	overrides []ical.Event
+}
+
+// groupEvents groups events by UID, keeping the order in which UIDs first appear
+func groupEvents(events []ical.Event) []*eventGroup {
+	var groups []*eventGroup
+	byUID := make(map[string]*eventGroup)
+	for i := range events {
+		event := &events[i]
+		group, ok := byUID[event.UID]
+		if !ok {
+			group = &eventGroup{}
+			byUID[event.UID] = group
+			groups = append(groups, group)
+		}
+
+		if event.RecurrenceID.IsZero() && group.base == nil {
+			group.base = event
+		} else {
+			group.overrides = append(group.overrides, *event)
+		}
+	}
+	return groups
+}
+
+// importer imports occurrences and records the outcome in its report
+type importer struct {
+	repo   lesson.Repository
+	uow    lesson.UnitOfWork
+	actor  *auth.User
+	check  func(classID *uint) error // whether the actor may change lessons of the class
+	dryRun bool
+	report *lesson.ImportReport
+	// seen holds the external IDs already handled in this import
+	seen map[string]bool
+}
+
+// importGroup expands an event and imports each occurrence, applying overrides
+func (imp *importer) importGroup(group *eventGroup) {
+	overrides := make(map[int64]ical.Event, len(group.overrides))
+	for _, o := range group.overrides {
+		overrides[o.RecurrenceID.Unix()] = o
+	}
+
+	if base := group.base; base != nil {
+		if !base.IsRecurring() {
+			imp.importOccurrence(base.UID, *base)
+		} else if starts, err := base.Occurrences(maxImportOccurrences); err != nil {
+			imp.report.Add(lesson.ImportItem{
+				ExternalID: base.UID,
+				Title:      base.Summary,
+				StartTime:  base.Start,
+				Action:     lesson.ImportError,
+				Reason:     err.Error(),
+			})
+		} else {
+			duration := base.End.Sub(base.Start)
+			for _, start := range starts {
+				event := *base
+				if o, ok := overrides[start.Unix()]; ok {
+					event = o
+					delete(overrides, start.Unix())
+				} else {
+					event.Start = start
+					event.End = start.Add(duration)
+				}
+				imp.importOccurrence(occurrenceID(base.UID, start), event)
+			}
+		}
+	}
+
+	// Overrides without a matching occurrence are imported on their own
+	remaining := make([]ical.Event, 0, len(overrides))
+	for _, o := range overrides {
+		remaining = append(remaining, o)
+	}
+	sort.Slice(remaining, func(i, j int) bool { return remaining[i].RecurrenceID.Before(remaining[j].RecurrenceID) })
+	for _, o := range remaining {
+		imp.importOccurrence(occurrenceID(o.UID, o.RecurrenceID), o)
+	}
+}
+
+// importOccurrence creates, updates or skips the lesson for one occurrence
+func (imp *importer) importOccurrence(externalID string, event ical.Event) {
+	imp.report.Add(imp.apply(externalID, event))
+}
+
+// apply decides and, unless in dry-run mode, carries out the action for an occurrence
+func (imp *importer) apply(externalID string, event ical.Event) lesson.ImportItem {
+	item := lesson.ImportItem{
+		ExternalID: externalID,
+		Title:      event.Summary,
+		StartTime:  event.Start,
+	}
+	skip := func(reason string) lesson.ImportItem {
+		item.Action = lesson.ImportSkip
+		item.Reason = reason
+		return item
+	}
+	fail := func(err error) lesson.ImportItem {
+		item.Action = lesson.ImportError
+		item.Reason = err.Error()
+		return item
+	}
+
+	if imp.seen[item.ExternalID] {
+		return skip("duplicate event in file")
+	}
+	imp.seen[item.ExternalID] = true
+
+	if event.Status == ical.StatusCancelled {
+		return skip("event is cancelled")
+	}
+	if event.AllDay {
+		return skip("all-day events are not imported as lessons")
+	}
+
+	l := &lesson.Lesson{
+		Title:       strings.TrimSpace(event.Summary),
+		Description: event.Description,
+		StartTime:   event.Start,
+		EndTime:     event.End,
+		Duration:    int(event.End.Sub(event.Start) / time.Minute),
+		ExternalID:  item.ExternalID,
+	}
+
+	existing, err := imp.repo.FindLessonByExternalID(item.ExternalID)
+	if err != nil {
+		return fail(err)
+	}
+
+	item.Action = lesson.ImportCreate
+	if existing != nil {
+		item.LessonID = existing.ID
+		if sameLesson(existing, l) {
+			return skip("unchanged")
+		}
+		item.Action = lesson.ImportUpdate
+		l.ID = existing.ID
+		l.ClassID = existing.ClassID
+		l.CreatedAt = existing.CreatedAt
+	}
+
+	if err := validateLesson(l); err != nil {
+		return fail(err)
+	}
+	if err := imp.check(l.ClassID); err != nil {
+		return fail(err)
+	}
+	if imp.dryRun {
+		return item
+	}
+
+	if err := saveLesson(imp.uow, imp.actor, l, existing); err != nil {
+		return fail(err)
+	}
+	item.LessonID = l.ID
+	return item
+}
+
+// occurrenceID builds the external ID of one occurrence of a recurring event
+func occurrenceID(uid string, start time.Time) string {
+	return uid + "/" + start.UTC().Format(occurrenceIDLayout)
+}
+
+// sameLesson checks if an import would leave the lesson unchanged
+func sameLesson(existing, imported *lesson.Lesson) bool {
+	return existing.Title == imported.Title &&
+		existing.Description == imported.Description &&
+		existing.Duration == imported.Duration &&
+		existing.StartTime.Equal(imported.StartTime) &&
+		existing.EndTime.Equal(imported.EndTime)
+}
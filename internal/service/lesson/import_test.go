@@ -0,0 +1,145 @@
+package lesson
+
+import (
+	"strings"
+	"testing"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	classAdapter "sarc-ng/internal/adapter/gorm/class"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	lessonAdapter "sarc-ng/internal/adapter/gorm/lesson"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/lesson"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func calendar(events ...string) *strings.Reader {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//Timetabler//EN"}
+	lines = append(lines, events...)
+	lines = append(lines, "END:VCALENDAR")
+	return strings.NewReader(strings.Join(lines, "\r\n"))
+}
+
+var term = []string{
+	"BEGIN:VEVENT",
+	"UID:algorithms@timetabler",
+	"SUMMARY:Algorithms",
+	"DTSTART:20260804T140000",
+	"DTEND:20260804T154000",
+	"RRULE:FREQ=WEEKLY;COUNT=4",
+	"EXDATE:20260811T140000",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:algorithms@timetabler",
+	"RECURRENCE-ID:20260818T140000",
+	"SUMMARY:Algorithms (exam)",
+	"DTSTART:20260818T140000",
+	"DTEND:20260818T170000",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:welcome@timetabler",
+	"SUMMARY:Welcome session",
+	"DTSTART:20260803T090000Z",
+	"DURATION:PT1H",
+	"END:VEVENT",
+	"BEGIN:VEVENT",
+	"UID:untitled@timetabler",
+	"DTSTART:20260803T110000Z",
+	"DURATION:PT1H",
+	"END:VEVENT",
+}
+
+var secretary = &auth.User{ID: "user-1", Username: "secretary", RequestID: "req-1"}
+
+func actions(report *lesson.ImportReport) map[string]lesson.ImportAction {
+	result := make(map[string]lesson.ImportAction, len(report.Items))
+	for _, item := range report.Items {
+		result[item.ExternalID] = item.Action
+	}
+	return result
+}
+
+func TestImportLessons(t *testing.T) {
+	db := gormtest.Open(t, &auditAdapter.GormModel{}, &lessonAdapter.GormModel{}, &eventAdapter.OutboxGormModel{})
+	repo := lessonAdapter.NewGormAdapter(db)
+	service := NewService(repo, lessonAdapter.NewUnitOfWork(db), classAdapter.NewGormAdapter(db))
+	options := lesson.ImportOptions{TimeZone: "America/Sao_Paulo"}
+
+	t.Run("Dry run changes nothing", func(t *testing.T) {
+		report, err := service.ImportLessons(secretary, calendar(term...), lesson.ImportOptions{DryRun: true, TimeZone: options.TimeZone})
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 4, report.Created)
+		assert.Equal(t, 1, report.Failed)
+
+		lessons, err := service.GetAllLessons(common.Query{})
+		require.NoError(t, err)
+		assert.Empty(t, lessons.Items)
+	})
+
+	t.Run("Creates one lesson per occurrence", func(t *testing.T) {
+		report, err := service.ImportLessons(secretary, calendar(term...), options)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]lesson.ImportAction{
+			"algorithms@timetabler/20260804T170000Z": lesson.ImportCreate,
+			"algorithms@timetabler/20260818T170000Z": lesson.ImportCreate,
+			"algorithms@timetabler/20260825T170000Z": lesson.ImportCreate,
+			"welcome@timetabler":                     lesson.ImportCreate,
+			"untitled@timetabler":                    lesson.ImportError,
+		}, actions(report))
+
+		exam, err := repo.FindLessonByExternalID("algorithms@timetabler/20260818T170000Z")
+		require.NoError(t, err)
+		require.NotNil(t, exam)
+		assert.Equal(t, "Algorithms (exam)", exam.Title)
+		assert.Equal(t, 180, exam.Duration)
+
+		for _, item := range report.Items {
+			if item.Action == lesson.ImportError {
+				assert.Contains(t, item.Reason, "title")
+			}
+		}
+	})
+
+	t.Run("Re-import skips unchanged and updates changed lessons", func(t *testing.T) {
+		changed := append([]string(nil), term...)
+		for i, line := range changed {
+			if line == "SUMMARY:Welcome session" {
+				changed[i] = "SUMMARY:Welcome session (auditorium)"
+			}
+		}
+
+		report, err := service.ImportLessons(secretary, calendar(changed...), options)
+		require.NoError(t, err)
+		assert.Equal(t, 0, report.Created)
+		assert.Equal(t, 1, report.Updated)
+		assert.Equal(t, 3, report.Skipped)
+		assert.Equal(t, lesson.ImportUpdate, actions(report)["welcome@timetabler"])
+
+		updates, err := auditAdapter.NewGormAdapter(db).ReadAuditEntryList(common.Query{}.Where("action", common.OpEqual, string(audit.ActionUpdate)))
+		require.NoError(t, err)
+		require.Len(t, updates.Items, 1)
+		assert.Equal(t, secretary.ID, updates.Items[0].ActorID)
+		assert.Equal(t, "req-1", updates.Items[0].RequestID)
+		assert.Equal(t, []audit.Change{{Field: "title", Before: "Welcome session", After: "Welcome session (auditorium)"}}, updates.Items[0].Changes)
+
+		lessons, err := service.GetAllLessons(common.Query{})
+		require.NoError(t, err)
+		assert.Len(t, lessons.Items, 4)
+	})
+
+	t.Run("Malformed file", func(t *testing.T) {
+		_, err := service.ImportLessons(secretary, strings.NewReader("not a calendar"), options)
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+	})
+
+	t.Run("Unknown time zone", func(t *testing.T) {
+		_, err := service.ImportLessons(secretary, calendar(term...), lesson.ImportOptions{TimeZone: "Mars/Olympus"})
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+	})
+}
@@ -1,30 +1,41 @@
 package lesson
 
 import (
+	"errors"
 	"fmt"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/class"
 	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
 	"sarc-ng/internal/domain/lesson"
 	"strings"
 )
 
 // Service implements lesson.Usecase interface
 type Service struct {
-	repo lesson.Repository
+	repo    lesson.Repository
+	uow     lesson.UnitOfWork
+	classes class.Repository
 }
 
 // Compile-time verification that Service implements lesson.Usecase
 var _ lesson.Usecase = (*Service)(nil)
 
-// NewService creates a new lesson service
-func NewService(repo lesson.Repository) *Service {
+// NewService creates a new lesson service. Every change, imported ones
+// included, is written through the unit of work together with its events and
+// audit entry.
+func NewService(repo lesson.Repository, uow lesson.UnitOfWork, classes class.Repository) *Service {
 	return &Service{
-		repo: repo,
+		repo:    repo,
+		uow:     uow,
+		classes: classes,
 	}
 }
 
-// GetAllLessons retrieves all lessons
-func (s *Service) GetAllLessons() ([]lesson.Lesson, error) {
-	return s.repo.ReadLessonList()
+// GetAllLessons retrieves the page of lessons selected by the query
+func (s *Service) GetAllLessons(query common.Query) (*common.Page[lesson.Lesson], error) {
+	return s.repo.ReadLessonList(query)
 }
 
 // GetLesson retrieves a lesson by ID with validation
@@ -36,49 +47,179 @@ func (s *Service) GetLesson(id uint) (*lesson.Lesson, error) {
 }
 
 // CreateLesson creates a new lesson with validation
-func (s *Service) CreateLesson(l *lesson.Lesson) error {
-	// Validate title
-	if strings.TrimSpace(l.Title) == "" {
-		return fmt.Errorf("%w: lesson title cannot be empty", common.ErrInvalidInput)
+func (s *Service) CreateLesson(actor *auth.User, l *lesson.Lesson) error {
+	if err := validateLesson(l); err != nil {
+		return err
 	}
 
-	// Validate duration
-	if l.Duration <= 0 {
-		return fmt.Errorf("%w: lesson duration must be greater than zero", common.ErrInvalidInput)
+	if err := s.validateClass(l.ClassID); err != nil {
+		return err
+	}
+	if err := s.checkBuilding(actor, l.ClassID); err != nil {
+		return err
 	}
 
-	return s.repo.CreateLesson(l)
+	return saveLesson(s.uow, actor, l, nil)
 }
 
 // UpdateLesson updates an existing lesson with validation
-func (s *Service) UpdateLesson(l *lesson.Lesson) error {
+func (s *Service) UpdateLesson(actor *auth.User, l *lesson.Lesson) error {
 	if l.ID == 0 {
 		return fmt.Errorf("%w: lesson ID cannot be zero for update", common.ErrInvalidInput)
 	}
 
-	// Validate title
-	if strings.TrimSpace(l.Title) == "" {
-		return fmt.Errorf("%w: lesson title cannot be empty", common.ErrInvalidInput)
+	if err := validateLesson(l); err != nil {
+		return err
 	}
 
-	// Validate duration
-	if l.Duration <= 0 {
-		return fmt.Errorf("%w: lesson duration must be greater than zero", common.ErrInvalidInput)
+	if err := s.validateClass(l.ClassID); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.ReadLesson(l.ID)
+	if err != nil {
+		return err
+	}
+	// Moving a lesson needs access to the buildings of both classes
+	if err := s.checkBuilding(actor, existing.ClassID); err != nil {
+		return err
+	}
+	if err := s.checkBuilding(actor, l.ClassID); err != nil {
+		return err
+	}
+	// The external ID is only set by imports, so edits must not drop it
+	if l.ExternalID == "" {
+		l.ExternalID = existing.ExternalID
 	}
 
-	return s.repo.UpdateLesson(l)
+	return saveLesson(s.uow, actor, l, existing)
 }
 
 // DeleteLesson removes a lesson by ID
-func (s *Service) DeleteLesson(id uint) error {
+func (s *Service) DeleteLesson(actor *auth.User, id uint) error {
 	if id == 0 {
 		return fmt.Errorf("%w: lesson ID cannot be zero", common.ErrInvalidInput)
 	}
 
-	_, err := s.repo.ReadLesson(id)
+	existing, err := s.repo.ReadLesson(id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkBuilding(actor, existing.ClassID); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repo lesson.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.DeleteLesson(id); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionDelete, audit.EntityLesson, id, existing, nil)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.LessonDeleted{Lesson: snapshot(*existing)})
+	})
+}
+
+// saveLesson creates the lesson, or updates it when the previous state is
+// given, and records the matching events and audit entry of the actor in the
+// same transaction
+func saveLesson(uow lesson.UnitOfWork, actor *auth.User, l *lesson.Lesson, previous *lesson.Lesson) error {
+	return uow.Do(func(repo lesson.Repository, events event.Outbox, trail audit.Trail) error {
+		if previous == nil {
+			if err := repo.CreateLesson(l); err != nil {
+				return err
+			}
+			if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionCreate, audit.EntityLesson, l.ID, nil, l)); err != nil {
+				return err
+			}
+			return events.RecordEvents(event.LessonCreated{Lesson: snapshot(*l)})
+		}
+
+		if err := repo.UpdateLesson(l); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionUpdate, audit.EntityLesson, l.ID, previous, l)); err != nil {
+			return err
+		}
+		recorded := []event.Event{event.LessonUpdated{Lesson: snapshot(*l)}}
+		if isRescheduled(previous, l) {
+			recorded = append(recorded, event.LessonRescheduled{
+				Lesson:            snapshot(*l),
+				PreviousStartTime: previous.StartTime,
+				PreviousEndTime:   previous.EndTime,
+				PreviousClassID:   previous.ClassID,
+			})
+		}
+		return events.RecordEvents(recorded...)
+	})
+}
+
+// isRescheduled reports whether a lesson moved to another time or class
+func isRescheduled(before, after *lesson.Lesson) bool {
+	sameClass := (before.ClassID == nil && after.ClassID == nil) ||
+		(before.ClassID != nil && after.ClassID != nil && *before.ClassID == *after.ClassID)
+	return !sameClass || !before.StartTime.Equal(after.StartTime) || !before.EndTime.Equal(after.EndTime)
+}
+
+// snapshot returns the state of a lesson carried by its events
+func snapshot(l lesson.Lesson) event.Lesson {
+	return event.Lesson{
+		ID:        l.ID,
+		Title:     l.Title,
+		StartTime: l.StartTime,
+		EndTime:   l.EndTime,
+		ClassID:   l.ClassID,
+	}
+}
+
+// validateClass checks that a lesson refers to an existing class, if any
+func (s *Service) validateClass(classID *uint) error {
+	if classID == nil {
+		return nil
+	}
+
+	if _, err := s.classes.ReadClass(*classID); err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return fmt.Errorf("%w: class %d does not exist", common.ErrInvalidInput, *classID)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkBuilding checks that the actor may change lessons held in the class,
+// which are in the building of the class. Lessons without a class are in no
+// building.
+func (s *Service) checkBuilding(actor *auth.User, classID *uint) error {
+	if actor == nil || len(actor.Buildings) == 0 {
+		return nil
+	}
+	if classID == nil {
+		return actor.CheckBuilding(0)
+	}
+
+	c, err := s.classes.ReadClass(*classID)
 	if err != nil {
 		return err
 	}
+	return actor.CheckBuilding(c.BuildingID)
+}
+
+// validateLesson checks the fields shared by created, updated and imported lessons
+func validateLesson(l *lesson.Lesson) error {
+	// Validate title
+	if strings.TrimSpace(l.Title) == "" {
+		return fmt.Errorf("%w: lesson title cannot be empty", common.ErrInvalidInput)
+	}
+
+	// Validate duration
+	if l.Duration <= 0 {
+		return fmt.Errorf("%w: lesson duration must be greater than zero", common.ErrInvalidInput)
+	}
+
+	if len(l.ExternalID) > 255 {
+		return fmt.Errorf("%w: external ID cannot be longer than 255 characters", common.ErrInvalidInput)
+	}
 
-	return s.repo.DeleteLesson(id)
+	return nil
 }
@@ -0,0 +1,315 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/notification"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/resource"
+	"sarc-ng/internal/domain/waitlist"
+	"sync"
+	"time"
+)
+
+// claimLease is how long a sender holds a notification while sending it. A
+// sender that crashes mid-send leaves it to be picked up again once the lease
+// runs out.
+const claimLease = time.Minute
+
+// EventTypes are the domain events that queue notifications
+var EventTypes = []event.Type{
+	event.TypeReservationStatusChanged,
+	event.TypeReservationCancelled,
+}
+
+// Service implements notification.Usecase interface. It queues notifications
+// when it is told about reservation events, reminders and waitlist promotions,
+// and sends them in the background.
+type Service struct {
+	repo      notification.Repository
+	resources resource.Repository
+	notifier  notification.Notifier
+	templates *Templates
+	settings  notification.Settings
+
+	// recipients caches the email addresses already recorded by user ID
+	recipients sync.Map
+}
+
+// Compile-time verification that Service implements notification.Usecase and
+// queues the reminders of the reservation service and the promotions of the
+// waitlist
+var (
+	_ notification.Usecase = (*Service)(nil)
+	_ reservation.Notifier = (*Service)(nil)
+	_ waitlist.Notifier    = (*Service)(nil)
+)
+
+// NewService creates a new notification service
+func NewService(
+	repo notification.Repository,
+	resources resource.Repository,
+	notifier notification.Notifier,
+	templates *Templates,
+	settings notification.Settings,
+) *Service {
+	return &Service{
+		repo:      repo,
+		resources: resources,
+		notifier:  notifier,
+		templates: templates,
+		settings:  settings,
+	}
+}
+
+// GetPreferences retrieves the notification preferences of the user, which
+// are the defaults until they change them
+func (s *Service) GetPreferences(user *auth.User) (*notification.Preferences, error) {
+	if user == nil {
+		return nil, fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+
+	p, err := s.repo.ReadNotificationPreferences(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		p = &notification.Preferences{UserID: user.ID}
+	}
+	if user.Email != "" {
+		p.Email = user.Email
+	}
+	return p, nil
+}
+
+// UpdatePreferences replaces the locale and opt-outs of the user, recording
+// the email address of their token
+func (s *Service) UpdatePreferences(user *auth.User, p *notification.Preferences) error {
+	current, err := s.GetPreferences(user)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if p.Locale != "" && !s.templates.Supports(p.Locale) {
+		return fmt.Errorf("%w: notifications are not available in locale '%s'", common.ErrInvalidInput, p.Locale)
+	}
+
+	p.UserID = user.ID
+	p.Email = current.Email
+	if err := s.repo.SaveNotificationPreferences(p); err != nil {
+		return err
+	}
+	s.recipients.Store(user.ID, p.Email)
+	return nil
+}
+
+// RememberRecipient records the email address of the user's token unless it
+// is already known
+func (s *Service) RememberRecipient(user *auth.User) error {
+	if user == nil || user.Email == "" {
+		return nil
+	}
+	if known, ok := s.recipients.Load(user.ID); ok && known == user.Email {
+		return nil
+	}
+
+	p, err := s.repo.ReadNotificationPreferences(user.ID)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		p = &notification.Preferences{UserID: user.ID}
+	}
+	if p.Email != user.Email {
+		p.Email = user.Email
+		if err := s.repo.SaveNotificationPreferences(p); err != nil {
+			return err
+		}
+	}
+	s.recipients.Store(user.ID, user.Email)
+	return nil
+}
+
+// HandleEvent queues a notification for reservations that were approved,
+// rejected or cancelled. It is subscribed to the in-process event sink.
+func (s *Service) HandleEvent(ctx context.Context, m event.Message) error {
+	var kind notification.Kind
+	var r event.Reservation
+	switch m.Type {
+	case event.TypeReservationStatusChanged:
+		var changed event.ReservationStatusChanged
+		if err := json.Unmarshal(m.Payload, &changed); err != nil {
+			return fmt.Errorf("failed to decode event %d: %w", m.ID, err)
+		}
+		switch reservation.Status(changed.Status) {
+		case reservation.StatusApproved:
+			kind = notification.KindReservationApproved
+		case reservation.StatusRejected:
+			kind = notification.KindReservationRejected
+		default:
+			return nil
+		}
+		r = changed.Reservation
+	case event.TypeReservationCancelled:
+		var cancelled event.ReservationCancelled
+		if err := json.Unmarshal(m.Payload, &cancelled); err != nil {
+			return fmt.Errorf("failed to decode event %d: %w", m.ID, err)
+		}
+		kind = notification.KindReservationCancelled
+		r = cancelled.Reservation
+	default:
+		return nil
+	}
+
+	return s.enqueue(kind, fmt.Sprintf("%s:event:%d", kind, m.ID), r.UserID, notification.Reservation{
+		ID:         r.ID,
+		ResourceID: r.ResourceID,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+		Reason:     r.StatusReason,
+	})
+}
+
+// NotifyReservationReminder queues a reminder that a reservation is about to start
+func (s *Service) NotifyReservationReminder(r reservation.Reservation) error {
+	kind := notification.KindReservationStarting
+	return s.enqueue(kind, fmt.Sprintf("%s:%d:%d", kind, r.ID, r.StartTime.Unix()), r.UserID, notification.Reservation{
+		ID:         r.ID,
+		ResourceID: r.ResourceID,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+	})
+}
+
+// NotifyWaitlistPromoted queues a notification that a waitlist entry became a
+// pending reservation
+func (s *Service) NotifyWaitlistPromoted(e waitlist.Entry, r reservation.Reservation) error {
+	kind := notification.KindWaitlistPromoted
+	return s.enqueue(kind, fmt.Sprintf("%s:%d", kind, e.ID), e.UserID, notification.Reservation{
+		ID:         r.ID,
+		ResourceID: r.ResourceID,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+	})
+}
+
+// enqueue renders a notification for the user and queues it, unless it was
+// queued before or the user does not want it
+func (s *Service) enqueue(kind notification.Kind, key, userID string, r notification.Reservation) error {
+	exists, err := s.repo.ExistsNotification(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	p, err := s.repo.ReadNotificationPreferences(userID)
+	if err != nil {
+		return err
+	}
+	if p == nil || !p.Wants(kind) {
+		return nil
+	}
+
+	r.ResourceName = fmt.Sprintf("resource #%d", r.ResourceID)
+	res, err := s.resources.ReadResource(r.ResourceID)
+	switch {
+	case errors.Is(err, common.ErrNotFound):
+		// Keep the placeholder name for resources deleted since
+	case err != nil:
+		return err
+	default:
+		r.ResourceName = res.Name
+	}
+	if s.settings.Location != nil {
+		r.StartTime = r.StartTime.In(s.settings.Location)
+		r.EndTime = r.EndTime.In(s.settings.Location)
+	}
+
+	subject, body, err := s.templates.Render(kind, p.Locale, s.settings.DefaultLocale, struct {
+		Reservation notification.Reservation
+	}{r})
+	if err != nil {
+		return err
+	}
+
+	return s.repo.CreateNotification(&notification.Notification{
+		Key:           key,
+		Kind:          kind,
+		UserID:        userID,
+		Recipient:     p.Email,
+		Subject:       subject,
+		Body:          body,
+		Status:        notification.StatusPending,
+		NextAttemptAt: time.Now(),
+	})
+}
+
+// SendNotifications sends the due notifications, oldest first, and returns
+// how many were sent
+func (s *Service) SendNotifications(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := s.repo.FindDueNotifications(now, s.settings.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range due {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		n := &due[i]
+		claimed, err := s.repo.ClaimNotification(n.ID, now, time.Now().Add(claimLease))
+		if err != nil {
+			return sent, err
+		}
+		if !claimed {
+			// Another sender is sending it
+			continue
+		}
+
+		if s.send(ctx, n) {
+			sent++
+		}
+		n.LockedUntil = nil
+		if err := s.repo.UpdateNotification(n); err != nil {
+			return sent, fmt.Errorf("failed to record sending of notification %d: %w", n.ID, err)
+		}
+	}
+	return sent, nil
+}
+
+// send hands the notification to the notifier and updates its status. It
+// reports whether the notification was sent.
+func (s *Service) send(ctx context.Context, n *notification.Notification) bool {
+	err := s.notifier.Send(ctx, notification.Message{To: n.Recipient, Subject: n.Subject, Body: n.Body})
+	n.Attempts++
+	if err == nil {
+		now := time.Now()
+		n.Status = notification.StatusSent
+		n.SentAt = &now
+		n.LastError = ""
+		return true
+	}
+
+	n.LastError = err.Error()
+	if n.Attempts >= s.settings.MaxAttempts {
+		n.Status = notification.StatusFailed
+		log.Printf("Giving up on %s notification %d to user %s after %d attempts: %v", n.Kind, n.ID, n.UserID, n.Attempts, err)
+		return false
+	}
+	n.NextAttemptAt = time.Now().Add(s.settings.Backoff(n.Attempts))
+	return false
+}
@@ -0,0 +1,197 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime/quotedprintable"
+	"strings"
+	"testing"
+	"time"
+
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	notificationAdapter "sarc-ng/internal/adapter/gorm/notification"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/adapter/notify"
+	"sarc-ng/internal/adapter/notify/smtptest"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/notification"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/resource"
+	"sarc-ng/internal/domain/waitlist"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var (
+	alice = &auth.User{ID: "user-1", Email: "alice@example.com"}
+	bob   = &auth.User{ID: "user-2", Email: "bob@example.com"}
+	start = time.Date(2030, 3, 4, 14, 0, 0, 0, time.UTC)
+)
+
+func newService(t *testing.T) (*gorm.DB, *Service, *smtptest.Server) {
+	db := gormtest.Open(t, &notificationAdapter.GormModel{}, &notificationAdapter.PreferencesGormModel{}, &resourceAdapter.GormModel{})
+	require.NoError(t, resourceAdapter.NewGormAdapter(db).CreateResource(&resource.Resource{Name: "Room Ä1", Type: "room", IsAvailable: true}))
+
+	server := smtptest.NewServer(t)
+	notifier, err := notify.NewSMTPNotifier(notify.SMTPSettings{
+		Host: server.Host,
+		Port: server.Port,
+		From: "SARC <no-reply@example.com>",
+		TLS:  notify.TLSNone,
+	})
+	require.NoError(t, err)
+
+	templates, err := LoadTemplates("")
+	require.NoError(t, err)
+
+	service := NewService(notificationAdapter.NewGormAdapter(db), resourceAdapter.NewGormAdapter(db), notifier, templates, notification.Settings{
+		DefaultLocale: "en",
+		Location:      time.UTC,
+		BatchSize:     10,
+		MaxAttempts:   2,
+		RetryBackoff:  time.Millisecond,
+	})
+	return db, service, server
+}
+
+// statusChanged builds the in-process message of a reservation status change
+func statusChanged(t *testing.T, id uint, userID string, status reservation.Status) event.Message {
+	payload, err := json.Marshal(event.ReservationStatusChanged{
+		Reservation: event.Reservation{
+			ID:         id,
+			ResourceID: 1,
+			UserID:     userID,
+			StartTime:  start,
+			EndTime:    start.Add(time.Hour),
+			Status:     string(status),
+		},
+		PreviousStatus: string(reservation.StatusPending),
+	})
+	require.NoError(t, err)
+	return event.Message{ID: id, Type: event.TypeReservationStatusChanged, Payload: payload}
+}
+
+func queued(t *testing.T, db *gorm.DB) []notificationAdapter.GormModel {
+	var models []notificationAdapter.GormModel
+	require.NoError(t, db.Order("id").Find(&models).Error)
+	return models
+}
+
+func TestHandleEventQueuesNotifications(t *testing.T) {
+	db, service, server := newService(t)
+	ctx := context.Background()
+
+	require.NoError(t, service.RememberRecipient(alice))
+	require.NoError(t, service.RememberRecipient(bob))
+	require.NoError(t, service.UpdatePreferences(bob, &notification.Preferences{OptOut: []notification.Kind{notification.KindReservationApproved}}))
+
+	require.NoError(t, service.HandleEvent(ctx, statusChanged(t, 1, alice.ID, reservation.StatusApproved)))
+	require.NoError(t, service.HandleEvent(ctx, statusChanged(t, 1, alice.ID, reservation.StatusApproved)), "events delivered twice are queued once")
+	require.NoError(t, service.HandleEvent(ctx, statusChanged(t, 2, bob.ID, reservation.StatusApproved)))
+	require.NoError(t, service.HandleEvent(ctx, statusChanged(t, 3, alice.ID, reservation.StatusCompleted)))
+	require.NoError(t, service.HandleEvent(ctx, statusChanged(t, 4, "unknown-user", reservation.StatusRejected)))
+
+	models := queued(t, db)
+	require.Len(t, models, 1, "opted out kinds, other statuses and users without an address are skipped")
+	assert.Equal(t, string(notification.KindReservationApproved), models[0].Kind)
+	assert.Equal(t, "alice@example.com", models[0].Recipient)
+	assert.Equal(t, "Your reservation of Room Ä1 was approved", models[0].Subject)
+	assert.Empty(t, server.Messages(), "nothing is sent while handling the event")
+
+	sent, err := service.SendNotifications(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "no-reply@example.com", messages[0].From)
+	assert.Equal(t, []string{"alice@example.com"}, messages[0].To)
+
+	headers, body, _ := strings.Cut(messages[0].Data, "\r\n\r\n")
+	assert.Contains(t, headers, "Subject: =?utf-8?q?Your_reservation_of_Room_=C3=841_was_approved?=")
+	assert.Contains(t, headers, "Content-Type: text/plain; charset=UTF-8")
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	require.NoError(t, err)
+	assert.Contains(t, string(decoded), "your reservation #1 of Room Ä1 was approved.")
+	assert.Contains(t, string(decoded), "Mon, 04 Mar 2030 14:00 UTC")
+
+	sent, err = service.SendNotifications(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent, "sent notifications are not sent again")
+}
+
+func TestNotificationsUseTheUserLocale(t *testing.T) {
+	db, service, _ := newService(t)
+
+	require.NoError(t, service.RememberRecipient(alice))
+	require.NoError(t, service.UpdatePreferences(alice, &notification.Preferences{Locale: "pt-BR"}))
+
+	r := reservation.Reservation{ID: 5, ResourceID: 1, UserID: alice.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	require.NoError(t, service.NotifyReservationReminder(r))
+	require.NoError(t, service.NotifyReservationReminder(r), "reminders are queued once per start time")
+
+	models := queued(t, db)
+	require.Len(t, models, 1)
+	assert.Equal(t, string(notification.KindReservationStarting), models[0].Kind)
+	assert.True(t, strings.HasPrefix(models[0].Body, "Olá"), models[0].Body)
+
+	err := service.UpdatePreferences(alice, &notification.Preferences{Locale: "xx"})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	err = service.UpdatePreferences(alice, &notification.Preferences{OptOut: []notification.Kind{"reservation.exploded"}})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+}
+
+func TestNotifyWaitlistPromoted(t *testing.T) {
+	db, service, _ := newService(t)
+
+	require.NoError(t, service.RememberRecipient(alice))
+	require.NoError(t, service.RememberRecipient(bob))
+	require.NoError(t, service.UpdatePreferences(bob, &notification.Preferences{OptOut: []notification.Kind{notification.KindWaitlistPromoted}}))
+
+	r := reservation.Reservation{ID: 9, ResourceID: 1, UserID: alice.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+	require.NoError(t, service.NotifyWaitlistPromoted(waitlist.Entry{ID: 3, UserID: alice.ID}, r))
+	require.NoError(t, service.NotifyWaitlistPromoted(waitlist.Entry{ID: 3, UserID: alice.ID}, r), "promotions are queued once per entry")
+	require.NoError(t, service.NotifyWaitlistPromoted(waitlist.Entry{ID: 4, UserID: bob.ID}, r))
+
+	models := queued(t, db)
+	require.Len(t, models, 1, "users can opt out of promotions")
+	assert.Equal(t, string(notification.KindWaitlistPromoted), models[0].Kind)
+	assert.Equal(t, alice.ID, models[0].UserID)
+	assert.Contains(t, models[0].Body, "pending reservation #9")
+}
+
+func TestSendNotificationsRetriesAndGivesUp(t *testing.T) {
+	db, service, server := newService(t)
+	ctx := context.Background()
+
+	require.NoError(t, service.RememberRecipient(alice))
+	require.NoError(t, service.HandleEvent(ctx, statusChanged(t, 1, alice.ID, reservation.StatusRejected)))
+	require.NoError(t, service.HandleEvent(ctx, statusChanged(t, 2, alice.ID, reservation.StatusApproved)))
+
+	server.RefuseNext(1)
+	sent, err := service.SendNotifications(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent, "a refused email does not hold up the others")
+
+	models := queued(t, db)
+	assert.Equal(t, string(notification.StatusPending), models[0].Status)
+	assert.Equal(t, 1, models[0].Attempts)
+	assert.Contains(t, models[0].LastError, "451")
+
+	time.Sleep(5 * time.Millisecond)
+	server.RefuseNext(1)
+	sent, err = service.SendNotifications(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	models = queued(t, db)
+	assert.Equal(t, string(notification.StatusFailed), models[0].Status, "failed after the last attempt")
+	assert.Equal(t, 2, models[0].Attempts)
+	assert.Equal(t, string(notification.StatusSent), models[1].Status)
+	assert.Len(t, server.Messages(), 1)
+}
@@ -0,0 +1,113 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sarc-ng/internal/domain/notification"
+	"strings"
+	"text/template"
+)
+
+// builtin holds the templates shipped with the application, one directory per
+// locale with a file per notification kind
+//
+//go:embed templates
+var builtin embed.FS
+
+// fallbackLocale is used when neither the user's nor the default locale has a template
+const fallbackLocale = "en"
+
+// Templates render notifications. Every template defines a "subject" and a
+// "body" template, which are given the reservation the notification is about.
+type Templates struct {
+	templates map[string]*template.Template // by "<locale>/<kind>"
+}
+
+// LoadTemplates parses the built-in templates and then those in dir, if
+// given, which add locales or replace built-in templates using the same layout
+func LoadTemplates(dir string) (*Templates, error) {
+	t := &Templates{templates: make(map[string]*template.Template)}
+
+	templates, err := fs.Sub(builtin, "templates")
+	if err != nil {
+		return nil, err
+	}
+	if err := t.load(templates); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := t.load(os.DirFS(dir)); err != nil {
+			return nil, fmt.Errorf("failed to load notification templates from %s: %w", dir, err)
+		}
+	}
+	return t, nil
+}
+
+// load parses every "<locale>/<kind>.tmpl" file of the file system
+func (t *Templates) load(files fs.FS) error {
+	paths, err := fs.Glob(files, "*/*.tmpl")
+	if err != nil {
+		return err
+	}
+	for _, p := range paths {
+		content, err := fs.ReadFile(files, p)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(p).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return err
+		}
+		for _, name := range []string{"subject", "body"} {
+			if tmpl.Lookup(name) == nil {
+				return fmt.Errorf("%s does not define a %q template", p, name)
+			}
+		}
+		t.templates[strings.TrimSuffix(p, ".tmpl")] = tmpl
+	}
+	return nil
+}
+
+// Supports reports whether there are templates in the locale or its language
+func (t *Templates) Supports(locale string) bool {
+	for key := range t.templates {
+		l := path.Dir(key)
+		if l == locale || l == language(locale) {
+			return true
+		}
+	}
+	return false
+}
+
+// Render renders the subject and body of a notification in the first locale
+// that has a template for its kind: the given locale, its language, the
+// default locale or English
+func (t *Templates) Render(kind notification.Kind, locale, defaultLocale string, data any) (string, string, error) {
+	for _, l := range []string{locale, language(locale), defaultLocale, language(defaultLocale), fallbackLocale} {
+		tmpl, ok := t.templates[l+"/"+string(kind)]
+		if l == "" || !ok {
+			continue
+		}
+
+		var subject, body bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+			return "", "", fmt.Errorf("failed to render %s subject: %w", kind, err)
+		}
+		if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+			return "", "", fmt.Errorf("failed to render %s body: %w", kind, err)
+		}
+		return strings.TrimSpace(subject.String()), body.String(), nil
+	}
+	return "", "", fmt.Errorf("no template for %s notifications", kind)
+}
+
+// language returns the language of a locale, e.g. "pt" for "pt-BR"
+func language(locale string) string {
+	l, _, _ := strings.Cut(locale, "-")
+	l, _, _ = strings.Cut(l, "_")
+	return l
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Worker sends queued notifications shortly after they are queued, so users
+// do not wait for the next scheduled send job
+type Worker struct {
+	service  *Service
+	interval time.Duration
+}
+
+// NewWorker creates a worker polling the queue at the given interval
+func NewWorker(service *Service, interval time.Duration) *Worker {
+	return &Worker{
+		service:  service,
+		interval: interval,
+	}
+}
+
+// Start sends notifications until the context is cancelled. It blocks, so
+// callers run it in its own goroutine.
+func (w *Worker) Start(ctx context.Context) {
+	log.Printf("Notification worker started, polling every %s", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Notification worker stopped")
+			return
+		case <-ticker.C:
+			if _, err := w.service.SendNotifications(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Failed to send notifications: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package policy
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/policy"
+)
+
+// Service implements policy.Usecase interface
+type Service struct {
+	repo     policy.Repository
+	defaults []policy.Policy
+}
+
+// Compile-time verification that Service implements policy.Usecase
+var _ policy.Usecase = (*Service)(nil)
+
+// NewService creates a new booking policy service. The defaults are the
+// policies loaded from configuration, stored by SeedPolicies.
+func NewService(repo policy.Repository, defaults []policy.Policy) *Service {
+	return &Service{
+		repo:     repo,
+		defaults: defaults,
+	}
+}
+
+// GetAllPolicies retrieves every booking policy
+func (s *Service) GetAllPolicies() ([]policy.Policy, error) {
+	return s.repo.ReadPolicyList()
+}
+
+// GetPolicy retrieves a booking policy by ID with validation
+func (s *Service) GetPolicy(id uint) (*policy.Policy, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: policy ID cannot be zero", common.ErrInvalidInput)
+	}
+	return s.repo.ReadPolicy(id)
+}
+
+// CreatePolicy creates the booking policy of a resource type
+func (s *Service) CreatePolicy(p *policy.Policy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindPolicyByResourceType(p.ResourceType)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate resource type: %w", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: resource type '%s' already has a policy", common.ErrConflict, p.ResourceType)
+	}
+
+	return s.repo.CreatePolicy(p)
+}
+
+// UpdatePolicy replaces the rules of an existing booking policy
+func (s *Service) UpdatePolicy(p *policy.Policy) error {
+	if p.ID == 0 {
+		return fmt.Errorf("%w: policy ID cannot be zero for update", common.ErrInvalidInput)
+	}
+
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	current, err := s.repo.ReadPolicy(p.ID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindPolicyByResourceType(p.ResourceType)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate resource type: %w", err)
+	}
+	if existing != nil && existing.ID != p.ID {
+		return fmt.Errorf("%w: resource type '%s' already has a policy", common.ErrConflict, p.ResourceType)
+	}
+
+	p.CreatedAt = current.CreatedAt
+	return s.repo.UpdatePolicy(p)
+}
+
+// DeletePolicy removes a booking policy by ID, lifting its rules
+func (s *Service) DeletePolicy(id uint) error {
+	if id == 0 {
+		return fmt.Errorf("%w: policy ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	if _, err := s.repo.ReadPolicy(id); err != nil {
+		return err
+	}
+
+	return s.repo.DeletePolicy(id)
+}
+
+// SeedPolicies stores the configured policies of the resource types that have
+// none yet. Policies edited through the API are left untouched.
+func (s *Service) SeedPolicies() (int, error) {
+	seeded := 0
+	for _, p := range s.defaults {
+		if err := p.Validate(); err != nil {
+			return seeded, fmt.Errorf("invalid policy for resource type '%s': %w", p.ResourceType, err)
+		}
+
+		existing, err := s.repo.FindPolicyByResourceType(p.ResourceType)
+		if err != nil {
+			return seeded, err
+		}
+		if existing != nil {
+			continue
+		}
+
+		if err := s.repo.CreatePolicy(&p); err != nil {
+			return seeded, err
+		}
+		seeded++
+	}
+	return seeded, nil
+}
@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/policy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedPolicies(t *testing.T) {
+	db := gormtest.Open(t, &policyAdapter.GormModel{})
+	defaults := []policy.Policy{
+		{ResourceType: "projector", MaxDuration: 4 * time.Hour, Buffer: 15 * time.Minute},
+		{ResourceType: "laboratory", AllowedGroups: []string{"teacher"}},
+	}
+	service := NewService(policyAdapter.NewGormAdapter(db), defaults)
+
+	seeded, err := service.SeedPolicies()
+	require.NoError(t, err)
+	assert.Equal(t, 2, seeded)
+
+	// Policies edited through the API survive a restart
+	policies, err := service.GetAllPolicies()
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	projector := policies[1]
+	require.Equal(t, "projector", projector.ResourceType)
+	projector.MaxDuration = 2 * time.Hour
+	require.NoError(t, service.UpdatePolicy(&projector))
+
+	seeded, err = service.SeedPolicies()
+	require.NoError(t, err)
+	assert.Equal(t, 0, seeded)
+	stored, err := service.GetPolicy(projector.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, stored.MaxDuration)
+	assert.Equal(t, 15*time.Minute, stored.Buffer)
+}
+
+func TestCreatePolicy(t *testing.T) {
+	db := gormtest.Open(t, &policyAdapter.GormModel{})
+	service := NewService(policyAdapter.NewGormAdapter(db), nil)
+
+	require.NoError(t, service.CreatePolicy(&policy.Policy{ResourceType: "projector", MaxDuration: time.Hour}))
+
+	err := service.CreatePolicy(&policy.Policy{ResourceType: "projector"})
+	assert.ErrorIs(t, err, common.ErrConflict)
+
+	err = service.CreatePolicy(&policy.Policy{ResourceType: "room", Hours: policy.Hours{TimeZone: "Nowhere/City"}})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+
+	assert.ErrorIs(t, service.DeletePolicy(99), common.ErrNotFound)
+}
@@ -0,0 +1,161 @@
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/resource"
+	"time"
+)
+
+// Service implements quota.Usecase interface
+type Service struct {
+	repo         quota.Repository
+	reservations reservation.Repository
+	resources    resource.Repository
+	settings     quota.Settings
+}
+
+// Compile-time verification that Service implements quota.Usecase
+var _ quota.Usecase = (*Service)(nil)
+
+// NewService creates a new quota service. The settings are the quota rules
+// loaded from configuration.
+func NewService(repo quota.Repository, reservations reservation.Repository, resources resource.Repository, settings quota.Settings) *Service {
+	return &Service{
+		repo:         repo,
+		reservations: reservations,
+		resources:    resources,
+		settings:     settings,
+	}
+}
+
+// GetQuota reports the remaining allowance of the user for the current week
+func (s *Service) GetQuota(user *auth.User) ([]quota.Allowance, error) {
+	if user == nil {
+		return nil, fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+
+	overrides, err := s.repo.ReadQuotaOverrideList(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	limits := s.settings.LimitsFor(user, overrides)
+	if len(limits) == 0 {
+		return []quota.Allowance{}, nil
+	}
+
+	now := time.Now()
+	weekStart, _ := s.settings.WeekOf(now)
+	owned, err := s.reservations.ReadReservationListByUser(user.ID, weekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[uint]string)
+	bookings := make([]quota.Booking, 0, len(owned))
+	for _, r := range owned {
+		if !r.Status.IsActive() {
+			continue
+		}
+		resourceType, ok := types[r.ResourceID]
+		if !ok {
+			res, err := s.resources.ReadResource(r.ResourceID)
+			switch {
+			case errors.Is(err, common.ErrNotFound):
+				// Bookings of deleted resources only count against quotas over every type
+			case err != nil:
+				return nil, err
+			default:
+				resourceType = res.Type
+			}
+			types[r.ResourceID] = resourceType
+		}
+		bookings = append(bookings, quota.Booking{ID: r.ID, ResourceType: resourceType, Start: r.StartTime, End: r.EndTime})
+	}
+
+	return s.settings.Allowances(limits, bookings, now), nil
+}
+
+// GetAllQuotaOverrides retrieves the overrides of a user, or of every user when userID is empty
+func (s *Service) GetAllQuotaOverrides(userID string) ([]quota.Override, error) {
+	return s.repo.ReadQuotaOverrideList(userID)
+}
+
+// GetQuotaOverride retrieves a quota override by ID with validation
+func (s *Service) GetQuotaOverride(id uint) (*quota.Override, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: quota override ID cannot be zero", common.ErrInvalidInput)
+	}
+	return s.repo.ReadQuotaOverride(id)
+}
+
+// CreateQuotaOverride grants a user an override of a quota, recording the manager who granted it
+func (s *Service) CreateQuotaOverride(manager *auth.User, o *quota.Override) error {
+	if manager == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindQuotaOverride(o.UserID, o.ResourceType)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate override: %w", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: user '%s' already has an override for this quota", common.ErrConflict, o.UserID)
+	}
+
+	o.GrantedBy = manager.ID
+	return s.repo.CreateQuotaOverride(o)
+}
+
+// UpdateQuotaOverride replaces the limits of an existing override, recording the manager who changed it
+func (s *Service) UpdateQuotaOverride(manager *auth.User, o *quota.Override) error {
+	if manager == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+
+	if o.ID == 0 {
+		return fmt.Errorf("%w: quota override ID cannot be zero for update", common.ErrInvalidInput)
+	}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	current, err := s.repo.ReadQuotaOverride(o.ID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.repo.FindQuotaOverride(o.UserID, o.ResourceType)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate override: %w", err)
+	}
+	if existing != nil && existing.ID != o.ID {
+		return fmt.Errorf("%w: user '%s' already has an override for this quota", common.ErrConflict, o.UserID)
+	}
+
+	o.GrantedBy = manager.ID
+	o.CreatedAt = current.CreatedAt
+	return s.repo.UpdateQuotaOverride(o)
+}
+
+// DeleteQuotaOverride removes an override by ID, holding the user to the configured rules again
+func (s *Service) DeleteQuotaOverride(id uint) error {
+	if id == 0 {
+		return fmt.Errorf("%w: quota override ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	if _, err := s.repo.ReadQuotaOverride(id); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteQuotaOverride(id)
+}
@@ -0,0 +1,62 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/quota"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetQuota(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &quotaAdapter.GormModel{})
+	lab := &resourceAdapter.GormModel{Name: "Lab 1", Type: "laboratory", IsAvailable: true}
+	require.NoError(t, db.Create(lab).Error)
+	settings := quota.Settings{Location: time.UTC, Rules: []quota.Rule{
+		{MaxActive: 3, MaxWeekly: 10 * time.Hour},
+		{ResourceType: "laboratory", MaxActive: 1},
+	}}
+	service := NewService(quotaAdapter.NewGormAdapter(db), reservationAdapter.NewGormAdapter(db), resourceAdapter.NewGormAdapter(db), settings)
+	student := &auth.User{ID: "student"}
+	manager := &auth.User{ID: "manager", Groups: []string{"manager"}}
+
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+	require.NoError(t, db.Create(&reservationAdapter.GormModel{
+		ResourceID: lab.ID, UserID: student.ID, StartTime: start, EndTime: start.Add(2 * time.Hour), Purpose: "Experiment", Status: "approved",
+	}).Error)
+	require.NoError(t, db.Create(&reservationAdapter.GormModel{
+		ResourceID: lab.ID, UserID: student.ID, StartTime: start.Add(3 * time.Hour), EndTime: start.Add(4 * time.Hour), Purpose: "Experiment", Status: "cancelled",
+	}).Error)
+
+	allowances, err := service.GetQuota(student)
+	require.NoError(t, err)
+	require.Len(t, allowances, 2)
+	assert.Equal(t, 2, allowances[0].RemainingActive())
+	assert.Equal(t, 0, allowances[1].RemainingActive())
+
+	t.Run("Managers waive quotas of individual users", func(t *testing.T) {
+		override := &quota.Override{UserID: student.ID, ResourceType: "laboratory", Waived: true, Reason: "Thesis"}
+		require.NoError(t, service.CreateQuotaOverride(manager, override))
+		assert.Equal(t, manager.ID, override.GrantedBy)
+
+		allowances, err := service.GetQuota(student)
+		require.NoError(t, err)
+		assert.Equal(t, -1, allowances[1].RemainingActive())
+
+		duplicate := &quota.Override{UserID: student.ID, ResourceType: "laboratory", MaxActive: 5}
+		assert.ErrorIs(t, service.CreateQuotaOverride(manager, duplicate), common.ErrConflict)
+	})
+
+	t.Run("Overrides must be well formed", func(t *testing.T) {
+		assert.ErrorIs(t, service.CreateQuotaOverride(manager, &quota.Override{MaxActive: 5}), common.ErrInvalidInput)
+		assert.ErrorIs(t, service.CreateQuotaOverride(manager, &quota.Override{UserID: "x", MaxActive: -1}), common.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,161 @@
+package reservation
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/reservation"
+	"time"
+)
+
+// CheckInReservation records that someone showed up for an approved reservation
+func (s *Service) CheckInReservation(actor *auth.User, id uint, token string) (*reservation.Reservation, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: reservation ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	r, err := s.repo.ReadReservation(id)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		if err := s.authorizeCheckInToken(r.ResourceID, token); err != nil {
+			return nil, err
+		}
+	} else if err := authorize(actor, r.UserID); err != nil {
+		return nil, err
+	}
+
+	if r.CheckedInAt != nil {
+		return nil, fmt.Errorf("%w: reservation is already checked in", common.ErrConflict)
+	}
+	if r.Status != reservation.StatusApproved {
+		return nil, fmt.Errorf("%w: %s reservations cannot be checked in", common.ErrConflict, r.Status)
+	}
+
+	now := time.Now()
+	opens, closes := s.checkIn.Window(r.StartTime, r.EndTime)
+	if now.Before(opens) {
+		return nil, fmt.Errorf("%w: check-in opens at %s", common.ErrConflict, opens.Format(time.RFC3339))
+	}
+	if !now.Before(closes) {
+		return nil, fmt.Errorf("%w: check-in closed at %s", common.ErrConflict, closes.Format(time.RFC3339))
+	}
+
+	before := *r
+	r.CheckedInAt = &now
+	err = s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.UpdateReservation(r); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionTransition, audit.EntityReservation, r.ID, &before, r)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.ReservationCheckedIn{Reservation: snapshot(*r), CheckedInAt: now})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ReleaseNoShows marks the approved reservations whose check-in closed while
+// they were still running as no-shows, records a no-show against their owners
+// and offers the freed time to the waitlist
+func (s *Service) ReleaseNoShows() (int, error) {
+	now := time.Now()
+	candidates, err := s.repo.FindReservationsMissingCheckIn(now.Add(-s.checkIn.GracePeriod))
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, candidate := range candidates {
+		var freed *reservation.Reservation
+		err := s.checkInUow.Do(func(checkins checkin.Repository, repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+			// Skip reservations checked in to or changed since they were found
+			r, err := repo.ReadReservation(candidate.ID)
+			if err != nil {
+				return err
+			}
+			if r.CheckedInAt != nil || r.Status != reservation.StatusApproved {
+				return nil
+			}
+
+			before := *r
+			previous := r.Status
+			r.Status = reservation.StatusNoShow
+			r.StatusReason = fmt.Sprintf("not checked in within %s of the start", s.checkIn.GracePeriod)
+			if err := repo.UpdateReservation(r); err != nil {
+				return err
+			}
+			if err := trail.RecordAuditEntries(audit.NewEntry(nil, audit.ActionTransition, audit.EntityReservation, r.ID, &before, r)); err != nil {
+				return err
+			}
+			if err := events.RecordEvents(transitionEvent(*r, previous)); err != nil {
+				return err
+			}
+			if err := checkins.CreateNoShow(&checkin.NoShow{
+				UserID:        r.UserID,
+				ReservationID: r.ID,
+				ResourceID:    r.ResourceID,
+				StartTime:     r.StartTime,
+			}); err != nil {
+				return err
+			}
+			freed = r
+			return nil
+		})
+		if errors.Is(err, common.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return released, fmt.Errorf("failed to release reservation %d: %w", candidate.ID, err)
+		}
+
+		if freed != nil {
+			released++
+			s.promoteWaitlist(freed)
+		}
+	}
+	if released > 0 {
+		log.Printf("Released %d reservations nobody checked in to", released)
+	}
+	return released, nil
+}
+
+// authorizeCheckInToken checks that the token is an unrevoked check-in token of the resource
+func (s *Service) authorizeCheckInToken(resourceID uint, token string) error {
+	checkInToken, err := s.checkins.ReadCheckInTokenByHash(checkin.HashToken(token))
+	if errors.Is(err, common.ErrNotFound) {
+		return fmt.Errorf("%w: invalid check-in token", common.ErrUnauthorized)
+	}
+	if err != nil {
+		return err
+	}
+
+	// A token of another resource is reported like an unknown one
+	if checkInToken.ResourceID != resourceID || checkInToken.IsRevoked() {
+		return fmt.Errorf("%w: invalid check-in token", common.ErrUnauthorized)
+	}
+	return nil
+}
+
+// checkNoShows verifies that the owner has not missed so many reservations
+// within the policy's no-show period that booking is suspended
+func (s *Service) checkNoShows(p *policy.Policy, ownerID string) error {
+	if p == nil || p.NoShowLimit == 0 {
+		return nil
+	}
+	noShows, err := s.checkins.CountNoShows(ownerID, time.Now().Add(-p.NoShowPeriod))
+	if err != nil {
+		return err
+	}
+	return p.CheckNoShows(noShows)
+}
@@ -0,0 +1,173 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	checkinAdapter "sarc-ng/internal/adapter/gorm/checkin"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	waitlistAdapter "sarc-ng/internal/adapter/gorm/waitlist"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/waitlist"
+	checkinService "sarc-ng/internal/service/checkin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInReservation(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{},
+		&checkinAdapter.TokenGormModel{}, &checkinAdapter.NoShowGormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	other := &resourceAdapter.GormModel{Name: "Lab 2", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(other).Error)
+	service := newTestService(db)
+	tokens := checkinService.NewService(checkinAdapter.NewGormAdapter(db), resourceAdapter.NewGormAdapter(db))
+
+	book := func(start time.Time, status reservation.Status) uint {
+		r := &reservationAdapter.GormModel{ResourceID: room.ID, UserID: owner.ID, StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Lecture", Status: string(status)}
+		require.NoError(t, db.Create(r).Error)
+		return r.ID
+	}
+	now := time.Now()
+
+	t.Run("Owner checks in", func(t *testing.T) {
+		id := book(now.Add(5*time.Minute), reservation.StatusApproved)
+		r, err := service.CheckInReservation(owner, id, "")
+		require.NoError(t, err)
+		require.NotNil(t, r.CheckedInAt)
+
+		_, err = service.CheckInReservation(owner, id, "")
+		assert.ErrorIs(t, err, common.ErrConflict)
+	})
+
+	t.Run("Only the owner or a manager without a token", func(t *testing.T) {
+		id := book(now, reservation.StatusApproved)
+		_, err := service.CheckInReservation(stranger, id, "")
+		assert.ErrorIs(t, err, common.ErrForbidden)
+		_, err = service.CheckInReservation(nil, id, "")
+		assert.ErrorIs(t, err, common.ErrUnauthorized)
+		_, err = service.CheckInReservation(manager, id, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("QR code token of the resource", func(t *testing.T) {
+		_, revoked, err := tokens.IssueCheckInToken(manager, room.ID)
+		require.NoError(t, err)
+		_, secret, err := tokens.IssueCheckInToken(manager, room.ID)
+		require.NoError(t, err)
+		_, elsewhere, err := tokens.IssueCheckInToken(manager, other.ID)
+		require.NoError(t, err)
+
+		id := book(now.Add(-5*time.Minute), reservation.StatusApproved)
+		for _, token := range []string{revoked, elsewhere, "made-up"} {
+			_, err := service.CheckInReservation(nil, id, token)
+			assert.ErrorIs(t, err, common.ErrUnauthorized)
+		}
+		_, err = service.CheckInReservation(nil, id, secret)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Kiosk keys issue tokens in their buildings", func(t *testing.T) {
+		building := &buildingAdapter.GormModel{Name: "Lobby", Code: "LOBBY"}
+		require.NoError(t, db.Create(building).Error)
+		lobby := building.ID
+		require.NoError(t, db.Model(other).Update("building_id", lobby).Error)
+		kiosk := &auth.User{ID: "apikey:1", Scopes: []auth.Permission{auth.PermissionCheckInIssue}, Buildings: []uint{lobby}}
+
+		token, _, err := tokens.IssueCheckInToken(kiosk, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "apikey:1", token.IssuedBy)
+		_, _, err = tokens.IssueCheckInToken(kiosk, room.ID)
+		assert.ErrorIs(t, err, common.ErrForbidden)
+	})
+
+	t.Run("Outside the check-in window", func(t *testing.T) {
+		_, err := service.CheckInReservation(owner, book(now.Add(time.Hour), reservation.StatusApproved), "")
+		assert.ErrorIs(t, err, common.ErrConflict, "too early")
+		_, err = service.CheckInReservation(owner, book(now.Add(-20*time.Minute), reservation.StatusApproved), "")
+		assert.ErrorIs(t, err, common.ErrConflict, "past the grace period")
+		_, err = service.CheckInReservation(owner, book(now, reservation.StatusPending), "")
+		assert.ErrorIs(t, err, common.ErrConflict, "not approved")
+	})
+}
+
+func TestReleaseNoShows(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{},
+		&quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{}, &checkinAdapter.TokenGormModel{}, &checkinAdapter.NoShowGormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	hall := &resourceAdapter.GormModel{Name: "Hall", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(hall).Error)
+	service := newTestService(db)
+	noShows := checkinAdapter.NewGormAdapter(db)
+
+	now := time.Now()
+	book := func(resourceID uint, start time.Time, status reservation.Status, checkedIn bool) uint {
+		r := &reservationAdapter.GormModel{ResourceID: resourceID, UserID: owner.ID, StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Lecture", Status: string(status)}
+		if checkedIn {
+			r.CheckedInAt = &start
+		}
+		require.NoError(t, db.Create(r).Error)
+		return r.ID
+	}
+	missed := book(room.ID, now.Add(-30*time.Minute), reservation.StatusApproved, false)
+	attended := book(hall.ID, now.Add(-30*time.Minute), reservation.StatusApproved, true)
+	inGrace := book(hall.ID, now.Add(-5*time.Minute), reservation.StatusApproved, false)
+	pending := book(hall.ID, now.Add(-30*time.Minute), reservation.StatusPending, false)
+	over := book(room.ID, now.Add(-2*time.Hour), reservation.StatusApproved, false)
+
+	entries := waitlistAdapter.NewGormAdapter(db)
+	entry := &waitlist.Entry{ResourceID: room.ID, UserID: stranger.ID, StartTime: now.Add(5 * time.Minute), EndTime: now.Add(25 * time.Minute),
+		Purpose: "Study", Status: waitlist.StatusWaiting, ExpiresAt: now.Add(5 * time.Minute)}
+	require.NoError(t, entries.CreateWaitlistEntry(entry))
+
+	released, err := service.ReleaseNoShows()
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+
+	status := func(id uint) reservation.Status {
+		r, err := service.GetReservation(manager, id)
+		require.NoError(t, err)
+		return r.Status
+	}
+	assert.Equal(t, reservation.StatusNoShow, status(missed))
+	assert.Equal(t, reservation.StatusApproved, status(attended))
+	assert.Equal(t, reservation.StatusApproved, status(inGrace))
+	assert.Equal(t, reservation.StatusPending, status(pending))
+	assert.Equal(t, reservation.StatusApproved, status(over), "reservations already over are left alone")
+
+	recorded, err := noShows.ReadNoShowList(owner.ID)
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	assert.Equal(t, missed, recorded[0].ReservationID)
+
+	promoted, err := entries.ReadWaitlistEntry(entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, waitlist.StatusPromoted, promoted.Status, "the freed time goes to the waitlist")
+
+	t.Run("Repeat offenders are suspended by the policy", func(t *testing.T) {
+		require.NoError(t, db.Create(&policyAdapter.GormModel{ResourceType: "room", NoShowLimit: 2, NoShowPeriodMinutes: 30 * 24 * 60}).Error)
+		start := now.Add(48 * time.Hour).Truncate(time.Hour)
+		require.NoError(t, service.CreateReservation(owner, &reservation.Reservation{ResourceID: room.ID, StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Lecture"}))
+
+		require.NoError(t, noShows.CreateNoShow(&checkin.NoShow{UserID: owner.ID, ReservationID: over, ResourceID: room.ID, StartTime: now.Add(-2 * time.Hour)}))
+		err := service.CreateReservation(owner, &reservation.Reservation{ResourceID: room.ID, StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour), Purpose: "Lecture"})
+		ruleErr, ok := common.AsRuleError(err)
+		require.True(t, ok, "got %v", err)
+		assert.Equal(t, policy.RuleNoShows, ruleErr.Rule)
+		assert.ErrorIs(t, err, common.ErrForbidden)
+	})
+}
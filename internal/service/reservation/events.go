@@ -0,0 +1,29 @@
+package reservation
+
+import (
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/reservation"
+)
+
+// snapshot returns the state of a reservation carried by its events
+func snapshot(r reservation.Reservation) event.Reservation {
+	return event.Reservation{
+		ID:           r.ID,
+		ResourceID:   r.ResourceID,
+		UserID:       r.UserID,
+		StartTime:    r.StartTime,
+		EndTime:      r.EndTime,
+		Status:       string(r.Status),
+		StatusReason: r.StatusReason,
+		SeriesID:     r.SeriesID,
+	}
+}
+
+// transitionEvent returns the event recorded when a reservation moved from
+// the previous status to its current one
+func transitionEvent(r reservation.Reservation, previous reservation.Status) event.Event {
+	if r.Status == reservation.StatusCancelled {
+		return event.ReservationCancelled{Reservation: snapshot(r)}
+	}
+	return event.ReservationStatusChanged{Reservation: snapshot(r), PreviousStatus: string(previous)}
+}
@@ -0,0 +1,94 @@
+package reservation
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/reservation"
+	"time"
+)
+
+// ExpirePendingReservations marks the pending reservations that started
+// without being approved or rejected as expired and offers the rest of their
+// time to the waitlist
+func (s *Service) ExpirePendingReservations() (int, error) {
+	now := time.Now()
+	query := common.Query{}.
+		Where("status", common.OpEqual, reservation.StatusPending).
+		Where("startTime", common.OpLessOrEqual, now)
+	stale, err := s.repo.ReadReservationList(query)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, r := range stale.Items {
+		freed, err := s.transitionReservation(nil, r.ID, reservation.StatusExpired, "not reviewed before it started")
+		if errors.Is(err, common.ErrConflict) || errors.Is(err, common.ErrNotFound) {
+			// Reviewed or removed since it was read
+			continue
+		}
+		if err != nil {
+			return expired, fmt.Errorf("failed to expire reservation %d: %w", r.ID, err)
+		}
+		expired++
+		s.promoteWaitlist(freed)
+	}
+	return expired, nil
+}
+
+// CompleteReservations marks the approved reservations that ended as completed
+func (s *Service) CompleteReservations() (int, error) {
+	query := common.Query{}.
+		Where("status", common.OpEqual, reservation.StatusApproved).
+		Where("endTime", common.OpLessOrEqual, time.Now())
+	over, err := s.repo.ReadReservationList(query)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for _, r := range over.Items {
+		_, err := s.transitionReservation(nil, r.ID, reservation.StatusCompleted, "")
+		if errors.Is(err, common.ErrConflict) || errors.Is(err, common.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return completed, fmt.Errorf("failed to complete reservation %d: %w", r.ID, err)
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// SendReservationReminders reminds owners of their approved reservations
+// starting within the lead time. Reservations whose reminder could not be sent
+// are tried again on the next run.
+func (s *Service) SendReservationReminders(lead time.Duration) (int, error) {
+	if lead <= 0 {
+		return 0, fmt.Errorf("%w: reminder lead time must be positive", common.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	upcoming, err := s.repo.FindReservationsToRemind(now, now.Add(lead))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, r := range upcoming {
+		if err := s.reminders.NotifyReservationReminder(r); err != nil {
+			log.Printf("Failed to remind user %s of reservation %d: %v", r.UserID, r.ID, err)
+			continue
+		}
+		sent++
+
+		remindedAt := time.Now()
+		r.RemindedAt = &remindedAt
+		if err := s.repo.UpdateReservation(&r); err != nil {
+			return sent, fmt.Errorf("failed to record reminder of reservation %d: %w", r.ID, err)
+		}
+	}
+	return sent, nil
+}
@@ -0,0 +1,102 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	checkinAdapter "sarc-ng/internal/adapter/gorm/checkin"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	waitlistAdapter "sarc-ng/internal/adapter/gorm/waitlist"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/internal/domain/reservation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReservationLifecycleJobs(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{},
+		&quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{}, &checkinAdapter.NoShowGormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	notifier := &recordingNotifier{}
+	service := NewService(
+		reservationAdapter.NewGormAdapter(db),
+		reservationAdapter.NewUnitOfWork(db),
+		resourceAdapter.NewGormAdapter(db),
+		policyAdapter.NewGormAdapter(db),
+		quotaAdapter.NewGormAdapter(db),
+		quota.Settings{},
+		waitlistAdapter.NewUnitOfWork(db),
+		notifier,
+		checkinAdapter.NewGormAdapter(db),
+		checkinAdapter.NewUnitOfWork(db),
+		checkin.Settings{GracePeriod: 15 * time.Minute},
+		notifier,
+	)
+
+	book := func(start time.Time, status reservation.Status) uint {
+		r := &reservationAdapter.GormModel{ResourceID: room.ID, UserID: owner.ID, StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Lecture", Status: string(status)}
+		require.NoError(t, db.Create(r).Error)
+		return r.ID
+	}
+	status := func(id uint) reservation.Status {
+		var model reservationAdapter.GormModel
+		require.NoError(t, db.First(&model, id).Error)
+		return reservation.Status(model.Status)
+	}
+	now := time.Now()
+
+	t.Run("Expire pending reservations that started", func(t *testing.T) {
+		stale := book(now.Add(-time.Minute), reservation.StatusPending)
+		upcoming := book(now.Add(2*time.Hour), reservation.StatusPending)
+
+		expired, err := service.ExpirePendingReservations()
+		require.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		assert.Equal(t, reservation.StatusExpired, status(stale))
+		assert.Equal(t, reservation.StatusPending, status(upcoming))
+
+		var recorded eventAdapter.OutboxGormModel
+		require.NoError(t, db.Where("aggregate_type = ? AND aggregate_id = ?", "reservation", stale).First(&recorded).Error)
+		assert.Equal(t, string(event.TypeReservationStatusChanged), recorded.Type)
+		assert.Contains(t, recorded.Payload, `"previousStatus":"pending"`)
+	})
+
+	t.Run("Complete approved reservations that ended", func(t *testing.T) {
+		over := book(now.Add(-3*time.Hour), reservation.StatusApproved)
+		ongoing := book(now.Add(-30*time.Minute), reservation.StatusApproved)
+		rejected := book(now.Add(-5*time.Hour), reservation.StatusRejected)
+
+		completed, err := service.CompleteReservations()
+		require.NoError(t, err)
+		assert.Equal(t, 1, completed)
+		assert.Equal(t, reservation.StatusCompleted, status(over))
+		assert.Equal(t, reservation.StatusApproved, status(ongoing))
+		assert.Equal(t, reservation.StatusRejected, status(rejected))
+	})
+
+	t.Run("Remind owners once", func(t *testing.T) {
+		soon := book(now.Add(30*time.Minute), reservation.StatusApproved)
+		book(now.Add(5*time.Hour), reservation.StatusApproved)
+		book(now.Add(20*time.Minute), reservation.StatusPending)
+
+		sent, err := service.SendReservationReminders(time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.Len(t, notifier.reminded, 1)
+		assert.Equal(t, soon, notifier.reminded[0].ID)
+
+		sent, err = service.SendReservationReminders(time.Hour)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+	})
+}
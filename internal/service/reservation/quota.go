@@ -0,0 +1,82 @@
+package reservation
+
+import (
+	"errors"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/internal/domain/reservation"
+	"time"
+)
+
+// checkQuota verifies that the bookings added for the owner keep them within
+// their quota. A manager booking on someone else's behalf is not held to it.
+// A non-zero excludeID leaves out the reservation being replaced. It must run
+// inside the unit of work that writes the bookings.
+func (s *Service) checkQuota(repo reservation.Repository, actor *auth.User, ownerID string, added []reservation.Reservation, excludeID uint) error {
+	if len(added) == 0 || actor == nil || actor.ID != ownerID {
+		return nil
+	}
+
+	overrides, err := s.quotas.ReadQuotaOverrideList(ownerID)
+	if err != nil {
+		return err
+	}
+	limits := s.quotaSettings.LimitsFor(actor, overrides)
+	if len(limits) == 0 {
+		return nil
+	}
+
+	types := make(map[uint]string)
+	addedBookings, err := s.quotaBookings(types, added, 0)
+	if err != nil {
+		return err
+	}
+
+	// Weekly totals need every booking since the earliest week touched
+	now := time.Now()
+	from := now
+	for _, b := range addedBookings {
+		if weekStart, _ := s.quotaSettings.WeekOf(b.Start); weekStart.Before(from) {
+			from = weekStart
+		}
+	}
+	owned, err := repo.ReadReservationListByUser(ownerID, from)
+	if err != nil {
+		return err
+	}
+	existing, err := s.quotaBookings(types, owned, excludeID)
+	if err != nil {
+		return err
+	}
+
+	return s.quotaSettings.Check(limits, existing, addedBookings, now)
+}
+
+// quotaBookings converts the active reservations other than excludeID into the
+// bookings counted against a quota, caching the resource types it looks up
+func (s *Service) quotaBookings(types map[uint]string, reservations []reservation.Reservation, excludeID uint) ([]quota.Booking, error) {
+	bookings := make([]quota.Booking, 0, len(reservations))
+	for _, r := range reservations {
+		if !r.Status.IsActive() || (excludeID != 0 && r.ID == excludeID) {
+			continue
+		}
+
+		resourceType, ok := types[r.ResourceID]
+		if !ok {
+			res, err := s.resources.ReadResource(r.ResourceID)
+			switch {
+			case errors.Is(err, common.ErrNotFound):
+				// Bookings of deleted resources only count against quotas over every type
+			case err != nil:
+				return nil, err
+			default:
+				resourceType = res.Type
+			}
+			types[r.ResourceID] = resourceType
+		}
+
+		bookings = append(bookings, quota.Booking{ID: r.ID, ResourceType: resourceType, Start: r.StartTime, End: r.EndTime})
+	}
+	return bookings, nil
+}
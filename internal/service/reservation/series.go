@@ -0,0 +1,657 @@
+package reservation
+
+import (
+	"errors"
+	"fmt"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/availability"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/pkg/recurrence"
+	"strings"
+	"time"
+)
+
+// maxSeriesOccurrences caps how many reservations a single series may create
+const maxSeriesOccurrences = 500
+
+// maxReportedConflicts limits how many conflicting dates are listed in an error
+const maxReportedConflicts = 5
+
+// CreateReservationSeries creates a recurring series owned by the actor and all
+// of its occurrences. Either every occurrence is booked or, if any of them
+// conflicts, breaks the booking policy or exceeds the actor's quota, none is.
+func (s *Service) CreateReservationSeries(actor *auth.User, series *reservation.Series) error {
+	if actor == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+	series.UserID = actor.ID
+
+	if err := validateSeries(series); err != nil {
+		return err
+	}
+
+	if series.StartTime.Before(time.Now()) {
+		return fmt.Errorf("%w: start time cannot be in the past", common.ErrInvalidInput)
+	}
+
+	occurrences, err := expandSeries(series, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	p, err := s.policyFor(series.ResourceID)
+	if err != nil {
+		return err
+	}
+	if err := checkSeriesPolicy(p, actor, occurrences); err != nil {
+		return err
+	}
+	if err := s.checkNoShows(p, series.UserID); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.LockReservationResource(series.ResourceID); err != nil {
+			return err
+		}
+		if err := s.checkQuota(repo, actor, series.UserID, occurrences, 0); err != nil {
+			return err
+		}
+		if err := checkSeriesConflicts(repo, p, series.ResourceID, occurrences); err != nil {
+			return err
+		}
+		if err := repo.CreateReservationSeries(series); err != nil {
+			return err
+		}
+		return createOccurrences(repo, events, trail, actor, series, occurrences)
+	})
+}
+
+// GetReservationSeries retrieves a series together with its occurrences on
+// behalf of an actor who may see it
+func (s *Service) GetReservationSeries(actor *auth.User, id uint) (*reservation.Series, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: series ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	series, err := s.readSeries(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeView(actor, series.UserID); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// readSeries reads a series together with its occurrences
+func (s *Service) readSeries(id uint) (*reservation.Series, error) {
+	series, err := s.repo.ReadReservationSeries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := s.repo.ReadReservationListBySeries(id)
+	if err != nil {
+		return nil, err
+	}
+	series.Occurrences = occurrences
+	return series, nil
+}
+
+// UpdateReservationOccurrence edits an occurrence of a series. Depending on the
+// scope the edit applies to that occurrence only, to it and every later one, or
+// to all upcoming occurrences. The resulting series is returned.
+func (s *Service) UpdateReservationOccurrence(actor *auth.User, id uint, scope reservation.EditScope, update reservation.OccurrenceUpdate) (*reservation.Series, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: reservation ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	if !scope.IsValid() {
+		return nil, fmt.Errorf("%w: edit scope must be one of %s, %s or %s",
+			common.ErrInvalidInput, reservation.EditThis, reservation.EditFollowing, reservation.EditAll)
+	}
+
+	if strings.TrimSpace(update.Purpose) == "" {
+		return nil, fmt.Errorf("%w: reservation purpose cannot be empty", common.ErrInvalidInput)
+	}
+
+	if update.StartTime.IsZero() || update.EndTime.IsZero() {
+		return nil, fmt.Errorf("%w: start and end time are required", common.ErrInvalidInput)
+	}
+
+	if !update.StartTime.Before(update.EndTime) {
+		return nil, fmt.Errorf("%w: start time must be before end time", common.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	if update.StartTime.Before(now) {
+		return nil, fmt.Errorf("%w: start time cannot be in the past", common.ErrInvalidInput)
+	}
+
+	occurrence, err := s.repo.ReadReservation(id)
+	if err != nil {
+		return nil, err
+	}
+	if occurrence.SeriesID == nil {
+		return nil, fmt.Errorf("%w: reservation is not part of a series", common.ErrInvalidInput)
+	}
+	if err := authorize(actor, occurrence.UserID); err != nil {
+		return nil, err
+	}
+	if occurrence.StartTime.Before(now) {
+		return nil, fmt.Errorf("%w: only upcoming occurrences can be edited", common.ErrConflict)
+	}
+	if occurrence.Status.IsFinal() {
+		return nil, fmt.Errorf("%w: %s reservations cannot be modified", common.ErrConflict, occurrence.Status)
+	}
+
+	switch scope {
+	case reservation.EditThis:
+		if update.RecurrenceRule != "" {
+			return nil, fmt.Errorf("%w: recurrence rule can only be changed for following or all occurrences", common.ErrInvalidInput)
+		}
+
+		r := *occurrence
+		r.StartTime = update.StartTime
+		r.EndTime = update.EndTime
+		r.Purpose = update.Purpose
+		r.Description = update.Description
+		if err := s.UpdateReservation(actor, &r); err != nil {
+			return nil, err
+		}
+		return s.readSeries(*occurrence.SeriesID)
+
+	case reservation.EditFollowing:
+		return s.splitSeries(actor, occurrence, update, now)
+
+	default:
+		return s.editWholeSeries(actor, occurrence, update, now)
+	}
+}
+
+// CancelReservationSeries cancels every upcoming active occurrence of a series,
+// handing the freed time to the waitlist
+func (s *Service) CancelReservationSeries(actor *auth.User, id uint) error {
+	if id == 0 {
+		return fmt.Errorf("%w: series ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	series, err := s.repo.ReadReservationSeries(id)
+	if err != nil {
+		return err
+	}
+	if err := authorize(actor, series.UserID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var cancelled []reservation.Reservation
+	err = s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		occurrences, err := repo.ReadReservationListBySeries(id)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range occurrences {
+			if o.StartTime.Before(now) || !o.Status.CanTransitionTo(reservation.StatusCancelled) {
+				continue
+			}
+			before := o
+			o.Status = reservation.StatusCancelled
+			if err := repo.UpdateReservation(&o); err != nil {
+				return err
+			}
+			if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionTransition, audit.EntityReservation, o.ID, &before, &o)); err != nil {
+				return err
+			}
+			if err := events.RecordEvents(event.ReservationCancelled{Reservation: snapshot(o)}); err != nil {
+				return err
+			}
+			cancelled = append(cancelled, o)
+		}
+
+		if len(cancelled) == 0 {
+			return fmt.Errorf("%w: series has no upcoming occurrences to cancel", common.ErrConflict)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range cancelled {
+		s.promoteWaitlist(&cancelled[i])
+	}
+	return nil
+}
+
+// editWholeSeries shifts the series by the change made to one occurrence and
+// regenerates every upcoming occurrence
+func (s *Service) editWholeSeries(actor *auth.User, occurrence *reservation.Reservation, update reservation.OccurrenceUpdate, now time.Time) (*reservation.Series, error) {
+	series, err := s.repo.ReadReservationSeries(*occurrence.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.policyFor(series.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := update.StartTime.Sub(occurrence.StartTime)
+	edited := *series
+	edited.StartTime = series.StartTime.Add(delta)
+	edited.EndTime = edited.StartTime.Add(update.EndTime.Sub(update.StartTime))
+	edited.Purpose = update.Purpose
+	edited.Description = update.Description
+	edited.ExceptionDates = shiftTimes(series.ExceptionDates, delta)
+	if update.RecurrenceRule != "" {
+		edited.RecurrenceRule = update.RecurrenceRule
+	}
+
+	err = s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.LockReservationResource(series.ResourceID); err != nil {
+			return err
+		}
+
+		existing, err := repo.ReadReservationListBySeries(series.ID)
+		if err != nil {
+			return err
+		}
+
+		// Occurrences cancelled or rejected individually stay that way
+		edited.ExceptionDates = append(edited.ExceptionDates, skippedOccurrences(existing, now, delta)...)
+
+		occurrences, err := expandSeries(&edited, now)
+		if err != nil {
+			return err
+		}
+		if len(occurrences) == 0 {
+			return fmt.Errorf("%w: edited series has no upcoming occurrences", common.ErrInvalidInput)
+		}
+		if err := checkSeriesPolicy(p, actor, occurrences); err != nil {
+			return err
+		}
+
+		if err := releaseOccurrences(repo, events, trail, actor, existing, now); err != nil {
+			return err
+		}
+		if err := s.checkQuota(repo, actor, edited.UserID, occurrences, 0); err != nil {
+			return err
+		}
+		if err := checkSeriesConflicts(repo, p, edited.ResourceID, occurrences); err != nil {
+			return err
+		}
+		if err := repo.UpdateReservationSeries(&edited); err != nil {
+			return err
+		}
+		return createOccurrences(repo, events, trail, actor, &edited, occurrences)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.readSeries(series.ID)
+}
+
+// splitSeries ends the original series before the occurrence and starts a new
+// series from the edited occurrence onwards
+func (s *Service) splitSeries(actor *auth.User, occurrence *reservation.Reservation, update reservation.OccurrenceUpdate, now time.Time) (*reservation.Series, error) {
+	series, err := s.repo.ReadReservationSeries(*occurrence.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := parseSeriesRule(series.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(series.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", common.ErrInvalidInput, series.TimeZone)
+	}
+
+	// Count the occurrences generated before the cutoff, exception dates included,
+	// so a COUNT rule can be divided between the two series
+	cutoff := occurrence.StartTime
+	starts, err := rule.Expand(series.StartTime.In(loc), nil, maxSeriesOccurrences)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrInvalidInput, err)
+	}
+	before := 0
+	for _, start := range starts {
+		if start.Before(cutoff) {
+			before++
+		}
+	}
+	if before == 0 {
+		// Nothing precedes the occurrence, so this and following means all
+		return s.editWholeSeries(actor, occurrence, update, now)
+	}
+
+	p, err := s.policyFor(series.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	head := *series
+	headRule := rule
+	if rule.Count > 0 {
+		headRule.Count = before
+	} else {
+		headRule.Until = cutoff.Add(-time.Second).UTC()
+		headRule.UntilIsDate = false
+	}
+	head.RecurrenceRule = headRule.String()
+	head.ExceptionDates = nil
+
+	tailRule := rule
+	if rule.Count > 0 {
+		tailRule.Count = rule.Count - before
+	}
+	delta := update.StartTime.Sub(occurrence.StartTime)
+	tail := reservation.Series{
+		ResourceID:     series.ResourceID,
+		UserID:         series.UserID,
+		Purpose:        update.Purpose,
+		Description:    update.Description,
+		StartTime:      update.StartTime,
+		EndTime:        update.EndTime,
+		TimeZone:       series.TimeZone,
+		RecurrenceRule: tailRule.String(),
+	}
+	if update.RecurrenceRule != "" {
+		tail.RecurrenceRule = update.RecurrenceRule
+	}
+	for _, exdate := range series.ExceptionDates {
+		if exdate.Before(cutoff) {
+			head.ExceptionDates = append(head.ExceptionDates, exdate)
+		} else {
+			tail.ExceptionDates = append(tail.ExceptionDates, exdate.Add(delta))
+		}
+	}
+
+	err = s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.LockReservationResource(series.ResourceID); err != nil {
+			return err
+		}
+
+		existing, err := repo.ReadReservationListBySeries(series.ID)
+		if err != nil {
+			return err
+		}
+		tail.ExceptionDates = append(tail.ExceptionDates, skippedOccurrences(existing, cutoff, delta)...)
+
+		occurrences, err := expandSeries(&tail, now)
+		if err != nil {
+			return err
+		}
+		if err := checkSeriesPolicy(p, actor, occurrences); err != nil {
+			return err
+		}
+
+		if err := releaseOccurrences(repo, events, trail, actor, existing, cutoff); err != nil {
+			return err
+		}
+		if err := s.checkQuota(repo, actor, tail.UserID, occurrences, 0); err != nil {
+			return err
+		}
+		if err := checkSeriesConflicts(repo, p, tail.ResourceID, occurrences); err != nil {
+			return err
+		}
+		if err := repo.UpdateReservationSeries(&head); err != nil {
+			return err
+		}
+		if err := repo.CreateReservationSeries(&tail); err != nil {
+			return err
+		}
+		return createOccurrences(repo, events, trail, actor, &tail, occurrences)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.readSeries(tail.ID)
+}
+
+// validateSeries checks the fields of a new series and defaults its time zone to UTC
+func validateSeries(series *reservation.Series) error {
+	if series.ResourceID == 0 {
+		return fmt.Errorf("%w: resource ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	if series.UserID == "" {
+		return fmt.Errorf("%w: user ID cannot be empty", common.ErrInvalidInput)
+	}
+
+	if strings.TrimSpace(series.Purpose) == "" {
+		return fmt.Errorf("%w: reservation purpose cannot be empty", common.ErrInvalidInput)
+	}
+
+	if series.StartTime.IsZero() || series.EndTime.IsZero() {
+		return fmt.Errorf("%w: start and end time are required", common.ErrInvalidInput)
+	}
+
+	if !series.StartTime.Before(series.EndTime) {
+		return fmt.Errorf("%w: start time must be before end time", common.ErrInvalidInput)
+	}
+
+	if series.TimeZone == "" {
+		series.TimeZone = "UTC"
+	}
+	if series.TimeZone == "Local" {
+		return fmt.Errorf("%w: time zone must be an IANA zone name", common.ErrInvalidInput)
+	}
+	if _, err := time.LoadLocation(series.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", common.ErrInvalidInput, series.TimeZone)
+	}
+
+	return nil
+}
+
+// parseSeriesRule parses a series rule and requires it to end through COUNT or UNTIL
+func parseSeriesRule(value string) (recurrence.Rule, error) {
+	rule, err := recurrence.Parse(value)
+	if err != nil {
+		return recurrence.Rule{}, fmt.Errorf("%w: %w", common.ErrInvalidInput, err)
+	}
+	if !rule.IsBounded() {
+		return recurrence.Rule{}, fmt.Errorf("%w: recurrence rule must end with COUNT or UNTIL", common.ErrInvalidInput)
+	}
+	return rule, nil
+}
+
+// expandSeries returns the pending occurrences of a series starting after the given time
+func expandSeries(series *reservation.Series, after time.Time) ([]reservation.Reservation, error) {
+	rule, err := parseSeriesRule(series.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(series.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", common.ErrInvalidInput, series.TimeZone)
+	}
+
+	starts, err := rule.Expand(series.StartTime.In(loc), series.ExceptionDates, maxSeriesOccurrences)
+	if errors.Is(err, recurrence.ErrTooManyOccurrences) {
+		return nil, fmt.Errorf("%w: a series cannot have more than %d occurrences", common.ErrInvalidInput, maxSeriesOccurrences)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	duration := series.Duration()
+	var occurrences []reservation.Reservation
+	for i, start := range starts {
+		if i > 0 && starts[i-1].Add(duration).After(start) {
+			return nil, fmt.Errorf("%w: occurrences of the series overlap each other", common.ErrInvalidInput)
+		}
+		if !start.After(after) {
+			continue
+		}
+		occurrences = append(occurrences, reservation.Reservation{
+			ResourceID:  series.ResourceID,
+			UserID:      series.UserID,
+			StartTime:   start,
+			EndTime:     start.Add(duration),
+			Purpose:     series.Purpose,
+			Description: series.Description,
+			Status:      reservation.StatusPending,
+		})
+	}
+
+	if len(occurrences) == 0 && after.IsZero() {
+		return nil, fmt.Errorf("%w: recurrence rule produces no occurrences", common.ErrInvalidInput)
+	}
+	return occurrences, nil
+}
+
+// checkSeriesPolicy verifies every occurrence against the policy, if there is
+// one. A violation names the first occurrence that breaks a rule.
+func checkSeriesPolicy(p *policy.Policy, booker *auth.User, occurrences []reservation.Reservation) error {
+	for _, o := range occurrences {
+		err := checkPolicy(p, booker, o.StartTime, o.EndTime)
+		if ruleErr, ok := common.AsRuleError(err); ok {
+			return common.NewRuleError(ruleErr.Err, ruleErr.Rule, "occurrence at %s: %s",
+				o.StartTime.Format(time.RFC3339), ruleErr.Message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSeriesConflicts fails with ErrConflict listing the occurrences that overlap
+// existing reservations, or with a rule error naming the first occurrence that
+// leaves less than the policy's buffer to another booking. The caller must hold
+// the resource lock.
+func checkSeriesConflicts(repo reservation.Repository, p *policy.Policy, resourceID uint, occurrences []reservation.Reservation) error {
+	if len(occurrences) == 0 {
+		return nil
+	}
+
+	var buffer time.Duration
+	if p != nil {
+		buffer = p.Buffer
+	}
+
+	// A single query over the whole span of the series, matched in memory
+	first, last := occurrences[0], occurrences[len(occurrences)-1]
+	existing, err := repo.FindOverlappingReservations(resourceID, first.StartTime.Add(-buffer), last.EndTime.Add(buffer), 0)
+	if err != nil {
+		return fmt.Errorf("failed to check availability: %w", err)
+	}
+
+	var conflicting []string
+	for _, o := range occurrences {
+		for _, e := range existing {
+			if e.StartTime.Before(o.EndTime) && e.EndTime.After(o.StartTime) {
+				conflicting = append(conflicting, o.StartTime.Format(time.RFC3339))
+				break
+			}
+		}
+	}
+
+	if len(conflicting) == 0 {
+		return checkSeriesBuffer(p, occurrences, existing)
+	}
+	listed := conflicting
+	if len(listed) > maxReportedConflicts {
+		listed = listed[:maxReportedConflicts]
+	}
+	return fmt.Errorf("%w: resource is not available for %d occurrence(s): %s",
+		common.ErrConflict, len(conflicting), strings.Join(listed, ", "))
+}
+
+// checkSeriesBuffer verifies that no occurrence comes closer to an existing
+// reservation than the policy's buffer allows
+func checkSeriesBuffer(p *policy.Policy, occurrences, existing []reservation.Reservation) error {
+	if p == nil || p.Buffer <= 0 || len(existing) == 0 {
+		return nil
+	}
+
+	neighbours := make([]availability.Window, len(existing))
+	for i, e := range existing {
+		neighbours[i] = availability.Window{Start: e.StartTime, End: e.EndTime}
+	}
+	for _, o := range occurrences {
+		err := p.CheckBuffer(policy.Booking{Start: o.StartTime, End: o.EndTime}, neighbours)
+		if ruleErr, ok := common.AsRuleError(err); ok {
+			return common.NewRuleError(ruleErr.Err, ruleErr.Rule, "occurrence at %s: %s",
+				o.StartTime.Format(time.RFC3339), ruleErr.Message)
+		}
+	}
+	return nil
+}
+
+// createOccurrences stores the occurrences linked to the series, recording them
+// as created by the actor
+func createOccurrences(repo reservation.Repository, events event.Outbox, trail audit.Trail, actor *auth.User, series *reservation.Series, occurrences []reservation.Reservation) error {
+	seriesID := series.ID
+	for i := range occurrences {
+		occurrences[i].SeriesID = &seriesID
+		if err := repo.CreateReservation(&occurrences[i]); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionCreate, audit.EntityReservation, occurrences[i].ID, nil, &occurrences[i])); err != nil {
+			return err
+		}
+		if err := events.RecordEvents(event.ReservationCreated{Reservation: snapshot(occurrences[i])}); err != nil {
+			return err
+		}
+	}
+	series.Occurrences = occurrences
+	return nil
+}
+
+// releaseOccurrences removes the active occurrences starting at or after from so they can be regenerated,
+// recording them as deleted by the actor
+func releaseOccurrences(repo reservation.Repository, events event.Outbox, trail audit.Trail, actor *auth.User, occurrences []reservation.Reservation, from time.Time) error {
+	for _, o := range occurrences {
+		if o.StartTime.Before(from) || !o.Status.IsActive() {
+			continue
+		}
+		if err := repo.DeleteReservation(o.ID); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionDelete, audit.EntityReservation, o.ID, &o, nil)); err != nil {
+			return err
+		}
+		if err := events.RecordEvents(event.ReservationDeleted{Reservation: snapshot(o)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skippedOccurrences returns the shifted start times of inactive occurrences at
+// or after from, so regenerating the series does not bring them back
+func skippedOccurrences(occurrences []reservation.Reservation, from time.Time, delta time.Duration) []time.Time {
+	var skipped []time.Time
+	for _, o := range occurrences {
+		if !o.StartTime.Before(from) && !o.Status.IsActive() {
+			skipped = append(skipped, o.StartTime.Add(delta))
+		}
+	}
+	return skipped
+}
+
+// shiftTimes returns a copy of times moved by delta
+func shiftTimes(times []time.Time, delta time.Duration) []time.Time {
+	if len(times) == 0 {
+		return nil
+	}
+	shifted := make([]time.Time, len(times))
+	for i, t := range times {
+		shifted[i] = t.Add(delta)
+	}
+	return shifted
+}
@@ -0,0 +1,224 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	waitlistAdapter "sarc-ng/internal/adapter/gorm/waitlist"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/reservation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newSeriesTestService(t *testing.T) (*Service, *gorm.DB, uint) {
+	t.Helper()
+
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &reservationAdapter.SeriesGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+
+	return newTestService(db), db, room.ID
+}
+
+func weeklySeries(resourceID uint, start time.Time, rule string) *reservation.Series {
+	return &reservation.Series{
+		ResourceID:     resourceID,
+		UserID:         "user-1",
+		StartTime:      start,
+		EndTime:        start.Add(2 * time.Hour),
+		TimeZone:       "UTC",
+		RecurrenceRule: rule,
+		Purpose:        "Algorithms lecture",
+	}
+}
+
+func occurrenceStarts(occurrences []reservation.Reservation, status reservation.Status) []time.Time {
+	var starts []time.Time
+	for _, o := range occurrences {
+		if o.Status == status {
+			starts = append(starts, o.StartTime.UTC())
+		}
+	}
+	return starts
+}
+
+func TestCreateReservationSeries(t *testing.T) {
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(24 * time.Hour).Add(14 * time.Hour)
+	week := 7 * 24 * time.Hour
+
+	t.Run("Books every occurrence as pending", func(t *testing.T) {
+		service, _, roomID := newSeriesTestService(t)
+
+		series := weeklySeries(roomID, start, "FREQ=WEEKLY;COUNT=4")
+		require.NoError(t, service.CreateReservationSeries(owner, series))
+
+		stored, err := service.GetReservationSeries(owner, series.ID)
+		require.NoError(t, err)
+		_, err = service.GetReservationSeries(stranger, series.ID)
+		assert.ErrorIs(t, err, common.ErrNotFound, "only the owner and reviewers see the series")
+		assert.Equal(t,
+			[]time.Time{start, start.Add(week), start.Add(2 * week), start.Add(3 * week)},
+			occurrenceStarts(stored.Occurrences, reservation.StatusPending))
+		for _, o := range stored.Occurrences {
+			require.NotNil(t, o.SeriesID)
+			assert.Equal(t, series.ID, *o.SeriesID)
+		}
+	})
+
+	t.Run("Conflicting occurrence books nothing", func(t *testing.T) {
+		service, db, roomID := newSeriesTestService(t)
+
+		require.NoError(t, service.CreateReservation(owner, &reservation.Reservation{
+			ResourceID: roomID,
+			UserID:     "user-2",
+			StartTime:  start.Add(2*week + time.Hour),
+			EndTime:    start.Add(2*week + 3*time.Hour),
+			Purpose:    "Exam",
+		}))
+
+		err := service.CreateReservationSeries(owner, weeklySeries(roomID, start, "FREQ=WEEKLY;COUNT=4"))
+		require.ErrorIs(t, err, common.ErrConflict)
+		assert.Contains(t, err.Error(), start.Add(2*week).Format(time.RFC3339))
+
+		var reservations, series int64
+		require.NoError(t, db.Model(&reservationAdapter.GormModel{}).Count(&reservations).Error)
+		require.NoError(t, db.Model(&reservationAdapter.SeriesGormModel{}).Count(&series).Error)
+		assert.Equal(t, int64(1), reservations)
+		assert.Equal(t, int64(0), series)
+	})
+
+	invalid := map[string]*reservation.Series{
+		"Unbounded rule":       weeklySeries(1, start, "FREQ=WEEKLY"),
+		"Malformed rule":       weeklySeries(1, start, "FREQ=HOURLY;COUNT=3"),
+		"Overlapping instance": weeklySeries(1, start, "FREQ=DAILY;COUNT=3"),
+		"Too many occurrences": weeklySeries(1, start, "FREQ=WEEKLY;COUNT=1000"),
+		"Unknown time zone":    weeklySeries(1, start, "FREQ=WEEKLY;COUNT=2"),
+	}
+	invalid["Overlapping instance"].EndTime = start.Add(25 * time.Hour)
+	invalid["Unknown time zone"].TimeZone = "Mars/Olympus"
+	for name, series := range invalid {
+		t.Run("Rejects "+name, func(t *testing.T) {
+			service, _, _ := newSeriesTestService(t)
+			assert.ErrorIs(t, service.CreateReservationSeries(owner, series), common.ErrInvalidInput)
+		})
+	}
+}
+
+func TestUpdateReservationOccurrence(t *testing.T) {
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(24 * time.Hour).Add(14 * time.Hour)
+	week := 7 * 24 * time.Hour
+
+	setup := func(t *testing.T) (*Service, *reservation.Series) {
+		service, _, roomID := newSeriesTestService(t)
+		series := weeklySeries(roomID, start, "FREQ=WEEKLY;COUNT=4")
+		require.NoError(t, service.CreateReservationSeries(owner, series))
+		return service, series
+	}
+	moveByHour := func(o reservation.Reservation) reservation.OccurrenceUpdate {
+		return reservation.OccurrenceUpdate{
+			StartTime: o.StartTime.Add(time.Hour),
+			EndTime:   o.EndTime.Add(time.Hour),
+			Purpose:   "Moved lecture",
+		}
+	}
+
+	t.Run("Only the owner or a manager", func(t *testing.T) {
+		service, series := setup(t)
+		target := series.Occurrences[1]
+
+		_, err := service.UpdateReservationOccurrence(stranger, target.ID, reservation.EditAll, moveByHour(target))
+		assert.ErrorIs(t, err, common.ErrForbidden)
+
+		_, err = service.UpdateReservationOccurrence(manager, target.ID, reservation.EditThis, moveByHour(target))
+		assert.NoError(t, err)
+	})
+
+	t.Run("This occurrence only", func(t *testing.T) {
+		service, series := setup(t)
+		target := series.Occurrences[1]
+
+		updated, err := service.UpdateReservationOccurrence(owner, target.ID, reservation.EditThis, moveByHour(target))
+		require.NoError(t, err)
+		assert.Equal(t,
+			[]time.Time{start, start.Add(week + time.Hour), start.Add(2 * week), start.Add(3 * week)},
+			occurrenceStarts(updated.Occurrences, reservation.StatusPending))
+	})
+
+	t.Run("This and following splits the series", func(t *testing.T) {
+		service, series := setup(t)
+		target := series.Occurrences[2]
+
+		tail, err := service.UpdateReservationOccurrence(owner, target.ID, reservation.EditFollowing, moveByHour(target))
+		require.NoError(t, err)
+		assert.NotEqual(t, series.ID, tail.ID)
+		assert.Equal(t, "FREQ=WEEKLY;COUNT=2", tail.RecurrenceRule)
+		assert.Equal(t,
+			[]time.Time{start.Add(2*week + time.Hour), start.Add(3*week + time.Hour)},
+			occurrenceStarts(tail.Occurrences, reservation.StatusPending))
+
+		head, err := service.GetReservationSeries(owner, series.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "FREQ=WEEKLY;COUNT=2", head.RecurrenceRule)
+		assert.Equal(t,
+			[]time.Time{start, start.Add(week)},
+			occurrenceStarts(head.Occurrences, reservation.StatusPending))
+	})
+
+	t.Run("All occurrences keeps individual cancellations", func(t *testing.T) {
+		service, series := setup(t)
+		require.NoError(t, service.CancelReservation(owner, series.Occurrences[3].ID))
+		target := series.Occurrences[1]
+
+		updated, err := service.UpdateReservationOccurrence(owner, target.ID, reservation.EditAll, moveByHour(target))
+		require.NoError(t, err)
+		assert.Equal(t, series.ID, updated.ID)
+		assert.Equal(t,
+			[]time.Time{start.Add(time.Hour), start.Add(week + time.Hour), start.Add(2*week + time.Hour)},
+			occurrenceStarts(updated.Occurrences, reservation.StatusPending))
+		assert.Len(t, occurrenceStarts(updated.Occurrences, reservation.StatusCancelled), 1)
+	})
+
+	t.Run("Rejects reservations outside a series", func(t *testing.T) {
+		service, series := setup(t)
+		single := &reservation.Reservation{
+			ResourceID: series.ResourceID,
+			UserID:     "user-1",
+			StartTime:  start.Add(time.Hour * 3),
+			EndTime:    start.Add(time.Hour * 4),
+			Purpose:    "Meeting",
+		}
+		require.NoError(t, service.CreateReservation(owner, single))
+
+		_, err := service.UpdateReservationOccurrence(owner, single.ID, reservation.EditAll, moveByHour(*single))
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+	})
+}
+
+func TestCancelReservationSeries(t *testing.T) {
+	service, _, roomID := newSeriesTestService(t)
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(24 * time.Hour).Add(14 * time.Hour)
+
+	series := weeklySeries(roomID, start, "FREQ=WEEKLY;COUNT=3")
+	require.NoError(t, service.CreateReservationSeries(owner, series))
+	require.NoError(t, service.ApproveReservation(manager, series.Occurrences[0].ID))
+
+	assert.ErrorIs(t, service.CancelReservationSeries(stranger, series.ID), common.ErrForbidden)
+
+	require.NoError(t, service.CancelReservationSeries(owner, series.ID))
+
+	cancelled, err := service.GetReservationSeries(owner, series.ID)
+	require.NoError(t, err)
+	assert.Len(t, occurrenceStarts(cancelled.Occurrences, reservation.StatusCancelled), 3)
+
+	assert.ErrorIs(t, service.CancelReservationSeries(owner, series.ID), common.ErrConflict)
+}
@@ -2,50 +2,113 @@ package reservation
 
 import (
 	"fmt"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/availability"
+	"sarc-ng/internal/domain/checkin"
 	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/quota"
 	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/resource"
+	"sarc-ng/internal/domain/waitlist"
+	"slices"
 	"strings"
 	"time"
 )
 
 // Service implements reservation.Usecase interface
 type Service struct {
-	repo reservation.Repository
+	repo          reservation.Repository
+	uow           reservation.UnitOfWork
+	resources     resource.Repository
+	policies      policy.Repository
+	quotas        quota.Repository
+	quotaSettings quota.Settings
+	waitlist      waitlist.UnitOfWork
+	notifier      waitlist.Notifier
+	checkins      checkin.Repository
+	checkInUow    checkin.UnitOfWork
+	checkIn       checkin.Settings
+	reminders     reservation.Notifier
 }
 
 // Compile-time verification that Service implements reservation.Usecase
 var _ reservation.Usecase = (*Service)(nil)
 
-// NewService creates a new reservation service
-func NewService(repo reservation.Repository) *Service {
+// NewService creates a new reservation service. Bookings are checked against
+// the policy of their resource's type and the quota of their owner, made of
+// the configured rules and the owner's overrides. Approved reservations not
+// checked in to within the check-in settings' grace period are released as
+// no-shows. Time freed by cancelling, rejecting or releasing a reservation goes
+// to the waitlist, whose owners are notified. Owners are sent reminders of
+// their approved reservations.
+func NewService(
+	repo reservation.Repository,
+	uow reservation.UnitOfWork,
+	resources resource.Repository,
+	policies policy.Repository,
+	quotas quota.Repository,
+	quotaSettings quota.Settings,
+	waitlist waitlist.UnitOfWork,
+	notifier waitlist.Notifier,
+	checkins checkin.Repository,
+	checkInUow checkin.UnitOfWork,
+	checkIn checkin.Settings,
+	reminders reservation.Notifier,
+) *Service {
 	return &Service{
-		repo: repo,
+		repo:          repo,
+		uow:           uow,
+		resources:     resources,
+		policies:      policies,
+		quotas:        quotas,
+		quotaSettings: quotaSettings,
+		waitlist:      waitlist,
+		notifier:      notifier,
+		checkins:      checkins,
+		checkInUow:    checkInUow,
+		checkIn:       checkIn,
+		reminders:     reminders,
 	}
 }
 
-// GetAllReservations retrieves all reservations
-func (s *Service) GetAllReservations() ([]reservation.Reservation, error) {
-	return s.repo.ReadReservationList()
+// GetAllReservations retrieves the page of reservations selected by the query
+func (s *Service) GetAllReservations(query common.Query) (*common.Page[reservation.Reservation], error) {
+	return s.repo.ReadReservationList(query)
 }
 
-// GetReservation retrieves a reservation by ID with validation
-func (s *Service) GetReservation(id uint) (*reservation.Reservation, error) {
+// GetReservation retrieves a reservation by ID on behalf of an actor who may see it
+func (s *Service) GetReservation(actor *auth.User, id uint) (*reservation.Reservation, error) {
 	if id == 0 {
 		return nil, fmt.Errorf("%w: reservation ID cannot be zero", common.ErrInvalidInput)
 	}
-	return s.repo.ReadReservation(id)
+	r, err := s.repo.ReadReservation(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeView(actor, r.UserID); err != nil {
+		return nil, err
+	}
+	return r, nil
 }
 
-// CreateReservation creates a new reservation with validation
-func (s *Service) CreateReservation(r *reservation.Reservation) error {
+// CreateReservation creates a new reservation owned by the actor
+func (s *Service) CreateReservation(actor *auth.User, r *reservation.Reservation) error {
+	if actor == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+	r.UserID = actor.ID
+
 	// Validate resource ID
 	if r.ResourceID == 0 {
 		return fmt.Errorf("%w: resource ID cannot be zero", common.ErrInvalidInput)
 	}
 
-	// Validate user ID
-	if r.UserID == 0 {
-		return fmt.Errorf("%w: user ID cannot be zero", common.ErrInvalidInput)
+	// Validate owner
+	if r.UserID == "" {
+		return fmt.Errorf("%w: user ID cannot be empty", common.ErrInvalidInput)
 	}
 
 	// Validate purpose
@@ -70,25 +133,45 @@ func (s *Service) CreateReservation(r *reservation.Reservation) error {
 		return fmt.Errorf("%w: start time cannot be in the past", common.ErrInvalidInput)
 	}
 
-	// Check for conflicts
-	available, err := s.CheckReservationAvailability(r.ResourceID, r.StartTime, r.EndTime)
+	// New reservations always start as pending and must be approved separately
+	if r.Status == "" {
+		r.Status = reservation.StatusPending
+	}
+	if r.Status != reservation.StatusPending {
+		return fmt.Errorf("%w: new reservations must start as %s", common.ErrInvalidInput, reservation.StatusPending)
+	}
+
+	p, err := s.policyFor(r.ResourceID)
 	if err != nil {
 		return err
 	}
-	if !available {
-		return fmt.Errorf("%w: resource is not available for the requested time", common.ErrConflict)
+	if err := checkPolicy(p, actor, r.StartTime, r.EndTime); err != nil {
+		return err
 	}
-
-	// Set default status if not provided
-	if strings.TrimSpace(r.Status) == "" {
-		r.Status = "pending"
+	if err := s.checkNoShows(p, r.UserID); err != nil {
+		return err
 	}
 
-	return s.repo.CreateReservation(r)
+	// Check quota and conflicts and insert atomically
+	return s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := s.checkQuota(repo, actor, r.UserID, []reservation.Reservation{*r}, 0); err != nil {
+			return err
+		}
+		if err := checkConflicts(repo, p, r.ResourceID, r.StartTime, r.EndTime, 0); err != nil {
+			return err
+		}
+		if err := repo.CreateReservation(r); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionCreate, audit.EntityReservation, r.ID, nil, r)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.ReservationCreated{Reservation: snapshot(*r)})
+	})
 }
 
 // UpdateReservation updates an existing reservation with validation
-func (s *Service) UpdateReservation(r *reservation.Reservation) error {
+func (s *Service) UpdateReservation(actor *auth.User, r *reservation.Reservation) error {
 	if r.ID == 0 {
 		return fmt.Errorf("%w: reservation ID cannot be zero for update", common.ErrInvalidInput)
 	}
@@ -116,57 +199,132 @@ func (s *Service) UpdateReservation(r *reservation.Reservation) error {
 	if existing == nil {
 		return fmt.Errorf("%w: reservation not found", common.ErrNotFound)
 	}
+	if err := authorize(actor, existing.UserID); err != nil {
+		return err
+	}
+	// A manager editing someone else's booking does not take it over
+	r.UserID = existing.UserID
+
+	// Status changes go through the dedicated approve, reject and cancel use cases
+	if r.Status != "" && r.Status != existing.Status {
+		return fmt.Errorf("%w: reservation status cannot be changed by update", common.ErrInvalidInput)
+	}
+	if existing.Status.IsFinal() {
+		return fmt.Errorf("%w: %s reservations cannot be modified", common.ErrConflict, existing.Status)
+	}
+	r.Status = existing.Status
+	r.StatusReason = existing.StatusReason
 
 	// Check for conflicts if time or resource changed
-	if existing.ResourceID != r.ResourceID ||
-	   !existing.StartTime.Equal(r.StartTime) ||
-	   !existing.EndTime.Equal(r.EndTime) {
-		available, err := s.CheckReservationAvailabilityExcluding(r.ResourceID, r.StartTime, r.EndTime, r.ID)
-		if err != nil {
+	if existing.ResourceID == r.ResourceID &&
+		existing.StartTime.Equal(r.StartTime) &&
+		existing.EndTime.Equal(r.EndTime) {
+		return s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+			if err := repo.UpdateReservation(r); err != nil {
+				return err
+			}
+			if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionUpdate, audit.EntityReservation, r.ID, existing, r)); err != nil {
+				return err
+			}
+			return events.RecordEvents(event.ReservationUpdated{Reservation: snapshot(*r)})
+		})
+	}
+
+	p, err := s.policyFor(r.ResourceID)
+	if err != nil {
+		return err
+	}
+	if err := checkPolicy(p, actor, r.StartTime, r.EndTime); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := s.checkQuota(repo, actor, r.UserID, []reservation.Reservation{*r}, r.ID); err != nil {
 			return err
 		}
-		if !available {
-			return fmt.Errorf("%w: resource is not available for the requested time", common.ErrConflict)
+		if err := checkConflicts(repo, p, r.ResourceID, r.StartTime, r.EndTime, r.ID); err != nil {
+			return err
 		}
-	}
-
-	return s.repo.UpdateReservation(r)
+		if err := repo.UpdateReservation(r); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionUpdate, audit.EntityReservation, r.ID, existing, r)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.ReservationUpdated{Reservation: snapshot(*r)})
+	})
 }
 
 // DeleteReservation removes a reservation by ID
-func (s *Service) DeleteReservation(id uint) error {
+func (s *Service) DeleteReservation(actor *auth.User, id uint) error {
 	if id == 0 {
 		return fmt.Errorf("%w: reservation ID cannot be zero", common.ErrInvalidInput)
 	}
 
-	_, err := s.repo.ReadReservation(id)
+	existing, err := s.repo.ReadReservation(id)
 	if err != nil {
 		return err
 	}
+	if err := authorize(actor, existing.UserID); err != nil {
+		return err
+	}
 
-	return s.repo.DeleteReservation(id)
+	return s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.DeleteReservation(id); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionDelete, audit.EntityReservation, id, existing, nil)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.ReservationDeleted{Reservation: snapshot(*existing)})
+	})
 }
 
-// CancelReservation cancels a reservation by setting its status
-func (s *Service) CancelReservation(id uint) error {
+// CancelReservation cancels a pending or approved reservation
+func (s *Service) CancelReservation(actor *auth.User, id uint) error {
 	if id == 0 {
 		return fmt.Errorf("%w: reservation ID cannot be zero", common.ErrInvalidInput)
 	}
 
-	reservation, err := s.repo.ReadReservation(id)
+	existing, err := s.repo.ReadReservation(id)
 	if err != nil {
 		return err
 	}
-	if reservation == nil {
-		return fmt.Errorf("%w: reservation not found", common.ErrNotFound)
+	if err := authorize(actor, existing.UserID); err != nil {
+		return err
 	}
 
-	if reservation.Status == "cancelled" {
-		return fmt.Errorf("%w: reservation is already cancelled", common.ErrConflict)
+	cancelled, err := s.transitionReservation(actor, id, reservation.StatusCancelled, "")
+	if err != nil {
+		return err
 	}
+	s.promoteWaitlist(cancelled)
+	return nil
+}
 
-	reservation.Status = "cancelled"
-	return s.repo.UpdateReservation(reservation)
+// ApproveReservation approves a pending reservation
+func (s *Service) ApproveReservation(actor *auth.User, id uint) error {
+	if err := s.checkBuilding(actor, id); err != nil {
+		return err
+	}
+	_, err := s.transitionReservation(actor, id, reservation.StatusApproved, "")
+	return err
+}
+
+// RejectReservation rejects a pending reservation, recording the reason
+func (s *Service) RejectReservation(actor *auth.User, id uint, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("%w: rejection reason cannot be empty", common.ErrInvalidInput)
+	}
+	if err := s.checkBuilding(actor, id); err != nil {
+		return err
+	}
+	rejected, err := s.transitionReservation(actor, id, reservation.StatusRejected, reason)
+	if err != nil {
+		return err
+	}
+	s.promoteWaitlist(rejected)
+	return nil
 }
 
 // CheckReservationAvailability checks if a resource is available for the given time period
@@ -183,55 +341,152 @@ func (s *Service) CheckReservationAvailability(resourceID uint, start, end time.
 		return false, fmt.Errorf("%w: start time cannot be in the past", common.ErrInvalidInput)
 	}
 
-	// Get all reservations and check for conflicts
-	allReservations, err := s.repo.ReadReservationList()
+	return s.CheckReservationAvailabilityExcluding(resourceID, start, end, 0)
+}
+
+// CheckReservationAvailabilityExcluding checks availability excluding a specific reservation
+func (s *Service) CheckReservationAvailabilityExcluding(resourceID uint, start, end time.Time, excludeID uint) (bool, error) {
+	conflicts, err := s.repo.FindOverlappingReservations(resourceID, start, end, excludeID)
 	if err != nil {
 		return false, fmt.Errorf("failed to check availability: %w", err)
 	}
 
-	// Check for time conflicts with active reservations
-	for _, existing := range allReservations {
-		if existing.ResourceID == resourceID &&
-		   existing.Status != "cancelled" &&
-		   existing.Status != "rejected" {
-			// Check if time ranges overlap
-			if timeRangesOverlap(start, end, existing.StartTime, existing.EndTime) {
-				return false, nil
-			}
+	return len(conflicts) == 0, nil
+}
+
+// transitionReservation moves a reservation to a new status if the state machine allows it
+// and returns the updated reservation. A nil actor records the transition as made by the
+// system.
+func (s *Service) transitionReservation(actor *auth.User, id uint, to reservation.Status, reason string) (*reservation.Reservation, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: reservation ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	var r *reservation.Reservation
+	err := s.uow.Do(func(repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		var err error
+		r, err = repo.ReadReservation(id)
+		if err != nil {
+			return err
 		}
+		if r == nil {
+			return fmt.Errorf("%w: reservation not found", common.ErrNotFound)
+		}
+
+		if r.Status == to {
+			return fmt.Errorf("%w: reservation is already %s", common.ErrConflict, to)
+		}
+		if !r.Status.CanTransitionTo(to) {
+			return fmt.Errorf("%w: cannot change reservation status from %s to %s", common.ErrConflict, r.Status, to)
+		}
+
+		before := *r
+		previous := r.Status
+		r.Status = to
+		r.StatusReason = reason
+		if err := repo.UpdateReservation(r); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionTransition, audit.EntityReservation, id, &before, r)); err != nil {
+			return err
+		}
+		return events.RecordEvents(transitionEvent(*r, previous))
+	})
+	if err != nil {
+		return nil, err
 	}
+	return r, nil
+}
 
-	return true, nil
+// authorize checks that the actor may change a booking of the given owner:
+// only the owner and managers may
+func authorize(actor *auth.User, ownerID string) error {
+	if actor == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+	if actor.ID != ownerID && !actor.IsManager() {
+		return fmt.Errorf("%w: only the owner or a manager can change this reservation", common.ErrForbidden)
+	}
+	return nil
 }
 
-// CheckReservationAvailabilityExcluding checks availability excluding a specific reservation
-func (s *Service) CheckReservationAvailabilityExcluding(resourceID uint, start, end time.Time, excludeID uint) (bool, error) {
-	// Get all reservations and check for conflicts
-	allReservations, err := s.repo.ReadReservationList()
-	if err != nil {
-		return false, fmt.Errorf("failed to check availability: %w", err)
+// authorizeView checks that the actor may see a booking of the given owner:
+// its owner, managers and API keys that review reservations may. Anyone else
+// is told the booking does not exist, so IDs cannot be probed.
+func authorizeView(actor *auth.User, ownerID string) error {
+	if actor == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
 	}
+	if actor.ID != ownerID && !actor.IsManager() && !slices.Contains(actor.Scopes, auth.PermissionReservationApprove) {
+		return fmt.Errorf("%w: reservation not found", common.ErrNotFound)
+	}
+	return nil
+}
 
-	// Check for time conflicts with active reservations
-	for _, existing := range allReservations {
-		if existing.ID == excludeID {
-			continue
-		}
+// checkBuilding checks that the actor may decide on the reservation, which is
+// in the building of its resource
+func (s *Service) checkBuilding(actor *auth.User, id uint) error {
+	if actor == nil || len(actor.Buildings) == 0 || id == 0 {
+		return nil
+	}
 
-		if existing.ResourceID == resourceID &&
-		   existing.Status != "cancelled" &&
-		   existing.Status != "rejected" {
-			// Check if time ranges overlap
-			if timeRangesOverlap(start, end, existing.StartTime, existing.EndTime) {
-				return false, nil
-			}
-		}
+	r, err := s.repo.ReadReservation(id)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return fmt.Errorf("%w: reservation not found", common.ErrNotFound)
+	}
+	booked, err := s.resources.ReadResource(r.ResourceID)
+	if err != nil {
+		return err
 	}
+	return actor.CheckBuilding(booked.Building())
+}
 
-	return true, nil
+// policyFor returns the booking policy of the resource's type, or nil if it has none
+func (s *Service) policyFor(resourceID uint) (*policy.Policy, error) {
+	r, err := s.resources.ReadResource(resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.policies.FindPolicyByResourceType(r.Type)
+}
+
+// checkPolicy verifies a booking against the policy, if there is one
+func checkPolicy(p *policy.Policy, booker *auth.User, start, end time.Time) error {
+	if p == nil {
+		return nil
+	}
+	return p.Check(policy.Booking{Start: start, End: end, Booker: booker}, time.Now())
 }
 
-// timeRangesOverlap checks if two time ranges overlap
-func timeRangesOverlap(start1, end1, start2, end2 time.Time) bool {
-	return start1.Before(end2) && start2.Before(end1)
+// checkConflicts locks the resource and fails with ErrConflict if the time range is taken,
+// or with a rule error if it leaves less than the policy's buffer to another booking.
+// It must run inside a unit of work so the lock is held until the write completes.
+func checkConflicts(repo reservation.Repository, p *policy.Policy, resourceID uint, start, end time.Time, excludeID uint) error {
+	if err := repo.LockReservationResource(resourceID); err != nil {
+		return err
+	}
+
+	var buffer time.Duration
+	if p != nil {
+		buffer = p.Buffer
+	}
+	conflicts, err := repo.FindOverlappingReservations(resourceID, start.Add(-buffer), end.Add(buffer), excludeID)
+	if err != nil {
+		return fmt.Errorf("failed to check availability: %w", err)
+	}
+
+	neighbours := make([]availability.Window, 0, len(conflicts))
+	for _, c := range conflicts {
+		if c.StartTime.Before(end) && c.EndTime.After(start) {
+			return fmt.Errorf("%w: resource is not available for the requested time", common.ErrConflict)
+		}
+		neighbours = append(neighbours, availability.Window{Start: c.StartTime, End: c.EndTime})
+	}
+	if len(neighbours) > 0 {
+		return p.CheckBuffer(policy.Booking{Start: start, End: end}, neighbours)
+	}
+	return nil
 }
@@ -0,0 +1,458 @@
+package reservation
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	checkinAdapter "sarc-ng/internal/adapter/gorm/checkin"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	waitlistAdapter "sarc-ng/internal/adapter/gorm/waitlist"
+	"sarc-ng/internal/adapter/notify"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/internal/domain/reservation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var (
+	owner    = &auth.User{ID: "user-1"}
+	stranger = &auth.User{ID: "user-2"}
+	manager  = &auth.User{ID: "user-3", Groups: []string{"manager"}}
+)
+
+func newTestService(db *gorm.DB) *Service {
+	return NewService(
+		reservationAdapter.NewGormAdapter(db),
+		reservationAdapter.NewUnitOfWork(db),
+		resourceAdapter.NewGormAdapter(db),
+		policyAdapter.NewGormAdapter(db),
+		quotaAdapter.NewGormAdapter(db),
+		quota.Settings{},
+		waitlistAdapter.NewUnitOfWork(db),
+		notify.NewLogNotifier(),
+		checkinAdapter.NewGormAdapter(db),
+		checkinAdapter.NewUnitOfWork(db),
+		checkin.Settings{GracePeriod: 15 * time.Minute, EarlyWindow: 10 * time.Minute},
+		notify.NewLogNotifier(),
+	)
+}
+
+func TestCreateReservationConcurrent(t *testing.T) {
+	const clients = 20
+
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+
+	service := newTestService(db)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	var wg sync.WaitGroup
+	errs := make([]error, clients)
+	ready := make(chan struct{})
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-ready
+			errs[i] = service.CreateReservation(&auth.User{ID: fmt.Sprintf("user-%d", i+1)}, &reservation.Reservation{
+				ResourceID: room.ID,
+				StartTime:  start,
+				EndTime:    end,
+				Purpose:    "Lab session",
+			})
+		}(i)
+	}
+	close(ready)
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, common.ErrConflict)
+	}
+	assert.Equal(t, 1, succeeded, "exactly one booking should win the slot")
+
+	var stored int64
+	require.NoError(t, db.Model(&reservationAdapter.GormModel{}).Count(&stored).Error)
+	assert.Equal(t, int64(1), stored)
+}
+
+func TestCreateReservationUnknownResource(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+	service := newTestService(db)
+
+	start := time.Now().Add(24 * time.Hour)
+	err := service.CreateReservation(owner, &reservation.Reservation{
+		ResourceID: 99,
+		UserID:     "user-1",
+		StartTime:  start,
+		EndTime:    start.Add(time.Hour),
+		Purpose:    "Lab session",
+	})
+
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
+
+func TestReservationStatusWorkflow(t *testing.T) {
+	newBooking := func(t *testing.T) (*Service, *reservation.Reservation) {
+		db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+		room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+		require.NoError(t, db.Create(room).Error)
+
+		service := newTestService(db)
+		start := time.Now().Add(24 * time.Hour)
+		r := &reservation.Reservation{
+			ResourceID: room.ID,
+			UserID:     "user-1",
+			StartTime:  start,
+			EndTime:    start.Add(time.Hour),
+			Purpose:    "Lab session",
+		}
+		require.NoError(t, service.CreateReservation(owner, r))
+		require.Equal(t, reservation.StatusPending, r.Status)
+		return service, r
+	}
+
+	t.Run("Create rejects non-pending status", func(t *testing.T) {
+		service, r := newBooking(t)
+
+		err := service.CreateReservation(owner, &reservation.Reservation{
+			ResourceID: r.ResourceID,
+			UserID:     "user-1",
+			StartTime:  r.EndTime,
+			EndTime:    r.EndTime.Add(time.Hour),
+			Purpose:    "Self-approved",
+			Status:     reservation.StatusApproved,
+		})
+
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+	})
+
+	t.Run("Approve then cancel", func(t *testing.T) {
+		service, r := newBooking(t)
+
+		require.NoError(t, service.ApproveReservation(manager, r.ID))
+		require.NoError(t, service.CancelReservation(owner, r.ID))
+
+		stored, err := service.GetReservation(owner, r.ID)
+		require.NoError(t, err)
+		assert.Equal(t, reservation.StatusCancelled, stored.Status)
+	})
+
+	t.Run("Reject records reason and frees the slot", func(t *testing.T) {
+		service, r := newBooking(t)
+
+		require.NoError(t, service.RejectReservation(manager, r.ID, "Room under maintenance"))
+
+		stored, err := service.GetReservation(owner, r.ID)
+		require.NoError(t, err)
+		assert.Equal(t, reservation.StatusRejected, stored.Status)
+		assert.Equal(t, "Room under maintenance", stored.StatusReason)
+
+		available, err := service.CheckReservationAvailability(r.ResourceID, r.StartTime, r.EndTime)
+		require.NoError(t, err)
+		assert.True(t, available)
+	})
+
+	t.Run("Reject requires a reason", func(t *testing.T) {
+		service, r := newBooking(t)
+
+		err := service.RejectReservation(manager, r.ID, "  ")
+
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+	})
+
+	t.Run("Illegal transitions conflict", func(t *testing.T) {
+		service, r := newBooking(t)
+		require.NoError(t, service.RejectReservation(manager, r.ID, "Duplicate request"))
+
+		assert.ErrorIs(t, service.ApproveReservation(manager, r.ID), common.ErrConflict)
+		assert.ErrorIs(t, service.CancelReservation(owner, r.ID), common.ErrConflict)
+		assert.ErrorIs(t, service.RejectReservation(manager, r.ID, "Again"), common.ErrConflict)
+	})
+
+	t.Run("Update cannot change status", func(t *testing.T) {
+		service, r := newBooking(t)
+
+		update := *r
+		update.Status = reservation.StatusApproved
+		err := service.UpdateReservation(owner, &update)
+
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+	})
+
+	t.Run("Update of a final reservation conflicts", func(t *testing.T) {
+		service, r := newBooking(t)
+		require.NoError(t, service.CancelReservation(owner, r.ID))
+
+		update := *r
+		update.Status = ""
+		update.Purpose = "Changed my mind"
+		err := service.UpdateReservation(owner, &update)
+
+		assert.ErrorIs(t, err, common.ErrConflict)
+	})
+}
+
+func TestReservationOwnership(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	service := newTestService(db)
+
+	book := func(t *testing.T, hour int) *reservation.Reservation {
+		start := time.Now().Add(24 * time.Hour).Truncate(time.Hour).Add(time.Duration(hour) * time.Hour)
+		r := &reservation.Reservation{
+			ResourceID: room.ID,
+			UserID:     owner.ID,
+			StartTime:  start,
+			EndTime:    start.Add(time.Hour),
+			Purpose:    "Lab session",
+		}
+		require.NoError(t, service.CreateReservation(owner, r))
+		return r
+	}
+
+	t.Run("Create requires an owner", func(t *testing.T) {
+		start := time.Now().Add(48 * time.Hour)
+		err := service.CreateReservation(nil, &reservation.Reservation{
+			ResourceID: room.ID,
+			StartTime:  start,
+			EndTime:    start.Add(time.Hour),
+			Purpose:    "Anonymous",
+		})
+		assert.ErrorIs(t, err, common.ErrUnauthorized)
+	})
+
+	t.Run("Other users cannot change the booking", func(t *testing.T) {
+		r := book(t, 0)
+
+		update := *r
+		update.Purpose = "Hijacked"
+		assert.ErrorIs(t, service.UpdateReservation(stranger, &update), common.ErrForbidden)
+		assert.ErrorIs(t, service.CancelReservation(stranger, r.ID), common.ErrForbidden)
+		assert.ErrorIs(t, service.DeleteReservation(stranger, r.ID), common.ErrForbidden)
+		assert.ErrorIs(t, service.DeleteReservation(nil, r.ID), common.ErrUnauthorized)
+	})
+
+	t.Run("Only the owner and reviewers see the booking", func(t *testing.T) {
+		r := book(t, 6)
+
+		_, err := service.GetReservation(stranger, r.ID)
+		assert.ErrorIs(t, err, common.ErrNotFound, "other users cannot tell it exists")
+		_, err = service.GetReservation(nil, r.ID)
+		assert.ErrorIs(t, err, common.ErrUnauthorized)
+
+		reviewer := &auth.User{ID: "apikey:1", Scopes: []auth.Permission{auth.PermissionReservationApprove}}
+		for _, actor := range []*auth.User{owner, manager, reviewer} {
+			_, err := service.GetReservation(actor, r.ID)
+			assert.NoError(t, err, actor.ID)
+		}
+	})
+
+	t.Run("Owner keeps ownership on update", func(t *testing.T) {
+		r := book(t, 2)
+
+		update := *r
+		update.UserID = stranger.ID
+		update.Purpose = "Longer lab session"
+		require.NoError(t, service.UpdateReservation(owner, &update))
+
+		stored, err := service.GetReservation(owner, r.ID)
+		require.NoError(t, err)
+		assert.Equal(t, owner.ID, stored.UserID)
+		assert.Equal(t, "Longer lab session", stored.Purpose)
+	})
+
+	t.Run("Managers can change any booking", func(t *testing.T) {
+		r := book(t, 4)
+
+		require.NoError(t, service.CancelReservation(manager, r.ID))
+		require.NoError(t, service.DeleteReservation(manager, r.ID))
+	})
+
+	t.Run("Listing by user", func(t *testing.T) {
+		mine, err := service.GetAllReservations(common.Query{}.Where("userId", common.OpEqual, owner.ID))
+		require.NoError(t, err)
+		assert.NotEmpty(t, mine.Items)
+		for _, r := range mine.Items {
+			assert.Equal(t, owner.ID, r.UserID)
+		}
+
+		theirs, err := service.GetAllReservations(common.Query{}.Where("userId", common.OpEqual, stranger.ID))
+		require.NoError(t, err)
+		assert.Empty(t, theirs.Items)
+	})
+}
+
+func TestReservationPolicy(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &reservationAdapter.SeriesGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+	projector := &resourceAdapter.GormModel{Name: "Projector A", Type: "projector", IsAvailable: true}
+	require.NoError(t, db.Create(projector).Error)
+	require.NoError(t, policyAdapter.NewGormAdapter(db).CreatePolicy(&policy.Policy{
+		ResourceType:  "projector",
+		MaxDuration:   4 * time.Hour,
+		MinLeadTime:   time.Hour,
+		MaxAdvance:    30 * 24 * time.Hour,
+		Buffer:        30 * time.Minute,
+		AllowedGroups: []string{"teacher", "manager"},
+	}))
+	service := newTestService(db)
+	teacher := &auth.User{ID: "user-4", Groups: []string{"teacher"}}
+
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	booking := func(start time.Time, length time.Duration) *reservation.Reservation {
+		return &reservation.Reservation{ResourceID: projector.ID, StartTime: start, EndTime: start.Add(length), Purpose: "Lecture"}
+	}
+	require.NoError(t, service.CreateReservation(teacher, booking(tomorrow, 2*time.Hour)))
+
+	tests := []struct {
+		name  string
+		actor *auth.User
+		r     *reservation.Reservation
+		kind  error
+		rule  string
+	}{
+		{"Group not allowed", owner, booking(tomorrow.Add(6*time.Hour), time.Hour), common.ErrForbidden, policy.RuleAllowedGroups},
+		{"Too long", teacher, booking(tomorrow.Add(6*time.Hour), 5*time.Hour), common.ErrInvalidInput, policy.RuleMaxDuration},
+		{"Too soon", teacher, booking(time.Now().Add(10*time.Minute), time.Hour), common.ErrInvalidInput, policy.RuleMinLeadTime},
+		{"Too far ahead", teacher, booking(tomorrow.AddDate(0, 2, 0), time.Hour), common.ErrInvalidInput, policy.RuleMaxAdvance},
+		{"Inside the buffer", teacher, booking(tomorrow.Add(2*time.Hour+15*time.Minute), time.Hour), common.ErrInvalidInput, policy.RuleBuffer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.CreateReservation(tt.actor, tt.r)
+			require.ErrorIs(t, err, tt.kind)
+			ruleErr, ok := common.AsRuleError(err)
+			require.True(t, ok, "expected a rule error, got %v", err)
+			assert.Equal(t, tt.rule, ruleErr.Rule)
+		})
+	}
+
+	t.Run("Overlaps are still conflicts", func(t *testing.T) {
+		err := service.CreateReservation(teacher, booking(tomorrow.Add(time.Hour), time.Hour))
+		assert.ErrorIs(t, err, common.ErrConflict)
+	})
+
+	t.Run("Past the buffer is fine", func(t *testing.T) {
+		assert.NoError(t, service.CreateReservation(teacher, booking(tomorrow.Add(2*time.Hour+30*time.Minute), time.Hour)))
+	})
+
+	t.Run("Series occurrences are checked one by one", func(t *testing.T) {
+		series := &reservation.Series{
+			ResourceID:     projector.ID,
+			StartTime:      tomorrow.Add(8 * time.Hour),
+			EndTime:        tomorrow.Add(9 * time.Hour),
+			TimeZone:       "UTC",
+			RecurrenceRule: "FREQ=WEEKLY;COUNT=6",
+			Purpose:        "Weekly seminar",
+		}
+		err := service.CreateReservationSeries(teacher, series)
+		require.ErrorIs(t, err, common.ErrInvalidInput)
+		ruleErr, ok := common.AsRuleError(err)
+		require.True(t, ok)
+		assert.Equal(t, policy.RuleMaxAdvance, ruleErr.Rule)
+		assert.Contains(t, ruleErr.Message, "occurrence at")
+	})
+
+	t.Run("Types without a policy are unrestricted", func(t *testing.T) {
+		room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+		require.NoError(t, db.Create(room).Error)
+		r := booking(tomorrow.AddDate(0, 3, 0), 8*time.Hour)
+		r.ResourceID = room.ID
+		assert.NoError(t, service.CreateReservation(owner, r))
+	})
+}
+
+func TestReservationQuota(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &reservationAdapter.SeriesGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	overrides := quotaAdapter.NewGormAdapter(db)
+	service := NewService(
+		reservationAdapter.NewGormAdapter(db),
+		reservationAdapter.NewUnitOfWork(db),
+		resourceAdapter.NewGormAdapter(db),
+		policyAdapter.NewGormAdapter(db),
+		overrides,
+		quota.Settings{Location: time.UTC, Rules: []quota.Rule{{MaxActive: 2}, {Group: "manager"}}},
+		waitlistAdapter.NewUnitOfWork(db),
+		notify.NewLogNotifier(),
+		checkinAdapter.NewGormAdapter(db),
+		checkinAdapter.NewUnitOfWork(db),
+		checkin.Settings{},
+		notify.NewLogNotifier(),
+	)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	booking := func(slot int) *reservation.Reservation {
+		begin := start.Add(time.Duration(slot) * time.Hour)
+		return &reservation.Reservation{ResourceID: room.ID, StartTime: begin, EndTime: begin.Add(time.Hour), Purpose: "Study"}
+	}
+	first := booking(0)
+	require.NoError(t, service.CreateReservation(owner, first))
+	require.NoError(t, service.CreateReservation(owner, booking(1)))
+
+	t.Run("Exceeding the quota is forbidden", func(t *testing.T) {
+		err := service.CreateReservation(owner, booking(2))
+		require.ErrorIs(t, err, common.ErrForbidden)
+		ruleErr, ok := common.AsRuleError(err)
+		require.True(t, ok, "expected a rule error, got %v", err)
+		assert.Equal(t, quota.RuleMaxActive, ruleErr.Rule)
+	})
+
+	t.Run("Moving a booking does not count it twice", func(t *testing.T) {
+		moved := *first
+		moved.StartTime, moved.EndTime = booking(3).StartTime, booking(3).EndTime
+		require.NoError(t, service.UpdateReservation(owner, &moved))
+	})
+
+	t.Run("Series count every occurrence", func(t *testing.T) {
+		series := &reservation.Series{
+			ResourceID:     room.ID,
+			Purpose:        "Study group",
+			StartTime:      booking(6).StartTime,
+			EndTime:        booking(6).EndTime,
+			RecurrenceRule: "FREQ=WEEKLY;COUNT=3",
+		}
+		err := service.CreateReservationSeries(stranger, series)
+		assert.ErrorIs(t, err, common.ErrForbidden)
+	})
+
+	t.Run("Cancelled bookings free the quota", func(t *testing.T) {
+		require.NoError(t, service.CancelReservation(owner, first.ID))
+		require.NoError(t, service.CreateReservation(owner, booking(4)))
+	})
+
+	t.Run("Managers are unlimited", func(t *testing.T) {
+		for slot := 10; slot < 13; slot++ {
+			require.NoError(t, service.CreateReservation(manager, booking(slot)))
+		}
+	})
+
+	t.Run("Overrides raise the quota", func(t *testing.T) {
+		require.NoError(t, overrides.CreateQuotaOverride(&quota.Override{UserID: owner.ID, MaxActive: 3, GrantedBy: manager.ID}))
+		require.NoError(t, service.CreateReservation(owner, booking(5)))
+		assert.ErrorIs(t, service.CreateReservation(owner, booking(7)), common.ErrForbidden)
+	})
+}
@@ -0,0 +1,110 @@
+package reservation
+
+import (
+	"errors"
+	"log"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/waitlist"
+	"time"
+)
+
+// promoteWaitlist books the time freed by a cancelled or rejected reservation
+// for the oldest waiting entry that now fits, and notifies its owner. Entries
+// are held to the same policy, no-show and quota checks as a new reservation
+// of their owner; those failing them keep waiting until they expire. The
+// freed reservation already stands, so failures are only logged.
+func (s *Service) promoteWaitlist(freed *reservation.Reservation) {
+	now := time.Now()
+	if !freed.EndTime.After(now) {
+		return
+	}
+
+	p, err := s.policyFor(freed.ResourceID)
+	if err != nil {
+		log.Printf("Failed to promote waitlist for reservation %d: %v", freed.ID, err)
+		return
+	}
+
+	var promoted *waitlist.Entry
+	var booked reservation.Reservation
+	err = s.waitlist.Do(func(entries waitlist.Repository, repo reservation.Repository, events event.Outbox, trail audit.Trail) error {
+		if _, err := entries.ExpireWaitlistEntries(now); err != nil {
+			return err
+		}
+
+		candidates, err := entries.FindWaitingWaitlistEntries(freed.ResourceID, freed.StartTime, freed.EndTime)
+		if err != nil {
+			return err
+		}
+		for _, e := range candidates {
+			booked = reservation.Reservation{
+				ResourceID:  e.ResourceID,
+				UserID:      e.UserID,
+				StartTime:   e.StartTime,
+				EndTime:     e.EndTime,
+				Purpose:     e.Purpose,
+				Description: e.Description,
+				Status:      reservation.StatusPending,
+			}
+
+			// Entries overlapping other bookings or that their owner may no longer book keep waiting
+			err := s.checkPromotion(repo, p, e.Owner(), booked)
+			if _, isRule := common.AsRuleError(err); isRule || errors.Is(err, common.ErrConflict) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := repo.CreateReservation(&booked); err != nil {
+				return err
+			}
+			if err := trail.RecordAuditEntries(audit.NewEntry(nil, audit.ActionCreate, audit.EntityReservation, booked.ID, nil, &booked)); err != nil {
+				return err
+			}
+			if err := events.RecordEvents(event.ReservationCreated{Reservation: snapshot(booked)}); err != nil {
+				return err
+			}
+
+			e.Status = waitlist.StatusPromoted
+			e.ReservationID = &booked.ID
+			if err := entries.UpdateWaitlistEntry(&e); err != nil {
+				return err
+			}
+			promoted = &e
+			return nil
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to promote waitlist for reservation %d: %v", freed.ID, err)
+		return
+	}
+
+	if promoted != nil {
+		if err := s.notifier.NotifyWaitlistPromoted(*promoted, booked); err != nil {
+			log.Printf("Failed to notify user %s of waitlist entry %d: %v", promoted.UserID, promoted.ID, err)
+		}
+	}
+}
+
+// checkPromotion runs the checks of CreateReservation on a booking promoted
+// from the waitlist for its owner. It must run inside the unit of work that
+// creates the booking.
+func (s *Service) checkPromotion(repo reservation.Repository, p *policy.Policy, owner *auth.User, booked reservation.Reservation) error {
+	if err := checkPolicy(p, owner, booked.StartTime, booked.EndTime); err != nil {
+		return err
+	}
+	if err := s.checkNoShows(p, owner.ID); err != nil {
+		return err
+	}
+	if err := s.checkQuota(repo, owner, owner.ID, []reservation.Reservation{booked}, 0); err != nil {
+		return err
+	}
+	return checkConflicts(repo, p, booked.ResourceID, booked.StartTime, booked.EndTime, 0)
+}
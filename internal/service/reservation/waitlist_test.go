@@ -0,0 +1,173 @@
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	checkinAdapter "sarc-ng/internal/adapter/gorm/checkin"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	waitlistAdapter "sarc-ng/internal/adapter/gorm/waitlist"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/waitlist"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier remembers the promotions and reminders it was told about
+type recordingNotifier struct {
+	promoted []waitlist.Entry
+	reminded []reservation.Reservation
+}
+
+func (n *recordingNotifier) NotifyWaitlistPromoted(entry waitlist.Entry, r reservation.Reservation) error {
+	n.promoted = append(n.promoted, entry)
+	return nil
+}
+
+func (n *recordingNotifier) NotifyReservationReminder(r reservation.Reservation) error {
+	n.reminded = append(n.reminded, r)
+	return nil
+}
+
+func TestWaitlistPromotion(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	notifier := &recordingNotifier{}
+	service := NewService(
+		reservationAdapter.NewGormAdapter(db),
+		reservationAdapter.NewUnitOfWork(db),
+		resourceAdapter.NewGormAdapter(db),
+		policyAdapter.NewGormAdapter(db),
+		quotaAdapter.NewGormAdapter(db),
+		quota.Settings{},
+		waitlistAdapter.NewUnitOfWork(db),
+		notifier,
+		checkinAdapter.NewGormAdapter(db),
+		checkinAdapter.NewUnitOfWork(db),
+		checkin.Settings{},
+		notifier,
+	)
+	entries := waitlistAdapter.NewGormAdapter(db)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	booked := &reservation.Reservation{ResourceID: room.ID, StartTime: start, EndTime: start.Add(2 * time.Hour), Purpose: "Lecture"}
+	require.NoError(t, service.CreateReservation(owner, booked))
+	blocker := &reservation.Reservation{ResourceID: room.ID, StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour), Purpose: "Lecture"}
+	require.NoError(t, service.CreateReservation(owner, blocker))
+
+	wait := func(user *auth.User, from, until time.Time, expires time.Time) *waitlist.Entry {
+		e := &waitlist.Entry{ResourceID: room.ID, UserID: user.ID, StartTime: from, EndTime: until, Purpose: "Study", Status: waitlist.StatusWaiting, ExpiresAt: expires}
+		require.NoError(t, entries.CreateWaitlistEntry(e))
+		return e
+	}
+	expired := wait(stranger, start, start.Add(time.Hour), time.Now().Add(-time.Minute))
+	overlapping := wait(stranger, start.Add(time.Hour), start.Add(3*time.Hour), start)
+	first := wait(stranger, start, start.Add(time.Hour), start)
+	second := wait(manager, start, start.Add(2*time.Hour), start)
+
+	require.NoError(t, service.CancelReservation(owner, booked.ID))
+
+	read := func(e *waitlist.Entry) *waitlist.Entry {
+		stored, err := entries.ReadWaitlistEntry(e.ID)
+		require.NoError(t, err)
+		return stored
+	}
+	assert.Equal(t, waitlist.StatusExpired, read(expired).Status)
+	assert.Equal(t, waitlist.StatusWaiting, read(overlapping).Status, "entry still overlapping another booking keeps waiting")
+	assert.Equal(t, waitlist.StatusWaiting, read(second).Status, "only the first entry that fits is promoted")
+
+	promoted := read(first)
+	require.Equal(t, waitlist.StatusPromoted, promoted.Status)
+	require.NotNil(t, promoted.ReservationID)
+	r, err := service.GetReservation(manager, *promoted.ReservationID)
+	require.NoError(t, err)
+	assert.Equal(t, stranger.ID, r.UserID)
+	assert.Equal(t, reservation.StatusPending, r.Status)
+	assert.True(t, r.StartTime.Equal(start))
+
+	require.Len(t, notifier.promoted, 1)
+	assert.Equal(t, first.ID, notifier.promoted[0].ID)
+
+	t.Run("Rejections free the time too", func(t *testing.T) {
+		require.NoError(t, service.RejectReservation(manager, r.ID, "Room needed for an exam"))
+		assert.Equal(t, waitlist.StatusPromoted, read(second).Status)
+		assert.Len(t, notifier.promoted, 2)
+	})
+}
+
+func TestWaitlistPromotionChecksTheOwner(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{},
+		&policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{}, &checkinAdapter.NoShowGormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	otherRoom := &resourceAdapter.GormModel{Name: "Lab 2", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(otherRoom).Error)
+	require.NoError(t, db.Create(&policyAdapter.GormModel{ResourceType: "room", NoShowLimit: 1, NoShowPeriodMinutes: 30 * 24 * 60}).Error)
+	notifier := &recordingNotifier{}
+	noShows := checkinAdapter.NewGormAdapter(db)
+	service := NewService(
+		reservationAdapter.NewGormAdapter(db),
+		reservationAdapter.NewUnitOfWork(db),
+		resourceAdapter.NewGormAdapter(db),
+		policyAdapter.NewGormAdapter(db),
+		quotaAdapter.NewGormAdapter(db),
+		quota.Settings{Location: time.UTC, Rules: []quota.Rule{{Group: "student", ResourceType: "room", MaxActive: 1}}},
+		waitlistAdapter.NewUnitOfWork(db),
+		notifier,
+		noShows,
+		checkinAdapter.NewUnitOfWork(db),
+		checkin.Settings{},
+		notifier,
+	)
+	entries := waitlistAdapter.NewGormAdapter(db)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	wait := func(user *auth.User, from time.Time) *waitlist.Entry {
+		e := &waitlist.Entry{ResourceID: room.ID, UserID: user.ID, UserGroups: user.Groups, StartTime: from, EndTime: from.Add(time.Hour), Purpose: "Study", Status: waitlist.StatusWaiting, ExpiresAt: from}
+		require.NoError(t, entries.CreateWaitlistEntry(e))
+		return e
+	}
+	status := func(e *waitlist.Entry) waitlist.Status {
+		stored, err := entries.ReadWaitlistEntry(e.ID)
+		require.NoError(t, err)
+		return stored.Status
+	}
+
+	t.Run("Owners at their quota keep waiting", func(t *testing.T) {
+		student := &auth.User{ID: "student", Groups: []string{"student"}}
+		require.NoError(t, service.CreateReservation(student, &reservation.Reservation{ResourceID: otherRoom.ID, StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Lecture"}))
+		booked := &reservation.Reservation{ResourceID: room.ID, StartTime: start, EndTime: start.Add(time.Hour), Purpose: "Lecture"}
+		require.NoError(t, service.CreateReservation(owner, booked))
+		waiting := wait(student, start)
+
+		require.NoError(t, service.CancelReservation(owner, booked.ID))
+		assert.Equal(t, waitlist.StatusWaiting, status(waiting), "the student's groups hold them to one active room")
+		assert.Empty(t, notifier.promoted)
+	})
+
+	t.Run("Suspended owners are passed over", func(t *testing.T) {
+		from := start.Add(2 * time.Hour)
+		booked := &reservation.Reservation{ResourceID: room.ID, StartTime: from, EndTime: from.Add(time.Hour), Purpose: "Lecture"}
+		require.NoError(t, service.CreateReservation(owner, booked))
+		require.NoError(t, noShows.CreateNoShow(&checkin.NoShow{UserID: stranger.ID, ReservationID: booked.ID, ResourceID: room.ID, StartTime: time.Now().Add(-time.Hour)}))
+		suspended := wait(stranger, from)
+		next := wait(manager, from)
+
+		require.NoError(t, service.CancelReservation(owner, booked.ID))
+		assert.Equal(t, waitlist.StatusWaiting, status(suspended))
+		assert.Equal(t, waitlist.StatusPromoted, status(next), "the next entry that passes the checks is promoted")
+		require.Len(t, notifier.promoted, 1)
+		assert.Equal(t, next.ID, notifier.promoted[0].ID)
+	})
+}
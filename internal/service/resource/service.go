@@ -1,30 +1,43 @@
 package resource
 
 import (
+	"errors"
 	"fmt"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/building"
+	"sarc-ng/internal/domain/class"
 	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
 	"sarc-ng/internal/domain/resource"
 	"strings"
 )
 
 // Service implements resource.Usecase interface
 type Service struct {
-	repo resource.Repository
+	repo      resource.Repository
+	uow       resource.UnitOfWork
+	buildings building.Repository
+	classes   class.Repository
 }
 
 // Compile-time verification that Service implements resource.Usecase
 var _ resource.Usecase = (*Service)(nil)
 
-// NewService creates a new resource service
-func NewService(repo resource.Repository) *Service {
+// NewService creates a new resource service. Every change is written through
+// the unit of work together with its events and audit entry.
+func NewService(repo resource.Repository, uow resource.UnitOfWork, buildings building.Repository, classes class.Repository) *Service {
 	return &Service{
-		repo: repo,
+		repo:      repo,
+		uow:       uow,
+		buildings: buildings,
+		classes:   classes,
 	}
 }
 
-// GetAllResources retrieves all resources
-func (s *Service) GetAllResources() ([]resource.Resource, error) {
-	return s.repo.ReadResourceList()
+// GetAllResources retrieves the page of resources selected by the query
+func (s *Service) GetAllResources(query common.Query) (*common.Page[resource.Resource], error) {
+	return s.repo.ReadResourceList(query)
 }
 
 // GetResource retrieves a resource by ID with validation
@@ -35,8 +48,34 @@ func (s *Service) GetResource(id uint) (*resource.Resource, error) {
 	return s.repo.ReadResource(id)
 }
 
+// GetResourcesByBuilding retrieves the resources kept in a building, including its classes
+func (s *Service) GetResourcesByBuilding(buildingID uint) ([]resource.Resource, error) {
+	if buildingID == 0 {
+		return nil, fmt.Errorf("%w: building ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	if _, err := s.buildings.ReadBuilding(buildingID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadResourceListByBuilding(buildingID)
+}
+
+// GetResourcesByClass retrieves the resources kept in a class
+func (s *Service) GetResourcesByClass(classID uint) ([]resource.Resource, error) {
+	if classID == 0 {
+		return nil, fmt.Errorf("%w: class ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	if _, err := s.classes.ReadClass(classID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadResourceListByClass(classID)
+}
+
 // CreateResource creates a new resource with validation
-func (s *Service) CreateResource(r *resource.Resource) error {
+func (s *Service) CreateResource(actor *auth.User, r *resource.Resource) error {
 	// Validate name
 	if strings.TrimSpace(r.Name) == "" {
 		return fmt.Errorf("%w: resource name cannot be empty", common.ErrInvalidInput)
@@ -47,11 +86,26 @@ func (s *Service) CreateResource(r *resource.Resource) error {
 		return fmt.Errorf("%w: resource type cannot be empty", common.ErrInvalidInput)
 	}
 
-	return s.repo.CreateResource(r)
+	if err := s.resolveLocation(r); err != nil {
+		return err
+	}
+	if err := actor.CheckBuilding(r.Building()); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repo resource.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.CreateResource(r); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionCreate, audit.EntityResource, r.ID, nil, r)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.ResourceCreated{Resource: snapshot(*r)})
+	})
 }
 
 // UpdateResource updates an existing resource with validation
-func (s *Service) UpdateResource(r *resource.Resource) error {
+func (s *Service) UpdateResource(actor *auth.User, r *resource.Resource) error {
 	if r.ID == 0 {
 		return fmt.Errorf("%w: resource ID cannot be zero for update", common.ErrInvalidInput)
 	}
@@ -66,37 +120,137 @@ func (s *Service) UpdateResource(r *resource.Resource) error {
 		return fmt.Errorf("%w: resource type cannot be empty", common.ErrInvalidInput)
 	}
 
-	return s.repo.UpdateResource(r)
+	if err := s.resolveLocation(r); err != nil {
+		return err
+	}
+	if err := actor.CheckBuilding(r.Building()); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repo resource.Repository, events event.Outbox, trail audit.Trail) error {
+		existing, err := repo.ReadResource(r.ID)
+		if err != nil {
+			return err
+		}
+		// Moving a resource needs access to both buildings
+		if err := actor.CheckBuilding(existing.Building()); err != nil {
+			return err
+		}
+		if err := repo.UpdateResource(r); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionUpdate, audit.EntityResource, r.ID, existing, r)); err != nil {
+			return err
+		}
+		return events.RecordEvents(updateEvents(*existing, *r)...)
+	})
 }
 
 // DeleteResource removes a resource by ID
-func (s *Service) DeleteResource(id uint) error {
+func (s *Service) DeleteResource(actor *auth.User, id uint) error {
 	if id == 0 {
 		return fmt.Errorf("%w: resource ID cannot be zero", common.ErrInvalidInput)
 	}
 
-	_, err := s.repo.ReadResource(id)
+	existing, err := s.repo.ReadResource(id)
 	if err != nil {
 		return err
 	}
+	if err := actor.CheckBuilding(existing.Building()); err != nil {
+		return err
+	}
 
-	return s.repo.DeleteResource(id)
+	return s.uow.Do(func(repo resource.Repository, events event.Outbox, trail audit.Trail) error {
+		if err := repo.DeleteResource(id); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionDelete, audit.EntityResource, id, existing, nil)); err != nil {
+			return err
+		}
+		return events.RecordEvents(event.ResourceDeleted{Resource: snapshot(*existing)})
+	})
 }
 
-// SetResourceAvailability sets the availability status of a resource
-func (s *Service) SetResourceAvailability(id uint, available bool) error {
+// SetResourceAvailability sets the availability status of a resource. The
+// audit log records it as a transition.
+func (s *Service) SetResourceAvailability(actor *auth.User, id uint, available bool) error {
 	if id == 0 {
 		return fmt.Errorf("%w: resource ID cannot be zero", common.ErrInvalidInput)
 	}
 
-	resource, err := s.repo.ReadResource(id)
-	if err != nil {
-		return err
+	return s.uow.Do(func(repo resource.Repository, events event.Outbox, trail audit.Trail) error {
+		existing, err := repo.ReadResource(id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return fmt.Errorf("%w: resource not found", common.ErrNotFound)
+		}
+		if err := actor.CheckBuilding(existing.Building()); err != nil {
+			return err
+		}
+
+		updated := *existing
+		updated.IsAvailable = available
+		if err := repo.UpdateResource(&updated); err != nil {
+			return err
+		}
+		if err := trail.RecordAuditEntries(audit.NewEntry(actor, audit.ActionTransition, audit.EntityResource, id, existing, &updated)); err != nil {
+			return err
+		}
+		return events.RecordEvents(updateEvents(*existing, updated)...)
+	})
+}
+
+// resolveLocation validates the building and class of a resource.
+// A resource kept in a class is always in the building of that class.
+func (s *Service) resolveLocation(r *resource.Resource) error {
+	if r.ClassID != nil {
+		c, err := s.classes.ReadClass(*r.ClassID)
+		if err != nil {
+			if errors.Is(err, common.ErrNotFound) {
+				return fmt.Errorf("%w: class %d does not exist", common.ErrInvalidInput, *r.ClassID)
+			}
+			return err
+		}
+		if r.BuildingID != nil && *r.BuildingID != c.BuildingID {
+			return fmt.Errorf("%w: class %d is not in building %d", common.ErrInvalidInput, c.ID, *r.BuildingID)
+		}
+
+		buildingID := c.BuildingID
+		r.BuildingID = &buildingID
+		return nil
 	}
-	if resource == nil {
-		return fmt.Errorf("%w: resource not found", common.ErrNotFound)
+
+	if r.BuildingID != nil {
+		if _, err := s.buildings.ReadBuilding(*r.BuildingID); err != nil {
+			if errors.Is(err, common.ErrNotFound) {
+				return fmt.Errorf("%w: building %d does not exist", common.ErrInvalidInput, *r.BuildingID)
+			}
+			return err
+		}
 	}
+	return nil
+}
 
-	resource.IsAvailable = available
-	return s.repo.UpdateResource(resource)
+// snapshot returns the state of a resource carried by its events
+func snapshot(r resource.Resource) event.Resource {
+	return event.Resource{
+		ID:          r.ID,
+		Name:        r.Name,
+		Type:        r.Type,
+		IsAvailable: r.IsAvailable,
+		BuildingID:  r.BuildingID,
+		ClassID:     r.ClassID,
+	}
+}
+
+// updateEvents returns the events of an edit of a resource: ResourceUpdated,
+// followed by ResourceAvailabilityChanged when its availability flipped
+func updateEvents(before, after resource.Resource) []event.Event {
+	events := []event.Event{event.ResourceUpdated{Resource: snapshot(after)}}
+	if before.IsAvailable != after.IsAvailable {
+		events = append(events, event.ResourceAvailabilityChanged{Resource: snapshot(after)})
+	}
+	return events
 }
@@ -0,0 +1,118 @@
+package resource
+
+import (
+	"testing"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	classAdapter "sarc-ng/internal/adapter/gorm/class"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	lessonAdapter "sarc-ng/internal/adapter/gorm/lesson"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/class"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/resource"
+	classService "sarc-ng/internal/service/class"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceLocation(t *testing.T) {
+	db := gormtest.Open(t,
+		&auditAdapter.GormModel{},
+		&buildingAdapter.GormModel{},
+		&classAdapter.GormModel{},
+		&eventAdapter.OutboxGormModel{},
+		&lessonAdapter.GormModel{},
+		&resourceAdapter.GormModel{},
+	)
+	buildings := buildingAdapter.NewGormAdapter(db)
+	classes := classAdapter.NewGormAdapter(db)
+	resources := resourceAdapter.NewGormAdapter(db)
+	service := NewService(resources, resourceAdapter.NewUnitOfWork(db), buildings, classes)
+	classUsecase := classService.NewService(classes, classAdapter.NewUnitOfWork(db), buildings, resources, lessonAdapter.NewGormAdapter(db))
+
+	main := &buildingAdapter.GormModel{Name: "Main", Code: "MAIN"}
+	annex := &buildingAdapter.GormModel{Name: "Annex", Code: "ANX"}
+	require.NoError(t, db.Create(main).Error)
+	require.NoError(t, db.Create(annex).Error)
+	room := &class.Class{Name: "Room 101", Capacity: 30, BuildingID: main.ID}
+	require.NoError(t, classUsecase.CreateClass(nil, room))
+
+	t.Run("Building is taken from the class", func(t *testing.T) {
+		projector := &resource.Resource{Name: "Projector", Type: "equipment", ClassID: &room.ID}
+		require.NoError(t, service.CreateResource(nil, projector))
+		require.NotNil(t, projector.BuildingID)
+		assert.Equal(t, main.ID, *projector.BuildingID)
+
+		inRoom, err := service.GetResourcesByClass(room.ID)
+		require.NoError(t, err)
+		assert.Len(t, inRoom, 1)
+	})
+
+	t.Run("Class in another building is rejected", func(t *testing.T) {
+		err := service.CreateResource(nil, &resource.Resource{Name: "Screen", Type: "equipment", ClassID: &room.ID, BuildingID: &annex.ID})
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+	})
+
+	t.Run("Unknown building or class is rejected", func(t *testing.T) {
+		missing := uint(999)
+		err := service.CreateResource(nil, &resource.Resource{Name: "Screen", Type: "equipment", BuildingID: &missing})
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+
+		err = service.CreateResource(nil, &resource.Resource{Name: "Screen", Type: "equipment", ClassID: &missing})
+		assert.ErrorIs(t, err, common.ErrInvalidInput)
+	})
+
+	t.Run("Resources move with their class", func(t *testing.T) {
+		room.BuildingID = annex.ID
+		require.NoError(t, classUsecase.UpdateClass(nil, room))
+
+		inAnnex, err := service.GetResourcesByBuilding(annex.ID)
+		require.NoError(t, err)
+		assert.Len(t, inAnnex, 1)
+
+		inMain, err := service.GetResourcesByBuilding(main.ID)
+		require.NoError(t, err)
+		assert.Empty(t, inMain)
+
+		// The move is recorded for the resource in the class transaction
+		var events, entries int64
+		require.NoError(t, db.Model(&eventAdapter.OutboxGormModel{}).
+			Where("type = ? AND aggregate_id = ?", event.TypeResourceUpdated, inAnnex[0].ID).Count(&events).Error)
+		assert.EqualValues(t, 1, events)
+		require.NoError(t, db.Model(&auditAdapter.GormModel{}).
+			Where("entity_type = ? AND entity_id = ? AND action = ?", audit.EntityResource, inAnnex[0].ID, audit.ActionUpdate).Count(&entries).Error)
+		assert.EqualValues(t, 1, entries)
+	})
+
+	t.Run("Class with resources cannot be deleted", func(t *testing.T) {
+		err := classUsecase.DeleteClass(nil, room.ID)
+		assert.ErrorIs(t, err, common.ErrConflict)
+	})
+	t.Run("API keys only change their buildings", func(t *testing.T) {
+		key := &auth.User{ID: "apikey:1", Scopes: []auth.Permission{auth.PermissionResourceWrite}, Buildings: []uint{annex.ID}}
+
+		inMain := &resource.Resource{Name: "Speaker", Type: "equipment", BuildingID: &main.ID}
+		assert.ErrorIs(t, service.CreateResource(key, inMain), common.ErrForbidden)
+		assert.ErrorIs(t, service.CreateResource(key, &resource.Resource{Name: "Cart", Type: "equipment"}), common.ErrForbidden,
+			"resources outside any building are out of reach")
+
+		speaker := &resource.Resource{Name: "Speaker", Type: "equipment", BuildingID: &annex.ID}
+		require.NoError(t, service.CreateResource(key, speaker))
+		require.NoError(t, service.SetResourceAvailability(key, speaker.ID, false))
+
+		speaker.BuildingID = &main.ID
+		assert.ErrorIs(t, service.UpdateResource(key, speaker), common.ErrForbidden, "moving needs access to both buildings")
+
+		require.NoError(t, service.CreateResource(nil, inMain))
+		assert.ErrorIs(t, service.DeleteResource(key, inMain.ID), common.ErrForbidden)
+		assert.ErrorIs(t, classUsecase.DeleteClass(key, room.ID), common.ErrConflict, "the class is in the annex")
+		assert.ErrorIs(t, classUsecase.CreateClass(key, &class.Class{Name: "Room 102", Capacity: 20, BuildingID: main.ID}), common.ErrForbidden)
+	})
+}
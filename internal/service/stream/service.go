@@ -0,0 +1,135 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/resource"
+	"sarc-ng/internal/domain/stream"
+	"slices"
+)
+
+// subscriberBuffer is how many matching updates wait for a slow client
+const subscriberBuffer = 16
+
+// EventTypes are the domain events pushed to live clients
+var EventTypes = []event.Type{
+	event.TypeResourceCreated,
+	event.TypeResourceUpdated,
+	event.TypeResourceDeleted,
+	event.TypeResourceAvailabilityChanged,
+	event.TypeReservationCreated,
+	event.TypeReservationUpdated,
+	event.TypeReservationDeleted,
+	event.TypeReservationCancelled,
+	event.TypeReservationStatusChanged,
+	event.TypeReservationCheckedIn,
+}
+
+// Service implements stream.Usecase interface. It publishes reservation and
+// resource events to the broker and filters them for every client.
+type Service struct {
+	broker    stream.Broker
+	resources resource.Repository
+}
+
+// Compile-time verification that Service implements stream.Usecase
+var _ stream.Usecase = (*Service)(nil)
+
+// NewService creates a new stream service
+func NewService(broker stream.Broker, resources resource.Repository) *Service {
+	return &Service{
+		broker:    broker,
+		resources: resources,
+	}
+}
+
+// HandleEvent publishes reservation and resource events as updates. It is
+// subscribed to the in-process event sink.
+func (s *Service) HandleEvent(ctx context.Context, m event.Message) error {
+	update := stream.Update{
+		ID:            m.ID,
+		Type:          m.Type,
+		AggregateType: m.AggregateType,
+		AggregateID:   m.AggregateID,
+		Data:          json.RawMessage(m.Payload),
+		OccurredAt:    m.OccurredAt,
+	}
+
+	switch m.AggregateType {
+	case "reservation":
+		// Every reservation event carries the reservation at its top level
+		var r event.Reservation
+		if err := json.Unmarshal(m.Payload, &r); err != nil {
+			return fmt.Errorf("failed to decode event %d: %w", m.ID, err)
+		}
+		update.ResourceID = r.ResourceID
+		update.UserID = r.UserID
+
+		res, err := s.resources.ReadResource(r.ResourceID)
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			// Resources deleted since are in no building
+		case err != nil:
+			return err
+		default:
+			update.BuildingID = res.BuildingID
+		}
+	case "resource":
+		var r event.Resource
+		if err := json.Unmarshal(m.Payload, &r); err != nil {
+			return fmt.Errorf("failed to decode event %d: %w", m.ID, err)
+		}
+		update.ResourceID = r.ID
+		update.BuildingID = r.BuildingID
+	default:
+		return nil
+	}
+
+	return s.broker.Publish(ctx, update)
+}
+
+// Subscribe returns the updates matching the filter until ctx is done. Only
+// managers and API keys that review reservations receive the reservations of
+// other users; everyone else receives their own and every resource update.
+func (s *Service) Subscribe(ctx context.Context, user *auth.User, filter stream.Filter) (<-chan stream.Update, error) {
+	if user == nil {
+		return nil, fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+	seesAll := user.IsManager() || slices.Contains(user.Scopes, auth.PermissionReservationApprove)
+	if !seesAll && filter.UserID != "" && filter.UserID != user.ID {
+		return nil, fmt.Errorf("%w: only managers can follow the reservations of other users", common.ErrForbidden)
+	}
+	for _, t := range filter.Types {
+		if !slices.Contains(EventTypes, t) {
+			return nil, fmt.Errorf("%w: '%s' is not streamed", common.ErrInvalidInput, t)
+		}
+	}
+
+	updates, err := s.broker.Subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make(chan stream.Update, subscriberBuffer)
+	go func() {
+		defer close(matching)
+		for update := range updates {
+			if !filter.Matches(update) {
+				continue
+			}
+			if !seesAll && update.AggregateType == "reservation" && update.UserID != user.ID {
+				continue
+			}
+			select {
+			case matching <- update:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return matching, nil
+}
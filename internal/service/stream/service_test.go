@@ -0,0 +1,159 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"sarc-ng/internal/adapter/broker"
+	"sarc-ng/internal/adapter/eventsink"
+	buildingAdapter "sarc-ng/internal/adapter/gorm/building"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/building"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/resource"
+	"sarc-ng/internal/domain/stream"
+	eventService "sarc-ng/internal/service/event"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var (
+	user    = &auth.User{ID: "user-1"}
+	manager = &auth.User{ID: "user-3", Groups: []string{"manager"}}
+)
+
+// setup wires the stream service to the event dispatcher the way the
+// application does and creates a resource in a building
+func setup(t *testing.T) (*gorm.DB, *Service, *eventService.Service, *resource.Resource) {
+	db := gormtest.Open(t, &buildingAdapter.GormModel{}, &resourceAdapter.GormModel{}, &eventAdapter.OutboxGormModel{})
+
+	hall := &building.Building{Name: "Main Hall", Code: "MH"}
+	require.NoError(t, buildingAdapter.NewGormAdapter(db).CreateBuilding(hall))
+	room := &resource.Resource{Name: "Room 101", Type: "room", IsAvailable: true, BuildingID: &hall.ID}
+	require.NoError(t, resourceAdapter.NewGormAdapter(db).CreateResource(room))
+
+	service := NewService(broker.NewMemory(8), resourceAdapter.NewGormAdapter(db))
+	inProcess := eventsink.NewInProcess()
+	inProcess.Subscribe(service.HandleEvent, EventTypes...)
+	events := eventService.NewService(eventAdapter.NewGormAdapter(db), []event.Sink{inProcess},
+		event.Settings{BatchSize: 10, MaxAttempts: 3, RetryBackoff: time.Millisecond})
+	return db, service, events, room
+}
+
+func recordAndDispatch(t *testing.T, db *gorm.DB, events *eventService.Service, e ...event.Event) {
+	require.NoError(t, eventAdapter.NewOutbox(db).RecordEvents(e...))
+	_, err := events.DispatchEvents(context.Background())
+	require.NoError(t, err)
+}
+
+// receive collects the updates that arrive shortly
+func receive(updates <-chan stream.Update) []stream.Update {
+	var received []stream.Update
+	timeout := time.After(50 * time.Millisecond)
+	for {
+		select {
+		case u := <-updates:
+			received = append(received, u)
+		case <-timeout:
+			return received
+		}
+	}
+}
+
+func TestUpdatesAreFiltered(t *testing.T) {
+	db, service, events, room := setup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	all, err := service.Subscribe(ctx, manager, stream.Filter{})
+	require.NoError(t, err)
+	inBuilding, err := service.Subscribe(ctx, manager, stream.Filter{BuildingIDs: []uint{*room.BuildingID}})
+	require.NoError(t, err)
+	mine, err := service.Subscribe(ctx, user, stream.Filter{UserID: user.ID, Types: []event.Type{event.TypeReservationCreated}})
+	require.NoError(t, err)
+	otherResource, err := service.Subscribe(ctx, user, stream.Filter{ResourceIDs: []uint{room.ID + 1}})
+	require.NoError(t, err)
+
+	start := time.Date(2030, 3, 4, 14, 0, 0, 0, time.UTC)
+	recordAndDispatch(t, db, events,
+		event.ReservationCreated{Reservation: event.Reservation{ID: 7, ResourceID: room.ID, UserID: user.ID, StartTime: start, EndTime: start.Add(time.Hour)}},
+		event.ReservationCreated{Reservation: event.Reservation{ID: 8, ResourceID: room.ID, UserID: "user-2"}},
+		event.ResourceAvailabilityChanged{Resource: event.Resource{ID: room.ID, IsAvailable: false, BuildingID: room.BuildingID}},
+		event.BuildingUpdated{Building: event.Building{ID: *room.BuildingID, Name: "Main Hall"}},
+	)
+
+	received := receive(all)
+	require.Len(t, received, 3, "building events are not streamed")
+	assert.Equal(t, event.TypeReservationCreated, received[0].Type)
+	assert.Equal(t, uint(7), received[0].AggregateID)
+	assert.Equal(t, room.ID, received[0].ResourceID)
+	assert.Equal(t, room.BuildingID, received[0].BuildingID, "reservations are placed in the building of their resource")
+	assert.Equal(t, user.ID, received[0].UserID)
+	var payload event.Reservation
+	require.NoError(t, json.Unmarshal(received[0].Data, &payload))
+	assert.True(t, start.Equal(payload.StartTime))
+	assert.Equal(t, event.TypeResourceAvailabilityChanged, received[2].Type)
+
+	assert.Len(t, receive(inBuilding), 3)
+	received = receive(mine)
+	require.Len(t, received, 1)
+	assert.Equal(t, uint(7), received[0].AggregateID)
+	assert.Empty(t, receive(otherResource))
+}
+
+func TestUsersOnlyFollowTheirOwnReservations(t *testing.T) {
+	db, service, events, room := setup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := service.Subscribe(ctx, user, stream.Filter{UserID: "user-2"})
+	assert.ErrorIs(t, err, common.ErrForbidden, "another user's reservations")
+	theirs, err := service.Subscribe(ctx, manager, stream.Filter{UserID: "user-2"})
+	require.NoError(t, err)
+	everything, err := service.Subscribe(ctx, user, stream.Filter{})
+	require.NoError(t, err)
+
+	recordAndDispatch(t, db, events,
+		event.ReservationCreated{Reservation: event.Reservation{ID: 7, ResourceID: room.ID, UserID: user.ID}},
+		event.ReservationCreated{Reservation: event.Reservation{ID: 8, ResourceID: room.ID, UserID: "user-2"}},
+		event.ResourceAvailabilityChanged{Resource: event.Resource{ID: room.ID, IsAvailable: false, BuildingID: room.BuildingID}},
+	)
+
+	received := receive(everything)
+	require.Len(t, received, 2, "reservations of other users are left out")
+	assert.Equal(t, uint(7), received[0].AggregateID)
+	assert.Equal(t, event.TypeResourceAvailabilityChanged, received[1].Type)
+
+	received = receive(theirs)
+	require.Len(t, received, 1)
+	assert.Equal(t, uint(8), received[0].AggregateID)
+}
+
+func TestSubscriptionsEndWithTheirContext(t *testing.T) {
+	_, service, _, _ := setup(t)
+
+	_, err := service.Subscribe(context.Background(), nil, stream.Filter{})
+	assert.ErrorIs(t, err, common.ErrUnauthorized)
+	_, err = service.Subscribe(context.Background(), user, stream.Filter{Types: []event.Type{event.TypeLessonCreated}})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := service.Subscribe(ctx, user, stream.Filter{})
+	require.NoError(t, err)
+	cancel()
+
+	select {
+	case _, open := <-updates:
+		assert.False(t, open)
+	case <-time.After(time.Second):
+		t.Fatal("the updates channel was not closed")
+	}
+}
@@ -0,0 +1,244 @@
+package waitlist
+
+import (
+	"errors"
+	"fmt"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/internal/domain/resource"
+	"sarc-ng/internal/domain/waitlist"
+	"strings"
+	"time"
+)
+
+// Service implements waitlist.Usecase interface
+type Service struct {
+	repo         waitlist.Repository
+	reservations reservation.Repository
+	resources    resource.Repository
+	policies     policy.Repository
+	checkins     checkin.Repository
+	quotas       quota.Repository
+	settings     quota.Settings
+}
+
+// Compile-time verification that Service implements waitlist.Usecase
+var _ waitlist.Usecase = (*Service)(nil)
+
+// NewService creates a new waitlist service. Users whose no-shows suspend
+// booking under the resource type's policy, or whose quota the entry would
+// exceed, cannot join the waitlist either.
+func NewService(
+	repo waitlist.Repository,
+	reservations reservation.Repository,
+	resources resource.Repository,
+	policies policy.Repository,
+	checkins checkin.Repository,
+	quotas quota.Repository,
+	settings quota.Settings,
+) *Service {
+	return &Service{
+		repo:         repo,
+		reservations: reservations,
+		resources:    resources,
+		policies:     policies,
+		checkins:     checkins,
+		quotas:       quotas,
+		settings:     settings,
+	}
+}
+
+// GetAllWaitlistEntries retrieves the page of waitlist entries selected by the query
+func (s *Service) GetAllWaitlistEntries(query common.Query) (*common.Page[waitlist.Entry], error) {
+	return s.repo.ReadWaitlistEntryList(query)
+}
+
+// GetWaitlistEntry retrieves a waitlist entry by ID. Only its owner and
+// managers may see it; anyone else is told it does not exist.
+func (s *Service) GetWaitlistEntry(actor *auth.User, id uint) (*waitlist.Entry, error) {
+	if actor == nil {
+		return nil, fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+
+	if id == 0 {
+		return nil, fmt.Errorf("%w: waitlist entry ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	e, err := s.repo.ReadWaitlistEntry(id)
+	if err != nil {
+		return nil, err
+	}
+	if actor.ID != e.UserID && !actor.IsManager() {
+		return nil, fmt.Errorf("%w: waitlist entry not found", common.ErrNotFound)
+	}
+	return e, nil
+}
+
+// JoinWaitlist queues the actor for a time window of a resource that is taken.
+// The entry expires at its start time unless an earlier expiry is given. The
+// actor's groups are kept so the entry is held to the same rules when promoted.
+func (s *Service) JoinWaitlist(actor *auth.User, e *waitlist.Entry) error {
+	if actor == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+	e.UserID = actor.ID
+	e.UserGroups = actor.Groups
+
+	if e.ResourceID == 0 {
+		return fmt.Errorf("%w: resource ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	if strings.TrimSpace(e.Purpose) == "" {
+		return fmt.Errorf("%w: reservation purpose cannot be empty", common.ErrInvalidInput)
+	}
+
+	if e.StartTime.IsZero() || e.EndTime.IsZero() {
+		return fmt.Errorf("%w: start and end time are required", common.ErrInvalidInput)
+	}
+
+	if !e.StartTime.Before(e.EndTime) {
+		return fmt.Errorf("%w: start time must be before end time", common.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	if e.StartTime.Before(now) {
+		return fmt.Errorf("%w: start time cannot be in the past", common.ErrInvalidInput)
+	}
+
+	if e.ExpiresAt.IsZero() {
+		e.ExpiresAt = e.StartTime
+	}
+	if !e.ExpiresAt.After(now) || e.ExpiresAt.After(e.StartTime) {
+		return fmt.Errorf("%w: expiry must be in the future and no later than the start time", common.ErrInvalidInput)
+	}
+
+	r, err := s.resources.ReadResource(e.ResourceID)
+	if err != nil {
+		return err
+	}
+
+	// The entry must be bookable once the window frees up
+	p, err := s.policies.FindPolicyByResourceType(r.Type)
+	if err != nil {
+		return err
+	}
+	if p != nil {
+		if err := p.Check(policy.Booking{Start: e.StartTime, End: e.EndTime, Booker: actor}, now); err != nil {
+			return err
+		}
+		if p.NoShowLimit > 0 {
+			noShows, err := s.checkins.CountNoShows(actor.ID, now.Add(-p.NoShowPeriod))
+			if err != nil {
+				return err
+			}
+			if err := p.CheckNoShows(noShows); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.checkQuota(actor, quota.Booking{ResourceType: r.Type, Start: e.StartTime, End: e.EndTime}, now); err != nil {
+		return err
+	}
+
+	conflicts, err := s.reservations.FindOverlappingReservations(e.ResourceID, e.StartTime, e.EndTime, 0)
+	if err != nil {
+		return fmt.Errorf("failed to check availability: %w", err)
+	}
+	if len(conflicts) == 0 {
+		return fmt.Errorf("%w: resource is available for the requested time, book it instead", common.ErrConflict)
+	}
+
+	existing, err := s.repo.FindWaitingWaitlistEntry(e.UserID, e.ResourceID, e.StartTime, e.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate entry: %w", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("%w: already waiting for this resource at an overlapping time", common.ErrConflict)
+	}
+
+	e.Status = waitlist.StatusWaiting
+	e.ReservationID = nil
+	return s.repo.CreateWaitlistEntry(e)
+}
+
+// LeaveWaitlist cancels a waiting entry. Only its owner and managers may.
+func (s *Service) LeaveWaitlist(actor *auth.User, id uint) error {
+	if actor == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+
+	if id == 0 {
+		return fmt.Errorf("%w: waitlist entry ID cannot be zero", common.ErrInvalidInput)
+	}
+
+	e, err := s.repo.ReadWaitlistEntry(id)
+	if err != nil {
+		return err
+	}
+	if actor.ID != e.UserID && !actor.IsManager() {
+		return fmt.Errorf("%w: only the owner or a manager can remove this waitlist entry", common.ErrForbidden)
+	}
+	if status := e.StatusAt(time.Now()); status != waitlist.StatusWaiting {
+		return fmt.Errorf("%w: waitlist entry is already %s", common.ErrConflict, status)
+	}
+
+	e.Status = waitlist.StatusCancelled
+	return s.repo.UpdateWaitlistEntry(e)
+}
+
+// ExpireWaitlistEntries marks the entries past their expiry
+func (s *Service) ExpireWaitlistEntries() (int, error) {
+	return s.repo.ExpireWaitlistEntries(time.Now())
+}
+
+// checkQuota verifies that the booking the entry would become keeps the actor
+// within their quota, failing with the rule error CreateReservation returns
+func (s *Service) checkQuota(actor *auth.User, added quota.Booking, now time.Time) error {
+	overrides, err := s.quotas.ReadQuotaOverrideList(actor.ID)
+	if err != nil {
+		return err
+	}
+	limits := s.settings.LimitsFor(actor, overrides)
+	if len(limits) == 0 {
+		return nil
+	}
+
+	// Weekly totals need every booking since the week of the entry started
+	from := now
+	if weekStart, _ := s.settings.WeekOf(added.Start); weekStart.Before(from) {
+		from = weekStart
+	}
+	owned, err := s.reservations.ReadReservationListByUser(actor.ID, from)
+	if err != nil {
+		return err
+	}
+
+	types := make(map[uint]string)
+	existing := make([]quota.Booking, 0, len(owned))
+	for _, r := range owned {
+		if !r.Status.IsActive() {
+			continue
+		}
+		resourceType, ok := types[r.ResourceID]
+		if !ok {
+			res, err := s.resources.ReadResource(r.ResourceID)
+			switch {
+			case errors.Is(err, common.ErrNotFound):
+				// Bookings of deleted resources only count against quotas over every type
+			case err != nil:
+				return err
+			default:
+				resourceType = res.Type
+			}
+			types[r.ResourceID] = resourceType
+		}
+		existing = append(existing, quota.Booking{ID: r.ID, ResourceType: resourceType, Start: r.StartTime, End: r.EndTime})
+	}
+
+	return s.settings.Check(limits, existing, []quota.Booking{added}, now)
+}
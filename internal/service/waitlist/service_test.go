@@ -0,0 +1,106 @@
+package waitlist
+
+import (
+	"testing"
+	"time"
+
+	auditAdapter "sarc-ng/internal/adapter/gorm/audit"
+	checkinAdapter "sarc-ng/internal/adapter/gorm/checkin"
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	policyAdapter "sarc-ng/internal/adapter/gorm/policy"
+	quotaAdapter "sarc-ng/internal/adapter/gorm/quota"
+	reservationAdapter "sarc-ng/internal/adapter/gorm/reservation"
+	resourceAdapter "sarc-ng/internal/adapter/gorm/resource"
+	waitlistAdapter "sarc-ng/internal/adapter/gorm/waitlist"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/internal/domain/waitlist"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinWaitlist(t *testing.T) {
+	db := gormtest.Open(t, &resourceAdapter.GormModel{}, &reservationAdapter.GormModel{}, &auditAdapter.GormModel{}, &eventAdapter.OutboxGormModel{}, &policyAdapter.GormModel{}, &quotaAdapter.GormModel{}, &waitlistAdapter.GormModel{})
+	room := &resourceAdapter.GormModel{Name: "Lab 1", Type: "room", IsAvailable: true}
+	require.NoError(t, db.Create(room).Error)
+	service := NewService(
+		waitlistAdapter.NewGormAdapter(db),
+		reservationAdapter.NewGormAdapter(db),
+		resourceAdapter.NewGormAdapter(db),
+		policyAdapter.NewGormAdapter(db),
+		checkinAdapter.NewGormAdapter(db),
+		quotaAdapter.NewGormAdapter(db),
+		quota.Settings{Location: time.UTC, Rules: []quota.Rule{{ResourceType: "room", MaxActive: 1}}},
+	)
+	student := &auth.User{ID: "student"}
+	other := &auth.User{ID: "other"}
+	manager := &auth.User{ID: "manager", Groups: []string{"manager"}}
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	require.NoError(t, db.Create(&reservationAdapter.GormModel{
+		ResourceID: room.ID, UserID: other.ID, StartTime: start, EndTime: start.Add(2 * time.Hour), Purpose: "Lecture", Status: "approved",
+	}).Error)
+	entry := func(from, until time.Time) *waitlist.Entry {
+		return &waitlist.Entry{ResourceID: room.ID, StartTime: from, EndTime: until, Purpose: "Study"}
+	}
+
+	joined := entry(start, start.Add(time.Hour))
+	require.NoError(t, service.JoinWaitlist(student, joined))
+	assert.Equal(t, student.ID, joined.UserID)
+	assert.Equal(t, waitlist.StatusWaiting, joined.Status)
+	assert.True(t, joined.ExpiresAt.Equal(start), "entries expire at their start by default")
+
+	t.Run("Invalid entries are refused", func(t *testing.T) {
+		late := entry(start, start.Add(time.Hour))
+		late.ExpiresAt = start.Add(time.Minute)
+
+		tests := []struct {
+			name  string
+			actor *auth.User
+			e     *waitlist.Entry
+			kind  error
+		}{
+			{"Anonymous", nil, entry(start, start.Add(time.Hour)), common.ErrUnauthorized},
+			{"Free window", student, entry(start.Add(3*time.Hour), start.Add(4*time.Hour)), common.ErrConflict},
+			{"Already waiting", student, entry(start.Add(30*time.Minute), start.Add(90*time.Minute)), common.ErrConflict},
+			{"Expiry after start", student, late, common.ErrInvalidInput},
+			{"In the past", student, entry(time.Now().Add(-time.Hour), time.Now()), common.ErrInvalidInput},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.ErrorIs(t, service.JoinWaitlist(tt.actor, tt.e), tt.kind)
+			})
+		}
+	})
+
+	t.Run("Entries over quota are refused like reservations", func(t *testing.T) {
+		// other already holds the one active room reservation the quota allows
+		err := service.JoinWaitlist(other, entry(start, start.Add(time.Hour)))
+		assert.ErrorIs(t, err, common.ErrForbidden)
+		ruleErr, ok := common.AsRuleError(err)
+		require.True(t, ok)
+		assert.Equal(t, quota.RuleMaxActive, ruleErr.Rule)
+	})
+
+	t.Run("Only the owner or a manager can leave", func(t *testing.T) {
+		assert.ErrorIs(t, service.LeaveWaitlist(other, joined.ID), common.ErrForbidden)
+		require.NoError(t, service.LeaveWaitlist(manager, joined.ID))
+		assert.ErrorIs(t, service.LeaveWaitlist(student, joined.ID), common.ErrConflict)
+
+		stored, err := service.GetWaitlistEntry(student, joined.ID)
+		require.NoError(t, err)
+		assert.Equal(t, waitlist.StatusCancelled, stored.Status)
+	})
+
+	t.Run("Only the owner or a manager can see the entry", func(t *testing.T) {
+		_, err := service.GetWaitlistEntry(other, joined.ID)
+		assert.ErrorIs(t, err, common.ErrNotFound)
+		_, err = service.GetWaitlistEntry(nil, joined.ID)
+		assert.ErrorIs(t, err, common.ErrUnauthorized)
+		_, err = service.GetWaitlistEntry(manager, joined.ID)
+		assert.NoError(t, err)
+	})
+}
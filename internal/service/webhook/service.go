@@ -0,0 +1,250 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/webhook"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	signing "sarc-ng/pkg/webhook"
+)
+
+// SinkName identifies the webhook subscriptions among the event sinks
+const SinkName = "webhooks"
+
+// Service implements webhook.Usecase interface. It is also the event sink
+// posting dispatched events to the subscriptions, so failed posts are retried
+// with the outbox's backoff.
+type Service struct {
+	repo   webhook.Repository
+	sender webhook.Sender
+}
+
+// Compile-time verification that Service implements webhook.Usecase and event.Sink
+var (
+	_ webhook.Usecase = (*Service)(nil)
+	_ event.Sink      = (*Service)(nil)
+)
+
+// NewService creates a new webhook service
+func NewService(repo webhook.Repository, sender webhook.Sender) *Service {
+	return &Service{
+		repo:   repo,
+		sender: sender,
+	}
+}
+
+// GetAllWebhookSubscriptions retrieves every subscription
+func (s *Service) GetAllWebhookSubscriptions() ([]webhook.Subscription, error) {
+	return s.repo.ReadWebhookSubscriptionList()
+}
+
+// GetWebhookSubscription retrieves a subscription by ID with validation
+func (s *Service) GetWebhookSubscription(id uint) (*webhook.Subscription, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("%w: webhook subscription ID cannot be zero", common.ErrInvalidInput)
+	}
+	return s.repo.ReadWebhookSubscription(id)
+}
+
+// CreateWebhookSubscription stores a subscription with a new secret, recording
+// the admin who created it
+func (s *Service) CreateWebhookSubscription(admin *auth.User, sub *webhook.Subscription) error {
+	if admin == nil {
+		return fmt.Errorf("%w: authentication required", common.ErrUnauthorized)
+	}
+
+	sub.URL = strings.TrimSpace(sub.URL)
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+
+	secret, err := signing.NewSecret()
+	if err != nil {
+		return fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	sub.Secret = secret
+	sub.CreatedBy = admin.ID
+	return s.repo.CreateWebhookSubscription(sub)
+}
+
+// UpdateWebhookSubscription replaces the URL, description, filters and state
+// of a subscription, keeping its secret
+func (s *Service) UpdateWebhookSubscription(sub *webhook.Subscription) error {
+	if sub.ID == 0 {
+		return fmt.Errorf("%w: webhook subscription ID cannot be zero for update", common.ErrInvalidInput)
+	}
+
+	sub.URL = strings.TrimSpace(sub.URL)
+	if err := sub.Validate(); err != nil {
+		return err
+	}
+
+	current, err := s.repo.ReadWebhookSubscription(sub.ID)
+	if err != nil {
+		return err
+	}
+
+	sub.Secret = current.Secret
+	sub.CreatedBy = current.CreatedBy
+	sub.CreatedAt = current.CreatedAt
+	return s.repo.UpdateWebhookSubscription(sub)
+}
+
+// DeleteWebhookSubscription removes a subscription and its delivery log
+func (s *Service) DeleteWebhookSubscription(id uint) error {
+	if _, err := s.GetWebhookSubscription(id); err != nil {
+		return err
+	}
+	return s.repo.DeleteWebhookSubscription(id)
+}
+
+// RotateWebhookSecret replaces the secret of a subscription. Requests are
+// signed with the new secret from then on.
+func (s *Service) RotateWebhookSecret(id uint) (*webhook.Subscription, error) {
+	sub, err := s.GetWebhookSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := signing.NewSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	sub.Secret = secret
+	if err := s.repo.UpdateWebhookSubscription(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// GetWebhookDeliveries retrieves a page of the delivery log of a subscription
+func (s *Service) GetWebhookDeliveries(subscriptionID uint, query common.Query) (*common.Page[webhook.Delivery], error) {
+	if _, err := s.GetWebhookSubscription(subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.repo.ReadWebhookDeliveryList(query.Where("subscriptionId", common.OpEqual, subscriptionID))
+}
+
+// Name identifies the webhook subscriptions among the event sinks
+func (s *Service) Name() string {
+	return SinkName
+}
+
+// Deliver posts the message to every active subscription that wants its type
+// and has not received it yet, recording each attempt. Subscriptions are posted
+// in parallel so a slow receiver does not hold up the others past the
+// deadline of ctx. It fails when any subscription did, so the message is
+// retried for those later.
+//
+// A receiver may get an event more than once, when its response is lost or
+// arrives after the deadline, so receivers must dedupe on the event ID.
+func (s *Service) Deliver(ctx context.Context, m event.Message) error {
+	subscriptions, err := s.repo.ReadActiveWebhookSubscriptionList()
+	if err != nil {
+		return err
+	}
+
+	type post struct {
+		sub     *webhook.Subscription
+		attempt int
+	}
+	var pending []post
+	for i := range subscriptions {
+		sub := &subscriptions[i]
+		if !sub.Wants(m.Type) {
+			continue
+		}
+
+		last, err := s.repo.FindLastWebhookDelivery(sub.ID, m.ID)
+		if err != nil {
+			return err
+		}
+		attempt := 1
+		if last != nil {
+			if last.Status == webhook.DeliverySucceeded {
+				continue
+			}
+			attempt = last.Attempt + 1
+		}
+		pending = append(pending, post{sub: sub, attempt: attempt})
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event.NewEnvelope(m))
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	deliveries := make([]webhook.Delivery, len(pending))
+	for i, p := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			deliveries[i] = s.post(ctx, p.sub, m, body, p.attempt)
+		}()
+	}
+	wg.Wait()
+
+	var failures []error
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		if err := s.repo.CreateWebhookDelivery(delivery); err != nil {
+			return fmt.Errorf("failed to record webhook delivery: %w", err)
+		}
+		if delivery.Status == webhook.DeliveryFailed {
+			failures = append(failures, fmt.Errorf("subscription %d: %s", delivery.SubscriptionID, delivery.Error))
+		}
+	}
+	return errors.Join(failures...)
+}
+
+// post sends a signed request to a subscription and describes the attempt
+func (s *Service) post(ctx context.Context, sub *webhook.Subscription, m event.Message, body []byte, attempt int) webhook.Delivery {
+	now := time.Now()
+	request := webhook.Request{
+		URL: sub.URL,
+		Headers: map[string]string{
+			"Content-Type":          "application/json",
+			signing.EventHeader:     string(m.Type),
+			signing.EventIDHeader:   strconv.FormatUint(uint64(m.ID), 10),
+			signing.TimestampHeader: strconv.FormatInt(now.Unix(), 10),
+			signing.SignatureHeader: signing.Sign(sub.Secret, now, body),
+		},
+		Body: body,
+	}
+
+	delivery := webhook.Delivery{
+		SubscriptionID: sub.ID,
+		EventID:        m.ID,
+		EventType:      m.Type,
+		Attempt:        attempt,
+		Status:         webhook.DeliveryFailed,
+	}
+	response, err := s.sender.Send(ctx, request)
+	delivery.Duration = time.Since(now)
+	switch {
+	case err != nil:
+		delivery.Error = err.Error()
+	case response.StatusCode < 200 || response.StatusCode > 299:
+		delivery.StatusCode = response.StatusCode
+		delivery.ResponseBody = response.Body
+		delivery.Error = fmt.Sprintf("receiver responded with status %d", response.StatusCode)
+	default:
+		delivery.Status = webhook.DeliverySucceeded
+		delivery.StatusCode = response.StatusCode
+		delivery.ResponseBody = response.Body
+	}
+	return delivery
+}
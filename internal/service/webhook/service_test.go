@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	eventAdapter "sarc-ng/internal/adapter/gorm/event"
+	"sarc-ng/internal/adapter/gorm/gormtest"
+	webhookAdapter "sarc-ng/internal/adapter/gorm/webhook"
+	httpSender "sarc-ng/internal/adapter/webhook"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/webhook"
+	eventService "sarc-ng/internal/service/event"
+	signing "sarc-ng/pkg/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var admin = &auth.User{ID: "admin-1", Groups: []string{"admin"}}
+
+// receiver is an httptest server that verifies signatures and refuses the
+// first failures requests it gets
+type receiver struct {
+	*httptest.Server
+	failures atomic.Int32
+	received chan event.Envelope
+}
+
+func newReceiver(t *testing.T, secret *string, failures int32) *receiver {
+	r := &receiver{received: make(chan event.Envelope, 10)}
+	r.failures.Store(failures)
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if err := signing.Verify(req.Header, *secret, body, time.Minute); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if r.failures.Add(-1) >= 0 {
+			http.Error(w, "try again later", http.StatusServiceUnavailable)
+			return
+		}
+		var envelope event.Envelope
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, string(envelope.Type), req.Header.Get(signing.EventHeader))
+		r.received <- envelope
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// recordEvent stores a message in the outbox as if a change had just been made
+func recordEvent(t *testing.T, db *gorm.DB, e event.Event) {
+	require.NoError(t, eventAdapter.NewOutbox(db).RecordEvents(e))
+}
+
+func newServices(t *testing.T) (*gorm.DB, *Service, *eventService.Service) {
+	db := gormtest.Open(t, &eventAdapter.OutboxGormModel{}, &webhookAdapter.SubscriptionGormModel{}, &webhookAdapter.DeliveryGormModel{})
+	service := NewService(webhookAdapter.NewGormAdapter(db), httpSender.NewHTTPSender())
+	events := eventService.NewService(eventAdapter.NewGormAdapter(db), []event.Sink{service},
+		event.Settings{BatchSize: 10, MaxAttempts: 3, RetryBackoff: time.Millisecond})
+	return db, service, events
+}
+
+func TestDeliverSignsAndFiltersEvents(t *testing.T) {
+	db, service, events := newServices(t)
+
+	var secret string
+	r := newReceiver(t, &secret, 0)
+	sub := &webhook.Subscription{URL: r.URL, EventTypes: []event.Type{event.TypeReservationCreated}, Active: true}
+	require.NoError(t, service.CreateWebhookSubscription(admin, sub))
+	secret = sub.Secret
+	assert.NotEmpty(t, secret)
+	assert.Equal(t, "admin-1", sub.CreatedBy)
+
+	inactive := &webhook.Subscription{URL: r.URL}
+	require.NoError(t, service.CreateWebhookSubscription(admin, inactive))
+
+	recordEvent(t, db, event.ResourceCreated{Resource: event.Resource{ID: 4, Name: "Projector"}})
+	recordEvent(t, db, event.ReservationCreated{Reservation: event.Reservation{ID: 7, ResourceID: 4}})
+
+	delivered, err := events.DispatchEvents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+
+	require.Len(t, r.received, 1, "only the filtered type is posted, and only to the active subscription")
+	envelope := <-r.received
+	assert.Equal(t, event.TypeReservationCreated, envelope.Type)
+	assert.Equal(t, uint(7), envelope.AggregateID)
+
+	deliveries, err := service.GetWebhookDeliveries(sub.ID, common.Query{})
+	require.NoError(t, err)
+	require.Len(t, deliveries.Items, 1)
+	assert.Equal(t, webhook.DeliverySucceeded, deliveries.Items[0].Status)
+	assert.Equal(t, http.StatusNoContent, deliveries.Items[0].StatusCode)
+	assert.Equal(t, 1, deliveries.Items[0].Attempt)
+}
+
+func TestDeliverRetriesFailedSubscriptions(t *testing.T) {
+	db, service, events := newServices(t)
+
+	var secret, flakySecret string
+	healthy := newReceiver(t, &secret, 0)
+	flaky := newReceiver(t, &flakySecret, 1)
+
+	sub := &webhook.Subscription{URL: healthy.URL, Active: true}
+	require.NoError(t, service.CreateWebhookSubscription(admin, sub))
+	secret = sub.Secret
+	flakySub := &webhook.Subscription{URL: flaky.URL, Active: true}
+	require.NoError(t, service.CreateWebhookSubscription(admin, flakySub))
+	flakySecret = flakySub.Secret
+
+	recordEvent(t, db, event.ReservationDeleted{Reservation: event.Reservation{ID: 9}})
+
+	delivered, err := events.DispatchEvents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered, "the flaky receiver refused the first attempt")
+	assert.Len(t, healthy.received, 1)
+
+	messages, err := events.GetOutboxMessages(common.Query{})
+	require.NoError(t, err)
+	require.Len(t, messages.Items, 1)
+	assert.Equal(t, event.StatusPending, messages.Items[0].Status)
+	assert.Contains(t, messages.Items[0].LastError, "status 503")
+
+	time.Sleep(5 * time.Millisecond)
+	delivered, err = events.DispatchEvents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, healthy.received, 1, "subscriptions that got the event are not posted again")
+	assert.Len(t, flaky.received, 1)
+
+	deliveries, err := service.GetWebhookDeliveries(flakySub.ID, common.Query{})
+	require.NoError(t, err)
+	require.Len(t, deliveries.Items, 2)
+	assert.Equal(t, webhook.DeliveryFailed, deliveries.Items[0].Status)
+	assert.Equal(t, "try again later\n", deliveries.Items[0].ResponseBody)
+	assert.Equal(t, 1, deliveries.Items[0].Attempt)
+	assert.Equal(t, webhook.DeliverySucceeded, deliveries.Items[1].Status)
+	assert.Equal(t, 2, deliveries.Items[1].Attempt)
+}
+
+func TestDeliverPostsSubscriptionsInParallel(t *testing.T) {
+	db, service, events := newServices(t)
+
+	// Receivers answer only once both have been posted to
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	both := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(both)
+	}()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		arrived.Done()
+		select {
+		case <-both:
+			w.WriteHeader(http.StatusNoContent)
+		case <-time.After(time.Second):
+			http.Error(w, "posted alone", http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	for range 2 {
+		require.NoError(t, service.CreateWebhookSubscription(admin, &webhook.Subscription{URL: server.URL, Active: true}))
+	}
+	recordEvent(t, db, event.ReservationDeleted{Reservation: event.Reservation{ID: 9}})
+
+	delivered, err := events.DispatchEvents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered, "a receiver waiting on the other does not hold it up")
+}
+
+func TestSubscriptionManagement(t *testing.T) {
+	_, service, _ := newServices(t)
+
+	err := service.CreateWebhookSubscription(admin, &webhook.Subscription{URL: "ftp://example.com"})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+	err = service.CreateWebhookSubscription(admin, &webhook.Subscription{URL: "https://example.com", EventTypes: []event.Type{"reservation.exploded"}})
+	assert.ErrorIs(t, err, common.ErrInvalidInput)
+
+	sub := &webhook.Subscription{URL: "https://example.com/hooks", Active: true}
+	require.NoError(t, service.CreateWebhookSubscription(admin, sub))
+	secret := sub.Secret
+
+	update := &webhook.Subscription{ID: sub.ID, URL: "https://example.com/v2", Active: false}
+	require.NoError(t, service.UpdateWebhookSubscription(update))
+	assert.Equal(t, secret, update.Secret, "updates keep the secret")
+	assert.False(t, update.Active)
+
+	rotated, err := service.RotateWebhookSecret(sub.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, rotated.Secret)
+
+	require.NoError(t, service.DeleteWebhookSubscription(sub.ID))
+	_, err = service.GetWebhookSubscription(sub.ID)
+	assert.ErrorIs(t, err, common.ErrNotFound)
+}
@@ -37,14 +37,7 @@ func (h *BaseHandler[Entity, CreateDTO, UpdateDTO, ResponseDTO]) ParseIDAndBindJ
 	}
 
 	var updateDTO UpdateDTO
-	if err := c.ShouldBindJSON(&updateDTO); err != nil {
-		RespondWithError(c, http.StatusBadRequest, "Invalid JSON format", err.Error())
-		return 0, nil, err
-	}
-
-	// Validate the DTO using struct tags
-	if err := validate.Struct(&updateDTO); err != nil {
-		RespondWithError(c, http.StatusBadRequest, "Validation failed", err.Error())
+	if err := BindAndValidateJSON(c, &updateDTO); err != nil {
 		return 0, nil, err
 	}
 
@@ -54,16 +47,26 @@ func (h *BaseHandler[Entity, CreateDTO, UpdateDTO, ResponseDTO]) ParseIDAndBindJ
 // BindCreateJSON is a helper method that binds JSON for create operations
 func (h *BaseHandler[Entity, CreateDTO, UpdateDTO, ResponseDTO]) BindCreateJSON(c *gin.Context) (*CreateDTO, error) {
 	var createDTO CreateDTO
-	if err := c.ShouldBindJSON(&createDTO); err != nil {
-		RespondWithError(c, http.StatusBadRequest, "Invalid JSON format", err.Error())
+	if err := BindAndValidateJSON(c, &createDTO); err != nil {
 		return nil, err
 	}
 
+	return &createDTO, nil
+}
+
+// BindAndValidateJSON binds the request body into dto and validates it using struct tags.
+// On failure it writes a 400 response and returns the error.
+func BindAndValidateJSON(c *gin.Context, dto any) error {
+	if err := c.ShouldBindJSON(dto); err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid JSON format", err.Error())
+		return err
+	}
+
 	// Validate the DTO using struct tags
-	if err := validate.Struct(&createDTO); err != nil {
+	if err := validate.Struct(dto); err != nil {
 		RespondWithError(c, http.StatusBadRequest, "Validation failed", err.Error())
-		return nil, err
+		return err
 	}
 
-	return &createDTO, nil
+	return nil
 }
@@ -37,7 +37,16 @@ func HandleError(c *gin.Context, err error, defaultMessage string) {
 	// Check for mapped domain errors using checker functions
 	for _, mapping := range errorMappings {
 		if mapping.Checker(err) {
-			RespondWithError(c, mapping.Mapping.StatusCode, mapping.Mapping.Message, err.Error())
+			response := ErrorResponse{
+				Error:   mapping.Mapping.Message,
+				Message: err.Error(),
+				Code:    mapping.Mapping.StatusCode,
+			}
+			// Policy violations also name the rule that was broken
+			if ruleErr, ok := domainCommon.AsRuleError(err); ok {
+				response.Rule = ruleErr.Rule
+			}
+			c.JSON(response.Code, response)
 			return
 		}
 	}
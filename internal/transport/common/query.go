@@ -0,0 +1,91 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/pkg/rest/types"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryFilter maps a query string parameter to a filter on a domain field
+type QueryFilter struct {
+	Param string
+	Field string
+	Op    domainCommon.Operator
+	Parse func(value string) (any, error)
+}
+
+// Equal filters a field by the query parameter of the same name
+func Equal(param string, parse func(string) (any, error)) QueryFilter {
+	return QueryFilter{Param: param, Field: param, Op: domainCommon.OpEqual, Parse: parse}
+}
+
+// ParseString accepts any non-empty value
+func ParseString(value string) (any, error) {
+	return value, nil
+}
+
+// ParseUint accepts a positive integer such as an ID
+func ParseUint(value string) (any, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id == 0 {
+		return nil, fmt.Errorf("must be a positive integer")
+	}
+	return uint(id), nil
+}
+
+// ParseBool accepts true or false
+func ParseBool(value string) (any, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("must be true or false")
+	}
+	return b, nil
+}
+
+// ParseTime accepts an RFC 3339 timestamp
+func ParseTime(value string) (any, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("must be an RFC 3339 timestamp")
+	}
+	return t, nil
+}
+
+// ParseListQuery reads pagination, sorting and the given filters from the query
+// string. It responds with 400 and returns false when a filter is malformed.
+func ParseListQuery(c *gin.Context, filters ...QueryFilter) (domainCommon.Query, types.PaginationParams, bool) {
+	params := types.ExtractPaginationParams(c)
+	query := domainCommon.Query{
+		Sort:   params.Sort,
+		Desc:   params.Order == "desc",
+		Limit:  params.Limit(),
+		Offset: params.Offset(),
+		Cursor: c.Query("cursor"),
+	}
+
+	query, ok := ApplyFilters(c, query, filters...)
+	return query, params, ok
+}
+
+// ApplyFilters adds the given filters that are present in the query string to
+// the query. It responds with 400 and returns false when one is malformed.
+func ApplyFilters(c *gin.Context, query domainCommon.Query, filters ...QueryFilter) (domainCommon.Query, bool) {
+	for _, filter := range filters {
+		raw := c.Query(filter.Param)
+		if raw == "" {
+			continue
+		}
+		value, err := filter.Parse(raw)
+		if err != nil {
+			RespondWithError(c, http.StatusBadRequest, "Invalid "+filter.Param+" parameter", filter.Param+" "+err.Error())
+			return domainCommon.Query{}, false
+		}
+		query = query.Where(filter.Field, filter.Op, value)
+	}
+	return query, true
+}
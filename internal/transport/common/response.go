@@ -2,15 +2,19 @@ package common
 
 import (
 	"net/http"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/pkg/rest/middleware"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
-// ErrorResponse represents a standard error response
+// ErrorResponse represents a standard error response. Rule names the booking
+// policy rule a request broke, if any.
 type ErrorResponse struct {
 	Error   string `json:"error" example:"Invalid input"`
 	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+	Rule    string `json:"rule,omitempty" example:"maxDuration"`
 	Code    int    `json:"code" example:"400"`
 }
 
@@ -34,6 +38,26 @@ func ParseIDFromPath(c *gin.Context, entityName string) (uint, error) {
 	return uint(id), nil
 }
 
+// CurrentUser returns the authenticated user, responding with 401 when there is none
+func CurrentUser(c *gin.Context) (*auth.User, bool) {
+	user, exists := middleware.GetUserFromContext(c)
+	if !exists {
+		RespondWithError(c, http.StatusUnauthorized, "Unauthorized", "authentication required")
+		return nil, false
+	}
+	return user, true
+}
+
+// Actor returns the user a change is made on behalf of: the authenticated user
+// or, on routes that do not require a token, an anonymous user that only
+// carries the request ID for the audit log
+func Actor(c *gin.Context) *auth.User {
+	if user, exists := middleware.GetUserFromContext(c); exists {
+		return user
+	}
+	return &auth.User{RequestID: middleware.GetRequestID(c)}
+}
+
 // RespondWithError sends a standardized error response
 func RespondWithError(c *gin.Context, statusCode int, error string, message string) {
 	c.JSON(statusCode, ErrorResponse{
@@ -0,0 +1,43 @@
+package apikey
+
+import (
+	"time"
+)
+
+// CreateAPIKeyDTO represents the data needed to create an API key
+type CreateAPIKeyDTO struct {
+	Name        string     `json:"name" validate:"required,max=255" example:"Lobby kiosk"`
+	Permissions []string   `json:"permissions" validate:"required,min=1,dive,required" example:"checkin:issue"`
+	BuildingIDs []uint     `json:"buildingIds,omitempty" validate:"dive,min=1" example:"3"` // empty for every building
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`                                     // never expires when absent
+}
+
+// UpdateAPIKeyDTO represents the data needed to update an API key
+type UpdateAPIKeyDTO struct {
+	Name        string     `json:"name" validate:"required,max=255" example:"Lobby kiosk"`
+	Permissions []string   `json:"permissions" validate:"required,min=1,dive,required" example:"checkin:issue"`
+	BuildingIDs []uint     `json:"buildingIds,omitempty" validate:"dive,min=1" example:"3"` // empty for every building
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`                                     // never expires when absent
+}
+
+// APIKeyDTO represents an API key. The key itself is only returned when it
+// is created.
+type APIKeyDTO struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix" example:"sarc_3f1c9a0"`
+	Permissions []string   `json:"permissions"`
+	BuildingIDs []uint     `json:"buildingIds"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedBy   string     `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// APIKeySecretDTO represents an API key with the key to send in the X-API-Key
+// header
+type APIKeySecretDTO struct {
+	APIKeyDTO
+	Key string `json:"key" example:"sarc_3f1c9a0..."`
+}
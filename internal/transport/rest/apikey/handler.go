@@ -0,0 +1,180 @@
+package apikey
+
+import (
+	"net/http"
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/transport/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for API keys
+type Handler struct {
+	*common.BaseHandler[apikey.APIKey, CreateAPIKeyDTO, UpdateAPIKeyDTO, APIKeyDTO]
+	service apikey.Usecase
+	mapper  *Mapper
+}
+
+// NewHandler creates a new API key handler
+func NewHandler(service apikey.Usecase) *Handler {
+	mapper := NewMapper()
+	baseHandler := common.NewBaseHandler[apikey.APIKey, CreateAPIKeyDTO, UpdateAPIKeyDTO, APIKeyDTO](
+		"API key")
+	return &Handler{
+		BaseHandler: baseHandler,
+		service:     service,
+		mapper:      mapper,
+	}
+}
+
+// GetAll retrieves every API key
+// @Summary List API keys
+// @Description Retrieve every API key with its permissions, buildings, expiry and last use. Keys themselves are not included. Admins only.
+// @Tags api-keys
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Success 200 {array} APIKeyDTO "List of API keys"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /api-keys [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	entities, err := h.service.GetAllAPIKeys()
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve API keys")
+		return
+	}
+
+	dtos := make([]APIKeyDTO, len(entities))
+	for i, entity := range entities {
+		dtos[i] = *h.mapper.FromDomain(&entity)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GetByID retrieves an API key by ID
+// @Summary Get API key by ID
+// @Description Retrieve an API key by its unique identifier. The key itself is not included. Admins only.
+// @Tags api-keys
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "API key ID" minimum(1)
+// @Success 200 {object} APIKeyDTO "API key details"
+// @Failure 400 {object} common.ErrorResponse "Invalid API key ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "API key not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /api-keys/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	entity, err := h.service.GetAPIKey(id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Create generates a new API key
+// @Summary Create an API key
+// @Description Generate a key for a service or device that cannot sign in. It is sent in the X-API-Key header instead of a token and only has the listed permissions, optionally only in the listed buildings. The key is in the response and is not shown again; only its hash is stored. Admins only.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param apiKey body CreateAPIKeyDTO true "API key data"
+// @Success 201 {object} APIKeySecretDTO "Created API key with the key"
+// @Failure 400 {object} common.ErrorResponse "Invalid name, permission, building or expiry"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /api-keys [post]
+func (h *Handler) Create(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	createDTO, err := h.BindCreateJSON(c)
+	if err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomain(createDTO)
+	if err := h.service.CreateAPIKey(user, entity); err != nil {
+		common.HandleError(c, err, "Failed to create "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapper.WithKeyFromDomain(entity))
+}
+
+// Update replaces the settings of an API key
+// @Summary Update an API key
+// @Description Replace the name, permissions, buildings and expiry of an API key by ID. The key itself is kept. Admins only.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "API key ID" minimum(1)
+// @Param apiKey body UpdateAPIKeyDTO true "API key data"
+// @Success 200 {object} APIKeyDTO "Updated API key"
+// @Failure 400 {object} common.ErrorResponse "Invalid name, permission or building"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "API key not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /api-keys/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	id, updateDTO, err := h.ParseIDAndBindJSON(c)
+	if err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomainWithID(updateDTO, id)
+	if err := h.service.UpdateAPIKey(entity); err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Delete revokes an API key
+// @Summary Delete an API key
+// @Description Revoke an API key by ID; requests with it are refused from now on. Admins only.
+// @Tags api-keys
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "API key ID" minimum(1)
+// @Success 200 {object} common.SuccessResponse "API key deleted successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid API key ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "API key not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /api-keys/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	if err := h.service.DeleteAPIKey(id); err != nil {
+		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
+		return
+	}
+
+	common.RespondWithSuccess(c, http.StatusOK, h.GetEntityName()+" deleted successfully")
+}
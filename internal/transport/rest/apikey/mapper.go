@@ -0,0 +1,85 @@
+package apikey
+
+import (
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/domain/auth"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new API key mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts a domain entity to DTO
+func (m *Mapper) FromDomain(entity *apikey.APIKey) *APIKeyDTO {
+	if entity == nil {
+		return nil
+	}
+	permissions := make([]string, len(entity.Permissions))
+	for i, p := range entity.Permissions {
+		permissions[i] = string(p)
+	}
+	buildings := entity.BuildingIDs
+	if buildings == nil {
+		buildings = []uint{}
+	}
+	return &APIKeyDTO{
+		ID:          entity.ID,
+		Name:        entity.Name,
+		Prefix:      entity.Prefix,
+		Permissions: permissions,
+		BuildingIDs: buildings,
+		ExpiresAt:   entity.ExpiresAt,
+		LastUsedAt:  entity.LastUsedAt,
+		CreatedBy:   entity.CreatedBy,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+// WithKeyFromDomain converts a domain entity to DTO including its key
+func (m *Mapper) WithKeyFromDomain(entity *apikey.APIKey) *APIKeySecretDTO {
+	if entity == nil {
+		return nil
+	}
+	return &APIKeySecretDTO{APIKeyDTO: *m.FromDomain(entity), Key: entity.Key}
+}
+
+// ToDomain converts a create DTO to domain entity
+func (m *Mapper) ToDomain(dto *CreateAPIKeyDTO) *apikey.APIKey {
+	if dto == nil {
+		return nil
+	}
+	return &apikey.APIKey{
+		Name:        dto.Name,
+		Permissions: permissions(dto.Permissions),
+		BuildingIDs: dto.BuildingIDs,
+		ExpiresAt:   dto.ExpiresAt,
+	}
+}
+
+// ToDomainWithID converts an update DTO to domain entity with ID
+func (m *Mapper) ToDomainWithID(dto *UpdateAPIKeyDTO, id uint) *apikey.APIKey {
+	if dto == nil {
+		return nil
+	}
+	return &apikey.APIKey{
+		ID:          id,
+		Name:        dto.Name,
+		Permissions: permissions(dto.Permissions),
+		BuildingIDs: dto.BuildingIDs,
+		ExpiresAt:   dto.ExpiresAt,
+	}
+}
+
+// permissions converts permission names
+func permissions(names []string) []auth.Permission {
+	result := make([]auth.Permission, len(names))
+	for i, name := range names {
+		result[i] = auth.Permission(name)
+	}
+	return result
+}
@@ -0,0 +1,24 @@
+package apikey
+
+import (
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the API key routes, which take the apikey:manage
+// permission
+func RegisterRoutes(rg *gin.RouterGroup, service apikey.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+
+	keys := rg.Group("/api-keys", middleware.RequirePermission(permissions, auth.PermissionAPIKeyManage))
+	{
+		keys.GET("", handler.GetAll)
+		keys.GET("/:id", handler.GetByID)
+		keys.POST("", handler.Create)
+		keys.PUT("/:id", handler.Update)
+		keys.DELETE("/:id", handler.Delete)
+	}
+}
@@ -0,0 +1,25 @@
+package audit
+
+import "time"
+
+// AuditEntryDTO represents a change recorded in the audit log
+type AuditEntryDTO struct {
+	ID            uint        `json:"id"`
+	Action        string      `json:"action" example:"update"`
+	EntityType    string      `json:"entityType" example:"reservation"`
+	EntityID      uint        `json:"entityId" example:"42"`
+	ActorID       string      `json:"actorId,omitempty"` // absent for changes made by background jobs and unauthenticated requests
+	ActorUsername string      `json:"actorUsername,omitempty"`
+	ActorGroups   []string    `json:"actorGroups,omitempty"`
+	RequestID     string      `json:"requestId,omitempty"`
+	Changes       []ChangeDTO `json:"changes"`
+	CreatedAt     time.Time   `json:"createdAt"`
+}
+
+// ChangeDTO is the value of a field before and after a change; null where the
+// entity did not exist
+type ChangeDTO struct {
+	Field  string `json:"field" example:"status"`
+	Before any    `json:"before" swaggertype:"string" example:"pending"`
+	After  any    `json:"after" swaggertype:"string" example:"approved"`
+}
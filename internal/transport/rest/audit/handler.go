@@ -0,0 +1,168 @@
+package audit
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sarc-ng/internal/domain/audit"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for the audit log
+type Handler struct {
+	service audit.Usecase
+	mapper  *Mapper
+}
+
+// entryFilters are the query parameters audit entries can be filtered by
+var entryFilters = []common.QueryFilter{
+	common.Equal("entityType", common.ParseString),
+	common.Equal("entityId", common.ParseUint),
+	common.Equal("action", parseAction),
+	common.Equal("actorId", common.ParseString),
+	common.Equal("requestId", common.ParseString),
+	{Param: "from", Field: "createdAt", Op: domainCommon.OpGreaterOrEqual, Parse: common.ParseTime},
+	{Param: "to", Field: "createdAt", Op: domainCommon.OpLessOrEqual, Parse: common.ParseTime},
+}
+
+// NewHandler creates a new audit handler
+func NewHandler(service audit.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// GetAll retrieves a page of the audit log
+// @Summary List audit entries
+// @Description Retrieve a page of the changes made to buildings, classes, lessons, resources and reservations, with who made them and how each field changed. Sortable fields: id, action, entityType, entityId, actorId, requestId, createdAt. Admins only.
+// @Tags audit
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param entityType query string false "Type of the changed entity" Enums(building, class, lesson, resource, reservation)
+// @Param entityId query int false "ID of the changed entity"
+// @Param action query string false "Kind of change" Enums(create, update, delete, transition)
+// @Param actorId query string false "Cognito sub of the user who made the change"
+// @Param requestId query string false "ID of the request that made the change"
+// @Param from query string false "Changes made at or after this time (RFC 3339)"
+// @Param to query string false "Changes made at or before this time (RFC 3339)"
+// @Success 200 {object} types.PaginatedResponse[AuditEntryDTO] "Page of audit entries"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /audit [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	query, params, ok := common.ParseListQuery(c, entryFilters...)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetAuditEntries(query)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve audit entries")
+		return
+	}
+
+	dtos := make([]AuditEntryDTO, len(page.Items))
+	for i, entry := range page.Items {
+		dtos[i] = *h.mapper.FromDomain(&entry)
+	}
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
+}
+
+// GetByID retrieves an audit entry by ID
+// @Summary Get audit entry by ID
+// @Description Retrieve an audit entry by its unique identifier. Admins only.
+// @Tags audit
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Audit entry ID" minimum(1)
+// @Success 200 {object} AuditEntryDTO "Audit entry details"
+// @Failure 400 {object} common.ErrorResponse "Invalid audit entry ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Audit entry not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /audit/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, "audit entry")
+	if err != nil {
+		return
+	}
+
+	entry, err := h.service.GetAuditEntry(id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve audit entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entry))
+}
+
+// Export downloads the audit log as CSV
+// @Summary Export audit entries as CSV
+// @Description Download every audit entry matching the filters as CSV, one row per changed field, streamed as the entries are read. Cells that would start a spreadsheet formula are prefixed with a quote. Takes the filters and sorting of the list; paging is ignored. Admins only.
+// @Tags audit
+// @Produce text/csv
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param entityType query string false "Type of the changed entity" Enums(building, class, lesson, resource, reservation)
+// @Param entityId query int false "ID of the changed entity"
+// @Param action query string false "Kind of change" Enums(create, update, delete, transition)
+// @Param actorId query string false "Cognito sub of the user who made the change"
+// @Param requestId query string false "ID of the request that made the change"
+// @Param from query string false "Changes made at or after this time (RFC 3339)"
+// @Param to query string false "Changes made at or before this time (RFC 3339)"
+// @Success 200 {string} string "CSV of the audit entries"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter or sort field"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /audit/export [get]
+func (h *Handler) Export(c *gin.Context) {
+	query, _, ok := common.ParseListQuery(c, entryFilters...)
+	if !ok {
+		return
+	}
+
+	// Entries are streamed as they are read, so the headers go first
+	filename := fmt.Sprintf("audit-%s.csv", time.Now().UTC().Format("20060102T150405Z"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.service.ExportAuditEntries(c.Writer, query); err != nil {
+		if c.Writer.Written() {
+			// The status is sent already, so the download is cut short
+			log.Printf("Failed to export audit entries: %v", err)
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		common.HandleError(c, err, "Failed to export audit entries")
+	}
+}
+
+// parseAction accepts one of the audit actions
+func parseAction(value string) (any, error) {
+	for _, action := range audit.Actions {
+		if value == string(action) {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("must be one of create, update, delete or transition")
+}
@@ -0,0 +1,34 @@
+package audit
+
+import "sarc-ng/internal/domain/audit"
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new audit mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts a domain entity to DTO
+func (m *Mapper) FromDomain(entity *audit.Entry) *AuditEntryDTO {
+	if entity == nil {
+		return nil
+	}
+	changes := make([]ChangeDTO, len(entity.Changes))
+	for i, c := range entity.Changes {
+		changes[i] = ChangeDTO{Field: c.Field, Before: c.Before, After: c.After}
+	}
+	return &AuditEntryDTO{
+		ID:            entity.ID,
+		Action:        string(entity.Action),
+		EntityType:    entity.EntityType,
+		EntityID:      entity.EntityID,
+		ActorID:       entity.ActorID,
+		ActorUsername: entity.ActorUsername,
+		ActorGroups:   entity.ActorGroups,
+		RequestID:     entity.RequestID,
+		Changes:       changes,
+		CreatedAt:     entity.CreatedAt,
+	}
+}
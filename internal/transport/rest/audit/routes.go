@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"sarc-ng/internal/domain/audit"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the audit log routes, which take the audit:read
+// permission
+func RegisterRoutes(rg *gin.RouterGroup, service audit.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+
+	entries := rg.Group("/audit", middleware.RequirePermission(permissions, auth.PermissionAuditRead))
+	{
+		entries.GET("", handler.GetAll)
+		entries.GET("/export", handler.Export)
+		entries.GET("/:id", handler.GetByID)
+	}
+}
@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"time"
+)
+
+// DevTokenRequestDTO asks for a development token for any user. Missing
+// fields get defaults: the developer username and its dev- prefixed subject.
+type DevTokenRequestDTO struct {
+	Subject   string   `json:"subject" validate:"omitempty,max=255" example:"dev-alice"`
+	Username  string   `json:"username" validate:"omitempty,max=255" example:"alice"`
+	Email     string   `json:"email" validate:"omitempty,email" example:"alice@example.com"`
+	Groups    []string `json:"groups" validate:"dive,required" example:"manager"`
+	ExpiresIn string   `json:"expiresIn" example:"8h"` // Go duration; the configured expiry when empty
+}
+
+// TokenDTO represents an issued access token
+type TokenDTO struct {
+	AccessToken string    `json:"accessToken"`
+	TokenType   string    `json:"tokenType" example:"Bearer"`
+	ExpiresIn   int64     `json:"expiresIn" example:"86400"` // seconds
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// DiscoveryDTO is the part of the OpenID Connect discovery document other
+// services need to trust the tokens
+type DiscoveryDTO struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
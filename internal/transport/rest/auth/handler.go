@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/transport/common"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for the tokens of the local issuer
+type Handler struct {
+	issuer auth.TokenIssuer
+	mapper *Mapper
+}
+
+// NewHandler creates a new auth handler
+func NewHandler(issuer auth.TokenIssuer) *Handler {
+	return &Handler{
+		issuer: issuer,
+		mapper: NewMapper(),
+	}
+}
+
+// IssueDevToken issues a token for the user described in the request
+// @Summary Issue a development token
+// @Description Sign an access token for any subject, username, email and groups, so the protected API can be used without an identity provider. Only available with auth.provider local and jwt.dev_tokens enabled; never enable it in production.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body DevTokenRequestDTO true "Who the token is for"
+// @Success 201 {object} TokenDTO "Issued token"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /auth/token [post]
+func (h *Handler) IssueDevToken(c *gin.Context) {
+	var dto DevTokenRequestDTO
+	if err := common.BindAndValidateJSON(c, &dto); err != nil {
+		return
+	}
+
+	var ttl time.Duration
+	if dto.ExpiresIn != "" {
+		var err error
+		ttl, err = time.ParseDuration(dto.ExpiresIn)
+		if err != nil || ttl <= 0 {
+			common.RespondWithError(c, http.StatusBadRequest, "Invalid expiresIn", fmt.Sprintf("'%s' is not a positive duration such as 8h", dto.ExpiresIn))
+			return
+		}
+	}
+
+	token, err := h.issuer.IssueToken(h.mapper.UserToDomain(&dto), ttl)
+	if err != nil {
+		common.HandleError(c, err, "Failed to issue token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapper.TokenFromDomain(token))
+}
+
+// GetKeySet publishes the public keys tokens are verified with
+// @Summary Get the token signing keys
+// @Description The JSON Web Key Set of the local token issuer. Empty for HS256, whose secret is never published.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.JWKS "Public keys"
+// @Router /.well-known/jwks.json [get]
+func (h *Handler) GetKeySet(c *gin.Context) {
+	c.JSON(http.StatusOK, h.issuer.KeySet())
+}
+
+// GetDiscovery publishes where the keys of the local issuer are
+// @Summary Get the OpenID Connect discovery document
+// @Description Lets other services trust the tokens of the local issuer as an OpenID Connect provider, when jwt.issuer is the public URL of this server.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} DiscoveryDTO "Discovery document"
+// @Router /.well-known/openid-configuration [get]
+func (h *Handler) GetDiscovery(c *gin.Context) {
+	issuer := h.issuer.Issuer()
+	algorithms := []string{}
+	for _, key := range h.issuer.KeySet().Keys {
+		if key.Alg != "" && !slices.Contains(algorithms, key.Alg) {
+			algorithms = append(algorithms, key.Alg)
+		}
+	}
+
+	c.JSON(http.StatusOK, &DiscoveryDTO{
+		Issuer:                           issuer,
+		JWKSURI:                          strings.TrimSuffix(issuer, "/") + "/.well-known/jwks.json",
+		IDTokenSigningAlgValuesSupported: algorithms,
+	})
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"time"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new auth mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// UserToDomain converts a development token request to the user it is for
+func (m *Mapper) UserToDomain(dto *DevTokenRequestDTO) *auth.User {
+	username := dto.Username
+	if username == "" {
+		username = "developer"
+	}
+	subject := dto.Subject
+	if subject == "" {
+		subject = "dev-" + username
+	}
+	return &auth.User{
+		ID:       subject,
+		Username: username,
+		Email:    dto.Email,
+		Groups:   dto.Groups,
+	}
+}
+
+// TokenFromDomain converts an issued token to DTO
+func (m *Mapper) TokenFromDomain(token *auth.Token) *TokenDTO {
+	if token == nil {
+		return nil
+	}
+	return &TokenDTO{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(token.ExpiresAt).Round(time.Second).Seconds()),
+		ExpiresAt:   token.ExpiresAt,
+	}
+}
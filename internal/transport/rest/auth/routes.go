@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"sarc-ng/internal/domain/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the routes of the local token issuer. Its keys are
+// published at the root, where OpenID Connect clients look for them; the
+// development token route only exists when development tokens are enabled.
+func RegisterRoutes(root, rg *gin.RouterGroup, issuer auth.TokenIssuer) {
+	handler := NewHandler(issuer)
+
+	root.GET("/.well-known/jwks.json", handler.GetKeySet)
+	root.GET("/.well-known/openid-configuration", handler.GetDiscovery)
+
+	if issuer.DevTokens() {
+		rg.POST("/auth/token", handler.IssueDevToken)
+	}
+}
@@ -0,0 +1,12 @@
+package availability
+
+import "time"
+
+// SlotDTO represents an open window of a resource
+type SlotDTO struct {
+	ResourceID      uint      `json:"resourceId"`
+	ResourceName    string    `json:"resourceName"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	DurationMinutes int       `json:"durationMinutes"`
+}
@@ -0,0 +1,130 @@
+package availability
+
+import (
+	"net/http"
+	"sarc-ng/internal/domain/availability"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/transport/common"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// resourceFilters are the query parameters that select candidate resources
+var resourceFilters = []common.QueryFilter{
+	common.Equal("type", common.ParseString),
+	common.Equal("buildingId", common.ParseUint),
+	common.Equal("classId", common.ParseUint),
+}
+
+// Handler handles HTTP requests for free-slot searches
+type Handler struct {
+	service availability.Usecase
+	mapper  *Mapper
+}
+
+// NewHandler creates a new availability handler
+func NewHandler(service availability.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// FindForResource lists the free slots of a resource
+// @Summary Find free slots of a resource
+// @Description List the open windows of at least the given duration in which the resource has no active reservation, no lesson in its class and is within opening hours. Slots are ranked by earliest start.
+// @Tags resources
+// @Produce json
+// @Param id path int true "Resource ID" minimum(1)
+// @Param from query string true "Start of the search window (RFC 3339)"
+// @Param to query string true "End of the search window (RFC 3339), at most 31 days after from"
+// @Param duration query string true "Minimum slot length, e.g. 90m or 2h"
+// @Param limit query int false "Maximum number of slots" default(20) minimum(1) maximum(100)
+// @Success 200 {array} SlotDTO "Free slots, earliest first"
+// @Failure 400 {object} common.ErrorResponse "Invalid search parameters"
+// @Failure 404 {object} common.ErrorResponse "Resource not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /resources/{id}/free-slots [get]
+func (h *Handler) FindForResource(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, "resource")
+	if err != nil {
+		return
+	}
+
+	search, ok := parseSearch(c)
+	if !ok {
+		return
+	}
+
+	slots, err := h.service.FindFreeSlots(id, search)
+	if err != nil {
+		common.HandleError(c, err, "Failed to find free slots")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(slots))
+}
+
+// FindAcross lists the free slots of every matching resource
+// @Summary Find free slots across resources
+// @Description List the open windows of at least the given duration across every available resource matching the filters, e.g. any projector for 2h tomorrow. Slots are ranked by earliest start.
+// @Tags resources
+// @Produce json
+// @Param from query string true "Start of the search window (RFC 3339)"
+// @Param to query string true "End of the search window (RFC 3339), at most 31 days after from"
+// @Param duration query string true "Minimum slot length, e.g. 90m or 2h"
+// @Param limit query int false "Maximum number of slots" default(20) minimum(1) maximum(100)
+// @Param type query string false "Resource type"
+// @Param buildingId query int false "Building ID"
+// @Param classId query int false "Class ID"
+// @Success 200 {array} SlotDTO "Free slots, earliest first"
+// @Failure 400 {object} common.ErrorResponse "Invalid search parameters"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /resources/free-slots [get]
+func (h *Handler) FindAcross(c *gin.Context) {
+	search, ok := parseSearch(c)
+	if !ok {
+		return
+	}
+
+	resources, ok := common.ApplyFilters(c, domainCommon.Query{}, resourceFilters...)
+	if !ok {
+		return
+	}
+
+	slots, err := h.service.FindFreeSlotsAcross(resources, search)
+	if err != nil {
+		common.HandleError(c, err, "Failed to find free slots")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(slots))
+}
+
+// parseSearch reads the search window, duration and limit from the query string
+func parseSearch(c *gin.Context) (availability.Search, bool) {
+	var search availability.Search
+	var err error
+
+	if search.From, err = time.Parse(time.RFC3339, c.Query("from")); err != nil {
+		common.RespondWithError(c, http.StatusBadRequest, "Invalid from parameter", "from must be an RFC 3339 timestamp")
+		return search, false
+	}
+	if search.To, err = time.Parse(time.RFC3339, c.Query("to")); err != nil {
+		common.RespondWithError(c, http.StatusBadRequest, "Invalid to parameter", "to must be an RFC 3339 timestamp")
+		return search, false
+	}
+	if search.Duration, err = time.ParseDuration(c.Query("duration")); err != nil {
+		common.RespondWithError(c, http.StatusBadRequest, "Invalid duration parameter", "duration must look like 90m or 2h")
+		return search, false
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if search.Limit, err = strconv.Atoi(limit); err != nil {
+			common.RespondWithError(c, http.StatusBadRequest, "Invalid limit parameter", "limit must be an integer")
+			return search, false
+		}
+	}
+	return search, true
+}
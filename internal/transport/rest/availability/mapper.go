@@ -0,0 +1,26 @@
+package availability
+
+import "sarc-ng/internal/domain/availability"
+
+// Mapper handles conversions between domain slots and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new availability mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts domain slots to DTOs
+func (m *Mapper) FromDomain(slots []availability.Slot) []SlotDTO {
+	dtos := make([]SlotDTO, len(slots))
+	for i, slot := range slots {
+		dtos[i] = SlotDTO{
+			ResourceID:      slot.ResourceID,
+			ResourceName:    slot.ResourceName,
+			StartTime:       slot.Start,
+			EndTime:         slot.End,
+			DurationMinutes: int(slot.Length().Minutes()),
+		}
+	}
+	return dtos
+}
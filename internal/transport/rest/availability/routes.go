@@ -0,0 +1,15 @@
+package availability
+
+import (
+	"sarc-ng/internal/domain/availability"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the free-slot search routes
+func RegisterRoutes(rg *gin.RouterGroup, service availability.Usecase) {
+	handler := NewHandler(service)
+
+	rg.GET("/resources/free-slots", handler.FindAcross)
+	rg.GET("/resources/:id/free-slots", handler.FindForResource)
+}
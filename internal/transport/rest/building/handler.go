@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"sarc-ng/internal/domain/building"
 	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +16,11 @@ type Handler struct {
 	mapper  *Mapper
 }
 
+// listFilters are the query parameters buildings can be filtered by
+var listFilters = []common.QueryFilter{
+	common.Equal("code", common.ParseString),
+}
+
 // NewHandler creates a new building handler
 func NewHandler(service building.Usecase) *Handler {
 	mapper := NewMapper()
@@ -27,27 +33,39 @@ func NewHandler(service building.Usecase) *Handler {
 	}
 }
 
-// GetAll retrieves all buildings
-// @Summary Get all buildings
-// @Description Retrieve a list of all buildings in the system
+// GetAll retrieves a page of buildings
+// @Summary List buildings
+// @Description Retrieve a page of buildings, optionally filtered. Sortable fields: id, name, code, createdAt, updatedAt.
 // @Tags buildings
 // @Accept json
 // @Produce json
-// @Success 200 {array} BuildingDTO "List of buildings"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param code query string false "Building code"
+// @Success 200 {object} types.PaginatedResponse[BuildingDTO] "Page of buildings"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /buildings [get]
 func (h *Handler) GetAll(c *gin.Context) {
-	entities, err := h.service.GetAllBuildings()
+	query, params, ok := common.ParseListQuery(c, listFilters...)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetAllBuildings(query)
 	if err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve "+h.GetEntityName()+"s", err.Error())
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName()+"s")
 		return
 	}
 
-	dtos := make([]BuildingDTO, len(entities))
-	for i, entity := range entities {
+	dtos := make([]BuildingDTO, len(page.Items))
+	for i, entity := range page.Items {
 		dtos[i] = *h.mapper.FromDomain(&entity)
 	}
-	c.JSON(http.StatusOK, dtos)
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
 }
 
 // GetByID retrieves a building by ID
@@ -88,9 +106,13 @@ func (h *Handler) GetByID(c *gin.Context) {
 // @Tags buildings
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param building body CreateBuildingDTO true "Building creation data"
 // @Success 201 {object} BuildingDTO "Created building"
 // @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /buildings [post]
 func (h *Handler) Create(c *gin.Context) {
@@ -100,7 +122,7 @@ func (h *Handler) Create(c *gin.Context) {
 	}
 
 	entity := h.mapper.ToDomain(createDTO)
-	if err := h.service.CreateBuilding(entity); err != nil {
+	if err := h.service.CreateBuilding(common.Actor(c), entity); err != nil {
 		common.RespondWithError(c, http.StatusInternalServerError, "Failed to create "+h.GetEntityName(), err.Error())
 		return
 	}
@@ -115,10 +137,14 @@ func (h *Handler) Create(c *gin.Context) {
 // @Tags buildings
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param id path int true "Building ID" minimum(1)
 // @Param building body UpdateBuildingDTO true "Building update data"
 // @Success 200 {object} BuildingDTO "Updated building"
 // @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 404 {object} common.ErrorResponse "Building not found"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /buildings/{id} [put]
@@ -129,7 +155,7 @@ func (h *Handler) Update(c *gin.Context) {
 	}
 
 	entity := h.mapper.ToDomainWithID(updateDTO, id)
-	if err := h.service.UpdateBuilding(entity); err != nil {
+	if err := h.service.UpdateBuilding(common.Actor(c), entity); err != nil {
 		common.RespondWithError(c, http.StatusInternalServerError, "Failed to update "+h.GetEntityName(), err.Error())
 		return
 	}
@@ -144,10 +170,15 @@ func (h *Handler) Update(c *gin.Context) {
 // @Tags buildings
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param id path int true "Building ID" minimum(1)
 // @Success 200 {object} common.SuccessResponse "Building deleted successfully"
 // @Failure 400 {object} common.ErrorResponse "Invalid building ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 404 {object} common.ErrorResponse "Building not found"
+// @Failure 409 {object} common.ErrorResponse "Building still has classes or resources"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /buildings/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
@@ -156,7 +187,7 @@ func (h *Handler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteBuilding(id); err != nil {
+	if err := h.service.DeleteBuilding(common.Actor(c), id); err != nil {
 		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
 		return
 	}
@@ -1,21 +1,29 @@
 package building
 
 import (
+	"sarc-ng/internal/domain/auth"
 	"sarc-ng/internal/domain/building"
+	"sarc-ng/pkg/rest/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes sets up the building routes
-func RegisterRoutes(rg *gin.RouterGroup, service building.Usecase) {
+// RegisterRoutes sets up the building routes. Anyone may read the buildings;
+// changing them takes the building:write permission, so the writes are
+// registered on the authenticated group.
+func RegisterRoutes(public, protected *gin.RouterGroup, service building.Usecase, permissions auth.Permissions) {
 	handler := NewHandler(service)
 
-	buildings := rg.Group("/buildings")
+	buildings := public.Group("/buildings")
 	{
 		buildings.GET("", handler.GetAll)
-		buildings.POST("", handler.Create)
 		buildings.GET("/:id", handler.GetByID)
-		buildings.PUT("/:id", handler.Update)
-		buildings.DELETE("/:id", handler.Delete)
+	}
+
+	writes := protected.Group("/buildings", middleware.RequirePermission(permissions, auth.PermissionBuildingWrite))
+	{
+		writes.POST("", handler.Create)
+		writes.PUT("/:id", handler.Update)
+		writes.DELETE("/:id", handler.Delete)
 	}
 }
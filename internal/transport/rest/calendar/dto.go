@@ -0,0 +1,28 @@
+package calendar
+
+import (
+	"time"
+)
+
+// CreateFeedTokenDTO represents the data needed to issue a calendar feed token
+type CreateFeedTokenDTO struct {
+	Label string `json:"label" validate:"max=100" example:"Work laptop"`
+}
+
+// FeedTokenDTO represents a calendar feed token without its secret
+type FeedTokenDTO struct {
+	ID         uint       `json:"id"`
+	UserID     string     `json:"userId"`
+	Label      string     `json:"label"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// CreatedFeedTokenDTO represents a newly issued feed token. The token and feed
+// path are only returned once.
+type CreatedFeedTokenDTO struct {
+	FeedTokenDTO
+	Token    string `json:"token"`
+	FeedPath string `json:"feedPath" example:"/api/v1/users/0f2c.../calendar.ics?token=..."`
+}
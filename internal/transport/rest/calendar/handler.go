@@ -0,0 +1,258 @@
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sarc-ng/internal/domain/calendar"
+	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/middleware"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contentType is the media type of iCalendar feeds
+const contentType = "text/calendar; charset=utf-8"
+
+// Handler handles HTTP requests for calendar feeds and feed tokens
+type Handler struct {
+	service calendar.Usecase
+	mapper  *Mapper
+}
+
+// NewHandler creates a new calendar handler
+func NewHandler(service calendar.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// ResourceFeed serves the iCalendar feed of a resource
+// @Summary Resource calendar feed
+// @Description iCalendar feed of the reservations of a resource, for subscribing from calendar clients. Reservations are shown as anonymous busy blocks unless a manager signs in.
+// @Security BearerAuth
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path int true "Resource ID" minimum(1)
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource ID"
+// @Failure 404 {object} common.ErrorResponse "Resource not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /resources/{id}/calendar.ics [get]
+func (h *Handler) ResourceFeed(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, "resource")
+	if err != nil {
+		return
+	}
+
+	user, _ := middleware.GetUserFromContext(c)
+	feed, err := h.service.GetResourceFeed(user, id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve resource calendar")
+		return
+	}
+	if !feed.Anonymous {
+		// Feeds with booking details must not be stored by shared caches
+		c.Header("Cache-Control", "private, no-store")
+	}
+
+	h.respondWithFeed(c, feed, fmt.Sprintf("resource-%d.ics", id))
+}
+
+// BuildingFeed serves the iCalendar feed of a building
+// @Summary Building calendar feed
+// @Description iCalendar feed of the reservations of every resource in a building. Reservations are shown as anonymous busy blocks unless a manager signs in.
+// @Security BearerAuth
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path int true "Building ID" minimum(1)
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} common.ErrorResponse "Invalid building ID"
+// @Failure 404 {object} common.ErrorResponse "Building not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /buildings/{id}/calendar.ics [get]
+func (h *Handler) BuildingFeed(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, "building")
+	if err != nil {
+		return
+	}
+
+	user, _ := middleware.GetUserFromContext(c)
+	feed, err := h.service.GetBuildingFeed(user, id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve building calendar")
+		return
+	}
+	if !feed.Anonymous {
+		// Feeds with booking details must not be stored by shared caches
+		c.Header("Cache-Control", "private, no-store")
+	}
+
+	h.respondWithFeed(c, feed, fmt.Sprintf("building-%d.ics", id))
+}
+
+// LessonFeed serves the iCalendar feed of all lessons
+// @Summary Lesson calendar feed
+// @Description iCalendar feed of recent and upcoming lessons
+// @Tags calendar
+// @Produce text/calendar
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /lessons/calendar.ics [get]
+func (h *Handler) LessonFeed(c *gin.Context) {
+	feed, err := h.service.GetLessonFeed()
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve lesson calendar")
+		return
+	}
+
+	h.respondWithFeed(c, feed, "lessons.ics")
+}
+
+// UserFeed serves the personal iCalendar feed of a user
+// @Summary Personal calendar feed
+// @Description iCalendar feed of a user's reservations. Calendar clients cannot send bearer tokens, so access is granted by a revocable feed token in the query string.
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path string true "User subject"
+// @Param token query string true "Feed token"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 400 {object} common.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} common.ErrorResponse "Missing, invalid or revoked feed token"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /users/{id}/calendar.ics [get]
+func (h *Handler) UserFeed(c *gin.Context) {
+	userID := c.Param("id")
+	if userID == "" {
+		common.RespondWithError(c, http.StatusBadRequest, "Invalid user ID", "user ID is required")
+		return
+	}
+
+	feed, err := h.service.GetUserFeed(userID, c.Query("token"))
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve user calendar")
+		return
+	}
+
+	// Personal feeds must not be stored by shared caches
+	c.Header("Cache-Control", "private, no-store")
+	h.respondWithFeed(c, feed, "personal.ics")
+}
+
+// CreateToken issues a feed token for the authenticated user
+// @Summary Create a calendar feed token
+// @Description Issue a revocable token for the caller's personal calendar feed. The token is only returned once.
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param token body CreateFeedTokenDTO true "Token data"
+// @Success 201 {object} CreatedFeedTokenDTO "Created token with its feed path"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /me/calendar-tokens [post]
+func (h *Handler) CreateToken(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var createDTO CreateFeedTokenDTO
+	if err := common.BindAndValidateJSON(c, &createDTO); err != nil {
+		return
+	}
+
+	feedToken, token, err := h.service.CreateFeedToken(user.ID, createDTO.Label)
+	if err != nil {
+		common.HandleError(c, err, "Failed to create calendar feed token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreatedFeedTokenDTO{
+		FeedTokenDTO: *h.mapper.FromDomain(feedToken),
+		Token:        token,
+		FeedPath:     fmt.Sprintf("/api/v1/users/%s/calendar.ics?token=%s", url.PathEscape(user.ID), token),
+	})
+}
+
+// GetTokens lists the feed tokens of the authenticated user
+// @Summary List calendar feed tokens
+// @Description List the caller's feed tokens, including revoked ones. Secrets are never returned.
+// @Tags calendar
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Success 200 {array} FeedTokenDTO "List of feed tokens"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /me/calendar-tokens [get]
+func (h *Handler) GetTokens(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.service.GetFeedTokens(user.ID)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve calendar feed tokens")
+		return
+	}
+
+	dtos := make([]FeedTokenDTO, len(tokens))
+	for i, token := range tokens {
+		dtos[i] = *h.mapper.FromDomain(&token)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// RevokeToken revokes a feed token of the authenticated user
+// @Summary Revoke a calendar feed token
+// @Description Revoke a feed token; calendar clients using it stop receiving updates
+// @Tags calendar
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param tokenId path int true "Feed token ID" minimum(1)
+// @Success 200 {object} common.SuccessResponse "Feed token revoked"
+// @Failure 400 {object} common.ErrorResponse "Invalid ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 404 {object} common.ErrorResponse "Feed token not found"
+// @Failure 409 {object} common.ErrorResponse "Feed token already revoked"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /me/calendar-tokens/{tokenId} [delete]
+func (h *Handler) RevokeToken(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 32)
+	if err != nil {
+		common.RespondWithError(c, http.StatusBadRequest, "Invalid feed token ID", "feed token ID must be a positive integer")
+		return
+	}
+
+	if err := h.service.RevokeFeedToken(user.ID, uint(tokenID)); err != nil {
+		common.HandleError(c, err, "Failed to revoke calendar feed token")
+		return
+	}
+
+	common.RespondWithSuccess(c, http.StatusOK, "calendar feed token revoked successfully")
+}
+
+// respondWithFeed encodes the feed as iCalendar data
+func (h *Handler) respondWithFeed(c *gin.Context, feed *calendar.Feed, filename string) {
+	var body bytes.Buffer
+	if err := h.mapper.ToCalendar(feed).Encode(&body); err != nil {
+		common.RespondWithError(c, http.StatusInternalServerError, "Failed to encode calendar", err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	c.Data(http.StatusOK, contentType, body.Bytes())
+}
@@ -0,0 +1,125 @@
+package calendar
+
+import (
+	"fmt"
+	"sarc-ng/internal/domain/calendar"
+	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/pkg/ical"
+	"strings"
+	"time"
+)
+
+const (
+	// busySummary is the title of reservations in anonymous feeds
+	busySummary = "Reserved"
+
+	// prodID identifies this application as the producer of the feeds
+	prodID = "-//SARC-NG//Calendar Feed//EN"
+
+	// uidDomain makes event UIDs globally unique; it must never change
+	uidDomain = "sarc-ng"
+
+	// refreshInterval hints subscribed clients how often to poll
+	refreshInterval = time.Hour
+)
+
+// Mapper handles conversions between domain entities, DTOs and iCalendar data
+type Mapper struct{}
+
+// NewMapper creates a new calendar mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts a domain feed token to DTO
+func (m *Mapper) FromDomain(entity *calendar.FeedToken) *FeedTokenDTO {
+	if entity == nil {
+		return nil
+	}
+	return &FeedTokenDTO{
+		ID:         entity.ID,
+		UserID:     entity.UserID,
+		Label:      entity.Label,
+		LastUsedAt: entity.LastUsedAt,
+		RevokedAt:  entity.RevokedAt,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
+
+// ToCalendar converts a feed to an iCalendar calendar
+func (m *Mapper) ToCalendar(feed *calendar.Feed) *ical.Calendar {
+	cal := &ical.Calendar{
+		ProdID:          prodID,
+		Name:            feed.Name,
+		RefreshInterval: refreshInterval,
+		Events:          make([]ical.Event, 0, len(feed.Reservations)+len(feed.Lessons)),
+	}
+
+	for _, r := range feed.Reservations {
+		event := ical.Event{
+			UID:          fmt.Sprintf("reservation-%d@%s", r.ID, uidDomain),
+			Summary:      r.Purpose,
+			Description:  r.Description,
+			Start:        r.StartTime,
+			End:          r.EndTime,
+			Status:       eventStatus(r.Status),
+			Created:      r.CreatedAt,
+			LastModified: r.UpdatedAt,
+		}
+		if res, ok := feed.Resources[r.ResourceID]; ok {
+			event.Location = joinNonEmpty(", ", res.Name, res.Location)
+		}
+		if r.StatusReason != "" {
+			event.Description = joinNonEmpty("\n\n", event.Description, string(r.Status)+": "+r.StatusReason)
+		}
+		if feed.Anonymous {
+			event.Summary = busySummary
+			event.Description = ""
+		}
+		cal.Events = append(cal.Events, event)
+	}
+
+	for _, l := range feed.Lessons {
+		cal.Events = append(cal.Events, lessonEvent(l))
+	}
+
+	return cal
+}
+
+// lessonEvent converts a lesson to an event
+func lessonEvent(l lesson.Lesson) ical.Event {
+	return ical.Event{
+		UID:          fmt.Sprintf("lesson-%d@%s", l.ID, uidDomain),
+		Summary:      l.Title,
+		Description:  l.Description,
+		Start:        l.StartTime,
+		End:          l.EndTime,
+		Status:       ical.StatusConfirmed,
+		Created:      l.CreatedAt,
+		LastModified: l.UpdatedAt,
+	}
+}
+
+// eventStatus maps a reservation status to the VEVENT status
+func eventStatus(status reservation.Status) ical.EventStatus {
+	switch status {
+	case reservation.StatusPending:
+		return ical.StatusTentative
+	case reservation.StatusRejected, reservation.StatusCancelled:
+		return ical.StatusCancelled
+	default:
+		return ical.StatusConfirmed
+	}
+}
+
+// joinNonEmpty joins the non-empty values with sep
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,31 @@
+package calendar
+
+import (
+	"sarc-ng/internal/domain/calendar"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterFeedRoutes sets up the read-only iCalendar feed routes. Calendar clients
+// cannot send bearer tokens, so these routes must not require the auth
+// middleware; rg may authenticate callers optionally, so managers see details.
+func RegisterFeedRoutes(rg *gin.RouterGroup, service calendar.Usecase) {
+	handler := NewHandler(service)
+
+	rg.GET("/resources/:id/calendar.ics", handler.ResourceFeed)
+	rg.GET("/buildings/:id/calendar.ics", handler.BuildingFeed)
+	rg.GET("/lessons/calendar.ics", handler.LessonFeed)
+	rg.GET("/users/:id/calendar.ics", handler.UserFeed)
+}
+
+// RegisterRoutes sets up the feed token management routes
+func RegisterRoutes(rg *gin.RouterGroup, service calendar.Usecase) {
+	handler := NewHandler(service)
+
+	tokens := rg.Group("/me/calendar-tokens")
+	{
+		tokens.GET("", handler.GetTokens)
+		tokens.POST("", handler.CreateToken)
+		tokens.DELETE("/:tokenId", handler.RevokeToken)
+	}
+}
@@ -0,0 +1,24 @@
+package checkin
+
+import (
+	"time"
+)
+
+// CheckInTokenDTO represents a newly issued check-in token. The token is only
+// returned once; it belongs in the QR code posted at the resource.
+type CheckInTokenDTO struct {
+	ID         uint      `json:"id"`
+	ResourceID uint      `json:"resourceId"`
+	IssuedBy   string    `json:"issuedBy"`
+	Token      string    `json:"token"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// NoShowDTO represents a reservation its owner did not check in to
+type NoShowDTO struct {
+	ID            uint      `json:"id"`
+	ReservationID uint      `json:"reservationId"`
+	ResourceID    uint      `json:"resourceId"`
+	StartTime     time.Time `json:"startTime"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
@@ -0,0 +1,89 @@
+package checkin
+
+import (
+	"net/http"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/internal/transport/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for check-in tokens and no-shows
+type Handler struct {
+	service checkin.Usecase
+	mapper  *Mapper
+}
+
+// NewHandler creates a new check-in handler
+func NewHandler(service checkin.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// IssueToken issues the check-in token for the QR code of a resource
+// @Summary Issue a check-in token
+// @Description Issue the token encoded in the QR code posted at a resource, which lets anyone check in to its reservations. Earlier tokens of the resource stop working. The token is only returned once. Requires the manager or admin group.
+// @Tags check-in
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Resource ID" minimum(1)
+// @Success 201 {object} CheckInTokenDTO "Issued token"
+// @Failure 400 {object} common.ErrorResponse "Invalid resource ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Resource not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /resources/{id}/check-in-token [post]
+func (h *Handler) IssueToken(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	resourceID, err := common.ParseIDFromPath(c, "resource")
+	if err != nil {
+		return
+	}
+
+	token, secret, err := h.service.IssueCheckInToken(user, resourceID)
+	if err != nil {
+		common.HandleError(c, err, "Failed to issue check-in token")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapper.TokenFromDomain(token, secret))
+}
+
+// GetMyNoShows lists the no-shows of the authenticated user
+// @Summary List my no-shows
+// @Description List the reservations the caller did not check in to, most recent first. Booking policies may suspend booking after too many.
+// @Tags check-in
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Success 200 {array} NoShowDTO "List of no-shows"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /me/no-shows [get]
+func (h *Handler) GetMyNoShows(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	noShows, err := h.service.GetNoShows(user.ID)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve no-shows")
+		return
+	}
+
+	dtos := make([]NoShowDTO, len(noShows))
+	for i, noShow := range noShows {
+		dtos[i] = *h.mapper.NoShowFromDomain(&noShow)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
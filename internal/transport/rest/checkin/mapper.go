@@ -0,0 +1,41 @@
+package checkin
+
+import (
+	"sarc-ng/internal/domain/checkin"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new check-in mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// TokenFromDomain converts a check-in token and its secret to DTO
+func (m *Mapper) TokenFromDomain(entity *checkin.Token, secret string) *CheckInTokenDTO {
+	if entity == nil {
+		return nil
+	}
+	return &CheckInTokenDTO{
+		ID:         entity.ID,
+		ResourceID: entity.ResourceID,
+		IssuedBy:   entity.IssuedBy,
+		Token:      secret,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
+
+// NoShowFromDomain converts a no-show to DTO
+func (m *Mapper) NoShowFromDomain(entity *checkin.NoShow) *NoShowDTO {
+	if entity == nil {
+		return nil
+	}
+	return &NoShowDTO{
+		ID:            entity.ID,
+		ReservationID: entity.ReservationID,
+		ResourceID:    entity.ResourceID,
+		StartTime:     entity.StartTime,
+		CreatedAt:     entity.CreatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package checkin
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/checkin"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the check-in token and no-show routes. Issuing check-in
+// tokens takes the checkin:issue permission.
+func RegisterRoutes(rg *gin.RouterGroup, service checkin.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+
+	rg.GET("/me/no-shows", handler.GetMyNoShows)
+	rg.POST("/resources/:id/check-in-token", middleware.RequirePermission(permissions, auth.PermissionCheckInIssue), handler.IssueToken)
+}
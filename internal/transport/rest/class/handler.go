@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"sarc-ng/internal/domain/class"
 	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +16,11 @@ type Handler struct {
 	mapper  *Mapper
 }
 
+// listFilters are the query parameters classes can be filtered by
+var listFilters = []common.QueryFilter{
+	common.Equal("buildingId", common.ParseUint),
+}
+
 // NewHandler creates a new class handler
 func NewHandler(service class.Usecase) *Handler {
 	mapper := NewMapper()
@@ -27,27 +33,39 @@ func NewHandler(service class.Usecase) *Handler {
 	}
 }
 
-// GetAll retrieves all classes
-// @Summary Get all classes
-// @Description Retrieve a list of all classes in the system
+// GetAll retrieves a page of classes
+// @Summary List classes
+// @Description Retrieve a page of classes, optionally filtered. Sortable fields: id, name, capacity, buildingId, createdAt, updatedAt.
 // @Tags classes
 // @Accept json
 // @Produce json
-// @Success 200 {array} ClassDTO "List of classes"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param buildingId query int false "Building ID"
+// @Success 200 {object} types.PaginatedResponse[ClassDTO] "Page of classes"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /classes [get]
 func (h *Handler) GetAll(c *gin.Context) {
-	entities, err := h.service.GetAllClasses()
+	query, params, ok := common.ParseListQuery(c, listFilters...)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetAllClasses(query)
 	if err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve "+h.GetEntityName()+"s", err.Error())
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName()+"s")
 		return
 	}
 
-	dtos := make([]ClassDTO, len(entities))
-	for i, entity := range entities {
+	dtos := make([]ClassDTO, len(page.Items))
+	for i, entity := range page.Items {
 		dtos[i] = *h.mapper.FromDomain(&entity)
 	}
-	c.JSON(http.StatusOK, dtos)
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
 }
 
 // GetByID retrieves a class by ID
@@ -88,9 +106,13 @@ func (h *Handler) GetByID(c *gin.Context) {
 // @Tags classes
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param class body CreateClassDTO true "Class creation data"
 // @Success 201 {object} ClassDTO "Created class"
 // @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /classes [post]
 func (h *Handler) Create(c *gin.Context) {
@@ -100,8 +122,8 @@ func (h *Handler) Create(c *gin.Context) {
 	}
 
 	entity := h.mapper.ToDomain(createDTO)
-	if err := h.service.CreateClass(entity); err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to create "+h.GetEntityName(), err.Error())
+	if err := h.service.CreateClass(common.Actor(c), entity); err != nil {
+		common.HandleError(c, err, "Failed to create "+h.GetEntityName())
 		return
 	}
 
@@ -115,10 +137,14 @@ func (h *Handler) Create(c *gin.Context) {
 // @Tags classes
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param id path int true "Class ID" minimum(1)
 // @Param class body UpdateClassDTO true "Class update data"
 // @Success 200 {object} ClassDTO "Updated class"
 // @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 404 {object} common.ErrorResponse "Class not found"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /classes/{id} [put]
@@ -129,8 +155,8 @@ func (h *Handler) Update(c *gin.Context) {
 	}
 
 	entity := h.mapper.ToDomainWithID(updateDTO, id)
-	if err := h.service.UpdateClass(entity); err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to update "+h.GetEntityName(), err.Error())
+	if err := h.service.UpdateClass(common.Actor(c), entity); err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName())
 		return
 	}
 
@@ -144,10 +170,15 @@ func (h *Handler) Update(c *gin.Context) {
 // @Tags classes
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param id path int true "Class ID" minimum(1)
 // @Success 200 {object} common.SuccessResponse "Class deleted successfully"
 // @Failure 400 {object} common.ErrorResponse "Invalid class ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 404 {object} common.ErrorResponse "Class not found"
+// @Failure 409 {object} common.ErrorResponse "Class still has resources or lessons"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /classes/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
@@ -156,10 +187,41 @@ func (h *Handler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteClass(id); err != nil {
+	if err := h.service.DeleteClass(common.Actor(c), id); err != nil {
 		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
 		return
 	}
 
 	common.RespondWithSuccess(c, http.StatusOK, h.GetEntityName()+" deleted successfully")
 }
+
+// GetByBuilding retrieves the classes of a building
+// @Summary Get classes of a building
+// @Description Retrieve the classes located in a specific building
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path int true "Building ID" minimum(1)
+// @Success 200 {array} ClassDTO "List of classes"
+// @Failure 400 {object} common.ErrorResponse "Invalid building ID"
+// @Failure 404 {object} common.ErrorResponse "Building not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /buildings/{id}/classes [get]
+func (h *Handler) GetByBuilding(c *gin.Context) {
+	buildingID, err := common.ParseIDFromPath(c, "building")
+	if err != nil {
+		return
+	}
+
+	entities, err := h.service.GetClassesByBuilding(buildingID)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName()+"es")
+		return
+	}
+
+	dtos := make([]ClassDTO, len(entities))
+	for i, entity := range entities {
+		dtos[i] = *h.mapper.FromDomain(&entity)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
@@ -18,11 +18,12 @@ func (m *Mapper) FromDomain(entity *class.Class) *ClassDTO {
 		return nil
 	}
 	return &ClassDTO{
-		ID:        entity.ID,
-		Name:      entity.Name,
-		Capacity:  entity.Capacity,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
+		ID:         entity.ID,
+		Name:       entity.Name,
+		Capacity:   entity.Capacity,
+		BuildingID: entity.BuildingID,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
 	}
 }
 
@@ -32,8 +33,9 @@ func (m *Mapper) ToDomain(dto *CreateClassDTO) *class.Class {
 		return nil
 	}
 	return &class.Class{
-		Name:     dto.Name,
-		Capacity: dto.Capacity,
+		Name:       dto.Name,
+		Capacity:   dto.Capacity,
+		BuildingID: dto.BuildingID,
 	}
 }
 
@@ -43,8 +45,9 @@ func (m *Mapper) ToDomainWithID(dto *UpdateClassDTO, id uint) *class.Class {
 		return nil
 	}
 	return &class.Class{
-		ID:       id,
-		Name:     dto.Name,
-		Capacity: dto.Capacity,
+		ID:         id,
+		Name:       dto.Name,
+		Capacity:   dto.Capacity,
+		BuildingID: dto.BuildingID,
 	}
 }
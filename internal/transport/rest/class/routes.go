@@ -1,21 +1,31 @@
 package class
 
 import (
+	"sarc-ng/internal/domain/auth"
 	"sarc-ng/internal/domain/class"
+	"sarc-ng/pkg/rest/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes sets up the class routes
-func RegisterRoutes(rg *gin.RouterGroup, service class.Usecase) {
+// RegisterRoutes sets up the class routes. Anyone may read the classes;
+// changing them takes the class:write permission, so the writes are registered
+// on the authenticated group.
+func RegisterRoutes(public, protected *gin.RouterGroup, service class.Usecase, permissions auth.Permissions) {
 	handler := NewHandler(service)
 
-	classes := rg.Group("/classes")
+	classes := public.Group("/classes")
 	{
 		classes.GET("", handler.GetAll)
-		classes.POST("", handler.Create)
 		classes.GET("/:id", handler.GetByID)
-		classes.PUT("/:id", handler.Update)
-		classes.DELETE("/:id", handler.Delete)
+	}
+
+	public.GET("/buildings/:id/classes", handler.GetByBuilding)
+
+	writes := protected.Group("/classes", middleware.RequirePermission(permissions, auth.PermissionClassWrite))
+	{
+		writes.POST("", handler.Create)
+		writes.PUT("/:id", handler.Update)
+		writes.DELETE("/:id", handler.Delete)
 	}
 }
@@ -0,0 +1,22 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventDTO represents a domain event in the outbox together with its delivery state
+type EventDTO struct {
+	ID            uint            `json:"id"`
+	Type          string          `json:"type" example:"reservation.created"`
+	AggregateType string          `json:"aggregateType" example:"reservation"`
+	AggregateID   uint            `json:"aggregateId"`
+	Data          json.RawMessage `json:"data" swaggertype:"object"`
+	Status        string          `json:"status" example:"pending"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"nextAttemptAt,omitempty"` // set while the event is pending
+	DeliveredTo   []string        `json:"deliveredTo"`             // sinks that accepted the event
+	LastError     string          `json:"lastError,omitempty"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	DeliveredAt   *time.Time      `json:"deliveredAt,omitempty"`
+}
@@ -0,0 +1,142 @@
+package event
+
+import (
+	"net/http"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// entityName is used in error and success messages
+const entityName = "event"
+
+// Handler handles HTTP requests for the event outbox
+type Handler struct {
+	service event.Usecase
+	mapper  *Mapper
+}
+
+// listFilters are the query parameters events can be filtered by
+var listFilters = []common.QueryFilter{
+	common.Equal("type", common.ParseString),
+	common.Equal("aggregateType", common.ParseString),
+	common.Equal("aggregateId", common.ParseUint),
+	common.Equal("status", common.ParseString),
+	{Param: "from", Field: "occurredAt", Op: domainCommon.OpGreaterOrEqual, Parse: common.ParseTime},
+	{Param: "to", Field: "occurredAt", Op: domainCommon.OpLessOrEqual, Parse: common.ParseTime},
+}
+
+// NewHandler creates a new event handler
+func NewHandler(service event.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// GetAll retrieves a page of events
+// @Summary List domain events
+// @Description Retrieve a page of the domain events recorded in the outbox with their delivery state. Sortable fields: id, type, aggregateType, aggregateId, status, attempts, nextAttemptAt, occurredAt, deliveredAt. Admins only.
+// @Tags events
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param type query string false "Event type" example(reservation.created)
+// @Param aggregateType query string false "Kind of record that changed" Enums(building, resource, lesson, reservation)
+// @Param aggregateId query int false "ID of the record that changed"
+// @Param status query string false "Delivery status" Enums(pending, delivered, dead)
+// @Param from query string false "Events that occurred at or after this time (RFC 3339)"
+// @Param to query string false "Events that occurred at or before this time (RFC 3339)"
+// @Success 200 {object} types.PaginatedResponse[EventDTO] "Page of events"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /events [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	query, params, ok := common.ParseListQuery(c, listFilters...)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetOutboxMessages(query)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve events")
+		return
+	}
+
+	dtos := make([]EventDTO, len(page.Items))
+	for i, message := range page.Items {
+		dtos[i] = *h.mapper.FromDomain(&message)
+	}
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
+}
+
+// GetByID retrieves an event by ID
+// @Summary Get a domain event
+// @Description Get a domain event recorded in the outbox with its delivery state. Admins only.
+// @Tags events
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Event ID" minimum(1)
+// @Success 200 {object} EventDTO "Event details"
+// @Failure 400 {object} common.ErrorResponse "Invalid event ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Event not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /events/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, entityName)
+	if err != nil {
+		return
+	}
+
+	message, err := h.service.GetOutboxMessage(id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+entityName)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(message))
+}
+
+// Retry gives a dead event another round of delivery attempts
+// @Summary Retry a dead event
+// @Description Make an event that ran out of delivery attempts pending again with a fresh round of attempts. Sinks that already accepted it are skipped. Admins only.
+// @Tags events
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Event ID" minimum(1)
+// @Success 200 {object} EventDTO "Event pending again"
+// @Failure 400 {object} common.ErrorResponse "Invalid event ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Event not found"
+// @Failure 409 {object} common.ErrorResponse "Event is not dead"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /events/{id}/retry [post]
+func (h *Handler) Retry(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, entityName)
+	if err != nil {
+		return
+	}
+
+	message, err := h.service.RetryOutboxMessage(id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retry "+entityName)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(message))
+}
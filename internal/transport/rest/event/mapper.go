@@ -0,0 +1,42 @@
+package event
+
+import (
+	"encoding/json"
+	"sarc-ng/internal/domain/event"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new event mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts an outbox message to DTO
+func (m *Mapper) FromDomain(message *event.Message) *EventDTO {
+	if message == nil {
+		return nil
+	}
+	dto := &EventDTO{
+		ID:            message.ID,
+		Type:          string(message.Type),
+		AggregateType: message.AggregateType,
+		AggregateID:   message.AggregateID,
+		Data:          json.RawMessage(message.Payload),
+		Status:        string(message.Status),
+		Attempts:      message.Attempts,
+		DeliveredTo:   message.DeliveredTo,
+		LastError:     message.LastError,
+		OccurredAt:    message.OccurredAt,
+		DeliveredAt:   message.DeliveredAt,
+	}
+	if dto.DeliveredTo == nil {
+		dto.DeliveredTo = []string{}
+	}
+	if message.Status == event.StatusPending {
+		next := message.NextAttemptAt
+		dto.NextAttemptAt = &next
+	}
+	return dto
+}
@@ -0,0 +1,22 @@
+package event
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the event outbox routes, which take the event:manage
+// permission
+func RegisterRoutes(rg *gin.RouterGroup, service event.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+
+	events := rg.Group("/events", middleware.RequirePermission(permissions, auth.PermissionEventManage))
+	{
+		events.GET("", handler.GetAll)
+		events.GET("/:id", handler.GetByID)
+		events.POST("/:id/retry", handler.Retry)
+	}
+}
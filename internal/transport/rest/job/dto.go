@@ -0,0 +1,29 @@
+package job
+
+import (
+	"time"
+)
+
+// JobDTO represents a registered background job
+type JobDTO struct {
+	Name           string     `json:"name" example:"expire-pending-reservations"`
+	Description    string     `json:"description"`
+	Schedule       string     `json:"schedule,omitempty" example:"*/5 * * * *"` // empty for jobs run on demand only
+	TimeoutSeconds int        `json:"timeoutSeconds,omitempty"`
+	NextRun        *time.Time `json:"nextRun,omitempty"`
+	LastRun        *JobRunDTO `json:"lastRun,omitempty"`
+}
+
+// JobRunDTO represents one run of a background job
+type JobRunDTO struct {
+	ID              uint       `json:"id"`
+	Job             string     `json:"job"`
+	Trigger         string     `json:"trigger" example:"schedule"`
+	Holder          string     `json:"holder"`
+	Status          string     `json:"status" example:"succeeded"`
+	Summary         string     `json:"summary,omitempty" example:"3 pending reservations expired"`
+	Error           string     `json:"error,omitempty"`
+	StartedAt       time.Time  `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
+	DurationSeconds float64    `json:"durationSeconds"`
+}
@@ -0,0 +1,122 @@
+package job
+
+import (
+	"net/http"
+	"sarc-ng/internal/domain/job"
+	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for background jobs
+type Handler struct {
+	service job.Usecase
+	mapper  *Mapper
+}
+
+// runFilters are the query parameters job runs can be filtered by
+var runFilters = []common.QueryFilter{
+	common.Equal("job", common.ParseString),
+	common.Equal("trigger", common.ParseString),
+	common.Equal("status", common.ParseString),
+}
+
+// NewHandler creates a new job handler
+func NewHandler(service job.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// GetAll lists the registered jobs
+// @Summary List background jobs
+// @Description List the registered background jobs with their schedule, next scheduled run and last run. Admins only.
+// @Tags jobs
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Success 200 {array} JobDTO "List of jobs"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /jobs [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	infos, err := h.service.GetJobs()
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve jobs")
+		return
+	}
+
+	dtos := make([]JobDTO, len(infos))
+	for i, info := range infos {
+		dtos[i] = *h.mapper.FromDomain(&info)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GetRuns retrieves a page of job runs
+// @Summary List job runs
+// @Description Retrieve a page of the run history of background jobs. Sortable fields: id, job, trigger, status, startedAt. Admins only.
+// @Tags jobs
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param job query string false "Job name"
+// @Param trigger query string false "What started the run" Enums(schedule, manual, event)
+// @Param status query string false "Status" Enums(running, succeeded, failed)
+// @Success 200 {object} types.PaginatedResponse[JobRunDTO] "Page of job runs"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /jobs/runs [get]
+func (h *Handler) GetRuns(c *gin.Context) {
+	query, params, ok := common.ParseListQuery(c, runFilters...)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetJobRuns(query)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve job runs")
+		return
+	}
+
+	dtos := make([]JobRunDTO, len(page.Items))
+	for i, run := range page.Items {
+		dtos[i] = *h.mapper.RunFromDomain(&run)
+	}
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
+}
+
+// Run runs a job now
+// @Summary Run a job now
+// @Description Run a background job immediately and wait for it to finish. A job that fails is reported in the status of the run. Admins only.
+// @Tags jobs
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param name path string true "Job name"
+// @Success 200 {object} JobRunDTO "Finished run"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Job not found"
+// @Failure 409 {object} common.ErrorResponse "Job is already running"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /jobs/{name}/run [post]
+func (h *Handler) Run(c *gin.Context) {
+	run, err := h.service.RunJob(c.Request.Context(), c.Param("name"), job.TriggerManual)
+	if err != nil {
+		common.HandleError(c, err, "Failed to run job")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.RunFromDomain(run))
+}
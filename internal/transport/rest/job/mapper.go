@@ -0,0 +1,47 @@
+package job
+
+import (
+	"sarc-ng/internal/domain/job"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new job mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts a job with its next and last run to DTO
+func (m *Mapper) FromDomain(info *job.Info) *JobDTO {
+	if info == nil {
+		return nil
+	}
+	return &JobDTO{
+		Name:           info.Name,
+		Description:    info.Description,
+		Schedule:       info.Schedule,
+		TimeoutSeconds: int(info.Timeout.Seconds()),
+		NextRun:        info.NextRun,
+		LastRun:        m.RunFromDomain(info.LastRun),
+	}
+}
+
+// RunFromDomain converts a job run to DTO
+func (m *Mapper) RunFromDomain(run *job.Run) *JobRunDTO {
+	if run == nil {
+		return nil
+	}
+	return &JobRunDTO{
+		ID:              run.ID,
+		Job:             run.Job,
+		Trigger:         string(run.Trigger),
+		Holder:          run.Holder,
+		Status:          string(run.Status),
+		Summary:         run.Summary,
+		Error:           run.Error,
+		StartedAt:       run.StartedAt,
+		FinishedAt:      run.FinishedAt,
+		DurationSeconds: run.Duration().Seconds(),
+	}
+}
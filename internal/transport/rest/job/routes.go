@@ -0,0 +1,22 @@
+package job
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/job"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the background job routes, which take the job:manage
+// permission
+func RegisterRoutes(rg *gin.RouterGroup, service job.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+
+	jobs := rg.Group("/jobs", middleware.RequirePermission(permissions, auth.PermissionJobManage))
+	{
+		jobs.GET("", handler.GetAll)
+		jobs.GET("/runs", handler.GetRuns)
+		jobs.POST("/:name/run", handler.Run)
+	}
+}
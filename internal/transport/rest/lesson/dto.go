@@ -9,6 +9,7 @@ type CreateLessonDTO struct {
 	Title     string    `json:"title" validate:"required"`
 	Duration  int       `json:"duration" validate:"min=1"`
 	StartTime time.Time `json:"startTime,omitempty"`
+	ClassID   *uint     `json:"classId,omitempty"`
 }
 
 // UpdateLessonDTO represents the data needed to update a lesson
@@ -16,6 +17,7 @@ type UpdateLessonDTO struct {
 	Title     string    `json:"title" validate:"required"`
 	Duration  int       `json:"duration" validate:"min=1"`
 	StartTime time.Time `json:"startTime,omitempty"`
+	ClassID   *uint     `json:"classId,omitempty"`
 }
 
 // LessonDTO represents lesson data for application operations
@@ -25,6 +27,29 @@ type LessonDTO struct {
 	Duration  int       `json:"duration"`
 	StartTime time.Time `json:"startTime"`
 	EndTime   time.Time `json:"endTime"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ClassID   *uint     `json:"classId,omitempty"`
+	// ExternalID is the calendar event an imported lesson came from
+	ExternalID string    `json:"externalId,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// ImportItemDTO reports what happened to one imported event occurrence
+type ImportItemDTO struct {
+	ExternalID string    `json:"externalId"`
+	Title      string    `json:"title"`
+	StartTime  time.Time `json:"startTime"`
+	Action     string    `json:"action" example:"create" enums:"create,update,skip,error"`
+	LessonID   uint      `json:"lessonId,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+}
+
+// ImportReportDTO summarises a lesson import
+type ImportReportDTO struct {
+	DryRun  bool            `json:"dryRun"`
+	Created int             `json:"created"`
+	Updated int             `json:"updated"`
+	Skipped int             `json:"skipped"`
+	Failed  int             `json:"failed"`
+	Items   []ImportItemDTO `json:"items"`
 }
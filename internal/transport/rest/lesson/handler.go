@@ -1,9 +1,13 @@
 package lesson
 
 import (
+	"errors"
 	"net/http"
+	domainCommon "sarc-ng/internal/domain/common"
 	"sarc-ng/internal/domain/lesson"
 	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +19,16 @@ type Handler struct {
 	mapper  *Mapper
 }
 
+// maxImportBytes limits the size of an uploaded iCalendar file
+const maxImportBytes = 5 << 20
+
+// listFilters are the query parameters lessons can be filtered by
+var listFilters = []common.QueryFilter{
+	common.Equal("classId", common.ParseUint),
+	{Param: "from", Field: "endTime", Op: domainCommon.OpGreaterOrEqual, Parse: common.ParseTime},
+	{Param: "to", Field: "startTime", Op: domainCommon.OpLessOrEqual, Parse: common.ParseTime},
+}
+
 // NewHandler creates a new lesson handler
 func NewHandler(service lesson.Usecase) *Handler {
 	mapper := NewMapper()
@@ -27,27 +41,41 @@ func NewHandler(service lesson.Usecase) *Handler {
 	}
 }
 
-// GetAll retrieves all lessons
-// @Summary Get all lessons
-// @Description Retrieve a list of all lessons in the system
+// GetAll retrieves a page of lessons
+// @Summary List lessons
+// @Description Retrieve a page of lessons, optionally filtered. Sortable fields: id, title, duration, startTime, endTime, classId, createdAt, updatedAt.
 // @Tags lessons
 // @Accept json
 // @Produce json
-// @Success 200 {array} LessonDTO "List of lessons"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param classId query int false "Class ID"
+// @Param from query string false "Only lessons ending at or after this RFC 3339 time"
+// @Param to query string false "Only lessons starting at or before this RFC 3339 time"
+// @Success 200 {object} types.PaginatedResponse[LessonDTO] "Page of lessons"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /lessons [get]
 func (h *Handler) GetAll(c *gin.Context) {
-	entities, err := h.service.GetAllLessons()
+	query, params, ok := common.ParseListQuery(c, listFilters...)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetAllLessons(query)
 	if err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve "+h.GetEntityName()+"s", err.Error())
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName()+"s")
 		return
 	}
 
-	dtos := make([]LessonDTO, len(entities))
-	for i, entity := range entities {
+	dtos := make([]LessonDTO, len(page.Items))
+	for i, entity := range page.Items {
 		dtos[i] = *h.mapper.FromDomain(&entity)
 	}
-	c.JSON(http.StatusOK, dtos)
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
 }
 
 // GetByID retrieves a lesson by ID
@@ -88,9 +116,13 @@ func (h *Handler) GetByID(c *gin.Context) {
 // @Tags lessons
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param lesson body CreateLessonDTO true "Lesson creation data"
 // @Success 201 {object} LessonDTO "Created lesson"
 // @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /lessons [post]
 func (h *Handler) Create(c *gin.Context) {
@@ -100,8 +132,8 @@ func (h *Handler) Create(c *gin.Context) {
 	}
 
 	entity := h.mapper.ToDomain(createDTO)
-	if err := h.service.CreateLesson(entity); err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to create "+h.GetEntityName(), err.Error())
+	if err := h.service.CreateLesson(common.Actor(c), entity); err != nil {
+		common.HandleError(c, err, "Failed to create "+h.GetEntityName())
 		return
 	}
 
@@ -115,10 +147,14 @@ func (h *Handler) Create(c *gin.Context) {
 // @Tags lessons
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param id path int true "Lesson ID" minimum(1)
 // @Param lesson body UpdateLessonDTO true "Lesson update data"
 // @Success 200 {object} LessonDTO "Updated lesson"
 // @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 404 {object} common.ErrorResponse "Lesson not found"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /lessons/{id} [put]
@@ -129,8 +165,8 @@ func (h *Handler) Update(c *gin.Context) {
 	}
 
 	entity := h.mapper.ToDomainWithID(updateDTO, id)
-	if err := h.service.UpdateLesson(entity); err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to update "+h.GetEntityName(), err.Error())
+	if err := h.service.UpdateLesson(common.Actor(c), entity); err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName())
 		return
 	}
 
@@ -144,9 +180,13 @@ func (h *Handler) Update(c *gin.Context) {
 // @Tags lessons
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param id path int true "Lesson ID" minimum(1)
 // @Success 200 {object} common.SuccessResponse "Lesson deleted successfully"
 // @Failure 400 {object} common.ErrorResponse "Invalid lesson ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 404 {object} common.ErrorResponse "Lesson not found"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /lessons/{id} [delete]
@@ -156,10 +196,54 @@ func (h *Handler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteLesson(id); err != nil {
+	if err := h.service.DeleteLesson(common.Actor(c), id); err != nil {
 		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
 		return
 	}
 
 	common.RespondWithSuccess(c, http.StatusOK, h.GetEntityName()+" deleted successfully")
 }
+
+// Import creates or updates lessons from an iCalendar file
+// @Summary Import lessons from iCalendar
+// @Description Create or update lessons from the VEVENTs of an .ics file. Recurring events are expanded into one lesson per occurrence; re-importing the same file updates lessons instead of duplicating them. Per-event problems are reported in the items.
+// @Tags lessons
+// @Accept text/calendar
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param calendar body string true "iCalendar data"
+// @Param dryRun query bool false "Report what would change without saving"
+// @Param timeZone query string false "IANA time zone for floating times" example(America/Sao_Paulo)
+// @Success 200 {object} ImportReportDTO "Import report"
+// @Failure 400 {object} common.ErrorResponse "Invalid iCalendar data or parameters"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 413 {object} common.ErrorResponse "File too large"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /lessons/import [post]
+func (h *Handler) Import(c *gin.Context) {
+	options := lesson.ImportOptions{TimeZone: c.Query("timeZone")}
+	if value := c.Query("dryRun"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			common.RespondWithError(c, http.StatusBadRequest, "Invalid dryRun parameter", "dryRun must be true or false")
+			return
+		}
+		options.DryRun = dryRun
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes)
+	report, err := h.service.ImportLessons(common.Actor(c), body, options)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			common.RespondWithError(c, http.StatusRequestEntityTooLarge, "File too large", "Calendar files are limited to 5 MB")
+			return
+		}
+		common.HandleError(c, err, "Failed to import "+h.GetEntityName()+"s")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.ImportReportFromDomain(report))
+}
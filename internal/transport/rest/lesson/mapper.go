@@ -18,13 +18,15 @@ func (m *Mapper) FromDomain(entity *lesson.Lesson) *LessonDTO {
 		return nil
 	}
 	return &LessonDTO{
-		ID:        entity.ID,
-		Title:     entity.Title,
-		Duration:  entity.Duration,
-		StartTime: entity.StartTime,
-		EndTime:   entity.EndTime,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
+		ID:         entity.ID,
+		Title:      entity.Title,
+		Duration:   entity.Duration,
+		StartTime:  entity.StartTime,
+		EndTime:    entity.EndTime,
+		ClassID:    entity.ClassID,
+		ExternalID: entity.ExternalID,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
 	}
 }
 
@@ -37,6 +39,7 @@ func (m *Mapper) ToDomain(dto *CreateLessonDTO) *lesson.Lesson {
 		Title:     dto.Title,
 		Duration:  dto.Duration,
 		StartTime: dto.StartTime,
+		ClassID:   dto.ClassID,
 	}
 }
 
@@ -50,5 +53,32 @@ func (m *Mapper) ToDomainWithID(dto *UpdateLessonDTO, id uint) *lesson.Lesson {
 		Title:     dto.Title,
 		Duration:  dto.Duration,
 		StartTime: dto.StartTime,
+		ClassID:   dto.ClassID,
+	}
+}
+
+// ImportReportFromDomain converts an import report to DTO
+func (m *Mapper) ImportReportFromDomain(report *lesson.ImportReport) *ImportReportDTO {
+	if report == nil {
+		return nil
+	}
+	items := make([]ImportItemDTO, len(report.Items))
+	for i, item := range report.Items {
+		items[i] = ImportItemDTO{
+			ExternalID: item.ExternalID,
+			Title:      item.Title,
+			StartTime:  item.StartTime,
+			Action:     string(item.Action),
+			LessonID:   item.LessonID,
+			Reason:     item.Reason,
+		}
+	}
+	return &ImportReportDTO{
+		DryRun:  report.DryRun,
+		Created: report.Created,
+		Updated: report.Updated,
+		Skipped: report.Skipped,
+		Failed:  report.Failed,
+		Items:   items,
 	}
 }
@@ -1,21 +1,30 @@
 package lesson
 
 import (
+	"sarc-ng/internal/domain/auth"
 	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/pkg/rest/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes sets up the lesson routes
-func RegisterRoutes(rg *gin.RouterGroup, service lesson.Usecase) {
+// RegisterRoutes sets up the lesson routes. Anyone may read the lessons;
+// changing or importing them takes the lesson:write permission, so the writes
+// are registered on the authenticated group.
+func RegisterRoutes(public, protected *gin.RouterGroup, service lesson.Usecase, permissions auth.Permissions) {
 	handler := NewHandler(service)
 
-	lessons := rg.Group("/lessons")
+	lessons := public.Group("/lessons")
 	{
 		lessons.GET("", handler.GetAll)
-		lessons.POST("", handler.Create)
 		lessons.GET("/:id", handler.GetByID)
-		lessons.PUT("/:id", handler.Update)
-		lessons.DELETE("/:id", handler.Delete)
+	}
+
+	writes := protected.Group("/lessons", middleware.RequirePermission(permissions, auth.PermissionLessonWrite))
+	{
+		writes.POST("", handler.Create)
+		writes.POST("/import", handler.Import)
+		writes.PUT("/:id", handler.Update)
+		writes.DELETE("/:id", handler.Delete)
 	}
 }
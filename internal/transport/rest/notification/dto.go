@@ -0,0 +1,20 @@
+package notification
+
+import "time"
+
+// PreferencesDTO represents the notification preferences of the authenticated user
+type PreferencesDTO struct {
+	Email     string     `json:"email" example:"ana@example.com"` // taken from the access token; empty when the token has none
+	Locale    string     `json:"locale" example:"pt-BR"`          // empty for the default locale
+	Disabled  bool       `json:"disabled" example:"false"`        // opts out of every notification
+	OptOut    []string   `json:"optOut" example:"reservation.starting"`
+	Available []string   `json:"available" example:"reservation.approved,reservation.rejected,reservation.cancelled,reservation.starting,waitlist.promoted"` // notifications that can be opted out of
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// UpdatePreferencesDTO represents the data needed to change notification preferences
+type UpdatePreferencesDTO struct {
+	Locale   string   `json:"locale,omitempty" example:"pt-BR"`
+	Disabled bool     `json:"disabled" example:"false"`
+	OptOut   []string `json:"optOut,omitempty" example:"reservation.starting"`
+}
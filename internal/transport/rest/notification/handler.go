@@ -0,0 +1,99 @@
+package notification
+
+import (
+	"log"
+	"net/http"
+	"sarc-ng/internal/domain/notification"
+	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for notification preferences
+type Handler struct {
+	service notification.Usecase
+	mapper  *Mapper
+}
+
+// NewHandler creates a new notification handler
+func NewHandler(service notification.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// RememberRecipient records the email address of authenticated users, so
+// they can be notified of changes others make to their reservations. A
+// failure is logged and does not fail the request.
+func RememberRecipient(service notification.Usecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user, ok := middleware.GetUserFromContext(c); ok {
+			if err := service.RememberRecipient(user); err != nil {
+				log.Printf("Failed to record email address of user %s: %v", user.ID, err)
+			}
+		}
+		c.Next()
+	}
+}
+
+// GetMine retrieves the notification preferences of the authenticated user
+// @Summary Get my notification preferences
+// @Description Retrieve the language and opt-outs of the emails the authenticated user gets when their reservations are approved, rejected, cancelled or about to start. Emails go to the address in the access token.
+// @Tags notifications
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Success 200 {object} PreferencesDTO "Notification preferences"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /me/notifications [get]
+func (h *Handler) GetMine(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.service.GetPreferences(user)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(preferences))
+}
+
+// UpdateMine replaces the notification preferences of the authenticated user
+// @Summary Update my notification preferences
+// @Description Set the language of the emails the authenticated user gets and opt out of all of them or of single kinds
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param preferences body UpdatePreferencesDTO true "Notification preferences"
+// @Success 200 {object} PreferencesDTO "Updated notification preferences"
+// @Failure 400 {object} common.ErrorResponse "Unknown notification or unsupported locale"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /me/notifications [put]
+func (h *Handler) UpdateMine(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var dto UpdatePreferencesDTO
+	if err := common.BindAndValidateJSON(c, &dto); err != nil {
+		return
+	}
+
+	preferences := h.mapper.ToDomain(&dto)
+	if err := h.service.UpdatePreferences(user, preferences); err != nil {
+		common.HandleError(c, err, "Failed to update notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(preferences))
+}
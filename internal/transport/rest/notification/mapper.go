@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"sarc-ng/internal/domain/notification"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new notification mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts notification preferences to DTO
+func (m *Mapper) FromDomain(entity *notification.Preferences) *PreferencesDTO {
+	if entity == nil {
+		return nil
+	}
+	dto := &PreferencesDTO{
+		Email:     entity.Email,
+		Locale:    entity.Locale,
+		Disabled:  entity.Disabled,
+		OptOut:    make([]string, len(entity.OptOut)),
+		Available: make([]string, len(notification.Kinds)),
+	}
+	for i, kind := range entity.OptOut {
+		dto.OptOut[i] = string(kind)
+	}
+	for i, kind := range notification.Kinds {
+		dto.Available[i] = string(kind)
+	}
+	if !entity.UpdatedAt.IsZero() {
+		updatedAt := entity.UpdatedAt
+		dto.UpdatedAt = &updatedAt
+	}
+	return dto
+}
+
+// ToDomain converts an update DTO to domain entity
+func (m *Mapper) ToDomain(dto *UpdatePreferencesDTO) *notification.Preferences {
+	if dto == nil {
+		return nil
+	}
+	optOut := make([]notification.Kind, len(dto.OptOut))
+	for i, kind := range dto.OptOut {
+		optOut[i] = notification.Kind(kind)
+	}
+	return &notification.Preferences{
+		Locale:   dto.Locale,
+		Disabled: dto.Disabled,
+		OptOut:   optOut,
+	}
+}
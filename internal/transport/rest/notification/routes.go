@@ -0,0 +1,15 @@
+package notification
+
+import (
+	"sarc-ng/internal/domain/notification"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the routes of the authenticated user's notification preferences
+func RegisterRoutes(rg *gin.RouterGroup, service notification.Usecase) {
+	handler := NewHandler(service)
+
+	rg.GET("/me/notifications", handler.GetMine)
+	rg.PUT("/me/notifications", handler.UpdateMine)
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"time"
+)
+
+// HoursDTO represents the times of day and weekdays in which bookings may take place
+type HoursDTO struct {
+	TimeZone string   `json:"timeZone,omitempty" example:"America/Sao_Paulo"`
+	From     string   `json:"from,omitempty" example:"07:00"`
+	Until    string   `json:"until,omitempty" example:"22:00"`
+	Days     []string `json:"days,omitempty" example:"mon,tue,wed,thu,fri"`
+}
+
+// CreatePolicyDTO represents the data needed to create a booking policy.
+// Zero values leave a rule unset.
+type CreatePolicyDTO struct {
+	ResourceType        string    `json:"resourceType" validate:"required" example:"projector"`
+	MaxDurationMinutes  int       `json:"maxDurationMinutes" validate:"min=0" example:"240"`
+	MinLeadTimeMinutes  int       `json:"minLeadTimeMinutes" validate:"min=0" example:"60"`
+	MaxAdvanceMinutes   int       `json:"maxAdvanceMinutes" validate:"min=0" example:"43200"`
+	BufferMinutes       int       `json:"bufferMinutes" validate:"min=0" example:"15"`
+	AllowedHours        *HoursDTO `json:"allowedHours,omitempty"`
+	AllowedGroups       []string  `json:"allowedGroups,omitempty" example:"teacher,manager"`
+	NoShowLimit         int       `json:"noShowLimit" validate:"min=0" example:"3"`
+	NoShowPeriodMinutes int       `json:"noShowPeriodMinutes" validate:"min=0" example:"43200"`
+}
+
+// UpdatePolicyDTO represents the data needed to update a booking policy.
+// Zero values leave a rule unset.
+type UpdatePolicyDTO struct {
+	ResourceType        string    `json:"resourceType" validate:"required" example:"projector"`
+	MaxDurationMinutes  int       `json:"maxDurationMinutes" validate:"min=0" example:"240"`
+	MinLeadTimeMinutes  int       `json:"minLeadTimeMinutes" validate:"min=0" example:"60"`
+	MaxAdvanceMinutes   int       `json:"maxAdvanceMinutes" validate:"min=0" example:"43200"`
+	BufferMinutes       int       `json:"bufferMinutes" validate:"min=0" example:"15"`
+	AllowedHours        *HoursDTO `json:"allowedHours,omitempty"`
+	AllowedGroups       []string  `json:"allowedGroups,omitempty" example:"teacher,manager"`
+	NoShowLimit         int       `json:"noShowLimit" validate:"min=0" example:"3"`
+	NoShowPeriodMinutes int       `json:"noShowPeriodMinutes" validate:"min=0" example:"43200"`
+}
+
+// PolicyDTO represents booking policy data for application operations
+type PolicyDTO struct {
+	ID                  uint      `json:"id"`
+	ResourceType        string    `json:"resourceType"`
+	MaxDurationMinutes  int       `json:"maxDurationMinutes"`
+	MinLeadTimeMinutes  int       `json:"minLeadTimeMinutes"`
+	MaxAdvanceMinutes   int       `json:"maxAdvanceMinutes"`
+	BufferMinutes       int       `json:"bufferMinutes"`
+	AllowedHours        *HoursDTO `json:"allowedHours,omitempty"`
+	AllowedGroups       []string  `json:"allowedGroups,omitempty"`
+	NoShowLimit         int       `json:"noShowLimit"`
+	NoShowPeriodMinutes int       `json:"noShowPeriodMinutes"`
+	CreatedAt           time.Time `json:"createdAt"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+}
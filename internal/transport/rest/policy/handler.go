@@ -0,0 +1,178 @@
+package policy
+
+import (
+	"net/http"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/transport/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for booking policy operations
+type Handler struct {
+	*common.BaseHandler[policy.Policy, CreatePolicyDTO, UpdatePolicyDTO, PolicyDTO]
+	service policy.Usecase
+	mapper  *Mapper
+}
+
+// NewHandler creates a new booking policy handler
+func NewHandler(service policy.Usecase) *Handler {
+	mapper := NewMapper()
+	baseHandler := common.NewBaseHandler[policy.Policy, CreatePolicyDTO, UpdatePolicyDTO, PolicyDTO](
+		"policy")
+	return &Handler{
+		BaseHandler: baseHandler,
+		service:     service,
+		mapper:      mapper,
+	}
+}
+
+// GetAll retrieves every booking policy
+// @Summary List booking policies
+// @Description Retrieve the booking policy of every resource type that has one
+// @Tags booking-policies
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Success 200 {array} PolicyDTO "List of booking policies"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /booking-policies [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	entities, err := h.service.GetAllPolicies()
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve policies")
+		return
+	}
+
+	dtos := make([]PolicyDTO, len(entities))
+	for i, entity := range entities {
+		dtos[i] = *h.mapper.FromDomain(&entity)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GetByID retrieves a booking policy by ID
+// @Summary Get booking policy by ID
+// @Description Retrieve a specific booking policy by its unique identifier
+// @Tags booking-policies
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Policy ID" minimum(1)
+// @Success 200 {object} PolicyDTO "Booking policy details"
+// @Failure 400 {object} common.ErrorResponse "Invalid policy ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 404 {object} common.ErrorResponse "Policy not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /booking-policies/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	entity, err := h.service.GetPolicy(id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Create creates a booking policy
+// @Summary Create a booking policy
+// @Description Create the booking policy of a resource type. Durations are in minutes and zero leaves a rule unset. Admins only.
+// @Tags booking-policies
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param policy body CreatePolicyDTO true "Booking policy data"
+// @Success 201 {object} PolicyDTO "Created booking policy"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 409 {object} common.ErrorResponse "Resource type already has a policy"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /booking-policies [post]
+func (h *Handler) Create(c *gin.Context) {
+	createDTO, err := h.BindCreateJSON(c)
+	if err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomain(createDTO)
+	if err := h.service.CreatePolicy(entity); err != nil {
+		common.HandleError(c, err, "Failed to create "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapper.FromDomain(entity))
+}
+
+// Update replaces a booking policy
+// @Summary Update a booking policy
+// @Description Replace the rules of a booking policy by ID. Durations are in minutes and zero leaves a rule unset. Admins only.
+// @Tags booking-policies
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Policy ID" minimum(1)
+// @Param policy body UpdatePolicyDTO true "Booking policy data"
+// @Success 200 {object} PolicyDTO "Updated booking policy"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Policy not found"
+// @Failure 409 {object} common.ErrorResponse "Resource type already has a policy"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /booking-policies/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	id, updateDTO, err := h.ParseIDAndBindJSON(c)
+	if err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomainWithID(updateDTO, id)
+	if err := h.service.UpdatePolicy(entity); err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Delete removes a booking policy
+// @Summary Delete a booking policy
+// @Description Delete a booking policy by ID, lifting the rules of its resource type. Admins only.
+// @Tags booking-policies
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Policy ID" minimum(1)
+// @Success 200 {object} common.SuccessResponse "Policy deleted successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid policy ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Policy not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /booking-policies/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	if err := h.service.DeletePolicy(id); err != nil {
+		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
+		return
+	}
+
+	common.RespondWithSuccess(c, http.StatusOK, h.GetEntityName()+" deleted successfully")
+}
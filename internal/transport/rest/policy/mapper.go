@@ -0,0 +1,103 @@
+package policy
+
+import (
+	"sarc-ng/internal/domain/policy"
+	"time"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new booking policy mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts a domain entity to DTO
+func (m *Mapper) FromDomain(entity *policy.Policy) *PolicyDTO {
+	if entity == nil {
+		return nil
+	}
+	dto := &PolicyDTO{
+		ID:                  entity.ID,
+		ResourceType:        entity.ResourceType,
+		MaxDurationMinutes:  minutes(entity.MaxDuration),
+		MinLeadTimeMinutes:  minutes(entity.MinLeadTime),
+		MaxAdvanceMinutes:   minutes(entity.MaxAdvance),
+		BufferMinutes:       minutes(entity.Buffer),
+		AllowedGroups:       entity.AllowedGroups,
+		NoShowLimit:         entity.NoShowLimit,
+		NoShowPeriodMinutes: minutes(entity.NoShowPeriod),
+		CreatedAt:           entity.CreatedAt,
+		UpdatedAt:           entity.UpdatedAt,
+	}
+	if entity.Hours.IsSet() {
+		dto.AllowedHours = &HoursDTO{
+			TimeZone: entity.Hours.TimeZone,
+			From:     entity.Hours.From,
+			Until:    entity.Hours.Until,
+			Days:     entity.Hours.Days,
+		}
+	}
+	return dto
+}
+
+// ToDomain converts a create DTO to domain entity
+func (m *Mapper) ToDomain(dto *CreatePolicyDTO) *policy.Policy {
+	if dto == nil {
+		return nil
+	}
+	return &policy.Policy{
+		ResourceType:  dto.ResourceType,
+		MaxDuration:   duration(dto.MaxDurationMinutes),
+		MinLeadTime:   duration(dto.MinLeadTimeMinutes),
+		MaxAdvance:    duration(dto.MaxAdvanceMinutes),
+		Buffer:        duration(dto.BufferMinutes),
+		Hours:         hoursToDomain(dto.AllowedHours),
+		AllowedGroups: dto.AllowedGroups,
+		NoShowLimit:   dto.NoShowLimit,
+		NoShowPeriod:  duration(dto.NoShowPeriodMinutes),
+	}
+}
+
+// ToDomainWithID converts an update DTO to domain entity with ID
+func (m *Mapper) ToDomainWithID(dto *UpdatePolicyDTO, id uint) *policy.Policy {
+	if dto == nil {
+		return nil
+	}
+	return &policy.Policy{
+		ID:            id,
+		ResourceType:  dto.ResourceType,
+		MaxDuration:   duration(dto.MaxDurationMinutes),
+		MinLeadTime:   duration(dto.MinLeadTimeMinutes),
+		MaxAdvance:    duration(dto.MaxAdvanceMinutes),
+		Buffer:        duration(dto.BufferMinutes),
+		Hours:         hoursToDomain(dto.AllowedHours),
+		AllowedGroups: dto.AllowedGroups,
+		NoShowLimit:   dto.NoShowLimit,
+		NoShowPeriod:  duration(dto.NoShowPeriodMinutes),
+	}
+}
+
+// hoursToDomain converts optional allowed hours
+func hoursToDomain(dto *HoursDTO) policy.Hours {
+	if dto == nil {
+		return policy.Hours{}
+	}
+	return policy.Hours{
+		TimeZone: dto.TimeZone,
+		From:     dto.From,
+		Until:    dto.Until,
+		Days:     dto.Days,
+	}
+}
+
+// minutes converts a duration to whole minutes
+func minutes(d time.Duration) int {
+	return int(d / time.Minute)
+}
+
+// duration converts minutes to a duration
+func duration(minutes int) time.Duration {
+	return time.Duration(minutes) * time.Minute
+}
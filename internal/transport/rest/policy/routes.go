@@ -0,0 +1,25 @@
+package policy
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the booking policy routes. Any authenticated user may
+// read the policies; changing them takes the policy:write permission.
+func RegisterRoutes(rg *gin.RouterGroup, service policy.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+	requireWrite := middleware.RequirePermission(permissions, auth.PermissionPolicyWrite)
+
+	policies := rg.Group("/booking-policies")
+	{
+		policies.GET("", handler.GetAll)
+		policies.GET("/:id", handler.GetByID)
+		policies.POST("", requireWrite, handler.Create)
+		policies.PUT("/:id", requireWrite, handler.Update)
+		policies.DELETE("/:id", requireWrite, handler.Delete)
+	}
+}
@@ -0,0 +1,56 @@
+package quota
+
+import (
+	"time"
+)
+
+// AllowanceDTO reports how much of a quota the user has used. Remaining values
+// are null when the quota is unlimited.
+type AllowanceDTO struct {
+	ResourceType           string    `json:"resourceType" example:"laboratory"` // empty for the quota over every resource type
+	MaxActive              int       `json:"maxActive" example:"3"`
+	Active                 int       `json:"active" example:"1"`
+	RemainingActive        *int      `json:"remainingActive" example:"2"`
+	MaxWeeklyMinutes       int       `json:"maxWeeklyMinutes" example:"600"`
+	WeeklyMinutes          int       `json:"weeklyMinutes" example:"120"`
+	RemainingWeeklyMinutes *int      `json:"remainingWeeklyMinutes" example:"480"`
+	Waived                 bool      `json:"waived"`
+	WeekStart              time.Time `json:"weekStart"`
+	WeekEnd                time.Time `json:"weekEnd"`
+}
+
+// CreateQuotaOverrideDTO represents the data needed to override a user's quota.
+// Zero limits mean unlimited.
+type CreateQuotaOverrideDTO struct {
+	UserID           string `json:"userId" validate:"required" example:"7d3f6a2e-1b2c-4d5e-8f90-a1b2c3d4e5f6"`
+	ResourceType     string `json:"resourceType,omitempty" example:"laboratory"`
+	MaxActive        int    `json:"maxActive" validate:"min=0" example:"5"`
+	MaxWeeklyMinutes int    `json:"maxWeeklyMinutes" validate:"min=0" example:"1200"`
+	Waived           bool   `json:"waived" example:"false"`
+	Reason           string `json:"reason,omitempty" example:"Thesis experiments"`
+}
+
+// UpdateQuotaOverrideDTO represents the data needed to update a quota override.
+// Zero limits mean unlimited.
+type UpdateQuotaOverrideDTO struct {
+	UserID           string `json:"userId" validate:"required" example:"7d3f6a2e-1b2c-4d5e-8f90-a1b2c3d4e5f6"`
+	ResourceType     string `json:"resourceType,omitempty" example:"laboratory"`
+	MaxActive        int    `json:"maxActive" validate:"min=0" example:"5"`
+	MaxWeeklyMinutes int    `json:"maxWeeklyMinutes" validate:"min=0" example:"1200"`
+	Waived           bool   `json:"waived" example:"false"`
+	Reason           string `json:"reason,omitempty" example:"Thesis experiments"`
+}
+
+// QuotaOverrideDTO represents quota override data for application operations
+type QuotaOverrideDTO struct {
+	ID               uint      `json:"id"`
+	UserID           string    `json:"userId"`
+	ResourceType     string    `json:"resourceType"`
+	MaxActive        int       `json:"maxActive"`
+	MaxWeeklyMinutes int       `json:"maxWeeklyMinutes"`
+	Waived           bool      `json:"waived"`
+	Reason           string    `json:"reason,omitempty"`
+	GrantedBy        string    `json:"grantedBy"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
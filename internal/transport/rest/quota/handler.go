@@ -0,0 +1,222 @@
+package quota
+
+import (
+	"net/http"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/internal/transport/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for quota operations
+type Handler struct {
+	*common.BaseHandler[quota.Override, CreateQuotaOverrideDTO, UpdateQuotaOverrideDTO, QuotaOverrideDTO]
+	service quota.Usecase
+	mapper  *Mapper
+}
+
+// NewHandler creates a new quota handler
+func NewHandler(service quota.Usecase) *Handler {
+	mapper := NewMapper()
+	baseHandler := common.NewBaseHandler[quota.Override, CreateQuotaOverrideDTO, UpdateQuotaOverrideDTO, QuotaOverrideDTO](
+		"quota override")
+	return &Handler{
+		BaseHandler: baseHandler,
+		service:     service,
+		mapper:      mapper,
+	}
+}
+
+// GetMine reports the remaining allowance of the authenticated user
+// @Summary Get my quota
+// @Description Report how many more active reservations and how much more booked time this week the authenticated user is allowed, per quota that applies to them
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Success 200 {array} AllowanceDTO "Remaining allowance per quota"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /me/quota [get]
+func (h *Handler) GetMine(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	allowances, err := h.service.GetQuota(user)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve quota")
+		return
+	}
+
+	dtos := make([]AllowanceDTO, len(allowances))
+	for i, allowance := range allowances {
+		dtos[i] = h.mapper.AllowanceFromDomain(allowance)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GetAll retrieves quota overrides
+// @Summary List quota overrides
+// @Description Retrieve the quota overrides of a user, or of every user when no user is given. Managers only.
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param userId query string false "User ID"
+// @Success 200 {array} QuotaOverrideDTO "List of quota overrides"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /quota-overrides [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	entities, err := h.service.GetAllQuotaOverrides(c.Query("userId"))
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve quota overrides")
+		return
+	}
+
+	dtos := make([]QuotaOverrideDTO, len(entities))
+	for i, entity := range entities {
+		dtos[i] = *h.mapper.FromDomain(&entity)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GetByID retrieves a quota override by ID
+// @Summary Get quota override by ID
+// @Description Retrieve a specific quota override by its unique identifier. Managers only.
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Quota override ID" minimum(1)
+// @Success 200 {object} QuotaOverrideDTO "Quota override details"
+// @Failure 400 {object} common.ErrorResponse "Invalid quota override ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Quota override not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /quota-overrides/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	entity, err := h.service.GetQuotaOverride(id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Create overrides a user's quota
+// @Summary Create a quota override
+// @Description Raise, lower or waive the quota of a user for a resource type, or for every type when none is given. Zero limits mean unlimited. Managers only.
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param override body CreateQuotaOverrideDTO true "Quota override data"
+// @Success 201 {object} QuotaOverrideDTO "Created quota override"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 409 {object} common.ErrorResponse "User already has an override for this quota"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /quota-overrides [post]
+func (h *Handler) Create(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	createDTO, err := h.BindCreateJSON(c)
+	if err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomain(createDTO)
+	if err := h.service.CreateQuotaOverride(user, entity); err != nil {
+		common.HandleError(c, err, "Failed to create "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapper.FromDomain(entity))
+}
+
+// Update replaces a quota override
+// @Summary Update a quota override
+// @Description Replace the limits of a quota override by ID. Zero limits mean unlimited. Managers only.
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Quota override ID" minimum(1)
+// @Param override body UpdateQuotaOverrideDTO true "Quota override data"
+// @Success 200 {object} QuotaOverrideDTO "Updated quota override"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Quota override not found"
+// @Failure 409 {object} common.ErrorResponse "User already has an override for this quota"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /quota-overrides/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	id, updateDTO, err := h.ParseIDAndBindJSON(c)
+	if err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomainWithID(updateDTO, id)
+	if err := h.service.UpdateQuotaOverride(user, entity); err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Delete removes a quota override
+// @Summary Delete a quota override
+// @Description Delete a quota override by ID, holding the user to the configured quota again. Managers only.
+// @Tags quotas
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Quota override ID" minimum(1)
+// @Success 200 {object} common.SuccessResponse "Quota override deleted successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid quota override ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Quota override not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /quota-overrides/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	if err := h.service.DeleteQuotaOverride(id); err != nil {
+		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
+		return
+	}
+
+	common.RespondWithSuccess(c, http.StatusOK, h.GetEntityName()+" deleted successfully")
+}
@@ -0,0 +1,96 @@
+package quota
+
+import (
+	"sarc-ng/internal/domain/quota"
+	"time"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new quota mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts a domain entity to DTO
+func (m *Mapper) FromDomain(entity *quota.Override) *QuotaOverrideDTO {
+	if entity == nil {
+		return nil
+	}
+	return &QuotaOverrideDTO{
+		ID:               entity.ID,
+		UserID:           entity.UserID,
+		ResourceType:     entity.ResourceType,
+		MaxActive:        entity.MaxActive,
+		MaxWeeklyMinutes: minutes(entity.MaxWeekly),
+		Waived:           entity.Waived,
+		Reason:           entity.Reason,
+		GrantedBy:        entity.GrantedBy,
+		CreatedAt:        entity.CreatedAt,
+		UpdatedAt:        entity.UpdatedAt,
+	}
+}
+
+// ToDomain converts a create DTO to domain entity
+func (m *Mapper) ToDomain(dto *CreateQuotaOverrideDTO) *quota.Override {
+	if dto == nil {
+		return nil
+	}
+	return &quota.Override{
+		UserID:       dto.UserID,
+		ResourceType: dto.ResourceType,
+		MaxActive:    dto.MaxActive,
+		MaxWeekly:    duration(dto.MaxWeeklyMinutes),
+		Waived:       dto.Waived,
+		Reason:       dto.Reason,
+	}
+}
+
+// ToDomainWithID converts an update DTO to domain entity with ID
+func (m *Mapper) ToDomainWithID(dto *UpdateQuotaOverrideDTO, id uint) *quota.Override {
+	if dto == nil {
+		return nil
+	}
+	return &quota.Override{
+		ID:           id,
+		UserID:       dto.UserID,
+		ResourceType: dto.ResourceType,
+		MaxActive:    dto.MaxActive,
+		MaxWeekly:    duration(dto.MaxWeeklyMinutes),
+		Waived:       dto.Waived,
+		Reason:       dto.Reason,
+	}
+}
+
+// AllowanceFromDomain converts an allowance to DTO
+func (m *Mapper) AllowanceFromDomain(allowance quota.Allowance) AllowanceDTO {
+	dto := AllowanceDTO{
+		ResourceType:     allowance.ResourceType,
+		MaxActive:        allowance.MaxActive,
+		Active:           allowance.Active,
+		MaxWeeklyMinutes: minutes(allowance.MaxWeekly),
+		WeeklyMinutes:    minutes(allowance.Weekly),
+		Waived:           allowance.Waived,
+		WeekStart:        allowance.WeekStart,
+		WeekEnd:          allowance.WeekEnd,
+	}
+	if remaining := allowance.RemainingActive(); remaining >= 0 {
+		dto.RemainingActive = &remaining
+	}
+	if remaining := allowance.RemainingWeekly(); remaining >= 0 {
+		remainingMinutes := minutes(remaining)
+		dto.RemainingWeeklyMinutes = &remainingMinutes
+	}
+	return dto
+}
+
+// minutes converts a duration to whole minutes
+func minutes(d time.Duration) int {
+	return int(d / time.Minute)
+}
+
+// duration converts minutes to a duration
+func duration(minutes int) time.Duration {
+	return time.Duration(minutes) * time.Minute
+}
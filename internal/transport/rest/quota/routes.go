@@ -0,0 +1,27 @@
+package quota
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/quota"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the quota routes. Any authenticated user may read
+// their own quota; overriding the quotas of others takes the quota:manage
+// permission.
+func RegisterRoutes(rg *gin.RouterGroup, service quota.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+
+	rg.GET("/me/quota", handler.GetMine)
+
+	overrides := rg.Group("/quota-overrides", middleware.RequirePermission(permissions, auth.PermissionQuotaManage))
+	{
+		overrides.GET("", handler.GetAll)
+		overrides.GET("/:id", handler.GetByID)
+		overrides.POST("", handler.Create)
+		overrides.PUT("/:id", handler.Update)
+		overrides.DELETE("/:id", handler.Delete)
+	}
+}
@@ -7,7 +7,6 @@ import (
 // CreateReservationDTO represents the data needed to create a reservation
 type CreateReservationDTO struct {
 	ResourceID  uint      `json:"resourceId" validate:"required"`
-	UserID      uint      `json:"userId" validate:"required"`
 	StartTime   time.Time `json:"startTime" validate:"required"`
 	EndTime     time.Time `json:"endTime" validate:"required"`
 	Purpose     string    `json:"purpose" validate:"required"`
@@ -18,7 +17,6 @@ type CreateReservationDTO struct {
 // UpdateReservationDTO represents the data needed to update a reservation
 type UpdateReservationDTO struct {
 	ResourceID  uint      `json:"resourceId" validate:"required"`
-	UserID      uint      `json:"userId" validate:"required"`
 	StartTime   time.Time `json:"startTime" validate:"required"`
 	EndTime     time.Time `json:"endTime" validate:"required"`
 	Purpose     string    `json:"purpose" validate:"required"`
@@ -28,14 +26,61 @@ type UpdateReservationDTO struct {
 
 // ReservationDTO represents reservation data for application operations
 type ReservationDTO struct {
-	ID          uint      `json:"id"`
-	ResourceID  uint      `json:"resourceId"`
-	UserID      uint      `json:"userId"`
-	StartTime   time.Time `json:"startTime"`
-	EndTime     time.Time `json:"endTime"`
-	Purpose     string    `json:"purpose"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID           uint       `json:"id"`
+	ResourceID   uint       `json:"resourceId"`
+	UserID       string     `json:"userId"`
+	StartTime    time.Time  `json:"startTime"`
+	EndTime      time.Time  `json:"endTime"`
+	Purpose      string     `json:"purpose"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	StatusReason string     `json:"statusReason,omitempty"`
+	SeriesID     *uint      `json:"seriesId,omitempty"`
+	CheckedInAt  *time.Time `json:"checkedInAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+// RejectReservationDTO represents the data needed to reject a reservation
+type RejectReservationDTO struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// CreateSeriesDTO represents the data needed to create a recurring reservation series
+type CreateSeriesDTO struct {
+	ResourceID     uint        `json:"resourceId" validate:"required"`
+	StartTime      time.Time   `json:"startTime" validate:"required"`
+	EndTime        time.Time   `json:"endTime" validate:"required"`
+	TimeZone       string      `json:"timeZone" example:"America/Sao_Paulo"`
+	RecurrenceRule string      `json:"recurrenceRule" validate:"required" example:"FREQ=WEEKLY;BYDAY=TU,TH;UNTIL=20261220T235959Z"`
+	ExceptionDates []time.Time `json:"exceptionDates"`
+	Purpose        string      `json:"purpose" validate:"required"`
+	Description    string      `json:"description"`
+}
+
+// UpdateOccurrenceDTO represents an edit to one occurrence of a series
+type UpdateOccurrenceDTO struct {
+	Scope          string    `json:"scope" validate:"required,oneof=this following all" enums:"this,following,all"`
+	StartTime      time.Time `json:"startTime" validate:"required"`
+	EndTime        time.Time `json:"endTime" validate:"required"`
+	Purpose        string    `json:"purpose" validate:"required"`
+	Description    string    `json:"description"`
+	RecurrenceRule string    `json:"recurrenceRule,omitempty"`
+}
+
+// SeriesDTO represents a recurring reservation series with its occurrences
+type SeriesDTO struct {
+	ID             uint             `json:"id"`
+	ResourceID     uint             `json:"resourceId"`
+	UserID         string           `json:"userId"`
+	StartTime      time.Time        `json:"startTime"`
+	EndTime        time.Time        `json:"endTime"`
+	TimeZone       string           `json:"timeZone"`
+	RecurrenceRule string           `json:"recurrenceRule"`
+	ExceptionDates []time.Time      `json:"exceptionDates,omitempty"`
+	Purpose        string           `json:"purpose"`
+	Description    string           `json:"description"`
+	Occurrences    []ReservationDTO `json:"occurrences"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	UpdatedAt      time.Time        `json:"updatedAt"`
 }
@@ -2,8 +2,13 @@ package reservation
 
 import (
 	"net/http"
+	"sarc-ng/internal/domain/auth"
+	domainCommon "sarc-ng/internal/domain/common"
 	"sarc-ng/internal/domain/reservation"
 	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/middleware"
+	"sarc-ng/pkg/rest/types"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +20,16 @@ type Handler struct {
 	mapper  *Mapper
 }
 
+// listFilters are the query parameters reservations can be filtered by
+var listFilters = []common.QueryFilter{
+	common.Equal("resourceId", common.ParseUint),
+	common.Equal("userId", common.ParseString),
+	common.Equal("seriesId", common.ParseUint),
+	common.Equal("status", common.ParseString),
+	{Param: "from", Field: "endTime", Op: domainCommon.OpGreaterOrEqual, Parse: common.ParseTime},
+	{Param: "to", Field: "startTime", Op: domainCommon.OpLessOrEqual, Parse: common.ParseTime},
+}
+
 // NewHandler creates a new reservation handler
 func NewHandler(service reservation.Usecase) *Handler {
 	mapper := NewMapper()
@@ -27,35 +42,71 @@ func NewHandler(service reservation.Usecase) *Handler {
 	}
 }
 
-// GetAll retrieves all reservations
-// @Summary Get all reservations
-// @Description Retrieve a list of all reservations in the system
+// GetAll retrieves a page of reservations
+// @Summary List reservations
+// @Description Retrieve a page of the caller's reservations. Managers get every reservation in the system unless mine is set. Sortable fields: id, resourceId, userId, status, startTime, endTime, seriesId, createdAt, updatedAt.
 // @Tags reservations
 // @Accept json
 // @Produce json
 // @Security CognitoOAuth
 // @Security BearerAuth
-// @Success 200 {array} ReservationDTO "List of reservations"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param mine query bool false "Only return the caller's reservations (managers only, others always get their own)"
+// @Param resourceId query int false "Resource ID"
+// @Param userId query string false "Owner subject"
+// @Param seriesId query int false "Series ID"
+// @Param status query string false "Status" Enums(pending, approved, rejected, cancelled, completed, no_show, expired)
+// @Param from query string false "Only reservations ending at or after this RFC 3339 time"
+// @Param to query string false "Only reservations starting at or before this RFC 3339 time"
+// @Success 200 {object} types.PaginatedResponse[ReservationDTO] "Page of reservations"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
 // @Failure 401 {object} common.ErrorResponse "Unauthorized"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /reservations [get]
 func (h *Handler) GetAll(c *gin.Context) {
-	entities, err := h.service.GetAllReservations()
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	query, params, ok := common.ParseListQuery(c, listFilters...)
+	if !ok {
+		return
+	}
+
+	mine := !user.IsManager()
+	if value := c.Query("mine"); value != "" && !mine {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			common.RespondWithError(c, http.StatusBadRequest, "Invalid mine parameter", err.Error())
+			return
+		}
+		mine = parsed
+	}
+	if mine {
+		query = query.Where("userId", domainCommon.OpEqual, user.ID)
+	}
+
+	page, err := h.service.GetAllReservations(query)
 	if err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve "+h.GetEntityName()+"s", err.Error())
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName()+"s")
 		return
 	}
 
-	dtos := make([]ReservationDTO, len(entities))
-	for i, entity := range entities {
+	dtos := make([]ReservationDTO, len(page.Items))
+	for i, entity := range page.Items {
 		dtos[i] = *h.mapper.FromDomain(&entity)
 	}
-	c.JSON(http.StatusOK, dtos)
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
 }
 
 // GetByID retrieves a reservation by ID
 // @Summary Get reservation by ID
-// @Description Retrieve a specific reservation by its unique identifier
+// @Description Retrieve a specific reservation by its unique identifier. Only the owner, a manager or a reviewer of reservations may see it; anyone else is told it does not exist.
 // @Tags reservations
 // @Accept json
 // @Produce json
@@ -74,7 +125,12 @@ func (h *Handler) GetByID(c *gin.Context) {
 		return
 	}
 
-	entity, err := h.service.GetReservation(id)
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	entity, err := h.service.GetReservation(user, id)
 	if err != nil {
 		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName())
 		return
@@ -90,7 +146,7 @@ func (h *Handler) GetByID(c *gin.Context) {
 
 // Create creates a new reservation
 // @Summary Create a new reservation
-// @Description Create a new reservation with resource, user, and time information
+// @Description Create a new reservation with resource and time information. The authenticated user becomes its owner.
 // @Tags reservations
 // @Accept json
 // @Produce json
@@ -98,19 +154,27 @@ func (h *Handler) GetByID(c *gin.Context) {
 // @Security BearerAuth
 // @Param reservation body CreateReservationDTO true "Reservation creation data"
 // @Success 201 {object} ReservationDTO "Created reservation"
-// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data or booking policy violated"
 // @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Booking policy restricts the resource type to other groups"
+// @Failure 404 {object} common.ErrorResponse "Resource not found"
+// @Failure 409 {object} common.ErrorResponse "Resource not available for the requested time; join the waitlist through POST /waitlist"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /reservations [post]
 func (h *Handler) Create(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
 	createDTO, err := h.BindCreateJSON(c)
 	if err != nil {
 		return
 	}
 
 	entity := h.mapper.ToDomain(createDTO)
-	if err := h.service.CreateReservation(entity); err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to create "+h.GetEntityName(), err.Error())
+	if err := h.service.CreateReservation(user, entity); err != nil {
+		common.HandleError(c, err, "Failed to create "+h.GetEntityName())
 		return
 	}
 
@@ -120,7 +184,7 @@ func (h *Handler) Create(c *gin.Context) {
 
 // Update updates an existing reservation
 // @Summary Update an existing reservation
-// @Description Update an existing reservation's resource, user, time, and status information by ID
+// @Description Update an existing reservation's resource, time, and status information by ID. Only the owner or a manager may update it.
 // @Tags reservations
 // @Accept json
 // @Produce json
@@ -129,20 +193,27 @@ func (h *Handler) Create(c *gin.Context) {
 // @Param id path int true "Reservation ID" minimum(1)
 // @Param reservation body UpdateReservationDTO true "Reservation update data"
 // @Success 200 {object} ReservationDTO "Updated reservation"
-// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data or booking policy violated"
 // @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Not the owner of the reservation"
 // @Failure 404 {object} common.ErrorResponse "Reservation not found"
+// @Failure 409 {object} common.ErrorResponse "Resource not available for the requested time"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /reservations/{id} [put]
 func (h *Handler) Update(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
 	id, updateDTO, err := h.ParseIDAndBindJSON(c)
 	if err != nil {
 		return
 	}
 
 	entity := h.mapper.ToDomainWithID(updateDTO, id)
-	if err := h.service.UpdateReservation(entity); err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to update "+h.GetEntityName(), err.Error())
+	if err := h.service.UpdateReservation(user, entity); err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName())
 		return
 	}
 
@@ -152,7 +223,7 @@ func (h *Handler) Update(c *gin.Context) {
 
 // Delete removes a reservation
 // @Summary Delete a reservation
-// @Description Delete a reservation by its ID
+// @Description Delete a reservation by its ID. Only the owner or a manager may delete it.
 // @Tags reservations
 // @Accept json
 // @Produce json
@@ -162,19 +233,185 @@ func (h *Handler) Update(c *gin.Context) {
 // @Success 200 {object} common.SuccessResponse "Reservation deleted successfully"
 // @Failure 400 {object} common.ErrorResponse "Invalid reservation ID"
 // @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Not the owner of the reservation"
 // @Failure 404 {object} common.ErrorResponse "Reservation not found"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /reservations/{id} [delete]
 func (h *Handler) Delete(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
 	id, err := common.ParseIDFromPath(c, h.GetEntityName())
 	if err != nil {
 		return
 	}
 
-	if err := h.service.DeleteReservation(id); err != nil {
+	if err := h.service.DeleteReservation(user, id); err != nil {
 		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
 		return
 	}
 
 	common.RespondWithSuccess(c, http.StatusOK, h.GetEntityName()+" deleted successfully")
 }
+
+// Cancel cancels a reservation
+// @Summary Cancel a reservation
+// @Description Cancel a pending or approved reservation. Only the owner or a manager may cancel it.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Reservation ID" minimum(1)
+// @Success 200 {object} ReservationDTO "Cancelled reservation"
+// @Failure 400 {object} common.ErrorResponse "Invalid reservation ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Not the owner of the reservation"
+// @Failure 404 {object} common.ErrorResponse "Reservation not found"
+// @Failure 409 {object} common.ErrorResponse "Status transition not allowed"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /reservations/{id}/cancel [post]
+func (h *Handler) Cancel(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	if err := h.service.CancelReservation(user, id); err != nil {
+		common.HandleError(c, err, "Failed to cancel "+h.GetEntityName())
+		return
+	}
+
+	h.respondWithReservation(c, user, id)
+}
+
+// CheckIn records that someone showed up for a reservation
+// @Summary Check in to a reservation
+// @Description Check in to an approved reservation, from shortly before it starts until the grace period after. The owner or a manager can check in while signed in; anyone can with the check-in token from the QR code of the reservation's resource. Reservations nobody checks in to are released as no-shows.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Reservation ID" minimum(1)
+// @Param token query string false "Check-in token of the resource"
+// @Success 200 {object} ReservationDTO "Checked in reservation"
+// @Failure 400 {object} common.ErrorResponse "Invalid reservation ID"
+// @Failure 401 {object} common.ErrorResponse "Not signed in or invalid check-in token"
+// @Failure 403 {object} common.ErrorResponse "Not the owner of the reservation"
+// @Failure 404 {object} common.ErrorResponse "Reservation not found"
+// @Failure 409 {object} common.ErrorResponse "Reservation not approved, already checked in or outside the check-in window"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /reservations/{id}/check-in [post]
+func (h *Handler) CheckIn(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	// Signing in is optional when checking in with a token
+	user, _ := middleware.GetUserFromContext(c)
+	entity, err := h.service.CheckInReservation(user, id, c.Query("token"))
+	if err != nil {
+		common.HandleError(c, err, "Failed to check in to "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Approve approves a pending reservation
+// @Summary Approve a reservation
+// @Description Approve a pending reservation. Requires the manager or admin group.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Reservation ID" minimum(1)
+// @Success 200 {object} ReservationDTO "Approved reservation"
+// @Failure 400 {object} common.ErrorResponse "Invalid reservation ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Reservation not found"
+// @Failure 409 {object} common.ErrorResponse "Status transition not allowed"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /reservations/{id}/approve [post]
+func (h *Handler) Approve(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.ApproveReservation(user, id); err != nil {
+		common.HandleError(c, err, "Failed to approve "+h.GetEntityName())
+		return
+	}
+
+	h.respondWithReservation(c, user, id)
+}
+
+// Reject rejects a pending reservation
+// @Summary Reject a reservation
+// @Description Reject a pending reservation with a reason. Requires the manager or admin group.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Reservation ID" minimum(1)
+// @Param rejection body RejectReservationDTO true "Rejection reason"
+// @Success 200 {object} ReservationDTO "Rejected reservation"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Reservation not found"
+// @Failure 409 {object} common.ErrorResponse "Status transition not allowed"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /reservations/{id}/reject [post]
+func (h *Handler) Reject(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	var rejectDTO RejectReservationDTO
+	if err := common.BindAndValidateJSON(c, &rejectDTO); err != nil {
+		return
+	}
+
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RejectReservation(user, id, rejectDTO.Reason); err != nil {
+		common.HandleError(c, err, "Failed to reject "+h.GetEntityName())
+		return
+	}
+
+	h.respondWithReservation(c, user, id)
+}
+
+// respondWithReservation writes the current state of a reservation as the response
+func (h *Handler) respondWithReservation(c *gin.Context, user *auth.User, id uint) {
+	entity, err := h.service.GetReservation(user, id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
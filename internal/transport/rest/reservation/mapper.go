@@ -18,16 +18,19 @@ func (m *Mapper) FromDomain(entity *reservation.Reservation) *ReservationDTO {
 		return nil
 	}
 	return &ReservationDTO{
-		ID:          entity.ID,
-		ResourceID:  entity.ResourceID,
-		UserID:      entity.UserID,
-		StartTime:   entity.StartTime,
-		EndTime:     entity.EndTime,
-		Purpose:     entity.Purpose,
-		Description: entity.Description,
-		Status:      entity.Status,
-		CreatedAt:   entity.CreatedAt,
-		UpdatedAt:   entity.UpdatedAt,
+		ID:           entity.ID,
+		ResourceID:   entity.ResourceID,
+		UserID:       entity.UserID,
+		StartTime:    entity.StartTime,
+		EndTime:      entity.EndTime,
+		Purpose:      entity.Purpose,
+		Description:  entity.Description,
+		Status:       string(entity.Status),
+		StatusReason: entity.StatusReason,
+		SeriesID:     entity.SeriesID,
+		CheckedInAt:  entity.CheckedInAt,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
 	}
 }
 
@@ -38,12 +41,11 @@ func (m *Mapper) ToDomain(dto *CreateReservationDTO) *reservation.Reservation {
 	}
 	return &reservation.Reservation{
 		ResourceID:  dto.ResourceID,
-		UserID:      dto.UserID,
 		StartTime:   dto.StartTime,
 		EndTime:     dto.EndTime,
 		Purpose:     dto.Purpose,
 		Description: dto.Description,
-		Status:      dto.Status,
+		Status:      reservation.Status(dto.Status),
 	}
 }
 
@@ -55,11 +57,64 @@ func (m *Mapper) ToDomainWithID(dto *UpdateReservationDTO, id uint) *reservation
 	return &reservation.Reservation{
 		ID:          id,
 		ResourceID:  dto.ResourceID,
-		UserID:      dto.UserID,
 		StartTime:   dto.StartTime,
 		EndTime:     dto.EndTime,
 		Purpose:     dto.Purpose,
 		Description: dto.Description,
-		Status:      dto.Status,
+		Status:      reservation.Status(dto.Status),
+	}
+}
+
+// SeriesFromDomain converts a domain series to DTO
+func (m *Mapper) SeriesFromDomain(entity *reservation.Series) *SeriesDTO {
+	if entity == nil {
+		return nil
+	}
+	occurrences := make([]ReservationDTO, len(entity.Occurrences))
+	for i := range entity.Occurrences {
+		occurrences[i] = *m.FromDomain(&entity.Occurrences[i])
+	}
+	return &SeriesDTO{
+		ID:             entity.ID,
+		ResourceID:     entity.ResourceID,
+		UserID:         entity.UserID,
+		StartTime:      entity.StartTime,
+		EndTime:        entity.EndTime,
+		TimeZone:       entity.TimeZone,
+		RecurrenceRule: entity.RecurrenceRule,
+		ExceptionDates: entity.ExceptionDates,
+		Purpose:        entity.Purpose,
+		Description:    entity.Description,
+		Occurrences:    occurrences,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
+	}
+}
+
+// SeriesToDomain converts a create series DTO to domain series
+func (m *Mapper) SeriesToDomain(dto *CreateSeriesDTO) *reservation.Series {
+	if dto == nil {
+		return nil
+	}
+	return &reservation.Series{
+		ResourceID:     dto.ResourceID,
+		StartTime:      dto.StartTime,
+		EndTime:        dto.EndTime,
+		TimeZone:       dto.TimeZone,
+		RecurrenceRule: dto.RecurrenceRule,
+		ExceptionDates: dto.ExceptionDates,
+		Purpose:        dto.Purpose,
+		Description:    dto.Description,
+	}
+}
+
+// OccurrenceUpdateToDomain converts an occurrence update DTO to its domain scope and update
+func (m *Mapper) OccurrenceUpdateToDomain(dto *UpdateOccurrenceDTO) (reservation.EditScope, reservation.OccurrenceUpdate) {
+	return reservation.EditScope(dto.Scope), reservation.OccurrenceUpdate{
+		StartTime:      dto.StartTime,
+		EndTime:        dto.EndTime,
+		Purpose:        dto.Purpose,
+		Description:    dto.Description,
+		RecurrenceRule: dto.RecurrenceRule,
 	}
 }
@@ -1,21 +1,45 @@
 package reservation
 
 import (
+	"sarc-ng/internal/domain/auth"
 	"sarc-ng/internal/domain/reservation"
+	"sarc-ng/pkg/rest/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes sets up the reservation routes
-func RegisterRoutes(rg *gin.RouterGroup, service reservation.Usecase) {
+// RegisterRoutes sets up the reservation routes. Booking, changing and cancelling
+// takes the reservation:write permission, which every user has by default;
+// approving and rejecting takes the reservation:approve permission.
+func RegisterRoutes(rg *gin.RouterGroup, service reservation.Usecase, permissions auth.Permissions) {
 	handler := NewHandler(service)
+	requireWrite := middleware.RequirePermission(permissions, auth.PermissionReservationWrite)
+	requireApprove := middleware.RequirePermission(permissions, auth.PermissionReservationApprove)
 
 	reservations := rg.Group("/reservations")
 	{
 		reservations.GET("", handler.GetAll)
-		reservations.POST("", handler.Create)
+		reservations.POST("", requireWrite, handler.Create)
 		reservations.GET("/:id", handler.GetByID)
-		reservations.PUT("/:id", handler.Update)
-		reservations.DELETE("/:id", handler.Delete)
+		reservations.PUT("/:id", requireWrite, handler.Update)
+		reservations.DELETE("/:id", requireWrite, handler.Delete)
+		reservations.POST("/:id/cancel", requireWrite, handler.Cancel)
+		reservations.POST("/:id/approve", requireApprove, handler.Approve)
+		reservations.POST("/:id/reject", requireApprove, handler.Reject)
+		reservations.PUT("/:id/occurrence", requireWrite, handler.UpdateOccurrence)
+		reservations.POST("/series", requireWrite, handler.CreateSeries)
+		reservations.GET("/series/:id", handler.GetSeries)
+		reservations.POST("/series/:id/cancel", requireWrite, handler.CancelSeries)
 	}
 }
+
+// RegisterCheckInRoutes sets up the check-in route. Check-in tokens from QR codes
+// work without signing in, so the route must not be behind the auth middleware;
+// it should use the optional one so signed-in owners are recognised. Signed-in
+// callers need the reservation:write permission.
+func RegisterCheckInRoutes(rg *gin.RouterGroup, service reservation.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+	requireWrite := middleware.RequirePermissionIfSignedIn(permissions, auth.PermissionReservationWrite)
+
+	rg.POST("/reservations/:id/check-in", requireWrite, handler.CheckIn)
+}
@@ -0,0 +1,166 @@
+package reservation
+
+import (
+	"net/http"
+	"sarc-ng/internal/transport/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateSeries creates a recurring reservation series
+// @Summary Create a recurring reservation series
+// @Description Create a series from an RFC 5545 recurrence rule. Every occurrence is booked as a pending reservation, or none is if any of them conflicts. The authenticated user becomes its owner.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param series body CreateSeriesDTO true "Series creation data"
+// @Success 201 {object} SeriesDTO "Created series with its occurrences"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data, recurrence rule or booking policy violated"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Booking policy restricts the resource type to other groups"
+// @Failure 404 {object} common.ErrorResponse "Resource not found"
+// @Failure 409 {object} common.ErrorResponse "Some occurrences conflict with existing reservations"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /reservations/series [post]
+func (h *Handler) CreateSeries(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var createDTO CreateSeriesDTO
+	if err := common.BindAndValidateJSON(c, &createDTO); err != nil {
+		return
+	}
+
+	series := h.mapper.SeriesToDomain(&createDTO)
+	if err := h.service.CreateReservationSeries(user, series); err != nil {
+		common.HandleError(c, err, "Failed to create reservation series")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapper.SeriesFromDomain(series))
+}
+
+// GetSeries retrieves a recurring reservation series
+// @Summary Get reservation series by ID
+// @Description Retrieve a recurring reservation series with all of its occurrences. Only the owner, a manager or a reviewer of reservations may see it; anyone else is told it does not exist.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Series ID" minimum(1)
+// @Success 200 {object} SeriesDTO "Series details"
+// @Failure 400 {object} common.ErrorResponse "Invalid series ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 404 {object} common.ErrorResponse "Series not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /reservations/series/{id} [get]
+func (h *Handler) GetSeries(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, "reservation series")
+	if err != nil {
+		return
+	}
+
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	series, err := h.service.GetReservationSeries(user, id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve reservation series")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.SeriesFromDomain(series))
+}
+
+// CancelSeries cancels the upcoming occurrences of a series
+// @Summary Cancel a reservation series
+// @Description Cancel every upcoming pending or approved occurrence of a series. Past occurrences are kept. Only the owner or a manager may cancel it.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Series ID" minimum(1)
+// @Success 200 {object} SeriesDTO "Series after cancellation"
+// @Failure 400 {object} common.ErrorResponse "Invalid series ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Not the owner of the series"
+// @Failure 404 {object} common.ErrorResponse "Series not found"
+// @Failure 409 {object} common.ErrorResponse "No upcoming occurrences to cancel"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /reservations/series/{id}/cancel [post]
+func (h *Handler) CancelSeries(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := common.ParseIDFromPath(c, "reservation series")
+	if err != nil {
+		return
+	}
+
+	if err := h.service.CancelReservationSeries(user, id); err != nil {
+		common.HandleError(c, err, "Failed to cancel reservation series")
+		return
+	}
+
+	series, err := h.service.GetReservationSeries(user, id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve reservation series")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.SeriesFromDomain(series))
+}
+
+// UpdateOccurrence edits an occurrence of a series
+// @Summary Edit a series occurrence
+// @Description Edit one occurrence of a recurring series. The scope selects whether the change applies to this occurrence only, to this and the following ones (splitting the series), or to all upcoming occurrences. Only the owner or a manager may edit it.
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Reservation ID" minimum(1)
+// @Param occurrence body UpdateOccurrenceDTO true "Occurrence update data"
+// @Success 200 {object} SeriesDTO "Series containing the edited occurrence"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Not the owner of the reservation"
+// @Failure 404 {object} common.ErrorResponse "Reservation not found"
+// @Failure 409 {object} common.ErrorResponse "Occurrence cannot be edited or conflicts with existing reservations"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /reservations/{id}/occurrence [put]
+func (h *Handler) UpdateOccurrence(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	var updateDTO UpdateOccurrenceDTO
+	if err := common.BindAndValidateJSON(c, &updateDTO); err != nil {
+		return
+	}
+
+	scope, update := h.mapper.OccurrenceUpdateToDomain(&updateDTO)
+	series, err := h.service.UpdateReservationOccurrence(user, id, scope, update)
+	if err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName()+" occurrence")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.SeriesFromDomain(series))
+}
@@ -11,6 +11,9 @@ type CreateResourceDTO struct {
 	Description string `json:"description"`
 	Location    string `json:"location"`
 	IsAvailable bool   `json:"isAvailable"`
+	// BuildingID may be left out when ClassID is set; it is taken from the class
+	BuildingID *uint `json:"buildingId,omitempty"`
+	ClassID    *uint `json:"classId,omitempty"`
 }
 
 // UpdateResourceDTO represents the data needed to update a resource
@@ -20,6 +23,9 @@ type UpdateResourceDTO struct {
 	Description string `json:"description"`
 	Location    string `json:"location"`
 	IsAvailable bool   `json:"isAvailable"`
+	// BuildingID may be left out when ClassID is set; it is taken from the class
+	BuildingID *uint `json:"buildingId,omitempty"`
+	ClassID    *uint `json:"classId,omitempty"`
 }
 
 // ResourceDTO represents resource data for application operations
@@ -30,6 +36,8 @@ type ResourceDTO struct {
 	Description string    `json:"description"`
 	Location    string    `json:"location"`
 	IsAvailable bool      `json:"isAvailable"`
+	BuildingID  *uint     `json:"buildingId,omitempty"`
+	ClassID     *uint     `json:"classId,omitempty"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"sarc-ng/internal/domain/resource"
 	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +16,14 @@ type Handler struct {
 	mapper  *Mapper
 }
 
+// listFilters are the query parameters resources can be filtered by
+var listFilters = []common.QueryFilter{
+	common.Equal("type", common.ParseString),
+	common.Equal("isAvailable", common.ParseBool),
+	common.Equal("buildingId", common.ParseUint),
+	common.Equal("classId", common.ParseUint),
+}
+
 // NewHandler creates a new resource handler
 func NewHandler(service resource.Usecase) *Handler {
 	mapper := NewMapper()
@@ -27,27 +36,42 @@ func NewHandler(service resource.Usecase) *Handler {
 	}
 }
 
-// GetAll retrieves all resources
-// @Summary Get all resources
-// @Description Retrieve a list of all resources in the system
+// GetAll retrieves a page of resources
+// @Summary List resources
+// @Description Retrieve a page of resources, optionally filtered. Sortable fields: id, name, type, isAvailable, buildingId, classId, createdAt, updatedAt.
 // @Tags resources
 // @Accept json
 // @Produce json
-// @Success 200 {array} ResourceDTO "List of resources"
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param type query string false "Resource type"
+// @Param isAvailable query bool false "Availability"
+// @Param buildingId query int false "Building ID"
+// @Param classId query int false "Class ID"
+// @Success 200 {object} types.PaginatedResponse[ResourceDTO] "Page of resources"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /resources [get]
 func (h *Handler) GetAll(c *gin.Context) {
-	entities, err := h.service.GetAllResources()
+	query, params, ok := common.ParseListQuery(c, listFilters...)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetAllResources(query)
 	if err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve "+h.GetEntityName()+"s", err.Error())
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName()+"s")
 		return
 	}
 
-	dtos := make([]ResourceDTO, len(entities))
-	for i, entity := range entities {
+	dtos := make([]ResourceDTO, len(page.Items))
+	for i, entity := range page.Items {
 		dtos[i] = *h.mapper.FromDomain(&entity)
 	}
-	c.JSON(http.StatusOK, dtos)
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
 }
 
 // GetByID retrieves a resource by ID
@@ -88,9 +112,13 @@ func (h *Handler) GetByID(c *gin.Context) {
 // @Tags resources
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param resource body CreateResourceDTO true "Resource creation data"
 // @Success 201 {object} ResourceDTO "Created resource"
 // @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /resources [post]
 func (h *Handler) Create(c *gin.Context) {
@@ -100,8 +128,8 @@ func (h *Handler) Create(c *gin.Context) {
 	}
 
 	entity := h.mapper.ToDomain(createDTO)
-	if err := h.service.CreateResource(entity); err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to create "+h.GetEntityName(), err.Error())
+	if err := h.service.CreateResource(common.Actor(c), entity); err != nil {
+		common.HandleError(c, err, "Failed to create "+h.GetEntityName())
 		return
 	}
 
@@ -115,10 +143,14 @@ func (h *Handler) Create(c *gin.Context) {
 // @Tags resources
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param id path int true "Resource ID" minimum(1)
 // @Param resource body UpdateResourceDTO true "Resource update data"
 // @Success 200 {object} ResourceDTO "Updated resource"
 // @Failure 400 {object} common.ErrorResponse "Invalid input data"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 404 {object} common.ErrorResponse "Resource not found"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /resources/{id} [put]
@@ -129,8 +161,8 @@ func (h *Handler) Update(c *gin.Context) {
 	}
 
 	entity := h.mapper.ToDomainWithID(updateDTO, id)
-	if err := h.service.UpdateResource(entity); err != nil {
-		common.RespondWithError(c, http.StatusInternalServerError, "Failed to update "+h.GetEntityName(), err.Error())
+	if err := h.service.UpdateResource(common.Actor(c), entity); err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName())
 		return
 	}
 
@@ -144,9 +176,13 @@ func (h *Handler) Update(c *gin.Context) {
 // @Tags resources
 // @Accept json
 // @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
 // @Param id path int true "Resource ID" minimum(1)
 // @Success 200 {object} common.SuccessResponse "Resource deleted successfully"
 // @Failure 400 {object} common.ErrorResponse "Invalid resource ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
 // @Failure 404 {object} common.ErrorResponse "Resource not found"
 // @Failure 500 {object} common.ErrorResponse "Internal server error"
 // @Router /resources/{id} [delete]
@@ -156,10 +192,73 @@ func (h *Handler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteResource(id); err != nil {
+	if err := h.service.DeleteResource(common.Actor(c), id); err != nil {
 		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
 		return
 	}
 
 	common.RespondWithSuccess(c, http.StatusOK, h.GetEntityName()+" deleted successfully")
 }
+
+// GetByBuilding retrieves the resources kept in a building
+// @Summary Get resources of a building
+// @Description Retrieve the resources kept in a specific building, including those in its classes
+// @Tags resources
+// @Accept json
+// @Produce json
+// @Param id path int true "Building ID" minimum(1)
+// @Success 200 {array} ResourceDTO "List of resources"
+// @Failure 400 {object} common.ErrorResponse "Invalid building ID"
+// @Failure 404 {object} common.ErrorResponse "Building not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /buildings/{id}/resources [get]
+func (h *Handler) GetByBuilding(c *gin.Context) {
+	buildingID, err := common.ParseIDFromPath(c, "building")
+	if err != nil {
+		return
+	}
+
+	entities, err := h.service.GetResourcesByBuilding(buildingID)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName()+"s")
+		return
+	}
+
+	h.respondWithList(c, entities)
+}
+
+// GetByClass retrieves the resources kept in a class
+// @Summary Get resources of a class
+// @Description Retrieve the resources kept in a specific class
+// @Tags resources
+// @Accept json
+// @Produce json
+// @Param id path int true "Class ID" minimum(1)
+// @Success 200 {array} ResourceDTO "List of resources"
+// @Failure 400 {object} common.ErrorResponse "Invalid class ID"
+// @Failure 404 {object} common.ErrorResponse "Class not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /classes/{id}/resources [get]
+func (h *Handler) GetByClass(c *gin.Context) {
+	classID, err := common.ParseIDFromPath(c, "class")
+	if err != nil {
+		return
+	}
+
+	entities, err := h.service.GetResourcesByClass(classID)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName()+"s")
+		return
+	}
+
+	h.respondWithList(c, entities)
+}
+
+// respondWithList writes resources as a JSON array
+func (h *Handler) respondWithList(c *gin.Context, entities []resource.Resource) {
+	dtos := make([]ResourceDTO, len(entities))
+	for i, entity := range entities {
+		dtos[i] = *h.mapper.FromDomain(&entity)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
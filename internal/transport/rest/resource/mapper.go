@@ -23,6 +23,8 @@ func (m *Mapper) FromDomain(entity *resource.Resource) *ResourceDTO {
 		Type:        entity.Type,
 		Description: entity.Description,
 		Location:    entity.Location,
+		BuildingID:  entity.BuildingID,
+		ClassID:     entity.ClassID,
 		IsAvailable: entity.IsAvailable,
 		CreatedAt:   entity.CreatedAt,
 		UpdatedAt:   entity.UpdatedAt,
@@ -39,6 +41,8 @@ func (m *Mapper) ToDomain(dto *CreateResourceDTO) *resource.Resource {
 		Type:        dto.Type,
 		Description: dto.Description,
 		Location:    dto.Location,
+		BuildingID:  dto.BuildingID,
+		ClassID:     dto.ClassID,
 		IsAvailable: dto.IsAvailable,
 	}
 }
@@ -54,6 +58,8 @@ func (m *Mapper) ToDomainWithID(dto *UpdateResourceDTO, id uint) *resource.Resou
 		Type:        dto.Type,
 		Description: dto.Description,
 		Location:    dto.Location,
+		BuildingID:  dto.BuildingID,
+		ClassID:     dto.ClassID,
 		IsAvailable: dto.IsAvailable,
 	}
 }
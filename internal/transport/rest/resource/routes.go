@@ -1,21 +1,32 @@
 package resource
 
 import (
+	"sarc-ng/internal/domain/auth"
 	"sarc-ng/internal/domain/resource"
+	"sarc-ng/pkg/rest/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-// RegisterRoutes sets up the resource routes
-func RegisterRoutes(rg *gin.RouterGroup, service resource.Usecase) {
+// RegisterRoutes sets up the resource routes. Anyone may read the resources;
+// changing them takes the resource:write permission, so the writes are
+// registered on the authenticated group.
+func RegisterRoutes(public, protected *gin.RouterGroup, service resource.Usecase, permissions auth.Permissions) {
 	handler := NewHandler(service)
 
-	resources := rg.Group("/resources")
+	resources := public.Group("/resources")
 	{
 		resources.GET("", handler.GetAll)
-		resources.POST("", handler.Create)
 		resources.GET("/:id", handler.GetByID)
-		resources.PUT("/:id", handler.Update)
-		resources.DELETE("/:id", handler.Delete)
+	}
+
+	public.GET("/buildings/:id/resources", handler.GetByBuilding)
+	public.GET("/classes/:id/resources", handler.GetByClass)
+
+	writes := protected.Group("/resources", middleware.RequirePermission(permissions, auth.PermissionResourceWrite))
+	{
+		writes.POST("", handler.Create)
+		writes.PUT("/:id", handler.Update)
+		writes.DELETE("/:id", handler.Delete)
 	}
 }
@@ -1,17 +1,44 @@
 package rest
 
 import (
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/domain/audit"
 	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/availability"
 	"sarc-ng/internal/domain/building"
+	"sarc-ng/internal/domain/calendar"
+	"sarc-ng/internal/domain/checkin"
 	"sarc-ng/internal/domain/class"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/job"
 	"sarc-ng/internal/domain/lesson"
+	"sarc-ng/internal/domain/notification"
+	"sarc-ng/internal/domain/policy"
+	"sarc-ng/internal/domain/quota"
 	"sarc-ng/internal/domain/reservation"
 	"sarc-ng/internal/domain/resource"
+	"sarc-ng/internal/domain/stream"
+	"sarc-ng/internal/domain/waitlist"
+	"sarc-ng/internal/domain/webhook"
+	apikeyRest "sarc-ng/internal/transport/rest/apikey"
+	auditRest "sarc-ng/internal/transport/rest/audit"
+	authRest "sarc-ng/internal/transport/rest/auth"
+	availabilityRest "sarc-ng/internal/transport/rest/availability"
 	buildingRest "sarc-ng/internal/transport/rest/building"
+	calendarRest "sarc-ng/internal/transport/rest/calendar"
+	checkinRest "sarc-ng/internal/transport/rest/checkin"
 	classRest "sarc-ng/internal/transport/rest/class"
+	eventRest "sarc-ng/internal/transport/rest/event"
+	jobRest "sarc-ng/internal/transport/rest/job"
 	lessonRest "sarc-ng/internal/transport/rest/lesson"
+	notificationRest "sarc-ng/internal/transport/rest/notification"
+	policyRest "sarc-ng/internal/transport/rest/policy"
+	quotaRest "sarc-ng/internal/transport/rest/quota"
 	reservationRest "sarc-ng/internal/transport/rest/reservation"
 	resourceRest "sarc-ng/internal/transport/rest/resource"
+	streamRest "sarc-ng/internal/transport/rest/stream"
+	waitlistRest "sarc-ng/internal/transport/rest/waitlist"
+	webhookRest "sarc-ng/internal/transport/rest/webhook"
 	"sarc-ng/pkg/rest/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -24,12 +51,27 @@ import (
 
 // Router contains all the dependencies for setting up routes
 type Router struct {
-	buildingService    building.Usecase
-	classService       class.Usecase
-	lessonService      lesson.Usecase
-	reservationService reservation.Usecase
-	resourceService    resource.Usecase
-	tokenValidator     auth.TokenValidator
+	buildingService     building.Usecase
+	classService        class.Usecase
+	lessonService       lesson.Usecase
+	reservationService  reservation.Usecase
+	resourceService     resource.Usecase
+	calendarService     calendar.Usecase
+	availabilityService availability.Usecase
+	policyService       policy.Usecase
+	quotaService        quota.Usecase
+	waitlistService     waitlist.Usecase
+	checkinService      checkin.Usecase
+	jobService          job.Usecase
+	eventService        event.Usecase
+	webhookService      webhook.Usecase
+	notificationService notification.Usecase
+	streamService       stream.Usecase
+	auditService        audit.Usecase
+	apiKeyService       apikey.Usecase
+	tokenValidator      auth.TokenValidator
+	tokenIssuer         auth.TokenIssuer // nil unless tokens are issued locally
+	permissions         auth.Permissions
 }
 
 // NewRouter creates a new router with all dependencies
@@ -39,15 +81,45 @@ func NewRouter(
 	lessonService lesson.Usecase,
 	reservationService reservation.Usecase,
 	resourceService resource.Usecase,
+	calendarService calendar.Usecase,
+	availabilityService availability.Usecase,
+	policyService policy.Usecase,
+	quotaService quota.Usecase,
+	waitlistService waitlist.Usecase,
+	checkinService checkin.Usecase,
+	jobService job.Usecase,
+	eventService event.Usecase,
+	webhookService webhook.Usecase,
+	notificationService notification.Usecase,
+	streamService stream.Usecase,
+	auditService audit.Usecase,
+	apiKeyService apikey.Usecase,
 	tokenValidator auth.TokenValidator,
+	tokenIssuer auth.TokenIssuer,
+	permissions auth.Permissions,
 ) *Router {
 	return &Router{
-		buildingService:    buildingService,
-		classService:       classService,
-		lessonService:      lessonService,
-		reservationService: reservationService,
-		resourceService:    resourceService,
-		tokenValidator:     tokenValidator,
+		buildingService:     buildingService,
+		classService:        classService,
+		lessonService:       lessonService,
+		reservationService:  reservationService,
+		resourceService:     resourceService,
+		calendarService:     calendarService,
+		availabilityService: availabilityService,
+		policyService:       policyService,
+		quotaService:        quotaService,
+		waitlistService:     waitlistService,
+		checkinService:      checkinService,
+		jobService:          jobService,
+		eventService:        eventService,
+		webhookService:      webhookService,
+		notificationService: notificationService,
+		streamService:       streamService,
+		auditService:        auditService,
+		apiKeyService:       apiKeyService,
+		tokenValidator:      tokenValidator,
+		tokenIssuer:         tokenIssuer,
+		permissions:         permissions,
 	}
 }
 
@@ -65,6 +137,7 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 
 // setupMiddleware applies global middleware to the router
 func (r *Router) setupMiddleware(router *gin.Engine) {
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger())
 	router.Use(middleware.Recovery())
 	router.Use(middleware.CORS())
@@ -91,22 +164,49 @@ func (r *Router) setupSystemRoutes(router *gin.Engine) {
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 }
 
-// setupAPIRoutes configures API v1 routes with authentication
+// setupAPIRoutes configures API v1 routes with authentication. Reads of the
+// catalogue are public; writes need a signed-in user and, for most, a
+// permission.
 func (r *Router) setupAPIRoutes(router *gin.Engine) {
 	// Public API routes (no authentication required)
-	// These are read-only endpoints accessible to everyone
 	publicV1 := router.Group("/api/v1")
-	{
-		buildingRest.RegisterRoutes(publicV1, r.buildingService)
-		classRest.RegisterRoutes(publicV1, r.classService)
-		lessonRest.RegisterRoutes(publicV1, r.lessonService)
-		resourceRest.RegisterRoutes(publicV1, r.resourceService)
-	}
 
 	// Protected API routes (authentication required)
 	protectedV1 := router.Group("/api/v1")
-	protectedV1.Use(middleware.AuthMiddleware(r.tokenValidator))
+	protectedV1.Use(middleware.AuthMiddleware(r.tokenValidator, r.apiKeyService))
+	protectedV1.Use(notificationRest.RememberRecipient(r.notificationService))
+
+	// Everyone may read the catalogue; its writes go on the protected group
+	{
+		buildingRest.RegisterRoutes(publicV1, protectedV1, r.buildingService, r.permissions)
+		classRest.RegisterRoutes(publicV1, protectedV1, r.classService, r.permissions)
+		lessonRest.RegisterRoutes(publicV1, protectedV1, r.lessonService, r.permissions)
+		resourceRest.RegisterRoutes(publicV1, protectedV1, r.resourceService, r.permissions)
+		availabilityRest.RegisterRoutes(publicV1, r.availabilityService)
+		// Calendar feeds authenticate personal feeds with their own tokens
+		calendarRest.RegisterFeedRoutes(publicV1.Group("", middleware.OptionalAuthMiddleware(r.tokenValidator, r.apiKeyService)), r.calendarService)
+		// Check-in works with a QR code token instead of signing in
+		reservationRest.RegisterCheckInRoutes(publicV1.Group("", middleware.OptionalAuthMiddleware(r.tokenValidator, r.apiKeyService)), r.reservationService, r.permissions)
+		// Live updates also take the token from the query string, for browsers
+		streamRest.RegisterRoutes(publicV1, r.streamService, r.tokenValidator, r.apiKeyService)
+		// The local issuer publishes its keys and may hand out development tokens
+		if r.tokenIssuer != nil {
+			authRest.RegisterRoutes(&router.RouterGroup, publicV1, r.tokenIssuer)
+		}
+	}
+
 	{
-		reservationRest.RegisterRoutes(protectedV1, r.reservationService)
+		reservationRest.RegisterRoutes(protectedV1, r.reservationService, r.permissions)
+		calendarRest.RegisterRoutes(protectedV1, r.calendarService)
+		policyRest.RegisterRoutes(protectedV1, r.policyService, r.permissions)
+		quotaRest.RegisterRoutes(protectedV1, r.quotaService, r.permissions)
+		waitlistRest.RegisterRoutes(protectedV1, r.waitlistService, r.permissions)
+		checkinRest.RegisterRoutes(protectedV1, r.checkinService, r.permissions)
+		jobRest.RegisterRoutes(protectedV1, r.jobService, r.permissions)
+		eventRest.RegisterRoutes(protectedV1, r.eventService, r.permissions)
+		webhookRest.RegisterRoutes(protectedV1, r.webhookService, r.permissions)
+		notificationRest.RegisterRoutes(protectedV1, r.notificationService)
+		auditRest.RegisterRoutes(protectedV1, r.auditService, r.permissions)
+		apikeyRest.RegisterRoutes(protectedV1, r.apiKeyService, r.permissions)
 	}
 }
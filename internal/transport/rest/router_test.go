@@ -0,0 +1,371 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sarc-ng/internal/domain/apikey"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/notification"
+	authService "sarc-ng/internal/service/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Who may call a route, from the least to the most restricted
+const (
+	anyone = iota
+	signedIn
+	booker // reservation:write
+	teacher
+	manager
+	admin
+)
+
+// roles are the callers of every route, each with the access level it has.
+// Callers with a key use an API key instead of a token; the routes in their
+// exceptions are the ones their scopes open or close against their level.
+var roles = []struct {
+	token      string
+	key        string
+	level      int
+	exceptions map[string]bool
+}{
+	{"", "", anyone, nil},
+	{"", "kiosk", signedIn, map[string]bool{
+		"POST /api/v1/resources/:id/check-in-token": true, // checkin:issue
+		"POST /api/v1/reservations/:id/check-in":    true, // signed-in check-ins need reservation:write
+	}},
+	{"", "booking", booker, nil},
+	{"user", "", booker, nil},
+	{"teacher", "", teacher, nil},
+	{"manager", "", manager, nil},
+	{"admin", "", admin, nil},
+}
+
+// routes lists who may call every route with the default permissions
+var routes = map[string]int{
+	"GET /health":           anyone,
+	"GET /metrics":          anyone,
+	"GET /swagger/*any":     anyone,
+	"GET /api/v1/stream":    signedIn,
+	"GET /api/v1/stream/ws": signedIn,
+
+	"GET /api/v1/buildings":                     anyone,
+	"GET /api/v1/buildings/:id":                 anyone,
+	"POST /api/v1/buildings":                    manager,
+	"PUT /api/v1/buildings/:id":                 manager,
+	"DELETE /api/v1/buildings/:id":              manager,
+	"GET /api/v1/buildings/:id/calendar.ics":    anyone,
+	"GET /api/v1/buildings/:id/classes":         anyone,
+	"GET /api/v1/buildings/:id/resources":       anyone,
+	"GET /api/v1/classes":                       anyone,
+	"GET /api/v1/classes/:id":                   anyone,
+	"POST /api/v1/classes":                      manager,
+	"PUT /api/v1/classes/:id":                   manager,
+	"DELETE /api/v1/classes/:id":                manager,
+	"GET /api/v1/classes/:id/resources":         anyone,
+	"GET /api/v1/lessons":                       anyone,
+	"GET /api/v1/lessons/:id":                   anyone,
+	"GET /api/v1/lessons/calendar.ics":          anyone,
+	"POST /api/v1/lessons":                      teacher,
+	"POST /api/v1/lessons/import":               teacher,
+	"PUT /api/v1/lessons/:id":                   teacher,
+	"DELETE /api/v1/lessons/:id":                teacher,
+	"GET /api/v1/resources":                     anyone,
+	"GET /api/v1/resources/:id":                 anyone,
+	"POST /api/v1/resources":                    manager,
+	"PUT /api/v1/resources/:id":                 manager,
+	"DELETE /api/v1/resources/:id":              manager,
+	"GET /api/v1/resources/:id/calendar.ics":    anyone,
+	"GET /api/v1/resources/:id/free-slots":      anyone,
+	"GET /api/v1/resources/free-slots":          anyone,
+	"POST /api/v1/resources/:id/check-in-token": manager,
+	"GET /api/v1/users/:id/calendar.ics":        anyone,
+
+	"GET /api/v1/reservations":                    signedIn,
+	"POST /api/v1/reservations":                   booker,
+	"GET /api/v1/reservations/:id":                signedIn,
+	"PUT /api/v1/reservations/:id":                booker,
+	"DELETE /api/v1/reservations/:id":             booker,
+	"POST /api/v1/reservations/:id/cancel":        booker,
+	"POST /api/v1/reservations/:id/approve":       manager,
+	"POST /api/v1/reservations/:id/reject":        manager,
+	"POST /api/v1/reservations/:id/check-in":      anyone,
+	"PUT /api/v1/reservations/:id/occurrence":     booker,
+	"POST /api/v1/reservations/series":            booker,
+	"GET /api/v1/reservations/series/:id":         signedIn,
+	"POST /api/v1/reservations/series/:id/cancel": booker,
+	"GET /api/v1/waitlist":                        signedIn,
+	"POST /api/v1/waitlist":                       booker,
+	"GET /api/v1/waitlist/:id":                    signedIn,
+	"DELETE /api/v1/waitlist/:id":                 booker,
+
+	"GET /api/v1/me/calendar-tokens":             signedIn,
+	"POST /api/v1/me/calendar-tokens":            signedIn,
+	"DELETE /api/v1/me/calendar-tokens/:tokenId": signedIn,
+	"GET /api/v1/me/no-shows":                    signedIn,
+	"GET /api/v1/me/notifications":               signedIn,
+	"PUT /api/v1/me/notifications":               signedIn,
+	"GET /api/v1/me/quota":                       signedIn,
+	"GET /api/v1/booking-policies":               signedIn,
+	"GET /api/v1/booking-policies/:id":           signedIn,
+	"POST /api/v1/booking-policies":              admin,
+	"PUT /api/v1/booking-policies/:id":           admin,
+	"DELETE /api/v1/booking-policies/:id":        admin,
+	"GET /api/v1/quota-overrides":                manager,
+	"GET /api/v1/quota-overrides/:id":            manager,
+	"POST /api/v1/quota-overrides":               manager,
+	"PUT /api/v1/quota-overrides/:id":            manager,
+	"DELETE /api/v1/quota-overrides/:id":         manager,
+
+	"GET /api/v1/jobs":                        admin,
+	"GET /api/v1/jobs/runs":                   admin,
+	"POST /api/v1/jobs/:name/run":             admin,
+	"GET /api/v1/events":                      admin,
+	"GET /api/v1/events/:id":                  admin,
+	"POST /api/v1/events/:id/retry":           admin,
+	"GET /api/v1/webhooks":                    admin,
+	"GET /api/v1/webhooks/:id":                admin,
+	"POST /api/v1/webhooks":                   admin,
+	"PUT /api/v1/webhooks/:id":                admin,
+	"DELETE /api/v1/webhooks/:id":             admin,
+	"POST /api/v1/webhooks/:id/rotate-secret": admin,
+	"GET /api/v1/webhooks/:id/deliveries":     admin,
+	"GET /api/v1/audit":                       admin,
+	"GET /api/v1/audit/:id":                   admin,
+	"GET /api/v1/audit/export":                admin,
+	"GET /api/v1/api-keys":                    admin,
+	"GET /api/v1/api-keys/:id":                admin,
+	"POST /api/v1/api-keys":                   admin,
+	"PUT /api/v1/api-keys/:id":                admin,
+	"DELETE /api/v1/api-keys/:id":             admin,
+}
+
+// fakeValidator accepts the role names as tokens, for users of the group of
+// that name. The "user" token is a user without groups.
+type fakeValidator struct{}
+
+func (fakeValidator) ValidateToken(_ context.Context, token string) (*auth.Claims, error) {
+	switch token {
+	case "user":
+		return &auth.Claims{Sub: token}, nil
+	case "teacher", "manager", "admin":
+		return &auth.Claims{Sub: token, Groups: []string{token}}, nil
+	default:
+		return nil, errors.New("invalid token")
+	}
+}
+
+func (fakeValidator) RefreshJWKS(context.Context) error { return nil }
+
+// fakeKeys accepts the "kiosk" API key, which may only issue check-in tokens
+// in building 3, and the "booking" API key, which may only book. Its other
+// methods are left out.
+type fakeKeys struct{ apikey.Usecase }
+
+func (fakeKeys) AuthenticateKey(_ context.Context, key string) (*auth.User, error) {
+	switch key {
+	case "kiosk":
+		return &auth.User{ID: "apikey:1", Scopes: []auth.Permission{auth.PermissionCheckInIssue}, Buildings: []uint{3}}, nil
+	case "booking":
+		return &auth.User{ID: "apikey:2", Scopes: []auth.Permission{auth.PermissionReservationWrite}}, nil
+	default:
+		return nil, errors.New("invalid API key")
+	}
+}
+
+// recipients ignores the users signing in. The other services are left out:
+// requests that get past authorization panic in their handler and are
+// recovered as 500s.
+type recipients struct{ notification.Usecase }
+
+func (recipients) RememberRecipient(*auth.User) error { return nil }
+
+// quiet keeps the recovered panics out of the test output
+func quiet(t *testing.T) {
+	gin.SetMode(gin.ReleaseMode)
+	errorWriter := gin.DefaultErrorWriter
+	gin.DefaultErrorWriter = io.Discard
+	t.Cleanup(func() { gin.DefaultErrorWriter = errorWriter })
+}
+
+func newEngine(t *testing.T, permissions auth.Permissions) *gin.Engine {
+	quiet(t)
+
+	engine := gin.New()
+	NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		recipients{}, nil, nil, fakeKeys{}, fakeValidator{}, nil, permissions).SetupRoutes(engine)
+	return engine
+}
+
+// serve requests the route with the role's token and returns the status
+func serve(engine *gin.Engine, method, path, token string) int {
+	return serveWith(engine, method, path, token, "")
+}
+
+// serveWith requests the route with the token or API key and returns the status
+func serveWith(engine *gin.Engine, method, path, token, key string) int {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = "1"
+		case strings.HasPrefix(segment, "*"):
+			segments[i] = "index.html"
+		}
+	}
+
+	req := httptest.NewRequest(method, strings.Join(segments, "/"), nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRoutePermissions(t *testing.T) {
+	engine := newEngine(t, auth.DefaultPermissions())
+
+	registered := make(map[string]bool)
+	for _, route := range engine.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for route := range routes {
+		assert.True(t, registered[route], "%s is not registered", route)
+	}
+
+	for _, route := range engine.Routes() {
+		key := route.Method + " " + route.Path
+		level, ok := routes[key]
+		if !assert.True(t, ok, "%s is missing from the matrix", key) {
+			continue
+		}
+
+		for _, role := range roles {
+			name := role.token
+			switch {
+			case role.key != "":
+				name = role.key + " key"
+			case name == "":
+				name = "anonymous"
+			}
+			t.Run(key+" as "+name, func(t *testing.T) {
+				status := serveWith(engine, route.Method, route.Path, role.token, role.key)
+				switch {
+				case (role.level >= level) != role.exceptions[key]:
+					assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, status)
+				case role.level == anyone:
+					assert.Equal(t, http.StatusUnauthorized, status)
+				default:
+					assert.Equal(t, http.StatusForbidden, status)
+				}
+			})
+		}
+	}
+}
+
+func TestRoutePermissionsAreConfigurable(t *testing.T) {
+	permissions, err := auth.ParsePermissions(map[string]string{
+		"building:write":    "admin",
+		"audit:read":        "manager",
+		"reservation:write": "teacher",
+	})
+	require.NoError(t, err)
+	engine := newEngine(t, permissions)
+
+	assert.Equal(t, http.StatusForbidden, serve(engine, http.MethodDelete, "/api/v1/buildings/:id", "manager"))
+	assert.NotEqual(t, http.StatusForbidden, serve(engine, http.MethodDelete, "/api/v1/buildings/:id", "admin"))
+	assert.NotEqual(t, http.StatusForbidden, serve(engine, http.MethodGet, "/api/v1/audit", "manager"))
+	assert.Equal(t, http.StatusForbidden, serve(engine, http.MethodGet, "/api/v1/audit", "teacher"))
+	assert.Equal(t, http.StatusForbidden, serve(engine, http.MethodPost, "/api/v1/reservations", "user"))
+	assert.NotEqual(t, http.StatusForbidden, serve(engine, http.MethodPost, "/api/v1/reservations", "teacher"))
+}
+
+func TestAPIKeysAreAccepted(t *testing.T) {
+	engine := newEngine(t, auth.DefaultPermissions())
+	withKey := func(method, path, key string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden},
+		withKey(http.MethodPost, "/api/v1/resources/1/check-in-token", "kiosk"), "keys have their scopes")
+	assert.Equal(t, http.StatusForbidden, withKey(http.MethodPost, "/api/v1/buildings", "kiosk"), "and only those")
+	assert.Equal(t, http.StatusForbidden, withKey(http.MethodGet, "/api/v1/api-keys", "kiosk"))
+	assert.Equal(t, http.StatusForbidden, withKey(http.MethodPost, "/api/v1/reservations", "kiosk"), "keys only book with reservation:write")
+	assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden},
+		withKey(http.MethodPost, "/api/v1/reservations", "booking"))
+	assert.Equal(t, http.StatusUnauthorized, withKey(http.MethodPost, "/api/v1/resources/1/check-in-token", "stolen"))
+}
+
+func TestLocalIssuerRoutes(t *testing.T) {
+	newIssuerEngine := func(devTokens bool) (*gin.Engine, *authService.LocalIssuer) {
+		issuer, err := authService.NewLocalIssuer(authService.LocalSettings{
+			Issuer: "http://sarc.test", Algorithm: "RS256", Expiry: time.Hour, DevTokens: devTokens,
+		})
+		require.NoError(t, err)
+
+		engine := gin.New()
+		NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+			recipients{}, nil, nil, nil, issuer, issuer, auth.DefaultPermissions()).SetupRoutes(engine)
+		return engine, issuer
+	}
+	issueToken := func(engine *gin.Engine, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/token", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w
+	}
+	quiet(t)
+
+	engine, issuer := newIssuerEngine(true)
+
+	w := issueToken(engine, `{"groups":["manager"],"expiresIn":"2h"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var token struct {
+		AccessToken string `json:"accessToken"`
+		ExpiresIn   int64  `json:"expiresIn"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &token))
+	assert.Equal(t, int64(7200), token.ExpiresIn)
+
+	claims, err := issuer.ValidateToken(context.Background(), token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "developer", claims.Username)
+	assert.Equal(t, "dev-developer", claims.Sub)
+	assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden},
+		serve(engine, http.MethodPost, "/api/v1/buildings", token.AccessToken))
+
+	w = issueToken(engine, `{}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &token))
+	assert.Equal(t, http.StatusForbidden, serve(engine, http.MethodPost, "/api/v1/buildings", token.AccessToken))
+	assert.Equal(t, http.StatusBadRequest, issueToken(engine, `{"expiresIn":"forever"}`).Code)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"jwks_uri":"http://sarc.test/.well-known/jwks.json"`)
+	assert.Equal(t, http.StatusOK, serve(engine, http.MethodGet, "/.well-known/jwks.json", ""))
+
+	engine, _ = newIssuerEngine(false)
+	assert.Equal(t, http.StatusNotFound, issueToken(engine, `{"groups":["admin"]}`).Code, "development tokens are off")
+}
@@ -0,0 +1,26 @@
+package stream
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// UpdateDTO represents a change pushed to live clients. Over SSE it is the data
+// of an event named after its type, with the event ID as the SSE ID.
+type UpdateDTO struct {
+	ID            uint            `json:"id" example:"42"`
+	Type          string          `json:"type" example:"reservation.status_changed"`
+	AggregateType string          `json:"aggregateType" example:"reservation"`
+	AggregateID   uint            `json:"aggregateId" example:"7"`
+	ResourceID    uint            `json:"resourceId" example:"3"`
+	BuildingID    *uint           `json:"buildingId,omitempty" example:"1"`
+	UserID        string          `json:"userId,omitempty" example:"user-123"`
+	Data          json.RawMessage `json:"data" swaggertype:"object"` // the domain event, as posted to webhooks
+	OccurredAt    time.Time       `json:"occurredAt" example:"2025-10-04T14:00:00Z"`
+}
+
+// HeartbeatDTO is sent over WebSocket connections that have been idle, so
+// that proxies keep them open
+type HeartbeatDTO struct {
+	Type string `json:"type" example:"heartbeat"`
+}
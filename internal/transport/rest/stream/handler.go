@@ -0,0 +1,224 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/stream"
+	"sarc-ng/internal/transport/common"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// heartbeatInterval is how long a connection may be idle before a heartbeat
+// is sent, below the idle timeout of common proxies and load balancers
+var heartbeatInterval = 25 * time.Second
+
+// reconnectDelay is how long EventSource clients wait before reconnecting
+const reconnectDelay = 3 * time.Second
+
+// Handler handles HTTP requests for live updates
+type Handler struct {
+	service stream.Usecase
+	mapper  *Mapper
+}
+
+// NewHandler creates a new stream handler
+func NewHandler(service stream.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// Stream pushes updates as Server-Sent Events
+// @Summary Stream live updates
+// @Description Push reservation and resource changes as Server-Sent Events until the client disconnects. Every event is named after its type (e.g. reservation.created), carries the ID of the domain event and an UpdateDTO as data; comments are sent as heartbeats. Filters are combined, and repeated or comma separated values match any of them. Updates are not replayed: clients refetch what they show after reconnecting. Only managers receive the reservations of other users. EventSource cannot send headers, so the access token may be given in the access_token parameter.
+// @Tags stream
+// @Produce text/event-stream
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param buildingId query []int false "Only changes in these buildings" collectionFormat(csv)
+// @Param resourceId query []int false "Only changes to these resources" collectionFormat(csv)
+// @Param userId query string false "Only reservations of this user; 'me' for the authenticated user. Other users need a manager."
+// @Param type query []string false "Only these event types" collectionFormat(csv)
+// @Param access_token query string false "Access token, for clients that cannot send the Authorization header"
+// @Success 200 {object} UpdateDTO "Stream of updates"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Following another user's reservations without being a manager"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /stream [get]
+func (h *Handler) Stream(c *gin.Context) {
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	updates, ok := h.subscribe(ctx, c)
+	if !ok {
+		return
+	}
+
+	// Streams outlive the server's write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", reconnectDelay.Milliseconds())
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		var err error
+		select {
+		case update, open := <-updates:
+			if !open {
+				return
+			}
+			data, marshalErr := json.Marshal(h.mapper.FromDomain(&update))
+			if marshalErr != nil {
+				return
+			}
+			_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", update.ID, update.Type, data)
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(c.Writer, ": heartbeat\n\n")
+		}
+		if err != nil {
+			return
+		}
+		c.Writer.Flush()
+		heartbeat.Reset(heartbeatInterval)
+	}
+}
+
+// WebSocket pushes updates over a WebSocket connection
+// @Summary Stream live updates over WebSocket
+// @Description Upgrade to a WebSocket connection and push reservation and resource changes as JSON text messages until either side closes it. Messages are UpdateDTOs, or {"type": "heartbeat"} after a while without changes; messages from the client are ignored. Takes the same filters as /stream, and the access token in the access_token parameter for browsers.
+// @Tags stream
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param buildingId query []int false "Only changes in these buildings" collectionFormat(csv)
+// @Param resourceId query []int false "Only changes to these resources" collectionFormat(csv)
+// @Param userId query string false "Only reservations of this user; 'me' for the authenticated user. Other users need a manager."
+// @Param type query []string false "Only these event types" collectionFormat(csv)
+// @Param access_token query string false "Access token, for clients that cannot send the Authorization header"
+// @Success 101 {object} UpdateDTO "Switching protocols"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter or not a WebSocket request"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Following another user's reservations without being a manager"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /stream/ws [get]
+func (h *Handler) WebSocket(c *gin.Context) {
+	// Hijacked connections outlive the request context, so the stream is
+	// cancelled once the client goes away or a send fails
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	updates, ok := h.subscribe(ctx, c)
+	if !ok {
+		return
+	}
+
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		go func() {
+			// Reading notices the client closing the connection
+			var discard []byte
+			for websocket.Message.Receive(ws, &discard) == nil {
+			}
+			cancel()
+		}()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			var err error
+			select {
+			case update, open := <-updates:
+				if !open {
+					return
+				}
+				err = websocket.JSON.Send(ws, h.mapper.FromDomain(&update))
+			case <-heartbeat.C:
+				err = websocket.JSON.Send(ws, HeartbeatDTO{Type: "heartbeat"})
+			}
+			if err != nil {
+				return
+			}
+			heartbeat.Reset(heartbeatInterval)
+		}
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// subscribe parses the filter and subscribes to the updates matching it. It
+// responds with an error and returns false when that fails.
+func (h *Handler) subscribe(ctx context.Context, c *gin.Context) (<-chan stream.Update, bool) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return nil, false
+	}
+
+	filter, err := parseFilter(c, user)
+	if err != nil {
+		common.RespondWithError(c, http.StatusBadRequest, "Invalid filter", err.Error())
+		return nil, false
+	}
+
+	updates, err := h.service.Subscribe(ctx, user, filter)
+	if err != nil {
+		common.HandleError(c, err, "Failed to subscribe to updates")
+		return nil, false
+	}
+	return updates, true
+}
+
+// parseFilter reads the filter from the query string. IDs and types may be
+// repeated or comma separated.
+func parseFilter(c *gin.Context, user *auth.User) (stream.Filter, error) {
+	var filter stream.Filter
+
+	for _, param := range []struct {
+		name string
+		ids  *[]uint
+	}{{"buildingId", &filter.BuildingIDs}, {"resourceId", &filter.ResourceIDs}} {
+		for _, value := range queryList(c, param.name) {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || id == 0 {
+				return stream.Filter{}, fmt.Errorf("%s must be a positive integer", param.name)
+			}
+			*param.ids = append(*param.ids, uint(id))
+		}
+	}
+
+	filter.UserID = c.Query("userId")
+	if filter.UserID == "me" {
+		filter.UserID = user.ID
+	}
+
+	for _, value := range queryList(c, "type") {
+		filter.Types = append(filter.Types, event.Type(value))
+	}
+	return filter, nil
+}
+
+// queryList returns the values of a repeated or comma separated parameter
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, value := range c.QueryArray(name) {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
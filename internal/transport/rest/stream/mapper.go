@@ -0,0 +1,26 @@
+package stream
+
+import "sarc-ng/internal/domain/stream"
+
+// Mapper handles conversion between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new stream mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts an update to its DTO
+func (m *Mapper) FromDomain(u *stream.Update) UpdateDTO {
+	return UpdateDTO{
+		ID:            u.ID,
+		Type:          string(u.Type),
+		AggregateType: u.AggregateType,
+		AggregateID:   u.AggregateID,
+		ResourceID:    u.ResourceID,
+		BuildingID:    u.BuildingID,
+		UserID:        u.UserID,
+		Data:          u.Data,
+		OccurredAt:    u.OccurredAt,
+	}
+}
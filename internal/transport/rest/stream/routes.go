@@ -0,0 +1,34 @@
+package stream
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/stream"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the live update routes. Browsers cannot send headers
+// with EventSource and WebSocket requests, so these routes authenticate with
+// their own middleware, which also accepts the token in the query string.
+// Services and devices may send an API key instead.
+func RegisterRoutes(rg *gin.RouterGroup, service stream.Usecase, validator auth.TokenValidator, keys auth.KeyAuthenticator) {
+	handler := NewHandler(service)
+
+	streams := rg.Group("/stream", tokenFromQuery(), middleware.AuthMiddleware(validator, keys))
+	{
+		streams.GET("", handler.Stream)
+		streams.GET("/ws", handler.WebSocket)
+	}
+}
+
+// tokenFromQuery moves the access_token query parameter into the
+// Authorization header of requests without one
+func tokenFromQuery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.Query("access_token"); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		c.Next()
+	}
+}
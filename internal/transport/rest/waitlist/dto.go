@@ -0,0 +1,31 @@
+package waitlist
+
+import (
+	"time"
+)
+
+// CreateWaitlistEntryDTO represents the data needed to join the waitlist
+type CreateWaitlistEntryDTO struct {
+	ResourceID  uint       `json:"resourceId" validate:"required"`
+	StartTime   time.Time  `json:"startTime" validate:"required"`
+	EndTime     time.Time  `json:"endTime" validate:"required"`
+	Purpose     string     `json:"purpose" validate:"required"`
+	Description string     `json:"description"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"` // defaults to the start time
+}
+
+// WaitlistEntryDTO represents waitlist entry data for application operations
+type WaitlistEntryDTO struct {
+	ID            uint      `json:"id"`
+	ResourceID    uint      `json:"resourceId"`
+	UserID        string    `json:"userId"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+	Purpose       string    `json:"purpose"`
+	Description   string    `json:"description"`
+	Status        string    `json:"status"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	ReservationID *uint     `json:"reservationId,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,207 @@
+package waitlist
+
+import (
+	"net/http"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/waitlist"
+	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// entityName is used in error and success messages
+const entityName = "waitlist entry"
+
+// Handler handles HTTP requests for waitlist operations
+type Handler struct {
+	service waitlist.Usecase
+	mapper  *Mapper
+}
+
+// listFilters are the query parameters waitlist entries can be filtered by
+var listFilters = []common.QueryFilter{
+	common.Equal("resourceId", common.ParseUint),
+	common.Equal("userId", common.ParseString),
+	common.Equal("status", common.ParseString),
+	{Param: "from", Field: "endTime", Op: domainCommon.OpGreaterOrEqual, Parse: common.ParseTime},
+	{Param: "to", Field: "startTime", Op: domainCommon.OpLessOrEqual, Parse: common.ParseTime},
+}
+
+// NewHandler creates a new waitlist handler
+func NewHandler(service waitlist.Usecase) *Handler {
+	return &Handler{
+		service: service,
+		mapper:  NewMapper(),
+	}
+}
+
+// GetAll retrieves a page of waitlist entries
+// @Summary List waitlist entries
+// @Description Retrieve a page of the caller's waitlist entries. Managers get every entry unless mine is set. Sortable fields: id, resourceId, userId, status, startTime, endTime, expiresAt, createdAt, updatedAt.
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param mine query bool false "Only return the caller's entries (managers only, others always get their own)"
+// @Param resourceId query int false "Resource ID"
+// @Param userId query string false "Owner subject"
+// @Param status query string false "Status" Enums(waiting, promoted, expired, cancelled)
+// @Param from query string false "Only entries ending at or after this RFC 3339 time"
+// @Param to query string false "Only entries starting at or before this RFC 3339 time"
+// @Success 200 {object} types.PaginatedResponse[WaitlistEntryDTO] "Page of waitlist entries"
+// @Failure 400 {object} common.ErrorResponse "Invalid filter, sort field or cursor"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /waitlist [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	query, params, ok := common.ParseListQuery(c, listFilters...)
+	if !ok {
+		return
+	}
+
+	mine := !user.IsManager()
+	if value := c.Query("mine"); value != "" && !mine {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			common.RespondWithError(c, http.StatusBadRequest, "Invalid mine parameter", err.Error())
+			return
+		}
+		mine = parsed
+	}
+	if mine {
+		query = query.Where("userId", domainCommon.OpEqual, user.ID)
+	}
+
+	page, err := h.service.GetAllWaitlistEntries(query)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve waitlist entries")
+		return
+	}
+
+	dtos := make([]WaitlistEntryDTO, len(page.Items))
+	for i, entity := range page.Items {
+		dtos[i] = *h.mapper.FromDomain(&entity)
+	}
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
+}
+
+// GetByID retrieves a waitlist entry by ID
+// @Summary Get waitlist entry by ID
+// @Description Retrieve a specific waitlist entry by its unique identifier. Only the owner or a manager may see it; anyone else is told it does not exist.
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Waitlist entry ID" minimum(1)
+// @Success 200 {object} WaitlistEntryDTO "Waitlist entry details"
+// @Failure 400 {object} common.ErrorResponse "Invalid waitlist entry ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 404 {object} common.ErrorResponse "Waitlist entry not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /waitlist/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, entityName)
+	if err != nil {
+		return
+	}
+
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	entity, err := h.service.GetWaitlistEntry(user, id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+entityName)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Join adds the caller to the waitlist
+// @Summary Join the waitlist
+// @Description Wait for a time window of a resource that is taken. When a conflicting reservation is cancelled or rejected, the oldest waiting entry that fits becomes a pending reservation and its owner is notified. Entries expire at their start time unless an earlier expiresAt is given.
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param entry body CreateWaitlistEntryDTO true "Waitlist entry data"
+// @Success 201 {object} WaitlistEntryDTO "Created waitlist entry"
+// @Failure 400 {object} common.ErrorResponse "Invalid input data or booking policy violated"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Booking policy restricts the resource type to other groups"
+// @Failure 404 {object} common.ErrorResponse "Resource not found"
+// @Failure 409 {object} common.ErrorResponse "Resource is free for the requested time or the caller is already waiting for it"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /waitlist [post]
+func (h *Handler) Join(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var createDTO CreateWaitlistEntryDTO
+	if err := common.BindAndValidateJSON(c, &createDTO); err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomain(&createDTO)
+	if err := h.service.JoinWaitlist(user, entity); err != nil {
+		common.HandleError(c, err, "Failed to join waitlist")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapper.FromDomain(entity))
+}
+
+// Leave removes a waitlist entry
+// @Summary Leave the waitlist
+// @Description Cancel a waiting entry. Only its owner or a manager can.
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Waitlist entry ID" minimum(1)
+// @Success 200 {object} common.SuccessResponse "Waitlist entry cancelled successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid waitlist entry ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Not the owner or a manager"
+// @Failure 404 {object} common.ErrorResponse "Waitlist entry not found"
+// @Failure 409 {object} common.ErrorResponse "Entry is no longer waiting"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /waitlist/{id} [delete]
+func (h *Handler) Leave(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	id, err := common.ParseIDFromPath(c, entityName)
+	if err != nil {
+		return
+	}
+
+	if err := h.service.LeaveWaitlist(user, id); err != nil {
+		common.HandleError(c, err, "Failed to leave waitlist")
+		return
+	}
+
+	common.RespondWithSuccess(c, http.StatusOK, entityName+" cancelled successfully")
+}
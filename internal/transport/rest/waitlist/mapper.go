@@ -0,0 +1,53 @@
+package waitlist
+
+import (
+	"sarc-ng/internal/domain/waitlist"
+	"time"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new waitlist mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts a domain entity to DTO, reporting entries past their expiry as expired
+func (m *Mapper) FromDomain(entity *waitlist.Entry) *WaitlistEntryDTO {
+	if entity == nil {
+		return nil
+	}
+	return &WaitlistEntryDTO{
+		ID:            entity.ID,
+		ResourceID:    entity.ResourceID,
+		UserID:        entity.UserID,
+		StartTime:     entity.StartTime,
+		EndTime:       entity.EndTime,
+		Purpose:       entity.Purpose,
+		Description:   entity.Description,
+		Status:        string(entity.StatusAt(time.Now())),
+		ExpiresAt:     entity.ExpiresAt,
+		ReservationID: entity.ReservationID,
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
+	}
+}
+
+// ToDomain converts a create DTO to domain entity
+func (m *Mapper) ToDomain(dto *CreateWaitlistEntryDTO) *waitlist.Entry {
+	if dto == nil {
+		return nil
+	}
+	entry := &waitlist.Entry{
+		ResourceID:  dto.ResourceID,
+		StartTime:   dto.StartTime,
+		EndTime:     dto.EndTime,
+		Purpose:     dto.Purpose,
+		Description: dto.Description,
+	}
+	if dto.ExpiresAt != nil {
+		entry.ExpiresAt = *dto.ExpiresAt
+	}
+	return entry
+}
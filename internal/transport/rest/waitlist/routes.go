@@ -0,0 +1,24 @@
+package waitlist
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/waitlist"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the waitlist routes. Joining and leaving takes the
+// reservation:write permission, as entries become reservations.
+func RegisterRoutes(rg *gin.RouterGroup, service waitlist.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+	requireWrite := middleware.RequirePermission(permissions, auth.PermissionReservationWrite)
+
+	entries := rg.Group("/waitlist")
+	{
+		entries.GET("", handler.GetAll)
+		entries.POST("", requireWrite, handler.Join)
+		entries.GET("/:id", handler.GetByID)
+		entries.DELETE("/:id", requireWrite, handler.Leave)
+	}
+}
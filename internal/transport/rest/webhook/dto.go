@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"time"
+)
+
+// CreateWebhookDTO represents the data needed to subscribe a URL to domain events
+type CreateWebhookDTO struct {
+	URL         string   `json:"url" validate:"required,url" example:"https://example.com/hooks/sarc"`
+	Description string   `json:"description,omitempty" example:"Room display sync"`
+	EventTypes  []string `json:"eventTypes,omitempty" example:"reservation.created,reservation.cancelled"` // empty for every event
+	Active      *bool    `json:"active,omitempty" example:"true"`                                          // defaults to true
+}
+
+// UpdateWebhookDTO represents the data needed to update a webhook subscription
+type UpdateWebhookDTO struct {
+	URL         string   `json:"url" validate:"required,url" example:"https://example.com/hooks/sarc"`
+	Description string   `json:"description,omitempty" example:"Room display sync"`
+	EventTypes  []string `json:"eventTypes,omitempty" example:"reservation.created,reservation.cancelled"` // empty for every event
+	Active      bool     `json:"active" example:"true"`
+}
+
+// WebhookDTO represents a webhook subscription. The secret is only returned
+// when it is generated.
+type WebhookDTO struct {
+	ID          uint      `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description,omitempty"`
+	EventTypes  []string  `json:"eventTypes"`
+	Active      bool      `json:"active"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// WebhookSecretDTO represents a webhook subscription with the secret its
+// requests are signed with
+type WebhookSecretDTO struct {
+	WebhookDTO
+	Secret string `json:"secret" example:"whsec_3f1c..."`
+}
+
+// DeliveryDTO represents one attempt to post an event to a webhook
+type DeliveryDTO struct {
+	ID             uint      `json:"id"`
+	SubscriptionID uint      `json:"subscriptionId"`
+	EventID        uint      `json:"eventId"`
+	EventType      string    `json:"eventType" example:"reservation.created"`
+	Attempt        int       `json:"attempt" example:"1"`
+	Status         string    `json:"status" example:"succeeded"`
+	StatusCode     int       `json:"statusCode,omitempty" example:"204"` // absent when no response was received
+	ResponseBody   string    `json:"responseBody,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	DurationMs     int64     `json:"durationMs" example:"87"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
@@ -0,0 +1,270 @@
+package webhook
+
+import (
+	"net/http"
+	domainCommon "sarc-ng/internal/domain/common"
+	"sarc-ng/internal/domain/webhook"
+	"sarc-ng/internal/transport/common"
+	"sarc-ng/pkg/rest/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler handles HTTP requests for webhook subscriptions
+type Handler struct {
+	*common.BaseHandler[webhook.Subscription, CreateWebhookDTO, UpdateWebhookDTO, WebhookDTO]
+	service webhook.Usecase
+	mapper  *Mapper
+}
+
+// deliveryFilters are the query parameters deliveries can be filtered by
+var deliveryFilters = []common.QueryFilter{
+	common.Equal("eventId", common.ParseUint),
+	common.Equal("eventType", common.ParseString),
+	common.Equal("status", common.ParseString),
+	{Param: "from", Field: "createdAt", Op: domainCommon.OpGreaterOrEqual, Parse: common.ParseTime},
+	{Param: "to", Field: "createdAt", Op: domainCommon.OpLessOrEqual, Parse: common.ParseTime},
+}
+
+// NewHandler creates a new webhook handler
+func NewHandler(service webhook.Usecase) *Handler {
+	mapper := NewMapper()
+	baseHandler := common.NewBaseHandler[webhook.Subscription, CreateWebhookDTO, UpdateWebhookDTO, WebhookDTO](
+		"webhook")
+	return &Handler{
+		BaseHandler: baseHandler,
+		service:     service,
+		mapper:      mapper,
+	}
+}
+
+// GetAll retrieves every webhook subscription
+// @Summary List webhooks
+// @Description Retrieve every webhook subscription. Secrets are not included. Admins only.
+// @Tags webhooks
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Success 200 {array} WebhookDTO "List of webhooks"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /webhooks [get]
+func (h *Handler) GetAll(c *gin.Context) {
+	entities, err := h.service.GetAllWebhookSubscriptions()
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve webhooks")
+		return
+	}
+
+	dtos := make([]WebhookDTO, len(entities))
+	for i, entity := range entities {
+		dtos[i] = *h.mapper.FromDomain(&entity)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GetByID retrieves a webhook subscription by ID
+// @Summary Get webhook by ID
+// @Description Retrieve a webhook subscription by its unique identifier. The secret is not included. Admins only.
+// @Tags webhooks
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Webhook ID" minimum(1)
+// @Success 200 {object} WebhookDTO "Webhook details"
+// @Failure 400 {object} common.ErrorResponse "Invalid webhook ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Webhook not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /webhooks/{id} [get]
+func (h *Handler) GetByID(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	entity, err := h.service.GetWebhookSubscription(id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Create subscribes a URL to domain events
+// @Summary Create a webhook
+// @Description Subscribe a URL to domain events, optionally filtered by event type. Events are posted as JSON and signed with HMAC-SHA256 using the secret in the response, which is not shown again: the X-Sarc-Signature header holds "sha256=" and the hex HMAC of the X-Sarc-Timestamp header, a dot and the body. Failed posts are retried with exponential backoff. Admins only.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param webhook body CreateWebhookDTO true "Webhook data"
+// @Success 201 {object} WebhookSecretDTO "Created webhook with its secret"
+// @Failure 400 {object} common.ErrorResponse "Invalid URL or unknown event type"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /webhooks [post]
+func (h *Handler) Create(c *gin.Context) {
+	user, ok := common.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	createDTO, err := h.BindCreateJSON(c)
+	if err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomain(createDTO)
+	if err := h.service.CreateWebhookSubscription(user, entity); err != nil {
+		common.HandleError(c, err, "Failed to create "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.mapper.WithSecretFromDomain(entity))
+}
+
+// Update replaces a webhook subscription
+// @Summary Update a webhook
+// @Description Replace the URL, description, event types and state of a webhook by ID. The secret is kept. Admins only.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Webhook ID" minimum(1)
+// @Param webhook body UpdateWebhookDTO true "Webhook data"
+// @Success 200 {object} WebhookDTO "Updated webhook"
+// @Failure 400 {object} common.ErrorResponse "Invalid URL or unknown event type"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Webhook not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /webhooks/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	id, updateDTO, err := h.ParseIDAndBindJSON(c)
+	if err != nil {
+		return
+	}
+
+	entity := h.mapper.ToDomainWithID(updateDTO, id)
+	if err := h.service.UpdateWebhookSubscription(entity); err != nil {
+		common.HandleError(c, err, "Failed to update "+h.GetEntityName())
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.FromDomain(entity))
+}
+
+// Delete removes a webhook subscription
+// @Summary Delete a webhook
+// @Description Delete a webhook by ID together with its delivery log. Admins only.
+// @Tags webhooks
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Webhook ID" minimum(1)
+// @Success 200 {object} common.SuccessResponse "Webhook deleted successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid webhook ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Webhook not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /webhooks/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	if err := h.service.DeleteWebhookSubscription(id); err != nil {
+		common.HandleError(c, err, "Failed to delete "+h.GetEntityName())
+		return
+	}
+
+	common.RespondWithSuccess(c, http.StatusOK, h.GetEntityName()+" deleted successfully")
+}
+
+// RotateSecret replaces the secret of a webhook subscription
+// @Summary Rotate a webhook secret
+// @Description Generate a new secret for a webhook. Requests are signed with it from now on; the old secret stops working immediately. Admins only.
+// @Tags webhooks
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Webhook ID" minimum(1)
+// @Success 200 {object} WebhookSecretDTO "Webhook with its new secret"
+// @Failure 400 {object} common.ErrorResponse "Invalid webhook ID"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Webhook not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /webhooks/{id}/rotate-secret [post]
+func (h *Handler) RotateSecret(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	entity, err := h.service.RotateWebhookSecret(id)
+	if err != nil {
+		common.HandleError(c, err, "Failed to rotate "+h.GetEntityName()+" secret")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.mapper.WithSecretFromDomain(entity))
+}
+
+// GetDeliveries retrieves a page of the delivery log of a webhook subscription
+// @Summary List webhook deliveries
+// @Description Retrieve a page of the attempts to post events to a webhook, one entry per attempt. Sortable fields: id, eventId, eventType, attempt, status, statusCode, createdAt. Admins only.
+// @Tags webhooks
+// @Produce json
+// @Security CognitoOAuth
+// @Security BearerAuth
+// @Param id path int true "Webhook ID" minimum(1)
+// @Param page query int false "Page number" default(1) minimum(1)
+// @Param pageSize query int false "Items per page" default(20) minimum(1) maximum(100)
+// @Param sort query string false "Sort field" default(id)
+// @Param order query string false "Sort order" Enums(asc, desc) default(asc)
+// @Param cursor query string false "Keyset cursor from the previous page (sorting by id only)"
+// @Param eventId query int false "ID of the event"
+// @Param eventType query string false "Event type" example(reservation.created)
+// @Param status query string false "Outcome of the attempt" Enums(succeeded, failed)
+// @Param from query string false "Attempts made at or after this time (RFC 3339)"
+// @Param to query string false "Attempts made at or before this time (RFC 3339)"
+// @Success 200 {object} types.PaginatedResponse[DeliveryDTO] "Page of deliveries"
+// @Failure 400 {object} common.ErrorResponse "Invalid webhook ID, filter, sort field or cursor"
+// @Failure 401 {object} common.ErrorResponse "Unauthorized"
+// @Failure 403 {object} common.ErrorResponse "Forbidden"
+// @Failure 404 {object} common.ErrorResponse "Webhook not found"
+// @Failure 500 {object} common.ErrorResponse "Internal server error"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *Handler) GetDeliveries(c *gin.Context) {
+	id, err := common.ParseIDFromPath(c, h.GetEntityName())
+	if err != nil {
+		return
+	}
+
+	query, params, ok := common.ParseListQuery(c, deliveryFilters...)
+	if !ok {
+		return
+	}
+
+	page, err := h.service.GetWebhookDeliveries(id, query)
+	if err != nil {
+		common.HandleError(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	dtos := make([]DeliveryDTO, len(page.Items))
+	for i, delivery := range page.Items {
+		dtos[i] = h.mapper.DeliveryFromDomain(&delivery)
+	}
+	c.JSON(http.StatusOK, types.NewPaginatedResponse(dtos, params, int(page.Total)).WithNextCursor(page.NextCursor))
+}
@@ -0,0 +1,100 @@
+package webhook
+
+import (
+	"sarc-ng/internal/domain/event"
+	"sarc-ng/internal/domain/webhook"
+)
+
+// Mapper handles conversions between domain entities and DTOs
+type Mapper struct{}
+
+// NewMapper creates a new webhook mapper
+func NewMapper() *Mapper {
+	return &Mapper{}
+}
+
+// FromDomain converts a domain entity to DTO
+func (m *Mapper) FromDomain(entity *webhook.Subscription) *WebhookDTO {
+	if entity == nil {
+		return nil
+	}
+	types := make([]string, len(entity.EventTypes))
+	for i, t := range entity.EventTypes {
+		types[i] = string(t)
+	}
+	return &WebhookDTO{
+		ID:          entity.ID,
+		URL:         entity.URL,
+		Description: entity.Description,
+		EventTypes:  types,
+		Active:      entity.Active,
+		CreatedBy:   entity.CreatedBy,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+// WithSecretFromDomain converts a domain entity to DTO including its secret
+func (m *Mapper) WithSecretFromDomain(entity *webhook.Subscription) *WebhookSecretDTO {
+	if entity == nil {
+		return nil
+	}
+	return &WebhookSecretDTO{WebhookDTO: *m.FromDomain(entity), Secret: entity.Secret}
+}
+
+// ToDomain converts a create DTO to domain entity
+func (m *Mapper) ToDomain(dto *CreateWebhookDTO) *webhook.Subscription {
+	if dto == nil {
+		return nil
+	}
+	active := true
+	if dto.Active != nil {
+		active = *dto.Active
+	}
+	return &webhook.Subscription{
+		URL:         dto.URL,
+		Description: dto.Description,
+		EventTypes:  eventTypes(dto.EventTypes),
+		Active:      active,
+	}
+}
+
+// ToDomainWithID converts an update DTO to domain entity with ID
+func (m *Mapper) ToDomainWithID(dto *UpdateWebhookDTO, id uint) *webhook.Subscription {
+	if dto == nil {
+		return nil
+	}
+	return &webhook.Subscription{
+		ID:          id,
+		URL:         dto.URL,
+		Description: dto.Description,
+		EventTypes:  eventTypes(dto.EventTypes),
+		Active:      dto.Active,
+	}
+}
+
+// DeliveryFromDomain converts a delivery attempt to DTO
+func (m *Mapper) DeliveryFromDomain(entity *webhook.Delivery) DeliveryDTO {
+	return DeliveryDTO{
+		ID:             entity.ID,
+		SubscriptionID: entity.SubscriptionID,
+		EventID:        entity.EventID,
+		EventType:      string(entity.EventType),
+		Attempt:        entity.Attempt,
+		Status:         string(entity.Status),
+		StatusCode:     entity.StatusCode,
+		ResponseBody:   entity.ResponseBody,
+		Error:          entity.Error,
+		DurationMs:     entity.Duration.Milliseconds(),
+		CreatedAt:      entity.CreatedAt,
+	}
+}
+
+// eventTypes converts event type names
+func eventTypes(names []string) []event.Type {
+	types := make([]event.Type, len(names))
+	for i, name := range names {
+		types[i] = event.Type(name)
+	}
+	return types
+}
@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"sarc-ng/internal/domain/auth"
+	"sarc-ng/internal/domain/webhook"
+	"sarc-ng/pkg/rest/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRoutes sets up the webhook subscription routes, which take the webhook:manage
+// permission
+func RegisterRoutes(rg *gin.RouterGroup, service webhook.Usecase, permissions auth.Permissions) {
+	handler := NewHandler(service)
+
+	webhooks := rg.Group("/webhooks", middleware.RequirePermission(permissions, auth.PermissionWebhookManage))
+	{
+		webhooks.GET("", handler.GetAll)
+		webhooks.GET("/:id", handler.GetByID)
+		webhooks.POST("", handler.Create)
+		webhooks.PUT("/:id", handler.Update)
+		webhooks.DELETE("/:id", handler.Delete)
+		webhooks.POST("/:id/rotate-secret", handler.RotateSecret)
+		webhooks.GET("/:id/deliveries", handler.GetDeliveries)
+	}
+}
@@ -0,0 +1,57 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@often"} {
+		_, err := Parse(spec)
+		assert.ErrorIs(t, err, ErrInvalidSchedule, spec)
+	}
+}
+
+func TestNext(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2026, 9, 2, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 9, 2, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 9, 2, 10, 15, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 9, 3, 3, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 9, 2, 11, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2026, 9, 3, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 9, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Either day field matches when both are restricted
+		{"0 12 15 * 5", time.Date(2026, 9, 4, 12, 0, 0, 0, time.UTC)},
+		{"0,30 8-9 * * *", time.Date(2026, 9, 3, 8, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			next := s.Next(from)
+			assert.Equal(t, tt.want, next)
+			assert.True(t, s.Matches(next))
+		})
+	}
+}
+
+func TestNextInLocation(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	s, err := Parse("@daily")
+	require.NoError(t, err)
+
+	next := s.Next(time.Date(2026, 9, 2, 10, 0, 0, 0, time.UTC).In(saoPaulo))
+	assert.Equal(t, time.Date(2026, 9, 3, 3, 0, 0, 0, time.UTC), next.UTC())
+}
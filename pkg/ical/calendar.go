@@ -0,0 +1,182 @@
+// Package ical reads and writes RFC 5545 iCalendar data
+package ical
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// EventStatus is the STATUS of a VEVENT
+type EventStatus string
+
+const (
+	// StatusTentative marks an event that is not confirmed yet
+	StatusTentative EventStatus = "TENTATIVE"
+	// StatusConfirmed marks a confirmed event
+	StatusConfirmed EventStatus = "CONFIRMED"
+	// StatusCancelled marks a cancelled event
+	StatusCancelled EventStatus = "CANCELLED"
+)
+
+const (
+	// maxLineOctets is the longest content line allowed before folding
+	maxLineOctets = 75
+
+	dateTimeLayout = "20060102T150405Z"
+)
+
+// Event is a single VEVENT. Times are written in UTC, which every client
+// converts to the viewer's time zone.
+type Event struct {
+	// UID must stay the same across feed refreshes so clients update instead of duplicate
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	Status       EventStatus
+	Created      time.Time
+	LastModified time.Time
+
+	// AllDay marks DATE values; Start and End are then midnights
+	AllDay bool
+	// RecurrenceRule is the RRULE value of a recurring event
+	RecurrenceRule string
+	ExceptionDates []time.Time
+	// RecurrenceID identifies the occurrence of a recurring event that this event overrides
+	RecurrenceID time.Time
+}
+
+// Calendar is a VCALENDAR published as a read-only feed
+type Calendar struct {
+	ProdID string
+	// Name is shown by clients as the calendar title (X-WR-CALNAME)
+	Name string
+	// RefreshInterval hints how often subscribed clients should poll
+	RefreshInterval time.Duration
+	// Timestamp is written as DTSTAMP; zero means the time of encoding
+	Timestamp time.Time
+	Events    []Event
+}
+
+// Encode writes the calendar in iCalendar format
+func (c *Calendar) Encode(w io.Writer) error {
+	stamp := c.Timestamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	lw := &lineWriter{w: w}
+	lw.line("BEGIN:VCALENDAR")
+	lw.line("VERSION:2.0")
+	lw.line("PRODID:" + c.ProdID)
+	lw.line("CALSCALE:GREGORIAN")
+	lw.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		lw.line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		interval := formatDuration(c.RefreshInterval)
+		lw.line("REFRESH-INTERVAL;VALUE=DURATION:" + interval)
+		lw.line("X-PUBLISHED-TTL:" + interval)
+	}
+
+	for _, event := range c.Events {
+		lw.line("BEGIN:VEVENT")
+		lw.line("UID:" + escapeText(event.UID))
+		lw.line("DTSTAMP:" + formatTime(stamp))
+		if event.AllDay {
+			lw.line("DTSTART;VALUE=DATE:" + event.Start.Format(dateLayout))
+			lw.line("DTEND;VALUE=DATE:" + event.End.Format(dateLayout))
+		} else {
+			lw.line("DTSTART:" + formatTime(event.Start))
+			lw.line("DTEND:" + formatTime(event.End))
+		}
+		if event.RecurrenceRule != "" {
+			lw.line("RRULE:" + event.RecurrenceRule)
+		}
+		for _, exdate := range event.ExceptionDates {
+			lw.line("EXDATE:" + formatTime(exdate))
+		}
+		if !event.RecurrenceID.IsZero() {
+			lw.line("RECURRENCE-ID:" + formatTime(event.RecurrenceID))
+		}
+		lw.line("SUMMARY:" + escapeText(event.Summary))
+		if event.Description != "" {
+			lw.line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		if event.Location != "" {
+			lw.line("LOCATION:" + escapeText(event.Location))
+		}
+		if event.Status != "" {
+			lw.line("STATUS:" + string(event.Status))
+		}
+		if !event.Created.IsZero() {
+			lw.line("CREATED:" + formatTime(event.Created))
+		}
+		if !event.LastModified.IsZero() {
+			lw.line("LAST-MODIFIED:" + formatTime(event.LastModified))
+		}
+		lw.line("END:VEVENT")
+	}
+
+	lw.line("END:VCALENDAR")
+	return lw.err
+}
+
+// lineWriter writes folded CRLF-terminated content lines, keeping the first error
+type lineWriter struct {
+	w   io.Writer
+	err error
+}
+
+// line folds a content line to 75 octets without splitting UTF-8 sequences
+func (lw *lineWriter) line(content string) {
+	if lw.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		b.WriteString(content[:cut])
+		b.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space that counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(content)
+	b.WriteString("\r\n")
+
+	_, lw.err = io.WriteString(lw.w, b.String())
+}
+
+// escapeText escapes a TEXT value as RFC 5545 section 3.3.11 requires
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(value)
+}
+
+// formatTime formats t as a UTC DATE-TIME
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
+
+// formatDuration formats d as a DURATION in whole minutes, e.g. PT90M
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("PT%dM", int(d.Minutes()))
+}
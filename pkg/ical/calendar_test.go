@@ -0,0 +1,71 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	cal := Calendar{
+		ProdID:          "-//SARC-NG//Calendar//EN",
+		Name:            "Lab 1",
+		RefreshInterval: time.Hour,
+		Timestamp:       time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC),
+		Events: []Event{{
+			UID:         "reservation-7@sarc-ng",
+			Summary:     "Thesis defense; room, projector",
+			Description: "Line one\nLine two",
+			Start:       time.Date(2026, 9, 8, 14, 0, 0, 0, saoPaulo),
+			End:         time.Date(2026, 9, 8, 16, 0, 0, 0, saoPaulo),
+			Status:      StatusCancelled,
+		}},
+	}
+
+	var b strings.Builder
+	require.NoError(t, cal.Encode(&b))
+
+	assert.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//SARC-NG//Calendar//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Lab 1",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT60M",
+		"X-PUBLISHED-TTL:PT60M",
+		"BEGIN:VEVENT",
+		"UID:reservation-7@sarc-ng",
+		"DTSTAMP:20260901T120000Z",
+		"DTSTART:20260908T170000Z",
+		"DTEND:20260908T190000Z",
+		`SUMMARY:Thesis defense\; room\, projector`,
+		`DESCRIPTION:Line one\nLine two`,
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n"), b.String())
+}
+
+func TestLineFolding(t *testing.T) {
+	var b strings.Builder
+	lw := &lineWriter{w: &b}
+	lw.line("SUMMARY:" + strings.Repeat("á", 60))
+	require.NoError(t, lw.err)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+		assert.True(t, strings.ToValidUTF8(line, "?") == line, "fold must not split a UTF-8 sequence")
+	}
+	assert.True(t, strings.HasPrefix(lines[1], " "))
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("á", 60), lines[0]+strings.TrimPrefix(lines[1], " "))
+}
@@ -0,0 +1,375 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMalformed indicates that iCalendar data could not be decoded
+var ErrMalformed = errors.New("malformed iCalendar data")
+
+const (
+	dateLayout          = "20060102"
+	floatingTimeLayout  = "20060102T150405"
+	maxContentLineBytes = 1 << 20
+)
+
+// Decode parses iCalendar data and returns the events of its first VCALENDAR.
+// Floating times, which carry neither a UTC marker nor a TZID, are read in loc;
+// nil means UTC. Time zones are resolved by their IANA TZID, so VTIMEZONE
+// definitions are not needed and are skipped.
+func Decode(r io.Reader, loc *time.Location) (*Calendar, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	d := decoder{loc: loc}
+	for _, l := range lines {
+		if err := d.property(l); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrMalformed, l.number, err)
+		}
+		if d.done {
+			return &d.cal, nil
+		}
+	}
+
+	if !d.started {
+		return nil, fmt.Errorf("%w: no VCALENDAR found", ErrMalformed)
+	}
+	return nil, fmt.Errorf("%w: missing END:VCALENDAR", ErrMalformed)
+}
+
+// contentLine is an unfolded content line with the number of its first physical line
+type contentLine struct {
+	number int
+	text   string
+}
+
+// unfold joins folded lines, which continue with a leading space or tab
+func unfold(r io.Reader) ([]contentLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxContentLineBytes)
+
+	var lines []contentLine
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		if (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, contentLine{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read iCalendar data: %w", err)
+	}
+	return lines, nil
+}
+
+// decoder tracks the component being decoded
+type decoder struct {
+	loc     *time.Location
+	cal     Calendar
+	started bool
+	done    bool
+
+	// event is the VEVENT being decoded, if any
+	event *Event
+	// nested counts components open inside the calendar other than VEVENT,
+	// such as VTIMEZONE or a VALARM inside an event
+	nested int
+
+	// DTEND and DURATION may appear before DTSTART, so the end is resolved last
+	hasEnd      bool
+	hasDuration bool
+	duration    time.Duration
+}
+
+// property applies one content line to the decoder state
+func (d *decoder) property(l contentLine) error {
+	name, params, value, err := splitContentLine(l.text)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR") && !d.started:
+		d.started = true
+		return nil
+	case !d.started:
+		return fmt.Errorf("expected BEGIN:VCALENDAR, got %s", name)
+	case name == "BEGIN":
+		if d.nested == 0 && d.event == nil && strings.EqualFold(value, "VEVENT") {
+			d.event = &Event{}
+			d.hasEnd, d.hasDuration = false, false
+			return nil
+		}
+		d.nested++
+		return nil
+	case name == "END" && d.nested > 0:
+		d.nested--
+		return nil
+	case name == "END" && d.event != nil:
+		if !strings.EqualFold(value, "VEVENT") {
+			return fmt.Errorf("unexpected END:%s inside VEVENT", value)
+		}
+		return d.endEvent()
+	case name == "END":
+		if !strings.EqualFold(value, "VCALENDAR") {
+			return fmt.Errorf("unexpected END:%s", value)
+		}
+		d.done = true
+		return nil
+	case d.nested > 0:
+		return nil
+	case d.event != nil:
+		return d.eventProperty(name, params, value)
+	}
+
+	switch name {
+	case "PRODID":
+		d.cal.ProdID = value
+	case "X-WR-CALNAME":
+		d.cal.Name = unescapeText(value)
+	}
+	return nil
+}
+
+// eventProperty applies a VEVENT property
+func (d *decoder) eventProperty(name string, params map[string]string, value string) error {
+	e := d.event
+	var err error
+
+	switch name {
+	case "UID":
+		e.UID = value
+	case "SUMMARY":
+		e.Summary = unescapeText(value)
+	case "DESCRIPTION":
+		e.Description = unescapeText(value)
+	case "LOCATION":
+		e.Location = unescapeText(value)
+	case "STATUS":
+		e.Status = EventStatus(strings.ToUpper(value))
+	case "DTSTART":
+		e.Start, e.AllDay, err = d.parseTime(value, params)
+	case "DTEND":
+		e.End, _, err = d.parseTime(value, params)
+		d.hasEnd = true
+	case "DURATION":
+		d.duration, err = parseDuration(value)
+		d.hasDuration = err == nil
+	case "RRULE":
+		e.RecurrenceRule = value
+	case "EXDATE":
+		for _, v := range strings.Split(value, ",") {
+			exdate, _, perr := d.parseTime(v, params)
+			if perr != nil {
+				return perr
+			}
+			e.ExceptionDates = append(e.ExceptionDates, exdate)
+		}
+	case "RECURRENCE-ID":
+		e.RecurrenceID, _, err = d.parseTime(value, params)
+	case "CREATED":
+		e.Created, _, err = d.parseTime(value, params)
+	case "LAST-MODIFIED":
+		e.LastModified, _, err = d.parseTime(value, params)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// endEvent validates the finished VEVENT and adds it to the calendar
+func (d *decoder) endEvent() error {
+	e := d.event
+	d.event = nil
+
+	if e.UID == "" {
+		return errors.New("VEVENT without UID")
+	}
+	if e.Start.IsZero() {
+		return fmt.Errorf("VEVENT %s without DTSTART", e.UID)
+	}
+
+	switch {
+	case d.hasEnd:
+	case d.hasDuration:
+		e.End = e.Start.Add(d.duration)
+	case e.AllDay:
+		e.End = e.Start.AddDate(0, 0, 1)
+	default:
+		e.End = e.Start
+	}
+
+	d.cal.Events = append(d.cal.Events, *e)
+	return nil
+}
+
+// parseTime parses a DATE or DATE-TIME value honouring VALUE and TZID parameters
+func (d *decoder) parseTime(value string, params map[string]string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, d.loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return t, false, nil
+	}
+
+	loc := d.loc
+	if tzid := params["TZID"]; tzid != "" {
+		var err error
+		// A leading slash marks a globally unique TZID, which is an IANA name in practice
+		if loc, err = time.LoadLocation(strings.TrimPrefix(tzid, "/")); err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+	}
+
+	t, err := time.ParseInLocation(floatingTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+	}
+	return t, false, nil
+}
+
+// splitContentLine splits "NAME;PARAM=VALUE:value" into its parts.
+// Names and parameter names are upper-cased; quoted parameter values may contain ':' and ';'.
+func splitContentLine(line string) (string, map[string]string, string, error) {
+	params := make(map[string]string)
+
+	end := strings.IndexAny(line, ";:")
+	if end <= 0 {
+		return "", nil, "", fmt.Errorf("invalid content line %q", line)
+	}
+	name := strings.ToUpper(line[:end])
+
+	rest := line[end:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return "", nil, "", fmt.Errorf("invalid parameter in %s", name)
+		}
+		paramName := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var paramValue string
+		if strings.HasPrefix(rest, `"`) {
+			closing := strings.IndexByte(rest[1:], '"')
+			if closing < 0 {
+				return "", nil, "", fmt.Errorf("unterminated quoted parameter in %s", name)
+			}
+			paramValue = rest[1 : closing+1]
+			rest = rest[closing+2:]
+		} else {
+			stop := strings.IndexAny(rest, ";:")
+			if stop < 0 {
+				return "", nil, "", fmt.Errorf("missing value in %s", name)
+			}
+			paramValue = rest[:stop]
+			rest = rest[stop:]
+		}
+		params[paramName] = paramValue
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return "", nil, "", fmt.Errorf("missing value in %s", name)
+	}
+	return name, params, rest[1:], nil
+}
+
+// unescapeText reverses the TEXT escaping of RFC 5545 section 3.3.11
+func unescapeText(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// parseDuration parses a DURATION value such as PT1H30M, P1D or -PT15M
+func parseDuration(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid duration %q", value)
+
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, invalid
+	}
+	value = value[1:]
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var total time.Duration
+	number := ""
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+		case c == 'T':
+			if number != "" {
+				return 0, invalid
+			}
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+		default:
+			unit, ok := units[c]
+			if !ok || number == "" {
+				return 0, invalid
+			}
+			n, err := strconv.Atoi(number)
+			if err != nil {
+				return 0, invalid
+			}
+			total += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, invalid
+	}
+	return sign * total, nil
+}
@@ -0,0 +1,126 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//Timetabler//EN",
+		"X-WR-CALNAME:Term 2026/2",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/Sao_Paulo",
+		"BEGIN:STANDARD",
+		"DTSTART:19700101T000000",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:algorithms@timetabler",
+		"SUMMARY:Algorithms\\, group A",
+		"DESCRIPTION:Bring a laptop\\nRoom may ",
+		" change",
+		"DTSTART;TZID=America/Sao_Paulo:20260804T140000",
+		"DURATION:PT1H40M",
+		"RRULE:FREQ=WEEKLY;COUNT=3",
+		"EXDATE;TZID=America/Sao_Paulo:20260811T140000",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"DESCRIPTION:Reminder",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:algorithms@timetabler",
+		"RECURRENCE-ID;TZID=America/Sao_Paulo:20260818T140000",
+		"SUMMARY:Algorithms (exam)",
+		"DTSTART:20260818T190000Z",
+		"DTEND:20260818T210000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:holiday@timetabler",
+		"SUMMARY:Holiday",
+		"DTSTART;VALUE=DATE:20260907",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	cal, err := Decode(strings.NewReader(data), saoPaulo)
+	require.NoError(t, err)
+	assert.Equal(t, "Term 2026/2", cal.Name)
+	require.Len(t, cal.Events, 3)
+
+	lecture := cal.Events[0]
+	assert.Equal(t, "Algorithms, group A", lecture.Summary)
+	assert.Equal(t, "Bring a laptop\nRoom may change", lecture.Description)
+	assert.True(t, lecture.Start.Equal(time.Date(2026, 8, 4, 14, 0, 0, 0, saoPaulo)))
+	assert.Equal(t, 100*time.Minute, lecture.End.Sub(lecture.Start))
+
+	starts, err := lecture.Occurrences(10)
+	require.NoError(t, err)
+	require.Len(t, starts, 2)
+	assert.True(t, starts[1].Equal(time.Date(2026, 8, 18, 14, 0, 0, 0, saoPaulo)))
+
+	exam := cal.Events[1]
+	assert.True(t, exam.RecurrenceID.Equal(starts[1]))
+	assert.Equal(t, 2*time.Hour, exam.End.Sub(exam.Start))
+
+	holiday := cal.Events[2]
+	assert.True(t, holiday.AllDay)
+	assert.Equal(t, StatusCancelled, holiday.Status)
+	assert.Equal(t, 24*time.Hour, holiday.End.Sub(holiday.Start))
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	tests := map[string]string{
+		"No calendar":       "BEGIN:VEVENT\r\nEND:VEVENT\r\n",
+		"Unterminated":      "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\n",
+		"Missing DTSTART":   "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"Unknown time zone": "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nDTSTART;TZID=Nowhere:20260101T100000\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"Bad duration":      "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nDTSTART:20260101T100000Z\r\nDURATION:1H\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(data), nil)
+			assert.ErrorIs(t, err, ErrMalformed)
+		})
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	start := time.Date(2026, 9, 8, 17, 0, 0, 0, time.UTC)
+	original := Calendar{
+		ProdID: "-//SARC-NG//Calendar//EN",
+		Events: []Event{{
+			UID:            "reservation-1@sarc-ng",
+			Summary:        "Lab; weekly, with notes\\",
+			Start:          start,
+			End:            start.Add(2 * time.Hour),
+			Status:         StatusTentative,
+			RecurrenceRule: "FREQ=WEEKLY;COUNT=4",
+			ExceptionDates: []time.Time{start.AddDate(0, 0, 7)},
+		}},
+	}
+
+	var b strings.Builder
+	require.NoError(t, original.Encode(&b))
+
+	decoded, err := Decode(strings.NewReader(b.String()), nil)
+	require.NoError(t, err)
+	require.Len(t, decoded.Events, 1)
+
+	event := decoded.Events[0]
+	assert.Equal(t, original.Events[0].Summary, event.Summary)
+	assert.Equal(t, original.Events[0].RecurrenceRule, event.RecurrenceRule)
+	assert.Equal(t, original.Events[0].ExceptionDates, event.ExceptionDates)
+	assert.True(t, event.Start.Equal(start))
+	assert.Equal(t, StatusTentative, event.Status)
+}
@@ -0,0 +1,30 @@
+package ical
+
+import (
+	"fmt"
+	"sarc-ng/pkg/recurrence"
+	"time"
+)
+
+// IsRecurring checks if the event has a recurrence rule
+func (e *Event) IsRecurring() bool {
+	return e.RecurrenceRule != ""
+}
+
+// Occurrences returns the start times of the event, expanding its RRULE and
+// leaving out EXDATEs. Expansion fails once more than max occurrences would be
+// produced, so unbounded rules always fail.
+func (e *Event) Occurrences(max int) ([]time.Time, error) {
+	if !e.IsRecurring() {
+		return []time.Time{e.Start}, nil
+	}
+
+	rule, err := recurrence.Parse(e.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	if !rule.IsBounded() {
+		return nil, fmt.Errorf("%w: rule must end with COUNT or UNTIL", recurrence.ErrTooManyOccurrences)
+	}
+	return rule.Expand(e.Start, e.ExceptionDates, max)
+}
@@ -0,0 +1,146 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// callbackPath is where the provider redirects the browser back to
+const callbackPath = "/callback"
+
+// callbackPage is shown in the browser once the provider redirected back
+const callbackPage = `<!DOCTYPE html>
+<html><head><title>SARC</title></head>
+<body><p>%s You can close this window and return to the terminal.</p></body></html>
+`
+
+// callbackResult is the outcome of the redirect back from the provider
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// LoginWithBrowser signs the user in with the authorization code flow with
+// PKCE. The authorization URL is passed to open, which should show it in a
+// browser. The provider redirects back to http://127.0.0.1:<port>/callback,
+// which the client must accept as a redirect URI; providers that do not
+// allow any port need CallbackPort set.
+func (p *Provider) LoginWithBrowser(ctx context.Context, open func(authURL string) error) (*Token, error) {
+	endpoints, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if endpoints.Authorization == "" {
+		return nil, fmt.Errorf("OIDC configuration of %s has no authorization_endpoint", p.Issuer)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", p.CallbackPort))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the redirect: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr(), callbackPath)
+
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authURL, err := url.Parse(endpoints.Authorization)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", p.scope())
+	query.Set("state", state)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	results := make(chan callbackResult, 1)
+	server := &http.Server{
+		Handler:           callbackHandler(state, results),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	if err := open(authURL.String()); err != nil {
+		return nil, err
+	}
+
+	var result callbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	return p.exchange(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {result.code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	})
+}
+
+// callbackHandler takes the code from the redirect back from the provider.
+// Redirects without the state of this sign-in are ignored, as anyone may
+// send the browser there.
+func callbackHandler(state string, results chan<- callbackResult) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			http.Error(w, "Unknown sign-in", http.StatusBadRequest)
+			return
+		}
+
+		var result callbackResult
+		if code := query.Get("error"); code != "" {
+			result.err = &Error{Code: code, Description: query.Get("error_description")}
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, callbackPage, "Signing in failed.")
+		} else if result.code = query.Get("code"); result.code == "" {
+			result.err = errors.New("redirect has no authorization code")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, callbackPage, "Signing in failed.")
+		} else {
+			fmt.Fprintf(w, callbackPage, "Signed in.")
+		}
+
+		select {
+		case results <- result:
+		default: // a repeated redirect; the first one counts
+		}
+	})
+	return mux
+}
+
+// randomString returns 32 random bytes, encoded for use in URLs
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,118 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// defaultPollInterval is how often the token endpoint is polled unless the
+// provider says otherwise
+const defaultPollInterval = 5 * time.Second
+
+// DeviceCode is what the user needs to approve a sign-in on another device
+type DeviceCode struct {
+	UserCode                string
+	VerificationURI         string
+	VerificationURIComplete string // includes the user code; may be empty
+	ExpiresAt               time.Time
+}
+
+// deviceResponse is the response of the device authorization endpoint
+type deviceResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURL         string `json:"verification_url"` // used by some providers instead
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+// LoginWithDevice signs the user in with the device flow. The code is passed
+// to prompt, which should tell the user where to enter it; the token
+// endpoint is then polled until the user approved or denied the sign-in or
+// the code expired.
+func (p *Provider) LoginWithDevice(ctx context.Context, prompt func(code DeviceCode)) (*Token, error) {
+	endpoints, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if endpoints.DeviceAuthorization == "" {
+		return nil, fmt.Errorf("OIDC configuration of %s has no device_authorization_endpoint", p.Issuer)
+	}
+
+	resp, err := p.postForm(ctx, endpoints.DeviceAuthorization, url.Values{"scope": {p.scope()}})
+	if err != nil {
+		return nil, err
+	}
+	var device struct {
+		Error
+		deviceResponse
+	}
+	decodeErr := decodeJSON(resp, &device)
+	resp.Body.Close()
+	if device.Code != "" {
+		return nil, &device.Error
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device authorization endpoint returned status code %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to read device authorization response: %w", decodeErr)
+	}
+	if device.DeviceCode == "" || device.UserCode == "" {
+		return nil, errors.New("device authorization response has no code")
+	}
+
+	code := DeviceCode{
+		UserCode:                device.UserCode,
+		VerificationURI:         device.VerificationURI,
+		VerificationURIComplete: device.VerificationURIComplete,
+		ExpiresAt:               time.Now().Add(time.Duration(device.ExpiresIn) * time.Second),
+	}
+	if code.VerificationURI == "" {
+		code.VerificationURI = device.VerificationURL
+	}
+	if device.ExpiresIn <= 0 {
+		code.ExpiresAt = time.Now().Add(10 * time.Minute)
+	}
+	prompt(code)
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ctx, cancel := context.WithDeadline(ctx, code.ExpiresAt)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, errors.New("the code expired before the sign-in was approved")
+			}
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		token, err := p.exchange(ctx, url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {device.DeviceCode},
+		})
+		var oauthErr *Error
+		if !errors.As(err, &oauthErr) {
+			return token, err
+		}
+		switch oauthErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+	}
+}
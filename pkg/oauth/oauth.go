@@ -0,0 +1,270 @@
+// Package oauth signs command-line users in with an OpenID Connect provider
+// and keeps their tokens.
+//
+// Users sign in with the authorization code flow with PKCE, in a browser that
+// redirects back to a listener on the loopback interface, or with the device
+// flow on machines without a browser. Both need a public client, one without
+// a secret. The tokens are cached in a credentials file only the user may
+// read, and the access token is renewed with the refresh token when it
+// expires.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultScopes are requested unless the provider names others. The
+// offline_access scope asks for a refresh token.
+var DefaultScopes = []string{"openid", "profile", "email", "offline_access"}
+
+// expiryLeeway renews access tokens a little before they expire, so they do
+// not expire on their way to the API
+const expiryLeeway = 30 * time.Second
+
+// ErrLoginRequired is returned when there are no tokens, or they can no
+// longer be renewed
+var ErrLoginRequired = errors.New("not signed in")
+
+// Error is an error response of the provider
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return "oauth: " + e.Code
+	}
+	return fmt.Sprintf("oauth: %s: %s", e.Code, e.Description)
+}
+
+// Token holds the tokens issued when a user signs in
+type Token struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken,omitempty"`
+	IDToken      string    `json:"idToken,omitempty"`
+	ExpiresAt    time.Time `json:"expiresAt,omitempty"` // zero when the provider did not say
+}
+
+// Valid checks if the access token may still be used at the time
+func (t *Token) Valid(now time.Time) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.ExpiresAt.IsZero() || now.Add(expiryLeeway).Before(t.ExpiresAt)
+}
+
+// Provider is an OpenID Connect provider users sign in with, through the
+// public client ClientID. Its endpoints are found through its discovery
+// document.
+type Provider struct {
+	Issuer       string
+	ClientID     string
+	Scopes       []string // defaults to DefaultScopes
+	CallbackPort int      // loopback port of the browser flow; 0 picks a free one
+	HTTPClient   *http.Client
+
+	mutex     sync.Mutex
+	endpoints *endpoints
+}
+
+// endpoints are the parts of the discovery document the flows use
+type endpoints struct {
+	Issuer              string `json:"issuer"`
+	Authorization       string `json:"authorization_endpoint"`
+	Token               string `json:"token_endpoint"`
+	DeviceAuthorization string `json:"device_authorization_endpoint"`
+	Revocation          string `json:"revocation_endpoint"`
+}
+
+// tokenResponse is a successful or failed response of the token endpoint
+type tokenResponse struct {
+	Error
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// Refresh renews the tokens with a refresh token. The refresh token is kept
+// when the provider does not rotate it. Rejected refresh tokens return
+// ErrLoginRequired.
+func (p *Provider) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	if refreshToken == "" {
+		return nil, fmt.Errorf("%w: no refresh token", ErrLoginRequired)
+	}
+
+	token, err := p.exchange(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		var oauthErr *Error
+		if errors.As(err, &oauthErr) && oauthErr.Code == "invalid_grant" {
+			return nil, fmt.Errorf("%w: %v", ErrLoginRequired, err)
+		}
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, nil
+}
+
+// Revoke revokes a refresh token, which providers also revoke the access
+// tokens issued with. Providers without a revocation endpoint are not asked.
+func (p *Provider) Revoke(ctx context.Context, refreshToken string) error {
+	endpoints, err := p.discover(ctx)
+	if err != nil {
+		return err
+	}
+	if endpoints.Revocation == "" || refreshToken == "" {
+		return nil
+	}
+
+	resp, err := p.postForm(ctx, endpoints.Revocation, url.Values{
+		"token":           {refreshToken},
+		"token_type_hint": {"refresh_token"},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to revoke token: status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// exchange asks the token endpoint for tokens
+func (p *Provider) exchange(ctx context.Context, form url.Values) (*Token, error) {
+	endpoints, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.postForm(ctx, endpoints.Token, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body tokenResponse
+	decodeErr := decodeJSON(resp, &body)
+	if body.Code != "" {
+		return nil, &body.Error
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned status code %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", decodeErr)
+	}
+	if body.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+	if body.TokenType != "" && !strings.EqualFold(body.TokenType, "bearer") {
+		return nil, fmt.Errorf("unsupported token type %s", body.TokenType)
+	}
+
+	token := &Token{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		IDToken:      body.IDToken,
+	}
+	if body.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// discover fetches the endpoints of the provider unless known
+func (p *Provider) discover(ctx context.Context) (*endpoints, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.endpoints != nil {
+		return p.endpoints, nil
+	}
+	if p.Issuer == "" || p.ClientID == "" {
+		return nil, errors.New("issuer and client ID are required")
+	}
+
+	discoveryURL := strings.TrimSuffix(p.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC configuration of %s: %w", p.Issuer, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to discover OIDC configuration of %s: status code %d", p.Issuer, resp.StatusCode)
+	}
+	var discovered endpoints
+	if err := decodeJSON(resp, &discovered); err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC configuration of %s: %w", p.Issuer, err)
+	}
+	if discovered.Issuer != p.Issuer {
+		return nil, fmt.Errorf("OIDC configuration of %s is for issuer %s", p.Issuer, discovered.Issuer)
+	}
+	if discovered.Token == "" {
+		return nil, fmt.Errorf("OIDC configuration of %s has no token_endpoint", p.Issuer)
+	}
+
+	p.endpoints = &discovered
+	return p.endpoints, nil
+}
+
+// postForm posts a form as the client
+func (p *Provider) postForm(ctx context.Context, endpoint string, form url.Values) (*http.Response, error) {
+	form.Set("client_id", p.ClientID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// scope returns the requested scopes as a scope parameter
+func (p *Provider) scope() string {
+	if len(p.Scopes) == 0 {
+		return strings.Join(DefaultScopes, " ")
+	}
+	return strings.Join(p.Scopes, " ")
+}
+
+func (p *Provider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return &http.Client{Timeout: 30 * time.Second}
+}
+
+// decodeJSON decodes a response body of at most 1 MiB
+func decodeJSON(resp *http.Response, target any) error {
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(target)
+}
@@ -0,0 +1,242 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProvider is a stand-in OpenID Connect provider with a public client
+type testProvider struct {
+	URL string
+
+	mutex         sync.Mutex
+	challenge     string // of the last authorization request
+	refreshTokens map[string]bool
+	issued        int
+	pendingPolls  atomic.Int32 // device polls answered with authorization_pending
+	revoked       []string
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	provider := &testProvider{refreshTokens: make(map[string]bool)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        provider.URL,
+			"authorization_endpoint":        provider.URL + "/auth",
+			"token_endpoint":                provider.URL + "/token",
+			"device_authorization_endpoint": provider.URL + "/device",
+			"revocation_endpoint":           provider.URL + "/revoke",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":      "device-1",
+			"user_code":        "WDJB-MJHT",
+			"verification_uri": provider.URL + "/activate",
+			"expires_in":       60,
+			"interval":         1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "sarc-cli", r.PostForm.Get("client_id"))
+		provider.token(w, r.PostForm)
+	})
+	mux.HandleFunc("/revoke", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		provider.mutex.Lock()
+		defer provider.mutex.Unlock()
+		provider.revoked = append(provider.revoked, r.PostForm.Get("token"))
+		delete(provider.refreshTokens, r.PostForm.Get("token"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	provider.URL = server.URL
+	return provider
+}
+
+// token answers the token endpoint for each grant type
+func (p *testProvider) token(w http.ResponseWriter, form url.Values) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	fail := func(code string) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+	}
+	switch form.Get("grant_type") {
+	case "authorization_code":
+		sum := sha256.Sum256([]byte(form.Get("code_verifier")))
+		if form.Get("code") != "code-1" || base64.RawURLEncoding.EncodeToString(sum[:]) != p.challenge {
+			fail("invalid_grant")
+			return
+		}
+	case "urn:ietf:params:oauth:grant-type:device_code":
+		if p.pendingPolls.Add(-1) >= 0 {
+			fail("authorization_pending")
+			return
+		}
+	case "refresh_token":
+		if !p.refreshTokens[form.Get("refresh_token")] {
+			fail("invalid_grant")
+			return
+		}
+		delete(p.refreshTokens, form.Get("refresh_token"))
+	default:
+		fail("unsupported_grant_type")
+		return
+	}
+
+	p.issued++
+	refreshToken := "refresh-" + strconv.Itoa(p.issued)
+	p.refreshTokens[refreshToken] = true
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token":  "access-" + strconv.Itoa(p.issued),
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    300,
+	})
+}
+
+func (p *testProvider) client() *Provider {
+	return &Provider{Issuer: p.URL, ClientID: "sarc-cli"}
+}
+
+func TestLoginWithBrowser(t *testing.T) {
+	provider := newTestProvider(t)
+	ctx := context.Background()
+
+	token, err := provider.client().LoginWithBrowser(ctx, func(authURL string) error {
+		parsed, err := url.Parse(authURL)
+		require.NoError(t, err)
+		query := parsed.Query()
+		assert.Equal(t, provider.URL+"/auth", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+		assert.Equal(t, "code", query.Get("response_type"))
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+		assert.Equal(t, "openid profile email offline_access", query.Get("scope"))
+
+		provider.mutex.Lock()
+		provider.challenge = query.Get("code_challenge")
+		provider.mutex.Unlock()
+
+		// The browser is sent back, first by someone else without the state
+		resp, err := http.Get(query.Get("redirect_uri") + "?code=forged&state=other")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp, err = http.Get(query.Get("redirect_uri") + "?code=code-1&state=" + url.QueryEscape(query.Get("state")))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", token.AccessToken)
+	assert.Equal(t, "refresh-1", token.RefreshToken)
+	assert.True(t, token.Valid(time.Now()))
+	assert.False(t, token.Valid(time.Now().Add(5*time.Minute)))
+
+	_, err = provider.client().LoginWithBrowser(ctx, func(authURL string) error {
+		parsed, _ := url.Parse(authURL)
+		query := parsed.Query()
+		resp, err := http.Get(query.Get("redirect_uri") + "?error=access_denied&state=" + url.QueryEscape(query.Get("state")))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return nil
+	})
+	var oauthErr *Error
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, "access_denied", oauthErr.Code)
+}
+
+func TestLoginWithDevice(t *testing.T) {
+	provider := newTestProvider(t)
+	provider.pendingPolls.Store(1)
+
+	var shown DeviceCode
+	token, err := provider.client().LoginWithDevice(context.Background(), func(code DeviceCode) {
+		shown = code
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "WDJB-MJHT", shown.UserCode)
+	assert.Equal(t, provider.URL+"/activate", shown.VerificationURI)
+	assert.Equal(t, "access-1", token.AccessToken)
+	assert.Equal(t, int32(-1), provider.pendingPolls.Load(), "polled until approved")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	provider.pendingPolls.Store(100)
+	_, err = provider.client().LoginWithDevice(ctx, func(DeviceCode) { cancel() })
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTokenSourceRenewsStoredTokens(t *testing.T) {
+	provider := newTestProvider(t)
+	store := &Store{Path: filepath.Join(t.TempDir(), ".sarc", "credentials")}
+	const apiURL = "http://localhost:8080"
+
+	_, err := NewTokenSource(store, apiURL)
+	assert.ErrorIs(t, err, ErrLoginRequired)
+
+	token, err := provider.client().Refresh(context.Background(), "unknown")
+	assert.ErrorIs(t, err, ErrLoginRequired)
+	assert.Nil(t, token)
+
+	provider.refreshTokens["refresh-0"] = true
+	require.NoError(t, store.Save(apiURL, &Credentials{
+		Issuer:   provider.URL,
+		ClientID: "sarc-cli",
+		Token:    Token{AccessToken: "access-0", RefreshToken: "refresh-0", ExpiresAt: time.Now().Add(-time.Minute)},
+	}))
+	require.NoError(t, store.Save("https://sarc.example.com", &Credentials{Issuer: "https://id.example.com", ClientID: "other"}))
+
+	info, err := os.Stat(store.Path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "only the user may read the credentials")
+
+	source, err := NewTokenSource(store, apiURL)
+	require.NoError(t, err)
+	access, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", access, "expired tokens are renewed")
+	access, err = source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access-1", access)
+
+	stored, err := store.Load(apiURL)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-1", stored.RefreshToken, "rotated refresh tokens are stored")
+
+	access, err = source.Refresh()
+	require.NoError(t, err)
+	assert.Equal(t, "access-2", access)
+
+	require.NoError(t, stored.Provider().Revoke(context.Background(), "refresh-2"))
+	assert.Equal(t, []string{"refresh-2"}, provider.revoked)
+	_, err = source.Refresh()
+	assert.ErrorIs(t, err, ErrLoginRequired, "revoked sessions need a new sign-in")
+
+	require.NoError(t, store.Delete(apiURL))
+	_, err = store.Load(apiURL)
+	assert.ErrorIs(t, err, ErrLoginRequired)
+	_, err = store.Load("https://sarc.example.com")
+	assert.NoError(t, err, "credentials of other APIs are kept")
+}
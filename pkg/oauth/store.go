@@ -0,0 +1,178 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Credentials are the tokens of a user signed in to an API, with the
+// provider and client they were issued by, so they can be renewed
+type Credentials struct {
+	Issuer   string   `json:"issuer"`
+	ClientID string   `json:"clientId"`
+	Scopes   []string `json:"scopes,omitempty"`
+	Token
+}
+
+// Provider returns the provider that issued the credentials
+func (c *Credentials) Provider() *Provider {
+	return &Provider{Issuer: c.Issuer, ClientID: c.ClientID, Scopes: c.Scopes}
+}
+
+// Store keeps the credentials of each API URL in a JSON file only the user
+// may read
+type Store struct {
+	Path string
+}
+
+// DefaultStore returns the store in ~/.sarc/credentials
+func DefaultStore() (*Store, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return &Store{Path: filepath.Join(home, ".sarc", "credentials")}, nil
+}
+
+// Load returns the credentials for the API, or ErrLoginRequired when there
+// are none
+func (s *Store) Load(apiURL string) (*Credentials, error) {
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	creds, ok := all[apiURL]
+	if !ok {
+		return nil, ErrLoginRequired
+	}
+	return creds, nil
+}
+
+// Save stores the credentials for the API, replacing any before
+func (s *Store) Save(apiURL string, creds *Credentials) error {
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	all[apiURL] = creds
+	return s.write(all)
+}
+
+// Delete removes the credentials for the API
+func (s *Store) Delete(apiURL string) error {
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[apiURL]; !ok {
+		return nil
+	}
+	delete(all, apiURL)
+	return s.write(all)
+}
+
+func (s *Store) read() (map[string]*Credentials, error) {
+	all := make(map[string]*Credentials)
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials in %s: %w", s.Path, err)
+	}
+	return all, nil
+}
+
+// write replaces the file at once, so a failed write does not lose the
+// credentials of other APIs
+func (s *Store) write(all map[string]*Credentials) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+	file, err := os.CreateTemp(dir, ".credentials-*")
+	if err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	if err := os.Rename(file.Name(), s.Path); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	return nil
+}
+
+// TokenSource hands out the access token stored for an API, renewing it
+// with the refresh token when it expired and storing the renewed tokens
+type TokenSource struct {
+	store  *Store
+	apiURL string
+
+	mutex    sync.Mutex
+	creds    *Credentials
+	provider *Provider
+}
+
+// NewTokenSource returns a source of the tokens stored for the API, or
+// ErrLoginRequired when there are none
+func NewTokenSource(store *Store, apiURL string) (*TokenSource, error) {
+	creds, err := store.Load(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenSource{store: store, apiURL: apiURL, creds: creds, provider: creds.Provider()}, nil
+}
+
+// Token returns the access token, renewing it first when it expired
+func (s *TokenSource) Token() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.creds.Valid(time.Now()) {
+		return s.creds.AccessToken, nil
+	}
+	return s.refresh()
+}
+
+// Refresh renews the access token although it has not expired, such as
+// after the API refused it
+func (s *TokenSource) Refresh() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.refresh()
+}
+
+func (s *TokenSource) refresh() (string, error) {
+	token, err := s.provider.Refresh(context.Background(), s.creds.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	s.creds.Token = *token
+	if err := s.store.Save(s.apiURL, s.creds); err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
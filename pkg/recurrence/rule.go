@@ -0,0 +1,344 @@
+// Package recurrence parses and expands RFC 5545 recurrence rules (RRULE)
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidRule indicates that a recurrence rule could not be parsed
+	ErrInvalidRule = errors.New("invalid recurrence rule")
+
+	// ErrTooManyOccurrences indicates that a rule expands past the allowed limit
+	ErrTooManyOccurrences = errors.New("recurrence rule produces too many occurrences")
+)
+
+// Frequency is the FREQ part of a recurrence rule
+type Frequency string
+
+const (
+	// Daily repeats every INTERVAL days
+	Daily Frequency = "DAILY"
+	// Weekly repeats every INTERVAL weeks
+	Weekly Frequency = "WEEKLY"
+	// Monthly repeats every INTERVAL months
+	Monthly Frequency = "MONTHLY"
+	// Yearly repeats every INTERVAL years
+	Yearly Frequency = "YEARLY"
+)
+
+// maxEmptyPeriods stops expansion of rules that can never produce another date,
+// such as the 31st of every 12th month starting in February
+const maxEmptyPeriods = 1000
+
+const (
+	untilDateTimeLayout = "20060102T150405Z"
+	untilDateLayout     = "20060102"
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is a parsed recurrence rule supporting FREQ, INTERVAL, BYDAY, UNTIL and COUNT
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	// Until is the inclusive end of the recurrence; zero means unbounded
+	Until time.Time
+	// UntilIsDate records that UNTIL was a DATE value, covering that whole day
+	UntilIsDate bool
+	// Count is the total number of occurrences; zero means unbounded
+	Count int
+}
+
+// Parse parses an RRULE value such as "FREQ=WEEKLY;BYDAY=TU;COUNT=15".
+// A leading "RRULE:" property name is accepted and ignored.
+func Parse(value string) (Rule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "RRULE:")
+	if value == "" {
+		return Rule{}, fmt.Errorf("%w: rule is empty", ErrInvalidRule)
+	}
+
+	rule := Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			freq := Frequency(strings.ToUpper(val))
+			switch freq {
+			case Daily, Weekly, Monthly, Yearly:
+				rule.Freq = freq
+			default:
+				return Rule{}, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRule)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return Rule{}, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRule)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, isDate, err := parseUntil(val)
+			if err != nil {
+				return Rule{}, err
+			}
+			rule.Until = until
+			rule.UntilIsDate = isDate
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, ok := weekdayCodes[strings.ToUpper(code)]
+				if !ok {
+					return Rule{}, fmt.Errorf("%w: unsupported BYDAY value %q", ErrInvalidRule, code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST":
+			if strings.ToUpper(val) != "MO" {
+				return Rule{}, fmt.Errorf("%w: only WKST=MO is supported", ErrInvalidRule)
+			}
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, name)
+		}
+	}
+
+	if rule.Freq == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRule)
+	}
+
+	return rule, nil
+}
+
+// IsBounded reports whether the rule ends through COUNT or UNTIL
+func (r Rule) IsBounded() bool {
+	return r.Count > 0 || !r.Until.IsZero()
+}
+
+// String formats the rule as an RRULE value
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			codes[i] = weekdayCode(day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.UntilIsDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(untilDateLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilDateTimeLayout))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// Expand returns the occurrence start times of the rule beginning at dtstart,
+// in chronological order and in dtstart's location. Times matching an entry in
+// exdates are left out. DTSTART always counts as the first occurrence, as RFC
+// 5545 requires. Expansion fails with ErrTooManyOccurrences once more than max
+// occurrences would be produced.
+func (r Rule) Expand(dtstart time.Time, exdates []time.Time, max int) ([]time.Time, error) {
+	until := r.untilIn(dtstart.Location())
+
+	var occurrences []time.Time
+	generated := 0
+	emit := func(t time.Time) (bool, error) {
+		if !until.IsZero() && t.After(until) {
+			return false, nil
+		}
+		generated++
+		if !isExcluded(t, exdates) {
+			if len(occurrences) == max {
+				return false, fmt.Errorf("%w: limit is %d", ErrTooManyOccurrences, max)
+			}
+			occurrences = append(occurrences, t)
+		}
+		return r.Count == 0 || generated < r.Count, nil
+	}
+
+	more, err := emit(dtstart)
+	if err != nil || !more {
+		return occurrences, err
+	}
+
+	emptyPeriods := 0
+	for period := 0; ; period++ {
+		candidates := r.periodCandidates(dtstart, period)
+		produced := false
+		for _, candidate := range candidates {
+			if !candidate.After(dtstart) {
+				continue
+			}
+			produced = true
+			more, err := emit(candidate)
+			if err != nil || !more {
+				return occurrences, err
+			}
+		}
+
+		if produced {
+			emptyPeriods = 0
+		} else if emptyPeriods++; emptyPeriods > maxEmptyPeriods {
+			return occurrences, nil
+		}
+	}
+}
+
+// periodCandidates returns the sorted candidate dates of the n-th period of the rule
+func (r Rule) periodCandidates(dtstart time.Time, n int) []time.Time {
+	step := n * r.Interval
+	loc := dtstart.Location()
+	hour, minute, sec := dtstart.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, sec, dtstart.Nanosecond(), loc)
+	}
+
+	switch r.Freq {
+	case Daily:
+		day := at(dtstart.Year(), dtstart.Month(), dtstart.Day()+step)
+		if len(r.ByDay) > 0 && !r.hasDay(day.Weekday()) {
+			return nil
+		}
+		return []time.Time{day}
+
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(dtstart.Year(), dtstart.Month(), dtstart.Day()+7*step)}
+		}
+		// Weeks start on Monday (WKST=MO)
+		monday := dtstart.Day() - (int(dtstart.Weekday())+6)%7 + 7*step
+		days := make([]time.Time, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			days = append(days, at(dtstart.Year(), dtstart.Month(), monday+(int(weekday)+6)%7))
+		}
+		sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+		return days
+
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+		if len(r.ByDay) == 0 {
+			// Months without the start day are skipped, as RFC 5545 requires
+			if dtstart.Day() > daysIn(first) {
+				return nil
+			}
+			return []time.Time{at(first.Year(), first.Month(), dtstart.Day())}
+		}
+		return r.matchingDays(first.Year(), first.Month(), daysIn(first), at)
+
+	case Yearly:
+		year := dtstart.Year() + step
+		if len(r.ByDay) == 0 {
+			day := at(year, dtstart.Month(), dtstart.Day())
+			if day.Month() != dtstart.Month() {
+				// 29 February outside leap years
+				return nil
+			}
+			return []time.Time{day}
+		}
+		length := time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC).
+			Sub(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC))
+		return r.matchingDays(year, time.January, int(length.Hours()/24), at)
+	}
+
+	return nil
+}
+
+// matchingDays returns every day in a span starting at the first of month whose weekday is in BYDAY
+func (r Rule) matchingDays(year int, month time.Month, span int, at func(int, time.Month, int) time.Time) []time.Time {
+	var days []time.Time
+	for day := 1; day <= span; day++ {
+		candidate := at(year, month, day)
+		if r.hasDay(candidate.Weekday()) {
+			days = append(days, candidate)
+		}
+	}
+	return days
+}
+
+// hasDay checks if the weekday is listed in BYDAY
+func (r Rule) hasDay(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// untilIn returns the UNTIL bound, treating a DATE value as the end of that day in loc
+func (r Rule) untilIn(loc *time.Location) time.Time {
+	if r.Until.IsZero() || !r.UntilIsDate {
+		return r.Until
+	}
+	return time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day(), 23, 59, 59, 0, loc)
+}
+
+// parseUntil parses an UNTIL value in either DATE or UTC DATE-TIME form
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(untilDateTimeLayout, value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(untilDateLayout, value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%w: UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ", ErrInvalidRule)
+}
+
+// isExcluded checks if t matches one of the exception dates
+func isExcluded(t time.Time, exdates []time.Time) bool {
+	for _, exdate := range exdates {
+		if t.Equal(exdate) {
+			return true
+		}
+	}
+	return false
+}
+
+// daysIn returns the number of days in the month of t
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekdayCode returns the two-letter RFC 5545 code for a weekday
+func weekdayCode(day time.Weekday) string {
+	for code, weekday := range weekdayCodes {
+		if weekday == day {
+			return code
+		}
+	}
+	return ""
+}
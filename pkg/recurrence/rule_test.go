@@ -0,0 +1,127 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Full rule round-trips", func(t *testing.T) {
+		rule, err := Parse("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;UNTIL=20270101T000000Z")
+		require.NoError(t, err)
+
+		assert.Equal(t, Weekly, rule.Freq)
+		assert.Equal(t, 2, rule.Interval)
+		assert.Equal(t, []time.Weekday{time.Tuesday, time.Thursday}, rule.ByDay)
+		assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), rule.Until)
+		assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;UNTIL=20270101T000000Z", rule.String())
+	})
+
+	invalid := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=DAILY;COUNT=3;UNTIL=20270101",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	}
+	for _, value := range invalid {
+		t.Run("Rejects "+value, func(t *testing.T) {
+			_, err := Parse(value)
+			assert.ErrorIs(t, err, ErrInvalidRule)
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	date := func(y int, m time.Month, d, h int) time.Time {
+		return time.Date(y, m, d, h, 0, 0, 0, newYork)
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		exdates []time.Time
+		want    []time.Time
+	}{
+		{
+			name:    "Weekly by count",
+			rule:    "FREQ=WEEKLY;BYDAY=TU;COUNT=3",
+			dtstart: date(2026, 9, 1, 14),
+			want:    []time.Time{date(2026, 9, 1, 14), date(2026, 9, 8, 14), date(2026, 9, 15, 14)},
+		},
+		{
+			name:    "Weekly keeps wall clock across DST change",
+			rule:    "FREQ=WEEKLY;COUNT=2",
+			dtstart: date(2026, 10, 27, 14),
+			want:    []time.Time{date(2026, 10, 27, 14), date(2026, 11, 3, 14)},
+		},
+		{
+			name:    "Several week days with interval",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20260930T235959Z",
+			dtstart: date(2026, 9, 2, 9),
+			want:    []time.Time{date(2026, 9, 2, 9), date(2026, 9, 14, 9), date(2026, 9, 16, 9), date(2026, 9, 28, 9), date(2026, 9, 30, 9)},
+		},
+		{
+			name:    "Daily with date UNTIL and EXDATE",
+			rule:    "FREQ=DAILY;UNTIL=20260904",
+			dtstart: date(2026, 9, 1, 8),
+			exdates: []time.Time{date(2026, 9, 3, 8)},
+			want:    []time.Time{date(2026, 9, 1, 8), date(2026, 9, 2, 8), date(2026, 9, 4, 8)},
+		},
+		{
+			name:    "EXDATE does not extend COUNT",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: date(2026, 9, 1, 8),
+			exdates: []time.Time{date(2026, 9, 2, 8)},
+			want:    []time.Time{date(2026, 9, 1, 8), date(2026, 9, 3, 8)},
+		},
+		{
+			name:    "Monthly skips short months",
+			rule:    "FREQ=MONTHLY;COUNT=3",
+			dtstart: date(2027, 1, 31, 10),
+			want:    []time.Time{date(2027, 1, 31, 10), date(2027, 3, 31, 10), date(2027, 5, 31, 10)},
+		},
+		{
+			name:    "Monthly by day",
+			rule:    "FREQ=MONTHLY;BYDAY=FR;COUNT=4",
+			dtstart: date(2026, 10, 23, 10),
+			want:    []time.Time{date(2026, 10, 23, 10), date(2026, 10, 30, 10), date(2026, 11, 6, 10), date(2026, 11, 13, 10)},
+		},
+		{
+			name:    "Yearly skips 29 February",
+			rule:    "FREQ=YEARLY;COUNT=2",
+			dtstart: date(2028, 2, 29, 10),
+			want:    []time.Time{date(2028, 2, 29, 10), date(2032, 2, 29, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			require.NoError(t, err)
+
+			got, err := rule.Expand(tt.dtstart, tt.exdates, 100)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Unbounded rule hits the limit", func(t *testing.T) {
+		rule, err := Parse("FREQ=DAILY")
+		require.NoError(t, err)
+
+		_, err = rule.Expand(date(2026, 9, 1, 8), nil, 10)
+		assert.ErrorIs(t, err, ErrTooManyOccurrences)
+	})
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+)
+
+// APIKeysService provides methods for API key operations
+type APIKeysService struct {
+	client *Client
+}
+
+// APIKeys returns the API keys service
+func (c *Client) APIKeys() *APIKeysService {
+	return &APIKeysService{client: c}
+}
+
+// List retrieves every API key
+func (s *APIKeysService) List() ([]byte, error) {
+	resp, err := s.client.doRequest("GET", "/api/v1/api-keys", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Get retrieves a specific API key by ID
+func (s *APIKeysService) Get(id uint) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/api-keys/%d", id)
+	resp, err := s.client.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Create generates a new API key
+func (s *APIKeysService) Create(req interface{}) ([]byte, error) {
+	resp, err := s.client.doRequest("POST", "/api/v1/api-keys", req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Update replaces the name, permissions, buildings and expiry of an API key
+func (s *APIKeysService) Update(id uint, req interface{}) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/api-keys/%d", id)
+	resp, err := s.client.doRequest("PUT", endpoint, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Delete revokes an API key by ID
+func (s *APIKeysService) Delete(id uint) error {
+	endpoint := fmt.Sprintf("/api/v1/api-keys/%d", id)
+	resp, err := s.client.doRequest("DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.handleRawResponse(resp)
+	return err
+}
@@ -0,0 +1,21 @@
+package client
+
+// AuthService provides methods for token operations
+type AuthService struct {
+	client *Client
+}
+
+// Auth returns the auth service
+func (c *Client) Auth() *AuthService {
+	return &AuthService{client: c}
+}
+
+// DevToken asks the local issuer of a development server for a token
+func (s *AuthService) DevToken(req interface{}) ([]byte, error) {
+	resp, err := s.client.doRequest("POST", "/api/v1/auth/token", req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
@@ -83,3 +83,25 @@ func (s *BuildingsService) Delete(id uint) error {
 	_, err = s.client.handleRawResponse(resp)
 	return err
 }
+
+// Classes retrieves the classes of a building
+func (s *BuildingsService) Classes(id uint) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/buildings/%d/classes", id)
+	resp, err := s.client.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Resources retrieves the resources kept in a building
+func (s *BuildingsService) Resources(id uint) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/buildings/%d/resources", id)
+	resp, err := s.client.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
@@ -66,3 +66,14 @@ func (s *ClassesService) Delete(id uint) error {
 	_, err = s.client.handleRawResponse(resp)
 	return err
 }
+
+// Resources retrieves the resources kept in a class
+func (s *ClassesService) Resources(id uint) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/classes/%d/resources", id)
+	resp, err := s.client.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
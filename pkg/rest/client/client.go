@@ -11,14 +11,29 @@ import (
 
 // Client represents the SARC API client
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	token       string
+	tokenSource TokenSource
+	apiKey      string
+	httpClient  *http.Client
+}
+
+// TokenSource supplies the bearer tokens of a signed in user, such as the
+// tokens cached by sarc login
+type TokenSource interface {
+	// Token returns the access token, renewing it first when it expired
+	Token() (string, error)
+	// Refresh renews the access token after the API refused it
+	Refresh() (string, error)
 }
 
 // Config holds the client configuration
 type Config struct {
-	BaseURL string
-	Timeout time.Duration
+	BaseURL     string
+	Token       string      // bearer token sent with every request, if any
+	TokenSource TokenSource // used without Token; refused requests are retried once with a renewed token
+	APIKey      string      // API key sent in the X-API-Key header instead, if any
+	Timeout     time.Duration
 }
 
 // NewClient creates a new SARC API client
@@ -28,7 +43,10 @@ func NewClient(config Config) *Client {
 	}
 
 	return &Client{
-		baseURL: config.BaseURL,
+		baseURL:     config.BaseURL,
+		token:       config.Token,
+		tokenSource: config.TokenSource,
+		apiKey:      config.APIKey,
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
 		},
@@ -46,14 +64,56 @@ func (c *Client) doRequest(method, endpoint string, body interface{}) (*http.Res
 		reqBody = bytes.NewBuffer(jsonData)
 	}
 
+	return c.doRawRequest(method, endpoint, "application/json", reqBody)
+}
+
+// doRawRequest performs an HTTP request with a body that is already encoded
+func (c *Client) doRawRequest(method, endpoint, contentType string, body io.Reader) (*http.Response, error) {
+	if c.token != "" || c.tokenSource == nil {
+		return c.send(method, endpoint, contentType, body, c.token)
+	}
+
+	// The body is sent again if the token is refused
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	token, err := c.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	resp, err := c.send(method, endpoint, contentType, bytesReader(payload), token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	if token, err = c.tokenSource.Refresh(); err != nil {
+		return nil, fmt.Errorf("failed to refresh access token: %w", err)
+	}
+	return c.send(method, endpoint, contentType, bytesReader(payload), token)
+}
+
+// send performs an HTTP request with the bearer token, if any
+func (c *Client) send(method, endpoint, contentType string, body io.Reader, token string) (*http.Response, error) {
 	url := c.baseURL + endpoint
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -63,6 +123,19 @@ func (c *Client) doRequest(method, endpoint string, body interface{}) (*http.Res
 	return resp, nil
 }
 
+// bytesReader returns a reader of the payload, or nil without one
+func bytesReader(payload []byte) io.Reader {
+	if payload == nil {
+		return nil
+	}
+	return bytes.NewReader(payload)
+}
+
+// BaseURL returns the URL of the API
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
 // handleRawResponse processes the HTTP response and returns raw JSON data
 func (c *Client) handleRawResponse(resp *http.Response) ([]byte, error) {
 	defer resp.Body.Close()
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingTokens renews the token on every refresh
+type rotatingTokens struct {
+	current   string
+	next      []string
+	refreshes int
+}
+
+func (s *rotatingTokens) Token() (string, error) {
+	return s.current, nil
+}
+
+func (s *rotatingTokens) Refresh() (string, error) {
+	s.refreshes++
+	if len(s.next) == 0 {
+		return "", errors.New("not signed in")
+	}
+	s.current, s.next = s.next[0], s.next[1:]
+	return s.current, nil
+}
+
+func TestTokenSourceIsRetriedOnceAfterRefresh(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	tokens := &rotatingTokens{current: "stale", next: []string{"fresh"}}
+	client := NewClient(Config{BaseURL: server.URL, TokenSource: tokens})
+	data, err := client.Reservations().Create(map[string]string{"purpose": "Exam"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1}`, string(data))
+	assert.Equal(t, 1, tokens.refreshes)
+	assert.Equal(t, []string{`{"purpose":"Exam"}`, `{"purpose":"Exam"}`}, bodies, "the body is sent again")
+
+	tokens = &rotatingTokens{current: "stale", next: []string{"also-stale", "fresh"}}
+	client = NewClient(Config{BaseURL: server.URL, TokenSource: tokens})
+	_, err = client.Reservations().Create(map[string]string{"purpose": "Exam"})
+	assert.ErrorContains(t, err, "API error (401)")
+	assert.Equal(t, 1, tokens.refreshes, "refused requests are retried only once")
+
+	tokens = &rotatingTokens{current: "stale"}
+	client = NewClient(Config{BaseURL: server.URL, TokenSource: tokens})
+	_, err = client.Reservations().Create(map[string]string{"purpose": "Exam"})
+	assert.ErrorContains(t, err, "failed to refresh access token")
+
+	tokens = &rotatingTokens{current: "stale", next: []string{"fresh"}}
+	client = NewClient(Config{BaseURL: server.URL, Token: "fresh", TokenSource: tokens})
+	_, err = client.Reservations().Create(map[string]string{"purpose": "Exam"})
+	require.NoError(t, err)
+	assert.Zero(t, tokens.refreshes, "a static token takes precedence")
+}
@@ -1,6 +1,10 @@
 package client
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"net/url"
+)
 
 // LessonsService provides methods for lesson operations
 type LessonsService struct {
@@ -66,3 +70,26 @@ func (s *LessonsService) Delete(id uint) error {
 	_, err = s.client.handleRawResponse(resp)
 	return err
 }
+
+// Import creates or updates lessons from iCalendar data.
+// With dryRun the server only reports what would change.
+func (s *LessonsService) Import(calendar io.Reader, dryRun bool, timeZone string) ([]byte, error) {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dryRun", "true")
+	}
+	if timeZone != "" {
+		query.Set("timeZone", timeZone)
+	}
+
+	endpoint := "/api/v1/lessons/import"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	resp, err := s.client.doRawRequest("POST", endpoint, "text/calendar", calendar)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
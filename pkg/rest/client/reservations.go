@@ -1,6 +1,10 @@
 package client
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
 
 // ReservationsService provides methods for reservation operations
 type ReservationsService struct {
@@ -23,6 +27,23 @@ func (s *ReservationsService) List(page, pageSize int) ([]byte, error) {
 	return s.client.handleRawResponse(resp)
 }
 
+// ListFiltered retrieves reservations matching the given query filters with pagination
+func (s *ReservationsService) ListFiltered(page, pageSize int, filters url.Values) ([]byte, error) {
+	query := url.Values{}
+	for key, values := range filters {
+		query[key] = values
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+
+	resp, err := s.client.doRequest("GET", "/api/v1/reservations?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
 // Get retrieves a specific reservation by ID
 func (s *ReservationsService) Get(id uint) ([]byte, error) {
 	endpoint := fmt.Sprintf("/api/v1/reservations/%d", id)
@@ -1,6 +1,10 @@
 package client
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
 
 // ResourcesService provides methods for resource operations
 type ResourcesService struct {
@@ -23,6 +27,23 @@ func (s *ResourcesService) List(page, pageSize int) ([]byte, error) {
 	return s.client.handleRawResponse(resp)
 }
 
+// ListFiltered retrieves resources matching the given query filters with pagination
+func (s *ResourcesService) ListFiltered(page, pageSize int, filters url.Values) ([]byte, error) {
+	query := url.Values{}
+	for key, values := range filters {
+		query[key] = values
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+
+	resp, err := s.client.doRequest("GET", "/api/v1/resources?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
 // Get retrieves a specific resource by ID
 func (s *ResourcesService) Get(id uint) ([]byte, error) {
 	endpoint := fmt.Sprintf("/api/v1/resources/%d", id)
@@ -34,6 +55,29 @@ func (s *ResourcesService) Get(id uint) ([]byte, error) {
 	return s.client.handleRawResponse(resp)
 }
 
+// FreeSlots retrieves the free slots of a resource. The params hold the from,
+// to, duration and optional limit of the search.
+func (s *ResourcesService) FreeSlots(id uint, params url.Values) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/resources/%d/free-slots?%s", id, params.Encode())
+	resp, err := s.client.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// FindFreeSlots retrieves the free slots across every resource matching the
+// type, buildingId and classId filters in params
+func (s *ResourcesService) FindFreeSlots(params url.Values) ([]byte, error) {
+	resp, err := s.client.doRequest("GET", "/api/v1/resources/free-slots?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
 // Create creates a new resource
 func (s *ResourcesService) Create(req interface{}) ([]byte, error) {
 	resp, err := s.client.doRequest("POST", "/api/v1/resources", req)
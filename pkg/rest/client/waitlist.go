@@ -0,0 +1,67 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// WaitlistService provides methods for waitlist operations
+type WaitlistService struct {
+	client *Client
+}
+
+// Waitlist returns the waitlist service
+func (c *Client) Waitlist() *WaitlistService {
+	return &WaitlistService{client: c}
+}
+
+// List retrieves waitlist entries matching the given query filters with pagination
+func (s *WaitlistService) List(page, pageSize int, filters url.Values) ([]byte, error) {
+	query := url.Values{}
+	for key, values := range filters {
+		query[key] = values
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+
+	resp, err := s.client.doRequest("GET", "/api/v1/waitlist?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Get retrieves a specific waitlist entry by ID
+func (s *WaitlistService) Get(id uint) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/waitlist/%d", id)
+	resp, err := s.client.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Join adds the caller to the waitlist for a taken time window
+func (s *WaitlistService) Join(req interface{}) ([]byte, error) {
+	resp, err := s.client.doRequest("POST", "/api/v1/waitlist", req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Leave cancels a waiting entry by ID
+func (s *WaitlistService) Leave(id uint) error {
+	endpoint := fmt.Sprintf("/api/v1/waitlist/%d", id)
+	resp, err := s.client.doRequest("DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.handleRawResponse(resp)
+	return err
+}
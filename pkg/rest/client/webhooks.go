@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// WebhooksService provides methods for webhook subscription operations
+type WebhooksService struct {
+	client *Client
+}
+
+// Webhooks returns the webhooks service
+func (c *Client) Webhooks() *WebhooksService {
+	return &WebhooksService{client: c}
+}
+
+// List retrieves every webhook subscription
+func (s *WebhooksService) List() ([]byte, error) {
+	resp, err := s.client.doRequest("GET", "/api/v1/webhooks", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Get retrieves a specific webhook subscription by ID
+func (s *WebhooksService) Get(id uint) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/webhooks/%d", id)
+	resp, err := s.client.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Create subscribes a URL to domain events
+func (s *WebhooksService) Create(req interface{}) ([]byte, error) {
+	resp, err := s.client.doRequest("POST", "/api/v1/webhooks", req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Update replaces an existing webhook subscription
+func (s *WebhooksService) Update(id uint, req interface{}) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/webhooks/%d", id)
+	resp, err := s.client.doRequest("PUT", endpoint, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Delete removes a webhook subscription by ID
+func (s *WebhooksService) Delete(id uint) error {
+	endpoint := fmt.Sprintf("/api/v1/webhooks/%d", id)
+	resp, err := s.client.doRequest("DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.handleRawResponse(resp)
+	return err
+}
+
+// RotateSecret generates a new signing secret for a webhook subscription
+func (s *WebhooksService) RotateSecret(id uint) ([]byte, error) {
+	endpoint := fmt.Sprintf("/api/v1/webhooks/%d/rotate-secret", id)
+	resp, err := s.client.doRequest("POST", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
+
+// Deliveries retrieves the delivery log of a webhook subscription matching the given query filters with pagination
+func (s *WebhooksService) Deliveries(id uint, page, pageSize int, filters url.Values) ([]byte, error) {
+	query := url.Values{}
+	for key, values := range filters {
+		query[key] = values
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+
+	endpoint := fmt.Sprintf("/api/v1/webhooks/%d/deliveries?%s", id, query.Encode())
+	resp, err := s.client.doRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.handleRawResponse(resp)
+}
@@ -18,9 +18,30 @@ const (
 	ContextKeyClaims = "claims"
 )
 
-// AuthMiddleware validates JWT tokens from request headers
-func AuthMiddleware(validator auth.TokenValidator) gin.HandlerFunc {
+// APIKeyHeader is the header API keys are sent in
+const APIKeyHeader = "X-API-Key"
+
+// AuthMiddleware validates JWT tokens from request headers, or API keys from
+// the X-API-Key header when keys is not nil
+func AuthMiddleware(validator auth.TokenValidator, keys auth.KeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// API keys take the place of tokens for services and devices
+		if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" && keys != nil {
+			user, err := keys.AuthenticateKey(c.Request.Context(), apiKey)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"error": "Invalid or expired API key",
+					"code":  "API_KEY_INVALID",
+				})
+				return
+			}
+
+			user.RequestID = GetRequestID(c)
+			c.Set(ContextKeyUser, user)
+			c.Next()
+			return
+		}
+
 		// Extract token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -53,15 +74,24 @@ func AuthMiddleware(validator auth.TokenValidator) gin.HandlerFunc {
 
 		// Set claims and user in context
 		c.Set(ContextKeyClaims, claims)
-		c.Set(ContextKeyUser, claims.ToUser())
+		c.Set(ContextKeyUser, requestUser(c, claims))
 
 		c.Next()
 	}
 }
 
-// OptionalAuthMiddleware validates JWT if present but doesn't require it
-func OptionalAuthMiddleware(validator auth.TokenValidator) gin.HandlerFunc {
+// OptionalAuthMiddleware validates JWT or API key if present but doesn't require it
+func OptionalAuthMiddleware(validator auth.TokenValidator, keys auth.KeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" && keys != nil {
+			if user, err := keys.AuthenticateKey(c.Request.Context(), apiKey); err == nil {
+				user.RequestID = GetRequestID(c)
+				c.Set(ContextKeyUser, user)
+			}
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.Next()
@@ -77,7 +107,7 @@ func OptionalAuthMiddleware(validator auth.TokenValidator) gin.HandlerFunc {
 		claims, err := validator.ValidateToken(c.Request.Context(), tokenString)
 		if err == nil {
 			c.Set(ContextKeyClaims, claims)
-			c.Set(ContextKeyUser, claims.ToUser())
+			c.Set(ContextKeyUser, requestUser(c, claims))
 		}
 
 		c.Next()
@@ -136,6 +166,45 @@ func RequireAllGroups(groups ...string) gin.HandlerFunc {
 	}
 }
 
+// RequirePermission middleware ensures user has been granted the permission
+func RequirePermission(permissions auth.Permissions, permission auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, exists := GetUserFromContext(c)
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "User not authenticated",
+				"code":  "USER_NOT_AUTHENTICATED",
+			})
+			return
+		}
+
+		if !permissions.Allows(user, permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":               "Insufficient permissions",
+				"code":                "INSUFFICIENT_PERMISSIONS",
+				"required_permission": permission,
+				"required_groups":     permissions.Groups(permission),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermissionIfSignedIn middleware lets anonymous requests through, for
+// routes they may call with another credential, but holds signed-in users and
+// API keys to the permission
+func RequirePermissionIfSignedIn(permissions auth.Permissions, permission auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := GetUserFromContext(c); !exists {
+			c.Next()
+			return
+		}
+		RequirePermission(permissions, permission)(c)
+	}
+}
+
 // RequireAdmin middleware ensures user has admin privileges
 func RequireAdmin() gin.HandlerFunc {
 	return RequireGroups("admin")
@@ -151,6 +220,13 @@ func RequireTeacher() gin.HandlerFunc {
 	return RequireGroups("admin", "manager", "teacher")
 }
 
+// requestUser returns the user of the claims, tagged with the ID of the request
+func requestUser(c *gin.Context, claims *auth.Claims) *auth.User {
+	user := claims.ToUser()
+	user.RequestID = GetRequestID(c)
+	return user
+}
+
 // extractBearerToken extracts the token from "Bearer <token>" format
 func extractBearerToken(authHeader string) string {
 	parts := strings.SplitN(authHeader, " ", 2)
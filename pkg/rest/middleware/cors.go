@@ -12,8 +12,8 @@ func CORS() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "HEAD"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With", HeaderRequestID, APIKeyHeader},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", HeaderRequestID},
 		AllowCredentials: false, // Set to false when using AllowOrigins: ["*"]
 		MaxAge:           12 * time.Hour,
 	})
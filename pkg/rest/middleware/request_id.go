@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderRequestID carries the ID of a request in both directions
+	HeaderRequestID = "X-Request-ID"
+
+	// ContextKeyRequestID is the key for the request ID in context
+	ContextKeyRequestID = "requestId"
+
+	// maxRequestIDLength bounds the IDs accepted from clients and proxies
+	maxRequestIDLength = 128
+)
+
+// RequestID tags every request with an ID, taken from the X-Request-ID header
+// when a client or proxy sent one and generated otherwise. The ID is echoed in
+// the response and recorded in the audit log of the changes the request makes.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+
+		c.Set(ContextKeyRequestID, id)
+		c.Header(HeaderRequestID, id)
+		c.Next()
+	}
+}
+
+// GetRequestID retrieves the ID of the request from context
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(ContextKeyRequestID)
+}
+
+// newRequestID generates a random 128-bit ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
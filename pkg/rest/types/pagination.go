@@ -30,6 +30,8 @@ type PaginationMeta struct {
 	PageSize   int `json:"pageSize"`
 	TotalItems int `json:"totalItems"`
 	TotalPages int `json:"totalPages"`
+	// NextCursor continues the listing with keyset pagination, when available
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // PaginatedResponse represents a paginated API response
@@ -87,6 +89,12 @@ func NewPaginatedResponse[T any](data []T, params PaginationParams, totalItems i
 	}
 }
 
+// WithNextCursor sets the keyset cursor of the following page
+func (r *PaginatedResponse[T]) WithNextCursor(cursor string) *PaginatedResponse[T] {
+	r.Meta.NextCursor = cursor
+	return r
+}
+
 // Offset calculates the database offset for the current page
 func (p PaginationParams) Offset() int {
 	return (p.Page - 1) * p.PageSize
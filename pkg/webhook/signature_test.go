@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, "whsec_"))
+
+	body := []byte(`{"type":"reservation.created"}`)
+	header := http.Header{}
+	SignRequest(header, secret, time.Now(), body)
+	assert.True(t, strings.HasPrefix(header.Get(SignatureHeader), "sha256="))
+	assert.NoError(t, Verify(header, secret, body, 5*time.Minute))
+
+	assert.ErrorIs(t, Verify(header, secret, []byte(`{"type":"reservation.deleted"}`), 0), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(header, "whsec_other", body, 0), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(http.Header{}, secret, body, 0), ErrMissingSignature)
+
+	old := http.Header{}
+	SignRequest(old, secret, time.Now().Add(-time.Hour), body)
+	assert.ErrorIs(t, Verify(old, secret, body, 5*time.Minute), ErrExpiredSignature)
+	assert.NoError(t, Verify(old, secret, body, 0))
+}
+
+func TestSignIsStable(t *testing.T) {
+	signedAt := time.Unix(1767225600, 0)
+	body := []byte("{}")
+	assert.Equal(t, Sign("whsec_test", signedAt, body), Sign("whsec_test", signedAt, body))
+	assert.NotEqual(t, Sign("whsec_test", signedAt, body), Sign("whsec_test", signedAt.Add(time.Second), body))
+}
@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"sarc-ng/pkg/rest/types"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -81,10 +83,14 @@ func TestBuildingsEndpoint(t *testing.T) {
 	// Should return 200 (empty list is fine for integration test)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-	// Verify it returns valid JSON
-	var buildings []interface{}
-	err = json.NewDecoder(resp.Body).Decode(&buildings)
+	// Lists are wrapped in the paginated envelope
+	var page types.PaginatedResponse[map[string]interface{}]
+	err = json.NewDecoder(resp.Body).Decode(&page)
 	require.NoError(t, err)
+	assert.NotNil(t, page.Data, "data is an array even when empty")
+	assert.Equal(t, types.DefaultPage, page.Meta.Page)
+	assert.Equal(t, types.DefaultPageSize, page.Meta.PageSize)
+	assert.Len(t, page.Data, min(page.Meta.TotalItems, page.Meta.PageSize))
 }
 
 func TestAPIEndpoints(t *testing.T) {
@@ -95,7 +101,6 @@ func TestAPIEndpoints(t *testing.T) {
 		{"Buildings", "/api/v1/buildings"},
 		{"Classes", "/api/v1/classes"},
 		{"Lessons", "/api/v1/lessons"},
-		{"Reservations", "/api/v1/reservations"},
 		{"Resources", "/api/v1/resources"},
 	}
 
@@ -105,13 +110,22 @@ func TestAPIEndpoints(t *testing.T) {
 			require.NoError(t, err)
 			defer resp.Body.Close()
 
-			// All endpoints should return 200 for GET requests
+			// The catalogue can be listed without signing in
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-			// Verify response is valid JSON
-			var result interface{}
-			err = json.NewDecoder(resp.Body).Decode(&result)
+			var page types.PaginatedResponse[map[string]interface{}]
+			err = json.NewDecoder(resp.Body).Decode(&page)
 			require.NoError(t, err)
+			assert.NotNil(t, page.Data)
+			assert.GreaterOrEqual(t, page.Meta.TotalPages, 0)
 		})
 	}
+
+	t.Run("Reservations need a signed-in user", func(t *testing.T) {
+		resp, err := http.Get(baseURL + "/api/v1/reservations")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
 }
@@ -0,0 +1,77 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	devTokenEndpoint = "/api/v1/auth/token"
+	jwksEndpoint     = "/.well-known/jwks.json"
+)
+
+// devToken asks the server, which must run the development configuration,
+// for a token of a user in the groups
+func devToken(t *testing.T, groups ...string) string {
+	body, err := json.Marshal(map[string]interface{}{"username": "integration", "groups": groups})
+	require.NoError(t, err)
+
+	resp, err := http.Post(baseURL+devTokenEndpoint, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "the server must run with jwt.dev_tokens enabled")
+
+	var token struct {
+		AccessToken string `json:"accessToken"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&token))
+	return token.AccessToken
+}
+
+// createBuilding posts a new building with the token, if any
+func createBuilding(t *testing.T, token string) int {
+	code := fmt.Sprintf("IT%d", time.Now().UnixNano())
+	body, err := json.Marshal(map[string]string{"name": "Integration " + code, "code": code})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, baseURL+buildingsEndpoint, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestJWKSEndpoint(t *testing.T) {
+	resp, err := http.Get(baseURL + jwksEndpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+	assert.NotEmpty(t, jwks.Keys)
+}
+
+func TestAuthenticatedRoutes(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, createBuilding(t, ""))
+	assert.Equal(t, http.StatusForbidden, createBuilding(t, devToken(t)))
+	assert.Equal(t, http.StatusCreated, createBuilding(t, devToken(t, "manager")))
+}
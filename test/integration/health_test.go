@@ -13,7 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestHealthEndpoint(t *testing.T) {
+func TestRouterHealthEndpoint(t *testing.T) {
 	// Set gin to test mode
 	gin.SetMode(gin.TestMode)
 
@@ -44,7 +44,7 @@ func TestHealthEndpoint(t *testing.T) {
 	resp.Body.Close()
 }
 
-func TestAPIEndpoints(t *testing.T) {
+func TestRouterAPIEndpoints(t *testing.T) {
 	// Set gin to test mode
 	gin.SetMode(gin.TestMode)
 